@@ -8,12 +8,15 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/gavv/httpexpect/v2"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,6 +40,71 @@ func TestProjectImportSignature(t *testing.T) {
 	// t.Cleanup(func() { _ = os.Remove(projectZipFilePath) })
 }
 
+// TestProjectImportSignedUploadFS runs the signed upload flow against the local file storage:
+// the zip is PUT to the URL signed by the server and the import is started by /api/import-project/complete.
+func TestProjectImportSignedUploadFS(t *testing.T) {
+	cfg := *disabledAuthConfig
+	cfg.FS.SignedUploadSecret = "secret"
+
+	// the URL is signed with the same secret and base as the endpoint registered by the server
+	signer, err := fs.NewUploadSigner("/api/import-upload", cfg.FS.SignedUploadSecret)
+	require.NoError(t, err)
+	file, err := fs.NewFileWithUploadSigner(afero.NewMemMapFs(), "https://example.com/", signer)
+	require.NoError(t, err)
+
+	e, _, _ := serverWithConfigAndFile(t, &cfg, fullSeeder, file, nil)
+
+	zipBytes, err := os.ReadFile(GenProjectZipFile(t, e))
+	require.NoError(t, err)
+
+	signed := e.POST("/api/signature-url").
+		WithHeader("X-Reearth-Debug-User", uID.String()).
+		WithMultipart().
+		WithFormField("workspace_id", wID.String()).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+	uploadURL := signed.Value("upload_url").String().Raw()
+	projectID := signed.Value("temporary_project").String().Raw()
+
+	u, err := url.Parse(uploadURL)
+	require.NoError(t, err)
+	require.Equal(t, "/api/import-upload/"+fmt.Sprintf("%s-%s-%s.zip", wID, projectID, uID), u.Path)
+
+	// too early
+	e.POST("/api/import-project/complete").
+		WithHeader("X-Reearth-Debug-User", uID.String()).
+		WithMultipart().
+		WithFormField("workspace_id", wID.String()).
+		WithFormField("temporary_project", projectID).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// tampered signature
+	e.PUT(u.Path).
+		WithQuery("expires", u.Query().Get("expires")).
+		WithQuery("signature", "00").
+		WithBytes(zipBytes).
+		Expect().
+		Status(http.StatusForbidden)
+
+	e.PUT(u.Path).
+		WithQueryString(u.RawQuery).
+		WithHeader("Content-Type", signed.Value("content_type").String().Raw()).
+		WithBytes(zipBytes).
+		Expect().
+		Status(http.StatusOK)
+
+	e.POST("/api/import-project/complete").
+		WithHeader("X-Reearth-Debug-User", uID.String()).
+		WithMultipart().
+		WithFormField("workspace_id", wID.String()).
+		WithFormField("temporary_project", projectID).
+		Expect().
+		Status(http.StatusOK).
+		JSON().IsEqual("finish")
+}
+
 func GenProjectZipFile(t *testing.T, e *httpexpect.Expect) (projectZipFilePath string) {
 
 	projectZipFilePath = filepath.Join(t.TempDir(), "project.zip")
@@ -16,6 +16,7 @@ import (
 	gqlclient "github.com/reearth/reearth-accounts/server/pkg/gqlclient"
 	accountsInfra "github.com/reearth/reearth-accounts/server/pkg/infrastructure"
 	"github.com/reearth/reearth/server/internal/app"
+	"github.com/reearth/reearth/server/internal/app/config"
 	"github.com/reearth/reearth/server/internal/app/otel"
 	"github.com/reearth/reearth/server/internal/infrastructure/fs"
 	"github.com/reearth/reearth/server/internal/infrastructure/mongo"
@@ -94,6 +95,11 @@ func newFakeAccountsClient() *gqlclient.Client {
 
 func serverWithAccountsClient(t *testing.T, seeder Seeder, fileGw gateway.File, accountsClient *gqlclient.Client) (*httpexpect.Expect, *repo.Container, *gateway.Container) {
 	t.Helper()
+	return serverWithConfigAndFile(t, disabledAuthConfig, seeder, fileGw, accountsClient)
+}
+
+func serverWithConfigAndFile(t *testing.T, cfg *config.Config, seeder Seeder, fileGw gateway.File, accountsClient *gqlclient.Client) (*httpexpect.Expect, *repo.Container, *gateway.Container) {
+	t.Helper()
 
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -113,7 +119,7 @@ func serverWithAccountsClient(t *testing.T, seeder Seeder, fileGw gateway.File,
 	gateways := initGatewayWithFile(fileGw)
 	accountGateway := initAccountGateway(ctx)
 	srv := app.NewServer(ctx, &app.ServerConfig{
-		Config:            disabledAuthConfig,
+		Config:            cfg,
 		Repos:             repos,
 		AccountRepos:      repos.AccountRepos(),
 		Gateways:          gateways,
@@ -6,6 +6,7 @@ import (
 	accountsUser "github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/apitoken"
 	"github.com/reearth/reearthx/appx"
 	"golang.org/x/text/language"
 )
@@ -23,6 +24,7 @@ const (
 	contextInternal    ContextKey = "Internal"
 	contextUserID      ContextKey = "reearth_user"
 	contextJwtToken    ContextKey = "jwtToken"
+	contextAPIToken    ContextKey = "apiToken"
 )
 
 var defaultLang = language.English
@@ -69,6 +71,18 @@ func AttachJwtToken(ctx context.Context, token string) context.Context {
 	return context.WithValue(ctx, contextJwtToken, token)
 }
 
+// AttachAPIToken attaches the API token that authenticated the request in place of a user.
+func AttachAPIToken(ctx context.Context, t *apitoken.APIToken) context.Context {
+	return context.WithValue(ctx, contextAPIToken, t)
+}
+
+func APIToken(ctx context.Context) *apitoken.APIToken {
+	if t, ok := ctx.Value(contextAPIToken).(*apitoken.APIToken); ok {
+		return t
+	}
+	return nil
+}
+
 func JwtToken(ctx context.Context) string {
 	if token, ok := ctx.Value(contextJwtToken).(string); ok {
 		return token
@@ -0,0 +1,45 @@
+package gqlmodel
+
+import (
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/samber/lo"
+)
+
+func ToAPIToken(t *apitoken.APIToken) *APIToken {
+	if t == nil {
+		return nil
+	}
+	return &APIToken{
+		ID:          IDFrom(t.ID()),
+		WorkspaceID: IDFrom(t.Workspace()),
+		Name:        t.Name(),
+		Scopes:      lo.Map(t.Scopes(), func(s apitoken.Scope, _ int) APITokenScope { return ToAPITokenScope(s) }),
+		ExpiresAt:   t.ExpiresAt(),
+		RevokedAt:   t.RevokedAt(),
+		LastUsedAt:  t.LastUsedAt(),
+		CreatedByID: IDFromRef(t.CreatedBy()),
+		CreatedAt:   t.CreatedAt(),
+		IsActive:    t.Validate(time.Now()) == nil,
+	}
+}
+
+func ToAPITokens(tokens []*apitoken.APIToken) []*APIToken {
+	res := make([]*APIToken, 0, len(tokens))
+	for _, t := range tokens {
+		if t := ToAPIToken(t); t != nil {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+func ToAPITokenScope(s apitoken.Scope) APITokenScope {
+	return APITokenScope(strings.ToUpper(string(s)))
+}
+
+func FromAPITokenScopes(scopes []APITokenScope) (apitoken.Scopes, error) {
+	return apitoken.ScopesFrom(lo.Map(scopes, func(s APITokenScope, _ int) string { return strings.ToLower(string(s)) }))
+}
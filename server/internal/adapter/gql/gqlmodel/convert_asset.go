@@ -1,7 +1,10 @@
 package gqlmodel
 
 import (
+	"strings"
+
 	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/samber/lo"
 )
 
 func ToAsset(a *asset.Asset) *Asset {
@@ -16,16 +19,37 @@ func ToAsset(a *asset.Asset) *Asset {
 	}
 
 	return &Asset{
-		ID:          IDFrom(a.ID()),
-		CreatedAt:   a.CreatedAt(),
-		WorkspaceID: IDFrom(a.Workspace()),
-		ProjectID:   pid,
-		Name:        a.Name(),
-		Size:        a.Size(),
-		URL:         a.URL(),
-		ContentType: a.ContentType(),
-		CoreSupport: a.CoreSupport(),
+		ID:           IDFrom(a.ID()),
+		CreatedAt:    a.CreatedAt(),
+		WorkspaceID:  IDFrom(a.Workspace()),
+		ProjectID:    pid,
+		Name:         a.Name(),
+		Size:         a.Size(),
+		URL:          a.URL(),
+		ContentType:  a.ContentType(),
+		CoreSupport:  a.CoreSupport(),
+		Metadata:     ToAssetMetadata(a.Metadata()),
+		ThumbnailURL: lo.EmptyableToPtr(a.ThumbnailURL()),
+	}
+}
+
+func ToAssetMetadata(m *asset.Metadata) *AssetMetadata {
+	if m == nil {
+		return nil
+	}
+	res := &AssetMetadata{
+		Width:        m.Width,
+		Height:       m.Height,
+		FeatureCount: m.FeatureCount,
+		Attributes:   m.Attributes,
 	}
+	if m.Location != nil {
+		res.Location = &AssetLocation{Lat: m.Location.Lat, Lng: m.Location.Lng}
+	}
+	if m.Bounds != nil {
+		res.Bounds = &AssetBounds{Min: m.Bounds.Min, Max: m.Bounds.Max}
+	}
+	return res
 }
 
 func ToAssets(assets []*asset.Asset) []*Asset {
@@ -38,6 +62,19 @@ func ToAssets(assets []*asset.Asset) []*Asset {
 	return result
 }
 
+func ToAssetUsages(usages []asset.Usage) []*AssetUsage {
+	result := make([]*AssetUsage, 0, len(usages))
+	for _, u := range usages {
+		result = append(result, &AssetUsage{
+			Type:      AssetUsageType(strings.ToUpper(string(u.Type))),
+			ProjectID: IDFrom(u.Project),
+			TargetID:  u.Target,
+			Field:     lo.EmptyableToPtr(u.Field),
+		})
+	}
+	return result
+}
+
 func AssetSortTypeFrom(ast *AssetSort) *asset.SortType {
 	if ast == nil {
 		return nil
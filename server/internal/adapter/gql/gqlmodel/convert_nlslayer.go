@@ -1,8 +1,15 @@
 package gqlmodel
 
 import (
+	"encoding/json"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/czml"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/nlslayer/decoding"
+	"github.com/reearth/reearthx/usecasex"
 	"github.com/reearth/reearthx/util"
 	"github.com/samber/lo"
 )
@@ -28,6 +35,30 @@ func ToNLSLayerSimple(l *nlslayer.NLSLayerSimple) *NLSLayerSimple {
 	}
 }
 
+func ToImportNLSLayerPayload(r *decoding.Result) *ImportNLSLayerPayload {
+	if r == nil {
+		return nil
+	}
+
+	return &ImportNLSLayerPayload{
+		Layer:  ToNLSLayer(r.Root, nil),
+		Layers: ToNLSLayers(r.Layers, nil),
+		Styles: ToStyles(r.Styles),
+	}
+}
+
+func ToCZML(packets []czml.Feature) (Array, error) {
+	b, err := json.Marshal(packets)
+	if err != nil {
+		return nil, err
+	}
+	var res Array
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func ToNLSConfig(p JSON) *nlslayer.Config {
 	if p == nil {
 		return nil
@@ -154,25 +185,34 @@ func ToNLSLayerSketchInfo(si *nlslayer.SketchInfo) *SketchInfo {
 		}
 	}
 
-	var features []*Feature
-	for _, f := range si.FeatureCollection().Features() {
-		feature := &Feature{
-			ID:         IDFrom(f.ID()),
-			Type:       f.FeatureType(),
-			Geometry:   convertGeometry(f.Geometry()),
-			Properties: *ToGoJsonRef(*f.Properties()),
-		}
-		features = append(features, feature)
+	return &SketchInfo{
+		CustomPropertySchema: customPropertySchema,
+		FeatureCollection:    ToFeatureCollection(si.FeatureCollection()),
+	}
+}
+
+func ToFeatureCollection(fc *nlslayer.FeatureCollection) *FeatureCollection {
+	if fc == nil {
+		return nil
+	}
+
+	features := make([]*Feature, 0, len(fc.Features()))
+	for _, f := range fc.Features() {
+		features = append(features, ToFeature(f))
 	}
 
-	featureCollection := &FeatureCollection{
-		Type:     si.FeatureCollection().FeatureCollectionType(),
+	return &FeatureCollection{
+		Type:     fc.FeatureCollectionType(),
 		Features: features,
 	}
+}
 
-	return &SketchInfo{
-		CustomPropertySchema: customPropertySchema,
-		FeatureCollection:    featureCollection,
+func ToFeature(f nlslayer.Feature) *Feature {
+	return &Feature{
+		ID:         IDFrom(f.ID()),
+		Type:       f.FeatureType(),
+		Geometry:   convertGeometry(f.Geometry()),
+		Properties: *ToGoJsonRef(*f.Properties()),
 	}
 }
 
@@ -197,11 +237,21 @@ func convertGeometry(g nlslayer.Geometry) Geometry {
 			Type:             g.PointType(),
 			PointCoordinates: g.Coordinates(),
 		}
+	case *nlslayer.MultiPoint:
+		return MultiPoint{
+			Type:                  g.MultiPointType(),
+			MultiPointCoordinates: g.Coordinates(),
+		}
 	case *nlslayer.LineString:
 		return LineString{
 			Type:                  g.LineStringType(),
 			LineStringCoordinates: g.Coordinates(),
 		}
+	case *nlslayer.MultiLineString:
+		return MultiLineString{
+			Type:                       g.MultiLineStringType(),
+			MultiLineStringCoordinates: g.Coordinates(),
+		}
 	case *nlslayer.Polygon:
 		return Polygon{
 			Type:               g.PolygonType(),
@@ -224,3 +274,138 @@ func convertGeometry(g nlslayer.Geometry) Geometry {
 	}
 	return nil
 }
+
+func FromFeatureOperationType(t FeatureOperationType) interfaces.FeatureOperationType {
+	switch t {
+	case FeatureOperationTypeAdd:
+		return interfaces.FeatureOperationAdd
+	case FeatureOperationTypeUpdate:
+		return interfaces.FeatureOperationUpdate
+	case FeatureOperationTypeDelete:
+		return interfaces.FeatureOperationDelete
+	}
+	return interfaces.FeatureOperationType("")
+}
+
+func FromFeatureQuery(q *FeatureQueryInput) (nlslayer.FeatureQuery, error) {
+	var res nlslayer.FeatureQuery
+	if q == nil {
+		return res, nil
+	}
+	if q.Bbox != nil {
+		res.BBox = &nlslayer.BBox{
+			West:  q.Bbox.West,
+			South: q.Bbox.South,
+			East:  q.Bbox.East,
+			North: q.Bbox.North,
+		}
+	}
+	if q.Intersects != nil {
+		g, err := nlslayer.NewGeometryFromMap(q.Intersects)
+		if err != nil {
+			return res, err
+		}
+		res.Intersects = g
+	}
+	if q.Near != nil {
+		res.Near = &nlslayer.Near{
+			Center:   q.Near.Center,
+			Distance: q.Near.Distance,
+		}
+	}
+	for _, p := range q.Properties {
+		if p == nil {
+			continue
+		}
+		res.Properties = append(res.Properties, nlslayer.PropertyPredicate{
+			Key:      p.Key,
+			Operator: nlslayer.PropertyOperator(strings.ToLower(string(p.Operator))),
+			Value:    p.Value,
+		})
+	}
+	return res, nil
+}
+
+func ToFeatureConnection(features []nlslayer.Feature, pi *usecasex.PageInfo) *FeatureConnection {
+	nodes := make([]*Feature, 0, len(features))
+	edges := make([]*FeatureEdge, 0, len(features))
+	for _, f := range features {
+		node := ToFeature(f)
+		nodes = append(nodes, node)
+		edges = append(edges, &FeatureEdge{
+			Cursor: usecasex.Cursor(f.ID().String()),
+			Node:   node,
+		})
+	}
+
+	totalCount := 0
+	if pi != nil {
+		totalCount = int(pi.TotalCount)
+	}
+	return &FeatureConnection{
+		Edges:      edges,
+		Nodes:      nodes,
+		PageInfo:   ToPageInfo(pi),
+		TotalCount: totalCount,
+	}
+}
+
+func ToFeatureRevisionAction(a nlslayer.FeatureRevisionAction) FeatureRevisionAction {
+	switch a {
+	case nlslayer.FeatureRevisionActionAdd:
+		return FeatureRevisionActionAdd
+	case nlslayer.FeatureRevisionActionUpdate:
+		return FeatureRevisionActionUpdate
+	case nlslayer.FeatureRevisionActionDelete:
+		return FeatureRevisionActionDelete
+	}
+	return FeatureRevisionAction("")
+}
+
+func ToFeatureRevision(r *nlslayer.FeatureRevision) *FeatureRevision {
+	if r == nil {
+		return nil
+	}
+	res := &FeatureRevision{
+		ID:         IDFrom(r.ID()),
+		LayerID:    IDFrom(r.Layer()),
+		FeatureID:  IDFrom(r.Feature()),
+		Action:     ToFeatureRevisionAction(r.Action()),
+		OperatorID: IDFromRef(r.Operator()),
+		CreatedAt:  r.CreatedAt(),
+	}
+	if r.Before() != nil {
+		res.Before = ToFeature(*r.Before())
+	}
+	if r.After() != nil {
+		res.After = ToFeature(*r.After())
+	}
+	return res
+}
+
+func ToFeatureRevisionConnection(revisions []*nlslayer.FeatureRevision, pi *usecasex.PageInfo) *FeatureRevisionConnection {
+	nodes := make([]*FeatureRevision, 0, len(revisions))
+	edges := make([]*FeatureRevisionEdge, 0, len(revisions))
+	for _, r := range revisions {
+		node := ToFeatureRevision(r)
+		if node == nil {
+			continue
+		}
+		nodes = append(nodes, node)
+		edges = append(edges, &FeatureRevisionEdge{
+			Cursor: usecasex.Cursor(node.ID),
+			Node:   node,
+		})
+	}
+
+	totalCount := 0
+	if pi != nil {
+		totalCount = int(pi.TotalCount)
+	}
+	return &FeatureRevisionConnection{
+		Edges:      edges,
+		Nodes:      nodes,
+		PageInfo:   ToPageInfo(pi),
+		TotalCount: totalCount,
+	}
+}
@@ -194,3 +194,30 @@ func TestToNLSLayerSketchInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestFromFeatureQuery(t *testing.T) {
+	q, err := FromFeatureQuery(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, nlslayer.FeatureQuery{}, q)
+
+	q, err = FromFeatureQuery(&FeatureQueryInput{
+		Bbox:       &BBoxInput{West: 1, South: 2, East: 3, North: 4},
+		Intersects: JSON{"type": "Point", "coordinates": []any{1.0, 2.0}},
+		Near:       &NearInput{Center: []float64{1, 2}, Distance: 100},
+		Properties: []*FeaturePropertyPredicateInput{
+			{Key: "height", Operator: FeaturePropertyOperatorGte, Value: 10},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, nlslayer.FeatureQuery{
+		BBox:       &nlslayer.BBox{West: 1, South: 2, East: 3, North: 4},
+		Intersects: nlslayer.NewPoint("Point", []float64{1, 2}),
+		Near:       &nlslayer.Near{Center: []float64{1, 2}, Distance: 100},
+		Properties: []nlslayer.PropertyPredicate{
+			{Key: "height", Operator: nlslayer.PropertyOperatorGte, Value: 10},
+		},
+	}, q)
+
+	_, err = FromFeatureQuery(&FeatureQueryInput{Intersects: JSON{"type": "Circle"}})
+	assert.Error(t, err)
+}
@@ -5,6 +5,9 @@ import (
 	"strings"
 
 	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene/sceneops"
+	"github.com/reearth/reearth/server/pkg/value"
 	"github.com/reearth/reearthx/util"
 	"github.com/samber/lo"
 )
@@ -176,3 +179,120 @@ func ToPluginWidgetAreaType(t plugin.WidgetAreaType) WidgetAreaType {
 	}
 	return ""
 }
+
+func ToPluginUpgradeReport(r *sceneops.PluginUpgradeReport) *PluginUpgradeReport {
+	if r == nil {
+		return nil
+	}
+
+	extensions := []*PluginUpgradeExtensionDiff{}
+	if r.Diff.PropertySchemaDeleted || !r.Diff.PropertySchemaDiff.IsEmpty() {
+		extensions = append(extensions, &PluginUpgradeExtensionDiff{
+			Removed: r.Diff.PropertySchemaDeleted,
+			Fields:  toPluginUpgradeFieldChanges(r.Diff.PropertySchemaDiff),
+		})
+	}
+	for _, e := range r.Diff.DeletedExtensions {
+		extensions = append(extensions, &PluginUpgradeExtensionDiff{
+			ExtensionID: IDFromStringRef(e.ExtensionID.Ref()),
+			Removed:     true,
+			Fields:      []*PluginUpgradeFieldChange{},
+		})
+	}
+	for _, e := range r.Diff.UpdatedExtensions {
+		extensions = append(extensions, &PluginUpgradeExtensionDiff{
+			ExtensionID: IDFromStringRef(e.ExtensionID.Ref()),
+			OldType:     lo.ToPtr(ToPluginExtensionType(e.OldType)),
+			NewType:     lo.ToPtr(ToPluginExtensionType(e.NewType)),
+			Fields:      toPluginUpgradeFieldChanges(e.PropertySchemaDiff),
+		})
+	}
+
+	return &PluginUpgradeReport{
+		PluginID:   IDFromPluginID(r.Diff.From),
+		ToPluginID: IDFromPluginID(r.Diff.To),
+		Extensions: extensions,
+		Targets:    lo.Map(r.Targets, toPluginUpgradeTarget),
+	}
+}
+
+func toPluginUpgradeFieldChanges(d property.SchemaDiff) []*PluginUpgradeFieldChange {
+	res := []*PluginUpgradeFieldChange{}
+	for _, m := range d.Moved {
+		res = append(res, &PluginUpgradeFieldChange{
+			Type:            PluginUpgradeFieldChangeTypeMoved,
+			SchemaGroupID:   IDFromString(m.From.SchemaGroup),
+			FieldID:         IDFromString(m.From.Field),
+			ToSchemaGroupID: IDFromStringRef(m.To.SchemaGroup.Ref()),
+		})
+	}
+	for _, t := range d.TypeChanged {
+		res = append(res, &PluginUpgradeFieldChange{
+			Type:          PluginUpgradeFieldChangeTypeCast,
+			SchemaGroupID: IDFromString(t.SchemaGroup),
+			FieldID:       IDFromString(t.Field),
+			NewType:       lo.ToPtr(ToValueType(value.Type(t.NewType))),
+		})
+	}
+	for _, f := range d.Deleted {
+		res = append(res, &PluginUpgradeFieldChange{
+			Type:          PluginUpgradeFieldChangeTypeDeleted,
+			SchemaGroupID: IDFromString(f.SchemaGroup),
+			FieldID:       IDFromString(f.Field),
+		})
+	}
+	return res
+}
+
+func toPluginUpgradeTarget(t sceneops.PluginUpgradeTarget, _ int) *PluginUpgradeTarget {
+	var typ PluginUpgradeTargetType
+	switch t.Type {
+	case sceneops.PluginUpgradeTargetScenePlugin:
+		typ = PluginUpgradeTargetTypeScenePlugin
+	case sceneops.PluginUpgradeTargetWidget:
+		typ = PluginUpgradeTargetTypeWidget
+	case sceneops.PluginUpgradeTargetInfoboxBlock:
+		typ = PluginUpgradeTargetTypeInfoboxBlock
+	case sceneops.PluginUpgradeTargetStoryBlock:
+		typ = PluginUpgradeTargetTypeStoryBlock
+	}
+
+	return &PluginUpgradeTarget{
+		Type:          typ,
+		ID:            ID(t.ID),
+		ExtensionID:   IDFromStringRef(t.Extension),
+		PropertyID:    IDFromRef(t.Property),
+		LayerID:       IDFromRef(t.Layer),
+		StoryID:       IDFromRef(t.Story),
+		PageID:        IDFromRef(t.Page),
+		Removed:       t.Removed,
+		DroppedFields: lo.Map(t.Dropped, toPluginUpgradeDroppedField),
+	}
+}
+
+func toPluginUpgradeDroppedField(f property.DroppedField, _ int) *PluginUpgradeDroppedField {
+	var reason PluginUpgradeDropReason
+	switch f.Reason {
+	case property.DropReasonDeleted:
+		reason = PluginUpgradeDropReasonDeleted
+	case property.DropReasonCastFailed:
+		reason = PluginUpgradeDropReasonCastFailed
+	case property.DropReasonConflict:
+		reason = PluginUpgradeDropReasonConflict
+	}
+
+	sg, item, field := f.Pointer.GetAll()
+	res := &PluginUpgradeDroppedField{
+		SchemaGroupID: IDFromStringRef(sg),
+		ItemID:        IDFromRef(item),
+		FieldID:       IDFromString(lo.FromPtr(field)),
+		Reason:        reason,
+	}
+	if f.Value != nil {
+		res.Type = lo.ToPtr(ToValueType(value.Type(f.Value.Type())))
+		if v := ToPropertyValue(f.Value.Value()); v != nil {
+			res.Value = *v
+		}
+	}
+	return res
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/samber/lo"
 )
 
 func FromPublishmentStatus(v PublishmentStatus) project.PublishmentStatus {
@@ -117,24 +118,26 @@ func ToProject(p *project.Project) *Project {
 		IsArchived:   p.IsArchived(),
 		CoreSupport:  p.CoreSupport(),
 		Starred:      p.Starred(),
+		IsTemplate:   p.IsTemplate(),
 		IsDeleted:    p.IsDeleted(),
 		Visibility:   p.Visibility(),
 		Metadata:     ToProjectMetadata(p.Metadata()),
 		ProjectAlias: p.ProjectAlias(),
 		// publishment
-		Alias:             p.Alias(),
-		PublishmentStatus: ToPublishmentStatus(p.PublishmentStatus()),
-		PublishedAt:       publishedAtRes,
-		PublicTitle:       p.PublicTitle(),
-		PublicDescription: p.PublicDescription(),
-		PublicImage:       p.PublicImage(),
-		PublicIconImage:   p.PublicIconImage(),
-		PublicNoIndex:     p.PublicNoIndex(),
-		IsBasicAuthActive: p.IsBasicAuthActive(),
-		BasicAuthUsername: p.BasicAuthUsername(),
-		BasicAuthPassword: p.BasicAuthPassword(),
-		EnableGa:          p.EnableGA(),
-		TrackingID:        p.TrackingID(),
+		Alias:                p.Alias(),
+		PublishmentStatus:    ToPublishmentStatus(p.PublishmentStatus()),
+		PublishedAt:          publishedAtRes,
+		PublishedVersion:     lo.EmptyableToPtr(p.PublishedVersion()),
+		PublicTitle:          p.PublicTitle(),
+		PublicDescription:    p.PublicDescription(),
+		PublicImage:          p.PublicImage(),
+		PublicIconImage:      p.PublicIconImage(),
+		PublicNoIndex:        p.PublicNoIndex(),
+		IsBasicAuthActive:    p.IsBasicAuthActive(),
+		BasicAuthUsername:    p.BasicAuthUsername(),
+		HasBasicAuthPassword: p.BasicAuthPassword() != "",
+		EnableGa:             p.EnableGA(),
+		TrackingID:           p.TrackingID(),
 	}
 }
 
@@ -0,0 +1,33 @@
+package gqlmodel
+
+import (
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/samber/lo"
+)
+
+func ToPublicationVersion(v *publication.Version) *PublicationVersion {
+	if v == nil {
+		return nil
+	}
+	return &PublicationVersion{
+		ID:            IDFrom(v.ID()),
+		SceneID:       IDFrom(v.Scene()),
+		ProjectID:     IDFromRef(v.Project()),
+		StoryID:       IDFromRef(v.Story()),
+		Version:       v.Version(),
+		Alias:         v.Alias(),
+		PublishedAt:   v.PublishedAt(),
+		PublishedByID: IDFromRef(v.PublishedBy()),
+		Message:       lo.EmptyableToPtr(v.Message()),
+	}
+}
+
+func ToPublicationVersions(versions []*publication.Version) []*PublicationVersion {
+	res := make([]*PublicationVersion, 0, len(versions))
+	for _, v := range versions {
+		if v := ToPublicationVersion(v); v != nil {
+			res = append(res, v)
+		}
+	}
+	return res
+}
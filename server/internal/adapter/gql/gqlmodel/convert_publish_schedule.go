@@ -0,0 +1,50 @@
+package gqlmodel
+
+import (
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/samber/lo"
+)
+
+func ToPublishSchedule(s *publication.Schedule) *PublishSchedule {
+	if s == nil {
+		return nil
+	}
+	var status *PublishmentStatus
+	if s.Status() != "" {
+		status = lo.ToPtr(PublishmentStatus(strings.ToUpper(string(s.Status()))))
+	}
+	return &PublishSchedule{
+		ID:          IDFrom(s.ID()),
+		SceneID:     IDFrom(s.Scene()),
+		ProjectID:   IDFromRef(s.Project()),
+		StoryID:     IDFromRef(s.Story()),
+		Alias:       lo.EmptyableToPtr(s.Alias()),
+		Status:      status,
+		PublishAt:   s.PublishAt(),
+		UnpublishAt: s.UnpublishAt(),
+		State:       PublishScheduleState(strings.ToUpper(string(s.State()))),
+		NextRunAt:   s.NextRunAt(),
+		LastError:   lo.EmptyableToPtr(s.LastError()),
+		CreatedByID: IDFromRef(s.CreatedBy()),
+		CreatedAt:   s.CreatedAt(),
+	}
+}
+
+func ToPublishSchedules(schedules []*publication.Schedule) []*PublishSchedule {
+	res := make([]*PublishSchedule, 0, len(schedules))
+	for _, s := range schedules {
+		if s := ToPublishSchedule(s); s != nil {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func FromPublishScheduleStatus(v *PublishmentStatus) publication.ScheduleStatus {
+	if v == nil {
+		return ""
+	}
+	return publication.ScheduleStatus(strings.ToLower(string(*v)))
+}
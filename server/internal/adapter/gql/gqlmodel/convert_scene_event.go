@@ -0,0 +1,29 @@
+package gqlmodel
+
+import (
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+func ToSceneEvent(e scene.Event) *SceneEvent {
+	return &SceneEvent{
+		Type:       ToSceneEventType(e.Type),
+		SceneID:    IDFrom(e.Scene),
+		ActorID:    IDFromRef(e.Actor),
+		LayerID:    IDFromRef(e.Layer),
+		FeatureID:  IDFromRef(e.Feature),
+		PropertyID: IDFromRef(e.Property),
+		StyleID:    IDFromRef(e.Style),
+		WidgetID:   IDFromRef(e.Widget),
+		StoryID:    IDFromRef(e.Story),
+		PageID:     IDFromRef(e.Page),
+		BlockID:    IDFromRef(e.Block),
+		OccurredAt: e.OccurredAt,
+	}
+}
+
+// ToSceneEventType converts an event type such as "layer.added" to LAYER_ADDED.
+func ToSceneEventType(t scene.EventType) SceneEventType {
+	return SceneEventType(strings.ToUpper(strings.ReplaceAll(string(t), ".", "_")))
+}
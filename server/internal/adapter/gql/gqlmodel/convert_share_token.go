@@ -0,0 +1,35 @@
+package gqlmodel
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/publication"
+)
+
+func ToShareToken(t *publication.ShareToken) *ShareToken {
+	if t == nil {
+		return nil
+	}
+	return &ShareToken{
+		ID:          IDFrom(t.ID()),
+		SceneID:     IDFrom(t.Scene()),
+		ProjectID:   IDFromRef(t.Project()),
+		StoryID:     IDFromRef(t.Story()),
+		Name:        t.Name(),
+		ExpiresAt:   t.ExpiresAt(),
+		RevokedAt:   t.RevokedAt(),
+		CreatedByID: IDFromRef(t.CreatedBy()),
+		CreatedAt:   t.CreatedAt(),
+		IsActive:    t.Validate(time.Now()) == nil,
+	}
+}
+
+func ToShareTokens(tokens []*publication.ShareToken) []*ShareToken {
+	res := make([]*ShareToken, 0, len(tokens))
+	for _, t := range tokens {
+		if t := ToShareToken(t); t != nil {
+			res = append(res, t)
+		}
+	}
+	return res
+}
@@ -15,6 +15,7 @@ func ToStory(s *storytelling.Story) *Story {
 		ProjectID:     IDFrom(s.Project()),
 		SceneID:       IDFrom(s.Scene()),
 		Title:         s.Title(),
+		Index:         s.Index(),
 		BgColor:       ToStoryBgColor(s.BgColor()),
 		PanelPosition: ToStoryPosition(s.PanelPosition()),
 		CreatedAt:     s.Id().Timestamp(),
@@ -25,19 +26,20 @@ func ToStory(s *storytelling.Story) *Story {
 		Pages:      ToPages(s.Pages()),
 
 		// publishment
-		Alias:             s.Alias(),
-		PublishmentStatus: ToStoryPublishmentStatus(s.PublishmentStatus()),
-		PublishedAt:       s.PublishedAt(),
-		PublicTitle:       s.PublicTitle(),
-		PublicDescription: s.PublicDescription(),
-		PublicImage:       s.PublicImage(),
-		PublicIconImage:   s.PublicIconImage(),
-		PublicNoIndex:     s.PublicNoIndex(),
-		IsBasicAuthActive: s.IsBasicAuthActive(),
-		BasicAuthUsername: s.BasicAuthUsername(),
-		BasicAuthPassword: s.BasicAuthPassword(),
-		EnableGa:          s.EnableGa(),
-		TrackingID:        s.TrackingID(),
+		Alias:                s.Alias(),
+		PublishmentStatus:    ToStoryPublishmentStatus(s.PublishmentStatus()),
+		PublishedAt:          s.PublishedAt(),
+		PublishedVersion:     lo.EmptyableToPtr(s.PublishedVersion()),
+		PublicTitle:          s.PublicTitle(),
+		PublicDescription:    s.PublicDescription(),
+		PublicImage:          s.PublicImage(),
+		PublicIconImage:      s.PublicIconImage(),
+		PublicNoIndex:        s.PublicNoIndex(),
+		IsBasicAuthActive:    s.IsBasicAuthActive(),
+		BasicAuthUsername:    s.BasicAuthUsername(),
+		HasBasicAuthPassword: s.BasicAuthPassword() != "",
+		EnableGa:             s.EnableGa(),
+		TrackingID:           s.TrackingID(),
 	}
 }
 
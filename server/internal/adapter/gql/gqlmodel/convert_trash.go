@@ -0,0 +1,56 @@
+package gqlmodel
+
+import (
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/trash"
+)
+
+const trashRetentionDay = 24 * time.Hour
+
+func ToTrashPolicy(p *trash.Policy) *TrashPolicy {
+	if p == nil {
+		return nil
+	}
+	var updatedAt *time.Time
+	if !p.UpdatedAt().IsZero() {
+		t := p.UpdatedAt()
+		updatedAt = &t
+	}
+	return &TrashPolicy{
+		WorkspaceID:   IDFrom(p.Workspace()),
+		RetentionDays: int(p.Retention() / trashRetentionDay),
+		UpdatedAt:     updatedAt,
+	}
+}
+
+func FromTrashRetentionDays(days int) time.Duration {
+	return time.Duration(days) * trashRetentionDay
+}
+
+func ToTrashPurge(p *trash.Purge) *TrashPurge {
+	if p == nil {
+		return nil
+	}
+	return &TrashPurge{
+		ID:          IDFrom(p.ID()),
+		WorkspaceID: IDFrom(p.Workspace()),
+		ProjectID:   IDFrom(p.Project()),
+		ProjectName: p.ProjectName(),
+		Reason:      TrashPurgeReason(strings.ToUpper(string(p.Reason()))),
+		DeletedAt:   p.DeletedAt(),
+		PurgedByID:  IDFromRef(p.PurgedBy()),
+		PurgedAt:    p.PurgedAt(),
+	}
+}
+
+func ToTrashPurges(purges []*trash.Purge) []*TrashPurge {
+	res := make([]*TrashPurge, 0, len(purges))
+	for _, p := range purges {
+		if p := ToTrashPurge(p); p != nil {
+			res = append(res, p)
+		}
+	}
+	return res
+}
@@ -0,0 +1,115 @@
+package gqlmodel
+
+import (
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+)
+
+func ToWebhook(w *webhook.Webhook) *Webhook {
+	if w == nil {
+		return nil
+	}
+	return &Webhook{
+		ID:          IDFrom(w.ID()),
+		WorkspaceID: IDFrom(w.Workspace()),
+		Name:        w.Name(),
+		URL:         lo.FromPtr(w.URL()),
+		Events:      lo.Map(w.Events(), func(e webhook.EventType, _ int) WebhookEvent { return ToWebhookEvent(e) }),
+		Enabled:     w.Enabled(),
+		CreatedByID: IDFromRef(w.CreatedBy()),
+		CreatedAt:   w.CreatedAt(),
+		UpdatedAt:   w.UpdatedAt(),
+	}
+}
+
+func ToWebhooks(webhooks []*webhook.Webhook) []*Webhook {
+	res := make([]*Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		if w := ToWebhook(w); w != nil {
+			res = append(res, w)
+		}
+	}
+	return res
+}
+
+// ToWebhookEvent converts an event type such as "project.publish" to PROJECT_PUBLISH.
+func ToWebhookEvent(e webhook.EventType) WebhookEvent {
+	return WebhookEvent(strings.ToUpper(strings.ReplaceAll(string(e), ".", "_")))
+}
+
+func FromWebhookEvents(events []WebhookEvent) ([]webhook.EventType, error) {
+	return webhook.EventTypesFrom(lo.Map(events, func(e WebhookEvent, _ int) string {
+		return strings.ToLower(strings.Replace(string(e), "_", ".", 1))
+	}))
+}
+
+func ToWebhookDeliveryStatus(s webhook.DeliveryStatus) WebhookDeliveryStatus {
+	return WebhookDeliveryStatus(strings.ToUpper(string(s)))
+}
+
+func FromWebhookDeliveryStatus(s *WebhookDeliveryStatus) *webhook.DeliveryStatus {
+	if s == nil {
+		return nil
+	}
+	return lo.ToPtr(webhook.DeliveryStatus(strings.ToLower(string(*s))))
+}
+
+func ToWebhookDelivery(d *webhook.Delivery) *WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+	return &WebhookDelivery{
+		ID:            IDFrom(d.ID()),
+		WebhookID:     IDFrom(d.Webhook()),
+		Event:         ToWebhookEvent(d.Event()),
+		Payload:       string(d.Payload()),
+		Status:        ToWebhookDeliveryStatus(d.Status()),
+		Attempts:      lo.Map(d.Attempts(), func(a webhook.Attempt, _ int) *WebhookDeliveryAttempt { return ToWebhookDeliveryAttempt(a) }),
+		NextAttemptAt: d.NextAttemptAt(),
+		CreatedAt:     d.CreatedAt(),
+	}
+}
+
+func ToWebhookDeliveryAttempt(a webhook.Attempt) *WebhookDeliveryAttempt {
+	res := &WebhookDeliveryAttempt{
+		At:       a.At,
+		Duration: int(a.Duration.Milliseconds()),
+	}
+	if a.StatusCode != 0 {
+		res.StatusCode = lo.ToPtr(a.StatusCode)
+	}
+	if a.Error != "" {
+		res.Error = lo.ToPtr(a.Error)
+	}
+	return res
+}
+
+func ToWebhookDeliveryConnection(deliveries []*webhook.Delivery, pi *usecasex.PageInfo) *WebhookDeliveryConnection {
+	nodes := make([]*WebhookDelivery, 0, len(deliveries))
+	edges := make([]*WebhookDeliveryEdge, 0, len(deliveries))
+	for _, d := range deliveries {
+		node := ToWebhookDelivery(d)
+		if node == nil {
+			continue
+		}
+		nodes = append(nodes, node)
+		edges = append(edges, &WebhookDeliveryEdge{
+			Cursor: usecasex.Cursor(node.ID),
+			Node:   node,
+		})
+	}
+
+	totalCount := 0
+	if pi != nil {
+		totalCount = int(pi.TotalCount)
+	}
+	return &WebhookDeliveryConnection{
+		Edges:      edges,
+		Nodes:      nodes,
+		PageInfo:   ToPageInfo(pi),
+		TotalCount: totalCount,
+	}
+}
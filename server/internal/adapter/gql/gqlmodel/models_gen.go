@@ -44,11 +44,25 @@ type PropertyItem interface {
 	IsPropertyItem()
 }
 
+type APIToken struct {
+	ID          ID              `json:"id"`
+	WorkspaceID ID              `json:"workspaceId"`
+	Name        string          `json:"name"`
+	Scopes      []APITokenScope `json:"scopes"`
+	ExpiresAt   *time.Time      `json:"expiresAt,omitempty"`
+	RevokedAt   *time.Time      `json:"revokedAt,omitempty"`
+	LastUsedAt  *time.Time      `json:"lastUsedAt,omitempty"`
+	CreatedByID *ID             `json:"createdById,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	IsActive    bool            `json:"isActive"`
+}
+
 type AddGeoJSONFeatureInput struct {
-	Type       string `json:"type"`
-	Geometry   JSON   `json:"geometry"`
-	Properties JSON   `json:"properties,omitempty"`
-	LayerID    ID     `json:"layerId"`
+	Type           string `json:"type"`
+	Geometry       JSON   `json:"geometry"`
+	Properties     JSON   `json:"properties,omitempty"`
+	LayerID        ID     `json:"layerId"`
+	RepairGeometry *bool  `json:"repairGeometry,omitempty"`
 }
 
 type AddMemberToWorkspaceInput struct {
@@ -120,21 +134,29 @@ type AddWidgetPayload struct {
 }
 
 type Asset struct {
-	ID          ID         `json:"id"`
-	WorkspaceID ID         `json:"workspaceId"`
-	Workspace   *Workspace `json:"workspace,omitempty"`
-	ProjectID   *ID        `json:"projectId,omitempty"`
-	Name        string     `json:"name"`
-	Size        int64      `json:"size"`
-	URL         string     `json:"url"`
-	ContentType string     `json:"contentType"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	CoreSupport bool       `json:"coreSupport"`
+	ID           ID             `json:"id"`
+	WorkspaceID  ID             `json:"workspaceId"`
+	Workspace    *Workspace     `json:"workspace,omitempty"`
+	ProjectID    *ID            `json:"projectId,omitempty"`
+	Name         string         `json:"name"`
+	Size         int64          `json:"size"`
+	URL          string         `json:"url"`
+	ContentType  string         `json:"contentType"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	CoreSupport  bool           `json:"coreSupport"`
+	Metadata     *AssetMetadata `json:"metadata,omitempty"`
+	ThumbnailURL *string        `json:"thumbnailUrl,omitempty"`
+	Usages       []*AssetUsage  `json:"usages"`
 }
 
 func (Asset) IsNode()        {}
 func (this Asset) GetID() ID { return this.ID }
 
+type AssetBounds struct {
+	Min []float64 `json:"min"`
+	Max []float64 `json:"max"`
+}
+
 type AssetConnection struct {
 	Edges      []*AssetEdge `json:"edges"`
 	Nodes      []*Asset     `json:"nodes"`
@@ -147,11 +169,52 @@ type AssetEdge struct {
 	Node   *Asset          `json:"node,omitempty"`
 }
 
+type AssetLocation struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type AssetMetadata struct {
+	Width        *int           `json:"width,omitempty"`
+	Height       *int           `json:"height,omitempty"`
+	Location     *AssetLocation `json:"location,omitempty"`
+	Bounds       *AssetBounds   `json:"bounds,omitempty"`
+	FeatureCount *int           `json:"featureCount,omitempty"`
+	Attributes   []string       `json:"attributes,omitempty"`
+}
+
 type AssetSort struct {
 	Field     AssetSortField `json:"field"`
 	Direction SortDirection  `json:"direction"`
 }
 
+type AssetUsage struct {
+	Type      AssetUsageType `json:"type"`
+	ProjectID ID             `json:"projectId"`
+	TargetID  string         `json:"targetId"`
+	Field     *string        `json:"field,omitempty"`
+}
+
+type BBoxInput struct {
+	West  float64 `json:"west"`
+	South float64 `json:"south"`
+	East  float64 `json:"east"`
+	North float64 `json:"north"`
+}
+
+type BatchGeoJSONFeaturesInput struct {
+	LayerID        ID                       `json:"layerId"`
+	Operations     []*FeatureOperationInput `json:"operations"`
+	RepairGeometry *bool                    `json:"repairGeometry,omitempty"`
+}
+
+type BatchGeoJSONFeaturesPayload struct {
+	LayerID           ID         `json:"layerId"`
+	Added             []*Feature `json:"added"`
+	Updated           []*Feature `json:"updated"`
+	DeletedFeatureIds []ID       `json:"deletedFeatureIds"`
+}
+
 type Camera struct {
 	Lat      float64 `json:"lat"`
 	Lng      float64 `json:"lng"`
@@ -162,6 +225,14 @@ type Camera struct {
 	Fov      float64 `json:"fov"`
 }
 
+type CancelPublishScheduleInput struct {
+	PublishScheduleID ID `json:"publishScheduleId"`
+}
+
+type CancelPublishSchedulePayload struct {
+	PublishSchedule *PublishSchedule `json:"publishSchedule"`
+}
+
 type ChangeCustomPropertyTitleInput struct {
 	LayerID  ID     `json:"layerId"`
 	Schema   JSON   `json:"schema,omitempty"`
@@ -169,6 +240,18 @@ type ChangeCustomPropertyTitleInput struct {
 	NewTitle string `json:"newTitle"`
 }
 
+type CreateAPITokenInput struct {
+	WorkspaceID ID              `json:"workspaceId"`
+	Name        *string         `json:"name,omitempty"`
+	Scopes      []APITokenScope `json:"scopes"`
+	ExpiresAt   *time.Time      `json:"expiresAt,omitempty"`
+}
+
+type CreateAPITokenPayload struct {
+	APIToken *APIToken `json:"apiToken"`
+	Token    string    `json:"token"`
+}
+
 type CreateAssetInput struct {
 	WorkspaceID ID             `json:"workspaceId"`
 	ProjectID   *ID            `json:"projectId,omitempty"`
@@ -206,6 +289,12 @@ type CreateNLSPhotoOverlayPayload struct {
 	Layer NLSLayer `json:"layer"`
 }
 
+type CreateProjectFromTemplateInput struct {
+	TemplateID  ID      `json:"templateId"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
 type CreateProjectInput struct {
 	WorkspaceID  ID         `json:"workspaceId"`
 	Visualizer   Visualizer `json:"visualizer"`
@@ -219,6 +308,19 @@ type CreateProjectInput struct {
 	Topics       []string   `json:"topics,omitempty"`
 }
 
+type CreatePublishScheduleInput struct {
+	ProjectID   *ID                `json:"projectId,omitempty"`
+	StoryID     *ID                `json:"storyId,omitempty"`
+	Alias       *string            `json:"alias,omitempty"`
+	Status      *PublishmentStatus `json:"status,omitempty"`
+	PublishAt   *time.Time         `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time         `json:"unpublishAt,omitempty"`
+}
+
+type CreatePublishSchedulePayload struct {
+	PublishSchedule *PublishSchedule `json:"publishSchedule"`
+}
+
 type CreateSceneInput struct {
 	ProjectID ID `json:"projectId"`
 }
@@ -227,6 +329,18 @@ type CreateScenePayload struct {
 	Scene *Scene `json:"scene"`
 }
 
+type CreateShareTokenInput struct {
+	ProjectID *ID        `json:"projectId,omitempty"`
+	StoryID   *ID        `json:"storyId,omitempty"`
+	Name      *string    `json:"name,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type CreateShareTokenPayload struct {
+	ShareToken *ShareToken `json:"shareToken"`
+	Token      string      `json:"token"`
+}
+
 type CreateStoryBlockInput struct {
 	StoryID     ID   `json:"storyId"`
 	PageID      ID   `json:"pageId"`
@@ -258,6 +372,19 @@ type CreateStoryPageInput struct {
 	Index           *int    `json:"index,omitempty"`
 }
 
+type CreateWebhookInput struct {
+	WorkspaceID ID             `json:"workspaceId"`
+	Name        *string        `json:"name,omitempty"`
+	URL         url.URL        `json:"url"`
+	Events      []WebhookEvent `json:"events,omitempty"`
+	Enabled     *bool          `json:"enabled,omitempty"`
+}
+
+type CreateWebhookPayload struct {
+	Webhook *Webhook `json:"webhook"`
+	Secret  string   `json:"secret"`
+}
+
 type CreateWorkspaceInput struct {
 	Name  string  `json:"name"`
 	Alias *string `json:"alias,omitempty"`
@@ -304,6 +431,14 @@ type DeleteStoryPayload struct {
 	StoryID ID `json:"storyId"`
 }
 
+type DeleteWebhookInput struct {
+	WebhookID ID `json:"webhookId"`
+}
+
+type DeleteWebhookPayload struct {
+	WebhookID ID `json:"webhookId"`
+}
+
 type DeleteWorkspaceInput struct {
 	WorkspaceID ID `json:"workspaceId"`
 }
@@ -320,6 +455,13 @@ type DuplicateNLSLayerPayload struct {
 	Layer NLSLayer `json:"layer"`
 }
 
+type DuplicateProjectInput struct {
+	ProjectID   ID      `json:"projectId"`
+	WorkspaceID *ID     `json:"workspaceId,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	ShareAssets *bool   `json:"shareAssets,omitempty"`
+}
+
 type DuplicateStoryPageInput struct {
 	SceneID ID `json:"sceneId"`
 	StoryID ID `json:"storyId"`
@@ -354,6 +496,62 @@ type FeatureCollection struct {
 	Features []*Feature `json:"features"`
 }
 
+type FeatureConnection struct {
+	Edges      []*FeatureEdge `json:"edges"`
+	Nodes      []*Feature     `json:"nodes"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int            `json:"totalCount"`
+}
+
+type FeatureEdge struct {
+	Cursor usecasex.Cursor `json:"cursor"`
+	Node   *Feature        `json:"node,omitempty"`
+}
+
+type FeatureOperationInput struct {
+	Type        FeatureOperationType `json:"type"`
+	FeatureID   *ID                  `json:"featureId,omitempty"`
+	FeatureType *string              `json:"featureType,omitempty"`
+	Geometry    JSON                 `json:"geometry,omitempty"`
+	Properties  JSON                 `json:"properties,omitempty"`
+}
+
+type FeaturePropertyPredicateInput struct {
+	Key      string                  `json:"key"`
+	Operator FeaturePropertyOperator `json:"operator"`
+	Value    any                     `json:"value,omitempty"`
+}
+
+type FeatureQueryInput struct {
+	Bbox       *BBoxInput                       `json:"bbox,omitempty"`
+	Intersects JSON                             `json:"intersects,omitempty"`
+	Near       *NearInput                       `json:"near,omitempty"`
+	Properties []*FeaturePropertyPredicateInput `json:"properties,omitempty"`
+}
+
+type FeatureRevision struct {
+	ID         ID                    `json:"id"`
+	LayerID    ID                    `json:"layerId"`
+	FeatureID  ID                    `json:"featureId"`
+	Action     FeatureRevisionAction `json:"action"`
+	Before     *Feature              `json:"before,omitempty"`
+	After      *Feature              `json:"after,omitempty"`
+	OperatorID *ID                   `json:"operatorId,omitempty"`
+	CreatedAt  time.Time             `json:"createdAt"`
+}
+
+type FeatureRevisionConnection struct {
+	Edges      []*FeatureRevisionEdge `json:"edges"`
+	Nodes      []*FeatureRevision     `json:"nodes"`
+	PageInfo   *PageInfo              `json:"pageInfo"`
+	TotalCount int                    `json:"totalCount"`
+}
+
+type FeatureRevisionEdge struct {
+	Cursor usecasex.Cursor  `json:"cursor"`
+	Node   *FeatureRevision `json:"node,omitempty"`
+}
+
 type GeometryCollection struct {
 	Type       string     `json:"type"`
 	Geometries []Geometry `json:"geometries"`
@@ -361,6 +559,38 @@ type GeometryCollection struct {
 
 func (GeometryCollection) IsGeometry() {}
 
+type ImportGeoJSONFeaturesInput struct {
+	LayerID        ID                        `json:"layerId"`
+	File           graphql.Upload            `json:"file"`
+	Mode           ImportGeoJSONFeaturesMode `json:"mode"`
+	RepairGeometry *bool                     `json:"repairGeometry,omitempty"`
+}
+
+type ImportGeoJSONFeaturesPayload struct {
+	LayerID           ID                 `json:"layerId"`
+	FeatureCollection *FeatureCollection `json:"featureCollection"`
+}
+
+type ImportKMLInput struct {
+	SceneID ID      `json:"sceneId"`
+	AssetID ID      `json:"assetId"`
+	Title   *string `json:"title,omitempty"`
+	Index   *int    `json:"index,omitempty"`
+}
+
+type ImportNLSLayerPayload struct {
+	Layer  NLSLayer   `json:"layer"`
+	Layers []NLSLayer `json:"layers"`
+	Styles []*Style   `json:"styles"`
+}
+
+type ImportShapefileInput struct {
+	SceneID ID      `json:"sceneId"`
+	AssetID ID      `json:"assetId"`
+	Title   *string `json:"title,omitempty"`
+	Index   *int    `json:"index,omitempty"`
+}
+
 type InfoboxBlock struct {
 	ID          ID               `json:"id"`
 	SceneID     ID               `json:"sceneId"`
@@ -509,6 +739,20 @@ type MoveStoryPayload struct {
 	Stories []*Story `json:"stories"`
 }
 
+type MultiLineString struct {
+	Type                       string        `json:"type"`
+	MultiLineStringCoordinates [][][]float64 `json:"multiLineStringCoordinates"`
+}
+
+func (MultiLineString) IsGeometry() {}
+
+type MultiPoint struct {
+	Type                  string      `json:"type"`
+	MultiPointCoordinates [][]float64 `json:"multiPointCoordinates"`
+}
+
+func (MultiPoint) IsGeometry() {}
+
 type MultiPolygon struct {
 	Type                    string          `json:"type"`
 	MultiPolygonCoordinates [][][][]float64 `json:"multiPolygonCoordinates"`
@@ -600,6 +844,11 @@ type NLSPhotoOverlay struct {
 	Scene      *Scene    `json:"scene,omitempty"`
 }
 
+type NearInput struct {
+	Center   []float64 `json:"center"`
+	Distance float64   `json:"distance"`
+}
+
 type PageInfo struct {
 	StartCursor     *usecasex.Cursor `json:"startCursor,omitempty"`
 	EndCursor       *usecasex.Cursor `json:"endCursor,omitempty"`
@@ -661,6 +910,50 @@ type PluginExtension struct {
 	TranslatedDescription    string              `json:"translatedDescription"`
 }
 
+type PluginUpgradeDroppedField struct {
+	SchemaGroupID *ID                     `json:"schemaGroupId,omitempty"`
+	ItemID        *ID                     `json:"itemId,omitempty"`
+	FieldID       ID                      `json:"fieldId"`
+	Type          *ValueType              `json:"type,omitempty"`
+	Value         any                     `json:"value,omitempty"`
+	Reason        PluginUpgradeDropReason `json:"reason"`
+}
+
+type PluginUpgradeExtensionDiff struct {
+	ExtensionID *ID                         `json:"extensionId,omitempty"`
+	Removed     bool                        `json:"removed"`
+	OldType     *PluginExtensionType        `json:"oldType,omitempty"`
+	NewType     *PluginExtensionType        `json:"newType,omitempty"`
+	Fields      []*PluginUpgradeFieldChange `json:"fields"`
+}
+
+type PluginUpgradeFieldChange struct {
+	Type            PluginUpgradeFieldChangeType `json:"type"`
+	SchemaGroupID   ID                           `json:"schemaGroupId"`
+	FieldID         ID                           `json:"fieldId"`
+	ToSchemaGroupID *ID                          `json:"toSchemaGroupId,omitempty"`
+	NewType         *ValueType                   `json:"newType,omitempty"`
+}
+
+type PluginUpgradeReport struct {
+	PluginID   ID                            `json:"pluginId"`
+	ToPluginID ID                            `json:"toPluginId"`
+	Extensions []*PluginUpgradeExtensionDiff `json:"extensions"`
+	Targets    []*PluginUpgradeTarget        `json:"targets"`
+}
+
+type PluginUpgradeTarget struct {
+	Type          PluginUpgradeTargetType      `json:"type"`
+	ID            ID                           `json:"id"`
+	ExtensionID   *ID                          `json:"extensionId,omitempty"`
+	PropertyID    *ID                          `json:"propertyId,omitempty"`
+	LayerID       *ID                          `json:"layerId,omitempty"`
+	StoryID       *ID                          `json:"storyId,omitempty"`
+	PageID        *ID                          `json:"pageId,omitempty"`
+	Removed       bool                         `json:"removed"`
+	DroppedFields []*PluginUpgradeDroppedField `json:"droppedFields"`
+}
+
 type Point struct {
 	Type             string    `json:"type"`
 	PointCoordinates []float64 `json:"pointCoordinates"`
@@ -688,36 +981,39 @@ type Polygon struct {
 func (Polygon) IsGeometry() {}
 
 type Project struct {
-	ID                ID                `json:"id"`
-	WorkspaceID       ID                `json:"workspaceId"`
-	Workspace         *Workspace        `json:"workspace,omitempty"`
-	Scene             *Scene            `json:"scene,omitempty"`
-	Name              string            `json:"name"`
-	Description       string            `json:"description"`
-	ImageURL          *url.URL          `json:"imageUrl,omitempty"`
-	CreatedAt         time.Time         `json:"createdAt"`
-	UpdatedAt         time.Time         `json:"updatedAt"`
-	Visualizer        Visualizer        `json:"visualizer"`
-	IsArchived        bool              `json:"isArchived"`
-	CoreSupport       bool              `json:"coreSupport"`
-	Starred           bool              `json:"starred"`
-	IsDeleted         bool              `json:"isDeleted"`
-	Visibility        string            `json:"visibility"`
-	Metadata          *ProjectMetadata  `json:"metadata,omitempty"`
-	ProjectAlias      string            `json:"projectAlias"`
-	Alias             string            `json:"alias"`
-	PublishmentStatus PublishmentStatus `json:"publishmentStatus"`
-	PublishedAt       *time.Time        `json:"publishedAt,omitempty"`
-	PublicTitle       string            `json:"publicTitle"`
-	PublicDescription string            `json:"publicDescription"`
-	PublicImage       string            `json:"publicImage"`
-	PublicIconImage   string            `json:"publicIconImage"`
-	PublicNoIndex     bool              `json:"publicNoIndex"`
-	IsBasicAuthActive bool              `json:"isBasicAuthActive"`
-	BasicAuthUsername string            `json:"basicAuthUsername"`
-	BasicAuthPassword string            `json:"basicAuthPassword"`
-	EnableGa          bool              `json:"enableGa"`
-	TrackingID        string            `json:"trackingId"`
+	ID                   ID                `json:"id"`
+	WorkspaceID          ID                `json:"workspaceId"`
+	Workspace            *Workspace        `json:"workspace,omitempty"`
+	Scene                *Scene            `json:"scene,omitempty"`
+	Name                 string            `json:"name"`
+	Description          string            `json:"description"`
+	ImageURL             *url.URL          `json:"imageUrl,omitempty"`
+	CreatedAt            time.Time         `json:"createdAt"`
+	UpdatedAt            time.Time         `json:"updatedAt"`
+	Visualizer           Visualizer        `json:"visualizer"`
+	IsArchived           bool              `json:"isArchived"`
+	CoreSupport          bool              `json:"coreSupport"`
+	Starred              bool              `json:"starred"`
+	IsTemplate           bool              `json:"isTemplate"`
+	IsDeleted            bool              `json:"isDeleted"`
+	Visibility           string            `json:"visibility"`
+	Metadata             *ProjectMetadata  `json:"metadata,omitempty"`
+	ProjectAlias         string            `json:"projectAlias"`
+	Alias                string            `json:"alias"`
+	PublishmentStatus    PublishmentStatus `json:"publishmentStatus"`
+	PublishedAt          *time.Time        `json:"publishedAt,omitempty"`
+	PublishedVersion     *int              `json:"publishedVersion,omitempty"`
+	PublicTitle          string            `json:"publicTitle"`
+	PublicDescription    string            `json:"publicDescription"`
+	PublicImage          string            `json:"publicImage"`
+	PublicIconImage      string            `json:"publicIconImage"`
+	PublicNoIndex        bool              `json:"publicNoIndex"`
+	IsBasicAuthActive    bool              `json:"isBasicAuthActive"`
+	BasicAuthUsername    string            `json:"basicAuthUsername"`
+	BasicAuthPassword    string            `json:"basicAuthPassword"`
+	HasBasicAuthPassword bool              `json:"hasBasicAuthPassword"`
+	EnableGa             bool              `json:"enableGa"`
+	TrackingID           string            `json:"trackingId"`
 }
 
 func (Project) IsNode()        {}
@@ -893,16 +1189,55 @@ type PropertySchemaGroup struct {
 	TranslatedTitle       string                 `json:"translatedTitle"`
 }
 
+type PublicationVersion struct {
+	ID            ID        `json:"id"`
+	SceneID       ID        `json:"sceneId"`
+	ProjectID     *ID       `json:"projectId,omitempty"`
+	StoryID       *ID       `json:"storyId,omitempty"`
+	Version       int       `json:"version"`
+	Alias         string    `json:"alias"`
+	PublishedAt   time.Time `json:"publishedAt"`
+	PublishedByID *ID       `json:"publishedById,omitempty"`
+	Message       *string   `json:"message,omitempty"`
+}
+
 type PublishProjectInput struct {
 	ProjectID ID                `json:"projectId"`
 	Alias     *string           `json:"alias,omitempty"`
 	Status    PublishmentStatus `json:"status"`
+	Message   *string           `json:"message,omitempty"`
+}
+
+type PublishSchedule struct {
+	ID          ID                   `json:"id"`
+	SceneID     ID                   `json:"sceneId"`
+	ProjectID   *ID                  `json:"projectId,omitempty"`
+	StoryID     *ID                  `json:"storyId,omitempty"`
+	Alias       *string              `json:"alias,omitempty"`
+	Status      *PublishmentStatus   `json:"status,omitempty"`
+	PublishAt   *time.Time           `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time           `json:"unpublishAt,omitempty"`
+	State       PublishScheduleState `json:"state"`
+	NextRunAt   *time.Time           `json:"nextRunAt,omitempty"`
+	LastError   *string              `json:"lastError,omitempty"`
+	CreatedByID *ID                  `json:"createdById,omitempty"`
+	CreatedAt   time.Time            `json:"createdAt"`
 }
 
 type PublishStoryInput struct {
 	StoryID ID                `json:"storyId"`
 	Alias   *string           `json:"alias,omitempty"`
 	Status  PublishmentStatus `json:"status"`
+	Message *string           `json:"message,omitempty"`
+}
+
+type PurgeProjectInput struct {
+	ProjectID ID `json:"projectId"`
+}
+
+type PurgeProjectPayload struct {
+	ProjectID  ID          `json:"projectId"`
+	TrashPurge *TrashPurge `json:"trashPurge"`
 }
 
 type Query struct {
@@ -916,7 +1251,8 @@ type Rect struct {
 }
 
 type RemoveAssetInput struct {
-	AssetID ID `json:"assetId"`
+	AssetID ID    `json:"assetId"`
+	Force   *bool `json:"force,omitempty"`
 }
 
 type RemoveAssetPayload struct {
@@ -1016,6 +1352,57 @@ type RemoveWidgetPayload struct {
 	WidgetID ID     `json:"widgetId"`
 }
 
+type RestoreProjectInput struct {
+	ProjectID ID `json:"projectId"`
+}
+
+type RevertFeatureCollectionInput struct {
+	LayerID    ID `json:"layerId"`
+	RevisionID ID `json:"revisionId"`
+}
+
+type RevertFeatureCollectionPayload struct {
+	LayerID           ID                 `json:"layerId"`
+	FeatureCollection *FeatureCollection `json:"featureCollection"`
+}
+
+type RevertGeoJSONFeatureInput struct {
+	LayerID    ID `json:"layerId"`
+	RevisionID ID `json:"revisionId"`
+}
+
+type RevertGeoJSONFeaturePayload struct {
+	LayerID   ID       `json:"layerId"`
+	FeatureID ID       `json:"featureId"`
+	Feature   *Feature `json:"feature,omitempty"`
+}
+
+type RevokeAPITokenInput struct {
+	APITokenID ID `json:"apiTokenId"`
+}
+
+type RevokeAPITokenPayload struct {
+	APIToken *APIToken `json:"apiToken"`
+}
+
+type RevokeShareTokenInput struct {
+	ShareTokenID ID `json:"shareTokenId"`
+}
+
+type RevokeShareTokenPayload struct {
+	ShareToken *ShareToken `json:"shareToken"`
+}
+
+type RollbackPublicationInput struct {
+	ProjectID *ID `json:"projectId,omitempty"`
+	StoryID   *ID `json:"storyId,omitempty"`
+	Version   int `json:"version"`
+}
+
+type RollbackPublicationPayload struct {
+	PublicationVersion *PublicationVersion `json:"publicationVersion"`
+}
+
 type Scene struct {
 	ID                ID                  `json:"id"`
 	WorkspaceID       ID                  `json:"workspaceId"`
@@ -1043,6 +1430,21 @@ type SceneAliasAvailability struct {
 	Available bool   `json:"available"`
 }
 
+type SceneEvent struct {
+	Type       SceneEventType `json:"type"`
+	SceneID    ID             `json:"sceneId"`
+	ActorID    *ID            `json:"actorId,omitempty"`
+	LayerID    *ID            `json:"layerId,omitempty"`
+	FeatureID  *ID            `json:"featureId,omitempty"`
+	PropertyID *ID            `json:"propertyId,omitempty"`
+	StyleID    *ID            `json:"styleId,omitempty"`
+	WidgetID   *ID            `json:"widgetId,omitempty"`
+	StoryID    *ID            `json:"storyId,omitempty"`
+	PageID     *ID            `json:"pageId,omitempty"`
+	BlockID    *ID            `json:"blockId,omitempty"`
+	OccurredAt time.Time      `json:"occurredAt"`
+}
+
 type ScenePlugin struct {
 	PluginID   ID        `json:"pluginId"`
 	PropertyID *ID       `json:"propertyId,omitempty"`
@@ -1062,6 +1464,19 @@ type SceneWidget struct {
 	Property    *Property        `json:"property,omitempty"`
 }
 
+type ShareToken struct {
+	ID          ID         `json:"id"`
+	SceneID     ID         `json:"sceneId"`
+	ProjectID   *ID        `json:"projectId,omitempty"`
+	StoryID     *ID        `json:"storyId,omitempty"`
+	Name        string     `json:"name"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	CreatedByID *ID        `json:"createdById,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	IsActive    bool       `json:"isActive"`
+}
+
 type SketchInfo struct {
 	CustomPropertySchema JSON               `json:"customPropertySchema,omitempty"`
 	FeatureCollection    *FeatureCollection `json:"featureCollection,omitempty"`
@@ -1075,31 +1490,34 @@ type Spacing struct {
 }
 
 type Story struct {
-	ID                ID                `json:"id"`
-	ProjectID         ID                `json:"projectId"`
-	SceneID           ID                `json:"sceneId"`
-	Scene             *Scene            `json:"scene,omitempty"`
-	Title             string            `json:"title"`
-	BgColor           *string           `json:"bgColor,omitempty"`
-	PanelPosition     Position          `json:"panelPosition"`
-	CreatedAt         time.Time         `json:"createdAt"`
-	UpdatedAt         time.Time         `json:"updatedAt"`
-	PropertyID        ID                `json:"propertyId"`
-	Property          *Property         `json:"property,omitempty"`
-	Pages             []*StoryPage      `json:"pages"`
-	Alias             string            `json:"alias"`
-	PublishmentStatus PublishmentStatus `json:"publishmentStatus"`
-	PublishedAt       *time.Time        `json:"publishedAt,omitempty"`
-	PublicTitle       string            `json:"publicTitle"`
-	PublicDescription string            `json:"publicDescription"`
-	PublicImage       string            `json:"publicImage"`
-	PublicIconImage   string            `json:"publicIconImage"`
-	PublicNoIndex     bool              `json:"publicNoIndex"`
-	IsBasicAuthActive bool              `json:"isBasicAuthActive"`
-	BasicAuthUsername string            `json:"basicAuthUsername"`
-	BasicAuthPassword string            `json:"basicAuthPassword"`
-	EnableGa          bool              `json:"enableGa"`
-	TrackingID        string            `json:"trackingId"`
+	ID                   ID                `json:"id"`
+	ProjectID            ID                `json:"projectId"`
+	SceneID              ID                `json:"sceneId"`
+	Scene                *Scene            `json:"scene,omitempty"`
+	Title                string            `json:"title"`
+	Index                int               `json:"index"`
+	BgColor              *string           `json:"bgColor,omitempty"`
+	PanelPosition        Position          `json:"panelPosition"`
+	CreatedAt            time.Time         `json:"createdAt"`
+	UpdatedAt            time.Time         `json:"updatedAt"`
+	PropertyID           ID                `json:"propertyId"`
+	Property             *Property         `json:"property,omitempty"`
+	Pages                []*StoryPage      `json:"pages"`
+	Alias                string            `json:"alias"`
+	PublishmentStatus    PublishmentStatus `json:"publishmentStatus"`
+	PublishedAt          *time.Time        `json:"publishedAt,omitempty"`
+	PublishedVersion     *int              `json:"publishedVersion,omitempty"`
+	PublicTitle          string            `json:"publicTitle"`
+	PublicDescription    string            `json:"publicDescription"`
+	PublicImage          string            `json:"publicImage"`
+	PublicIconImage      string            `json:"publicIconImage"`
+	PublicNoIndex        bool              `json:"publicNoIndex"`
+	IsBasicAuthActive    bool              `json:"isBasicAuthActive"`
+	BasicAuthUsername    string            `json:"basicAuthUsername"`
+	BasicAuthPassword    string            `json:"basicAuthPassword"`
+	HasBasicAuthPassword bool              `json:"hasBasicAuthPassword"`
+	EnableGa             bool              `json:"enableGa"`
+	TrackingID           string            `json:"trackingId"`
 }
 
 func (Story) IsNode()        {}
@@ -1157,12 +1575,32 @@ type Style struct {
 	Scene   *Scene `json:"scene,omitempty"`
 }
 
+type Subscription struct {
+}
+
 type Timeline struct {
 	CurrentTime *string `json:"currentTime,omitempty"`
 	StartTime   *string `json:"startTime,omitempty"`
 	EndTime     *string `json:"endTime,omitempty"`
 }
 
+type TrashPolicy struct {
+	WorkspaceID   ID         `json:"workspaceId"`
+	RetentionDays int        `json:"retentionDays"`
+	UpdatedAt     *time.Time `json:"updatedAt,omitempty"`
+}
+
+type TrashPurge struct {
+	ID          ID               `json:"id"`
+	WorkspaceID ID               `json:"workspaceId"`
+	ProjectID   ID               `json:"projectId"`
+	ProjectName string           `json:"projectName"`
+	Reason      TrashPurgeReason `json:"reason"`
+	DeletedAt   *time.Time       `json:"deletedAt,omitempty"`
+	PurgedByID  *ID              `json:"purgedById,omitempty"`
+	PurgedAt    time.Time        `json:"purgedAt"`
+}
+
 type Typography struct {
 	FontFamily *string    `json:"fontFamily,omitempty"`
 	FontWeight *string    `json:"fontWeight,omitempty"`
@@ -1207,10 +1645,11 @@ type UpdateCustomPropertySchemaInput struct {
 }
 
 type UpdateGeoJSONFeatureInput struct {
-	FeatureID  ID   `json:"featureId"`
-	Geometry   JSON `json:"geometry,omitempty"`
-	Properties JSON `json:"properties,omitempty"`
-	LayerID    ID   `json:"layerId"`
+	FeatureID      ID    `json:"featureId"`
+	Geometry       JSON  `json:"geometry,omitempty"`
+	Properties     JSON  `json:"properties,omitempty"`
+	LayerID        ID    `json:"layerId"`
+	RepairGeometry *bool `json:"repairGeometry,omitempty"`
 }
 
 type UpdateMeInput struct {
@@ -1265,6 +1704,7 @@ type UpdateProjectInput struct {
 	DeleteImageURL        *bool    `json:"deleteImageUrl,omitempty"`
 	SceneID               *ID      `json:"sceneId,omitempty"`
 	Starred               *bool    `json:"starred,omitempty"`
+	IsTemplate            *bool    `json:"isTemplate,omitempty"`
 	Deleted               *bool    `json:"deleted,omitempty"`
 	Visibility            *string  `json:"visibility,omitempty"`
 	ProjectAlias          *string  `json:"projectAlias,omitempty"`
@@ -1312,6 +1752,15 @@ type UpdatePropertyValueInput struct {
 	Type          ValueType `json:"type"`
 }
 
+type UpdateShareTokenExpiryInput struct {
+	ShareTokenID ID         `json:"shareTokenId"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+}
+
+type UpdateShareTokenExpiryPayload struct {
+	ShareToken *ShareToken `json:"shareToken"`
+}
+
 type UpdateStoryInput struct {
 	SceneID               ID        `json:"sceneId"`
 	StoryID               ID        `json:"storyId"`
@@ -1354,6 +1803,29 @@ type UpdateStylePayload struct {
 	Style *Style `json:"style"`
 }
 
+type UpdateTrashPolicyInput struct {
+	WorkspaceID   ID  `json:"workspaceId"`
+	RetentionDays int `json:"retentionDays"`
+}
+
+type UpdateTrashPolicyPayload struct {
+	TrashPolicy *TrashPolicy `json:"trashPolicy"`
+}
+
+type UpdateWebhookInput struct {
+	WebhookID        ID             `json:"webhookId"`
+	Name             *string        `json:"name,omitempty"`
+	URL              *url.URL       `json:"url,omitempty"`
+	Events           []WebhookEvent `json:"events,omitempty"`
+	Enabled          *bool          `json:"enabled,omitempty"`
+	RegenerateSecret *bool          `json:"regenerateSecret,omitempty"`
+}
+
+type UpdateWebhookPayload struct {
+	Webhook *Webhook `json:"webhook"`
+	Secret  *string  `json:"secret,omitempty"`
+}
+
 type UpdateWidgetAlignSystemInput struct {
 	Type       WidgetAlignSystemType   `json:"type"`
 	SceneID    ID                      `json:"sceneId"`
@@ -1439,6 +1911,48 @@ type UserMetadata struct {
 	PhotoURL *string `json:"photoURL,omitempty"`
 }
 
+type Webhook struct {
+	ID          ID             `json:"id"`
+	WorkspaceID ID             `json:"workspaceId"`
+	Name        string         `json:"name"`
+	URL         url.URL        `json:"url"`
+	Events      []WebhookEvent `json:"events"`
+	Enabled     bool           `json:"enabled"`
+	CreatedByID *ID            `json:"createdById,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type WebhookDelivery struct {
+	ID            ID                        `json:"id"`
+	WebhookID     ID                        `json:"webhookId"`
+	Event         WebhookEvent              `json:"event"`
+	Payload       string                    `json:"payload"`
+	Status        WebhookDeliveryStatus     `json:"status"`
+	Attempts      []*WebhookDeliveryAttempt `json:"attempts"`
+	NextAttemptAt *time.Time                `json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time                 `json:"createdAt"`
+}
+
+type WebhookDeliveryAttempt struct {
+	At         time.Time `json:"at"`
+	StatusCode *int      `json:"statusCode,omitempty"`
+	Error      *string   `json:"error,omitempty"`
+	Duration   int       `json:"duration"`
+}
+
+type WebhookDeliveryConnection struct {
+	Edges      []*WebhookDeliveryEdge `json:"edges"`
+	Nodes      []*WebhookDelivery     `json:"nodes"`
+	PageInfo   *PageInfo              `json:"pageInfo"`
+	TotalCount int                    `json:"totalCount"`
+}
+
+type WebhookDeliveryEdge struct {
+	Cursor usecasex.Cursor  `json:"cursor"`
+	Node   *WebhookDelivery `json:"node,omitempty"`
+}
+
 type WidgetAlignSystem struct {
 	Inner *WidgetZone `json:"inner,omitempty"`
 	Outer *WidgetZone `json:"outer,omitempty"`
@@ -1529,50 +2043,50 @@ type WorkspaceMember struct {
 	User   *User `json:"user,omitempty"`
 }
 
-type AssetSortField string
+type APITokenScope string
 
 const (
-	AssetSortFieldDate AssetSortField = "DATE"
-	AssetSortFieldSize AssetSortField = "SIZE"
-	AssetSortFieldName AssetSortField = "NAME"
+	APITokenScopeRead    APITokenScope = "READ"
+	APITokenScopeWrite   APITokenScope = "WRITE"
+	APITokenScopePublish APITokenScope = "PUBLISH"
 )
 
-var AllAssetSortField = []AssetSortField{
-	AssetSortFieldDate,
-	AssetSortFieldSize,
-	AssetSortFieldName,
+var AllAPITokenScope = []APITokenScope{
+	APITokenScopeRead,
+	APITokenScopeWrite,
+	APITokenScopePublish,
 }
 
-func (e AssetSortField) IsValid() bool {
+func (e APITokenScope) IsValid() bool {
 	switch e {
-	case AssetSortFieldDate, AssetSortFieldSize, AssetSortFieldName:
+	case APITokenScopeRead, APITokenScopeWrite, APITokenScopePublish:
 		return true
 	}
 	return false
 }
 
-func (e AssetSortField) String() string {
+func (e APITokenScope) String() string {
 	return string(e)
 }
 
-func (e *AssetSortField) UnmarshalGQL(v any) error {
+func (e *APITokenScope) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = AssetSortField(str)
+	*e = APITokenScope(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid AssetSortField", str)
+		return fmt.Errorf("%s is not a valid APITokenScope", str)
 	}
 	return nil
 }
 
-func (e AssetSortField) MarshalGQL(w io.Writer) {
+func (e APITokenScope) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *AssetSortField) UnmarshalJSON(b []byte) error {
+func (e *APITokenScope) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -1580,56 +2094,56 @@ func (e *AssetSortField) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e AssetSortField) MarshalJSON() ([]byte, error) {
+func (e APITokenScope) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type ListOperation string
+type AssetSortField string
 
 const (
-	ListOperationAdd    ListOperation = "ADD"
-	ListOperationMove   ListOperation = "MOVE"
-	ListOperationRemove ListOperation = "REMOVE"
+	AssetSortFieldDate AssetSortField = "DATE"
+	AssetSortFieldSize AssetSortField = "SIZE"
+	AssetSortFieldName AssetSortField = "NAME"
 )
 
-var AllListOperation = []ListOperation{
-	ListOperationAdd,
-	ListOperationMove,
-	ListOperationRemove,
+var AllAssetSortField = []AssetSortField{
+	AssetSortFieldDate,
+	AssetSortFieldSize,
+	AssetSortFieldName,
 }
 
-func (e ListOperation) IsValid() bool {
+func (e AssetSortField) IsValid() bool {
 	switch e {
-	case ListOperationAdd, ListOperationMove, ListOperationRemove:
+	case AssetSortFieldDate, AssetSortFieldSize, AssetSortFieldName:
 		return true
 	}
 	return false
 }
 
-func (e ListOperation) String() string {
+func (e AssetSortField) String() string {
 	return string(e)
 }
 
-func (e *ListOperation) UnmarshalGQL(v any) error {
+func (e *AssetSortField) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = ListOperation(str)
+	*e = AssetSortField(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid ListOperation", str)
+		return fmt.Errorf("%s is not a valid AssetSortField", str)
 	}
 	return nil
 }
 
-func (e ListOperation) MarshalGQL(w io.Writer) {
+func (e AssetSortField) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *ListOperation) UnmarshalJSON(b []byte) error {
+func (e *AssetSortField) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -1637,70 +2151,609 @@ func (e *ListOperation) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e ListOperation) MarshalJSON() ([]byte, error) {
+func (e AssetSortField) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type NodeType string
+type AssetUsageType string
 
 const (
-	NodeTypeAsset          NodeType = "ASSET"
-	NodeTypeUser           NodeType = "USER"
-	NodeTypeWorkspace      NodeType = "WORKSPACE"
-	NodeTypeProject        NodeType = "PROJECT"
-	NodeTypePlugin         NodeType = "PLUGIN"
-	NodeTypeScene          NodeType = "SCENE"
-	NodeTypePropertySchema NodeType = "PROPERTY_SCHEMA"
-	NodeTypeProperty       NodeType = "PROPERTY"
-	NodeTypeLayerGroup     NodeType = "LAYER_GROUP"
-	NodeTypeLayerItem      NodeType = "LAYER_ITEM"
+	AssetUsageTypeProperty AssetUsageType = "PROPERTY"
+	AssetUsageTypeLayer    AssetUsageType = "LAYER"
+	AssetUsageTypeStyle    AssetUsageType = "STYLE"
+	AssetUsageTypeProject  AssetUsageType = "PROJECT"
+	AssetUsageTypeFeature  AssetUsageType = "FEATURE"
 )
 
-var AllNodeType = []NodeType{
-	NodeTypeAsset,
-	NodeTypeUser,
-	NodeTypeWorkspace,
-	NodeTypeProject,
-	NodeTypePlugin,
-	NodeTypeScene,
-	NodeTypePropertySchema,
-	NodeTypeProperty,
-	NodeTypeLayerGroup,
-	NodeTypeLayerItem,
+var AllAssetUsageType = []AssetUsageType{
+	AssetUsageTypeProperty,
+	AssetUsageTypeLayer,
+	AssetUsageTypeStyle,
+	AssetUsageTypeProject,
+	AssetUsageTypeFeature,
 }
 
-func (e NodeType) IsValid() bool {
+func (e AssetUsageType) IsValid() bool {
 	switch e {
-	case NodeTypeAsset, NodeTypeUser, NodeTypeWorkspace, NodeTypeProject, NodeTypePlugin, NodeTypeScene, NodeTypePropertySchema, NodeTypeProperty, NodeTypeLayerGroup, NodeTypeLayerItem:
+	case AssetUsageTypeProperty, AssetUsageTypeLayer, AssetUsageTypeStyle, AssetUsageTypeProject, AssetUsageTypeFeature:
 		return true
 	}
 	return false
 }
 
-func (e NodeType) String() string {
+func (e AssetUsageType) String() string {
 	return string(e)
 }
 
-func (e *NodeType) UnmarshalGQL(v any) error {
+func (e *AssetUsageType) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = NodeType(str)
+	*e = AssetUsageType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AssetUsageType", str)
+	}
+	return nil
+}
+
+func (e AssetUsageType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *AssetUsageType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e AssetUsageType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FeatureOperationType string
+
+const (
+	FeatureOperationTypeAdd    FeatureOperationType = "ADD"
+	FeatureOperationTypeUpdate FeatureOperationType = "UPDATE"
+	FeatureOperationTypeDelete FeatureOperationType = "DELETE"
+)
+
+var AllFeatureOperationType = []FeatureOperationType{
+	FeatureOperationTypeAdd,
+	FeatureOperationTypeUpdate,
+	FeatureOperationTypeDelete,
+}
+
+func (e FeatureOperationType) IsValid() bool {
+	switch e {
+	case FeatureOperationTypeAdd, FeatureOperationTypeUpdate, FeatureOperationTypeDelete:
+		return true
+	}
+	return false
+}
+
+func (e FeatureOperationType) String() string {
+	return string(e)
+}
+
+func (e *FeatureOperationType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeatureOperationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeatureOperationType", str)
+	}
+	return nil
+}
+
+func (e FeatureOperationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FeatureOperationType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FeatureOperationType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FeaturePropertyOperator string
+
+const (
+	FeaturePropertyOperatorEq       FeaturePropertyOperator = "EQ"
+	FeaturePropertyOperatorNe       FeaturePropertyOperator = "NE"
+	FeaturePropertyOperatorGt       FeaturePropertyOperator = "GT"
+	FeaturePropertyOperatorGte      FeaturePropertyOperator = "GTE"
+	FeaturePropertyOperatorLt       FeaturePropertyOperator = "LT"
+	FeaturePropertyOperatorLte      FeaturePropertyOperator = "LTE"
+	FeaturePropertyOperatorContains FeaturePropertyOperator = "CONTAINS"
+	FeaturePropertyOperatorExists   FeaturePropertyOperator = "EXISTS"
+)
+
+var AllFeaturePropertyOperator = []FeaturePropertyOperator{
+	FeaturePropertyOperatorEq,
+	FeaturePropertyOperatorNe,
+	FeaturePropertyOperatorGt,
+	FeaturePropertyOperatorGte,
+	FeaturePropertyOperatorLt,
+	FeaturePropertyOperatorLte,
+	FeaturePropertyOperatorContains,
+	FeaturePropertyOperatorExists,
+}
+
+func (e FeaturePropertyOperator) IsValid() bool {
+	switch e {
+	case FeaturePropertyOperatorEq, FeaturePropertyOperatorNe, FeaturePropertyOperatorGt, FeaturePropertyOperatorGte, FeaturePropertyOperatorLt, FeaturePropertyOperatorLte, FeaturePropertyOperatorContains, FeaturePropertyOperatorExists:
+		return true
+	}
+	return false
+}
+
+func (e FeaturePropertyOperator) String() string {
+	return string(e)
+}
+
+func (e *FeaturePropertyOperator) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeaturePropertyOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeaturePropertyOperator", str)
+	}
+	return nil
+}
+
+func (e FeaturePropertyOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FeaturePropertyOperator) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FeaturePropertyOperator) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FeatureRevisionAction string
+
+const (
+	FeatureRevisionActionAdd    FeatureRevisionAction = "ADD"
+	FeatureRevisionActionUpdate FeatureRevisionAction = "UPDATE"
+	FeatureRevisionActionDelete FeatureRevisionAction = "DELETE"
+)
+
+var AllFeatureRevisionAction = []FeatureRevisionAction{
+	FeatureRevisionActionAdd,
+	FeatureRevisionActionUpdate,
+	FeatureRevisionActionDelete,
+}
+
+func (e FeatureRevisionAction) IsValid() bool {
+	switch e {
+	case FeatureRevisionActionAdd, FeatureRevisionActionUpdate, FeatureRevisionActionDelete:
+		return true
+	}
+	return false
+}
+
+func (e FeatureRevisionAction) String() string {
+	return string(e)
+}
+
+func (e *FeatureRevisionAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeatureRevisionAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeatureRevisionAction", str)
+	}
+	return nil
+}
+
+func (e FeatureRevisionAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FeatureRevisionAction) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FeatureRevisionAction) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ImportGeoJSONFeaturesMode string
+
+const (
+	ImportGeoJSONFeaturesModeAppend  ImportGeoJSONFeaturesMode = "APPEND"
+	ImportGeoJSONFeaturesModeReplace ImportGeoJSONFeaturesMode = "REPLACE"
+)
+
+var AllImportGeoJSONFeaturesMode = []ImportGeoJSONFeaturesMode{
+	ImportGeoJSONFeaturesModeAppend,
+	ImportGeoJSONFeaturesModeReplace,
+}
+
+func (e ImportGeoJSONFeaturesMode) IsValid() bool {
+	switch e {
+	case ImportGeoJSONFeaturesModeAppend, ImportGeoJSONFeaturesModeReplace:
+		return true
+	}
+	return false
+}
+
+func (e ImportGeoJSONFeaturesMode) String() string {
+	return string(e)
+}
+
+func (e *ImportGeoJSONFeaturesMode) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportGeoJSONFeaturesMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportGeoJSONFeaturesMode", str)
+	}
+	return nil
+}
+
+func (e ImportGeoJSONFeaturesMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ImportGeoJSONFeaturesMode) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ImportGeoJSONFeaturesMode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ListOperation string
+
+const (
+	ListOperationAdd    ListOperation = "ADD"
+	ListOperationMove   ListOperation = "MOVE"
+	ListOperationRemove ListOperation = "REMOVE"
+)
+
+var AllListOperation = []ListOperation{
+	ListOperationAdd,
+	ListOperationMove,
+	ListOperationRemove,
+}
+
+func (e ListOperation) IsValid() bool {
+	switch e {
+	case ListOperationAdd, ListOperationMove, ListOperationRemove:
+		return true
+	}
+	return false
+}
+
+func (e ListOperation) String() string {
+	return string(e)
+}
+
+func (e *ListOperation) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ListOperation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ListOperation", str)
+	}
+	return nil
+}
+
+func (e ListOperation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ListOperation) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ListOperation) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type NodeType string
+
+const (
+	NodeTypeAsset          NodeType = "ASSET"
+	NodeTypeUser           NodeType = "USER"
+	NodeTypeWorkspace      NodeType = "WORKSPACE"
+	NodeTypeProject        NodeType = "PROJECT"
+	NodeTypePlugin         NodeType = "PLUGIN"
+	NodeTypeScene          NodeType = "SCENE"
+	NodeTypePropertySchema NodeType = "PROPERTY_SCHEMA"
+	NodeTypeProperty       NodeType = "PROPERTY"
+	NodeTypeLayerGroup     NodeType = "LAYER_GROUP"
+	NodeTypeLayerItem      NodeType = "LAYER_ITEM"
+)
+
+var AllNodeType = []NodeType{
+	NodeTypeAsset,
+	NodeTypeUser,
+	NodeTypeWorkspace,
+	NodeTypeProject,
+	NodeTypePlugin,
+	NodeTypeScene,
+	NodeTypePropertySchema,
+	NodeTypeProperty,
+	NodeTypeLayerGroup,
+	NodeTypeLayerItem,
+}
+
+func (e NodeType) IsValid() bool {
+	switch e {
+	case NodeTypeAsset, NodeTypeUser, NodeTypeWorkspace, NodeTypeProject, NodeTypePlugin, NodeTypeScene, NodeTypePropertySchema, NodeTypeProperty, NodeTypeLayerGroup, NodeTypeLayerItem:
+		return true
+	}
+	return false
+}
+
+func (e NodeType) String() string {
+	return string(e)
+}
+
+func (e *NodeType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NodeType(str)
 	if !e.IsValid() {
 		return fmt.Errorf("%s is not a valid NodeType", str)
 	}
 	return nil
 }
 
-func (e NodeType) MarshalGQL(w io.Writer) {
+func (e NodeType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NodeType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NodeType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PluginExtensionType string
+
+const (
+	PluginExtensionTypePrimitive    PluginExtensionType = "PRIMITIVE"
+	PluginExtensionTypeWidget       PluginExtensionType = "WIDGET"
+	PluginExtensionTypeBlock        PluginExtensionType = "BLOCK"
+	PluginExtensionTypeVisualizer   PluginExtensionType = "VISUALIZER"
+	PluginExtensionTypeInfobox      PluginExtensionType = "INFOBOX"
+	PluginExtensionTypePhotooverlay PluginExtensionType = "PHOTOOVERLAY"
+	PluginExtensionTypeStory        PluginExtensionType = "Story"
+	PluginExtensionTypeStoryPage    PluginExtensionType = "StoryPage"
+	PluginExtensionTypeStoryBlock   PluginExtensionType = "StoryBlock"
+	PluginExtensionTypeInfoboxBlock PluginExtensionType = "InfoboxBlock"
+)
+
+var AllPluginExtensionType = []PluginExtensionType{
+	PluginExtensionTypePrimitive,
+	PluginExtensionTypeWidget,
+	PluginExtensionTypeBlock,
+	PluginExtensionTypeVisualizer,
+	PluginExtensionTypeInfobox,
+	PluginExtensionTypePhotooverlay,
+	PluginExtensionTypeStory,
+	PluginExtensionTypeStoryPage,
+	PluginExtensionTypeStoryBlock,
+	PluginExtensionTypeInfoboxBlock,
+}
+
+func (e PluginExtensionType) IsValid() bool {
+	switch e {
+	case PluginExtensionTypePrimitive, PluginExtensionTypeWidget, PluginExtensionTypeBlock, PluginExtensionTypeVisualizer, PluginExtensionTypeInfobox, PluginExtensionTypePhotooverlay, PluginExtensionTypeStory, PluginExtensionTypeStoryPage, PluginExtensionTypeStoryBlock, PluginExtensionTypeInfoboxBlock:
+		return true
+	}
+	return false
+}
+
+func (e PluginExtensionType) String() string {
+	return string(e)
+}
+
+func (e *PluginExtensionType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PluginExtensionType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PluginExtensionType", str)
+	}
+	return nil
+}
+
+func (e PluginExtensionType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PluginExtensionType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PluginExtensionType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PluginUpgradeDropReason string
+
+const (
+	PluginUpgradeDropReasonDeleted    PluginUpgradeDropReason = "DELETED"
+	PluginUpgradeDropReasonCastFailed PluginUpgradeDropReason = "CAST_FAILED"
+	PluginUpgradeDropReasonConflict   PluginUpgradeDropReason = "CONFLICT"
+)
+
+var AllPluginUpgradeDropReason = []PluginUpgradeDropReason{
+	PluginUpgradeDropReasonDeleted,
+	PluginUpgradeDropReasonCastFailed,
+	PluginUpgradeDropReasonConflict,
+}
+
+func (e PluginUpgradeDropReason) IsValid() bool {
+	switch e {
+	case PluginUpgradeDropReasonDeleted, PluginUpgradeDropReasonCastFailed, PluginUpgradeDropReasonConflict:
+		return true
+	}
+	return false
+}
+
+func (e PluginUpgradeDropReason) String() string {
+	return string(e)
+}
+
+func (e *PluginUpgradeDropReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PluginUpgradeDropReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PluginUpgradeDropReason", str)
+	}
+	return nil
+}
+
+func (e PluginUpgradeDropReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PluginUpgradeDropReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PluginUpgradeDropReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PluginUpgradeFieldChangeType string
+
+const (
+	PluginUpgradeFieldChangeTypeMoved   PluginUpgradeFieldChangeType = "MOVED"
+	PluginUpgradeFieldChangeTypeCast    PluginUpgradeFieldChangeType = "CAST"
+	PluginUpgradeFieldChangeTypeDeleted PluginUpgradeFieldChangeType = "DELETED"
+)
+
+var AllPluginUpgradeFieldChangeType = []PluginUpgradeFieldChangeType{
+	PluginUpgradeFieldChangeTypeMoved,
+	PluginUpgradeFieldChangeTypeCast,
+	PluginUpgradeFieldChangeTypeDeleted,
+}
+
+func (e PluginUpgradeFieldChangeType) IsValid() bool {
+	switch e {
+	case PluginUpgradeFieldChangeTypeMoved, PluginUpgradeFieldChangeTypeCast, PluginUpgradeFieldChangeTypeDeleted:
+		return true
+	}
+	return false
+}
+
+func (e PluginUpgradeFieldChangeType) String() string {
+	return string(e)
+}
+
+func (e *PluginUpgradeFieldChangeType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PluginUpgradeFieldChangeType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PluginUpgradeFieldChangeType", str)
+	}
+	return nil
+}
+
+func (e PluginUpgradeFieldChangeType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *NodeType) UnmarshalJSON(b []byte) error {
+func (e *PluginUpgradeFieldChangeType) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -1708,70 +2761,58 @@ func (e *NodeType) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e NodeType) MarshalJSON() ([]byte, error) {
+func (e PluginUpgradeFieldChangeType) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type PluginExtensionType string
+type PluginUpgradeTargetType string
 
 const (
-	PluginExtensionTypePrimitive    PluginExtensionType = "PRIMITIVE"
-	PluginExtensionTypeWidget       PluginExtensionType = "WIDGET"
-	PluginExtensionTypeBlock        PluginExtensionType = "BLOCK"
-	PluginExtensionTypeVisualizer   PluginExtensionType = "VISUALIZER"
-	PluginExtensionTypeInfobox      PluginExtensionType = "INFOBOX"
-	PluginExtensionTypePhotooverlay PluginExtensionType = "PHOTOOVERLAY"
-	PluginExtensionTypeStory        PluginExtensionType = "Story"
-	PluginExtensionTypeStoryPage    PluginExtensionType = "StoryPage"
-	PluginExtensionTypeStoryBlock   PluginExtensionType = "StoryBlock"
-	PluginExtensionTypeInfoboxBlock PluginExtensionType = "InfoboxBlock"
+	PluginUpgradeTargetTypeScenePlugin  PluginUpgradeTargetType = "SCENE_PLUGIN"
+	PluginUpgradeTargetTypeWidget       PluginUpgradeTargetType = "WIDGET"
+	PluginUpgradeTargetTypeInfoboxBlock PluginUpgradeTargetType = "INFOBOX_BLOCK"
+	PluginUpgradeTargetTypeStoryBlock   PluginUpgradeTargetType = "STORY_BLOCK"
 )
 
-var AllPluginExtensionType = []PluginExtensionType{
-	PluginExtensionTypePrimitive,
-	PluginExtensionTypeWidget,
-	PluginExtensionTypeBlock,
-	PluginExtensionTypeVisualizer,
-	PluginExtensionTypeInfobox,
-	PluginExtensionTypePhotooverlay,
-	PluginExtensionTypeStory,
-	PluginExtensionTypeStoryPage,
-	PluginExtensionTypeStoryBlock,
-	PluginExtensionTypeInfoboxBlock,
+var AllPluginUpgradeTargetType = []PluginUpgradeTargetType{
+	PluginUpgradeTargetTypeScenePlugin,
+	PluginUpgradeTargetTypeWidget,
+	PluginUpgradeTargetTypeInfoboxBlock,
+	PluginUpgradeTargetTypeStoryBlock,
 }
 
-func (e PluginExtensionType) IsValid() bool {
+func (e PluginUpgradeTargetType) IsValid() bool {
 	switch e {
-	case PluginExtensionTypePrimitive, PluginExtensionTypeWidget, PluginExtensionTypeBlock, PluginExtensionTypeVisualizer, PluginExtensionTypeInfobox, PluginExtensionTypePhotooverlay, PluginExtensionTypeStory, PluginExtensionTypeStoryPage, PluginExtensionTypeStoryBlock, PluginExtensionTypeInfoboxBlock:
+	case PluginUpgradeTargetTypeScenePlugin, PluginUpgradeTargetTypeWidget, PluginUpgradeTargetTypeInfoboxBlock, PluginUpgradeTargetTypeStoryBlock:
 		return true
 	}
 	return false
 }
 
-func (e PluginExtensionType) String() string {
+func (e PluginUpgradeTargetType) String() string {
 	return string(e)
 }
 
-func (e *PluginExtensionType) UnmarshalGQL(v any) error {
+func (e *PluginUpgradeTargetType) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = PluginExtensionType(str)
+	*e = PluginUpgradeTargetType(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid PluginExtensionType", str)
+		return fmt.Errorf("%s is not a valid PluginUpgradeTargetType", str)
 	}
 	return nil
 }
 
-func (e PluginExtensionType) MarshalGQL(w io.Writer) {
+func (e PluginUpgradeTargetType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *PluginExtensionType) UnmarshalJSON(b []byte) error {
+func (e *PluginUpgradeTargetType) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -1779,7 +2820,7 @@ func (e *PluginExtensionType) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e PluginExtensionType) MarshalJSON() ([]byte, error) {
+func (e PluginUpgradeTargetType) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
@@ -2039,6 +3080,67 @@ func (e PropertySchemaFieldUI) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type PublishScheduleState string
+
+const (
+	PublishScheduleStatePending   PublishScheduleState = "PENDING"
+	PublishScheduleStatePublished PublishScheduleState = "PUBLISHED"
+	PublishScheduleStateDone      PublishScheduleState = "DONE"
+	PublishScheduleStateCanceled  PublishScheduleState = "CANCELED"
+	PublishScheduleStateFailed    PublishScheduleState = "FAILED"
+)
+
+var AllPublishScheduleState = []PublishScheduleState{
+	PublishScheduleStatePending,
+	PublishScheduleStatePublished,
+	PublishScheduleStateDone,
+	PublishScheduleStateCanceled,
+	PublishScheduleStateFailed,
+}
+
+func (e PublishScheduleState) IsValid() bool {
+	switch e {
+	case PublishScheduleStatePending, PublishScheduleStatePublished, PublishScheduleStateDone, PublishScheduleStateCanceled, PublishScheduleStateFailed:
+		return true
+	}
+	return false
+}
+
+func (e PublishScheduleState) String() string {
+	return string(e)
+}
+
+func (e *PublishScheduleState) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PublishScheduleState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PublishScheduleState", str)
+	}
+	return nil
+}
+
+func (e PublishScheduleState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PublishScheduleState) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PublishScheduleState) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type PublishmentStatus string
 
 const (
@@ -2155,6 +3257,101 @@ func (e Role) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type SceneEventType string
+
+const (
+	SceneEventTypeLayerAdded      SceneEventType = "LAYER_ADDED"
+	SceneEventTypeLayerUpdated    SceneEventType = "LAYER_UPDATED"
+	SceneEventTypeLayerRemoved    SceneEventType = "LAYER_REMOVED"
+	SceneEventTypeFeatureAdded    SceneEventType = "FEATURE_ADDED"
+	SceneEventTypeFeatureUpdated  SceneEventType = "FEATURE_UPDATED"
+	SceneEventTypeFeatureRemoved  SceneEventType = "FEATURE_REMOVED"
+	SceneEventTypePropertyUpdated SceneEventType = "PROPERTY_UPDATED"
+	SceneEventTypeStyleAdded      SceneEventType = "STYLE_ADDED"
+	SceneEventTypeStyleUpdated    SceneEventType = "STYLE_UPDATED"
+	SceneEventTypeStyleRemoved    SceneEventType = "STYLE_REMOVED"
+	SceneEventTypeWidgetAdded     SceneEventType = "WIDGET_ADDED"
+	SceneEventTypeWidgetUpdated   SceneEventType = "WIDGET_UPDATED"
+	SceneEventTypeWidgetRemoved   SceneEventType = "WIDGET_REMOVED"
+	SceneEventTypeStoryAdded      SceneEventType = "STORY_ADDED"
+	SceneEventTypeStoryUpdated    SceneEventType = "STORY_UPDATED"
+	SceneEventTypeStoryRemoved    SceneEventType = "STORY_REMOVED"
+	SceneEventTypePageAdded       SceneEventType = "PAGE_ADDED"
+	SceneEventTypePageUpdated     SceneEventType = "PAGE_UPDATED"
+	SceneEventTypePageRemoved     SceneEventType = "PAGE_REMOVED"
+	SceneEventTypeBlockAdded      SceneEventType = "BLOCK_ADDED"
+	SceneEventTypeBlockUpdated    SceneEventType = "BLOCK_UPDATED"
+	SceneEventTypeBlockRemoved    SceneEventType = "BLOCK_REMOVED"
+)
+
+var AllSceneEventType = []SceneEventType{
+	SceneEventTypeLayerAdded,
+	SceneEventTypeLayerUpdated,
+	SceneEventTypeLayerRemoved,
+	SceneEventTypeFeatureAdded,
+	SceneEventTypeFeatureUpdated,
+	SceneEventTypeFeatureRemoved,
+	SceneEventTypePropertyUpdated,
+	SceneEventTypeStyleAdded,
+	SceneEventTypeStyleUpdated,
+	SceneEventTypeStyleRemoved,
+	SceneEventTypeWidgetAdded,
+	SceneEventTypeWidgetUpdated,
+	SceneEventTypeWidgetRemoved,
+	SceneEventTypeStoryAdded,
+	SceneEventTypeStoryUpdated,
+	SceneEventTypeStoryRemoved,
+	SceneEventTypePageAdded,
+	SceneEventTypePageUpdated,
+	SceneEventTypePageRemoved,
+	SceneEventTypeBlockAdded,
+	SceneEventTypeBlockUpdated,
+	SceneEventTypeBlockRemoved,
+}
+
+func (e SceneEventType) IsValid() bool {
+	switch e {
+	case SceneEventTypeLayerAdded, SceneEventTypeLayerUpdated, SceneEventTypeLayerRemoved, SceneEventTypeFeatureAdded, SceneEventTypeFeatureUpdated, SceneEventTypeFeatureRemoved, SceneEventTypePropertyUpdated, SceneEventTypeStyleAdded, SceneEventTypeStyleUpdated, SceneEventTypeStyleRemoved, SceneEventTypeWidgetAdded, SceneEventTypeWidgetUpdated, SceneEventTypeWidgetRemoved, SceneEventTypeStoryAdded, SceneEventTypeStoryUpdated, SceneEventTypeStoryRemoved, SceneEventTypePageAdded, SceneEventTypePageUpdated, SceneEventTypePageRemoved, SceneEventTypeBlockAdded, SceneEventTypeBlockUpdated, SceneEventTypeBlockRemoved:
+		return true
+	}
+	return false
+}
+
+func (e SceneEventType) String() string {
+	return string(e)
+}
+
+func (e *SceneEventType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneEventType", str)
+	}
+	return nil
+}
+
+func (e SceneEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SceneEventType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SceneEventType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type SortDirection string
 
 const (
@@ -2328,6 +3525,61 @@ func (e Theme) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type TrashPurgeReason string
+
+const (
+	TrashPurgeReasonManual  TrashPurgeReason = "MANUAL"
+	TrashPurgeReasonExpired TrashPurgeReason = "EXPIRED"
+)
+
+var AllTrashPurgeReason = []TrashPurgeReason{
+	TrashPurgeReasonManual,
+	TrashPurgeReasonExpired,
+}
+
+func (e TrashPurgeReason) IsValid() bool {
+	switch e {
+	case TrashPurgeReasonManual, TrashPurgeReasonExpired:
+		return true
+	}
+	return false
+}
+
+func (e TrashPurgeReason) String() string {
+	return string(e)
+}
+
+func (e *TrashPurgeReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TrashPurgeReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TrashPurgeReason", str)
+	}
+	return nil
+}
+
+func (e TrashPurgeReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TrashPurgeReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TrashPurgeReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type ValueType string
 
 const (
@@ -2462,6 +3714,130 @@ func (e Visualizer) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type WebhookEvent string
+
+const (
+	WebhookEventProjectCreate  WebhookEvent = "PROJECT_CREATE"
+	WebhookEventProjectUpdate  WebhookEvent = "PROJECT_UPDATE"
+	WebhookEventProjectDelete  WebhookEvent = "PROJECT_DELETE"
+	WebhookEventProjectPublish WebhookEvent = "PROJECT_PUBLISH"
+	WebhookEventStoryPublish   WebhookEvent = "STORY_PUBLISH"
+	WebhookEventImportSuccess  WebhookEvent = "IMPORT_SUCCESS"
+	WebhookEventImportFailure  WebhookEvent = "IMPORT_FAILURE"
+	WebhookEventAssetUpload    WebhookEvent = "ASSET_UPLOAD"
+)
+
+var AllWebhookEvent = []WebhookEvent{
+	WebhookEventProjectCreate,
+	WebhookEventProjectUpdate,
+	WebhookEventProjectDelete,
+	WebhookEventProjectPublish,
+	WebhookEventStoryPublish,
+	WebhookEventImportSuccess,
+	WebhookEventImportFailure,
+	WebhookEventAssetUpload,
+}
+
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case WebhookEventProjectCreate, WebhookEventProjectUpdate, WebhookEventProjectDelete, WebhookEventProjectPublish, WebhookEventStoryPublish, WebhookEventImportSuccess, WebhookEventImportFailure, WebhookEventAssetUpload:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEvent) String() string {
+	return string(e)
+}
+
+func (e *WebhookEvent) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEvent(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEvent", str)
+	}
+	return nil
+}
+
+func (e WebhookEvent) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookEvent) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookEvent) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type WidgetAlignSystemType string
 
 const (
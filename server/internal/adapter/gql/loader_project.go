@@ -129,6 +129,40 @@ func (c *ProjectLoader) FindStarredByWorkspace(ctx context.Context, wsID gqlmode
 	}, nil
 }
 
+func (c *ProjectLoader) FindTemplatesByWorkspace(ctx context.Context, wsID gqlmodel.ID, pagination *gqlmodel.Pagination) (*gqlmodel.ProjectConnection, error) {
+	tid, err := gqlmodel.ToID[accountsID.Workspace](wsID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, pi, err := c.usecase.FindTemplatesByWorkspace(ctx, tid, gqlmodel.ToPagination(pagination), getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*gqlmodel.ProjectEdge, 0, len(res))
+	nodes := make([]*gqlmodel.Project, 0, len(res))
+	for _, p := range res {
+		prj := gqlmodel.ToProject(p)
+		edges = append(edges, &gqlmodel.ProjectEdge{
+			Node:   prj,
+			Cursor: usecasex.Cursor(prj.ID),
+		})
+		nodes = append(nodes, prj)
+	}
+
+	totalCount := int64(len(nodes))
+	if pi != nil {
+		totalCount = pi.TotalCount
+	}
+	return &gqlmodel.ProjectConnection{
+		Edges:      edges,
+		Nodes:      nodes,
+		PageInfo:   gqlmodel.ToPageInfo(pi),
+		TotalCount: int(totalCount),
+	}, nil
+}
+
 func (c *ProjectLoader) FindDeletedByWorkspace(ctx context.Context, wsID gqlmodel.ID, pagination *gqlmodel.Pagination) (*gqlmodel.ProjectConnection, error) {
 	tid, err := gqlmodel.ToID[accountsID.Workspace](wsID)
 	if err != nil {
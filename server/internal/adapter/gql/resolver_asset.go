@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/id"
 )
 
 func (r *Resolver) Asset() AssetResolver {
@@ -15,3 +16,16 @@ type assetResolver struct{ *Resolver }
 func (r *assetResolver) Workspace(ctx context.Context, obj *gqlmodel.Asset) (*gqlmodel.Workspace, error) {
 	return dataloaders(ctx).Workspace.Load(obj.WorkspaceID)
 }
+
+func (r *assetResolver) Usages(ctx context.Context, obj *gqlmodel.Asset) ([]*gqlmodel.AssetUsage, error) {
+	aid, err := gqlmodel.ToID[id.Asset](obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := usecases(ctx).Asset.FindUsages(ctx, aid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToAssetUsages(usages), nil
+}
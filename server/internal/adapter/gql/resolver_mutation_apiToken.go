@@ -0,0 +1,54 @@
+package gql
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/samber/lo"
+)
+
+func (r *mutationResolver) CreateAPIToken(ctx context.Context, input gqlmodel.CreateAPITokenInput) (*gqlmodel.CreateAPITokenPayload, error) {
+	wid, err := gqlmodel.ToID[accountsID.Workspace](input.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := gqlmodel.FromAPITokenScopes(input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	at, token, err := usecases(ctx).APIToken.Create(ctx, interfaces.CreateAPITokenParam{
+		WorkspaceID: wid,
+		Name:        lo.FromPtr(input.Name),
+		Scopes:      scopes,
+		ExpiresAt:   input.ExpiresAt,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.CreateAPITokenPayload{
+		APIToken: gqlmodel.ToAPIToken(at),
+		Token:    token,
+	}, nil
+}
+
+func (r *mutationResolver) RevokeAPIToken(ctx context.Context, input gqlmodel.RevokeAPITokenInput) (*gqlmodel.RevokeAPITokenPayload, error) {
+	tid, err := gqlmodel.ToID[id.APIToken](input.APITokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := usecases(ctx).APIToken.Revoke(ctx, tid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RevokeAPITokenPayload{
+		APIToken: gqlmodel.ToAPIToken(at),
+	}, nil
+}
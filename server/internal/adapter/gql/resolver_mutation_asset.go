@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/idx"
+	"github.com/samber/lo"
 )
 
 func (r *mutationResolver) CreateAsset(ctx context.Context, input gqlmodel.CreateAssetInput) (*gqlmodel.CreateAssetPayload, error) {
@@ -94,7 +95,7 @@ func (r *mutationResolver) RemoveAsset(ctx context.Context, input gqlmodel.Remov
 		return nil, err
 	}
 
-	res, err2 := usecases(ctx).Asset.Remove(ctx, aid, getOperator(ctx))
+	res, err2 := usecases(ctx).Asset.Remove(ctx, aid, lo.FromPtr(input.Force), getOperator(ctx))
 	if err2 != nil {
 		return nil, err2
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/samber/lo"
 )
 
 func (r *mutationResolver) AddGeoJSONFeature(ctx context.Context, input gqlmodel.AddGeoJSONFeatureInput) (*gqlmodel.Feature, error) {
@@ -17,10 +18,11 @@ func (r *mutationResolver) AddGeoJSONFeature(ctx context.Context, input gqlmodel
 	}
 
 	res, err := usecases(ctx).NLSLayer.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{
-		LayerID:    lid,
-		Type:       input.Type,
-		Geometry:   input.Geometry,
-		Properties: gqlmodel.ToGoJsonRef(input.Properties),
+		LayerID:        lid,
+		Type:           input.Type,
+		Geometry:       input.Geometry,
+		Properties:     gqlmodel.ToGoJsonRef(input.Properties),
+		RepairGeometry: lo.FromPtr(input.RepairGeometry),
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -51,10 +53,11 @@ func (r *mutationResolver) UpdateGeoJSONFeature(ctx context.Context, input gqlmo
 	}
 
 	res, err := usecases(ctx).NLSLayer.UpdateGeoJSONFeature(ctx, interfaces.UpdateNLSLayerGeoJSONFeatureParams{
-		LayerID:    lid,
-		FeatureID:  fid,
-		Geometry:   gqlmodel.ToGoJsonRef(input.Geometry),
-		Properties: gqlmodel.ToGoJsonRef(input.Properties),
+		LayerID:        lid,
+		FeatureID:      fid,
+		Geometry:       gqlmodel.ToGoJsonRef(input.Geometry),
+		Properties:     gqlmodel.ToGoJsonRef(input.Properties),
+		RepairGeometry: lo.FromPtr(input.RepairGeometry),
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -97,6 +100,126 @@ func (r *mutationResolver) DeleteGeoJSONFeature(ctx context.Context, input gqlmo
 	}, nil
 }
 
+func (r *mutationResolver) BatchGeoJSONFeatures(ctx context.Context, input gqlmodel.BatchGeoJSONFeaturesInput) (*gqlmodel.BatchGeoJSONFeaturesPayload, error) {
+	lid, err := gqlmodel.ToID[id.NLSLayer](input.LayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]interfaces.FeatureOperation, 0, len(input.Operations))
+	for _, op := range input.Operations {
+		var fid *id.FeatureID
+		if op.FeatureID != nil {
+			f, err := gqlmodel.ToID[id.Feature](*op.FeatureID)
+			if err != nil {
+				return nil, err
+			}
+			fid = &f
+		}
+		operations = append(operations, interfaces.FeatureOperation{
+			Type:        gqlmodel.FromFeatureOperationType(op.Type),
+			FeatureID:   fid,
+			FeatureType: lo.FromPtr(op.FeatureType),
+			Geometry:    gqlmodel.ToGoJsonRef(op.Geometry),
+			Properties:  gqlmodel.ToGoJsonRef(op.Properties),
+		})
+	}
+
+	res, err := usecases(ctx).NLSLayer.BatchGeoJSONFeatures(ctx, interfaces.BatchNLSLayerGeoJSONFeaturesParams{
+		LayerID:        lid,
+		Operations:     operations,
+		RepairGeometry: lo.FromPtr(input.RepairGeometry),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.BatchGeoJSONFeaturesPayload{
+		LayerID:           input.LayerID,
+		Added:             lo.Map(res.Added, func(f nlslayer.Feature, _ int) *gqlmodel.Feature { return gqlmodel.ToFeature(f) }),
+		Updated:           lo.Map(res.Updated, func(f nlslayer.Feature, _ int) *gqlmodel.Feature { return gqlmodel.ToFeature(f) }),
+		DeletedFeatureIds: lo.Map(res.Deleted, func(fid id.FeatureID, _ int) gqlmodel.ID { return gqlmodel.IDFrom(fid) }),
+	}, nil
+}
+
+func (r *mutationResolver) ImportGeoJSONFeatures(ctx context.Context, input gqlmodel.ImportGeoJSONFeaturesInput) (*gqlmodel.ImportGeoJSONFeaturesPayload, error) {
+	lid, err := gqlmodel.ToID[id.NLSLayer](input.LayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).NLSLayer.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID:        lid,
+		File:           gqlmodel.FromFile(&input.File),
+		Replace:        input.Mode == gqlmodel.ImportGeoJSONFeaturesModeReplace,
+		RepairGeometry: lo.FromPtr(input.RepairGeometry),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ImportGeoJSONFeaturesPayload{
+		LayerID:           input.LayerID,
+		FeatureCollection: gqlmodel.ToFeatureCollection(res),
+	}, nil
+}
+
+func (r *mutationResolver) RevertGeoJSONFeature(ctx context.Context, input gqlmodel.RevertGeoJSONFeatureInput) (*gqlmodel.RevertGeoJSONFeaturePayload, error) {
+	lid, rid, err := toRevertFeatureIDs(input.LayerID, input.RevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).NLSLayer.RevertFeature(ctx, interfaces.RevertNLSLayerFeatureParams{
+		LayerID:    lid,
+		RevisionID: rid,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &gqlmodel.RevertGeoJSONFeaturePayload{
+		LayerID:   input.LayerID,
+		FeatureID: gqlmodel.IDFrom(res.Feature()),
+	}
+	if res.After() != nil {
+		payload.Feature = gqlmodel.ToFeature(*res.After())
+	}
+	return payload, nil
+}
+
+func (r *mutationResolver) RevertFeatureCollection(ctx context.Context, input gqlmodel.RevertFeatureCollectionInput) (*gqlmodel.RevertFeatureCollectionPayload, error) {
+	lid, rid, err := toRevertFeatureIDs(input.LayerID, input.RevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).NLSLayer.RevertFeatureCollection(ctx, interfaces.RevertNLSLayerFeatureParams{
+		LayerID:    lid,
+		RevisionID: rid,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RevertFeatureCollectionPayload{
+		LayerID:           input.LayerID,
+		FeatureCollection: gqlmodel.ToFeatureCollection(res),
+	}, nil
+}
+
+func toRevertFeatureIDs(layerID, revisionID gqlmodel.ID) (id.NLSLayerID, id.FeatureRevisionID, error) {
+	lid, err := gqlmodel.ToID[id.NLSLayer](layerID)
+	if err != nil {
+		return id.NLSLayerID{}, id.FeatureRevisionID{}, err
+	}
+	rid, err := gqlmodel.ToID[id.FeatureRevision](revisionID)
+	if err != nil {
+		return id.NLSLayerID{}, id.FeatureRevisionID{}, err
+	}
+	return lid, rid, nil
+}
+
 func convertGeometry(nlslayerGeom nlslayer.Geometry) (gqlmodel.Geometry, error) {
 	switch geom := nlslayerGeom.(type) {
 	case *nlslayer.Point:
@@ -104,11 +227,21 @@ func convertGeometry(nlslayerGeom nlslayer.Geometry) (gqlmodel.Geometry, error)
 			Type:             geom.PointType(),
 			PointCoordinates: geom.Coordinates(),
 		}, nil
+	case *nlslayer.MultiPoint:
+		return gqlmodel.MultiPoint{
+			Type:                  geom.MultiPointType(),
+			MultiPointCoordinates: geom.Coordinates(),
+		}, nil
 	case *nlslayer.LineString:
 		return gqlmodel.LineString{
 			Type:                  geom.LineStringType(),
 			LineStringCoordinates: geom.Coordinates(),
 		}, nil
+	case *nlslayer.MultiLineString:
+		return gqlmodel.MultiLineString{
+			Type:                       geom.MultiLineStringType(),
+			MultiLineStringCoordinates: geom.Coordinates(),
+		}, nil
 	case *nlslayer.Polygon:
 		return gqlmodel.Polygon{
 			Type:               geom.PolygonType(),
@@ -307,3 +307,41 @@ func (r *mutationResolver) RemoveCustomProperty(ctx context.Context, input gqlmo
 		Layer: gqlmodel.ToNLSLayer(layer, nil),
 	}, nil
 }
+
+func (r *mutationResolver) ImportKml(ctx context.Context, input gqlmodel.ImportKMLInput) (*gqlmodel.ImportNLSLayerPayload, error) {
+	sid, aid, err := gqlmodel.ToID2[id.Scene, id.Asset](input.SceneID, input.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).NLSLayer.ImportKML(ctx, interfaces.ImportNLSLayerInput{
+		SceneID: sid,
+		AssetID: aid,
+		Title:   input.Title,
+		Index:   input.Index,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToImportNLSLayerPayload(res), nil
+}
+
+func (r *mutationResolver) ImportShapefile(ctx context.Context, input gqlmodel.ImportShapefileInput) (*gqlmodel.ImportNLSLayerPayload, error) {
+	sid, aid, err := gqlmodel.ToID2[id.Scene, id.Asset](input.SceneID, input.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).NLSLayer.ImportShapefile(ctx, interfaces.ImportNLSLayerInput{
+		SceneID: sid,
+		AssetID: aid,
+		Title:   input.Title,
+		Index:   input.Index,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlmodel.ToImportNLSLayerPayload(res), nil
+}
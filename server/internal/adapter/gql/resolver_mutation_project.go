@@ -17,6 +17,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/visualizer"
+	"github.com/samber/lo"
 	"github.com/spf13/afero"
 )
 
@@ -53,6 +54,52 @@ func (r *mutationResolver) CreateProject(ctx context.Context, input gqlmodel.Cre
 	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
 }
 
+func (r *mutationResolver) CreateProjectFromTemplate(ctx context.Context, input gqlmodel.CreateProjectFromTemplateInput) (*gqlmodel.ProjectPayload, error) {
+	tid, err := gqlmodel.ToID[id.Project](input.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).Project.CreateFromTemplate(ctx, interfaces.CreateProjectFromTemplateParam{
+		TemplateID:  tid,
+		Name:        input.Name,
+		Description: input.Description,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
+func (r *mutationResolver) DuplicateProject(ctx context.Context, input gqlmodel.DuplicateProjectInput) (*gqlmodel.ProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var wid *accountsID.WorkspaceID
+	if input.WorkspaceID != nil {
+		tid, err := gqlmodel.ToID[accountsID.Workspace](*input.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		wid = &tid
+	}
+
+	res, err := usecases(ctx).Project.Duplicate(ctx, interfaces.DuplicateProjectParam{
+		ID:          pid,
+		WorkspaceID: wid,
+		Name:        input.Name,
+		ShareAssets: lo.FromPtr(input.ShareAssets),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(res)}, nil
+}
+
 func (r *mutationResolver) UpdateProject(ctx context.Context, input gqlmodel.UpdateProjectInput) (*gqlmodel.ProjectPayload, error) {
 	deletePublicImage := false
 	if input.DeletePublicImage != nil {
@@ -82,6 +129,7 @@ func (r *mutationResolver) UpdateProject(ctx context.Context, input gqlmodel.Upd
 		Archived:       input.Archived,
 		DeleteImageURL: deleteImageURL,
 		Starred:        input.Starred,
+		Template:       input.IsTemplate,
 		Deleted:        input.Deleted,
 		SceneID:        gqlmodel.ToIDRef[id.Scene](input.SceneID),
 		Visibility:     input.Visibility,
@@ -139,9 +187,10 @@ func (r *mutationResolver) PublishProject(ctx context.Context, input gqlmodel.Pu
 	}
 
 	res, err := usecases(ctx).Project.Publish(ctx, interfaces.PublishProjectParam{
-		ID:     pid,
-		Alias:  input.Alias,
-		Status: gqlmodel.FromPublishmentStatus(input.Status),
+		ID:      pid,
+		Alias:   input.Alias,
+		Status:  gqlmodel.FromPublishmentStatus(input.Status),
+		Message: lo.FromPtr(input.Message),
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -0,0 +1,38 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+func (r *mutationResolver) RollbackPublication(ctx context.Context, input gqlmodel.RollbackPublicationInput) (*gqlmodel.RollbackPublicationPayload, error) {
+	param := interfaces.RollbackPublicationParam{
+		Version: input.Version,
+	}
+	if input.ProjectID != nil {
+		pid, err := gqlmodel.ToID[id.Project](*input.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		param.ProjectID = &pid
+	}
+	if input.StoryID != nil {
+		sid, err := gqlmodel.ToID[id.Story](*input.StoryID)
+		if err != nil {
+			return nil, err
+		}
+		param.StoryID = &sid
+	}
+
+	v, err := usecases(ctx).PublicationVersion.Rollback(ctx, param, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RollbackPublicationPayload{
+		PublicationVersion: gqlmodel.ToPublicationVersion(v),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+func (r *mutationResolver) CreatePublishSchedule(ctx context.Context, input gqlmodel.CreatePublishScheduleInput) (*gqlmodel.CreatePublishSchedulePayload, error) {
+	param := interfaces.CreatePublishScheduleParam{
+		Alias:       input.Alias,
+		Status:      gqlmodel.FromPublishScheduleStatus(input.Status),
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
+	}
+	if input.ProjectID != nil {
+		pid, err := gqlmodel.ToID[id.Project](*input.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		param.ProjectID = &pid
+	}
+	if input.StoryID != nil {
+		sid, err := gqlmodel.ToID[id.Story](*input.StoryID)
+		if err != nil {
+			return nil, err
+		}
+		param.StoryID = &sid
+	}
+
+	s, err := usecases(ctx).PublishSchedule.Create(ctx, param, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.CreatePublishSchedulePayload{
+		PublishSchedule: gqlmodel.ToPublishSchedule(s),
+	}, nil
+}
+
+func (r *mutationResolver) CancelPublishSchedule(ctx context.Context, input gqlmodel.CancelPublishScheduleInput) (*gqlmodel.CancelPublishSchedulePayload, error) {
+	sid, err := gqlmodel.ToID[id.PublishSchedule](input.PublishScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := usecases(ctx).PublishSchedule.Cancel(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.CancelPublishSchedulePayload{
+		PublishSchedule: gqlmodel.ToPublishSchedule(s),
+	}, nil
+}
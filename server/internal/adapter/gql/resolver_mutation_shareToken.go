@@ -0,0 +1,73 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/samber/lo"
+)
+
+func (r *mutationResolver) CreateShareToken(ctx context.Context, input gqlmodel.CreateShareTokenInput) (*gqlmodel.CreateShareTokenPayload, error) {
+	param := interfaces.CreateShareTokenParam{
+		Name:      lo.FromPtr(input.Name),
+		ExpiresAt: input.ExpiresAt,
+	}
+	if input.ProjectID != nil {
+		pid, err := gqlmodel.ToID[id.Project](*input.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		param.ProjectID = &pid
+	}
+	if input.StoryID != nil {
+		sid, err := gqlmodel.ToID[id.Story](*input.StoryID)
+		if err != nil {
+			return nil, err
+		}
+		param.StoryID = &sid
+	}
+
+	st, token, err := usecases(ctx).ShareToken.Create(ctx, param, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.CreateShareTokenPayload{
+		ShareToken: gqlmodel.ToShareToken(st),
+		Token:      token,
+	}, nil
+}
+
+func (r *mutationResolver) RevokeShareToken(ctx context.Context, input gqlmodel.RevokeShareTokenInput) (*gqlmodel.RevokeShareTokenPayload, error) {
+	tid, err := gqlmodel.ToID[id.ShareToken](input.ShareTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := usecases(ctx).ShareToken.Revoke(ctx, tid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.RevokeShareTokenPayload{
+		ShareToken: gqlmodel.ToShareToken(st),
+	}, nil
+}
+
+func (r *mutationResolver) UpdateShareTokenExpiry(ctx context.Context, input gqlmodel.UpdateShareTokenExpiryInput) (*gqlmodel.UpdateShareTokenExpiryPayload, error) {
+	tid, err := gqlmodel.ToID[id.ShareToken](input.ShareTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := usecases(ctx).ShareToken.UpdateExpiry(ctx, tid, input.ExpiresAt, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateShareTokenExpiryPayload{
+		ShareToken: gqlmodel.ToShareToken(st),
+	}, nil
+}
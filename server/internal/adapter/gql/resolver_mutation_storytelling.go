@@ -98,9 +98,10 @@ func (r *mutationResolver) PublishStory(ctx context.Context, input gqlmodel.Publ
 	}
 
 	res, err := usecases(ctx).StoryTelling.Publish(ctx, interfaces.PublishStoryInput{
-		ID:     sID,
-		Alias:  input.Alias,
-		Status: gqlmodel.FromStoryPublishmentStatus(input.Status),
+		ID:      sID,
+		Alias:   input.Alias,
+		Status:  gqlmodel.FromStoryPublishmentStatus(input.Status),
+		Message: lo.FromPtr(input.Message),
 	}, getOperator(ctx))
 	if err != nil {
 		return nil, err
@@ -116,6 +117,7 @@ func (r *mutationResolver) MoveStory(ctx context.Context, input gqlmodel.MoveSto
 	}
 
 	inp := interfaces.MoveStoryInput{
+		SceneID: scId,
 		StoryID: sId,
 		Index:   input.Index,
 	}
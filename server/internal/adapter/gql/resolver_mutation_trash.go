@@ -0,0 +1,56 @@
+package gql
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+func (r *mutationResolver) UpdateTrashPolicy(ctx context.Context, input gqlmodel.UpdateTrashPolicyInput) (*gqlmodel.UpdateTrashPolicyPayload, error) {
+	wid, err := gqlmodel.ToID[accountsID.Workspace](input.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := usecases(ctx).Trash.UpdatePolicy(ctx, wid, gqlmodel.FromTrashRetentionDays(input.RetentionDays), getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateTrashPolicyPayload{
+		TrashPolicy: gqlmodel.ToTrashPolicy(p),
+	}, nil
+}
+
+func (r *mutationResolver) RestoreProject(ctx context.Context, input gqlmodel.RestoreProjectInput) (*gqlmodel.ProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	prj, err := usecases(ctx).Trash.Restore(ctx, pid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.ProjectPayload{Project: gqlmodel.ToProject(prj)}, nil
+}
+
+func (r *mutationResolver) PurgeProject(ctx context.Context, input gqlmodel.PurgeProjectInput) (*gqlmodel.PurgeProjectPayload, error) {
+	pid, err := gqlmodel.ToID[id.Project](input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := usecases(ctx).Trash.Purge(ctx, pid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.PurgeProjectPayload{
+		ProjectID:  input.ProjectID,
+		TrashPurge: gqlmodel.ToTrashPurge(p),
+	}, nil
+}
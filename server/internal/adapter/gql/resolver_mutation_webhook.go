@@ -0,0 +1,92 @@
+package gql
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/samber/lo"
+)
+
+func (r *mutationResolver) CreateWebhook(ctx context.Context, input gqlmodel.CreateWebhookInput) (*gqlmodel.CreateWebhookPayload, error) {
+	wid, err := gqlmodel.ToID[accountsID.Workspace](input.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := gqlmodel.FromWebhookEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := usecases(ctx).Webhook.Create(ctx, interfaces.CreateWebhookParam{
+		WorkspaceID: wid,
+		Name:        lo.FromPtr(input.Name),
+		URL:         &input.URL,
+		Events:      events,
+		Enabled:     lo.FromPtrOr(input.Enabled, true),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.CreateWebhookPayload{
+		Webhook: gqlmodel.ToWebhook(w),
+		Secret:  w.Secret(),
+	}, nil
+}
+
+func (r *mutationResolver) UpdateWebhook(ctx context.Context, input gqlmodel.UpdateWebhookInput) (*gqlmodel.UpdateWebhookPayload, error) {
+	wid, err := gqlmodel.ToID[id.Webhook](input.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events *[]webhook.EventType
+	if input.Events != nil {
+		e, err := gqlmodel.FromWebhookEvents(input.Events)
+		if err != nil {
+			return nil, err
+		}
+		events = &e
+	}
+
+	regenerateSecret := lo.FromPtr(input.RegenerateSecret)
+	w, err := usecases(ctx).Webhook.Update(ctx, interfaces.UpdateWebhookParam{
+		ID:               wid,
+		Name:             input.Name,
+		URL:              input.URL,
+		Events:           events,
+		Enabled:          input.Enabled,
+		RegenerateSecret: regenerateSecret,
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &gqlmodel.UpdateWebhookPayload{
+		Webhook: gqlmodel.ToWebhook(w),
+	}
+	if regenerateSecret {
+		res.Secret = lo.ToPtr(w.Secret())
+	}
+	return res, nil
+}
+
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, input gqlmodel.DeleteWebhookInput) (*gqlmodel.DeleteWebhookPayload, error) {
+	wid, err := gqlmodel.ToID[id.Webhook](input.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecases(ctx).Webhook.Delete(ctx, wid, getOperator(ctx)); err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.DeleteWebhookPayload{
+		WebhookID: input.WebhookID,
+	}, nil
+}
@@ -5,6 +5,9 @@ import (
 
 	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
 )
 
 func (r *Resolver) Query() QueryResolver {
@@ -17,6 +20,19 @@ func (r *queryResolver) Assets(ctx context.Context, workspaceID gqlmodel.ID, pro
 	return loaders(ctx).Asset.FindByWorkspace(ctx, workspaceID, projectId, keyword, gqlmodel.AssetSortTypeFrom(sortType), pagination)
 }
 
+func (r *queryResolver) OrphanedAssets(ctx context.Context, workspaceID gqlmodel.ID) ([]*gqlmodel.Asset, error) {
+	wid, err := gqlmodel.ToID[accountsID.Workspace](workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := usecases(ctx).Asset.FindOrphans(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToAssets(assets), nil
+}
+
 func (r *queryResolver) Me(ctx context.Context) (*gqlmodel.Me, error) {
 	u := getUser(ctx)
 	if u == nil {
@@ -160,6 +176,24 @@ func (r *queryResolver) Plugins(ctx context.Context, ids []gqlmodel.ID) ([]*gqlm
 	return data, nil
 }
 
+func (r *queryResolver) PluginUpgradeReport(ctx context.Context, sceneID gqlmodel.ID, pluginID gqlmodel.ID, toPluginID gqlmodel.ID) (*gqlmodel.PluginUpgradeReport, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, topid, err := gqlmodel.ToPluginID2(pluginID, toPluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := usecases(ctx).Scene.PluginUpgradeReport(ctx, sid, pid, topid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToPluginUpgradeReport(report), nil
+}
+
 func (r *queryResolver) Scene(ctx context.Context, projectID gqlmodel.ID) (*gqlmodel.Scene, error) {
 	return loaders(ctx).Scene.FindByProject(ctx, projectID)
 }
@@ -188,6 +222,10 @@ func (r *queryResolver) StarredProjects(ctx context.Context, workspaceId gqlmode
 	return loaders(ctx).Project.FindStarredByWorkspace(ctx, workspaceId, pagination)
 }
 
+func (r *queryResolver) TemplateProjects(ctx context.Context, workspaceId gqlmodel.ID, pagination *gqlmodel.Pagination) (*gqlmodel.ProjectConnection, error) {
+	return loaders(ctx).Project.FindTemplatesByWorkspace(ctx, workspaceId, pagination)
+}
+
 func (r *queryResolver) DeletedProjects(ctx context.Context, workspaceId gqlmodel.ID, pagination *gqlmodel.Pagination) (*gqlmodel.ProjectConnection, error) {
 	return loaders(ctx).Project.FindDeletedByWorkspace(ctx, workspaceId, pagination)
 }
@@ -196,6 +234,215 @@ func (r *queryResolver) VisibilityProjects(ctx context.Context, authenticated bo
 	return loaders(ctx).Project.VisibilityByWorkspace(ctx, workspaceId, authenticated)
 }
 
+func (r *queryResolver) ExportNLSLayerCzml(ctx context.Context, layerID gqlmodel.ID) (gqlmodel.Array, error) {
+	lid, err := gqlmodel.ToID[id.NLSLayer](layerID)
+	if err != nil {
+		return nil, err
+	}
+
+	packets, err := usecases(ctx).NLSLayer.ExportCZML(ctx, lid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToCZML(packets)
+}
+
+func (r *queryResolver) QueryNLSLayerFeatures(ctx context.Context, layerID gqlmodel.ID, query *gqlmodel.FeatureQueryInput, pagination *gqlmodel.Pagination) (*gqlmodel.FeatureConnection, error) {
+	lid, err := gqlmodel.ToID[id.NLSLayer](layerID)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := gqlmodel.FromFeatureQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	features, pi, err := usecases(ctx).NLSLayer.QueryFeatures(ctx, interfaces.QueryNLSLayerFeaturesParams{
+		LayerID:    lid,
+		Query:      q,
+		Pagination: gqlmodel.ToPagination(pagination),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToFeatureConnection(features, pi), nil
+}
+
+func (r *queryResolver) FeatureRevisions(ctx context.Context, layerID gqlmodel.ID, featureID *gqlmodel.ID, pagination *gqlmodel.Pagination) (*gqlmodel.FeatureRevisionConnection, error) {
+	lid, err := gqlmodel.ToID[id.NLSLayer](layerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fid *id.FeatureID
+	if featureID != nil {
+		f, err := gqlmodel.ToID[id.Feature](*featureID)
+		if err != nil {
+			return nil, err
+		}
+		fid = &f
+	}
+
+	revisions, pi, err := usecases(ctx).NLSLayer.FetchFeatureRevisions(ctx, interfaces.FetchNLSLayerFeatureRevisionsParams{
+		LayerID:    lid,
+		FeatureID:  fid,
+		Pagination: gqlmodel.ToPagination(pagination),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToFeatureRevisionConnection(revisions, pi), nil
+}
+
+func (r *queryResolver) ShareTokens(ctx context.Context, projectID *gqlmodel.ID, storyID *gqlmodel.ID) ([]*gqlmodel.ShareToken, error) {
+	if (projectID == nil) == (storyID == nil) {
+		return nil, publication.ErrInvalidShareTokenTarget
+	}
+
+	var tokens []*publication.ShareToken
+	if projectID != nil {
+		pid, err := gqlmodel.ToID[id.Project](*projectID)
+		if err != nil {
+			return nil, err
+		}
+		if tokens, err = usecases(ctx).ShareToken.FetchByProject(ctx, pid, getOperator(ctx)); err != nil {
+			return nil, err
+		}
+	} else {
+		sid, err := gqlmodel.ToID[id.Story](*storyID)
+		if err != nil {
+			return nil, err
+		}
+		if tokens, err = usecases(ctx).ShareToken.FetchByStory(ctx, sid, getOperator(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	return gqlmodel.ToShareTokens(tokens), nil
+}
+
+func (r *queryResolver) PublishSchedules(ctx context.Context, projectID *gqlmodel.ID, storyID *gqlmodel.ID) ([]*gqlmodel.PublishSchedule, error) {
+	if (projectID == nil) == (storyID == nil) {
+		return nil, publication.ErrInvalidScheduleTarget
+	}
+
+	var schedules []*publication.Schedule
+	if projectID != nil {
+		pid, err := gqlmodel.ToID[id.Project](*projectID)
+		if err != nil {
+			return nil, err
+		}
+		if schedules, err = usecases(ctx).PublishSchedule.FetchByProject(ctx, pid, getOperator(ctx)); err != nil {
+			return nil, err
+		}
+	} else {
+		sid, err := gqlmodel.ToID[id.Story](*storyID)
+		if err != nil {
+			return nil, err
+		}
+		if schedules, err = usecases(ctx).PublishSchedule.FetchByStory(ctx, sid, getOperator(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	return gqlmodel.ToPublishSchedules(schedules), nil
+}
+
+func (r *queryResolver) TrashPolicy(ctx context.Context, workspaceID gqlmodel.ID) (*gqlmodel.TrashPolicy, error) {
+	wid, err := gqlmodel.ToID[accountsID.Workspace](workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := usecases(ctx).Trash.FindPolicy(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToTrashPolicy(p), nil
+}
+
+func (r *queryResolver) TrashPurges(ctx context.Context, workspaceID gqlmodel.ID) ([]*gqlmodel.TrashPurge, error) {
+	wid, err := gqlmodel.ToID[accountsID.Workspace](workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	purges, err := usecases(ctx).Trash.FindPurges(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToTrashPurges(purges), nil
+}
+
+func (r *queryResolver) APITokens(ctx context.Context, workspaceID gqlmodel.ID) ([]*gqlmodel.APIToken, error) {
+	wid, err := gqlmodel.ToID[accountsID.Workspace](workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := usecases(ctx).APIToken.FetchByWorkspace(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToAPITokens(tokens), nil
+}
+
+func (r *queryResolver) Webhooks(ctx context.Context, workspaceID gqlmodel.ID) ([]*gqlmodel.Webhook, error) {
+	wid, err := gqlmodel.ToID[accountsID.Workspace](workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := usecases(ctx).Webhook.FetchByWorkspace(ctx, wid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToWebhooks(webhooks), nil
+}
+
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID gqlmodel.ID, status *gqlmodel.WebhookDeliveryStatus, pagination *gqlmodel.Pagination) (*gqlmodel.WebhookDeliveryConnection, error) {
+	wid, err := gqlmodel.ToID[id.Webhook](webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, pi, err := usecases(ctx).Webhook.FetchDeliveries(ctx, interfaces.FetchWebhookDeliveriesParam{
+		WebhookID:  wid,
+		Status:     gqlmodel.FromWebhookDeliveryStatus(status),
+		Pagination: gqlmodel.ToPagination(pagination),
+	}, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToWebhookDeliveryConnection(deliveries, pi), nil
+}
+
+func (r *queryResolver) PublicationVersions(ctx context.Context, projectID *gqlmodel.ID, storyID *gqlmodel.ID) ([]*gqlmodel.PublicationVersion, error) {
+	if (projectID == nil) == (storyID == nil) {
+		return nil, publication.ErrInvalidVersionTarget
+	}
+
+	var versions []*publication.Version
+	if projectID != nil {
+		pid, err := gqlmodel.ToID[id.Project](*projectID)
+		if err != nil {
+			return nil, err
+		}
+		if versions, err = usecases(ctx).PublicationVersion.FetchByProject(ctx, pid, getOperator(ctx)); err != nil {
+			return nil, err
+		}
+	} else {
+		sid, err := gqlmodel.ToID[id.Story](*storyID)
+		if err != nil {
+			return nil, err
+		}
+		if versions, err = usecases(ctx).PublicationVersion.FetchByStory(ctx, sid, getOperator(ctx)); err != nil {
+			return nil, err
+		}
+	}
+
+	return gqlmodel.ToPublicationVersions(versions), nil
+}
+
 func (r *queryResolver) WorkspacePolicyCheck(ctx context.Context, input gqlmodel.PolicyCheckInput) (*gqlmodel.PolicyCheckPayload, error) {
 	wid, err := gqlmodel.ToID[accountsID.Workspace](input.WorkspaceID)
 	if err != nil {
@@ -0,0 +1,40 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+func (r *Resolver) Subscription() SubscriptionResolver {
+	return &subscriptionResolver{r}
+}
+
+type subscriptionResolver struct{ *Resolver }
+
+func (r *subscriptionResolver) SceneEvents(ctx context.Context, sceneID gqlmodel.ID) (<-chan *gqlmodel.SceneEvent, error) {
+	sid, err := gqlmodel.ToID[id.Scene](sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := usecases(ctx).Scene.SubscribeEvents(ctx, sid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan *gqlmodel.SceneEvent)
+	go func() {
+		defer close(res)
+		// events is closed when the client disconnects and ctx is done
+		for e := range events {
+			select {
+			case res <- gqlmodel.ToSceneEvent(e):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res, nil
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// The inputs and outputs of the REST API under /api/v1.
+
+type ListOutput[T any] struct {
+	Items    []T             `json:"items"`
+	PageInfo *PageInfoOutput `json:"pageInfo,omitempty"`
+}
+
+type PageInfoOutput struct {
+	EndCursor   *string `json:"endCursor,omitempty"`
+	HasNextPage bool    `json:"hasNextPage"`
+}
+
+func ListFrom[S, T any](items []S, f func(S) T, pageInfo *usecasex.PageInfo) ListOutput[T] {
+	res := ListOutput[T]{Items: make([]T, 0, len(items))}
+	for _, i := range items {
+		res.Items = append(res.Items, f(i))
+	}
+	if pageInfo != nil {
+		var endCursor *string
+		if pageInfo.EndCursor != nil {
+			c := string(*pageInfo.EndCursor)
+			endCursor = &c
+		}
+		res.PageInfo = &PageInfoOutput{
+			EndCursor:   endCursor,
+			HasNextPage: pageInfo.HasNextPage,
+		}
+	}
+	return res
+}
+
+type ProjectOutput struct {
+	ID                string     `json:"id"`
+	WorkspaceID       string     `json:"workspaceId"`
+	SceneID           string     `json:"sceneId"`
+	Name              string     `json:"name"`
+	Description       string     `json:"description"`
+	Alias             string     `json:"alias"`
+	PublishmentStatus string     `json:"publishmentStatus"`
+	PublishedAt       *time.Time `json:"publishedAt,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+func ProjectOutputFrom(p *project.Project) ProjectOutput {
+	var publishedAt *time.Time
+	if t := p.PublishedAt(); !t.IsZero() {
+		publishedAt = &t
+	}
+	return ProjectOutput{
+		ID:                p.ID().String(),
+		WorkspaceID:       p.Workspace().String(),
+		SceneID:           p.Scene().String(),
+		Name:              p.Name(),
+		Description:       p.Description(),
+		Alias:             p.Alias(),
+		PublishmentStatus: string(p.PublishmentStatus()),
+		PublishedAt:       publishedAt,
+		CreatedAt:         p.CreatedAt(),
+		UpdatedAt:         p.UpdatedAt(),
+	}
+}
+
+type PublishProjectInput struct {
+	Alias *string `json:"alias"`
+	// Status is public, limited or private. It defaults to public.
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type LayerOutput struct {
+	ID        string `json:"id"`
+	SceneID   string `json:"sceneId"`
+	Title     string `json:"title"`
+	LayerType string `json:"layerType"`
+	Visible   bool   `json:"visible"`
+	// Sketch is true for the layers whose features can be edited through the API.
+	Sketch bool `json:"sketch"`
+}
+
+func LayerOutputFrom(l nlslayer.NLSLayer) LayerOutput {
+	return LayerOutput{
+		ID:        l.ID().String(),
+		SceneID:   l.Scene().String(),
+		Title:     l.Title(),
+		LayerType: string(l.LayerType()),
+		Visible:   l.IsVisible(),
+		Sketch:    l.IsSketch(),
+	}
+}
+
+// FeatureOutput is a GeoJSON feature.
+type FeatureOutput struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id"`
+	Geometry   map[string]any `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+func FeatureOutputFrom(f nlslayer.Feature) FeatureOutput {
+	return FeatureOutput{
+		Type:       f.FeatureType(),
+		ID:         f.ID().String(),
+		Geometry:   nlslayer.GeometryToMap(f.Geometry()),
+		Properties: *f.Properties(),
+	}
+}
+
+// FeatureCollectionOutput is a GeoJSON feature collection.
+type FeatureCollectionOutput struct {
+	Type     string          `json:"type"`
+	Features []FeatureOutput `json:"features"`
+}
+
+func FeatureCollectionOutputFrom(fc *nlslayer.FeatureCollection) FeatureCollectionOutput {
+	res := FeatureCollectionOutput{Type: "FeatureCollection", Features: []FeatureOutput{}}
+	if fc == nil {
+		return res
+	}
+	for _, f := range fc.Features() {
+		res.Features = append(res.Features, FeatureOutputFrom(f))
+	}
+	return res
+}
+
+// FeatureInput is a GeoJSON feature to add to a layer.
+type FeatureInput struct {
+	Type       string          `json:"type"`
+	Geometry   map[string]any  `json:"geometry"`
+	Properties *map[string]any `json:"properties"`
+}
+
+// UpdateFeatureInput replaces the geometry or the properties of a feature. Omitted members are left unchanged.
+type UpdateFeatureInput struct {
+	Geometry   *map[string]any `json:"geometry"`
+	Properties *map[string]any `json:"properties"`
+}
+
+type AssetOutput struct {
+	ID           string    `json:"id"`
+	WorkspaceID  string    `json:"workspaceId"`
+	ProjectID    *string   `json:"projectId,omitempty"`
+	Name         string    `json:"name"`
+	Size         int64     `json:"size"`
+	URL          string    `json:"url"`
+	ContentType  string    `json:"contentType"`
+	ThumbnailURL string    `json:"thumbnailUrl,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func AssetOutputFrom(a *asset.Asset) AssetOutput {
+	return AssetOutput{
+		ID:           a.ID().String(),
+		WorkspaceID:  a.Workspace().String(),
+		ProjectID:    a.Project().StringRef(),
+		Name:         a.Name(),
+		Size:         a.Size(),
+		URL:          a.URL(),
+		ContentType:  a.ContentType(),
+		ThumbnailURL: a.ThumbnailURL(),
+		CreatedAt:    a.CreatedAt(),
+	}
+}
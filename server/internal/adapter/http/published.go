@@ -24,6 +24,14 @@ func (c *PublishedController) Data(ctx context.Context, name string) (io.Reader,
 	return c.usecase.Data(ctx, name)
 }
 
+func (c *PublishedController) DataVersion(ctx context.Context, name string, version int) (io.Reader, error) {
+	return c.usecase.DataVersion(ctx, name, version)
+}
+
 func (c *PublishedController) Index(ctx context.Context, name string, url *url.URL) (string, error) {
 	return c.usecase.Index(ctx, name, url)
 }
+
+func (c *PublishedController) ValidateShareToken(ctx context.Context, name, token string) error {
+	return c.usecase.ValidateShareToken(ctx, name, token)
+}
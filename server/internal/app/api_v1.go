@@ -0,0 +1,347 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth/server/internal/adapter"
+	http1 "github.com/reearth/reearth/server/internal/adapter/http"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+)
+
+const (
+	apiV1DefaultPageSize = 50
+	apiV1MaxPageSize     = 100
+)
+
+var errNotSketchLayer = errors.New("features can only be changed on sketch layers")
+
+// servAPIv1 serves the REST API for automation such as CI pipelines. It is authenticated with the API tokens of
+// workspaces, and each route requires a scope of the token.
+func servAPIv1(apiRoot *echo.Group, cfg *ServerConfig, publishedIndexHTML string) {
+	v1 := apiRoot.Group("/v1", privateCache, attachOpMiddlewareAPIToken(cfg))
+	// rebuild the usecases with the operator of the token, see newUsecaseMiddleware
+	v1.Use(newUsecaseMiddleware(cfg, publishedIndexHTML))
+
+	read := requireAPITokenScope(apitoken.ScopeRead)
+	write := requireAPITokenScope(apitoken.ScopeWrite)
+	publish := requireAPITokenScope(apitoken.ScopePublish)
+
+	v1.GET("/projects", apiV1Projects, read)
+	v1.GET("/projects/:projectId", apiV1Project, read)
+	v1.GET("/projects/:projectId/layers", apiV1ProjectLayers, read)
+	v1.POST("/projects/:projectId/publish", apiV1PublishProject, publish)
+
+	v1.GET("/layers/:layerId/features", apiV1Features, read)
+	v1.POST("/layers/:layerId/features", apiV1AddFeature, write)
+	v1.PATCH("/layers/:layerId/features/:featureId", apiV1UpdateFeature, write)
+	v1.DELETE("/layers/:layerId/features/:featureId", apiV1DeleteFeature, write)
+
+	v1.GET("/assets", apiV1Assets, read)
+	v1.POST("/assets", apiV1UploadAsset, write)
+}
+
+func apiV1Projects(c echo.Context) error {
+	ctx := c.Request().Context()
+	pagination, err := apiV1Pagination(c)
+	if err != nil {
+		return err
+	}
+
+	projects, pageInfo, err := adapter.Usecases(ctx).Project.FindByWorkspace(ctx, adapter.APIToken(ctx).Workspace(), nil, nil, pagination, adapter.Operator(ctx))
+	if err != nil {
+		return apiV1Error(err)
+	}
+	return c.JSON(http.StatusOK, http1.ListFrom(projects, http1.ProjectOutputFrom, pageInfo))
+}
+
+func apiV1Project(c echo.Context) error {
+	prj, err := apiV1FindProject(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, http1.ProjectOutputFrom(prj))
+}
+
+func apiV1ProjectLayers(c echo.Context) error {
+	ctx := c.Request().Context()
+	prj, err := apiV1FindProject(c)
+	if err != nil {
+		return err
+	}
+
+	layers, err := adapter.Usecases(ctx).NLSLayer.FetchByScene(ctx, prj.Scene(), adapter.Operator(ctx))
+	if err != nil {
+		return apiV1Error(err)
+	}
+	return c.JSON(http.StatusOK, http1.ListFrom(layers.Deref(), http1.LayerOutputFrom, nil))
+}
+
+func apiV1PublishProject(c echo.Context) error {
+	ctx := c.Request().Context()
+	prj, err := apiV1FindProject(c)
+	if err != nil {
+		return err
+	}
+
+	var inp http1.PublishProjectInput
+	if err := c.Bind(&inp); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("failed to parse request body: %v", err))
+	}
+	status := project.PublishmentStatus(inp.Status)
+	switch status {
+	case "":
+		status = project.PublishmentStatusPublic
+	case project.PublishmentStatusPublic, project.PublishmentStatusLimited, project.PublishmentStatusPrivate:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "status must be public, limited or private")
+	}
+
+	prj, err = adapter.Usecases(ctx).Project.Publish(ctx, interfaces.PublishProjectParam{
+		ID:      prj.ID(),
+		Alias:   inp.Alias,
+		Status:  status,
+		Message: inp.Message,
+	}, adapter.Operator(ctx))
+	if err != nil {
+		return apiV1Error(err)
+	}
+	return c.JSON(http.StatusOK, http1.ProjectOutputFrom(prj))
+}
+
+func apiV1Features(c echo.Context) error {
+	l, err := apiV1FindLayer(c)
+	if err != nil {
+		return err
+	}
+
+	var fc *nlslayer.FeatureCollection
+	if l.HasSketch() {
+		fc = l.Sketch().FeatureCollection()
+	}
+	return c.JSON(http.StatusOK, http1.FeatureCollectionOutputFrom(fc))
+}
+
+func apiV1AddFeature(c echo.Context) error {
+	ctx := c.Request().Context()
+	l, err := apiV1FindSketchLayer(c)
+	if err != nil {
+		return err
+	}
+
+	var inp http1.FeatureInput
+	if err := c.Bind(&inp); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("failed to parse request body: %v", err))
+	}
+	if inp.Type == "" {
+		inp.Type = "Feature"
+	}
+
+	f, err := adapter.Usecases(ctx).NLSLayer.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{
+		LayerID:    l.ID(),
+		Type:       inp.Type,
+		Geometry:   inp.Geometry,
+		Properties: inp.Properties,
+	}, adapter.Operator(ctx))
+	if err != nil {
+		return apiV1Error(err)
+	}
+	return c.JSON(http.StatusCreated, http1.FeatureOutputFrom(f))
+}
+
+func apiV1UpdateFeature(c echo.Context) error {
+	ctx := c.Request().Context()
+	l, err := apiV1FindSketchLayer(c)
+	if err != nil {
+		return err
+	}
+	fid, err := id.FeatureIDFrom(c.Param("featureId"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	var inp http1.UpdateFeatureInput
+	if err := c.Bind(&inp); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("failed to parse request body: %v", err))
+	}
+
+	f, err := adapter.Usecases(ctx).NLSLayer.UpdateGeoJSONFeature(ctx, interfaces.UpdateNLSLayerGeoJSONFeatureParams{
+		LayerID:    l.ID(),
+		FeatureID:  fid,
+		Geometry:   inp.Geometry,
+		Properties: inp.Properties,
+	}, adapter.Operator(ctx))
+	if err != nil {
+		return apiV1Error(err)
+	}
+	return c.JSON(http.StatusOK, http1.FeatureOutputFrom(f))
+}
+
+func apiV1DeleteFeature(c echo.Context) error {
+	ctx := c.Request().Context()
+	l, err := apiV1FindSketchLayer(c)
+	if err != nil {
+		return err
+	}
+	fid, err := id.FeatureIDFrom(c.Param("featureId"))
+	if err != nil {
+		return echo.ErrBadRequest
+	}
+
+	if _, err := adapter.Usecases(ctx).NLSLayer.DeleteGeoJSONFeature(ctx, interfaces.DeleteNLSLayerGeoJSONFeatureParams{
+		LayerID:   l.ID(),
+		FeatureID: fid,
+	}, adapter.Operator(ctx)); err != nil {
+		return apiV1Error(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func apiV1Assets(c echo.Context) error {
+	ctx := c.Request().Context()
+	pagination, err := apiV1Pagination(c)
+	if err != nil {
+		return err
+	}
+	pid, err := apiV1ProjectIDQuery(c)
+	if err != nil {
+		return err
+	}
+
+	assets, pageInfo, err := adapter.Usecases(ctx).Asset.FindByWorkspaceProject(ctx, adapter.APIToken(ctx).Workspace(), pid, nil, nil, pagination, adapter.Operator(ctx))
+	if err != nil {
+		return apiV1Error(err)
+	}
+	return c.JSON(http.StatusOK, http1.ListFrom(assets, http1.AssetOutputFrom, pageInfo))
+}
+
+func apiV1UploadAsset(c echo.Context) error {
+	ctx := c.Request().Context()
+	pid, err := apiV1ProjectIDQuery(c)
+	if err != nil {
+		return err
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "file is required")
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	a, err := adapter.Usecases(ctx).Asset.Create(ctx, interfaces.CreateAssetParam{
+		WorkspaceID: adapter.APIToken(ctx).Workspace(),
+		ProjectID:   pid,
+		CoreSupport: true,
+		File: &file.File{
+			Content:     f,
+			Path:        fh.Filename,
+			Size:        fh.Size,
+			ContentType: fh.Header.Get(echo.HeaderContentType),
+		},
+	}, adapter.Operator(ctx))
+	if err != nil {
+		return apiV1Error(err)
+	}
+	return c.JSON(http.StatusCreated, http1.AssetOutputFrom(a))
+}
+
+// apiV1FindProject returns the project in the path. Projects of other workspaces are not found
+// since the repos are filtered by the workspace of the token.
+func apiV1FindProject(c echo.Context) (*project.Project, error) {
+	ctx := c.Request().Context()
+	pid, err := id.ProjectIDFrom(c.Param("projectId"))
+	if err != nil {
+		return nil, echo.ErrBadRequest
+	}
+
+	projects, err := adapter.Usecases(ctx).Project.Fetch(ctx, []id.ProjectID{pid}, adapter.Operator(ctx))
+	if err != nil {
+		return nil, apiV1Error(err)
+	}
+	if len(projects) == 0 || projects[0] == nil || projects[0].IsDeleted() {
+		return nil, rerror.ErrNotFound
+	}
+	return projects[0], nil
+}
+
+func apiV1FindLayer(c echo.Context) (nlslayer.NLSLayer, error) {
+	ctx := c.Request().Context()
+	lid, err := id.NLSLayerIDFrom(c.Param("layerId"))
+	if err != nil {
+		return nil, echo.ErrBadRequest
+	}
+
+	layers, err := adapter.Usecases(ctx).NLSLayer.Fetch(ctx, id.NLSLayerIDList{lid}, adapter.Operator(ctx))
+	if err != nil {
+		return nil, apiV1Error(err)
+	}
+	if len(layers) == 0 || layers[0] == nil {
+		return nil, rerror.ErrNotFound
+	}
+	return *layers[0], nil
+}
+
+func apiV1FindSketchLayer(c echo.Context) (nlslayer.NLSLayer, error) {
+	l, err := apiV1FindLayer(c)
+	if err != nil {
+		return nil, err
+	}
+	if !l.IsSketch() {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, errNotSketchLayer.Error())
+	}
+	return l, nil
+}
+
+func apiV1ProjectIDQuery(c echo.Context) (*id.ProjectID, error) {
+	p := c.QueryParam("projectId")
+	if p == "" {
+		return nil, nil
+	}
+	pid, err := id.ProjectIDFrom(p)
+	if err != nil {
+		return nil, echo.ErrBadRequest
+	}
+	return &pid, nil
+}
+
+// apiV1Pagination reads the first and after query parameters of the list endpoints.
+func apiV1Pagination(c echo.Context) (*usecasex.Pagination, error) {
+	first := int64(apiV1DefaultPageSize)
+	if f := c.QueryParam("first"); f != "" {
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil || n <= 0 || n > apiV1MaxPageSize {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("first must be between 1 and %d", apiV1MaxPageSize))
+		}
+		first = n
+	}
+
+	var after *usecasex.Cursor
+	if a := c.QueryParam("after"); a != "" {
+		after = lo.ToPtr(usecasex.Cursor(a))
+	}
+	return usecasex.CursorPagination{First: &first, After: after}.Wrap(), nil
+}
+
+func apiV1Error(err error) error {
+	if errors.Is(err, interfaces.ErrOperationDenied) {
+		return echo.ErrForbidden
+	}
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"net/http/pprof"
 	"os"
 
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -141,6 +142,9 @@ func initEcho(
 	serveFiles(e, allowedOrigins(cfg), cfg.Gateways.DomainChecker, cfg.Gateways.File)
 	serveExportFile(e, cfg, allowedOrigins(cfg), cfg.Gateways.DomainChecker, cfg.Gateways.File)
 
+	// REST API for automation, authenticated with the API tokens of workspaces instead of interactive sessions
+	servAPIv1(apiRoot, cfg, publishedIndexHTML) // /v1/...
+
 	apiPrivateRoute := apiRoot.Group("", privateCache)
 	if cfg.Config.UseMockAuth() {
 		apiPrivateRoute.Use(attachOpMiddlewareMockUser(cfg))
@@ -154,11 +158,21 @@ func initEcho(
 	apiPrivateRoute.Use(LatestLogoutAtHeader)
 
 	// Main backend API
-	apiPrivateRoute.POST("/graphql", GraphqlAPI(cfg.Config.GraphQL, cfg.AccountsAPIClient, gqldev))
+	var websocketInit transport.WebsocketInitFunc
+	if !cfg.Config.UseMockAuth() {
+		websocketInit = websocketInitReearthAccounts(cfg, usecaseConfig(cfg, publishedIndexHTML))
+	}
+	gqlHandler := GraphqlAPI(cfg.Config.GraphQL, cfg.AccountsAPIClient, gqldev, origins, websocketInit)
+	apiPrivateRoute.POST("/graphql", gqlHandler)
+	// WebSocket for the subscriptions
+	apiPrivateRoute.GET("/graphql", gqlHandler)
 
 	// Registering an initial auth0 user (for local development)
 	apiPrivateRoute.POST("/signup", Signup(cfg))
 
+	// Layer Export API
+	servLayerExport(apiPrivateRoute) // /layers/:layerId/export.czml, /layers/:layerId/export.shp.zip
+
 	// Project Import API direct upload version
 	servSplitUploadFiles(apiPrivateRoute, cfg) // /split-import
 	// Project Import API using GCP trriger version
@@ -195,15 +209,20 @@ func newUsecaseMiddleware(cfg *ServerConfig, publishedIndexHTML string) echo.Mid
 		cfg.Gateways,
 		cfg.AccountRepos,
 		cfg.AccountGateways,
-		interactor.ContainerConfig{
-			SignupSecret:       cfg.Config.SignupSecret,
-			PublishedIndexHTML: publishedIndexHTML,
-			PublishedIndexURL:  cfg.Config.Published.IndexURL,
-			AuthSrvUIDomain:    cfg.Config.Host_Web,
-		},
+		usecaseConfig(cfg, publishedIndexHTML),
 	)
 }
 
+func usecaseConfig(cfg *ServerConfig, publishedIndexHTML string) interactor.ContainerConfig {
+	return interactor.ContainerConfig{
+		SignupSecret:       cfg.Config.SignupSecret,
+		PublishedIndexHTML: publishedIndexHTML,
+		PublishedIndexURL:  cfg.Config.Published.IndexURL,
+		AuthSrvUIDomain:    cfg.Config.Host_Web,
+		TrashRetention:     cfg.Config.Trash.Retention,
+	}
+}
+
 func errorHandler(next func(error, echo.Context)) func(error, echo.Context) {
 	return func(err error, c echo.Context) {
 		if c.Response().Committed {
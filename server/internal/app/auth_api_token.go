@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsRole "github.com/reearth/reearth-accounts/server/pkg/role"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// attachOpMiddlewareAPIToken authenticates the requests of the REST API with the API token in the Authorization header
+// and attaches an operator limited to the workspace of the token. Interactive sessions are not accepted here, and API
+// tokens are not accepted by the other routes, so the scopes of a token cannot be bypassed through GraphQL.
+func attachOpMiddlewareAPIToken(cfg *ServerConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := req.Context()
+
+			ctx = adapter.AttachCurrentHost(ctx, cfg.Config.Host)
+
+			token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if !apitoken.IsToken(token) {
+				return echo.ErrUnauthorized
+			}
+
+			// the usecases here are built before the operator is known, so the token can be looked up in any workspace
+			at, err := adapter.Usecases(ctx).APIToken.Authenticate(ctx, token)
+			if errors.Is(err, interfaces.ErrInvalidAPIToken) {
+				log.Warnfc(ctx, "auth: invalid api token: %s", c.Path())
+				return echo.ErrUnauthorized
+			}
+			if err != nil {
+				return err
+			}
+
+			op, err := generateOperatorFromAPIToken(ctx, cfg, at)
+			if errors.Is(err, interfaces.ErrAPITokenCreatorNotAllowed) {
+				log.Warnfc(ctx, "auth: creator of api token %s is no longer a maintainer of workspace %s", at.ID(), at.Workspace())
+				return echo.ErrUnauthorized
+			}
+			if err != nil {
+				return err
+			}
+
+			ctx = adapter.AttachAPIToken(ctx, at)
+			ctx = adapter.AttachOperator(ctx, op)
+			log.Infofc(ctx, "auth: request context (api_token_id=%s, workspace_id=%s, path=%s)", at.ID(), at.Workspace(), c.Path())
+
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// generateOperatorFromAPIToken returns an operator that can read the workspace of the token and its scenes, or write
+// them when the token has the write or publish scope. The user is the creator of the token so that what the token
+// creates is attributed to them. The creator must still be a maintainer of the workspace, as creating the token
+// requires, so removing a member or lowering their role also disables their tokens.
+func generateOperatorFromAPIToken(ctx context.Context, cfg *ServerConfig, at *apitoken.APIToken) (*usecase.Operator, error) {
+	wid := at.Workspace()
+	uid := at.CreatedBy()
+	if uid == nil {
+		return nil, interfaces.ErrAPITokenCreatorNotAllowed
+	}
+	ws, err := cfg.Repos.Workspace.FindByID(ctx, wid)
+	if errors.Is(err, rerror.ErrNotFound) {
+		return nil, interfaces.ErrAPITokenCreatorNotAllowed
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ws.Members().UserRole(*uid).Includes(accountsRole.RoleMaintainer) {
+		return nil, interfaces.ErrAPITokenCreatorNotAllowed
+	}
+
+	scenes, err := cfg.Repos.Scene.FindByWorkspace(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{
+			User: uid,
+		},
+	}
+	if at.Scopes().CanWrite() {
+		op.AcOperator.WritableWorkspaces = accountsID.WorkspaceIDList{wid}
+		op.WritableScenes = scenes.IDs()
+	} else {
+		op.AcOperator.ReadableWorkspaces = accountsID.WorkspaceIDList{wid}
+		op.ReadableScenes = scenes.IDs()
+	}
+	return op, nil
+}
+
+// requireAPITokenScope rejects the request unless the API token that authenticated it has the scope.
+func requireAPITokenScope(s apitoken.Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			at := adapter.APIToken(c.Request().Context())
+			if at == nil {
+				return echo.ErrUnauthorized
+			}
+			if !at.Allows(s) {
+				return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("the api token does not have the %s scope", s))
+			}
+			return next(c)
+		}
+	}
+}
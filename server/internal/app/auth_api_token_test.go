@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsRole "github.com/reearth/reearth-accounts/server/pkg/role"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateOperatorFromAPIToken(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.New()
+	cfg := &ServerConfig{Repos: repos}
+
+	wid := accountsID.NewWorkspaceID()
+	uid := accountsID.NewUserID()
+	writer := accountsID.NewUserID()
+	ws := accountsWorkspace.New().ID(wid).Members(map[accountsID.UserID]accountsWorkspace.Member{
+		uid:    {Role: accountsRole.RoleMaintainer},
+		writer: {Role: accountsRole.RoleWriter},
+	}).MustBuild()
+	require.NoError(t, repos.Workspace.Save(ctx, ws))
+	s := scene.New().NewID().Workspace(wid).Project(id.NewProjectID()).MustBuild()
+	require.NoError(t, repos.Scene.Save(ctx, s))
+	other := scene.New().NewID().Workspace(accountsID.NewWorkspaceID()).Project(id.NewProjectID()).MustBuild()
+	require.NoError(t, repos.Scene.Save(ctx, other))
+
+	newTokenBy := func(u *accountsID.UserID, scopes ...apitoken.Scope) *apitoken.APIToken {
+		_, hash, err := apitoken.GenerateToken()
+		require.NoError(t, err)
+		return apitoken.New().NewID().Workspace(wid).Scopes(scopes).Hash(hash).CreatedBy(u).MustBuild()
+	}
+	newToken := func(scopes ...apitoken.Scope) *apitoken.APIToken {
+		return newTokenBy(&uid, scopes...)
+	}
+
+	t.Run("read", func(t *testing.T) {
+		op, err := generateOperatorFromAPIToken(ctx, cfg, newToken(apitoken.ScopeRead))
+		require.NoError(t, err)
+		assert.Equal(t, &uid, op.AcOperator.User)
+		assert.True(t, op.IsReadableWorkspace(wid))
+		assert.False(t, op.IsWritableWorkspace(wid))
+		assert.True(t, op.IsReadableScene(s.ID()))
+		assert.False(t, op.IsWritableScene(s.ID()))
+		assert.False(t, op.IsReadableScene(other.ID()))
+	})
+
+	for _, sc := range []apitoken.Scope{apitoken.ScopeWrite, apitoken.ScopePublish} {
+		t.Run(string(sc), func(t *testing.T) {
+			op, err := generateOperatorFromAPIToken(ctx, cfg, newToken(sc))
+			require.NoError(t, err)
+			assert.True(t, op.IsWritableWorkspace(wid))
+			assert.False(t, op.IsMaintainingWorkspace(wid))
+			assert.True(t, op.IsWritableScene(s.ID()))
+			assert.False(t, op.IsReadableScene(other.ID()))
+		})
+	}
+
+	// the creator has to be a maintainer of the workspace as long as the token is used
+	for name, u := range map[string]*accountsID.UserID{
+		"not a member":   lo.ToPtr(accountsID.NewUserID()),
+		"not maintainer": &writer,
+		"no creator":     nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := generateOperatorFromAPIToken(ctx, cfg, newTokenBy(u, apitoken.ScopeRead))
+			assert.ErrorIs(t, err, interfaces.ErrAPITokenCreatorNotAllowed)
+		})
+	}
+}
+
+func TestRequireAPITokenScope(t *testing.T) {
+	_, hash, err := apitoken.GenerateToken()
+	require.NoError(t, err)
+	at := apitoken.New().NewID().Workspace(accountsID.NewWorkspaceID()).Scopes(apitoken.Scopes{apitoken.ScopeWrite}).Hash(hash).MustBuild()
+
+	tests := []struct {
+		name  string
+		token *apitoken.APIToken
+		scope apitoken.Scope
+		want  int
+	}{
+		{name: "no token", scope: apitoken.ScopeRead, want: http.StatusUnauthorized},
+		{name: "read is implied", token: at, scope: apitoken.ScopeRead, want: http.StatusOK},
+		{name: "granted", token: at, scope: apitoken.ScopeWrite, want: http.StatusOK},
+		{name: "missing", token: at, scope: apitoken.ScopePublish, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.GET("/", func(c echo.Context) error {
+				return c.String(http.StatusOK, "ok")
+			}, requireAPITokenScope(tt.scope))
+
+			ctx := context.Background()
+			if tt.token != nil {
+				ctx = adapter.AttachAPIToken(ctx, tt.token)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
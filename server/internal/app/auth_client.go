@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/internal/adapter/gql"
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 
@@ -96,6 +99,13 @@ func attachOpMiddlewareReearthAccounts(cfg *ServerConfig) echo.MiddlewareFunc {
 				return next(c)
 			}
 
+			// Skip authentication for the GraphQL subscriptions, which authenticate with the connection_init payload
+			if req.URL.Path == "/api/graphql" && isWebsocketUpgrade(req) {
+				log.Debugfc(ctx, "auth: deferring authentication of websocket to connection_init")
+				c.SetRequest(req.WithContext(ctx))
+				return next(c)
+			}
+
 			// The token is set as is and sent to reearth-accounts for verification.
 			token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
 			ctx = adapter.AttachJwtToken(ctx, token)
@@ -281,3 +291,44 @@ func AuthRequiredMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+func isWebsocketUpgrade(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Upgrade"), "websocket")
+}
+
+// websocketInitReearthAccounts authenticates the GraphQL subscriptions with the token in the connection_init payload,
+// since browsers cannot set the Authorization header when they open a WebSocket.
+// The usecases are built again with the operator, like newUsecaseMiddleware does for the other requests.
+func websocketInitReearthAccounts(cfg *ServerConfig, ucConfig interactor.ContainerConfig) transport.WebsocketInitFunc {
+	return func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		token := strings.TrimPrefix(payload.Authorization(), "Bearer ")
+		if token == "" {
+			return ctx, nil, echo.ErrUnauthorized
+		}
+		ctx = adapter.AttachJwtToken(ctx, token)
+
+		userModel, err := cfg.AccountsAPIClient.UserRepo.FindMe(ctx)
+		if err != nil {
+			return ctx, nil, handleAccountsAPIError(ctx, fmt.Errorf("(FindMe): %w, websocket", err))
+		}
+		if userModel == nil {
+			return ctx, nil, echo.ErrUnauthorized
+		}
+
+		u, err := buildAccountDomainUserFromUserModel(ctx, userModel)
+		if err != nil {
+			return ctx, nil, err
+		}
+		ctx = adapter.AttachUser(ctx, u)
+
+		op, err := generateOperator(ctx, cfg, u)
+		if err != nil {
+			return ctx, nil, err
+		}
+		ctx = adapter.AttachOperator(ctx, op)
+		log.Infofc(ctx, "auth: websocket context (user_id=%s)", u.ID())
+
+		uc := BuildUsecases(ctx, cfg.Repos, cfg.Gateways, cfg.AccountRepos, cfg.AccountGateways, ucConfig)
+		return gql.AttachUsecases(ctx, &uc, cfg.AccountsAPIClient, enableDataLoaders), nil, nil
+	}
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/internal/app/config"
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachOpMiddlewareReearthAccounts_Websocket(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/graphql?access_token=token", nil)
+	req.Header.Set("Upgrade", "websocket")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	called := false
+	// the accounts API is not called since the websocket is authenticated with the connection_init payload
+	err := attachOpMiddlewareReearthAccounts(&ServerConfig{Config: &config.Config{}})(func(c echo.Context) error {
+		called = true
+		assert.Empty(t, adapter.JwtToken(c.Request().Context()))
+		assert.Nil(t, adapter.Operator(c.Request().Context()))
+		return nil
+	})(c)
+
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestWebsocketInitReearthAccounts(t *testing.T) {
+	initFunc := websocketInitReearthAccounts(&ServerConfig{}, interactor.ContainerConfig{})
+
+	_, _, err := initFunc(context.Background(), transport.InitPayload{})
+	assert.Equal(t, echo.ErrUnauthorized, err)
+	_, _, err = initFunc(context.Background(), transport.InitPayload{"Authorization": "Bearer "})
+	assert.Equal(t, echo.ErrUnauthorized, err)
+}
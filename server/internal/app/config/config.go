@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"net/url"
 	"os"
 	"strings"
@@ -35,6 +37,8 @@ type Config struct {
 	DB_Vis                 string            `default:"reearth" pp:",omitempty"`
 	GraphQL                GraphQLConfig     `pp:",omitempty"`
 	Published              PublishedConfig   `pp:",omitempty"`
+	Trash                  TrashConfig       `pp:",omitempty"`
+	Webhook                WebhookConfig     `pp:",omitempty"`
 	GCPProject             string            `envconfig:"GOOGLE_CLOUD_PROJECT" pp:",omitempty"`
 	OtelEnabled            bool              `envconfig:"REEARTH_OTEL_ENABLED" default:"false"`
 	OtelEndpoint           string            `envconfig:"REEARTH_OTEL_ENDPOINT" default:"localhost:4317"`
@@ -76,6 +80,7 @@ type Config struct {
 	// storage
 	GCS GCSConfig `pp:",omitempty"`
 	S3  S3Config  `pp:",omitempty"`
+	FS  FSConfig  `pp:",omitempty"`
 
 	// auth
 	Auth          AuthConfigs   `pp:",omitempty"`
@@ -173,6 +178,10 @@ func ReadConfig(debug bool) (*Config, error) {
 		c.Host_Web = c.Host
 	}
 
+	if c.FS.SignedUploadSecret == "" {
+		c.FS.SignedUploadSecret = randomSecret()
+	}
+
 	return &c, err
 }
 
@@ -192,7 +201,7 @@ func (c *Config) UseMockAuth() bool {
 }
 
 func (c *Config) secrets() []string {
-	s := []string{c.DB, c.Auth0.ClientSecret}
+	s := []string{c.DB, c.Auth0.ClientSecret, c.FS.SignedUploadSecret}
 	for _, ac := range c.DB_Users {
 		s = append(s, ac.URI)
 	}
@@ -311,3 +320,11 @@ func strPtr(s string) *string {
 func intPtr(i int) *int {
 	return &i
 }
+
+func randomSecret() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
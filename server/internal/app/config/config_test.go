@@ -13,6 +13,7 @@ func TestReadConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, cfg.Auth)
 	assert.Empty(t, cfg.Auths())
+	assert.Len(t, cfg.FS.SignedUploadSecret, 64) // generated
 
 	t.Setenv("REEARTH_AUTH", `[{"iss":"bar"}]`)
 	t.Setenv("REEARTH_AUTH_ISS", "hoge")
@@ -47,6 +48,11 @@ func TestReadConfig(t *testing.T) {
 	cfg, err = ReadConfig(false)
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"https://hoge.com/myplugin", "https://hoge.com/myplugin2"}, cfg.Ext_Plugin)
+
+	t.Setenv("REEARTH_FS_SIGNEDUPLOADSECRET", "secret")
+	cfg, err = ReadConfig(false)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", cfg.FS.SignedUploadSecret)
 }
 
 func Test_AddHTTPScheme(t *testing.T) {
@@ -13,8 +13,19 @@ func (g GCSConfig) IsConfigured() bool {
 type S3Config struct {
 	BucketName              string
 	PublicationCacheControl string
+	// Endpoint and UsePathStyle are used to connect to an S3-compatible storage such as MinIO.
+	Endpoint     string `pp:",omitempty"`
+	UsePathStyle bool   `pp:",omitempty"`
 }
 
 func (s S3Config) IsConfigured() bool {
 	return s.BucketName != ""
 }
+
+// FSConfig configures the local file system storage used when neither GCS nor S3 is configured.
+type FSConfig struct {
+	// SignedUploadSecret signs the URLs of /api/import-upload, which receives project zips uploaded directly
+	// by the browser. A random secret is generated when it is empty, so it must be set explicitly when
+	// more than one server instance shares the storage.
+	SignedUploadSecret string `pp:",omitempty"`
+}
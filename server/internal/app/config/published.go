@@ -1,8 +1,13 @@
 package config
 
-import "net/url"
+import (
+	"net/url"
+	"time"
+)
 
 type PublishedConfig struct {
 	IndexURL *url.URL `pp:",omitempty"`
 	Host     string   `pp:",omitempty"`
+	// ScheduleInterval is how often the publish schedules are checked. Zero or less disables the scheduler.
+	ScheduleInterval time.Duration `default:"1m" pp:",omitempty"`
 }
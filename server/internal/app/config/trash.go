@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type TrashConfig struct {
+	// Retention is how long deleted projects stay in the trash of the workspaces that have not configured their own retention.
+	// Zero keeps them forever.
+	Retention time.Duration `default:"720h" pp:",omitempty"`
+	// PurgeInterval is how often expired projects are purged from the trash. Zero or less disables the purge job.
+	PurgeInterval time.Duration `default:"1h" pp:",omitempty"`
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type WebhookConfig struct {
+	// DeliveryInterval is how often the due webhook deliveries are sent. Zero or less disables sending them.
+	DeliveryInterval time.Duration `default:"10s" pp:",omitempty"`
+	// Timeout is how many seconds a webhook has to respond before the attempt fails.
+	Timeout int `default:"10" pp:",omitempty"`
+}
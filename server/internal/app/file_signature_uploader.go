@@ -8,15 +8,19 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/internal/app/config"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
 	"github.com/reearth/reearth/server/internal/usecase"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	file_ "github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
@@ -92,96 +96,66 @@ func servSignatureUploadFiles(
 				return nil, err
 			}
 
-			claimed, err := usecases.Project.ClaimImport(ctx, *pid, op)
-			if err != nil {
-				return nil, err
+			return importUploadedProject(ctx, cfg, usecases, op, base, *wid, *pid)
+		}),
+	)
+
+	// this endpoint is called by the client after it has uploaded the zip to the signed URL.
+	// It starts the import without relying on storage notifications, which are only available on GCS.
+	apiPrivateRoute.POST("/import-project/complete",
+		securityHandler(func(c echo.Context, ctx context.Context, usecases *interfaces.Container, op *usecase.Operator) (interface{}, error) {
+			if op == nil || op.AcOperator == nil || op.AcOperator.User == nil {
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
 			}
-			if !claimed {
-				log.Infof("[Import] skipping %s: already succeeded or in progress", pid.String())
-				return map[string]string{"status": "skipped", "reason": "already processed or in progress"}, nil
+
+			workspaceID, err := accountsID.WorkspaceIDFrom(c.FormValue("workspace_id"))
+			if err != nil {
+				errMsg := fmt.Sprintf("Invalid workspace id: %v", err)
+				return nil, echo.NewHTTPError(http.StatusBadRequest, errMsg)
 			}
 
-			// The uploaded zip is the only copy of the import input. It is kept
-			// until a complete local copy exists, so that a redelivery after a
-			// failed download still has something to read. Once the copy is
-			// complete, re-downloading cannot change the outcome, so it is
-			// removed as before regardless of how the import ends.
-			removeZip := false
-			defer func() {
-				if removeZip {
-					removeGcsZip(ctx, cfg.Gateways.File, base)
-				}
-			}()
+			projectID, err := id.ProjectIDFrom(c.FormValue("temporary_project"))
+			if err != nil {
+				errMsg := fmt.Sprintf("Invalid project id: %v", err)
+				return nil, echo.NewHTTPError(http.StatusBadRequest, errMsg)
+			}
 
-			result := map[string]any{}
+			// The file name is derived from the operator, so a user can only import what they uploaded themselves.
+			base := GenFileName(workspaceID, projectID, *op.AcOperator.User)
 
+			// Check the upload has finished before claiming, so that calling this too early does not fail the import.
 			f, err := cfg.Gateways.File.ReadImportProjectZip(ctx, base)
 			if err != nil {
-				errMsg := fmt.Sprintf("fail ReadImportProjectZip: %v", err)
-				UpdateImportStatus(ctx, usecases, op, *pid, project.ProjectImportStatusFailed, errMsg, result)
+				if errors.Is(err, rerror.ErrNotFound) {
+					return nil, echo.NewHTTPError(http.StatusNotFound, "uploaded file not found")
+				}
 				return nil, err
 			}
-			defer f.Close()
+			_ = f.Close()
 
-			// Every failure past this point records a terminal status before
-			// returning. Leaving the claim PROCESSING would make the redelivery
-			// skip the import and acknowledge the message, so it would never run.
-			tmpfile, err := os.CreateTemp("", "import-*.zip")
-			if err != nil {
-				errMsg := fmt.Sprintf("failed to create temp file: %v", err)
-				UpdateImportStatus(ctx, usecases, op, *pid, project.ProjectImportStatusFailed, errMsg, result)
-				return nil, errors.New(errMsg)
-			}
-			defer os.Remove(tmpfile.Name())
-			defer tmpfile.Close()
+			return importUploadedProject(ctx, cfg, usecases, op, base, workspaceID, projectID)
+		}),
+	)
 
-			if _, err := io.Copy(tmpfile, f); err != nil {
-				errMsg := fmt.Sprintf("failed to copy to temp file: %v", err)
-				UpdateImportStatus(ctx, usecases, op, *pid, project.ProjectImportStatusFailed, errMsg, result)
-				return nil, errors.New(errMsg)
-			}
+	// this endpoint receives the uploads to the URLs signed by the local file storage.
+	// It is authorized by the signature instead of the user's token.
+	if signer := newImportUploadSigner(cfg.Config); signer != nil {
+		apiRoot.PUT("/import-upload/:name", func(c echo.Context) error {
+			ctx := c.Request().Context()
+			name := c.Param("name")
 
-			if _, err := tmpfile.Seek(0, io.SeekStart); err != nil {
-				errMsg := fmt.Sprintf("failed to seek: %v", err)
-				UpdateImportStatus(ctx, usecases, op, *pid, project.ProjectImportStatusFailed, errMsg, result)
-				return nil, errors.New(errMsg)
+			if err := signer.Verify(name, c.QueryParam("expires"), c.QueryParam("signature"), time.Now()); err != nil {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
 			}
 
-			// A complete local copy exists from here on.
-			removeZip = true
-
-			currentHost := adapter.CurrentHost(ctx)
-			importData, assetsZip, pluginsZip, version, err := file_.UncompressExportZip(currentHost, tmpfile)
-			if err != nil {
-				errMsg := fmt.Sprintf("fail UncompressExportZip: %v", err)
-				UpdateImportStatus(ctx, usecases, op, *pid, project.ProjectImportStatusFailed, errMsg, result)
-				if errors.Is(err, zip.ErrFormat) || errors.Is(err, zip.ErrAlgorithm) || errors.Is(err, zip.ErrChecksum) {
-					// Corrupt or invalid zip — retrying will never fix it, acknowledge to stop Pub/Sub retries
-					return map[string]string{"status": "unrecoverable", "reason": errMsg}, nil
-				}
-				// I/O or other transient error — return 500 to allow Pub/Sub to retry
-				return nil, errors.New(errMsg)
+			if err := cfg.Gateways.File.UploadImportProjectZip(ctx, name, c.Request().Body); err != nil {
+				log.Errorfc(ctx, "[Import] fail UploadImportProjectZip: %v", err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "failed to upload file")
 			}
-			// EXPORT_DATA_VERSION
-			ok := ImportProject(
-				ctx,
-				usecases,
-				op,
-				*wid,
-				*pid,
-				importData,
-				assetsZip,
-				pluginsZip,
-				result,
-				version,
-			)
 
-			if ok {
-				return "finish", nil
-			}
-			return "failure", nil
-		}),
-	)
+			return c.NoContent(http.StatusOK)
+		})
+	}
 
 	// this endpoint is called from Pub/Sub push subscription triggered by GCS event
 	// OIDC token authentication is handled by the push subscription
@@ -226,7 +200,7 @@ func servSignatureUploadFiles(
 			removeZip := false
 			defer func() {
 				if removeZip {
-					removeGcsZip(ctx, cfg.Gateways.File, base)
+					removeImportZip(ctx, cfg.Gateways.File, base)
 				}
 			}()
 
@@ -317,9 +291,123 @@ func servSignatureUploadFiles(
 
 }
 
-func removeGcsZip(ctx context.Context, fileGateway gateway.File, name string) {
+func removeImportZip(ctx context.Context, fileGateway gateway.File, name string) {
 	log.Infof("[Import] remove file", name)
 	if err := fileGateway.RemoveImportProjectZip(ctx, name); err != nil {
 		log.Errorf("fail RemoveImportProjectZip: %v", err)
 	}
 }
+
+// importUploadedProject imports the project from the zip uploaded to the signed URL.
+func importUploadedProject(
+	ctx context.Context,
+	cfg *ServerConfig,
+	usecases *interfaces.Container,
+	op *usecase.Operator,
+	base string,
+	wid accountsID.WorkspaceID,
+	pid id.ProjectID,
+) (any, error) {
+	claimed, err := usecases.Project.ClaimImport(ctx, pid, op)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		log.Infof("[Import] skipping %s: already succeeded or in progress", pid.String())
+		return map[string]string{"status": "skipped", "reason": "already processed or in progress"}, nil
+	}
+
+	// The uploaded zip is the only copy of the import input. It is kept
+	// until a complete local copy exists, so that a redelivery after a
+	// failed download still has something to read. Once the copy is
+	// complete, re-downloading cannot change the outcome, so it is
+	// removed as before regardless of how the import ends.
+	removeZip := false
+	defer func() {
+		if removeZip {
+			removeImportZip(ctx, cfg.Gateways.File, base)
+		}
+	}()
+
+	result := map[string]any{}
+
+	f, err := cfg.Gateways.File.ReadImportProjectZip(ctx, base)
+	if err != nil {
+		errMsg := fmt.Sprintf("fail ReadImportProjectZip: %v", err)
+		UpdateImportStatus(ctx, usecases, op, pid, project.ProjectImportStatusFailed, errMsg, result)
+		return nil, err
+	}
+	defer f.Close()
+
+	// Every failure past this point records a terminal status before
+	// returning. Leaving the claim PROCESSING would make the redelivery
+	// skip the import and acknowledge the message, so it would never run.
+	tmpfile, err := os.CreateTemp("", "import-*.zip")
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to create temp file: %v", err)
+		UpdateImportStatus(ctx, usecases, op, pid, project.ProjectImportStatusFailed, errMsg, result)
+		return nil, errors.New(errMsg)
+	}
+	defer os.Remove(tmpfile.Name())
+	defer tmpfile.Close()
+
+	if _, err := io.Copy(tmpfile, f); err != nil {
+		errMsg := fmt.Sprintf("failed to copy to temp file: %v", err)
+		UpdateImportStatus(ctx, usecases, op, pid, project.ProjectImportStatusFailed, errMsg, result)
+		return nil, errors.New(errMsg)
+	}
+
+	if _, err := tmpfile.Seek(0, io.SeekStart); err != nil {
+		errMsg := fmt.Sprintf("failed to seek: %v", err)
+		UpdateImportStatus(ctx, usecases, op, pid, project.ProjectImportStatusFailed, errMsg, result)
+		return nil, errors.New(errMsg)
+	}
+
+	// A complete local copy exists from here on.
+	removeZip = true
+
+	currentHost := adapter.CurrentHost(ctx)
+	importData, assetsZip, pluginsZip, version, err := file_.UncompressExportZip(currentHost, tmpfile)
+	if err != nil {
+		errMsg := fmt.Sprintf("fail UncompressExportZip: %v", err)
+		UpdateImportStatus(ctx, usecases, op, pid, project.ProjectImportStatusFailed, errMsg, result)
+		if errors.Is(err, zip.ErrFormat) || errors.Is(err, zip.ErrAlgorithm) || errors.Is(err, zip.ErrChecksum) {
+			// Corrupt or invalid zip — retrying will never fix it, acknowledge to stop Pub/Sub retries
+			return map[string]string{"status": "unrecoverable", "reason": errMsg}, nil
+		}
+		// I/O or other transient error — return 500 to allow Pub/Sub to retry
+		return nil, errors.New(errMsg)
+	}
+	// EXPORT_DATA_VERSION
+	ok := ImportProject(
+		ctx,
+		usecases,
+		op,
+		wid,
+		pid,
+		importData,
+		assetsZip,
+		pluginsZip,
+		result,
+		version,
+	)
+
+	if ok {
+		return "finish", nil
+	}
+	return "failure", nil
+}
+
+// newImportUploadSigner returns the signer of the URLs of /api/import-upload,
+// which is only used when neither GCS nor S3 is configured.
+func newImportUploadSigner(conf *config.Config) *fs.UploadSigner {
+	if conf == nil || conf.GCS.IsConfigured() || conf.S3.IsConfigured() || conf.FS.SignedUploadSecret == "" {
+		return nil
+	}
+	signer, err := fs.NewUploadSigner(strings.TrimSuffix(conf.Host, "/")+"/api/import-upload", conf.FS.SignedUploadSecret)
+	if err != nil {
+		log.Warnf("file: failed to init upload signer: %v", err)
+		return nil
+	}
+	return signer
+}
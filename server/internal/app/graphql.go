@@ -3,6 +3,10 @@ package app
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -10,6 +14,7 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth-accounts/server/pkg/gqlclient"
 	"github.com/reearth/reearth/server/internal/adapter"
@@ -35,7 +40,9 @@ const (
 	maxMemorySize     = 100 * 1024 * 1024       // 100MB
 )
 
-func GraphqlAPI(conf config.GraphQLConfig, accountsAPIClient *gqlclient.Client, dev bool) echo.HandlerFunc {
+// GraphqlAPI serves the GraphQL API. The subscriptions are served over WebSocket, which accepts the connections from
+// allowedOrigins and authenticates them with websocketInit when it is set.
+func GraphqlAPI(conf config.GraphQLConfig, accountsAPIClient *gqlclient.Client, dev bool, allowedOrigins []string, websocketInit transport.WebsocketInitFunc) echo.HandlerFunc {
 
 	schema := gql.NewExecutableSchema(gql.Config{
 		Resolvers: gql.NewResolver(accountsAPIClient),
@@ -45,6 +52,10 @@ func GraphqlAPI(conf config.GraphQLConfig, accountsAPIClient *gqlclient.Client,
 
 	srv.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: websocketCheckOrigin(allowedOrigins),
+		},
+		InitFunc: websocketInit,
 	})
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
@@ -109,6 +120,22 @@ func GraphqlAPI(conf config.GraphQLConfig, accountsAPIClient *gqlclient.Client,
 	}
 }
 
+// websocketCheckOrigin allows the WebSocket connections from the same origin and the allowed origins.
+// The connections without the Origin header do not come from browsers and are allowed.
+func websocketCheckOrigin(allowedOrigins []string) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if slices.Contains(allowedOrigins, "*") || slices.Contains(allowedOrigins, origin) {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
 // isHandledError returns true for errors that represent expected user-facing failures
 // (not found, operation denied) which should be logged at WARN rather than ERROR.
 func isHandledError(e error) bool {
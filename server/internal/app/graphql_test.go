@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/reearth/reearth/server/internal/adapter"
@@ -108,3 +110,21 @@ func TestIsHandledError(t *testing.T) {
 		assert.False(t, isHandledError(rerror.ErrNotImplemented))
 	})
 }
+
+func TestWebsocketCheckOrigin(t *testing.T) {
+	check := websocketCheckOrigin([]string{"https://app.example.com"})
+	req := func(origin string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "https://api.example.com/api/graphql", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return r
+	}
+
+	assert.True(t, check(req("https://app.example.com")))
+	assert.True(t, check(req("https://api.example.com")))
+	assert.True(t, check(req("")))
+	assert.False(t, check(req("https://evil.example.com")))
+	assert.False(t, websocketCheckOrigin(nil)(req("https://app.example.com")))
+	assert.True(t, websocketCheckOrigin([]string{"*"})(req("https://evil.example.com")))
+}
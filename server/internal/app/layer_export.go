@@ -0,0 +1,64 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth/server/internal/adapter"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer/encoding"
+)
+
+func servLayerExport(apiPrivate *echo.Group) {
+	apiPrivate.GET("/layers/:layerId/export.czml", func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		lid, err := id.NLSLayerIDFrom(c.Param("layerId"))
+		if err != nil {
+			return echo.ErrBadRequest
+		}
+
+		uc := adapter.Usecases(ctx)
+		op := adapter.Operator(ctx)
+		packets, err := uc.NLSLayer.ExportCZML(ctx, lid, op)
+		if err != nil {
+			return layerExportError(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"%s.czml\"", lid))
+		return c.JSON(http.StatusOK, packets)
+	})
+
+	apiPrivate.GET("/layers/:layerId/export.shp.zip", func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		lid, err := id.NLSLayerIDFrom(c.Param("layerId"))
+		if err != nil {
+			return echo.ErrBadRequest
+		}
+
+		uc := adapter.Usecases(ctx)
+		op := adapter.Operator(ctx)
+		buf := &bytes.Buffer{}
+		if err := uc.NLSLayer.ExportShapefile(ctx, lid, buf, op); err != nil {
+			return layerExportError(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"%s.zip\"", lid))
+		return c.Stream(http.StatusOK, "application/zip", buf)
+	})
+}
+
+func layerExportError(err error) error {
+	if errors.Is(err, interfaces.ErrOperationDenied) {
+		return echo.ErrForbidden
+	}
+	if errors.Is(err, encoding.ErrNotSketchLayer) || errors.Is(err, encoding.ErrNoShapeFeature) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return err
+}
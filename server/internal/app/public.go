@@ -5,9 +5,11 @@ import (
 	"crypto/subtle"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
@@ -20,6 +22,7 @@ import (
 	http1 "github.com/reearth/reearth/server/internal/adapter/http"
 	"github.com/reearth/reearth/server/internal/app/config"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/publication"
 	"github.com/reearth/reearthx/rerror"
 )
 
@@ -123,9 +126,21 @@ func PublishedData(pattern string, useParam bool) echo.HandlerFunc {
 			return err
 		}
 
-		r, err := contr.Data(c.Request().Context(), alias)
-		if err != nil {
-			return err
+		var r io.Reader
+		if v := c.QueryParam("version"); v != "" {
+			version, err := strconv.Atoi(v)
+			if err != nil || version <= 0 {
+				return echo.ErrBadRequest
+			}
+			r, err = contr.DataVersion(c.Request().Context(), alias, version)
+			if err != nil {
+				return err
+			}
+		} else {
+			r, err = contr.Data(c.Request().Context(), alias)
+			if err != nil {
+				return err
+			}
 		}
 
 		return c.Stream(http.StatusOK, "application/json", r)
@@ -266,7 +281,7 @@ func PublishedAuthMiddleware() echo.MiddlewareFunc {
 				// produces a 401 challenge instead of a confusing 404.
 				return false, nil
 			}
-			return !md.IsBasicAuthActive || subtle.ConstantTimeCompare([]byte(user), []byte(md.BasicAuthUsername)) == 1 && subtle.ConstantTimeCompare([]byte(password), []byte(md.BasicAuthPassword)) == 1, nil
+			return !md.IsBasicAuthActive || subtle.ConstantTimeCompare([]byte(user), []byte(md.BasicAuthUsername)) == 1 && publication.MatchBasicAuthPassword(md.BasicAuthPassword, password), nil
 		},
 		Skipper: func(c echo.Context) bool {
 			name := c.Param("name")
@@ -298,6 +313,67 @@ func PublishedAuthMiddleware() echo.MiddlewareFunc {
 	})
 }
 
+const (
+	shareTokenQueryParam   = "token"
+	shareTokenHeader       = "X-Reearth-Share-Token"
+	shareTokenCookiePrefix = "reearth_share_token_"
+)
+
+// PublishedShareTokenMiddleware requires a valid share token to access a publication with the limited status.
+// The token is read from the query, the header or the cookie, and it is stored in the cookie once it is accepted
+// so that the viewer can load the data of the publication after the page is opened with the shared link.
+func PublishedShareTokenMiddleware(pattern string, useParam bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			alias := resolveAlias(c, pattern, useParam)
+			if alias == "" {
+				return next(c)
+			}
+
+			contr, err := publishedController(c)
+			if err != nil {
+				return err
+			}
+
+			token, fromCookie := shareTokenFromRequest(c, alias)
+			if err := contr.ValidateShareToken(c.Request().Context(), alias, token); err != nil {
+				if errors.Is(err, interfaces.ErrShareTokenRequired) {
+					return echo.ErrUnauthorized
+				}
+				if errors.Is(err, interfaces.ErrInvalidShareToken) {
+					return echo.ErrForbidden
+				}
+				return err
+			}
+
+			if token != "" && !fromCookie {
+				c.SetCookie(&http.Cookie{
+					Name:     shareTokenCookiePrefix + alias,
+					Value:    token,
+					Path:     "/",
+					HttpOnly: true,
+					Secure:   c.Scheme() == "https",
+					SameSite: http.SameSiteLaxMode,
+				})
+			}
+			return next(c)
+		}
+	}
+}
+
+func shareTokenFromRequest(c echo.Context, alias string) (string, bool) {
+	if t := c.QueryParam(shareTokenQueryParam); t != "" {
+		return t, false
+	}
+	if t := c.Request().Header.Get(shareTokenHeader); t != "" {
+		return t, false
+	}
+	if cookie, err := c.Cookie(shareTokenCookiePrefix + alias); err == nil && cookie.Value != "" {
+		return cookie.Value, true
+	}
+	return "", false
+}
+
 func publishedController(c echo.Context) (*http1.PublishedController, error) {
 	uc := adapter.Usecases(c.Request().Context())
 	if uc.Published == nil {
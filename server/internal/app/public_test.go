@@ -109,10 +109,101 @@ func TestPublishedAuthMiddleware(t *testing.T) {
 	}
 }
 
+func TestPublishedShareTokenMiddleware(t *testing.T) {
+	tests := []struct {
+		Name          string
+		PublishedName string
+		Query         string
+		Header        string
+		Cookie        string
+		Error         error
+		SetCookie     bool
+	}{
+		{
+			Name: "empty name",
+		},
+		{
+			Name:          "not limited",
+			PublishedName: "prj",
+		},
+		{
+			Name:          "no token",
+			PublishedName: "limited",
+			Error:         echo.ErrUnauthorized,
+		},
+		{
+			Name:          "invalid token",
+			PublishedName: "limited",
+			Query:         "xxx",
+			Error:         echo.ErrForbidden,
+		},
+		{
+			Name:          "token in query",
+			PublishedName: "limited",
+			Query:         "tkn",
+			SetCookie:     true,
+		},
+		{
+			Name:          "token in header",
+			PublishedName: "limited",
+			Header:        "tkn",
+			SetCookie:     true,
+		},
+		{
+			Name:          "token in cookie",
+			PublishedName: "limited",
+			Cookie:        "tkn",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := assert.New(t)
+			target := "/"
+			if tc.Query != "" {
+				target += "?token=" + tc.Query
+			}
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			if tc.Header != "" {
+				req.Header.Set(shareTokenHeader, tc.Header)
+			}
+			if tc.Cookie != "" {
+				req.AddCookie(&http.Cookie{Name: shareTokenCookiePrefix + tc.PublishedName, Value: tc.Cookie})
+			}
+			res := httptest.NewRecorder()
+			e := echo.New()
+			c := e.NewContext(req, res)
+			c.SetParamNames("name")
+			c.SetParamValues(tc.PublishedName)
+			m := mockPublishedUsecaseMiddleware(false)
+
+			err := m(PublishedShareTokenMiddleware("", true)(func(c echo.Context) error {
+				return c.String(http.StatusOK, "test")
+			}))(c)
+			if tc.Error != nil {
+				assert.ErrorIs(err, tc.Error)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal("test", res.Body.String())
+			if tc.SetCookie {
+				assert.Contains(res.Header().Get(echo.HeaderSetCookie), shareTokenCookiePrefix+tc.PublishedName+"=tkn")
+			} else {
+				assert.Empty(res.Header().Get(echo.HeaderSetCookie))
+			}
+		})
+	}
+}
+
 func TestPublishedData(t *testing.T) {
 	tests := []struct {
 		Name          string
 		PublishedName string
+		Query         string
+		Body          string
 		Error         error
 	}{
 		{
@@ -127,6 +218,25 @@ func TestPublishedData(t *testing.T) {
 		{
 			Name:          "ok",
 			PublishedName: "prj",
+			Body:          "aaa",
+		},
+		{
+			Name:          "version",
+			PublishedName: "prj",
+			Query:         "?version=1",
+			Body:          "v1",
+		},
+		{
+			Name:          "version not found",
+			PublishedName: "prj",
+			Query:         "?version=2",
+			Error:         rerror.ErrNotFound,
+		},
+		{
+			Name:          "invalid version",
+			PublishedName: "prj",
+			Query:         "?version=a",
+			Error:         echo.ErrBadRequest,
 		},
 	}
 
@@ -136,7 +246,7 @@ func TestPublishedData(t *testing.T) {
 			t.Parallel()
 
 			assert := assert.New(t)
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req := httptest.NewRequest(http.MethodGet, "/"+tc.Query, nil)
 			res := httptest.NewRecorder()
 			e := echo.New()
 			c := e.NewContext(req, res)
@@ -149,7 +259,7 @@ func TestPublishedData(t *testing.T) {
 				assert.NoError(err)
 				assert.Equal(http.StatusOK, res.Code)
 				assert.Equal("application/json", res.Header().Get(echo.HeaderContentType))
-				assert.Equal("aaa", res.Body.String())
+				assert.Equal(tc.Body, res.Body.String())
 			} else {
 				assert.ErrorIs(err, tc.Error)
 			}
@@ -328,6 +438,13 @@ func (p *mockPublished) Data(ctx context.Context, name string) (io.Reader, error
 	return nil, rerror.ErrNotFound
 }
 
+func (p *mockPublished) DataVersion(ctx context.Context, name string, version int) (io.Reader, error) {
+	if name == "prj" && version == 1 {
+		return strings.NewReader("v1"), nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
 func (p *mockPublished) Index(ctx context.Context, name string, url *url.URL) (string, error) {
 	if p.EmptyIndex {
 		return "", nil
@@ -338,6 +455,19 @@ func (p *mockPublished) Index(ctx context.Context, name string, url *url.URL) (s
 	return "", rerror.ErrNotFound
 }
 
+func (p *mockPublished) ValidateShareToken(ctx context.Context, name, token string) error {
+	if name != "limited" {
+		return nil
+	}
+	if token == "" {
+		return interfaces.ErrShareTokenRequired
+	}
+	if token != "tkn" {
+		return interfaces.ErrInvalidShareToken
+	}
+	return nil
+}
+
 func TestGetAliasFromHost(t *testing.T) {
 	assert.Equal(t, "", getAliasFromHost("", ".example.com")) // invalid regexp
 	assert.Equal(t, "", getAliasFromHost("", "{}.example.com"))
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearthx/log"
+)
+
+// startPublishScheduler periodically publishes and unpublishes the projects and the stories whose schedules are due.
+func startPublishScheduler(ctx context.Context, uc interfaces.PublishSchedule, interval time.Duration) {
+	startPeriodicJob(ctx, "publish scheduler", interval, uc.RunDue)
+}
+
+// startPeriodicJob runs the job every interval in the background until ctx is done.
+// A non-positive interval disables the job. Errors are logged and do not stop the job.
+func startPeriodicJob(ctx context.Context, name string, interval time.Duration, run func(context.Context, time.Time) error) {
+	if interval <= 0 {
+		log.Infof("%s: disabled", name)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if err := run(ctx, now); err != nil {
+					log.Errorfc(ctx, "%s: %v", name, err)
+				}
+			}
+		}
+	}()
+	log.Infof("%s: started with interval %s", name, interval)
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartPeriodicJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+	startPeriodicJob(ctx, "test job", time.Millisecond, func(context.Context, time.Time) error {
+		runs.Add(1)
+		return errors.New("failed")
+	})
+
+	// errors do not stop the job
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, time.Millisecond)
+
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	stopped := runs.Load()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestStartPeriodicJob_Disabled(t *testing.T) {
+	var runs atomic.Int32
+	startPeriodicJob(context.Background(), "test job", 0, func(context.Context, time.Time) error {
+		runs.Add(1)
+		return nil
+	})
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Zero(t, runs.Load())
+}
@@ -17,7 +17,9 @@ import (
 	"github.com/reearth/reearth/server/internal/infrastructure/marketplace"
 	mongorepo "github.com/reearth/reearth/server/internal/infrastructure/mongo"
 	"github.com/reearth/reearth/server/internal/infrastructure/policy"
+	"github.com/reearth/reearth/server/internal/infrastructure/pubsub"
 	"github.com/reearth/reearth/server/internal/infrastructure/s3"
+	"github.com/reearth/reearth/server/internal/infrastructure/webhook"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 
@@ -153,6 +155,12 @@ func initReposAndGateways(ctx context.Context, conf *config.Config, debug bool)
 		gateways.PluginRegistry = marketplace.New(conf.Marketplace.Endpoint, conf.Marketplace.Secret, conf.Marketplace.OAuth.Config())
 	}
 
+	// webhook
+	gateways.WebhookSender = webhook.NewHTTPSender(conf.Webhook.Timeout)
+
+	// scene events for the GraphQL subscriptions, which only reach the editors connected to this instance
+	gateways.PubSub = pubsub.NewMemory()
+
 	// release lock of all scenes
 	if err := visRepos.SceneLock.ReleaseAllLock(context.Background()); err != nil {
 		log.Fatalf("repo initialization error: %v", err)
@@ -176,7 +184,13 @@ func initFile(ctx context.Context, conf *config.Config) (fileRepo gateway.File)
 
 	if conf.S3.IsConfigured() {
 		log.Infofc(ctx, "[Storage] S3 storage is used: %s", conf.S3.BucketName)
-		fileRepo, err = s3.NewS3(ctx, conf.S3.BucketName, conf.AssetBaseURL, conf.S3.PublicationCacheControl)
+		fileRepo, err = s3.NewS3(
+			ctx,
+			conf.S3.BucketName,
+			conf.AssetBaseURL,
+			conf.S3.PublicationCacheControl,
+			s3.WithEndpoint(conf.S3.Endpoint, conf.S3.UsePathStyle),
+		)
 		if err != nil {
 			log.Warnf("file: failed to init S3 storage: %s\n", err.Error())
 		}
@@ -185,7 +199,7 @@ func initFile(ctx context.Context, conf *config.Config) (fileRepo gateway.File)
 
 	log.Infof("[Storage] local afero storage is used")
 	afs := afero.NewBasePathFs(afero.NewOsFs(), "tmp/afero")
-	fileRepo, err = fs.NewFile(afs, conf.AssetBaseURL)
+	fileRepo, err = fs.NewFileWithUploadSigner(afs, conf.AssetBaseURL, newImportUploadSigner(conf))
 	if err != nil {
 		log.Fatalf("file: init error: %+v", err)
 	}
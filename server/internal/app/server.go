@@ -13,6 +13,7 @@ import (
 	"github.com/reearth/reearth/server/internal/app/config"
 	"github.com/reearth/reearth/server/internal/app/otel"
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interactor"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 
 	"github.com/reearth/reearthx/log"
@@ -22,6 +23,14 @@ import (
 
 func runServer(ctx context.Context, conf *config.Config, otelServiceName otel.OtelServiceName, debug bool) {
 	repos, gateways, acRepos, acGateways, accountsAPIClient := initReposAndGateways(ctx, conf, debug)
+
+	// the internal API server shares the database, so only the main server runs the publish schedules, the trash purge and the webhook deliveries
+	if !conf.Visualizer.InternalApi.Active {
+		startPublishScheduler(ctx, interactor.NewPublishSchedule(repos, gateways), conf.Published.ScheduleInterval)
+		startTrashPurger(ctx, interactor.NewTrash(repos, gateways, conf.Trash.Retention), conf.Trash.PurgeInterval)
+		startWebhookDispatcher(ctx, interactor.NewWebhook(repos, gateways), conf.Webhook.DeliveryInterval)
+	}
+
 	// Start web server
 	NewServer(ctx, &ServerConfig{
 		Config:            conf,
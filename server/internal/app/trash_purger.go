@@ -0,0 +1,13 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+)
+
+// startTrashPurger periodically purges the projects that have been in the trash longer than the retention of their workspaces.
+func startTrashPurger(ctx context.Context, uc interfaces.Trash, interval time.Duration) {
+	startPeriodicJob(ctx, "trash purger", interval, uc.PurgeExpired)
+}
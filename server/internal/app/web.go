@@ -31,10 +31,10 @@ func (w *WebHandler) Handler(ec *echo.Echo) {
 
 	ec.GET("/api/published/", func(c echo.Context) error { return echo.ErrNotFound })
 	ec.GET("/api/published/:name", PublishedMetadata())
-	ec.GET("/api/published_data/:name", PublishedData(w.HostPattern, true)) // for oss / localhost
+	ec.GET("/api/published_data/:name", PublishedData(w.HostPattern, true), PublishedShareTokenMiddleware(w.HostPattern, true)) // for oss / localhost
 
 	// BasicAuth endpoint
-	publishedGroup := ec.Group("/p", PublishedAuthMiddleware()) // for prod / dev
+	publishedGroup := ec.Group("/p", PublishedAuthMiddleware(), PublishedShareTokenMiddleware(w.HostPattern, true)) // for prod / dev
 
 	publishedGroup.GET("/:name/data.json", PublishedData(w.HostPattern, true))
 	publishedGroup.GET("/:name/", PublishedIndex(w.HostPattern, true))
@@ -84,7 +84,7 @@ func (w *WebHandler) Handler(ec *echo.Echo) {
 	notFound := func(c echo.Context) error { return echo.ErrNotFound }
 
 	ec.GET("/reearth_config.json", WebConfigHandler(w.AuthConfig, w.WebConfig, publishedHost))
-	ec.GET("/data.json", PublishedData(w.HostPattern, false)) // for prod / dev
+	ec.GET("/data.json", PublishedData(w.HostPattern, false), PublishedShareTokenMiddleware(w.HostPattern, false)) // for prod / dev
 
 	if favicon != nil && faviconPath != "" {
 		ec.GET(faviconPath, func(c echo.Context) error {
@@ -95,7 +95,7 @@ func (w *WebHandler) Handler(ec *echo.Echo) {
 	ec.GET("/index.html", func(c echo.Context) error {
 		return c.Redirect(http.StatusPermanentRedirect, "/")
 	})
-	ec.GET("/", notFound, PublishedIndexMiddleware(w.HostPattern, false, w.AppDisabled), static)
+	ec.GET("/", notFound, PublishedShareTokenMiddleware(w.HostPattern, false), PublishedIndexMiddleware(w.HostPattern, false, w.AppDisabled), static)
 	ec.GET("*", notFound, static)
 }
 
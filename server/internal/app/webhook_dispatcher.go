@@ -0,0 +1,13 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+)
+
+// startWebhookDispatcher periodically sends the webhook deliveries whose next attempt is due.
+func startWebhookDispatcher(ctx context.Context, uc interfaces.Webhook, interval time.Duration) {
+	startPeriodicJob(ctx, "webhook dispatcher", interval, uc.DeliverDue)
+}
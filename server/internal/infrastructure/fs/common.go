@@ -5,6 +5,8 @@ const (
 	pluginDir        = "plugins"
 	publishedDir     = "published"
 	storyDir         = "stories"
+	snapshotDir      = "snapshots"
 	exportDir        = "export"
+	importDir        = "import"
 	manifestFilePath = "reearth.yml"
 )
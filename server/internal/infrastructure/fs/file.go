@@ -26,6 +26,7 @@ type fileRepo struct {
 	fs              afero.Fs
 	urlBase         *url.URL
 	baseFileStorage *infrastructure.BaseFileStorage
+	uploadSigner    *UploadSigner
 }
 
 func NewFile(fs afero.Fs, urlBase string) (gateway.File, error) {
@@ -45,6 +46,16 @@ func NewFile(fs afero.Fs, urlBase string) (gateway.File, error) {
 	}, nil
 }
 
+// NewFileWithUploadSigner returns a file gateway that supports signed uploads of project import zips.
+func NewFileWithUploadSigner(fs afero.Fs, urlBase string, signer *UploadSigner) (gateway.File, error) {
+	f, err := NewFile(fs, urlBase)
+	if err != nil {
+		return nil, err
+	}
+	f.(*fileRepo).uploadSigner = signer
+	return f, nil
+}
+
 // asset
 
 func (f *fileRepo) ReadAsset(ctx context.Context, filename string) (io.ReadCloser, error) {
@@ -177,6 +188,21 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, filepath.Join(storyDir, sanitize.Path(name+".json")))
 }
 
+// publication snapshots
+
+func (f *fileRepo) ReadPublicationSnapshot(ctx context.Context, name string) (io.ReadCloser, error) {
+	return f.read(ctx, filepath.Join(snapshotDir, sanitize.Path(name+".json")))
+}
+
+func (f *fileRepo) UploadPublicationSnapshot(ctx context.Context, reader io.Reader, name string) error {
+	_, err := f.upload(ctx, filepath.Join(snapshotDir, sanitize.Path(name+".json")), reader)
+	return err
+}
+
+func (f *fileRepo) RemovePublicationSnapshot(ctx context.Context, name string) error {
+	return f.delete(ctx, filepath.Join(snapshotDir, sanitize.Path(name+".json")))
+}
+
 // export
 
 func (f *fileRepo) ReadExportProjectZip(ctx context.Context, filename string) (io.ReadCloser, error) {
@@ -203,16 +229,52 @@ func (f *fileRepo) RemoveExportProjectZip(ctx context.Context, filename string)
 
 // import
 
-func (f *fileRepo) GenerateSignedUploadUrl(context.Context, string) (*string, int, *string, error) {
-	return nil, 0, nil, nil
+func (f *fileRepo) GenerateSignedUploadUrl(ctx context.Context, filename string) (*string, int, *string, error) {
+	if f.uploadSigner == nil {
+		return nil, 0, nil, gateway.ErrSignedUploadNotSupported
+	}
+	sn := importFileName(filename)
+	if sn == "" {
+		return nil, 0, nil, gateway.ErrInvalidFile
+	}
+
+	contentType := signedUploadContentType
+	signedURL, _ := f.uploadSigner.SignedURL(sn, time.Now())
+	return &signedURL, signedUploadExpiresIn, &contentType, nil
 }
 
-func (f *fileRepo) ReadImportProjectZip(context.Context, string) (io.ReadCloser, error) {
-	return nil, nil
+func (f *fileRepo) UploadImportProjectZip(ctx context.Context, filename string, content io.Reader) error {
+	sn := importFileName(filename)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, filepath.Join(importDir, sn), content)
+	return err
 }
 
-func (f *fileRepo) RemoveImportProjectZip(context.Context, string) error {
-	return nil
+func (f *fileRepo) ReadImportProjectZip(ctx context.Context, filename string) (io.ReadCloser, error) {
+	sn := importFileName(filename)
+	if sn == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, filepath.Join(importDir, sn))
+}
+
+func (f *fileRepo) RemoveImportProjectZip(ctx context.Context, filename string) error {
+	sn := importFileName(filename)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, filepath.Join(importDir, sn))
+}
+
+// importFileName sanitizes the name of a project import zip. It returns an empty string when the name does not point to a file.
+func importFileName(filename string) string {
+	sn := sanitize.Path(filename)
+	if sn == "." || sn == "/" {
+		return ""
+	}
+	return sn
 }
 
 // helpers
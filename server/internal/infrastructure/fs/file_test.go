@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/reearth/reearth/server/internal/testutil"
@@ -309,6 +310,50 @@ func TestFile_RemoveBuiltScene(t *testing.T) {
 	assert.ErrorIs(t, err, os.ErrNotExist)
 }
 
+func TestFile_GenerateSignedUploadUrl(t *testing.T) {
+	f, _ := NewFile(mockFs(), "")
+	_, _, _, err := f.GenerateSignedUploadUrl(context.Background(), "a.zip")
+	assert.ErrorIs(t, err, gateway.ErrSignedUploadNotSupported)
+
+	signer, _ := NewUploadSigner("https://example.com/api/import-upload", "secret")
+	f, _ = NewFileWithUploadSigner(mockFs(), "", signer)
+	u, expires, contentType, err := f.GenerateSignedUploadUrl(context.Background(), "a.zip")
+	assert.NoError(t, err)
+	assert.Equal(t, 15, expires)
+	assert.Equal(t, "application/octet-stream", *contentType)
+
+	pu, err := url.Parse(*u)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/import-upload/a.zip", pu.Path)
+	assert.NoError(t, signer.Verify("a.zip", pu.Query().Get("expires"), pu.Query().Get("signature"), time.Now()))
+}
+
+func TestFile_ImportProjectZip(t *testing.T) {
+	fs := mockFs()
+	f, _ := NewFile(fs, "")
+	ctx := context.Background()
+
+	err := f.UploadImportProjectZip(ctx, "a.zip", strings.NewReader("zip"))
+	assert.NoError(t, err)
+
+	r, err := f.ReadImportProjectZip(ctx, "a.zip")
+	assert.NoError(t, err)
+	c, _ := io.ReadAll(r)
+	assert.Equal(t, "zip", string(c))
+	assert.NoError(t, r.Close())
+
+	_, err = f.ReadImportProjectZip(ctx, "")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	assert.ErrorIs(t, f.RemoveImportProjectZip(ctx, ""), gateway.ErrInvalidFile)
+
+	assert.NoError(t, f.RemoveImportProjectZip(ctx, "a.zip"))
+	_, err = fs.Stat(filepath.Join("import", "a.zip"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = f.ReadImportProjectZip(ctx, "a.zip")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
 func TestGetAssetFileURL(t *testing.T) {
 	e, err := url.Parse("http://hoge.com/assets/xxx.yyy")
 	assert.NoError(t, err)
@@ -0,0 +1,81 @@
+package fs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	signedUploadContentType = "application/octet-stream"
+	signedUploadExpiresIn   = 15 // minutes
+)
+
+var (
+	ErrInvalidUploadSignature = errors.New("invalid upload signature")
+	ErrUploadSignatureExpired = errors.New("upload signature expired")
+)
+
+// UploadSigner issues and verifies URLs of the endpoint that receives files uploaded directly to the local storage,
+// which plays the role of the signed URLs of GCS and S3.
+type UploadSigner struct {
+	base   *url.URL
+	secret []byte
+}
+
+func NewUploadSigner(baseURL, secret string) (*UploadSigner, error) {
+	if secret == "" {
+		return nil, errors.New("upload signer secret is empty")
+	}
+	b, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errors.New("invalid upload base URL")
+	}
+	return &UploadSigner{base: b, secret: []byte(secret)}, nil
+}
+
+// SignedURL returns the URL to upload the file and the time the URL expires at.
+func (s *UploadSigner) SignedURL(name string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(time.Duration(signedUploadExpiresIn) * time.Minute)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	// https://github.com/golang/go/issues/38351
+	u := *s.base
+	u.Path = path.Join(u.Path, name)
+	u.RawQuery = url.Values{
+		"expires":   {expires},
+		"signature": {s.sign(name, expires)},
+	}.Encode()
+	return u.String(), expiresAt
+}
+
+// Verify checks the expires and signature query parameters of an upload request of the file.
+func (s *UploadSigner) Verify(name, expires, signature string, now time.Time) error {
+	sig, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, s.mac(name, expires)) {
+		return ErrInvalidUploadSignature
+	}
+	e, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidUploadSignature
+	}
+	if now.After(time.Unix(e, 0)) {
+		return ErrUploadSignatureExpired
+	}
+	return nil
+}
+
+func (s *UploadSigner) sign(name, expires string) string {
+	return hex.EncodeToString(s.mac(name, expires))
+}
+
+func (s *UploadSigner) mac(name, expires string) []byte {
+	m := hmac.New(sha256.New, s.secret)
+	_, _ = m.Write([]byte(name + "\n" + expires))
+	return m.Sum(nil)
+}
@@ -0,0 +1,59 @@
+package fs
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadSigner(t *testing.T) {
+	_, err := NewUploadSigner("https://example.com/api/import-upload", "")
+	assert.Error(t, err)
+
+	s, err := NewUploadSigner("https://example.com/api/import-upload", "secret")
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signed, expiresAt := s.SignedURL("a.zip", now)
+	assert.Equal(t, now.Add(15*time.Minute), expiresAt)
+
+	u, err := url.Parse(signed)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", u.Host)
+	assert.Equal(t, "/api/import-upload/a.zip", u.Path)
+	expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+
+	other, _ := NewUploadSigner("https://example.com/api/import-upload", "other")
+
+	tests := []struct {
+		name      string
+		signer    *UploadSigner
+		file      string
+		expires   string
+		signature string
+		now       time.Time
+		want      error
+	}{
+		{name: "valid", signer: s, file: "a.zip", expires: expires, signature: signature, now: now},
+		{name: "expired", signer: s, file: "a.zip", expires: expires, signature: signature, now: expiresAt.Add(time.Second), want: ErrUploadSignatureExpired},
+		{name: "another file", signer: s, file: "b.zip", expires: expires, signature: signature, now: now, want: ErrInvalidUploadSignature},
+		{name: "extended expiry", signer: s, file: "a.zip", expires: "9999999999", signature: signature, now: now, want: ErrInvalidUploadSignature},
+		{name: "malformed signature", signer: s, file: "a.zip", expires: expires, signature: "xyz", now: now, want: ErrInvalidUploadSignature},
+		{name: "another secret", signer: other, file: "a.zip", expires: expires, signature: signature, now: now, want: ErrInvalidUploadSignature},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.signer.Verify(tt.file, tt.expires, tt.signature, tt.now)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
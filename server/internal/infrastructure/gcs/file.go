@@ -30,6 +30,7 @@ const (
 	gcsPluginBasePath string = "plugins"
 	gcsMapBasePath    string = "maps"
 	gcsStoryBasePath  string = "stories"
+	gcsSnapshotPath   string = "snapshots"
 	gcsExportBasePath string = "export"
 	gcsImportBasePath string = "import"
 )
@@ -283,6 +284,32 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(gcsStoryBasePath, sn))
 }
 
+// publication snapshots
+
+func (f *fileRepo) ReadPublicationSnapshot(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, gateway.ErrInvalidFile
+	}
+	return f.read(ctx, path.Join(gcsSnapshotPath, sanitize.Path(name)+".json"))
+}
+
+func (f *fileRepo) UploadPublicationSnapshot(ctx context.Context, content io.Reader, name string) error {
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(gcsSnapshotPath, sn), content)
+	return err
+}
+
+func (f *fileRepo) RemovePublicationSnapshot(ctx context.Context, name string) error {
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(gcsSnapshotPath, sn))
+}
+
 // export
 
 func (f *fileRepo) ReadExportProjectZip(ctx context.Context, name string) (io.ReadCloser, error) {
@@ -342,6 +369,15 @@ func (f *fileRepo) GenerateSignedUploadUrl(ctx context.Context, filename string)
 	return &signedURL, expiresIn, &contentType, nil
 }
 
+func (f *fileRepo) UploadImportProjectZip(ctx context.Context, name string, content io.Reader) error {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(gcsImportBasePath, sn), content)
+	return err
+}
+
 func (f *fileRepo) ReadImportProjectZip(ctx context.Context, name string) (io.ReadCloser, error) {
 	sn := sanitize.Path(name)
 	if sn == "" {
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type APIToken struct {
+	lock sync.Mutex
+	data map[id.APITokenID]*apitoken.APIToken
+	f    repo.WorkspaceFilter
+}
+
+func NewAPIToken() *APIToken {
+	return &APIToken{
+		data: map[id.APITokenID]*apitoken.APIToken{},
+	}
+}
+
+func NewAPITokenWith(items ...*apitoken.APIToken) repo.APIToken {
+	r := NewAPIToken()
+	for _, i := range items {
+		_ = r.Save(context.Background(), i)
+	}
+	return r
+}
+
+func (r *APIToken) Filtered(f repo.WorkspaceFilter) repo.APIToken {
+	return &APIToken{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *APIToken) FindByID(_ context.Context, id id.APITokenID) (*apitoken.APIToken, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res, ok := r.data[id]
+	if ok && r.f.CanRead(res.Workspace()) {
+		return res, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *APIToken) FindByHash(_ context.Context, hash string) (*apitoken.APIToken, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, t := range r.data {
+		if t.Hash() == hash && r.f.CanRead(t.Workspace()) {
+			return t, nil
+		}
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *APIToken) FindByWorkspace(_ context.Context, wid accountsID.WorkspaceID) ([]*apitoken.APIToken, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+
+	var result []*apitoken.APIToken
+	for _, t := range r.data {
+		if t.Workspace() == wid {
+			result = append(result, t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID().Compare(result[j].ID()) > 0
+	})
+	return result, nil
+}
+
+func (r *APIToken) Save(_ context.Context, t *apitoken.APIToken) error {
+	if !r.f.CanWrite(t.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[t.ID()] = t
+	return nil
+}
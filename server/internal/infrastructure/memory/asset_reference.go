@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+// AssetReference reads the data of the other memory repos without any filter.
+// The URLs are extracted on every lookup instead of being recorded when the entities are saved.
+type AssetReference struct {
+	property *Property
+	nlsLayer *NLSLayer
+	style    *Style
+	project  *Project
+	scene    *Scene
+}
+
+func NewAssetReference(property *Property, nlsLayer *NLSLayer, style *Style, project *Project, scene *Scene) *AssetReference {
+	return &AssetReference{
+		property: property,
+		nlsLayer: nlsLayer,
+		style:    style,
+		project:  project,
+		scene:    scene,
+	}
+}
+
+func (r *AssetReference) FindByURLs(ctx context.Context, urls []string) (*repo.AssetReferences, error) {
+	res := &repo.AssetReferences{}
+	if len(urls) == 0 {
+		return res, nil
+	}
+	refers := func(values ...any) bool {
+		for _, u := range asset.URLs(values...) {
+			for _, u2 := range urls {
+				if u == u2 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	var sceneIDs id.SceneIDList
+
+	r.project.lock.Lock()
+	for _, p := range r.project.data {
+		var image string
+		if u := p.ImageURL(); u != nil {
+			image = u.String()
+		}
+		if refers(image, p.PublicImage(), p.PublicIconImage()) {
+			res.Projects = append(res.Projects, p)
+		}
+	}
+	r.project.lock.Unlock()
+
+	r.nlsLayer.lock.Lock()
+	for _, l := range r.nlsLayer.data {
+		var values []any
+		if cfg := l.Config(); cfg != nil {
+			values = append(values, map[string]any(*cfg))
+		}
+		if sk := l.Sketch(); sk != nil && sk.FeatureCollection() != nil {
+			for _, f := range sk.FeatureCollection().Features() {
+				if props := f.Properties(); props != nil {
+					values = append(values, map[string]any(*props))
+				}
+			}
+		}
+		if refers(values...) {
+			res.NLSLayers = append(res.NLSLayers, &l)
+			sceneIDs = sceneIDs.AddUniq(l.Scene())
+		}
+	}
+	r.nlsLayer.lock.Unlock()
+
+	r.style.lock.Lock()
+	for _, s := range r.style.data {
+		if v := s.Value(); v != nil && refers(map[string]any(*v)) {
+			res.Styles = append(res.Styles, &s)
+			sceneIDs = sceneIDs.AddUniq(s.Scene())
+		}
+	}
+	r.style.lock.Unlock()
+
+	r.property.lock.Lock()
+	for _, p := range r.property.data {
+		var values []any
+		for _, f := range p.Fields(nil) {
+			values = append(values, f.Value().Interface())
+		}
+		if refers(values...) {
+			res.Properties = append(res.Properties, p)
+			sceneIDs = sceneIDs.AddUniq(p.Scene())
+		}
+	}
+	r.property.lock.Unlock()
+
+	r.scene.lock.Lock()
+	for _, sid := range sceneIDs {
+		if s, ok := r.scene.data[sid]; ok {
+			res.Scenes = append(res.Scenes, s)
+		}
+	}
+	r.scene.lock.Unlock()
+
+	return res, nil
+}
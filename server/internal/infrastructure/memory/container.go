@@ -7,22 +7,38 @@ import (
 )
 
 func New() *repo.Container {
+	nlsLayer := NewNLSLayer()
+	style := NewStyle()
+	project := NewProject().(*Project)
+	property := NewProperty()
+	scene := NewScene()
+
 	return &repo.Container{
-		Asset:           NewAsset(),
-		Config:          NewConfig(),
-		NLSLayer:        NewNLSLayer(),
-		Style:           NewStyle(),
-		Plugin:          NewPlugin(),
-		Project:         NewProject(),
-		ProjectMetadata: NewProjectMetadata(),
-		PropertySchema:  NewPropertySchema(),
-		Property:        NewProperty(),
-		Scene:           NewScene(),
-		Workspace:       accountsInfra.NewMemoryWorkspace(),
-		User:            accountsInfra.NewMemoryUser(),
-		SceneLock:       NewSceneLock(),
-		Storytelling:    NewStorytelling(),
-		Lock:            NewLock(),
-		Transaction:     &usecasex.NopTransaction{},
+		Asset:              NewAsset(),
+		AssetReference:     NewAssetReference(property, nlsLayer, style, project, scene),
+		Config:             NewConfig(),
+		NLSLayer:           nlsLayer,
+		FeatureRevision:    NewFeatureRevision(),
+		Style:              style,
+		Plugin:             NewPlugin(),
+		Project:            project,
+		ProjectMetadata:    NewProjectMetadata(),
+		PropertySchema:     NewPropertySchema(),
+		Property:           property,
+		Scene:              scene,
+		Workspace:          accountsInfra.NewMemoryWorkspace(),
+		User:               accountsInfra.NewMemoryUser(),
+		SceneLock:          NewSceneLock(),
+		Storytelling:       NewStorytelling(),
+		ShareToken:         NewShareToken(),
+		PublishSchedule:    NewPublishSchedule(),
+		PublicationVersion: NewPublicationVersion(),
+		TrashPolicy:        NewTrashPolicy(),
+		TrashPurge:         NewTrashPurge(),
+		APIToken:           NewAPIToken(),
+		Webhook:            NewWebhook(),
+		WebhookDelivery:    NewWebhookDelivery(),
+		Lock:               NewLock(),
+		Transaction:        &usecasex.NopTransaction{},
 	}
 }
@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type FeatureRevision struct {
+	lock sync.Mutex
+	data map[id.FeatureRevisionID]*nlslayer.FeatureRevision
+	f    repo.SceneFilter
+}
+
+func NewFeatureRevision() *FeatureRevision {
+	return &FeatureRevision{
+		data: map[id.FeatureRevisionID]*nlslayer.FeatureRevision{},
+	}
+}
+
+func NewFeatureRevisionWith(items ...*nlslayer.FeatureRevision) repo.FeatureRevision {
+	r := NewFeatureRevision()
+	_ = r.SaveAll(context.Background(), items)
+	return r
+}
+
+func (r *FeatureRevision) Filtered(f repo.SceneFilter) repo.FeatureRevision {
+	return &FeatureRevision{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *FeatureRevision) FindByID(_ context.Context, id id.FeatureRevisionID) (*nlslayer.FeatureRevision, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res, ok := r.data[id]
+	if ok && r.f.CanRead(res.Scene()) {
+		return res, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *FeatureRevision) FindByLayer(_ context.Context, lid id.NLSLayerID, filter repo.FeatureRevisionFilter) ([]*nlslayer.FeatureRevision, *usecasex.PageInfo, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*nlslayer.FeatureRevision
+	for _, rev := range r.data {
+		if rev.Layer() != lid || !r.f.CanRead(rev.Scene()) {
+			continue
+		}
+		if filter.Feature != nil && rev.Feature() != *filter.Feature {
+			continue
+		}
+		result = append(result, rev)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID().Compare(result[j].ID()) > 0
+	})
+
+	total := int64(len(result))
+	start, end := 0, len(result)
+	if p := filter.Pagination; p != nil && p.Offset != nil {
+		start = min(int(p.Offset.Offset), end)
+		if p.Offset.Limit > 0 {
+			end = min(start+int(p.Offset.Limit), end)
+		}
+	} else if p != nil && p.Cursor != nil {
+		if p.Cursor.After != nil {
+			for i, rev := range result {
+				if rev.ID().String() == string(*p.Cursor.After) {
+					start = i + 1
+					break
+				}
+			}
+		}
+		if p.Cursor.First != nil {
+			end = min(start+int(*p.Cursor.First), end)
+		}
+	}
+	result = result[start:end]
+
+	var startCursor, endCursor *usecasex.Cursor
+	if len(result) > 0 {
+		_startCursor := usecasex.Cursor(result[0].ID().String())
+		_endCursor := usecasex.Cursor(result[len(result)-1].ID().String())
+		startCursor = &_startCursor
+		endCursor = &_endCursor
+	}
+
+	return result, usecasex.NewPageInfo(
+		total,
+		startCursor,
+		endCursor,
+		end < int(total),
+		start > 0,
+	), nil
+}
+
+func (r *FeatureRevision) FindByLayerAfter(_ context.Context, lid id.NLSLayerID, after id.FeatureRevisionID) ([]*nlslayer.FeatureRevision, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*nlslayer.FeatureRevision
+	for _, rev := range r.data {
+		if rev.Layer() == lid && r.f.CanRead(rev.Scene()) && rev.ID().Compare(after) > 0 {
+			result = append(result, rev)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID().Compare(result[j].ID()) < 0
+	})
+	return result, nil
+}
+
+func (r *FeatureRevision) SaveAll(_ context.Context, revisions []*nlslayer.FeatureRevision) error {
+	for _, rev := range revisions {
+		if !r.f.CanWrite(rev.Scene()) {
+			return repo.ErrOperationDenied
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, rev := range revisions {
+		r.data[rev.ID()] = rev
+	}
+	return nil
+}
+
+func (r *FeatureRevision) RemoveByLayer(_ context.Context, lid id.NLSLayerID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, rev := range r.data {
+		if rev.Layer() == lid && r.f.CanWrite(rev.Scene()) {
+			delete(r.data, k)
+		}
+	}
+	return nil
+}
+
+func (r *FeatureRevision) RemoveByScene(_ context.Context, sid id.SceneID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, rev := range r.data {
+		if rev.Scene() == sid && r.f.CanWrite(rev.Scene()) {
+			delete(r.data, k)
+		}
+	}
+	return nil
+}
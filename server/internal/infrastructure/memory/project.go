@@ -111,6 +111,28 @@ func (r *Project) FindStarredByWorkspace(ctx context.Context, id accountsID.Work
 	return result, usecasex.NewPageInfo(int64(len(result)), nil, nil, false, false), nil
 }
 
+func (r *Project) FindTemplatesByWorkspace(ctx context.Context, id accountsID.WorkspaceID, _ *usecasex.Pagination) ([]*project.Project, *usecasex.PageInfo, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.f.CanRead(id) {
+		return nil, usecasex.EmptyPageInfo(), nil
+	}
+
+	var result []*project.Project
+	for _, p := range r.data {
+		if p.Workspace() == id && p.IsTemplate() && !p.IsDeleted() && p.CoreSupport() {
+			result = append(result, p)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UpdatedAt().After(result[j].UpdatedAt())
+	})
+
+	return result, usecasex.NewPageInfo(int64(len(result)), nil, nil, false, false), nil
+}
+
 func (r *Project) FindDeletedByWorkspace(ctx context.Context, id accountsID.WorkspaceID, _ *usecasex.Pagination) ([]*project.Project, *usecasex.PageInfo, error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -133,6 +155,24 @@ func (r *Project) FindDeletedByWorkspace(ctx context.Context, id accountsID.Work
 	return result, usecasex.NewPageInfo(int64(len(result)), nil, nil, false, false), nil
 }
 
+func (r *Project) FindDeletedBefore(ctx context.Context, before time.Time) ([]*project.Project, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*project.Project
+	for _, p := range r.data {
+		if r.f.CanRead(p.Workspace()) && p.IsDeleted() && p.DeletedAt() != nil && !p.DeletedAt().After(before) {
+			result = append(result, p)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DeletedAt().Before(*result[j].DeletedAt())
+	})
+
+	return result, nil
+}
+
 func (r *Project) FindActiveById(ctx context.Context, id id.ProjectID) (*project.Project, error) {
 	for _, p := range r.data {
 		if p.ID() == id && !p.IsDeleted() {
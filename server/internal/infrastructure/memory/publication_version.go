@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type PublicationVersion struct {
+	lock sync.Mutex
+	data map[id.PublicationVersionID]*publication.Version
+	f    repo.SceneFilter
+}
+
+func NewPublicationVersion() *PublicationVersion {
+	return &PublicationVersion{
+		data: map[id.PublicationVersionID]*publication.Version{},
+	}
+}
+
+func NewPublicationVersionWith(items ...*publication.Version) repo.PublicationVersion {
+	r := NewPublicationVersion()
+	for _, i := range items {
+		_ = r.Save(context.Background(), i)
+	}
+	return r
+}
+
+func (r *PublicationVersion) Filtered(f repo.SceneFilter) repo.PublicationVersion {
+	return &PublicationVersion{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *PublicationVersion) FindByProject(_ context.Context, pid id.ProjectID) ([]*publication.Version, error) {
+	return r.find(pid.String()), nil
+}
+
+func (r *PublicationVersion) FindByStory(_ context.Context, sid id.StoryID) ([]*publication.Version, error) {
+	return r.find(sid.String()), nil
+}
+
+func (r *PublicationVersion) FindProjectVersion(_ context.Context, pid id.ProjectID, version int) (*publication.Version, error) {
+	return r.findVersion(pid.String(), version)
+}
+
+func (r *PublicationVersion) FindStoryVersion(_ context.Context, sid id.StoryID, version int) (*publication.Version, error) {
+	return r.findVersion(sid.String(), version)
+}
+
+func (r *PublicationVersion) Save(_ context.Context, v *publication.Version) error {
+	if !r.f.CanWrite(v.Scene()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, v2 := range r.data {
+		if v2.ID() != v.ID() && v2.Target() == v.Target() && v2.Version() == v.Version() {
+			return repo.ErrDuplicatedPublicationVersion
+		}
+	}
+	r.data[v.ID()] = v
+	return nil
+}
+
+func (r *PublicationVersion) RemoveByScene(_ context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, v := range r.data {
+		if v.Scene() == sid {
+			delete(r.data, k)
+		}
+	}
+	return nil
+}
+
+func (r *PublicationVersion) findVersion(target string, version int) (*publication.Version, error) {
+	for _, v := range r.find(target) {
+		if v.Version() == version {
+			return v, nil
+		}
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *PublicationVersion) find(target string) []*publication.Version {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*publication.Version
+	for _, v := range r.data {
+		if r.f.CanRead(v.Scene()) && v.Target() == target {
+			result = append(result, v)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version() > result[j].Version()
+	})
+	return result
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type PublishSchedule struct {
+	lock sync.Mutex
+	data map[id.PublishScheduleID]*publication.Schedule
+	f    repo.SceneFilter
+}
+
+func NewPublishSchedule() *PublishSchedule {
+	return &PublishSchedule{
+		data: map[id.PublishScheduleID]*publication.Schedule{},
+	}
+}
+
+func NewPublishScheduleWith(items ...*publication.Schedule) repo.PublishSchedule {
+	r := NewPublishSchedule()
+	for _, i := range items {
+		_ = r.Save(context.Background(), i)
+	}
+	return r
+}
+
+func (r *PublishSchedule) Filtered(f repo.SceneFilter) repo.PublishSchedule {
+	return &PublishSchedule{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *PublishSchedule) FindByID(_ context.Context, id id.PublishScheduleID) (*publication.Schedule, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res, ok := r.data[id]
+	if ok && r.f.CanRead(res.Scene()) {
+		return res, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *PublishSchedule) FindByProject(_ context.Context, pid id.ProjectID) ([]*publication.Schedule, error) {
+	return r.find(func(s *publication.Schedule) bool {
+		return s.Project() != nil && *s.Project() == pid
+	}), nil
+}
+
+func (r *PublishSchedule) FindByStory(_ context.Context, sid id.StoryID) ([]*publication.Schedule, error) {
+	return r.find(func(s *publication.Schedule) bool {
+		return s.Story() != nil && *s.Story() == sid
+	}), nil
+}
+
+func (r *PublishSchedule) FindDue(_ context.Context, now time.Time) ([]*publication.Schedule, error) {
+	result := r.find(func(s *publication.Schedule) bool {
+		return s.IsDue(now)
+	})
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].NextRunAt().Before(*result[j].NextRunAt())
+	})
+	return result, nil
+}
+
+func (r *PublishSchedule) Save(_ context.Context, s *publication.Schedule) error {
+	if !r.f.CanWrite(s.Scene()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[s.ID()] = s
+	return nil
+}
+
+func (r *PublishSchedule) RemoveByScene(_ context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, v := range r.data {
+		if v.Scene() == sid {
+			delete(r.data, k)
+		}
+	}
+	return nil
+}
+
+func (r *PublishSchedule) find(f func(*publication.Schedule) bool) []*publication.Schedule {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*publication.Schedule
+	for _, s := range r.data {
+		if r.f.CanRead(s.Scene()) && f(s) {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID().Compare(result[j].ID()) > 0
+	})
+	return result
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type ShareToken struct {
+	lock sync.Mutex
+	data map[id.ShareTokenID]*publication.ShareToken
+	f    repo.SceneFilter
+}
+
+func NewShareToken() *ShareToken {
+	return &ShareToken{
+		data: map[id.ShareTokenID]*publication.ShareToken{},
+	}
+}
+
+func NewShareTokenWith(items ...*publication.ShareToken) repo.ShareToken {
+	r := NewShareToken()
+	for _, i := range items {
+		_ = r.Save(context.Background(), i)
+	}
+	return r
+}
+
+func (r *ShareToken) Filtered(f repo.SceneFilter) repo.ShareToken {
+	return &ShareToken{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *ShareToken) FindByID(_ context.Context, id id.ShareTokenID) (*publication.ShareToken, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res, ok := r.data[id]
+	if ok && r.f.CanRead(res.Scene()) {
+		return res, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *ShareToken) FindByHash(_ context.Context, hash string) (*publication.ShareToken, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, t := range r.data {
+		if t.Hash() == hash && r.f.CanRead(t.Scene()) {
+			return t, nil
+		}
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *ShareToken) FindByProject(_ context.Context, pid id.ProjectID) ([]*publication.ShareToken, error) {
+	return r.find(func(t *publication.ShareToken) bool {
+		return t.Project() != nil && *t.Project() == pid
+	}), nil
+}
+
+func (r *ShareToken) FindByStory(_ context.Context, sid id.StoryID) ([]*publication.ShareToken, error) {
+	return r.find(func(t *publication.ShareToken) bool {
+		return t.Story() != nil && *t.Story() == sid
+	}), nil
+}
+
+func (r *ShareToken) Save(_ context.Context, t *publication.ShareToken) error {
+	if !r.f.CanWrite(t.Scene()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[t.ID()] = t
+	return nil
+}
+
+func (r *ShareToken) RemoveByScene(_ context.Context, sid id.SceneID) error {
+	if !r.f.CanWrite(sid) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, v := range r.data {
+		if v.Scene() == sid {
+			delete(r.data, k)
+		}
+	}
+	return nil
+}
+
+func (r *ShareToken) find(f func(*publication.ShareToken) bool) []*publication.ShareToken {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*publication.ShareToken
+	for _, t := range r.data {
+		if r.f.CanRead(t.Scene()) && f(t) {
+			result = append(result, t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID().Compare(result[j].ID()) > 0
+	})
+	return result
+}
@@ -69,10 +69,9 @@ func (r *Storytelling) FindByScene(_ context.Context, sId id.SceneID) (*storytel
 	for _, s := range r.data {
 		if s.Scene() == sId {
 			result = append(result, s)
-			continue
 		}
-		result = append(result, nil)
 	}
+	result = result.Sorted()
 	return &result, nil
 }
 
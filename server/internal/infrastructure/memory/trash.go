@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/trash"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type TrashPolicy struct {
+	lock sync.Mutex
+	data map[accountsID.WorkspaceID]*trash.Policy
+	f    repo.WorkspaceFilter
+}
+
+func NewTrashPolicy() *TrashPolicy {
+	return &TrashPolicy{
+		data: map[accountsID.WorkspaceID]*trash.Policy{},
+	}
+}
+
+func (r *TrashPolicy) Filtered(f repo.WorkspaceFilter) repo.TrashPolicy {
+	return &TrashPolicy{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *TrashPolicy) FindByWorkspace(_ context.Context, wid accountsID.WorkspaceID) (*trash.Policy, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res, ok := r.data[wid]
+	if ok && r.f.CanRead(wid) {
+		return res, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *TrashPolicy) FindByWorkspaces(_ context.Context, ids accountsID.WorkspaceIDList) ([]*trash.Policy, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*trash.Policy
+	for _, wid := range ids {
+		if p, ok := r.data[wid]; ok && r.f.CanRead(wid) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (r *TrashPolicy) Save(_ context.Context, p *trash.Policy) error {
+	if !r.f.CanWrite(p.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[p.Workspace()] = p
+	return nil
+}
+
+type TrashPurge struct {
+	lock sync.Mutex
+	data map[id.TrashPurgeID]*trash.Purge
+	f    repo.WorkspaceFilter
+}
+
+func NewTrashPurge() *TrashPurge {
+	return &TrashPurge{
+		data: map[id.TrashPurgeID]*trash.Purge{},
+	}
+}
+
+func (r *TrashPurge) Filtered(f repo.WorkspaceFilter) repo.TrashPurge {
+	return &TrashPurge{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *TrashPurge) FindByWorkspace(_ context.Context, wid accountsID.WorkspaceID) ([]*trash.Purge, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+
+	var result []*trash.Purge
+	for _, p := range r.data {
+		if p.Workspace() == wid {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID().Compare(result[j].ID()) > 0
+	})
+	return result, nil
+}
+
+func (r *TrashPurge) Save(_ context.Context, p *trash.Purge) error {
+	if !r.f.CanWrite(p.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[p.ID()] = p
+	return nil
+}
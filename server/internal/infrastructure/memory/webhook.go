@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type Webhook struct {
+	lock sync.Mutex
+	data map[id.WebhookID]*webhook.Webhook
+	f    repo.WorkspaceFilter
+}
+
+func NewWebhook() *Webhook {
+	return &Webhook{
+		data: map[id.WebhookID]*webhook.Webhook{},
+	}
+}
+
+func NewWebhookWith(items ...*webhook.Webhook) repo.Webhook {
+	r := NewWebhook()
+	for _, i := range items {
+		_ = r.Save(context.Background(), i)
+	}
+	return r
+}
+
+func (r *Webhook) Filtered(f repo.WorkspaceFilter) repo.Webhook {
+	return &Webhook{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *Webhook) FindByID(_ context.Context, id id.WebhookID) (*webhook.Webhook, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res, ok := r.data[id]
+	if ok && r.f.CanRead(res.Workspace()) {
+		return res, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *Webhook) FindByWorkspace(_ context.Context, wid accountsID.WorkspaceID) ([]*webhook.Webhook, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+
+	var result []*webhook.Webhook
+	for _, w := range r.data {
+		if w.Workspace() == wid {
+			result = append(result, w)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID().Compare(result[j].ID()) > 0
+	})
+	return result, nil
+}
+
+func (r *Webhook) Save(_ context.Context, w *webhook.Webhook) error {
+	if !r.f.CanWrite(w.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[w.ID()] = w
+	return nil
+}
+
+func (r *Webhook) Remove(_ context.Context, id id.WebhookID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if w, ok := r.data[id]; ok && r.f.CanWrite(w.Workspace()) {
+		delete(r.data, id)
+		return nil
+	}
+	return rerror.ErrNotFound
+}
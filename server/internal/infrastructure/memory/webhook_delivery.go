@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type WebhookDelivery struct {
+	lock sync.Mutex
+	data map[id.WebhookDeliveryID]*webhook.Delivery
+	f    repo.WorkspaceFilter
+}
+
+func NewWebhookDelivery() *WebhookDelivery {
+	return &WebhookDelivery{
+		data: map[id.WebhookDeliveryID]*webhook.Delivery{},
+	}
+}
+
+func NewWebhookDeliveryWith(items ...*webhook.Delivery) repo.WebhookDelivery {
+	r := NewWebhookDelivery()
+	for _, i := range items {
+		_ = r.Save(context.Background(), i)
+	}
+	return r
+}
+
+func (r *WebhookDelivery) Filtered(f repo.WorkspaceFilter) repo.WebhookDelivery {
+	return &WebhookDelivery{
+		// note data is shared between the source repo and mutex cannot work well
+		data: r.data,
+		f:    r.f.Merge(f),
+	}
+}
+
+func (r *WebhookDelivery) FindByWebhook(_ context.Context, wid id.WebhookID, filter repo.WebhookDeliveryFilter) ([]*webhook.Delivery, *usecasex.PageInfo, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*webhook.Delivery
+	for _, d := range r.data {
+		if d.Webhook() != wid || !r.f.CanRead(d.Workspace()) {
+			continue
+		}
+		if filter.Status != nil && d.Status() != *filter.Status {
+			continue
+		}
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID().Compare(result[j].ID()) > 0
+	})
+
+	total := int64(len(result))
+	start, end := 0, len(result)
+	if p := filter.Pagination; p != nil && p.Offset != nil {
+		start = min(int(p.Offset.Offset), end)
+		if p.Offset.Limit > 0 {
+			end = min(start+int(p.Offset.Limit), end)
+		}
+	} else if p != nil && p.Cursor != nil {
+		if p.Cursor.After != nil {
+			for i, d := range result {
+				if d.ID().String() == string(*p.Cursor.After) {
+					start = i + 1
+					break
+				}
+			}
+		}
+		if p.Cursor.First != nil {
+			end = min(start+int(*p.Cursor.First), end)
+		}
+	}
+	result = result[start:end]
+
+	var startCursor, endCursor *usecasex.Cursor
+	if len(result) > 0 {
+		_startCursor := usecasex.Cursor(result[0].ID().String())
+		_endCursor := usecasex.Cursor(result[len(result)-1].ID().String())
+		startCursor = &_startCursor
+		endCursor = &_endCursor
+	}
+
+	return result, usecasex.NewPageInfo(
+		total,
+		startCursor,
+		endCursor,
+		end < int(total),
+		start > 0,
+	), nil
+}
+
+func (r *WebhookDelivery) FindDue(_ context.Context, now time.Time, limit int) ([]*webhook.Delivery, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result []*webhook.Delivery
+	for _, d := range r.data {
+		if r.f.CanRead(d.Workspace()) && d.IsDue(now) {
+			result = append(result, d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NextAttemptAt().Before(*result[j].NextAttemptAt())
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *WebhookDelivery) Save(_ context.Context, d *webhook.Delivery) error {
+	if !r.f.CanWrite(d.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[d.ID()] = d
+	return nil
+}
+
+func (r *WebhookDelivery) RemoveByWebhook(_ context.Context, wid id.WebhookID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, d := range r.data {
+		if d.Webhook() == wid && r.f.CanWrite(d.Workspace()) {
+			delete(r.data, k)
+		}
+	}
+	return nil
+}
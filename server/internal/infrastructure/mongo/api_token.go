@@ -0,0 +1,78 @@
+package mongo
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	apiTokenIndexes       = []string{"workspace"}
+	apiTokenUniqueIndexes = []string{"id", "tokenhash"}
+)
+
+type APIToken struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewAPIToken(client *mongox.Client) *APIToken {
+	return &APIToken{
+		client: client.WithCollection("apiToken"),
+	}
+}
+
+func (r *APIToken) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, apiTokenIndexes, apiTokenUniqueIndexes)
+}
+
+func (r *APIToken) Filtered(f repo.WorkspaceFilter) repo.APIToken {
+	return &APIToken{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *APIToken) FindByID(ctx context.Context, id id.APITokenID) (*apitoken.APIToken, error) {
+	return r.findOne(ctx, bson.M{"id": id.String()})
+}
+
+func (r *APIToken) FindByHash(ctx context.Context, hash string) (*apitoken.APIToken, error) {
+	return r.findOne(ctx, bson.M{"tokenhash": hash})
+}
+
+func (r *APIToken) FindByWorkspace(ctx context.Context, wid accountsID.WorkspaceID) ([]*apitoken.APIToken, error) {
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+	c := mongodoc.NewAPITokenConsumer(r.f.Readable)
+	// API token IDs are ULIDs, so sorting by ID sorts by creation time
+	if err := r.client.Find(ctx, bson.M{"workspace": wid.String()}, c, options.Find().SetSort(bson.D{{Key: "id", Value: -1}})); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *APIToken) Save(ctx context.Context, t *apitoken.APIToken) error {
+	if !r.f.CanWrite(t.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, tid := mongodoc.NewAPIToken(t)
+	return r.client.SaveOne(ctx, tid, doc)
+}
+
+func (r *APIToken) findOne(ctx context.Context, filter any) (*apitoken.APIToken, error) {
+	c := mongodoc.NewAPITokenConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, applyWorkspaceFilter(filter, r.f.Readable), c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIToken(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	r := NewAPIToken(mongox.NewClientWithDatabase(c))
+	require.NoError(t, r.Init(ctx))
+
+	wid := accountsID.NewWorkspaceID()
+	wid2 := accountsID.NewWorkspaceID()
+	uid := accountsID.NewUserID()
+	expiresAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	t1 := apitoken.New().NewID().Workspace(wid).Name("a").Scopes(apitoken.Scopes{apitoken.ScopeRead, apitoken.ScopePublish}).
+		Hash(apitoken.HashToken("a")).ExpiresAt(&expiresAt).CreatedBy(&uid).MustBuild()
+	t2 := apitoken.New().NewID().Workspace(wid).Name("b").Scopes(apitoken.Scopes{apitoken.ScopeWrite}).
+		Hash(apitoken.HashToken("b")).MustBuild()
+	t3 := apitoken.New().NewID().Workspace(wid2).Name("c").Scopes(apitoken.Scopes{apitoken.ScopeRead}).
+		Hash(apitoken.HashToken("c")).MustBuild()
+	for _, at := range []*apitoken.APIToken{t1, t2, t3} {
+		require.NoError(t, r.Save(ctx, at))
+	}
+
+	got, err := r.FindByHash(ctx, apitoken.HashToken("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, t1.ID(), got.ID())
+	assert.Equal(t, t1.Scopes(), got.Scopes())
+	assert.Equal(t, t1.CreatedBy(), got.CreatedBy())
+	assert.True(t, expiresAt.Equal(*got.ExpiresAt()))
+
+	_, err = r.FindByHash(ctx, apitoken.HashToken("x"))
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	list, err := r.FindByWorkspace(ctx, wid)
+	assert.NoError(t, err)
+	assert.Equal(t, []id.APITokenID{t2.ID(), t1.ID()}, []id.APITokenID{list[0].ID(), list[1].ID()})
+
+	now := time.Now().Truncate(time.Millisecond)
+	t2.Use(now, time.Minute)
+	t2.Revoke(now)
+	require.NoError(t, r.Save(ctx, t2))
+	got, err = r.FindByID(ctx, t2.ID())
+	assert.NoError(t, err)
+	assert.True(t, got.IsRevoked())
+	assert.True(t, now.Equal(*got.LastUsedAt()))
+
+	filtered := r.Filtered(repo.WorkspaceFilter{Readable: accountsID.WorkspaceIDList{wid2}, Writable: accountsID.WorkspaceIDList{wid2}})
+	assert.ErrorIs(t, filtered.Save(ctx, t1), repo.ErrOperationDenied)
+	_, err = filtered.FindByHash(ctx, apitoken.HashToken("a"))
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	list, err = filtered.FindByWorkspace(ctx, wid)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
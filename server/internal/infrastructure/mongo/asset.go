@@ -208,6 +208,11 @@ func (r *Asset) RemoveByProjectWithFile(ctx context.Context, pid id.ProjectID, f
 					// objects must be cleaned up via GCS lifecycle rules.
 					log.Errorfc(gctx, "asset: gcs delete failed for %s: %v", a.ID(), err)
 				}
+				if tu, _ := url.Parse(a.ThumbnailURL()); a.ThumbnailURL() != "" && tu != nil {
+					if err := f.RemoveAsset(gctx, tu); err != nil {
+						log.Errorfc(gctx, "asset: gcs delete failed for the thumbnail of %s: %v", a.ID(), err)
+					}
+				}
 				return nil
 			})
 		}
@@ -0,0 +1,74 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AssetReference finds the documents by the "asseturls" field that the documents of properties, layers,
+// styles and projects record when they are saved. The collections are read without any filter.
+type AssetReference struct {
+	property *Property
+	nlsLayer *NLSLayer
+	style    *Style
+	project  *Project
+	scene    *Scene
+}
+
+func NewAssetReference(client *mongox.Client) *AssetReference {
+	return &AssetReference{
+		property: NewProperty(client),
+		nlsLayer: NewNLSLayer(client),
+		style:    NewStyle(client),
+		project:  NewProject(client),
+		scene:    NewScene(client),
+	}
+}
+
+func (r *AssetReference) FindByURLs(ctx context.Context, urls []string) (*repo.AssetReferences, error) {
+	res := &repo.AssetReferences{}
+	if len(urls) == 0 {
+		return res, nil
+	}
+	filter := bson.M{"asseturls": bson.M{"$in": urls}}
+
+	var err error
+	if res.Projects, err = r.project.find(ctx, filter); err != nil {
+		return nil, err
+	}
+	if res.NLSLayers, err = r.nlsLayer.find(ctx, nil, filter); err != nil {
+		return nil, err
+	}
+	styles, err := r.style.find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	res.Styles = *styles
+	if res.Properties, err = r.property.find(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	var sceneIDs id.SceneIDList
+	for _, l := range res.NLSLayers {
+		sceneIDs = sceneIDs.AddUniq((*l).Scene())
+	}
+	for _, s := range res.Styles {
+		sceneIDs = sceneIDs.AddUniq(s.Scene())
+	}
+	for _, p := range res.Properties {
+		sceneIDs = sceneIDs.AddUniq(p.Scene())
+	}
+	if len(sceneIDs) == 0 {
+		return res, nil
+	}
+	if res.Scenes, err = r.scene.find(ctx, bson.M{
+		"id": bson.M{"$in": sceneIDs.Strings()},
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package mongo
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetReference_FindByURLs(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	client := mongox.NewClientWithDatabase(c)
+	r := NewAssetReference(client)
+
+	a := "https://example.com/assets/a.png"
+	wid := accountsID.NewWorkspaceID()
+	prj := project.New().NewID().Workspace(wid).ImageURL(lo.Must(url.Parse(a))).MustBuild()
+	other := project.New().NewID().Workspace(wid).MustBuild()
+	sc := scene.New().NewID().Workspace(wid).Project(prj.ID()).MustBuild()
+	prop := property.New().NewID().Scene(sc.ID()).Schema(id.MustPropertySchemaID("reearth/cesium")).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("html").Value(property.ValueTypeString.ValueFrom(`<img src="` + a + `">`).Some()).MustBuild(),
+		}).MustBuild(),
+	}).MustBuild()
+	layer := nlslayer.NewNLSLayerSimple().NewID().Scene(sc.ID()).LayerType("simple").
+		Config(&nlslayer.Config{"data": map[string]any{"url": a}}).MustBuild()
+	style := scene.NewStyle().NewID().Scene(sc.ID()).Value(&scene.StyleValue{"image": a + "?v=1"}).MustBuild()
+	unused := scene.NewStyle().NewID().Scene(sc.ID()).Value(&scene.StyleValue{"color": "red"}).MustBuild()
+
+	// the URLs are recorded by the documents of the other repos
+	require.NoError(t, NewProject(client).Save(ctx, prj))
+	require.NoError(t, NewProject(client).Save(ctx, other))
+	require.NoError(t, NewScene(client).Save(ctx, sc))
+	require.NoError(t, NewProperty(client).Save(ctx, prop))
+	require.NoError(t, NewNLSLayer(client).Save(ctx, layer))
+	require.NoError(t, NewStyle(client).SaveAll(ctx, scene.StyleList{style, unused}))
+
+	got, err := r.FindByURLs(ctx, []string{a, "https://example.com/assets/b.png"})
+	require.NoError(t, err)
+	assert.Equal(t, []id.ProjectID{prj.ID()}, lo.Map(got.Projects, func(p *project.Project, _ int) id.ProjectID { return p.ID() }))
+	assert.Equal(t, []id.SceneID{sc.ID()}, lo.Map(got.Scenes, func(s *scene.Scene, _ int) id.SceneID { return s.ID() }))
+	require.Len(t, got.NLSLayers, 1)
+	assert.Equal(t, layer.ID(), (*got.NLSLayers[0]).ID())
+	require.Len(t, got.Styles, 1)
+	assert.Equal(t, style.ID(), got.Styles[0].ID())
+	require.Len(t, got.Properties, 1)
+	assert.Equal(t, prop.ID(), got.Properties[0].ID())
+
+	got, err = r.FindByURLs(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &repo.AssetReferences{}, got)
+}
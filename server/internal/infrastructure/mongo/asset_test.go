@@ -97,6 +97,40 @@ func TestAsset_FindByIDs(t *testing.T) {
 	assert.Equal(t, a2.ID(), got[2].ID())
 }
 
+func TestAsset_Metadata(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	ws := accountsID.NewWorkspaceID()
+	width, height, count := 30, 20, 2
+
+	withMetadata := asset.New().NewID().Workspace(ws).URL("https://example.com/a.png").Size(1).
+		Metadata(&asset.Metadata{
+			Width:        &width,
+			Height:       &height,
+			Location:     &asset.Location{Lat: 35, Lng: 139},
+			Bounds:       &asset.Bounds{Min: []float64{139, 34}, Max: []float64{141, 36}},
+			FeatureCount: &count,
+			Attributes:   []string{"id", "name"},
+		}).
+		ThumbnailURL("https://example.com/a_thumbnail.png").
+		MustBuild()
+	withoutMetadata := asset.New().NewID().Workspace(ws).URL("https://example.com/b.txt").Size(1).MustBuild()
+
+	r := NewAsset(mongox.NewClientWithDatabase(c))
+	require.NoError(t, r.Save(ctx, withMetadata))
+	require.NoError(t, r.Save(ctx, withoutMetadata))
+
+	got, err := r.FindByID(ctx, withMetadata.ID())
+	require.NoError(t, err)
+	assert.Equal(t, withMetadata.Metadata(), got.Metadata())
+	assert.Equal(t, withMetadata.ThumbnailURL(), got.ThumbnailURL())
+
+	got, err = r.FindByID(ctx, withoutMetadata.ID())
+	require.NoError(t, err)
+	assert.Nil(t, got.Metadata())
+	assert.Empty(t, got.ThumbnailURL())
+}
+
 func TestAsset_Remove(t *testing.T) {
 	c := mongotest.Connect(t)(t)
 	ctx := context.Background()
@@ -155,6 +189,15 @@ func (c *countingFileGateway) ReadStoryFile(_ context.Context, _ string) (io.Rea
 }
 func (c *countingFileGateway) MoveStory(_ context.Context, _, _ string) error { return nil }
 func (c *countingFileGateway) RemoveStory(_ context.Context, _ string) error  { return nil }
+func (c *countingFileGateway) UploadPublicationSnapshot(_ context.Context, _ io.Reader, _ string) error {
+	return nil
+}
+func (c *countingFileGateway) ReadPublicationSnapshot(_ context.Context, _ string) (io.ReadCloser, error) {
+	return nil, nil
+}
+func (c *countingFileGateway) RemovePublicationSnapshot(_ context.Context, _ string) error {
+	return nil
+}
 func (c *countingFileGateway) ReadExportProjectZip(_ context.Context, _ string) (io.ReadCloser, error) {
 	return nil, nil
 }
@@ -165,6 +208,9 @@ func (c *countingFileGateway) RemoveExportProjectZip(_ context.Context, _ string
 func (c *countingFileGateway) GenerateSignedUploadUrl(_ context.Context, _ string) (*string, int, *string, error) {
 	return nil, 0, nil, nil
 }
+func (c *countingFileGateway) UploadImportProjectZip(_ context.Context, _ string, _ io.Reader) error {
+	return nil
+}
 func (c *countingFileGateway) ReadImportProjectZip(_ context.Context, _ string) (io.ReadCloser, error) {
 	return nil, nil
 }
@@ -33,25 +33,35 @@ func New(ctx context.Context, db *mongo.Database, account *accountsInfra.Contain
 	}
 
 	c := &repo.Container{
-		Asset:           NewAsset(client),
-		Config:          NewConfig(db.Collection("config"), lock),
-		NLSLayer:        NewNLSLayer(client),
-		Style:           NewStyle(client),
-		Lock:            lock,
-		Plugin:          NewPlugin(client),
-		Project:         NewProject(client),
-		ProjectMetadata: NewProjectMetadata(client),
-		PropertySchema:  NewPropertySchema(client),
-		Property:        NewProperty(client),
-		Scene:           NewScene(client),
-		SceneLock:       NewSceneLock(client),
-		Workspace:       account.Workspace,
-		User:            account.User,
-		Storytelling:    NewStorytelling(client),
-		Transaction:     client.Transaction(),
-		Extensions:      nil,
-		Role:            account.Role,
-		Permittable:     account.Permittable,
+		Asset:              NewAsset(client),
+		AssetReference:     NewAssetReference(client),
+		Config:             NewConfig(db.Collection("config"), lock),
+		NLSLayer:           NewNLSLayer(client),
+		FeatureRevision:    NewFeatureRevision(client),
+		Style:              NewStyle(client),
+		Lock:               lock,
+		Plugin:             NewPlugin(client),
+		Project:            NewProject(client),
+		ProjectMetadata:    NewProjectMetadata(client),
+		PropertySchema:     NewPropertySchema(client),
+		Property:           NewProperty(client),
+		Scene:              NewScene(client),
+		SceneLock:          NewSceneLock(client),
+		Workspace:          account.Workspace,
+		User:               account.User,
+		Storytelling:       NewStorytelling(client),
+		ShareToken:         NewShareToken(client),
+		PublishSchedule:    NewPublishSchedule(client),
+		PublicationVersion: NewPublicationVersion(client),
+		TrashPolicy:        NewTrashPolicy(client),
+		TrashPurge:         NewTrashPurge(client),
+		APIToken:           NewAPIToken(client),
+		Webhook:            NewWebhook(client),
+		WebhookDelivery:    NewWebhookDelivery(client),
+		Transaction:        client.Transaction(),
+		Extensions:         nil,
+		Role:               account.Role,
+		Permittable:        account.Permittable,
 	}
 
 	// init
@@ -107,12 +117,23 @@ func Init(r *repo.Container) error {
 
 	ctx := context.Background()
 	return util.Try(
+		func() error { return r.APIToken.(*APIToken).Init(ctx) },
+		func() error { return r.Webhook.(*Webhook).Init(ctx) },
+		func() error { return r.WebhookDelivery.(*WebhookDelivery).Init(ctx) },
 		func() error { return r.Asset.(*Asset).Init(ctx) },
+		func() error { return r.FeatureRevision.(*FeatureRevision).Init(ctx) },
+		func() error { return r.NLSLayer.(*NLSLayer).Init(ctx) },
 		func() error { return r.Plugin.(*Plugin).Init(ctx) },
 		func() error { return r.Project.(*Project).Init(ctx) },
+		func() error { return r.PublishSchedule.(*PublishSchedule).Init(ctx) },
+		func() error { return r.PublicationVersion.(*PublicationVersion).Init(ctx) },
 		func() error { return r.Property.(*Property).Init(ctx) },
 		func() error { return r.PropertySchema.(*PropertySchema).Init(ctx) },
 		func() error { return r.Scene.(*Scene).Init(ctx) },
+		func() error { return r.ShareToken.(*ShareToken).Init(ctx) },
+		func() error { return r.Style.(*Style).Init(ctx) },
+		func() error { return r.TrashPolicy.(*TrashPolicy).Init(ctx) },
+		func() error { return r.TrashPurge.(*TrashPurge).Init(ctx) },
 		// func() error { return r.User.(*accountsMongo.User).Init() },
 		// func() error { return r.Workspace.(*accountsMongo.Workspace).Init() },
 	)
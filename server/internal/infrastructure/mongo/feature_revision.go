@@ -0,0 +1,107 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	featureRevisionIndexes       = []string{"layer", "layer,feature", "scene"}
+	featureRevisionUniqueIndexes = []string{"id"}
+)
+
+type FeatureRevision struct {
+	client *mongox.ClientCollection
+	f      repo.SceneFilter
+}
+
+func NewFeatureRevision(client *mongox.Client) *FeatureRevision {
+	return &FeatureRevision{
+		client: client.WithCollection("featureRevision"),
+	}
+}
+
+func (r *FeatureRevision) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, featureRevisionIndexes, featureRevisionUniqueIndexes)
+}
+
+func (r *FeatureRevision) Filtered(f repo.SceneFilter) repo.FeatureRevision {
+	return &FeatureRevision{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *FeatureRevision) FindByID(ctx context.Context, id id.FeatureRevisionID) (*nlslayer.FeatureRevision, error) {
+	c := mongodoc.NewFeatureRevisionConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, bson.M{"id": id.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *FeatureRevision) FindByLayer(ctx context.Context, lid id.NLSLayerID, filter repo.FeatureRevisionFilter) ([]*nlslayer.FeatureRevision, *usecasex.PageInfo, error) {
+	f := bson.M{"layer": lid.String()}
+	if filter.Feature != nil {
+		f["feature"] = filter.Feature.String()
+	}
+
+	c := mongodoc.NewFeatureRevisionConsumer(r.f.Readable)
+	// revision IDs are ULIDs, so sorting by ID sorts by creation time
+	pageInfo, err := r.client.Paginate(ctx, r.readFilter(f), &usecasex.Sort{Key: "id", Reverted: true}, filter.Pagination, c)
+	if err != nil {
+		return nil, nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, pageInfo, nil
+}
+
+func (r *FeatureRevision) FindByLayerAfter(ctx context.Context, lid id.NLSLayerID, after id.FeatureRevisionID) ([]*nlslayer.FeatureRevision, error) {
+	c := mongodoc.NewFeatureRevisionConsumer(r.f.Readable)
+	filter := r.readFilter(bson.M{
+		"layer": lid.String(),
+		"id":    bson.M{"$gt": after.String()},
+	})
+	if err := r.client.Find(ctx, filter, c, options.Find().SetSort(bson.D{{Key: "id", Value: 1}})); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *FeatureRevision) SaveAll(ctx context.Context, revisions []*nlslayer.FeatureRevision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+	for _, rev := range revisions {
+		if !r.f.CanWrite(rev.Scene()) {
+			return repo.ErrOperationDenied
+		}
+	}
+
+	docs, ids := mongodoc.NewFeatureRevisions(revisions)
+	return r.client.SaveAll(ctx, ids, docs)
+}
+
+func (r *FeatureRevision) RemoveByLayer(ctx context.Context, lid id.NLSLayerID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"layer": lid.String()}))
+}
+
+func (r *FeatureRevision) RemoveByScene(ctx context.Context, sid id.SceneID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"scene": sid.String()}))
+}
+
+func (r *FeatureRevision) readFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Readable)
+}
+
+func (r *FeatureRevision) writeFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Writable)
+}
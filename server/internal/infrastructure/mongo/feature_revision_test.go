@@ -0,0 +1,62 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureRevision(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	r := NewFeatureRevision(mongox.NewClientWithDatabase(c))
+
+	sid := id.NewSceneID()
+	lid := id.NewNLSLayerID()
+	uid := accountsID.NewUserID()
+	f := lo.Must(nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{1, 2})))
+	f.UpdateProperties(&map[string]any{"name": "a"})
+	moved := *f
+	moved.UpdateGeometry(nlslayer.NewPoint("Point", []float64{3, 4}))
+
+	newRevision := func(action nlslayer.FeatureRevisionAction, before, after *nlslayer.Feature) *nlslayer.FeatureRevision {
+		return nlslayer.NewFeatureRevision().NewID().Layer(lid).Scene(sid).Feature(f.ID()).
+			Action(action).Before(before).After(after).Operator(&uid).MustBuild()
+	}
+	r1 := newRevision(nlslayer.FeatureRevisionActionAdd, nil, f)
+	r2 := newRevision(nlslayer.FeatureRevisionActionUpdate, f, &moved)
+	r3 := newRevision(nlslayer.FeatureRevisionActionDelete, &moved, nil)
+	require.NoError(t, r.SaveAll(ctx, []*nlslayer.FeatureRevision{r1, r2, r3}))
+
+	got, err := r.FindByID(ctx, r2.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, r2, got)
+
+	list, pi, err := r.FindByLayer(ctx, lid, repo.FeatureRevisionFilter{
+		Pagination: usecasex.CursorPagination{First: lo.ToPtr(int64(2))}.Wrap(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []id.FeatureRevisionID{r3.ID(), r2.ID()}, lo.Map(list, func(r *nlslayer.FeatureRevision, _ int) id.FeatureRevisionID { return r.ID() }))
+	assert.Equal(t, int64(3), pi.TotalCount)
+
+	after, err := r.FindByLayerAfter(ctx, lid, r1.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, []*nlslayer.FeatureRevision{r2, r3}, after)
+
+	assert.ErrorIs(t, r.Filtered(repo.SceneFilter{Writable: id.SceneIDList{}}).SaveAll(ctx, []*nlslayer.FeatureRevision{r1}), repo.ErrOperationDenied)
+
+	require.NoError(t, r.RemoveByLayer(ctx, lid))
+	list, _, err = r.FindByLayer(ctx, lid, repo.FeatureRevisionFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,55 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// HashBasicAuthPasswords replaces the plaintext basic auth passwords of published projects and stories with their hashes.
+func HashBasicAuthPasswords(ctx context.Context, c DBClient) error {
+	for _, name := range []string{"project", "storytelling"} {
+		if err := hashBasicAuthPasswords(ctx, c.WithCollection(name)); err != nil {
+			return fmt.Errorf("migration: HashBasicAuthPasswords: %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func hashBasicAuthPasswords(ctx context.Context, col *mongox.ClientCollection) error {
+	filter := bson.M{"basicauthpassword": bson.M{"$nin": []any{"", nil}}}
+
+	return col.Find(ctx, filter, &mongox.BatchConsumer{
+		Size: 1000,
+		Callback: func(rows []bson.Raw) error {
+			for _, row := range rows {
+				var doc struct {
+					ID                string `bson:"id"`
+					BasicAuthPassword string `bson:"basicauthpassword"`
+				}
+				if err := bson.Unmarshal(row, &doc); err != nil {
+					log.Errorfc(ctx, "migration: HashBasicAuthPasswords: failed to unmarshal row: %v", err)
+					continue
+				}
+				if publication.IsHashedBasicAuthPassword(doc.BasicAuthPassword) {
+					continue
+				}
+
+				hashed, err := publication.HashBasicAuthPassword(doc.BasicAuthPassword)
+				if err != nil {
+					return err
+				}
+				if _, err := col.Client().UpdateOne(ctx, bson.M{"id": doc.ID}, bson.M{
+					"$set": bson.M{"basicauthpassword": hashed},
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
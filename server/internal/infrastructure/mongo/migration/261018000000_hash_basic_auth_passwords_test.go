@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestHashBasicAuthPasswords(t *testing.T) {
+	ctx := context.Background()
+	db := mongotest.Connect(t)(t)
+	client := mongox.NewClientWithDatabase(db)
+
+	hashed, err := publication.HashBasicAuthPassword("already")
+	require.NoError(t, err)
+
+	for _, name := range []string{"project", "storytelling"} {
+		col := client.WithCollection(name).Client()
+		_, err := col.InsertMany(ctx, []any{
+			bson.M{"id": "plain", "basicauthpassword": "pass"},
+			bson.M{"id": "hashed", "basicauthpassword": hashed},
+			bson.M{"id": "empty", "basicauthpassword": ""},
+			bson.M{"id": "none"},
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, HashBasicAuthPasswords(ctx, client))
+	// running it again must not hash the hashes
+	require.NoError(t, HashBasicAuthPasswords(ctx, client))
+
+	for _, name := range []string{"project", "storytelling"} {
+		col := client.WithCollection(name).Client()
+		password := func(id string) any {
+			var doc bson.M
+			require.NoError(t, col.FindOne(ctx, bson.M{"id": id}).Decode(&doc))
+			return doc["basicauthpassword"]
+		}
+
+		plain, ok := password("plain").(string)
+		require.True(t, ok)
+		assert.True(t, publication.IsHashedBasicAuthPassword(plain), name)
+		assert.True(t, publication.MatchBasicAuthPassword(plain, "pass"), name)
+		assert.Equal(t, hashed, password("hashed"), name)
+		assert.Equal(t, "", password("empty"), name)
+		assert.Nil(t, password("none"), name)
+	}
+}
@@ -0,0 +1,24 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SetProjectDeletedAt records when the projects already in the trash were deleted, so that the trash purge job can expire them.
+// The time they were actually deleted is unknown, so they are treated as deleted when the migration runs and get the full retention period.
+func SetProjectDeletedAt(ctx context.Context, c DBClient) error {
+	_, err := c.WithCollection("project").Client().UpdateMany(ctx, bson.M{
+		"deleted":   true,
+		"deletedat": bson.M{"$exists": false},
+	}, bson.M{
+		"$set": bson.M{"deletedat": time.Now()},
+	})
+	if err != nil {
+		return fmt.Errorf("migration: SetProjectDeletedAt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSetProjectDeletedAt(t *testing.T) {
+	ctx := context.Background()
+	db := mongotest.Connect(t)(t)
+	client := mongox.NewClientWithDatabase(db)
+
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	col := client.WithCollection("project").Client()
+	_, err := col.InsertMany(ctx, []any{
+		bson.M{"id": "trashed", "deleted": true},
+		bson.M{"id": "recorded", "deleted": true, "deletedat": deletedAt},
+		bson.M{"id": "active", "deleted": false},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, SetProjectDeletedAt(ctx, client))
+
+	find := func(id string) bson.M {
+		var doc bson.M
+		require.NoError(t, col.FindOne(ctx, bson.M{"id": id}).Decode(&doc))
+		return doc
+	}
+	assert.NotNil(t, find("trashed")["deletedat"])
+	assert.Equal(t, deletedAt, find("recorded")["deletedat"].(primitive.DateTime).Time().UTC())
+	assert.NotContains(t, find("active"), "deletedat")
+}
@@ -0,0 +1,94 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddAssetURLs records the asset URLs that the existing properties, layers, styles and projects contain.
+// The documents saved from now on record them by themselves.
+func AddAssetURLs(ctx context.Context, c DBClient) error {
+	if err := addAssetURLs(ctx, c.WithCollection("property"), func(d *mongodoc.PropertyDocument) ([]string, error) {
+		m, err := d.Model()
+		if err != nil {
+			return nil, err
+		}
+		doc, _ := mongodoc.NewProperty(m)
+		return doc.AssetURLs, nil
+	}); err != nil {
+		return fmt.Errorf("migration: AddAssetURLs: property: %w", err)
+	}
+	if err := addAssetURLs(ctx, c.WithCollection("nlsLayer"), func(d *mongodoc.NLSLayerDocument) ([]string, error) {
+		m, err := d.Model()
+		if err != nil {
+			return nil, err
+		}
+		doc, _ := mongodoc.NewNLSLayer(m)
+		return doc.AssetURLs, nil
+	}); err != nil {
+		return fmt.Errorf("migration: AddAssetURLs: nlsLayer: %w", err)
+	}
+	if err := addAssetURLs(ctx, c.WithCollection("style"), func(d *mongodoc.StyleDocument) ([]string, error) {
+		m, err := d.Model()
+		if err != nil {
+			return nil, err
+		}
+		doc, _ := mongodoc.NewStyle(*m)
+		return doc.AssetURLs, nil
+	}); err != nil {
+		return fmt.Errorf("migration: AddAssetURLs: style: %w", err)
+	}
+	if err := addAssetURLs(ctx, c.WithCollection("project"), func(d *mongodoc.ProjectDocument) ([]string, error) {
+		m, err := d.Model()
+		if err != nil {
+			return nil, err
+		}
+		doc, _ := mongodoc.NewProject(m)
+		return doc.AssetURLs, nil
+	}); err != nil {
+		return fmt.Errorf("migration: AddAssetURLs: project: %w", err)
+	}
+	return nil
+}
+
+// addAssetURLs decodes the documents that have not recorded their asset URLs yet and records the URLs
+// computed the same way as when the documents are saved.
+func addAssetURLs[T any](ctx context.Context, col *mongox.ClientCollection, urls func(*T) ([]string, error)) error {
+	filter := bson.M{"asseturls": bson.M{"$exists": false}}
+
+	return col.Find(ctx, filter, &mongox.BatchConsumer{
+		Size: 1000,
+		Callback: func(rows []bson.Raw) error {
+			for _, row := range rows {
+				id, ok := row.Lookup("id").StringValueOK()
+				if !ok {
+					continue
+				}
+				var doc T
+				if err := bson.Unmarshal(row, &doc); err != nil {
+					log.Errorfc(ctx, "migration: AddAssetURLs: failed to unmarshal %s: %v", id, err)
+					continue
+				}
+				u, err := urls(&doc)
+				if err != nil {
+					log.Warnfc(ctx, "migration: AddAssetURLs: skipping %s: %v", id, err)
+					continue
+				}
+				if len(u) == 0 {
+					continue
+				}
+				if _, err := col.Client().UpdateOne(ctx, bson.M{"id": id}, bson.M{
+					"$set": bson.M{"asseturls": u},
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package migration
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAddAssetURLs(t *testing.T) {
+	ctx := context.Background()
+	db := mongotest.Connect(t)(t)
+	client := mongox.NewClientWithDatabase(db)
+
+	a := "https://example.com/assets/a.png"
+	sid := id.NewSceneID()
+	prj := project.New().NewID().Workspace(accountsID.NewWorkspaceID()).ImageURL(lo.Must(url.Parse(a))).MustBuild()
+	plain := project.New().NewID().Workspace(accountsID.NewWorkspaceID()).MustBuild()
+	prop := property.New().NewID().Scene(sid).Schema(id.MustPropertySchemaID("reearth/cesium")).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("image").Value(property.ValueTypeURL.ValueFrom(a).Some()).MustBuild(),
+		}).MustBuild(),
+	}).MustBuild()
+	layer := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).LayerType("simple").
+		Config(&nlslayer.Config{"data": map[string]any{"url": a}}).MustBuild()
+	style := scene.NewStyle().NewID().Scene(sid).Value(&scene.StyleValue{"image": a}).MustBuild()
+
+	// the documents saved before the migration have no asset URLs
+	prjDoc, _ := mongodoc.NewProject(prj)
+	plainDoc, _ := mongodoc.NewProject(plain)
+	propDoc, _ := mongodoc.NewProperty(prop)
+	layerDoc, _ := mongodoc.NewNLSLayer(layer)
+	styleDoc, _ := mongodoc.NewStyle(*style)
+	prjDoc.AssetURLs, propDoc.AssetURLs, layerDoc.AssetURLs, styleDoc.AssetURLs = nil, nil, nil, nil
+	insert := func(name string, docs ...any) {
+		_, err := client.WithCollection(name).Client().InsertMany(ctx, docs)
+		require.NoError(t, err)
+	}
+	insert("project", prjDoc, plainDoc)
+	insert("property", propDoc)
+	insert("nlsLayer", layerDoc)
+	insert("style", styleDoc)
+
+	require.NoError(t, AddAssetURLs(ctx, client))
+
+	assetURLs := func(name, id string) any {
+		var doc bson.M
+		require.NoError(t, client.WithCollection(name).Client().FindOne(ctx, bson.M{"id": id}).Decode(&doc))
+		return doc["asseturls"]
+	}
+	assert.Equal(t, bson.A{a}, assetURLs("project", prj.ID().String()))
+	assert.Nil(t, assetURLs("project", plain.ID().String()))
+	assert.Equal(t, bson.A{a}, assetURLs("property", prop.ID().String()))
+	assert.Equal(t, bson.A{a}, assetURLs("nlsLayer", layer.ID().String()))
+	assert.Equal(t, bson.A{a}, assetURLs("style", style.ID().String()))
+}
@@ -49,4 +49,7 @@ var migrations = migration.Migrations[DBClient]{
 	260804000000: SetTileCategory,
 	260804013000: RepairSetTileCategoryLegacyTileType,
 	260805000000: RemoveLegacyImportStatusFields,
+	261018000000: HashBasicAuthPasswords,
+	261018000100: SetProjectDeletedAt,
+	261018000200: AddAssetURLs,
 }
@@ -0,0 +1,84 @@
+package mongodoc
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/id"
+	"golang.org/x/exp/slices"
+)
+
+type APITokenDocument struct {
+	ID         string
+	Workspace  string
+	Name       string
+	Scopes     []string
+	TokenHash  string
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedBy  *string
+}
+
+type APITokenConsumer = Consumer[*APITokenDocument, *apitoken.APIToken]
+
+func NewAPITokenConsumer(workspaces []accountsID.WorkspaceID) *APITokenConsumer {
+	return NewConsumer[*APITokenDocument, *apitoken.APIToken](func(a *apitoken.APIToken) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewAPIToken(t *apitoken.APIToken) (*APITokenDocument, string) {
+	tid := t.ID().String()
+	doc := &APITokenDocument{
+		ID:         tid,
+		Workspace:  t.Workspace().String(),
+		Name:       t.Name(),
+		Scopes:     t.Scopes().Strings(),
+		TokenHash:  t.Hash(),
+		ExpiresAt:  t.ExpiresAt(),
+		RevokedAt:  t.RevokedAt(),
+		LastUsedAt: t.LastUsedAt(),
+	}
+	if t.CreatedBy() != nil {
+		doc.CreatedBy = t.CreatedBy().StringRef()
+	}
+	return doc, tid
+}
+
+func (d *APITokenDocument) Model() (*apitoken.APIToken, error) {
+	tid, err := id.APITokenIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := accountsID.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := apitoken.ScopesFrom(d.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBy *accountsID.UserID
+	if d.CreatedBy != nil {
+		uid, err := accountsID.UserIDFrom(*d.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		createdBy = &uid
+	}
+
+	return apitoken.New().
+		ID(tid).
+		Workspace(wid).
+		Name(d.Name).
+		Scopes(scopes).
+		Hash(d.TokenHash).
+		ExpiresAt(d.ExpiresAt).
+		RevokedAt(d.RevokedAt).
+		LastUsedAt(d.LastUsedAt).
+		CreatedBy(createdBy).
+		Build()
+}
@@ -20,6 +20,19 @@ type AssetDocument struct {
 	URL         string
 	ContentType string
 	CoreSupport bool
+	Metadata    *AssetMetadataDocument
+	Thumbnail   string
+}
+
+type AssetMetadataDocument struct {
+	Width        *int
+	Height       *int
+	Lat          *float64
+	Lng          *float64
+	BoundsMin    []float64
+	BoundsMax    []float64
+	FeatureCount *int
+	Attributes   []string
 }
 
 type AssetConsumer = Consumer[*AssetDocument, *asset.Asset]
@@ -49,9 +62,49 @@ func NewAsset(asset *asset.Asset) (*AssetDocument, string) {
 		URL:         asset.URL(),
 		ContentType: asset.ContentType(),
 		CoreSupport: asset.CoreSupport(),
+		Metadata:    newAssetMetadata(asset.Metadata()),
+		Thumbnail:   asset.ThumbnailURL(),
 	}, aid
 }
 
+func newAssetMetadata(m *asset.Metadata) *AssetMetadataDocument {
+	if m == nil {
+		return nil
+	}
+	d := &AssetMetadataDocument{
+		Width:        m.Width,
+		Height:       m.Height,
+		FeatureCount: m.FeatureCount,
+		Attributes:   m.Attributes,
+	}
+	if m.Location != nil {
+		d.Lat, d.Lng = &m.Location.Lat, &m.Location.Lng
+	}
+	if m.Bounds != nil {
+		d.BoundsMin, d.BoundsMax = m.Bounds.Min, m.Bounds.Max
+	}
+	return d
+}
+
+func (d *AssetMetadataDocument) model() *asset.Metadata {
+	if d == nil {
+		return nil
+	}
+	m := &asset.Metadata{
+		Width:        d.Width,
+		Height:       d.Height,
+		FeatureCount: d.FeatureCount,
+		Attributes:   d.Attributes,
+	}
+	if d.Lat != nil && d.Lng != nil {
+		m.Location = &asset.Location{Lat: *d.Lat, Lng: *d.Lng}
+	}
+	if len(d.BoundsMin) > 0 && len(d.BoundsMin) == len(d.BoundsMax) {
+		m.Bounds = &asset.Bounds{Min: d.BoundsMin, Max: d.BoundsMax}
+	}
+	return m
+}
+
 func (d *AssetDocument) Model() (*asset.Asset, error) {
 	aid, err := id.AssetIDFrom(d.ID)
 	if err != nil {
@@ -81,5 +134,7 @@ func (d *AssetDocument) Model() (*asset.Asset, error) {
 		URL(d.URL).
 		ContentType(d.ContentType).
 		CoreSupport(d.CoreSupport).
+		Metadata(d.Metadata.model()).
+		ThumbnailURL(d.Thumbnail).
 		Build()
 }
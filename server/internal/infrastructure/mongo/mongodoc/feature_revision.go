@@ -0,0 +1,115 @@
+package mongodoc
+
+import (
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"golang.org/x/exp/slices"
+)
+
+type FeatureRevisionDocument struct {
+	ID       string
+	Layer    string
+	Scene    string
+	Feature  string
+	Action   string
+	Before   *NLSLayerFeatureDocument
+	After    *NLSLayerFeatureDocument
+	Operator *string
+}
+
+type FeatureRevisionConsumer = Consumer[*FeatureRevisionDocument, *nlslayer.FeatureRevision]
+
+func NewFeatureRevisionConsumer(scenes []id.SceneID) *FeatureRevisionConsumer {
+	return NewConsumer[*FeatureRevisionDocument, *nlslayer.FeatureRevision](func(a *nlslayer.FeatureRevision) bool {
+		return scenes == nil || slices.Contains(scenes, a.Scene())
+	})
+}
+
+func NewFeatureRevision(r *nlslayer.FeatureRevision) (*FeatureRevisionDocument, string) {
+	rid := r.ID().String()
+	doc := &FeatureRevisionDocument{
+		ID:      rid,
+		Layer:   r.Layer().String(),
+		Scene:   r.Scene().String(),
+		Feature: r.Feature().String(),
+		Action:  string(r.Action()),
+	}
+	if r.Before() != nil {
+		before := NewNLSLayerFeature(*r.Before())
+		doc.Before = &before
+	}
+	if r.After() != nil {
+		after := NewNLSLayerFeature(*r.After())
+		doc.After = &after
+	}
+	if r.Operator() != nil {
+		doc.Operator = r.Operator().StringRef()
+	}
+	return doc, rid
+}
+
+func NewFeatureRevisions(revisions []*nlslayer.FeatureRevision) ([]interface{}, []string) {
+	res := make([]interface{}, 0, len(revisions))
+	ids := make([]string, 0, len(revisions))
+	for _, r := range revisions {
+		if r == nil {
+			continue
+		}
+		doc, rid := NewFeatureRevision(r)
+		res = append(res, doc)
+		ids = append(ids, rid)
+	}
+	return res, ids
+}
+
+func (d *FeatureRevisionDocument) Model() (*nlslayer.FeatureRevision, error) {
+	rid, err := id.FeatureRevisionIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	lid, err := id.NLSLayerIDFrom(d.Layer)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+	fid, err := id.FeatureIDFrom(d.Feature)
+	if err != nil {
+		return nil, err
+	}
+
+	var before, after *nlslayer.Feature
+	if d.Before != nil {
+		if before, err = ToModelNLSLayerFeature(*d.Before); err != nil {
+			return nil, err
+		}
+	}
+	if d.After != nil {
+		if after, err = ToModelNLSLayerFeature(*d.After); err != nil {
+			return nil, err
+		}
+	}
+
+	var operator *accountsID.UserID
+	if d.Operator != nil {
+		uid, err := accountsID.UserIDFrom(*d.Operator)
+		if err != nil {
+			return nil, err
+		}
+		operator = &uid
+	}
+
+	return nlslayer.NewFeatureRevision().
+		ID(rid).
+		Layer(lid).
+		Scene(sid).
+		Feature(fid).
+		Action(nlslayer.FeatureRevisionAction(d.Action)).
+		Before(before).
+		After(after).
+		Operator(operator).
+		Build()
+}
@@ -3,6 +3,7 @@ package mongodoc
 import (
 	"errors"
 
+	"github.com/reearth/reearth/server/pkg/asset"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -23,6 +24,8 @@ type NLSLayerDocument struct {
 	IsSketch       bool
 	Sketch         *NLSLayerSketchInfoDocument
 	DataSourceName *string
+	// AssetURLs are the URLs in the config and the sketch features, so that the layers can be looked up by asset URL.
+	AssetURLs []string `bson:",omitempty"`
 }
 
 type NLSLayerSimpleDocument struct {
@@ -77,11 +80,21 @@ type NLSLayerPointDocument struct {
 	Coordinates []float64
 }
 
+type NLSLayerMultiPointDocument struct {
+	Type        string
+	Coordinates [][]float64
+}
+
 type NLSLayerLineString struct {
 	Type        string
 	Coordinates [][]float64
 }
 
+type NLSLayerMultiLineStringDocument struct {
+	Type        string
+	Coordinates [][][]float64
+}
+
 type NLSLayerPolygonDocument struct {
 	Type        string
 	Coordinates [][][]float64
@@ -122,7 +135,7 @@ func NewNLSLayer(l nlslayer.NLSLayer) (*NLSLayerDocument, string) {
 	}
 
 	id := l.ID().String()
-	return &NLSLayerDocument{
+	doc := &NLSLayerDocument{
 		ID:             id,
 		Index:          l.Index(),
 		Title:          l.Title(),
@@ -136,7 +149,25 @@ func NewNLSLayer(l nlslayer.NLSLayer) (*NLSLayerDocument, string) {
 		IsSketch:       l.IsSketch(),
 		Sketch:         NewNLSLayerSketchInfo(l.Sketch()),
 		DataSourceName: l.DataSourceName(),
-	}, id
+	}
+	doc.AssetURLs = doc.assetURLs()
+	return doc, id
+}
+
+func (d *NLSLayerDocument) assetURLs() []string {
+	var values []any
+	if d.Simple != nil {
+		values = append(values, d.Simple.Config)
+	}
+	if d.Group != nil {
+		values = append(values, d.Group.Config)
+	}
+	if d.Sketch != nil && d.Sketch.FeatureCollection != nil {
+		for _, f := range d.Sketch.FeatureCollection.Features {
+			values = append(values, f.Properties)
+		}
+	}
+	return asset.URLs(values...)
 }
 
 func NewNLSLayers(layers nlslayer.NLSLayerList, f id.SceneIDList) ([]interface{}, []string) {
@@ -339,23 +370,10 @@ func ToModelNLSLayerSketchInfo(si *NLSLayerSketchInfoDocument) (*nlslayer.Sketch
 
 	features := make([]nlslayer.Feature, 0, len(si.FeatureCollection.Features))
 	for _, f := range si.FeatureCollection.Features {
-		id, err := id.FeatureIDFrom(f.ID)
-		if err != nil {
-			return nil, err
-		}
-		geometry, err := ToModelNLSLayerGeometry(f.Geometry)
-		if err != nil {
-			return nil, err
-		}
-		feature, err := nlslayer.NewFeature(
-			id,
-			f.Type,
-			geometry,
-		)
+		feature, err := ToModelNLSLayerFeature(f)
 		if err != nil {
 			return nil, err
 		}
-		feature.UpdateProperties(&f.Properties)
 		features = append(features, *feature)
 	}
 
@@ -372,6 +390,27 @@ func ToModelNLSLayerSketchInfo(si *NLSLayerSketchInfoDocument) (*nlslayer.Sketch
 	return sketchInfo, nil
 }
 
+func ToModelNLSLayerFeature(f NLSLayerFeatureDocument) (*nlslayer.Feature, error) {
+	id, err := id.FeatureIDFrom(f.ID)
+	if err != nil {
+		return nil, err
+	}
+	geometry, err := ToModelNLSLayerGeometry(f.Geometry)
+	if err != nil {
+		return nil, err
+	}
+	feature, err := nlslayer.NewFeature(
+		id,
+		f.Type,
+		geometry,
+	)
+	if err != nil {
+		return nil, err
+	}
+	feature.UpdateProperties(&f.Properties)
+	return feature, nil
+}
+
 func ToModelNLSLayerGeometry(g map[string]any) (nlslayer.Geometry, error) {
 	if g == nil {
 		return nil, errors.New("geometry map is nil")
@@ -382,7 +421,7 @@ func ToModelNLSLayerGeometry(g map[string]any) (nlslayer.Geometry, error) {
 		return nil, errors.New("geometry type is missing or not a string")
 	}
 
-	if geometryType == "Point" || geometryType == "LineString" || geometryType == "Polygon" || geometryType == "MultiPolygon" {
+	if geometryType == "Point" || geometryType == "MultiPoint" || geometryType == "LineString" || geometryType == "MultiLineString" || geometryType == "Polygon" || geometryType == "MultiPolygon" {
 		rawCoordinates, ok := g["coordinates"]
 		if !ok {
 			return nil, errors.New("coordinates are missing")
@@ -400,12 +439,24 @@ func ToModelNLSLayerGeometry(g map[string]any) (nlslayer.Geometry, error) {
 				return nil, errors.New("invalid coordinates for Point")
 			}
 			return nlslayer.NewPoint(geometryType, coords), nil
+		case "MultiPoint":
+			coords, ok := coordinates.([][]float64)
+			if !ok {
+				return nil, errors.New("invalid coordinates for MultiPoint")
+			}
+			return nlslayer.NewMultiPoint(geometryType, coords), nil
 		case "LineString":
 			coords, ok := coordinates.([][]float64)
 			if !ok {
 				return nil, errors.New("invalid coordinates for LineString")
 			}
 			return nlslayer.NewLineString(geometryType, coords), nil
+		case "MultiLineString":
+			coords, ok := coordinates.([][][]float64)
+			if !ok {
+				return nil, errors.New("invalid coordinates for MultiLineString")
+			}
+			return nlslayer.NewMultiLineString(geometryType, coords), nil
 		case "Polygon":
 			coords, ok := coordinates.([][][]float64)
 			if !ok {
@@ -458,7 +509,7 @@ func convertCoordinates(geometryType string, rawCoordinates interface{}) (interf
 			coords = append(coords, coord)
 		}
 		return coords, nil
-	case "LineString":
+	case "MultiPoint", "LineString":
 		var coords [][]float64
 		for _, rawCoord := range rawCoordinates.(primitive.A) {
 			var coord []float64
@@ -472,7 +523,7 @@ func convertCoordinates(geometryType string, rawCoordinates interface{}) (interf
 			coords = append(coords, coord)
 		}
 		return coords, nil
-	case "Polygon":
+	case "MultiLineString", "Polygon":
 		var polygons [][][]float64
 		for _, rawPolygon := range rawCoordinates.(primitive.A) {
 			var polygon [][]float64
@@ -584,9 +635,15 @@ func NewNLSLayerGeometry(g nlslayer.Geometry) map[string]any {
 	case *nlslayer.Point:
 		gMap["type"] = g.PointType()
 		gMap["coordinates"] = g.Coordinates()
+	case *nlslayer.MultiPoint:
+		gMap["type"] = g.MultiPointType()
+		gMap["coordinates"] = g.Coordinates()
 	case *nlslayer.LineString:
 		gMap["type"] = g.LineStringType()
 		gMap["coordinates"] = g.Coordinates()
+	case *nlslayer.MultiLineString:
+		gMap["type"] = g.MultiLineStringType()
+		gMap["coordinates"] = g.Coordinates()
 	case *nlslayer.Polygon:
 		gMap["type"] = g.PolygonType()
 		gMap["coordinates"] = g.Coordinates()
@@ -6,6 +6,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -150,6 +151,36 @@ func TestToModelNLSLayerGeometry(t *testing.T) {
 			want:    nlslayer.NewMultiPolygon("MultiPolygon", [][][][]float64{{{{1.0, 2.0}, {3.0, 4.0}, {5.0, 6.0}, {1.0, 2.0}}}}),
 			wantErr: false,
 		},
+		{
+			name: "New multi point",
+			args: map[string]any{
+				"type": "MultiPoint",
+				"coordinates": primitive.A{
+					primitive.A{1.0, 2.0},
+					primitive.A{3.0, 4.0},
+				},
+			},
+			want:    nlslayer.NewMultiPoint("MultiPoint", [][]float64{{1.0, 2.0}, {3.0, 4.0}}),
+			wantErr: false,
+		},
+		{
+			name: "New multi line string",
+			args: map[string]any{
+				"type": "MultiLineString",
+				"coordinates": primitive.A{
+					primitive.A{
+						primitive.A{1.0, 2.0},
+						primitive.A{3.0, 4.0},
+					},
+					primitive.A{
+						primitive.A{5.0, 6.0},
+						primitive.A{7.0, 8.0},
+					},
+				},
+			},
+			want:    nlslayer.NewMultiLineString("MultiLineString", [][][]float64{{{1.0, 2.0}, {3.0, 4.0}}, {{5.0, 6.0}, {7.0, 8.0}}}),
+			wantErr: false,
+		},
 		{
 			name: "New geometry collection",
 			args: map[string]any{
@@ -273,6 +304,28 @@ func TestNewNLSLayerSketchInfo(t *testing.T) {
 	assert.Equal(t, expected, NewNLSLayerSketchInfo(si))
 }
 
+func TestNLSLayerSketchInfoDocument_CustomPropertySchema(t *testing.T) {
+	schema := map[string]any{
+		"name": "Text_1",
+		"kind": map[string]any{"type": "enum", "options": []any{"a", "b"}, "default": "a", "index": 2},
+	}
+
+	b, err := bson.Marshal(NewNLSLayerSketchInfo(nlslayer.NewSketchInfo(&schema, nil)))
+	assert.NoError(t, err)
+	var doc NLSLayerSketchInfoDocument
+	assert.NoError(t, bson.Unmarshal(b, &doc))
+	si, err := ToModelNLSLayerSketchInfo(&doc)
+	assert.NoError(t, err)
+
+	// enum options are decoded as primitive.A and still have to be accepted
+	res, err := si.TypedCustomPropertySchema()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, res.Field("kind").Options())
+	assert.Equal(t, "a", res.Field("kind").Default())
+	assert.Equal(t, 2, res.Field("kind").Index())
+	assert.Equal(t, nlslayer.CustomPropertyTypeString, res.Field("name").Type())
+}
+
 func TestNewNLSLayerFeatureCollection(t *testing.T) {
 	fid := id.NewFeatureID()
 	property := map[string]any{"key1": "value1"}
@@ -353,6 +406,22 @@ func TestNewNLSLayerGeometry(t *testing.T) {
 				"coordinates": [][]float64{{1, 2}, {3, 4}},
 			},
 		},
+		{
+			name: "New multi point",
+			args: nlslayer.NewMultiPoint("MultiPoint", [][]float64{{1, 2}, {3, 4}}),
+			want: map[string]any{
+				"type":        "MultiPoint",
+				"coordinates": [][]float64{{1, 2}, {3, 4}},
+			},
+		},
+		{
+			name: "New multi line string",
+			args: nlslayer.NewMultiLineString("MultiLineString", [][][]float64{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}}),
+			want: map[string]any{
+				"type":        "MultiLineString",
+				"coordinates": [][][]float64{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}},
+			},
+		},
 		{
 			name: "New polygon",
 			args: nlslayer.NewPolygon("Polygon", [][][]float64{{{1, 2}, {3, 4}, {5, 6}, {1, 2}}}),
@@ -417,3 +486,32 @@ func TestNewNLSLayerGeometry(t *testing.T) {
 		})
 	}
 }
+
+func TestNewNLSLayer_AssetURLs(t *testing.T) {
+	a := "https://example.com/assets/a.png"
+	b := "https://example.com/assets/b.png"
+	f, err := nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{1.0, 2.0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.UpdateProperties(&map[string]any{"image": b})
+	l := nlslayer.NewNLSLayerSimple().NewID().Scene(id.NewSceneID()).LayerType("simple").
+		Config(&nlslayer.Config{"data": map[string]any{"urls": []any{a}}}).
+		IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*f}))).
+		MustBuild()
+
+	doc, _ := NewNLSLayer(l)
+	assert.Equal(t, []string{a, b}, doc.AssetURLs)
+
+	// the layers loaded from the db have arrays of primitive.A and record the same URLs when they are saved again
+	raw, err := bson.Marshal(doc)
+	assert.NoError(t, err)
+	var loaded NLSLayerDocument
+	assert.NoError(t, bson.Unmarshal(raw, &loaded))
+	assert.IsType(t, primitive.A{}, loaded.Simple.Config["data"].(map[string]any)["urls"])
+	m, err := loaded.Model()
+	assert.NoError(t, err)
+	doc2, _ := NewNLSLayer(m)
+	assert.Equal(t, []string{a, b}, doc2.AssetURLs)
+}
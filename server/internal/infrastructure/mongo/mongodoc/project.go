@@ -5,6 +5,7 @@ import (
 	"time"
 
 	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/asset"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/visualizer"
@@ -22,13 +23,16 @@ type ProjectDocument struct {
 	Archived     bool
 	CoreSupport  bool
 	Starred      bool
+	Template     bool
 	Deleted      bool
+	DeletedAt    *time.Time
 	Visibility   string
 	ProjectAlias string
 	// publishment
 	Alias             string
 	PublishmentStatus string
 	PublishedAt       time.Time
+	PublishedVersion  int
 	PublicTitle       string
 	PublicDescription string
 	PublicImage       string
@@ -39,6 +43,8 @@ type ProjectDocument struct {
 	BasicAuthPassword string
 	EnableGA          bool
 	TrackingID        string
+	// AssetURLs are the URLs of the images, so that the projects can be looked up by asset URL.
+	AssetURLs []string `bson:",omitempty"`
 }
 
 type ProjectConsumer = Consumer[*ProjectDocument, *project.Project]
@@ -68,13 +74,16 @@ func NewProject(p *project.Project) (*ProjectDocument, string) {
 		Archived:     p.IsArchived(),
 		CoreSupport:  p.CoreSupport(),
 		Starred:      p.Starred(),
+		Template:     p.IsTemplate(),
 		Deleted:      p.IsDeleted(),
+		DeletedAt:    p.DeletedAt(),
 		Visibility:   p.Visibility(),
 		ProjectAlias: p.ProjectAlias(),
 		// publishment
 		Alias:             p.Alias(),
 		PublishmentStatus: string(p.PublishmentStatus()),
 		PublishedAt:       p.PublishedAt(),
+		PublishedVersion:  p.PublishedVersion(),
 		PublicTitle:       p.PublicTitle(),
 		PublicDescription: p.PublicDescription(),
 		PublicImage:       p.PublicImage(),
@@ -85,6 +94,7 @@ func NewProject(p *project.Project) (*ProjectDocument, string) {
 		BasicAuthPassword: p.BasicAuthPassword(),
 		EnableGA:          p.EnableGA(),
 		TrackingID:        p.TrackingID(),
+		AssetURLs:         asset.URLs(imageURL, p.PublicImage(), p.PublicIconImage()),
 	}, pid
 }
 
@@ -121,13 +131,16 @@ func (d *ProjectDocument) Model() (*project.Project, error) {
 		IsArchived(d.Archived).
 		CoreSupport(d.CoreSupport).
 		Starred(d.Starred).
+		Template(d.Template).
 		Deleted(d.Deleted).
+		DeletedAt(d.DeletedAt).
 		Visibility(project.Visibility(d.Visibility)).
 		ProjectAlias(d.ProjectAlias).
 		// publishment
 		Alias(d.Alias).
 		PublishmentStatus(project.PublishmentStatus(d.PublishmentStatus)).
 		PublishedAt(d.PublishedAt).
+		PublishedVersion(d.PublishedVersion).
 		PublicTitle(d.PublicTitle).
 		PublicDescription(d.PublicDescription).
 		PublicImage(d.PublicImage).
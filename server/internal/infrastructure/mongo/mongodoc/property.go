@@ -4,6 +4,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"golang.org/x/exp/slices"
 
+	"github.com/reearth/reearth/server/pkg/asset"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearthx/mongox"
@@ -21,6 +22,8 @@ type PropertyDocument struct {
 	SchemaPlugin string
 	SchemaName   string
 	Items        []*PropertyItemDocument
+	// AssetURLs are the URLs in the field values, so that the properties can be looked up by asset URL.
+	AssetURLs []string `bson:",omitempty"`
 }
 
 type PropertyFieldDocument struct {
@@ -144,9 +147,26 @@ func NewProperty(property *property.Property) (*PropertyDocument, string) {
 	for _, f := range items {
 		doc.Items = append(doc.Items, newPropertyItem(f))
 	}
+	doc.AssetURLs = asset.URLs(propertyItemValues(doc.Items)...)
 	return &doc, pid
 }
 
+func propertyItemValues(items []*PropertyItemDocument) []any {
+	var res []any
+	for _, i := range items {
+		if i == nil {
+			continue
+		}
+		for _, f := range i.Fields {
+			if f != nil {
+				res = append(res, f.Value)
+			}
+		}
+		res = append(res, propertyItemValues(i.Groups)...)
+	}
+	return res
+}
+
 func NewProperties(properties []*property.Property, f id.SceneIDList) ([]interface{}, []string) {
 	if properties == nil {
 		return nil, nil
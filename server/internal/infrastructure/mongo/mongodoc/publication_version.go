@@ -0,0 +1,83 @@
+package mongodoc
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"golang.org/x/exp/slices"
+)
+
+type PublicationVersionDocument struct {
+	ID      string
+	Scene   string
+	Project *string
+	Story   *string
+	// Target is the ID of the project or the story, which is unique together with Version
+	Target      string
+	Version     int
+	Alias       string
+	PublishedAt time.Time
+	PublishedBy *string
+	Message     string
+}
+
+type PublicationVersionConsumer = Consumer[*PublicationVersionDocument, *publication.Version]
+
+func NewPublicationVersionConsumer(scenes []id.SceneID) *PublicationVersionConsumer {
+	return NewConsumer[*PublicationVersionDocument, *publication.Version](func(a *publication.Version) bool {
+		return scenes == nil || slices.Contains(scenes, a.Scene())
+	})
+}
+
+func NewPublicationVersion(v *publication.Version) (*PublicationVersionDocument, string) {
+	vid := v.ID().String()
+	doc := &PublicationVersionDocument{
+		ID:          vid,
+		Scene:       v.Scene().String(),
+		Project:     v.Project().StringRef(),
+		Story:       v.Story().StringRef(),
+		Target:      v.Target(),
+		Version:     v.Version(),
+		Alias:       v.Alias(),
+		PublishedAt: v.PublishedAt(),
+		Message:     v.Message(),
+	}
+	if v.PublishedBy() != nil {
+		doc.PublishedBy = v.PublishedBy().StringRef()
+	}
+	return doc, vid
+}
+
+func (d *PublicationVersionDocument) Model() (*publication.Version, error) {
+	vid, err := id.PublicationVersionIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+
+	var publishedBy *accountsID.UserID
+	if d.PublishedBy != nil {
+		uid, err := accountsID.UserIDFrom(*d.PublishedBy)
+		if err != nil {
+			return nil, err
+		}
+		publishedBy = &uid
+	}
+
+	return publication.NewVersion().
+		ID(vid).
+		Scene(sid).
+		Project(id.ProjectIDFromRef(d.Project)).
+		Story(id.StoryIDFromRef(d.Story)).
+		Version(d.Version).
+		Alias(d.Alias).
+		PublishedAt(d.PublishedAt).
+		PublishedBy(publishedBy).
+		Message(d.Message).
+		Build()
+}
@@ -0,0 +1,89 @@
+package mongodoc
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"golang.org/x/exp/slices"
+)
+
+type PublishScheduleDocument struct {
+	ID          string
+	Scene       string
+	Project     *string
+	Story       *string
+	Alias       string
+	Status      string
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	State       string
+	LastError   string
+	CreatedBy   *string
+	// NextRunAt is persisted so that due schedules can be queried
+	NextRunAt *time.Time
+}
+
+type PublishScheduleConsumer = Consumer[*PublishScheduleDocument, *publication.Schedule]
+
+func NewPublishScheduleConsumer(scenes []id.SceneID) *PublishScheduleConsumer {
+	return NewConsumer[*PublishScheduleDocument, *publication.Schedule](func(a *publication.Schedule) bool {
+		return scenes == nil || slices.Contains(scenes, a.Scene())
+	})
+}
+
+func NewPublishSchedule(s *publication.Schedule) (*PublishScheduleDocument, string) {
+	sid := s.ID().String()
+	doc := &PublishScheduleDocument{
+		ID:          sid,
+		Scene:       s.Scene().String(),
+		Project:     s.Project().StringRef(),
+		Story:       s.Story().StringRef(),
+		Alias:       s.Alias(),
+		Status:      string(s.Status()),
+		PublishAt:   s.PublishAt(),
+		UnpublishAt: s.UnpublishAt(),
+		State:       string(s.State()),
+		LastError:   s.LastError(),
+		NextRunAt:   s.NextRunAt(),
+	}
+	if s.CreatedBy() != nil {
+		doc.CreatedBy = s.CreatedBy().StringRef()
+	}
+	return doc, sid
+}
+
+func (d *PublishScheduleDocument) Model() (*publication.Schedule, error) {
+	psid, err := id.PublishScheduleIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBy *accountsID.UserID
+	if d.CreatedBy != nil {
+		uid, err := accountsID.UserIDFrom(*d.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		createdBy = &uid
+	}
+
+	return publication.NewSchedule().
+		ID(psid).
+		Scene(sid).
+		Project(id.ProjectIDFromRef(d.Project)).
+		Story(id.StoryIDFromRef(d.Story)).
+		Alias(d.Alias).
+		Status(publication.ScheduleStatus(d.Status)).
+		PublishAt(d.PublishAt).
+		UnpublishAt(d.UnpublishAt).
+		State(publication.ScheduleState(d.State)).
+		LastError(d.LastError).
+		CreatedBy(createdBy).
+		Build()
+}
@@ -0,0 +1,80 @@
+package mongodoc
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"golang.org/x/exp/slices"
+)
+
+type ShareTokenDocument struct {
+	ID        string
+	Scene     string
+	Project   *string
+	Story     *string
+	Name      string
+	TokenHash string
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+	CreatedBy *string
+}
+
+type ShareTokenConsumer = Consumer[*ShareTokenDocument, *publication.ShareToken]
+
+func NewShareTokenConsumer(scenes []id.SceneID) *ShareTokenConsumer {
+	return NewConsumer[*ShareTokenDocument, *publication.ShareToken](func(a *publication.ShareToken) bool {
+		return scenes == nil || slices.Contains(scenes, a.Scene())
+	})
+}
+
+func NewShareToken(t *publication.ShareToken) (*ShareTokenDocument, string) {
+	tid := t.ID().String()
+	doc := &ShareTokenDocument{
+		ID:        tid,
+		Scene:     t.Scene().String(),
+		Project:   t.Project().StringRef(),
+		Story:     t.Story().StringRef(),
+		Name:      t.Name(),
+		TokenHash: t.Hash(),
+		ExpiresAt: t.ExpiresAt(),
+		RevokedAt: t.RevokedAt(),
+	}
+	if t.CreatedBy() != nil {
+		doc.CreatedBy = t.CreatedBy().StringRef()
+	}
+	return doc, tid
+}
+
+func (d *ShareTokenDocument) Model() (*publication.ShareToken, error) {
+	tid, err := id.ShareTokenIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	sid, err := id.SceneIDFrom(d.Scene)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBy *accountsID.UserID
+	if d.CreatedBy != nil {
+		uid, err := accountsID.UserIDFrom(*d.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		createdBy = &uid
+	}
+
+	return publication.NewShareToken().
+		ID(tid).
+		Scene(sid).
+		Project(id.ProjectIDFromRef(d.Project)).
+		Story(id.StoryIDFromRef(d.Story)).
+		Name(d.Name).
+		Hash(d.TokenHash).
+		ExpiresAt(d.ExpiresAt).
+		RevokedAt(d.RevokedAt).
+		CreatedBy(createdBy).
+		Build()
+}
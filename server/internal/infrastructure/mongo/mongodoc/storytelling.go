@@ -25,6 +25,7 @@ type StorytellingDocument struct {
 	Alias             string
 	Status            string
 	PublishedAt       *time.Time
+	PublishedVersion  int
 	PublicTitle       string
 	PublicDescription string
 	PublicImage       string
@@ -72,7 +73,7 @@ func NewStorytelling(s *storytelling.Story) (*StorytellingDocument, string) {
 		Title:         s.Title(),
 		Pages:         newPages(s.Pages()),
 		UpdatedAt:     s.UpdatedAt(),
-		Index:         1,
+		Index:         s.Index(),
 		PanelPosition: string(s.PanelPosition()),
 		BgColor:       s.BgColor(),
 
@@ -80,6 +81,7 @@ func NewStorytelling(s *storytelling.Story) (*StorytellingDocument, string) {
 		Alias:             s.Alias(),
 		Status:            string(s.PublishmentStatus()),
 		PublishedAt:       s.PublishedAt(),
+		PublishedVersion:  s.PublishedVersion(),
 		PublicTitle:       s.PublicTitle(),
 		PublicDescription: s.PublicDescription(),
 		PublicImage:       s.PublicImage(),
@@ -186,6 +188,7 @@ func (d *StorytellingDocument) Model() (*storytelling.Story, error) {
 		Property(property).
 		Scene(scene).
 		Title(d.Title).
+		Index(d.Index).
 		PanelPosition(storytelling.Position(d.PanelPosition)).
 		BgColor(d.BgColor).
 		UpdatedAt(d.UpdatedAt).
@@ -195,6 +198,7 @@ func (d *StorytellingDocument) Model() (*storytelling.Story, error) {
 		Alias(d.Alias).
 		Status(storytelling.PublishmentStatus(d.Status)).
 		PublishedAt(d.PublishedAt).
+		PublishedVersion(d.PublishedVersion).
 		PublicBasicAuth(
 			d.IsBasicAuthActive,
 			d.BasicAuthUsername,
@@ -1,6 +1,7 @@
 package mongodoc
 
 import (
+	"github.com/reearth/reearth/server/pkg/asset"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"golang.org/x/exp/slices"
@@ -11,6 +12,8 @@ type StyleDocument struct {
 	Name  string
 	Value map[string]any
 	Scene string
+	// AssetURLs are the URLs in the value, so that the styles can be looked up by asset URL.
+	AssetURLs []string `bson:",omitempty"`
 }
 
 type StyleConsumer = Consumer[*StyleDocument, *scene.Style]
@@ -24,10 +27,11 @@ func NewStyleConsumer(scenes []id.SceneID) *StyleConsumer {
 func NewStyle(s scene.Style) (*StyleDocument, string) {
 	id := s.ID().String()
 	return &StyleDocument{
-		ID:    id,
-		Name:  s.Name(),
-		Value: *s.Value(),
-		Scene: s.Scene().String(),
+		ID:        id,
+		Name:      s.Name(),
+		Value:     *s.Value(),
+		Scene:     s.Scene().String(),
+		AssetURLs: asset.URLs(map[string]any(*s.Value())),
 	}, id
 }
 
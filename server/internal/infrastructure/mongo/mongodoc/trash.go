@@ -0,0 +1,112 @@
+package mongodoc
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/trash"
+	"golang.org/x/exp/slices"
+)
+
+type TrashPolicyDocument struct {
+	Workspace string
+	// Retention is in seconds
+	Retention int64
+	UpdatedAt time.Time
+}
+
+type TrashPolicyConsumer = Consumer[*TrashPolicyDocument, *trash.Policy]
+
+func NewTrashPolicyConsumer(workspaces []accountsID.WorkspaceID) *TrashPolicyConsumer {
+	return NewConsumer[*TrashPolicyDocument, *trash.Policy](func(a *trash.Policy) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewTrashPolicy(p *trash.Policy) (*TrashPolicyDocument, string) {
+	wid := p.Workspace().String()
+	return &TrashPolicyDocument{
+		Workspace: wid,
+		Retention: int64(p.Retention() / time.Second),
+		UpdatedAt: p.UpdatedAt(),
+	}, wid
+}
+
+func (d *TrashPolicyDocument) Model() (*trash.Policy, error) {
+	wid, err := accountsID.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	return trash.NewPolicy(wid, time.Duration(d.Retention)*time.Second, d.UpdatedAt)
+}
+
+type TrashPurgeDocument struct {
+	ID          string
+	Workspace   string
+	Project     string
+	ProjectName string
+	Reason      string
+	DeletedAt   *time.Time
+	PurgedBy    *string
+	PurgedAt    time.Time
+}
+
+type TrashPurgeConsumer = Consumer[*TrashPurgeDocument, *trash.Purge]
+
+func NewTrashPurgeConsumer(workspaces []accountsID.WorkspaceID) *TrashPurgeConsumer {
+	return NewConsumer[*TrashPurgeDocument, *trash.Purge](func(a *trash.Purge) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewTrashPurge(p *trash.Purge) (*TrashPurgeDocument, string) {
+	pid := p.ID().String()
+	doc := &TrashPurgeDocument{
+		ID:          pid,
+		Workspace:   p.Workspace().String(),
+		Project:     p.Project().String(),
+		ProjectName: p.ProjectName(),
+		Reason:      string(p.Reason()),
+		DeletedAt:   p.DeletedAt(),
+		PurgedAt:    p.PurgedAt(),
+	}
+	if p.PurgedBy() != nil {
+		doc.PurgedBy = p.PurgedBy().StringRef()
+	}
+	return doc, pid
+}
+
+func (d *TrashPurgeDocument) Model() (*trash.Purge, error) {
+	tpid, err := id.TrashPurgeIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := accountsID.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := id.ProjectIDFrom(d.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	var purgedBy *accountsID.UserID
+	if d.PurgedBy != nil {
+		uid, err := accountsID.UserIDFrom(*d.PurgedBy)
+		if err != nil {
+			return nil, err
+		}
+		purgedBy = &uid
+	}
+
+	return trash.NewPurge().
+		ID(tpid).
+		Workspace(wid).
+		Project(pid).
+		ProjectName(d.ProjectName).
+		Reason(trash.PurgeReason(d.Reason)).
+		DeletedAt(d.DeletedAt).
+		PurgedBy(purgedBy).
+		Build()
+}
@@ -0,0 +1,93 @@
+package mongodoc
+
+import (
+	"net/url"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"golang.org/x/exp/slices"
+)
+
+type WebhookDocument struct {
+	ID        string
+	Workspace string
+	Name      string
+	URL       string
+	Secret    string
+	Events    []string
+	Enabled   bool
+	UpdatedAt time.Time
+	CreatedBy *string
+}
+
+type WebhookConsumer = Consumer[*WebhookDocument, *webhook.Webhook]
+
+func NewWebhookConsumer(workspaces []accountsID.WorkspaceID) *WebhookConsumer {
+	return NewConsumer[*WebhookDocument, *webhook.Webhook](func(a *webhook.Webhook) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewWebhook(w *webhook.Webhook) (*WebhookDocument, string) {
+	wid := w.ID().String()
+	events := make([]string, 0, len(w.Events()))
+	for _, e := range w.Events() {
+		events = append(events, string(e))
+	}
+	doc := &WebhookDocument{
+		ID:        wid,
+		Workspace: w.Workspace().String(),
+		Name:      w.Name(),
+		URL:       w.URL().String(),
+		Secret:    w.Secret(),
+		Events:    events,
+		Enabled:   w.Enabled(),
+		UpdatedAt: w.UpdatedAt(),
+	}
+	if w.CreatedBy() != nil {
+		doc.CreatedBy = w.CreatedBy().StringRef()
+	}
+	return doc, wid
+}
+
+func (d *WebhookDocument) Model() (*webhook.Webhook, error) {
+	wid, err := id.WebhookIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	wsid, err := accountsID.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return nil, err
+	}
+	events, err := webhook.EventTypesFrom(d.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBy *accountsID.UserID
+	if d.CreatedBy != nil {
+		uid, err := accountsID.UserIDFrom(*d.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		createdBy = &uid
+	}
+
+	return webhook.New().
+		ID(wid).
+		Workspace(wsid).
+		Name(d.Name).
+		URL(u).
+		Secret(d.Secret).
+		Events(events).
+		Enabled(d.Enabled).
+		UpdatedAt(d.UpdatedAt).
+		CreatedBy(createdBy).
+		Build()
+}
@@ -0,0 +1,97 @@
+package mongodoc
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"golang.org/x/exp/slices"
+)
+
+type WebhookDeliveryDocument struct {
+	ID        string
+	Webhook   string
+	Workspace string
+	Event     string
+	Payload   string
+	Status    string
+	Attempts  []WebhookDeliveryAttemptDocument
+	// NextAttemptAt is unset once the delivery is finished, so that due deliveries can be queried
+	NextAttemptAt *time.Time
+}
+
+type WebhookDeliveryAttemptDocument struct {
+	At         time.Time
+	StatusCode int
+	Error      string
+	// Duration is in milliseconds
+	Duration int64
+}
+
+type WebhookDeliveryConsumer = Consumer[*WebhookDeliveryDocument, *webhook.Delivery]
+
+func NewWebhookDeliveryConsumer(workspaces []accountsID.WorkspaceID) *WebhookDeliveryConsumer {
+	return NewConsumer[*WebhookDeliveryDocument, *webhook.Delivery](func(a *webhook.Delivery) bool {
+		return workspaces == nil || slices.Contains(workspaces, a.Workspace())
+	})
+}
+
+func NewWebhookDelivery(d *webhook.Delivery) (*WebhookDeliveryDocument, string) {
+	did := d.ID().String()
+	attempts := make([]WebhookDeliveryAttemptDocument, 0, len(d.Attempts()))
+	for _, a := range d.Attempts() {
+		attempts = append(attempts, WebhookDeliveryAttemptDocument{
+			At:         a.At,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			Duration:   a.Duration.Milliseconds(),
+		})
+	}
+	return &WebhookDeliveryDocument{
+		ID:            did,
+		Webhook:       d.Webhook().String(),
+		Workspace:     d.Workspace().String(),
+		Event:         string(d.Event()),
+		Payload:       string(d.Payload()),
+		Status:        string(d.Status()),
+		Attempts:      attempts,
+		NextAttemptAt: d.NextAttemptAt(),
+	}, did
+}
+
+func (d *WebhookDeliveryDocument) Model() (*webhook.Delivery, error) {
+	did, err := id.WebhookDeliveryIDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := id.WebhookIDFrom(d.Webhook)
+	if err != nil {
+		return nil, err
+	}
+	wsid, err := accountsID.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]webhook.Attempt, 0, len(d.Attempts))
+	for _, a := range d.Attempts {
+		attempts = append(attempts, webhook.Attempt{
+			At:         a.At,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			Duration:   time.Duration(a.Duration) * time.Millisecond,
+		})
+	}
+
+	return webhook.NewDelivery().
+		ID(did).
+		Webhook(wid).
+		Workspace(wsid).
+		Event(webhook.EventType(d.Event)).
+		Payload([]byte(d.Payload)).
+		Status(webhook.DeliveryStatus(d.Status)).
+		Attempts(attempts).
+		NextAttemptAt(d.NextAttemptAt).
+		Build()
+}
@@ -25,6 +25,7 @@ var (
 		"infobox.property,scene",
 		"infobox.blocks.property",
 		"infobox.blocks.property,scene",
+		"asseturls",
 	}
 	nlsLayerUniqueIndexes = []string{"id"}
 )
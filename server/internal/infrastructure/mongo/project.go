@@ -27,7 +27,7 @@ import (
 )
 
 var (
-	projectIndexes       = []string{"alias", "alias,publishmentstatus", "workspace"}
+	projectIndexes       = []string{"alias", "alias,publishmentstatus", "workspace", "deleted,deletedat", "asseturls"}
 	projectUniqueIndexes = []string{"id"}
 )
 
@@ -485,6 +485,25 @@ func (r *Project) FindStarredByWorkspace(ctx context.Context, id accountsID.Work
 	return r.paginate(ctx, filter, sort, defaultPagination(p))
 }
 
+func (r *Project) FindTemplatesByWorkspace(ctx context.Context, id accountsID.WorkspaceID, p *usecasex.Pagination) ([]*project.Project, *usecasex.PageInfo, error) {
+	if !r.f.CanRead(id) {
+		return nil, nil, repo.ErrOperationDenied
+	}
+
+	filter := bson.M{
+		"workspace": id.String(),
+		"template":  true,
+		"$or": []bson.M{
+			{"deleted": false},
+			{"deleted": bson.M{"$exists": false}},
+		},
+		"coresupport": true,
+	}
+
+	sort := &project.SortType{Key: project.SortTypeUpdatedAt.Key, Desc: true}
+	return r.paginate(ctx, filter, sort, defaultPagination(p))
+}
+
 func (r *Project) FindDeletedByWorkspace(ctx context.Context, id accountsID.WorkspaceID, p *usecasex.Pagination) ([]*project.Project, *usecasex.PageInfo, error) {
 	if !r.f.CanRead(id) {
 		return nil, nil, repo.ErrOperationDenied
@@ -500,6 +519,20 @@ func (r *Project) FindDeletedByWorkspace(ctx context.Context, id accountsID.Work
 	return r.paginate(ctx, filter, sort, defaultPagination(p))
 }
 
+func (r *Project) FindDeletedBefore(ctx context.Context, before time.Time) ([]*project.Project, error) {
+	// projects deleted before deletedat was recorded are given one by the SetProjectDeletedAt migration
+	filter := applyWorkspaceFilter(bson.M{
+		"deleted":   true,
+		"deletedat": bson.M{"$lte": before},
+	}, r.f.Readable)
+
+	c := mongodoc.NewProjectConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, filter, c, options.Find().SetSort(bson.D{{Key: "deletedat", Value: 1}})); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
 func (r *Project) FindActiveById(ctx context.Context, id id.ProjectID) (*project.Project, error) {
 	prj, err := r.findOne(ctx, bson.M{
 		"id":      id.String(),
@@ -302,6 +302,91 @@ func TestProject_FindStarredByWorkspace(t *testing.T) {
 	})
 }
 
+func TestProject_FindTemplatesByWorkspace(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+
+	wid := accountsID.NewWorkspaceID()
+	wid2 := accountsID.NewWorkspaceID()
+
+	pid1 := id.NewProjectID()
+	pid2 := id.NewProjectID()
+	pid3 := id.NewProjectID()
+	pid4 := id.NewProjectID()
+	pid5 := id.NewProjectID()
+
+	now := time.Now()
+	_, _ = c.Collection("project").InsertMany(ctx, []any{
+		bson.M{"id": pid1.String(), "workspace": wid.String(), "name": "Project 1", "template": true, "coresupport": true, "updatedat": now.Add(-2 * time.Hour)},
+		bson.M{"id": pid2.String(), "workspace": wid.String(), "name": "Project 2", "template": true, "coresupport": true, "updatedat": now},
+		bson.M{"id": pid3.String(), "workspace": wid.String(), "name": "Project 3", "template": false, "coresupport": true, "updatedat": now.Add(-1 * time.Hour)},
+		bson.M{"id": pid4.String(), "workspace": wid.String(), "name": "Project 4", "template": true, "deleted": true, "coresupport": true, "updatedat": now},
+		bson.M{"id": pid5.String(), "workspace": wid2.String(), "name": "Project 5", "template": true, "coresupport": true, "updatedat": now},
+	})
+
+	r := NewProject(mongox.NewClientWithDatabase(c))
+
+	t.Run("FindTemplatesByWorkspace orders by last updated time descending", func(t *testing.T) {
+		got, pi, err := r.FindTemplatesByWorkspace(ctx, wid, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, pi)
+		assert.Equal(t, int64(2), pi.TotalCount)
+		require.Len(t, got, 2)
+		assert.Equal(t, []id.ProjectID{pid2, pid1}, []id.ProjectID{got[0].ID(), got[1].ID()})
+		assert.True(t, got[0].IsTemplate())
+	})
+
+	t.Run("FindTemplatesByWorkspace with workspace filter", func(t *testing.T) {
+		r2 := r.Filtered(repo.WorkspaceFilter{
+			Readable: accountsID.WorkspaceIDList{wid2},
+		})
+		got, _, err := r2.FindTemplatesByWorkspace(ctx, wid, nil)
+		assert.Equal(t, repo.ErrOperationDenied, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("FindTemplatesByWorkspace with different workspace", func(t *testing.T) {
+		got, _, err := r.FindTemplatesByWorkspace(ctx, wid2, nil)
+		assert.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, pid5, got[0].ID())
+	})
+}
+
+func TestProject_FindDeletedBefore(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+
+	wid := accountsID.NewWorkspaceID()
+	wid2 := accountsID.NewWorkspaceID()
+
+	pid1 := id.NewProjectID()
+	pid2 := id.NewProjectID()
+	pid3 := id.NewProjectID()
+	pid4 := id.NewProjectID()
+
+	now := time.Now().Truncate(time.Millisecond)
+	_, _ = c.Collection("project").InsertMany(ctx, []any{
+		bson.M{"id": pid1.String(), "workspace": wid.String(), "deleted": true, "deletedat": now.Add(-1 * time.Hour)},
+		bson.M{"id": pid2.String(), "workspace": wid2.String(), "deleted": true, "deletedat": now.Add(-2 * time.Hour)},
+		bson.M{"id": pid3.String(), "workspace": wid.String(), "deleted": true, "deletedat": now.Add(time.Hour)},
+		bson.M{"id": pid4.String(), "workspace": wid.String(), "deleted": false},
+	})
+
+	r := NewProject(mongox.NewClientWithDatabase(c))
+
+	got, err := r.FindDeletedBefore(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, []id.ProjectID{pid2, pid1}, []id.ProjectID{got[0].ID(), got[1].ID()})
+	assert.True(t, now.Add(-2*time.Hour).Equal(*got[0].DeletedAt()))
+
+	got, err = r.Filtered(repo.WorkspaceFilter{Readable: accountsID.WorkspaceIDList{wid}}).FindDeletedBefore(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, pid1, got[0].ID())
+}
+
 func TestProject_FindDeletedByWorkspace(t *testing.T) {
 	c := mongotest.Connect(t)(t)
 	ctx := context.Background()
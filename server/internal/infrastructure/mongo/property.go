@@ -33,6 +33,7 @@ var (
 		"items.groups.fields.links.schema,scene",
 		"items.groups.fields.links.dataset",
 		"items.groups.fields.links.dataset,scene",
+		"asseturls",
 	}
 	propertyUniqueIndexes = []string{"id"}
 )
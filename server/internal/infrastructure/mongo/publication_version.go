@@ -0,0 +1,100 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	publicationVersionIndexes       = []string{"scene"}
+	publicationVersionUniqueIndexes = []string{"id", "target,version"}
+)
+
+type PublicationVersion struct {
+	client *mongox.ClientCollection
+	f      repo.SceneFilter
+}
+
+func NewPublicationVersion(client *mongox.Client) *PublicationVersion {
+	return &PublicationVersion{
+		client: client.WithCollection("publicationVersion"),
+	}
+}
+
+func (r *PublicationVersion) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, publicationVersionIndexes, publicationVersionUniqueIndexes)
+}
+
+func (r *PublicationVersion) Filtered(f repo.SceneFilter) repo.PublicationVersion {
+	return &PublicationVersion{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *PublicationVersion) FindByProject(ctx context.Context, pid id.ProjectID) ([]*publication.Version, error) {
+	return r.find(ctx, bson.M{"target": pid.String()})
+}
+
+func (r *PublicationVersion) FindByStory(ctx context.Context, sid id.StoryID) ([]*publication.Version, error) {
+	return r.find(ctx, bson.M{"target": sid.String()})
+}
+
+func (r *PublicationVersion) FindProjectVersion(ctx context.Context, pid id.ProjectID, version int) (*publication.Version, error) {
+	return r.findOne(ctx, bson.M{"target": pid.String(), "version": version})
+}
+
+func (r *PublicationVersion) FindStoryVersion(ctx context.Context, sid id.StoryID, version int) (*publication.Version, error) {
+	return r.findOne(ctx, bson.M{"target": sid.String(), "version": version})
+}
+
+func (r *PublicationVersion) Save(ctx context.Context, v *publication.Version) error {
+	if !r.f.CanWrite(v.Scene()) {
+		return repo.ErrOperationDenied
+	}
+	doc, vid := mongodoc.NewPublicationVersion(v)
+	if err := r.client.SaveOne(ctx, vid, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return repo.ErrDuplicatedPublicationVersion
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *PublicationVersion) RemoveByScene(ctx context.Context, sid id.SceneID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"scene": sid.String()}))
+}
+
+func (r *PublicationVersion) findOne(ctx context.Context, filter any) (*publication.Version, error) {
+	c := mongodoc.NewPublicationVersionConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, r.readFilter(filter), c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *PublicationVersion) find(ctx context.Context, filter any) ([]*publication.Version, error) {
+	c := mongodoc.NewPublicationVersionConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, r.readFilter(filter), c, options.Find().SetSort(bson.D{{Key: "version", Value: -1}})); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *PublicationVersion) readFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Readable)
+}
+
+func (r *PublicationVersion) writeFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Writable)
+}
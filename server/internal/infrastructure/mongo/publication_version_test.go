@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicationVersion(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	r := NewPublicationVersion(mongox.NewClientWithDatabase(c))
+	require.NoError(t, r.Init(ctx))
+
+	sid := id.NewSceneID()
+	pid := id.NewProjectID()
+	stid := id.NewStoryID()
+	uid := accountsID.NewUserID()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	v1 := publication.NewVersion().NewID().Scene(sid).Project(&pid).Version(1).Alias("foo").
+		PublishedAt(now.Add(-time.Hour)).PublishedBy(&uid).Message("first").MustBuild()
+	v2 := publication.NewVersion().NewID().Scene(sid).Project(&pid).Version(2).Alias("foo").
+		PublishedAt(now).MustBuild()
+	v3 := publication.NewVersion().NewID().Scene(sid).Story(&stid).Version(1).Alias("bar").
+		PublishedAt(now).MustBuild()
+	for _, v := range []*publication.Version{v1, v2, v3} {
+		require.NoError(t, r.Save(ctx, v))
+	}
+
+	list, err := r.FindByProject(ctx, pid)
+	assert.NoError(t, err)
+	assert.Equal(t, []id.PublicationVersionID{v2.ID(), v1.ID()}, []id.PublicationVersionID{list[0].ID(), list[1].ID()})
+
+	got, err := r.FindProjectVersion(ctx, pid, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, v1.ID(), got.ID())
+	assert.Equal(t, "first", got.Message())
+	assert.Equal(t, &uid, got.PublishedBy())
+	assert.True(t, now.Add(-time.Hour).Equal(got.PublishedAt()))
+
+	got, err = r.FindStoryVersion(ctx, stid, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, v3.ID(), got.ID())
+	assert.Equal(t, v3.SnapshotName(), got.SnapshotName())
+
+	_, err = r.FindStoryVersion(ctx, stid, 2)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	dup := publication.NewVersion().NewID().Scene(sid).Project(&pid).Version(2).Alias("foo").MustBuild()
+	assert.ErrorIs(t, r.Save(ctx, dup), repo.ErrDuplicatedPublicationVersion)
+
+	other := r.Filtered(repo.SceneFilter{Readable: id.SceneIDList{id.NewSceneID()}})
+	list, err = other.FindByProject(ctx, pid)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
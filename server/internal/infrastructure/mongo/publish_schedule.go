@@ -0,0 +1,92 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	publishScheduleIndexes       = []string{"scene", "project", "story", "nextrunat"}
+	publishScheduleUniqueIndexes = []string{"id"}
+)
+
+type PublishSchedule struct {
+	client *mongox.ClientCollection
+	f      repo.SceneFilter
+}
+
+func NewPublishSchedule(client *mongox.Client) *PublishSchedule {
+	return &PublishSchedule{
+		client: client.WithCollection("publishSchedule"),
+	}
+}
+
+func (r *PublishSchedule) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, publishScheduleIndexes, publishScheduleUniqueIndexes)
+}
+
+func (r *PublishSchedule) Filtered(f repo.SceneFilter) repo.PublishSchedule {
+	return &PublishSchedule{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *PublishSchedule) FindByID(ctx context.Context, id id.PublishScheduleID) (*publication.Schedule, error) {
+	c := mongodoc.NewPublishScheduleConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, bson.M{"id": id.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *PublishSchedule) FindByProject(ctx context.Context, pid id.ProjectID) ([]*publication.Schedule, error) {
+	// publish schedule IDs are ULIDs, so sorting by ID sorts by creation time
+	return r.find(ctx, bson.M{"project": pid.String()}, bson.D{{Key: "id", Value: -1}})
+}
+
+func (r *PublishSchedule) FindByStory(ctx context.Context, sid id.StoryID) ([]*publication.Schedule, error) {
+	return r.find(ctx, bson.M{"story": sid.String()}, bson.D{{Key: "id", Value: -1}})
+}
+
+func (r *PublishSchedule) FindDue(ctx context.Context, now time.Time) ([]*publication.Schedule, error) {
+	// nextrunat is unset once the schedule is finished, so $lte only matches unfinished schedules
+	return r.find(ctx, bson.M{"nextrunat": bson.M{"$lte": now}}, bson.D{{Key: "nextrunat", Value: 1}})
+}
+
+func (r *PublishSchedule) Save(ctx context.Context, s *publication.Schedule) error {
+	if !r.f.CanWrite(s.Scene()) {
+		return repo.ErrOperationDenied
+	}
+	doc, sid := mongodoc.NewPublishSchedule(s)
+	return r.client.SaveOne(ctx, sid, doc)
+}
+
+func (r *PublishSchedule) RemoveByScene(ctx context.Context, sid id.SceneID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"scene": sid.String()}))
+}
+
+func (r *PublishSchedule) find(ctx context.Context, filter any, sort bson.D) ([]*publication.Schedule, error) {
+	c := mongodoc.NewPublishScheduleConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, r.readFilter(filter), c, options.Find().SetSort(sort)); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *PublishSchedule) readFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Readable)
+}
+
+func (r *PublishSchedule) writeFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Writable)
+}
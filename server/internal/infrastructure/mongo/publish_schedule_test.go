@@ -0,0 +1,77 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishSchedule(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	r := NewPublishSchedule(mongox.NewClientWithDatabase(c))
+	require.NoError(t, r.Init(ctx))
+
+	sid := id.NewSceneID()
+	pid := id.NewProjectID()
+	stid := id.NewStoryID()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	past2 := now.Add(-2 * time.Hour)
+	soon := now.Add(30 * time.Minute)
+	future := now.Add(time.Hour)
+
+	s1 := publication.NewSchedule().NewID().Scene(sid).Project(&pid).Alias("foo").
+		Status(publication.ScheduleStatusPublic).PublishAt(&past).UnpublishAt(&future).MustBuild()
+	s2 := publication.NewSchedule().NewID().Scene(sid).Project(&pid).UnpublishAt(&past2).MustBuild()
+	s3 := publication.NewSchedule().NewID().Scene(sid).Story(&stid).
+		Status(publication.ScheduleStatusLimited).PublishAt(&soon).MustBuild()
+	for _, s := range []*publication.Schedule{s1, s2, s3} {
+		require.NoError(t, r.Save(ctx, s))
+	}
+
+	got, err := r.FindByID(ctx, s1.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", got.Alias())
+	assert.Equal(t, publication.ScheduleStatusPublic, got.Status())
+	assert.Equal(t, publication.ScheduleStatePending, got.State())
+	assert.True(t, future.Equal(*got.UnpublishAt()))
+
+	list, err := r.FindByProject(ctx, pid)
+	assert.NoError(t, err)
+	assert.Equal(t, []id.PublishScheduleID{s2.ID(), s1.ID()}, []id.PublishScheduleID{list[0].ID(), list[1].ID()})
+
+	list, err = r.FindByStory(ctx, stid)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, s3.ID(), list[0].ID())
+
+	list, err = r.FindDue(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []id.PublishScheduleID{s2.ID(), s1.ID()}, []id.PublishScheduleID{list[0].ID(), list[1].ID()})
+
+	require.NoError(t, s1.Done())
+	require.NoError(t, s2.Cancel())
+	require.NoError(t, r.Save(ctx, s1))
+	require.NoError(t, r.Save(ctx, s2))
+	list, err = r.FindDue(ctx, now)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	list, err = r.FindDue(ctx, future)
+	assert.NoError(t, err)
+	assert.Equal(t, []id.PublishScheduleID{s3.ID(), s1.ID()}, []id.PublishScheduleID{list[0].ID(), list[1].ID()})
+
+	assert.ErrorIs(t, r.Filtered(repo.SceneFilter{Writable: id.SceneIDList{}}).Save(ctx, s1), repo.ErrOperationDenied)
+	list, err = r.Filtered(repo.SceneFilter{Readable: id.SceneIDList{}}).FindByProject(ctx, pid)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
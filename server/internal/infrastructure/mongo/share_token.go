@@ -0,0 +1,94 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	shareTokenIndexes       = []string{"scene", "project", "story"}
+	shareTokenUniqueIndexes = []string{"id", "tokenhash"}
+)
+
+type ShareToken struct {
+	client *mongox.ClientCollection
+	f      repo.SceneFilter
+}
+
+func NewShareToken(client *mongox.Client) *ShareToken {
+	return &ShareToken{
+		client: client.WithCollection("shareToken"),
+	}
+}
+
+func (r *ShareToken) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, shareTokenIndexes, shareTokenUniqueIndexes)
+}
+
+func (r *ShareToken) Filtered(f repo.SceneFilter) repo.ShareToken {
+	return &ShareToken{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *ShareToken) FindByID(ctx context.Context, id id.ShareTokenID) (*publication.ShareToken, error) {
+	return r.findOne(ctx, bson.M{"id": id.String()})
+}
+
+func (r *ShareToken) FindByHash(ctx context.Context, hash string) (*publication.ShareToken, error) {
+	return r.findOne(ctx, bson.M{"tokenhash": hash})
+}
+
+func (r *ShareToken) FindByProject(ctx context.Context, pid id.ProjectID) ([]*publication.ShareToken, error) {
+	return r.find(ctx, bson.M{"project": pid.String()})
+}
+
+func (r *ShareToken) FindByStory(ctx context.Context, sid id.StoryID) ([]*publication.ShareToken, error) {
+	return r.find(ctx, bson.M{"story": sid.String()})
+}
+
+func (r *ShareToken) Save(ctx context.Context, t *publication.ShareToken) error {
+	if !r.f.CanWrite(t.Scene()) {
+		return repo.ErrOperationDenied
+	}
+	doc, tid := mongodoc.NewShareToken(t)
+	return r.client.SaveOne(ctx, tid, doc)
+}
+
+func (r *ShareToken) RemoveByScene(ctx context.Context, sid id.SceneID) error {
+	return r.client.RemoveAll(ctx, r.writeFilter(bson.M{"scene": sid.String()}))
+}
+
+func (r *ShareToken) findOne(ctx context.Context, filter any) (*publication.ShareToken, error) {
+	c := mongodoc.NewShareTokenConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, filter, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *ShareToken) find(ctx context.Context, filter any) ([]*publication.ShareToken, error) {
+	c := mongodoc.NewShareTokenConsumer(r.f.Readable)
+	// share token IDs are ULIDs, so sorting by ID sorts by creation time
+	if err := r.client.Find(ctx, r.readFilter(filter), c, options.Find().SetSort(bson.D{{Key: "id", Value: -1}})); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *ShareToken) readFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Readable)
+}
+
+func (r *ShareToken) writeFilter(filter interface{}) interface{} {
+	return applySceneFilter(filter, r.f.Writable)
+}
@@ -0,0 +1,69 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareToken(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	r := NewShareToken(mongox.NewClientWithDatabase(c))
+	require.NoError(t, r.Init(ctx))
+
+	sid := id.NewSceneID()
+	pid := id.NewProjectID()
+	stid := id.NewStoryID()
+	uid := accountsID.NewUserID()
+	expiresAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	t1 := publication.NewShareToken().NewID().Scene(sid).Project(&pid).Name("a").
+		Hash(publication.HashShareToken("a")).ExpiresAt(&expiresAt).CreatedBy(&uid).MustBuild()
+	t2 := publication.NewShareToken().NewID().Scene(sid).Project(&pid).Name("b").
+		Hash(publication.HashShareToken("b")).MustBuild()
+	t3 := publication.NewShareToken().NewID().Scene(sid).Story(&stid).Name("c").
+		Hash(publication.HashShareToken("c")).MustBuild()
+	for _, st := range []*publication.ShareToken{t1, t2, t3} {
+		require.NoError(t, r.Save(ctx, st))
+	}
+
+	got, err := r.FindByHash(ctx, publication.HashShareToken("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, t1.ID(), got.ID())
+	assert.Equal(t, t1.CreatedBy(), got.CreatedBy())
+	assert.True(t, expiresAt.Equal(*got.ExpiresAt()))
+
+	_, err = r.FindByHash(ctx, publication.HashShareToken("x"))
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	list, err := r.FindByProject(ctx, pid)
+	assert.NoError(t, err)
+	assert.Equal(t, []id.ShareTokenID{t2.ID(), t1.ID()}, []id.ShareTokenID{list[0].ID(), list[1].ID()})
+
+	list, err = r.FindByStory(ctx, stid)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, t3.ID(), list[0].ID())
+
+	t2.Revoke(time.Now())
+	require.NoError(t, r.Save(ctx, t2))
+	got, err = r.FindByID(ctx, t2.ID())
+	assert.NoError(t, err)
+	assert.True(t, got.IsRevoked())
+
+	assert.ErrorIs(t, r.Filtered(repo.SceneFilter{Writable: id.SceneIDList{}}).Save(ctx, t1), repo.ErrOperationDenied)
+	list, err = r.Filtered(repo.SceneFilter{Readable: id.SceneIDList{}}).FindByProject(ctx, pid)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
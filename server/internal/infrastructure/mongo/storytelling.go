@@ -6,6 +6,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/alias"
 	"github.com/reearth/reearth/server/pkg/storytelling"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
@@ -68,9 +69,10 @@ func (r *Storytelling) FindByScene(ctx context.Context, id id.SceneID) (*storyte
 	if !r.f.CanRead(id) {
 		return nil, nil
 	}
+	// stories saved before they had an index share the same index, so they are ordered by creation
 	return r.find(ctx, bson.M{
 		"scene": id.String(),
-	})
+	}, options.Find().SetSort(bson.D{{Key: "index", Value: 1}, {Key: "id", Value: 1}}))
 }
 
 func (r *Storytelling) FindByScenes(ctx context.Context, ids []id.SceneID) (*storytelling.StoryList, error) {
@@ -161,9 +163,9 @@ func (r *Storytelling) count(ctx context.Context, filter interface{}) (int64, er
 	return r.client.Count(ctx, filter)
 }
 
-func (r *Storytelling) find(ctx context.Context, filter interface{}) (*storytelling.StoryList, error) {
+func (r *Storytelling) find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*storytelling.StoryList, error) {
 	c := mongodoc.NewStorytellingConsumer(r.f.Readable)
-	if err := r.client.Find(ctx, filter, c); err != nil {
+	if err := r.client.Find(ctx, filter, c, opts...); err != nil {
 		return nil, err
 	}
 	return (*storytelling.StoryList)(&c.Result), nil
@@ -13,7 +13,7 @@ import (
 )
 
 var (
-	styleIndexes       = []string{"scene", "id,scene", "scene,infobox.fields"}
+	styleIndexes       = []string{"scene", "id,scene", "scene,infobox.fields", "asseturls"}
 	styleUniqueIndexes = []string{"id"}
 )
 
@@ -0,0 +1,117 @@
+package mongo
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/trash"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	trashPolicyUniqueIndexes = []string{"workspace"}
+	trashPurgeIndexes        = []string{"workspace"}
+	trashPurgeUniqueIndexes  = []string{"id"}
+)
+
+type TrashPolicy struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewTrashPolicy(client *mongox.Client) *TrashPolicy {
+	return &TrashPolicy{
+		client: client.WithCollection("trashPolicy"),
+	}
+}
+
+func (r *TrashPolicy) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, nil, trashPolicyUniqueIndexes)
+}
+
+func (r *TrashPolicy) Filtered(f repo.WorkspaceFilter) repo.TrashPolicy {
+	return &TrashPolicy{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *TrashPolicy) FindByWorkspace(ctx context.Context, wid accountsID.WorkspaceID) (*trash.Policy, error) {
+	if !r.f.CanRead(wid) {
+		return nil, rerror.ErrNotFound
+	}
+	c := mongodoc.NewTrashPolicyConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, bson.M{"workspace": wid.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *TrashPolicy) FindByWorkspaces(ctx context.Context, ids accountsID.WorkspaceIDList) ([]*trash.Policy, error) {
+	filter := applyWorkspaceFilter(bson.M{"workspace": bson.M{"$in": ids.Strings()}}, r.f.Readable)
+	c := mongodoc.NewTrashPolicyConsumer(r.f.Readable)
+	if err := r.client.Find(ctx, filter, c); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *TrashPolicy) Save(ctx context.Context, p *trash.Policy) error {
+	if !r.f.CanWrite(p.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, wid := mongodoc.NewTrashPolicy(p)
+	// policies are keyed by their workspace since a workspace has only one
+	_, err := r.client.Client().ReplaceOne(ctx, bson.M{"workspace": wid}, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+type TrashPurge struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewTrashPurge(client *mongox.Client) *TrashPurge {
+	return &TrashPurge{
+		client: client.WithCollection("trashPurge"),
+	}
+}
+
+func (r *TrashPurge) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, trashPurgeIndexes, trashPurgeUniqueIndexes)
+}
+
+func (r *TrashPurge) Filtered(f repo.WorkspaceFilter) repo.TrashPurge {
+	return &TrashPurge{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *TrashPurge) FindByWorkspace(ctx context.Context, wid accountsID.WorkspaceID) ([]*trash.Purge, error) {
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+	c := mongodoc.NewTrashPurgeConsumer(r.f.Readable)
+	// trash purge IDs are ULIDs, so sorting by ID sorts by purge time
+	if err := r.client.Find(ctx, bson.M{"workspace": wid.String()}, c, options.Find().SetSort(bson.D{{Key: "id", Value: -1}})); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *TrashPurge) Save(ctx context.Context, p *trash.Purge) error {
+	if !r.f.CanWrite(p.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, pid := mongodoc.NewTrashPurge(p)
+	return r.client.SaveOne(ctx, pid, doc)
+}
@@ -0,0 +1,86 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/trash"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashPolicy(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	r := NewTrashPolicy(mongox.NewClientWithDatabase(c))
+	require.NoError(t, r.Init(ctx))
+
+	wid := accountsID.NewWorkspaceID()
+	wid2 := accountsID.NewWorkspaceID()
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	_, err := r.FindByWorkspace(ctx, wid)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	p, err := trash.NewPolicy(wid, 7*24*time.Hour, now)
+	require.NoError(t, err)
+	require.NoError(t, r.Save(ctx, p))
+	require.NoError(t, p.SetRetention(24*time.Hour, now.Add(time.Hour)))
+	require.NoError(t, r.Save(ctx, p))
+
+	got, err := r.FindByWorkspace(ctx, wid)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, got.Retention())
+	assert.Equal(t, now.Add(time.Hour), got.UpdatedAt())
+
+	got2, err := r.FindByWorkspaces(ctx, accountsID.WorkspaceIDList{wid, wid2})
+	require.NoError(t, err)
+	assert.Len(t, got2, 1)
+
+	filtered := r.Filtered(repo.WorkspaceFilter{Readable: accountsID.WorkspaceIDList{wid2}, Writable: accountsID.WorkspaceIDList{wid2}})
+	_, err = filtered.FindByWorkspace(ctx, wid)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	assert.ErrorIs(t, filtered.Save(ctx, p), repo.ErrOperationDenied)
+}
+
+func TestTrashPurge(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	r := NewTrashPurge(mongox.NewClientWithDatabase(c))
+	require.NoError(t, r.Init(ctx))
+
+	wid := accountsID.NewWorkspaceID()
+	uid := accountsID.NewUserID()
+	deletedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	p1 := trash.NewPurge().NewID().Workspace(wid).Project(id.NewProjectID()).ProjectName("a").
+		Reason(trash.PurgeReasonExpired).DeletedAt(&deletedAt).MustBuild()
+	p2 := trash.NewPurge().NewID().Workspace(wid).Project(id.NewProjectID()).ProjectName("b").PurgedBy(&uid).MustBuild()
+	p3 := trash.NewPurge().NewID().Workspace(accountsID.NewWorkspaceID()).Project(id.NewProjectID()).MustBuild()
+	for _, p := range []*trash.Purge{p1, p2, p3} {
+		require.NoError(t, r.Save(ctx, p))
+	}
+
+	got, err := r.FindByWorkspace(ctx, wid)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, p2.ID(), got[0].ID())
+	assert.Equal(t, &uid, got[0].PurgedBy())
+	assert.Equal(t, p1.ID(), got[1].ID())
+	assert.Equal(t, trash.PurgeReasonExpired, got[1].Reason())
+	assert.Equal(t, &deletedAt, got[1].DeletedAt())
+	assert.Equal(t, "a", got[1].ProjectName())
+
+	filtered := r.Filtered(repo.WorkspaceFilter{Readable: accountsID.WorkspaceIDList{}, Writable: accountsID.WorkspaceIDList{}})
+	got, err = filtered.FindByWorkspace(ctx, wid)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.ErrorIs(t, filtered.Save(ctx, p1), repo.ErrOperationDenied)
+}
@@ -0,0 +1,74 @@
+package mongo
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	webhookIndexes       = []string{"workspace"}
+	webhookUniqueIndexes = []string{"id"}
+)
+
+type Webhook struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewWebhook(client *mongox.Client) *Webhook {
+	return &Webhook{
+		client: client.WithCollection("webhook"),
+	}
+}
+
+func (r *Webhook) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, webhookIndexes, webhookUniqueIndexes)
+}
+
+func (r *Webhook) Filtered(f repo.WorkspaceFilter) repo.Webhook {
+	return &Webhook{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *Webhook) FindByID(ctx context.Context, id id.WebhookID) (*webhook.Webhook, error) {
+	c := mongodoc.NewWebhookConsumer(r.f.Readable)
+	if err := r.client.FindOne(ctx, applyWorkspaceFilter(bson.M{"id": id.String()}, r.f.Readable), c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *Webhook) FindByWorkspace(ctx context.Context, wid accountsID.WorkspaceID) ([]*webhook.Webhook, error) {
+	if !r.f.CanRead(wid) {
+		return nil, nil
+	}
+	c := mongodoc.NewWebhookConsumer(r.f.Readable)
+	// webhook IDs are ULIDs, so sorting by ID sorts by creation time
+	if err := r.client.Find(ctx, bson.M{"workspace": wid.String()}, c, options.Find().SetSort(bson.D{{Key: "id", Value: -1}})); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *Webhook) Save(ctx context.Context, w *webhook.Webhook) error {
+	if !r.f.CanWrite(w.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, wid := mongodoc.NewWebhook(w)
+	return r.client.SaveOne(ctx, wid, doc)
+}
+
+func (r *Webhook) Remove(ctx context.Context, id id.WebhookID) error {
+	return r.client.RemoveOne(ctx, applyWorkspaceFilter(bson.M{"id": id.String()}, r.f.Writable))
+}
@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	webhookDeliveryIndexes       = []string{"webhook", "workspace", "nextattemptat"}
+	webhookDeliveryUniqueIndexes = []string{"id"}
+)
+
+type WebhookDelivery struct {
+	client *mongox.ClientCollection
+	f      repo.WorkspaceFilter
+}
+
+func NewWebhookDelivery(client *mongox.Client) *WebhookDelivery {
+	return &WebhookDelivery{
+		client: client.WithCollection("webhookDelivery"),
+	}
+}
+
+func (r *WebhookDelivery) Init(ctx context.Context) error {
+	return createIndexes(ctx, r.client, webhookDeliveryIndexes, webhookDeliveryUniqueIndexes)
+}
+
+func (r *WebhookDelivery) Filtered(f repo.WorkspaceFilter) repo.WebhookDelivery {
+	return &WebhookDelivery{
+		client: r.client,
+		f:      r.f.Merge(f),
+	}
+}
+
+func (r *WebhookDelivery) FindByWebhook(ctx context.Context, wid id.WebhookID, filter repo.WebhookDeliveryFilter) ([]*webhook.Delivery, *usecasex.PageInfo, error) {
+	f := bson.M{"webhook": wid.String()}
+	if filter.Status != nil {
+		f["status"] = string(*filter.Status)
+	}
+
+	c := mongodoc.NewWebhookDeliveryConsumer(r.f.Readable)
+	// delivery IDs are ULIDs, so sorting by ID sorts by creation time
+	pageInfo, err := r.client.Paginate(ctx, applyWorkspaceFilter(f, r.f.Readable), &usecasex.Sort{Key: "id", Reverted: true}, filter.Pagination, c)
+	if err != nil {
+		return nil, nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, pageInfo, nil
+}
+
+func (r *WebhookDelivery) FindDue(ctx context.Context, now time.Time, limit int) ([]*webhook.Delivery, error) {
+	c := mongodoc.NewWebhookDeliveryConsumer(r.f.Readable)
+	// nextattemptat is unset once the delivery is finished, so $lte only matches pending deliveries
+	filter := applyWorkspaceFilter(bson.M{"nextattemptat": bson.M{"$lte": now}}, r.f.Readable)
+	opts := options.Find().SetSort(bson.D{{Key: "nextattemptat", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	if err := r.client.Find(ctx, filter, c, opts); err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return c.Result, nil
+}
+
+func (r *WebhookDelivery) Save(ctx context.Context, d *webhook.Delivery) error {
+	if !r.f.CanWrite(d.Workspace()) {
+		return repo.ErrOperationDenied
+	}
+	doc, did := mongodoc.NewWebhookDelivery(d)
+	return r.client.SaveOne(ctx, did, doc)
+}
+
+func (r *WebhookDelivery) RemoveByWebhook(ctx context.Context, wid id.WebhookID) error {
+	return r.client.RemoveAll(ctx, applyWorkspaceFilter(bson.M{"webhook": wid.String()}, r.f.Writable))
+}
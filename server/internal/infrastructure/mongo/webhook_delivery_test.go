@@ -0,0 +1,83 @@
+package mongo
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/mongox/mongotest"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDelivery(t *testing.T) {
+	c := mongotest.Connect(t)(t)
+	ctx := context.Background()
+	client := mongox.NewClientWithDatabase(c)
+	wr := NewWebhook(client)
+	require.NoError(t, wr.Init(ctx))
+	r := NewWebhookDelivery(client)
+	require.NoError(t, r.Init(ctx))
+
+	wid := accountsID.NewWorkspaceID()
+	u, _ := url.Parse("https://example.com/hook")
+	w := webhook.New().NewID().Workspace(wid).URL(u).Secret("secret").Enabled(true).
+		Events([]webhook.EventType{webhook.EventProjectPublish}).MustBuild()
+	require.NoError(t, wr.Save(ctx, w))
+
+	got, err := wr.FindByID(ctx, w.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, u, got.URL())
+	assert.Equal(t, w.Events(), got.Events())
+	assert.Equal(t, "secret", got.Secret())
+
+	// the driver decodes times in UTC
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	later := now.Add(time.Hour)
+	d1 := webhook.NewDelivery().NewID().Webhook(w.ID()).Workspace(wid).Event(webhook.EventProjectPublish).
+		Payload([]byte(`{"a":1}`)).NextAttemptAt(&now).MustBuild()
+	d2 := webhook.NewDelivery().NewID().Webhook(w.ID()).Workspace(wid).Event(webhook.EventProjectPublish).
+		Payload([]byte(`{"a":2}`)).NextAttemptAt(&later).MustBuild()
+	d3 := webhook.NewDelivery().NewID().Webhook(w.ID()).Workspace(wid).Event(webhook.EventProjectPublish).
+		Payload([]byte(`{"a":3}`)).NextAttemptAt(&now).MustBuild()
+	d3.RecordAttempt(webhook.Attempt{At: now, StatusCode: 200, Duration: 120 * time.Millisecond})
+	for _, d := range []*webhook.Delivery{d1, d2, d3} {
+		require.NoError(t, r.Save(ctx, d))
+	}
+
+	due, err := r.FindDue(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []id.WebhookDeliveryID{d1.ID()}, lo.Map(due, func(d *webhook.Delivery, _ int) id.WebhookDeliveryID { return d.ID() }))
+	assert.Equal(t, `{"a":1}`, string(due[0].Payload()))
+
+	list, pi, err := r.FindByWebhook(ctx, w.ID(), repo.WebhookDeliveryFilter{
+		Pagination: usecasex.CursorPagination{First: lo.ToPtr(int64(2))}.Wrap(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []id.WebhookDeliveryID{d3.ID(), d2.ID()}, lo.Map(list, func(d *webhook.Delivery, _ int) id.WebhookDeliveryID { return d.ID() }))
+	assert.True(t, pi.HasNextPage)
+	assert.Equal(t, d3.Attempts(), list[0].Attempts())
+
+	list, _, err = r.FindByWebhook(ctx, w.ID(), repo.WebhookDeliveryFilter{Status: lo.ToPtr(webhook.DeliveryStatusSucceeded)})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	filtered := r.Filtered(repo.WorkspaceFilter{Readable: accountsID.WorkspaceIDList{accountsID.NewWorkspaceID()}})
+	due, err = filtered.FindDue(ctx, later, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, due)
+
+	require.NoError(t, r.RemoveByWebhook(ctx, w.ID()))
+	require.NoError(t, wr.Remove(ctx, w.ID()))
+	list, _, err = r.FindByWebhook(ctx, w.ID(), repo.WebhookDeliveryFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,69 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/log"
+)
+
+// subscriberBufferSize is how many events a subscriber can fall behind before its events are dropped.
+const subscriberBufferSize = 64
+
+// Memory delivers the events to the subscribers in the same process, so the editors of a scene have to be connected to
+// the same server instance to see each other's changes.
+type Memory struct {
+	lock        sync.RWMutex
+	subscribers map[id.SceneID]map[chan scene.Event]struct{}
+}
+
+var _ gateway.PubSub = (*Memory)(nil)
+
+func NewMemory() *Memory {
+	return &Memory{
+		subscribers: map[id.SceneID]map[chan scene.Event]struct{}{},
+	}
+}
+
+func (m *Memory) Publish(ctx context.Context, e scene.Event) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for ch := range m.subscribers[e.Scene] {
+		select {
+		case ch <- e:
+		default:
+			// a slow subscriber must not block the change that published the event
+			log.Warnfc(ctx, "pubsub: dropped %s of scene %s for a slow subscriber", e.Type, e.Scene)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Subscribe(ctx context.Context, sid id.SceneID) (<-chan scene.Event, error) {
+	ch := make(chan scene.Event, subscriberBufferSize)
+
+	m.lock.Lock()
+	if m.subscribers[sid] == nil {
+		m.subscribers[sid] = map[chan scene.Event]struct{}{}
+	}
+	m.subscribers[sid][ch] = struct{}{}
+	m.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		delete(m.subscribers[sid], ch)
+		if len(m.subscribers[sid]) == 0 {
+			delete(m.subscribers, sid)
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,82 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	sid, sid2 := id.NewSceneID(), id.NewSceneID()
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	ch1, err := m.Subscribe(ctx1, sid)
+	require.NoError(t, err)
+	ctx2, cancel2 := context.WithCancel(ctx)
+	defer cancel2()
+	ch2, err := m.Subscribe(ctx2, sid)
+	require.NoError(t, err)
+	ctx3, cancel3 := context.WithCancel(ctx)
+	defer cancel3()
+	ch3, err := m.Subscribe(ctx3, sid2)
+	require.NoError(t, err)
+
+	lid := id.NewNLSLayerID()
+	e := scene.Event{Type: scene.EventLayerAdded, Scene: sid, Layer: &lid}
+	assert.NoError(t, m.Publish(ctx, e))
+
+	assert.Equal(t, e, receive(t, ch1))
+	assert.Equal(t, e, receive(t, ch2))
+	select {
+	case <-ch3:
+		assert.Fail(t, "the event of another scene is received")
+	default:
+	}
+
+	// the channel is closed when the context is done
+	cancel1()
+	select {
+	case _, ok := <-ch1:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the channel is not closed")
+	}
+
+	e2 := scene.Event{Type: scene.EventLayerRemoved, Scene: sid, Layer: &lid}
+	assert.NoError(t, m.Publish(ctx, e2))
+	assert.Equal(t, e2, receive(t, ch2))
+}
+
+func TestMemory_SlowSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := NewMemory()
+	sid := id.NewSceneID()
+
+	ch, err := m.Subscribe(ctx, sid)
+	require.NoError(t, err)
+
+	// publishing never blocks even when the subscriber does not receive the events
+	for range subscriberBufferSize + 10 {
+		assert.NoError(t, m.Publish(ctx, scene.Event{Type: scene.EventStyleUpdated, Scene: sid}))
+	}
+	assert.Len(t, ch, subscriberBufferSize)
+}
+
+func receive(t *testing.T, ch <-chan scene.Event) scene.Event {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		assert.Fail(t, "no event is received")
+		return scene.Event{}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -29,10 +30,29 @@ const (
 	pluginBasePath string = "plugins"
 	mapBasePath    string = "maps"
 	storyBasePath  string = "stories"
+	snapshotPath   string = "snapshots"
 	exportBasePath string = "export"
+	importBasePath string = "import"
 	fileSizeLimit  int64  = 1024 * 1024 * 100 // about 100MB
+
+	signedUploadContentType = "application/octet-stream"
+	signedUploadExpiresIn   = 15 // minutes
 )
 
+// Option customizes the S3 client, e.g. to point it at an S3-compatible storage such as MinIO.
+type Option func(*s3.Options)
+
+// WithEndpoint makes the client send requests to the endpoint instead of AWS.
+// Path-style addressing is needed by most S3-compatible storages that do not have per-bucket hostnames.
+func WithEndpoint(endpoint string, usePathStyle bool) Option {
+	return func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = usePathStyle
+	}
+}
+
 type fileRepo struct {
 	bucketName   string
 	base         *url.URL
@@ -40,7 +60,7 @@ type fileRepo struct {
 	client       *s3.Client
 }
 
-func NewS3(ctx context.Context, bucketName, baseURL, cacheControl string) (gateway.File, error) {
+func NewS3(ctx context.Context, bucketName, baseURL, cacheControl string, opts ...Option) (gateway.File, error) {
 	if bucketName == "" {
 		return nil, errors.New("bucket name is empty")
 	}
@@ -63,7 +83,9 @@ func NewS3(ctx context.Context, bucketName, baseURL, cacheControl string) (gatew
 		bucketName:   bucketName,
 		base:         u,
 		cacheControl: cacheControl,
-		client:       s3.NewFromConfig(cfg),
+		client: s3.NewFromConfig(cfg, lo.Map(opts, func(o Option, _ int) func(*s3.Options) {
+			return o
+		})...),
 	}, nil
 }
 
@@ -217,6 +239,32 @@ func (f *fileRepo) RemoveStory(ctx context.Context, name string) error {
 	return f.delete(ctx, path.Join(storyBasePath, sn))
 }
 
+// publication snapshots
+
+func (f *fileRepo) ReadPublicationSnapshot(ctx context.Context, name string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return f.read(ctx, path.Join(snapshotPath, sanitize.Path(name)+".json"))
+}
+
+func (f *fileRepo) UploadPublicationSnapshot(ctx context.Context, content io.Reader, name string) error {
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(snapshotPath, sn), content)
+	return err
+}
+
+func (f *fileRepo) RemovePublicationSnapshot(ctx context.Context, name string) error {
+	sn := sanitize.Path(name + ".json")
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(snapshotPath, sn))
+}
+
 // export
 
 func (f *fileRepo) ReadExportProjectZip(ctx context.Context, name string) (io.ReadCloser, error) {
@@ -240,16 +288,48 @@ func (f *fileRepo) RemoveExportProjectZip(ctx context.Context, filename string)
 
 // import
 
-func (f *fileRepo) GenerateSignedUploadUrl(context.Context, string) (*string, int, *string, error) {
-	return nil, 0, nil, nil
+func (f *fileRepo) GenerateSignedUploadUrl(ctx context.Context, filename string) (*string, int, *string, error) {
+	sn := sanitize.Path(filename)
+	if sn == "" {
+		return nil, 0, nil, gateway.ErrInvalidFile
+	}
+
+	contentType := signedUploadContentType
+	req, err := s3.NewPresignClient(f.client).PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(f.bucketName),
+		Key:         aws.String(path.Join(importBasePath, sn)),
+		ContentType: aws.String(contentType),
+	}, s3.WithPresignExpires(time.Duration(signedUploadExpiresIn)*time.Minute))
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to generate signed URL: %w", err)
+	}
+
+	return &req.URL, signedUploadExpiresIn, &contentType, nil
 }
 
-func (f *fileRepo) ReadImportProjectZip(context.Context, string) (io.ReadCloser, error) {
-	return nil, nil
+func (f *fileRepo) UploadImportProjectZip(ctx context.Context, name string, content io.Reader) error {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	_, err := f.upload(ctx, path.Join(importBasePath, sn), content)
+	return err
 }
 
-func (f *fileRepo) RemoveImportProjectZip(context.Context, string) error {
-	return nil
+func (f *fileRepo) ReadImportProjectZip(ctx context.Context, name string) (io.ReadCloser, error) {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return nil, gateway.ErrInvalidFile
+	}
+	return f.read(ctx, path.Join(importBasePath, sn))
+}
+
+func (f *fileRepo) RemoveImportProjectZip(ctx context.Context, name string) error {
+	sn := sanitize.Path(name)
+	if sn == "" {
+		return gateway.ErrInvalidFile
+	}
+	return f.delete(ctx, path.Join(importBasePath, sn))
 }
 
 // helpers
@@ -264,6 +344,10 @@ func (f *fileRepo) read(ctx context.Context, filename string) (io.ReadCloser, er
 		Key:    aws.String(filename),
 	})
 	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return nil, rerror.ErrNotFound
+		}
 		return nil, rerror.ErrInternalByWithContext(ctx, fmt.Errorf("s3: read err: %+v", err))
 	}
 
@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/pkg/webhook"
+)
+
+const userAgent = "reearth-webhook"
+
+var errPrivateAddress = errors.New("webhook url resolves to a loopback, private or link-local address")
+
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeoutSeconds int) *HTTPSender {
+	return newHTTPSender(timeoutSeconds, webhook.IsPublicIP)
+}
+
+// newHTTPSender returns a sender that connects only to the addresses that allowIP accepts.
+// The addresses are checked when dialing, after the host name is resolved, so that a host name that resolves to
+// an internal address, or starts to do so after the webhook was saved, cannot be used to reach the internal network.
+func newHTTPSender(timeoutSeconds int, allowIP func(net.IP) bool) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !allowIP(net.ParseIP(host)) {
+				return fmt.Errorf("%w: %s", errPrivateAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// a proxy would be dialed instead of the webhook, which would bypass the check of the address
+	transport.Proxy = nil
+
+	return &HTTPSender{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(timeoutSeconds) * time.Second,
+			// a redirect is reported as the response of the attempt instead of being followed,
+			// so that a webhook cannot make the server send the signed payload somewhere else
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, req gateway.WebhookRequest) (*gateway.WebhookResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL.String(), bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	for k, v := range req.Header {
+		httpReq.Header[k] = v
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// drain a bit of the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return &gateway.WebhookResponse{StatusCode: resp.StatusCode}, nil
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "project.publish", r.Header.Get("X-Reearth-Event"))
+			assert.Equal(t, `{"a":1}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	// the test server listens on the loopback address
+	s := newHTTPSender(5, func(net.IP) bool { return true })
+	send := func(path string) (*gateway.WebhookResponse, error) {
+		u, err := url.Parse(server.URL + path)
+		require.NoError(t, err)
+		return s.Send(context.Background(), gateway.WebhookRequest{
+			URL:    u,
+			Header: http.Header{"X-Reearth-Event": []string{"project.publish"}},
+			Body:   []byte(`{"a":1}`),
+		})
+	}
+
+	res, err := send("/ok")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+	res, err = send("/redirect")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, res.StatusCode)
+
+	res, err = send("/error")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+	server.Close()
+	_, err = send("/ok")
+	assert.Error(t, err)
+}
+
+func TestHTTPSender_Send_PrivateAddress(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	// a host name that resolves to the loopback address is rejected when dialing
+	u, err := url.Parse(strings.Replace(server.URL, "127.0.0.1", "localhost", 1))
+	require.NoError(t, err)
+	_, err = NewHTTPSender(5).Send(context.Background(), gateway.WebhookRequest{URL: u, Body: []byte(`{}`)})
+	assert.ErrorIs(t, err, errPrivateAddress)
+	assert.False(t, called)
+}
@@ -10,4 +10,6 @@ type Container struct {
 	Google         Google
 	PolicyChecker  PolicyChecker
 	DomainChecker  DomainChecker
+	WebhookSender  WebhookSender
+	PubSub         PubSub
 }
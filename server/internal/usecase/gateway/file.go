@@ -12,10 +12,11 @@ import (
 )
 
 var (
-	ErrInvalidFile        error = errors.New("invalid file")
-	ErrFailedToUploadFile error = errors.New("failed to upload file")
-	ErrFileTooLarge       error = errors.New("file too large")
-	ErrFailedToRemoveFile error = errors.New("failed to remove file")
+	ErrInvalidFile              error = errors.New("invalid file")
+	ErrFailedToUploadFile       error = errors.New("failed to upload file")
+	ErrFileTooLarge             error = errors.New("file too large")
+	ErrFailedToRemoveFile       error = errors.New("failed to remove file")
+	ErrSignedUploadNotSupported error = errors.New("signed upload is not supported")
 )
 
 const (
@@ -42,12 +43,18 @@ type File interface {
 	MoveStory(context.Context, string, string) error
 	RemoveStory(context.Context, string) error
 
+	// publication snapshots are immutable copies of the built scenes and stories of each publish
+	UploadPublicationSnapshot(context.Context, io.Reader, string) error
+	ReadPublicationSnapshot(context.Context, string) (io.ReadCloser, error)
+	RemovePublicationSnapshot(context.Context, string) error
+
 	ReadExportProjectZip(context.Context, string) (io.ReadCloser, error)
 	UploadExportProjectZip(context.Context, afero.File) error
 	RemoveExportProjectZip(context.Context, string) error
 
 	GenerateSignedUploadUrl(context.Context, string) (*string, int, *string, error)
 
+	UploadImportProjectZip(context.Context, string, io.Reader) error
 	ReadImportProjectZip(context.Context, string) (io.ReadCloser, error)
 	RemoveImportProjectZip(context.Context, string) error
 }
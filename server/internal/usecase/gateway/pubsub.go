@@ -0,0 +1,16 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+// PubSub delivers the change events of scenes to the editors subscribing to them.
+type PubSub interface {
+	// Publish sends the event to the current subscribers of its scene without waiting for them to receive it.
+	Publish(ctx context.Context, e scene.Event) error
+	// Subscribe returns a channel that receives the events of the scene until ctx is done, when the channel is closed.
+	Subscribe(ctx context.Context, sid id.SceneID) (<-chan scene.Event, error)
+}
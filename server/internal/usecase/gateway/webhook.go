@@ -0,0 +1,22 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type WebhookRequest struct {
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+}
+
+type WebhookResponse struct {
+	StatusCode int
+}
+
+type WebhookSender interface {
+	// Send posts the request to the webhook. An error is returned only when no response was received.
+	Send(ctx context.Context, req WebhookRequest) (*WebhookResponse, error)
+}
@@ -0,0 +1,155 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// apiTokenUseInterval is how often the last use of a token is recorded.
+const apiTokenUseInterval = time.Minute
+
+type APIToken struct {
+	common
+	apiTokenRepo repo.APIToken
+	transaction  usecasex.Transaction
+}
+
+func NewAPIToken(r *repo.Container) interfaces.APIToken {
+	return &APIToken{
+		apiTokenRepo: r.APIToken,
+		transaction:  r.Transaction,
+	}
+}
+
+func (i *APIToken) FetchByWorkspace(ctx context.Context, wid accountsID.WorkspaceID, operator *usecase.Operator) ([]*apitoken.APIToken, error) {
+	if err := i.canManage(wid, operator); err != nil {
+		return nil, err
+	}
+	return i.apiTokenRepo.FindByWorkspace(ctx, wid)
+}
+
+func (i *APIToken) Create(ctx context.Context, param interfaces.CreateAPITokenParam, operator *usecase.Operator) (_ *apitoken.APIToken, _ string, err error) {
+	if err := i.canManage(param.WorkspaceID, operator); err != nil {
+		return nil, "", err
+	}
+	if param.ExpiresAt != nil && !param.ExpiresAt.After(time.Now()) {
+		return nil, "", interfaces.ErrInvalidAPITokenExpiry
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	token, hash, err := apitoken.GenerateToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	at, err := apitoken.New().
+		NewID().
+		Workspace(param.WorkspaceID).
+		Name(param.Name).
+		Scopes(param.Scopes).
+		Hash(hash).
+		ExpiresAt(param.ExpiresAt).
+		CreatedBy(operator.AcOperator.User).
+		Build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := i.apiTokenRepo.Save(ctx, at); err != nil {
+		return nil, "", err
+	}
+
+	tx.Commit()
+	return at, token, nil
+}
+
+func (i *APIToken) Revoke(ctx context.Context, tid id.APITokenID, operator *usecase.Operator) (_ *apitoken.APIToken, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	at, err := i.apiTokenRepo.FindByID(ctx, tid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.canManage(at.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	at.Revoke(time.Now())
+	if err := i.apiTokenRepo.Save(ctx, at); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return at, nil
+}
+
+func (i *APIToken) Authenticate(ctx context.Context, token string) (*apitoken.APIToken, error) {
+	if !apitoken.IsToken(token) {
+		return nil, interfaces.ErrInvalidAPIToken
+	}
+
+	at, err := i.apiTokenRepo.FindByHash(ctx, apitoken.HashToken(token))
+	if err != nil {
+		if errors.Is(err, rerror.ErrNotFound) {
+			return nil, interfaces.ErrInvalidAPIToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := at.Validate(now); err != nil {
+		return nil, interfaces.ErrInvalidAPIToken
+	}
+
+	if at.Use(now, apiTokenUseInterval) {
+		// failing to record the last use should not fail the request
+		if err := i.apiTokenRepo.Save(ctx, at); err != nil {
+			log.Warnfc(ctx, "api token: failed to record the last use of %s: %v", at.ID(), err)
+		}
+	}
+	return at, nil
+}
+
+// canManage allows the maintainers of the workspace to manage its tokens, since a token grants access to the whole workspace.
+func (i *APIToken) canManage(wid accountsID.WorkspaceID, operator *usecase.Operator) error {
+	if err := i.OnlyOperator(operator); err != nil {
+		return err
+	}
+	if operator.AcOperator == nil || !operator.IsMaintainingWorkspace(wid) {
+		return interfaces.ErrOperationDenied
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIToken(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	uc := NewAPIToken(db)
+
+	wid := accountsID.NewWorkspaceID()
+	uid := accountsID.NewUserID()
+	maintainer := &usecase.Operator{AcOperator: &accountsWorkspace.Operator{
+		User:                   &uid,
+		MaintainableWorkspaces: accountsID.WorkspaceIDList{wid},
+	}}
+	writer := &usecase.Operator{AcOperator: &accountsWorkspace.Operator{
+		WritableWorkspaces: accountsID.WorkspaceIDList{wid},
+	}}
+
+	_, _, err := uc.Create(ctx, interfaces.CreateAPITokenParam{WorkspaceID: wid, Scopes: apitoken.Scopes{apitoken.ScopeRead}}, writer)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	_, _, err = uc.Create(ctx, interfaces.CreateAPITokenParam{
+		WorkspaceID: wid,
+		Scopes:      apitoken.Scopes{apitoken.ScopeRead},
+		ExpiresAt:   lo.ToPtr(time.Now().Add(-time.Hour)),
+	}, maintainer)
+	assert.ErrorIs(t, err, interfaces.ErrInvalidAPITokenExpiry)
+
+	_, _, err = uc.Create(ctx, interfaces.CreateAPITokenParam{WorkspaceID: wid}, maintainer)
+	assert.ErrorIs(t, err, apitoken.ErrNoScope)
+
+	at, token, err := uc.Create(ctx, interfaces.CreateAPITokenParam{
+		WorkspaceID: wid,
+		Name:        "ci",
+		Scopes:      apitoken.Scopes{apitoken.ScopeWrite, apitoken.ScopePublish},
+	}, maintainer)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", at.Name())
+	assert.Equal(t, &uid, at.CreatedBy())
+	assert.Equal(t, apitoken.HashToken(token), at.Hash())
+	assert.Nil(t, at.LastUsedAt())
+
+	list, err := uc.FetchByWorkspace(ctx, wid, maintainer)
+	assert.NoError(t, err)
+	assert.Equal(t, []*apitoken.APIToken{at}, list)
+	_, err = uc.FetchByWorkspace(ctx, wid, writer)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	got, err := uc.Authenticate(ctx, token)
+	assert.NoError(t, err)
+	assert.Equal(t, at.ID(), got.ID())
+	assert.NotNil(t, got.LastUsedAt())
+
+	_, err = uc.Authenticate(ctx, token+"x")
+	assert.ErrorIs(t, err, interfaces.ErrInvalidAPIToken)
+	_, err = uc.Authenticate(ctx, "eyJhbGciOiJSUzI1NiJ9.e30.sig")
+	assert.ErrorIs(t, err, interfaces.ErrInvalidAPIToken)
+
+	_, err = uc.Revoke(ctx, at.ID(), writer)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	at, err = uc.Revoke(ctx, at.ID(), maintainer)
+	assert.NoError(t, err)
+	assert.True(t, at.IsRevoked())
+
+	_, err = uc.Authenticate(ctx, token)
+	assert.ErrorIs(t, err, interfaces.ErrInvalidAPIToken)
+}
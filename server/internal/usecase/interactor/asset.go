@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,6 +22,7 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/asset/extraction"
 	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/image"
@@ -87,7 +89,12 @@ func (i *Asset) Create(ctx context.Context, inp interfaces.CreateAssetParam, ope
 	}
 
 	result, _, err := i.uploadAndSave(ctx, inp.File, ws, inp.ProjectID, inp.CoreSupport)
-	return result, err
+	if err != nil {
+		return nil, err
+	}
+
+	newWebhookEmitter(i.repos).emitAssetUpload(ctx, result)
+	return result, nil
 }
 
 func (i *Asset) CreateIconAsset(ctx context.Context, inp interfaces.CreateIconAssetParam, operator *usecase.Operator) (*asset.Asset, error) {
@@ -162,7 +169,7 @@ func (i *Asset) Update(ctx context.Context, aid id.AssetID, pid *id.ProjectID, o
 	)
 }
 
-func (i *Asset) Remove(ctx context.Context, aid id.AssetID, operator *usecase.Operator) (result id.AssetID, err error) {
+func (i *Asset) Remove(ctx context.Context, aid id.AssetID, force bool, operator *usecase.Operator) (result id.AssetID, err error) {
 	return Run1(
 		ctx, operator, i.repos,
 		Usecase().Transaction(),
@@ -176,11 +183,26 @@ func (i *Asset) Remove(ctx context.Context, aid id.AssetID, operator *usecase.Op
 				return aid, interfaces.ErrOperationDenied
 			}
 
+			if !force {
+				idx, err := assetUsages(ctx, i.repos.AssetReference, asset.URL())
+				if err != nil {
+					return aid, err
+				}
+				if idx.IsUsed(asset.URL()) {
+					return aid, interfaces.ErrAssetInUse
+				}
+			}
+
 			if url, _ := url.Parse(asset.URL()); url != nil {
 				if err := i.gateways.File.RemoveAsset(ctx, url); err != nil {
 					return aid, err
 				}
 			}
+			if url, _ := url.Parse(asset.ThumbnailURL()); asset.ThumbnailURL() != "" && url != nil {
+				if err := i.gateways.File.RemoveAsset(ctx, url); err != nil {
+					log.Warnf("asset: failed to remove the thumbnail of %s: %v", aid, err)
+				}
+			}
 
 			return aid, i.repos.Asset.Remove(ctx, aid)
 		},
@@ -275,6 +297,11 @@ var (
 
 func (i *Asset) uploadAndSave(ctx context.Context, f *file.File, ws *accountsWorkspace.Workspace, pid *id.ProjectID, coreSupport bool) (*asset.Asset, *url.URL, error) {
 
+	data, err := bufferForExtraction(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// upload
 	u, size, err := i.gateways.File.UploadAsset(ctx, f)
 	if err != nil {
@@ -306,6 +333,8 @@ func (i *Asset) uploadAndSave(ctx context.Context, f *file.File, ws *accountsWor
 		URL(u.String()).
 		ContentType(f.ContentType).
 		CoreSupport(coreSupport).
+		Metadata(extractMetadata(f.Path, data)).
+		ThumbnailURL(i.uploadThumbnail(ctx, f.Path, data)).
 		Build()
 	if err != nil {
 		log.Errorf("[Import Error] asset build")
@@ -319,3 +348,64 @@ func (i *Asset) uploadAndSave(ctx context.Context, f *file.File, ws *accountsWor
 
 	return a, u, nil
 }
+
+// bufferForExtraction reads the content of the file into memory when metadata can be extracted from it,
+// and replaces the content so that the file can still be uploaded.
+func bufferForExtraction(f *file.File) ([]byte, error) {
+	if f.Content == nil || !extraction.IsSupported(f.Path) || f.Size > extraction.MaxSize {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(f.Content, extraction.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > extraction.MaxSize {
+		// the declared size was wrong, so upload the rest of the content without extracting metadata
+		f.Content = io.NopCloser(io.MultiReader(bytes.NewReader(data), f.Content))
+		return nil, nil
+	}
+	f.Content = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// extractMetadata returns the metadata of the file, or nil when it cannot be read.
+// A file whose metadata cannot be read is still a valid asset.
+func extractMetadata(name string, data []byte) *asset.Metadata {
+	if data == nil {
+		return nil
+	}
+	m, err := extraction.Extract(name, data)
+	if err != nil {
+		log.Warnf("asset: failed to extract metadata of %s: %v", name, err)
+		return nil
+	}
+	return m
+}
+
+// uploadThumbnail uploads a thumbnail of an image and returns its URL, or an empty string when the image has none.
+func (i *Asset) uploadThumbnail(ctx context.Context, name string, data []byte) string {
+	if data == nil || !extraction.IsImage(name) {
+		return ""
+	}
+
+	thumbnail, err := image.ProcessThumbnail(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		if !errors.Is(err, image.ErrNoThumbnailNeeded) {
+			log.Warnf("asset: failed to make a thumbnail of %s: %v", name, err)
+		}
+		return ""
+	}
+
+	u, _, err := i.gateways.File.UploadAsset(ctx, &file.File{
+		Content:     io.NopCloser(bytes.NewReader(thumbnail)),
+		Path:        strings.TrimSuffix(path.Base(name), path.Ext(name)) + "_thumbnail.png",
+		Size:        int64(len(thumbnail)),
+		ContentType: "image/png",
+	})
+	if err != nil {
+		log.Warnf("asset: failed to upload the thumbnail of %s: %v", name, err)
+		return ""
+	}
+	return u.String()
+}
@@ -7,6 +7,7 @@ import (
 	"image/color"
 	"image/png"
 	"io"
+	"path"
 	"strings"
 	"testing"
 
@@ -226,6 +227,82 @@ func TestAsset_CreateIconAsset(t *testing.T) {
 	})
 }
 
+func TestAsset_CreateWithMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	ws := accountsWorkspace.New().NewID().MustBuild()
+	gFile, err := fs.NewFile(afero.NewMemMapFs(), "https://example.com")
+	require.NoError(t, err)
+
+	wsRepo := accountsInfra.NewMemoryWorkspace()
+	_ = wsRepo.Save(ctx, ws)
+
+	uContainer := &Asset{
+		repos: &repo.Container{
+			Asset:     memory.NewAsset(),
+			Workspace: wsRepo,
+		},
+		gateways: &gateway.Container{
+			File: gFile,
+		},
+	}
+	operator := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{
+			WritableWorkspaces: accountsID.WorkspaceIDList{ws.ID()},
+		},
+	}
+
+	create := func(name string, data []byte) *asset.Asset {
+		res, err := uContainer.Create(ctx, interfaces.CreateAssetParam{
+			WorkspaceID: ws.ID(),
+			CoreSupport: true,
+			File: &file.File{
+				Content: io.NopCloser(bytes.NewReader(data)),
+				Path:    name,
+				Size:    int64(len(data)),
+			},
+		}, operator)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("image", func(t *testing.T) {
+		res := create("photo.png", createTestPNGImage(600, 300))
+		require.NotNil(t, res.Metadata())
+		assert.Equal(t, 600, *res.Metadata().Width)
+		assert.Equal(t, 300, *res.Metadata().Height)
+		assert.NotEmpty(t, res.ThumbnailURL())
+		assert.NotEqual(t, res.URL(), res.ThumbnailURL())
+
+		// the uploaded file is not affected by reading the metadata
+		r, err := gFile.ReadAsset(ctx, path.Base(res.URL()))
+		require.NoError(t, err)
+		uploaded, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, createTestPNGImage(600, 300), uploaded)
+	})
+
+	t.Run("small image", func(t *testing.T) {
+		res := create("icon.png", createTestPNGImage(32, 32))
+		assert.Equal(t, 32, *res.Metadata().Width)
+		assert.Empty(t, res.ThumbnailURL())
+	})
+
+	t.Run("geojson", func(t *testing.T) {
+		res := create("data.geojson", []byte(`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[139,35]},"properties":{"name":"a"}}]}`))
+		require.NotNil(t, res.Metadata())
+		assert.Equal(t, 1, *res.Metadata().FeatureCount)
+		assert.Equal(t, []string{"name"}, res.Metadata().Attributes)
+		assert.Equal(t, &asset.Bounds{Min: []float64{139, 35}, Max: []float64{139, 35}}, res.Metadata().Bounds)
+		assert.Empty(t, res.ThumbnailURL())
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		res := create("broken.geojson", []byte("not json"))
+		assert.Nil(t, res.Metadata())
+	})
+}
+
 func createTestPNGImage(width, height int) []byte {
 	img := image.NewRGBA(image.Rect(0, 0, width, height))
 	for y := 0; y < height; y++ {
@@ -0,0 +1,166 @@
+package interactor
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+func (i *Asset) FindUsages(ctx context.Context, aid id.AssetID, operator *usecase.Operator) ([]asset.Usage, error) {
+	return Run1(
+		ctx, operator, i.repos,
+		Usecase(),
+		func(ctx context.Context) ([]asset.Usage, error) {
+			a, err := i.repos.Asset.FindByID(ctx, aid)
+			if err != nil {
+				return nil, err
+			}
+
+			if !operator.IsReadableWorkspace(a.Workspace()) {
+				return nil, interfaces.ErrOperationDenied
+			}
+
+			idx, err := assetUsages(ctx, i.repos.AssetReference, a.URL())
+			if err != nil {
+				return nil, err
+			}
+
+			// the usages in the workspaces that the operator cannot read are hidden
+			var res []asset.Usage
+			for _, u := range idx.Usages(a.URL()) {
+				if operator.IsReadableWorkspace(u.Workspace) {
+					res = append(res, u)
+				}
+			}
+			return res, nil
+		},
+	)
+}
+
+func (i *Asset) FindOrphans(ctx context.Context, wid accountsID.WorkspaceID, operator *usecase.Operator) ([]*asset.Asset, error) {
+	return Run1(
+		ctx, operator, i.repos,
+		Usecase().WithReadableWorkspaces(wid),
+		func(ctx context.Context) ([]*asset.Asset, error) {
+			var res []*asset.Asset
+			if err := repo.IterateAssetsByWorkspaceProject(i.repos.Asset, ctx, wid, nil, assetBatchSize, func(batch []*asset.Asset) error {
+				urls := make([]string, 0, len(batch))
+				for _, a := range batch {
+					urls = append(urls, a.URL())
+				}
+
+				idx, err := assetUsages(ctx, i.repos.AssetReference, urls...)
+				if err != nil {
+					return err
+				}
+				for _, a := range batch {
+					if !idx.IsUsed(a.URL()) {
+						res = append(res, a)
+					}
+				}
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			return res, nil
+		},
+	)
+}
+
+// assetUsages finds where the asset URLs are used in every workspace, including the projects in the trash.
+// The candidates are looked up by the URLs recorded when the entities are saved,
+// then their fields are checked to tell where exactly the URLs are used.
+func assetUsages(ctx context.Context, r repo.AssetReference, urls ...string) (*asset.UsageIndex, error) {
+	idx := asset.NewUsageIndex(urls...)
+	if len(urls) == 0 {
+		return idx, nil
+	}
+
+	refs, err := r.FindByURLs(ctx, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range refs.Projects {
+		u := asset.Usage{Type: asset.UsageTypeProject, Workspace: p.Workspace(), Project: p.ID(), Target: p.ID().String()}
+		if img := p.ImageURL(); img != nil {
+			u.Field = "imageUrl"
+			idx.AddString(img.String(), u)
+		}
+		u.Field = "publicImage"
+		idx.AddString(p.PublicImage(), u)
+		u.Field = "publicIconImage"
+		idx.AddString(p.PublicIconImage(), u)
+	}
+
+	scenes := make(map[id.SceneID]*scene.Scene, len(refs.Scenes))
+	for _, s := range refs.Scenes {
+		scenes[s.ID()] = s
+	}
+	// usage returns the usage in the project of the scene, or false when the scene no longer exists
+	usage := func(sid id.SceneID, t asset.UsageType, target string) (asset.Usage, bool) {
+		s, ok := scenes[sid]
+		if !ok {
+			return asset.Usage{}, false
+		}
+		return asset.Usage{Type: t, Workspace: s.Workspace(), Project: s.Project(), Target: target}, true
+	}
+
+	for _, l := range refs.NLSLayers {
+		u, ok := usage((*l).Scene(), asset.UsageTypeLayer, (*l).ID().String())
+		if !ok {
+			continue
+		}
+		if cfg := (*l).Config(); cfg != nil {
+			idx.AddValue(map[string]any(*cfg), u)
+		}
+		if sk := (*l).Sketch(); sk != nil && sk.FeatureCollection() != nil {
+			u.Type = asset.UsageTypeFeature
+			for _, f := range sk.FeatureCollection().Features() {
+				if props := f.Properties(); props != nil {
+					u.Field = f.ID().String()
+					idx.AddValue(map[string]any(*props), u)
+				}
+			}
+		}
+	}
+
+	for _, s := range refs.Styles {
+		u, ok := usage(s.Scene(), asset.UsageTypeStyle, s.ID().String())
+		if !ok {
+			continue
+		}
+		if v := s.Value(); v != nil {
+			idx.AddValue(map[string]any(*v), u)
+		}
+	}
+
+	for _, p := range refs.Properties {
+		u, ok := usage(p.Scene(), asset.UsageTypeProperty, p.ID().String())
+		if !ok {
+			continue
+		}
+		for _, f := range p.Fields(nil) {
+			u.Field = f.Field().String()
+			switch f.Type() {
+			case property.ValueTypeURL:
+				if v := f.Value().ValueURL(); v != nil {
+					idx.AddString(v.String(), u)
+				}
+			case property.ValueTypeString:
+				if v := f.Value().ValueString(); v != nil {
+					idx.AddString(*v, u)
+				}
+			}
+		}
+	}
+
+	return idx, nil
+}
@@ -0,0 +1,159 @@
+package interactor
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_Usages(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	fileGateway := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com/"))
+	uc := NewAsset(db, &gateway.Container{File: fileGateway})
+
+	wsID := accountsID.NewWorkspaceID()
+	prj := project.New().NewID().Workspace(wsID).MustBuild()
+
+	newAsset := func(name string) *asset.Asset {
+		u, size, err := fileGateway.UploadAsset(ctx, &file.File{
+			Content: io.NopCloser(strings.NewReader(name)),
+			Path:    name,
+		})
+		require.NoError(t, err)
+		a := asset.New().NewID().Workspace(wsID).Name(name).Size(size).URL(u.String()).CoreSupport(true).MustBuild()
+		require.NoError(t, db.Asset.Save(ctx, a))
+		return a
+	}
+	image := newAsset("image.png")
+	data := newAsset("data.geojson")
+	html := newAsset("photo.jpg")
+	icon := newAsset("icon.png")
+	shared := newAsset("shared.png")
+	orphan := newAsset("orphan.png")
+	hidden := newAsset("hidden.png")
+
+	prj.SetImageURL(lo.Must(url.Parse(image.URL())))
+	require.NoError(t, db.Project.Save(ctx, prj))
+
+	sceneID := id.NewSceneID()
+	sceneProp := property.New().NewID().Scene(sceneID).Schema(id.MustPropertySchemaID("reearth/cesium")).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("text").Value(property.ValueTypeString.ValueFrom(`<img src="` + html.URL() + `">`).Some()).MustBuild(),
+		}).MustBuild(),
+	}).MustBuild()
+	require.NoError(t, db.Property.Save(ctx, sceneProp))
+	sc := scene.New().ID(sceneID).Workspace(wsID).Project(prj.ID()).Property(sceneProp.ID()).MustBuild()
+	require.NoError(t, db.Scene.Save(ctx, sc))
+	layer := nlslayer.NewNLSLayerSimple().NewID().Scene(sceneID).Title("layer").LayerType("simple").
+		Config(&nlslayer.Config{"data": map[string]any{"url": data.URL()}}).MustBuild()
+	require.NoError(t, db.NLSLayer.Save(ctx, layer))
+	feature := lo.Must(nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{1, 2})))
+	feature.UpdateProperties(&map[string]any{"marker": map[string]any{"image": icon.URL()}})
+	sketch := nlslayer.NewNLSLayerSimple().NewID().Scene(sceneID).Title("sketch").LayerType("simple").IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*feature}))).MustBuild()
+	require.NoError(t, db.NLSLayer.Save(ctx, sketch))
+
+	// a project of another workspace can refer to the asset by its URL
+	ws2ID := accountsID.NewWorkspaceID()
+	prj2 := project.New().NewID().Workspace(ws2ID).MustBuild()
+	prj2.SetImageURL(lo.Must(url.Parse(shared.URL())))
+	require.NoError(t, db.Project.Save(ctx, prj2))
+	require.NoError(t, db.Scene.Save(ctx, scene.New().NewID().Workspace(ws2ID).Project(prj2.ID()).MustBuild()))
+
+	// a project that the operator cannot read refers to the asset too
+	hiddenWS := accountsID.NewWorkspaceID()
+	hiddenPrj := project.New().NewID().Workspace(hiddenWS).MustBuild()
+	hiddenSceneID := id.NewSceneID()
+	hiddenStyle := scene.NewStyle().NewID().Scene(hiddenSceneID).Value(&scene.StyleValue{"image": hidden.URL()}).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, hiddenPrj))
+	require.NoError(t, db.Scene.Save(ctx, scene.New().ID(hiddenSceneID).Workspace(hiddenWS).Project(hiddenPrj.ID()).MustBuild()))
+	require.NoError(t, db.Style.Save(ctx, *hiddenStyle))
+
+	op := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{
+			WritableWorkspaces: accountsID.WorkspaceIDList{wsID},
+			ReadableWorkspaces: accountsID.WorkspaceIDList{ws2ID},
+		},
+	}
+
+	usages, err := uc.FindUsages(ctx, image.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, []asset.Usage{
+		{Type: asset.UsageTypeProject, Workspace: wsID, Project: prj.ID(), Target: prj.ID().String(), Field: "imageUrl"},
+	}, usages)
+
+	usages, err = uc.FindUsages(ctx, data.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, []asset.Usage{
+		{Type: asset.UsageTypeLayer, Workspace: wsID, Project: prj.ID(), Target: layer.ID().String(), Field: "data.url"},
+	}, usages)
+
+	usages, err = uc.FindUsages(ctx, html.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, []asset.Usage{
+		{Type: asset.UsageTypeProperty, Workspace: wsID, Project: prj.ID(), Target: sceneProp.ID().String(), Field: "text"},
+	}, usages)
+
+	usages, err = uc.FindUsages(ctx, icon.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, []asset.Usage{
+		{Type: asset.UsageTypeFeature, Workspace: wsID, Project: prj.ID(), Target: sketch.ID().String(), Field: feature.ID().String() + ".marker.image"},
+	}, usages)
+
+	usages, err = uc.FindUsages(ctx, shared.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, []asset.Usage{
+		{Type: asset.UsageTypeProject, Workspace: ws2ID, Project: prj2.ID(), Target: prj2.ID().String(), Field: "imageUrl"},
+	}, usages)
+
+	usages, err = uc.FindUsages(ctx, hidden.ID(), op)
+	require.NoError(t, err)
+	assert.Empty(t, usages)
+
+	_, err = uc.FindUsages(ctx, image.ID(), &usecase.Operator{AcOperator: &accountsWorkspace.Operator{}})
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	orphans, err := uc.FindOrphans(ctx, wsID, op)
+	require.NoError(t, err)
+	assert.Equal(t, []*asset.Asset{orphan}, orphans)
+
+	// used assets are kept unless the removal is forced
+	_, err = uc.Remove(ctx, data.ID(), false, op)
+	assert.ErrorIs(t, err, interfaces.ErrAssetInUse)
+	_, err = db.Asset.FindByID(ctx, data.ID())
+	assert.NoError(t, err)
+
+	_, err = uc.Remove(ctx, shared.ID(), false, op)
+	assert.ErrorIs(t, err, interfaces.ErrAssetInUse)
+	_, err = uc.Remove(ctx, hidden.ID(), false, op)
+	assert.ErrorIs(t, err, interfaces.ErrAssetInUse)
+
+	_, err = uc.Remove(ctx, orphan.ID(), false, op)
+	assert.NoError(t, err)
+	_, err = uc.Remove(ctx, data.ID(), true, op)
+	assert.NoError(t, err)
+	_, err = db.Asset.FindByID(ctx, data.ID())
+	assert.Error(t, err)
+}
@@ -15,9 +15,12 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/asset"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/samber/lo"
 
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
@@ -60,6 +63,8 @@ type ContainerConfig struct {
 	AuthSrvUIDomain    string
 	PublishedIndexHTML string
 	PublishedIndexURL  *url.URL
+	// TrashRetention is the default trash retention of the workspaces. Zero keeps the projects in the trash forever.
+	TrashRetention time.Duration
 }
 
 func NewContainer(
@@ -72,25 +77,31 @@ func NewContainer(
 
 	var published interfaces.Published
 	if config.PublishedIndexURL != nil && config.PublishedIndexURL.String() != "" {
-		published = NewPublishedWithURL(r.Project, r.Storytelling, g.File, config.PublishedIndexURL)
+		published = NewPublishedWithURL(r.Project, r.Storytelling, r.ShareToken, r.PublicationVersion, g.File, config.PublishedIndexURL)
 	} else {
-		published = NewPublished(r.Project, r.Storytelling, g.File, config.PublishedIndexHTML)
+		published = NewPublished(r.Project, r.Storytelling, r.ShareToken, r.PublicationVersion, g.File, config.PublishedIndexHTML)
 	}
 
 	return interfaces.Container{
-		Asset:           NewAsset(r, g),
-		NLSLayer:        NewNLSLayer(r, g),
-		Style:           NewStyle(r),
-		Plugin:          NewPlugin(r, g),
-		Policy:          NewPolicy(r, g.PolicyChecker),
-		Project:         NewProject(r, g),
-		ProjectMetadata: NewProjectMetadata(r, g),
-		Property:        NewProperty(r, g),
-		Published:       published,
-		Scene:           NewScene(r, g),
-		StoryTelling:    NewStorytelling(r, g),
-		Workspace:       NewWorkspaceInteractor(ar),
-		User:            NewUserInteractor(ar, ag, config.SignupSecret, config.AuthSrvUIDomain, ar.Users),
+		APIToken:           NewAPIToken(r),
+		Asset:              NewAsset(r, g),
+		NLSLayer:           NewNLSLayer(r, g),
+		Style:              NewStyle(r, g),
+		Plugin:             NewPlugin(r, g),
+		Policy:             NewPolicy(r, g.PolicyChecker),
+		Project:            NewProject(r, g),
+		ProjectMetadata:    NewProjectMetadata(r, g),
+		Property:           NewProperty(r, g),
+		PublicationVersion: NewPublicationVersion(r, g),
+		Published:          published,
+		PublishSchedule:    NewPublishSchedule(r, g),
+		Scene:              NewScene(r, g),
+		ShareToken:         NewShareToken(r),
+		StoryTelling:       NewStorytelling(r, g),
+		Trash:              NewTrash(r, g, config.TrashRetention),
+		Webhook:            NewWebhook(r, g),
+		Workspace:          NewWorkspaceInteractor(ar),
+		User:               NewUserInteractor(ar, ag, config.SignupSecret, config.AuthSrvUIDomain, ar.Users),
 	}
 }
 
@@ -207,6 +218,11 @@ type SceneDeleter struct {
 	Storytelling   repo.Storytelling
 	Style          repo.Style
 	File           gateway.File
+
+	FeatureRevision    repo.FeatureRevision
+	PublicationVersion repo.PublicationVersion
+	ShareToken         repo.ShareToken
+	PublishSchedule    repo.PublishSchedule
 }
 
 func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) error {
@@ -225,6 +241,11 @@ func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) er
 		}
 	}
 
+	// Delete feature revisions
+	if err := d.FeatureRevision.RemoveByScene(ctx, s.ID()); err != nil {
+		return err
+	}
+
 	// Delete nlsLayer
 	if err := d.NLSLayer.RemoveByScene(ctx, s.ID()); err != nil {
 		return err
@@ -245,6 +266,35 @@ func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) er
 		return err
 	}
 
+	stories, err := d.Storytelling.FindByScene(ctx, s.ID())
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+
+	// Unpublish stories
+	for _, st := range lo.FromPtr(stories) {
+		if st.PublishmentStatus() != storytelling.PublishmentStatusPrivate {
+			if err := d.File.RemoveStory(ctx, st.Alias()); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Delete publication versions and their snapshots
+	if err := d.deletePublicationVersions(ctx, s, lo.FromPtr(stories)); err != nil {
+		return err
+	}
+
+	// Delete share tokens
+	if err := d.ShareToken.RemoveByScene(ctx, s.ID()); err != nil {
+		return err
+	}
+
+	// Delete publish schedules
+	if err := d.PublishSchedule.RemoveByScene(ctx, s.ID()); err != nil {
+		return err
+	}
+
 	// Delete storytelling
 	if err := d.Storytelling.RemoveByScene(ctx, s.ID()); err != nil {
 		return err
@@ -268,12 +318,34 @@ func (d SceneDeleter) Delete(ctx context.Context, s *scene.Scene, force bool) er
 	return nil
 }
 
+func (d SceneDeleter) deletePublicationVersions(ctx context.Context, s *scene.Scene, stories storytelling.StoryList) error {
+	versions, err := d.PublicationVersion.FindByProject(ctx, s.Project())
+	if err != nil {
+		return err
+	}
+	for _, st := range stories {
+		v, err := d.PublicationVersion.FindByStory(ctx, st.Id())
+		if err != nil {
+			return err
+		}
+		versions = append(versions, v...)
+	}
+
+	for _, v := range versions {
+		if err := d.File.RemovePublicationSnapshot(ctx, v.SnapshotName()); err != nil {
+			return err
+		}
+	}
+	return d.PublicationVersion.RemoveByScene(ctx, s.ID())
+}
+
 type ProjectDeleter struct {
 	SceneDeleter
 	File            gateway.File
 	Project         repo.Project
 	ProjectMetadata repo.ProjectMetadata
 	Asset           repo.Asset
+	AssetReference  repo.AssetReference
 }
 
 // Delete runs the storage deletes (assets, plugin files, built scene, via
@@ -300,6 +372,11 @@ func (d ProjectDeleter) Delete(ctx context.Context, prj *project.Project, force
 		return err
 	}
 
+	// Keep the assets that other projects use, such as duplicates that share them
+	if err := d.keepUsedAssets(ctx, prj); err != nil {
+		return err
+	}
+
 	// Delete assets
 	if err := d.Asset.RemoveByProjectWithFile(ctx, prj.ID(), d.File); err != nil {
 		return err
@@ -330,6 +407,41 @@ func (d ProjectDeleter) Delete(ctx context.Context, prj *project.Project, force
 	return nil
 }
 
+// keepUsedAssets moves the assets of the project that other projects still refer to into its workspace,
+// so that they are not removed with the project.
+func (d ProjectDeleter) keepUsedAssets(ctx context.Context, prj *project.Project) error {
+	pid := prj.ID()
+	var assets []*asset.Asset
+	if err := repo.IterateAssetsByWorkspaceProject(d.Asset, ctx, prj.Workspace(), &pid, assetBatchSize, func(batch []*asset.Asset) error {
+		assets = append(assets, batch...)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, batch := range lo.Chunk(assets, assetBatchSize) {
+		urls := make([]string, 0, len(batch))
+		for _, a := range batch {
+			urls = append(urls, a.URL())
+		}
+		idx, err := assetUsages(ctx, d.AssetReference, urls...)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range batch {
+			if !lo.ContainsBy(idx.Usages(a.URL()), func(u asset.Usage) bool { return u.Project != pid }) {
+				continue
+			}
+			a.SetProject(nil)
+			if err := d.Asset.Save(ctx, a); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func IsCurrentHostAssets(ctx context.Context, u string) bool {
 	if strings.HasPrefix(u, "assets/") || strings.HasPrefix(u, "/assets/") {
 		return true
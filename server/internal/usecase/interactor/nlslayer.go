@@ -26,6 +26,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/builder"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 
@@ -60,6 +61,8 @@ type NLSLayer struct {
 	common
 	commonSceneLock
 	nlslayerRepo  repo.NLSLayer
+	styleRepo     repo.Style
+	assetRepo     repo.Asset
 	sceneLockRepo repo.SceneLock
 	projectRepo   repo.Project
 	sceneRepo     repo.Scene
@@ -69,13 +72,17 @@ type NLSLayer struct {
 	workspaceRepo accountsWorkspace.Repo
 	transaction   usecasex.Transaction
 
-	propertySchemaRepo repo.PropertySchema
+	propertySchemaRepo  repo.PropertySchema
+	featureRevisionRepo repo.FeatureRevision
+	sceneEvents         sceneEvents
 }
 
 func NewNLSLayer(r *repo.Container, gr *gateway.Container) interfaces.NLSLayer {
 	return &NLSLayer{
 		commonSceneLock: commonSceneLock{sceneLockRepo: r.SceneLock},
 		nlslayerRepo:    r.NLSLayer,
+		styleRepo:       r.Style,
+		assetRepo:       r.Asset,
 		sceneLockRepo:   r.SceneLock,
 		projectRepo:     r.Project,
 		sceneRepo:       r.Scene,
@@ -85,7 +92,9 @@ func NewNLSLayer(r *repo.Container, gr *gateway.Container) interfaces.NLSLayer {
 		workspaceRepo:   r.Workspace,
 		transaction:     r.Transaction,
 
-		propertySchemaRepo: r.PropertySchema,
+		propertySchemaRepo:  r.PropertySchema,
+		featureRevisionRepo: r.FeatureRevision,
+		sceneEvents:         newSceneEvents(gr),
 	}
 }
 
@@ -172,6 +181,9 @@ func (i *NLSLayer) AddLayerSimple(ctx context.Context, inp interfaces.AddNLSLaye
 	}
 
 	if inp.Schema != nil {
+		if _, err := nlslayer.NewCustomPropertySchema(inp.Schema); err != nil {
+			return nil, err
+		}
 		featureCollection := nlslayer.NewFeatureCollection(
 			"FeatureCollection",
 			[]nlslayer.Feature{},
@@ -196,6 +208,7 @@ func (i *NLSLayer) AddLayerSimple(ctx context.Context, inp interfaces.AddNLSLaye
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerAdded, Scene: layerSimple.Scene(), Layer: lo.ToPtr(layerSimple.ID())})
 	return layerSimple, nil
 }
 
@@ -267,6 +280,11 @@ func (i *NLSLayer) Remove(ctx context.Context, lid id.NLSLayerID, operator *usec
 	if err != nil {
 		return lid, nil, err
 	}
+	for _, layerID := range layers {
+		if err := i.featureRevisionRepo.RemoveByLayer(ctx, layerID); err != nil {
+			return lid, nil, err
+		}
+	}
 
 	err = updateProjectUpdatedAtByScene(ctx, l.Scene(), i.projectRepo, i.sceneRepo)
 	if err != nil {
@@ -274,6 +292,9 @@ func (i *NLSLayer) Remove(ctx context.Context, lid id.NLSLayerID, operator *usec
 	}
 
 	tx.Commit()
+	for _, layerID := range layers {
+		i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerRemoved, Scene: l.Scene(), Layer: lo.ToPtr(layerID)})
+	}
 	return lid, parentLayer, nil
 }
 
@@ -330,6 +351,7 @@ func (i *NLSLayer) Update(ctx context.Context, inp interfaces.UpdateNLSLayerInpu
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
 	return layer, nil
 }
 
@@ -387,6 +409,7 @@ func (i *NLSLayer) CreateNLSInfobox(ctx context.Context, lid id.NLSLayerID, oper
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: l.Scene(), Layer: lo.ToPtr(l.ID())})
 	return l, nil
 }
 
@@ -468,6 +491,7 @@ func (i *NLSLayer) CreateNLSPhotoOverlay(ctx context.Context, lid id.NLSLayerID,
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: l.Scene(), Layer: lo.ToPtr(l.ID())})
 	return l, nil
 }
 
@@ -521,6 +545,7 @@ func (i *NLSLayer) RemoveNLSInfobox(ctx context.Context, layerID id.NLSLayerID,
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
 	return layer, nil
 }
 
@@ -574,6 +599,7 @@ func (i *NLSLayer) RemoveNLSPhotoOverlay(ctx context.Context, layerID id.NLSLaye
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
 	return layer, nil
 }
 
@@ -713,6 +739,7 @@ func (i *NLSLayer) AddNLSInfoboxBlock(ctx context.Context, inp interfaces.AddNLS
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: l.Scene(), Layer: lo.ToPtr(l.ID())})
 	return block, l, err
 }
 
@@ -760,6 +787,7 @@ func (i *NLSLayer) MoveNLSInfoboxBlock(ctx context.Context, inp interfaces.MoveN
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
 	return inp.InfoboxBlockID, layer, inp.Index, err
 }
 
@@ -807,6 +835,7 @@ func (i *NLSLayer) RemoveNLSInfoboxBlock(ctx context.Context, inp interfaces.Rem
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
 	return inp.InfoboxBlockID, layer, err
 }
 
@@ -844,6 +873,7 @@ func (i *NLSLayer) Duplicate(ctx context.Context, lid id.NLSLayerID, operator *u
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerAdded, Scene: duplicatedLayer.Scene(), Layer: lo.ToPtr(duplicatedLayer.ID())})
 	return duplicatedLayer, nil
 }
 
@@ -868,6 +898,10 @@ func (i *NLSLayer) AddOrUpdateCustomProperties(ctx context.Context, inp interfac
 		return nil, interfaces.ErrOperationDenied
 	}
 
+	if _, err := nlslayer.NewCustomPropertySchema(&inp.Schema); err != nil {
+		return nil, err
+	}
+
 	if layer.Sketch() == nil {
 		featureCollection := nlslayer.NewFeatureCollection(
 			"FeatureCollection",
@@ -895,6 +929,7 @@ func (i *NLSLayer) AddOrUpdateCustomProperties(ctx context.Context, inp interfac
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
 	return layer, nil
 }
 
@@ -923,45 +958,24 @@ func (i *NLSLayer) ChangeCustomPropertyTitle(ctx context.Context, inp interfaces
 		return nil, interfaces.ErrOperationDenied
 	}
 
-	titleExists := false
-	for _, feature := range layer.Sketch().FeatureCollection().Features() {
-		if props := feature.Properties(); props != nil && *props != nil {
-			if _, ok := (*props)[oldTitle]; ok {
-				titleExists = true
-			}
-			if _, ok := (*props)[newTitle]; ok {
-				return nil, fmt.Errorf("property with title %s already exists", newTitle)
-			}
-		}
+	if _, err := nlslayer.NewCustomPropertySchema(&inp.Schema); err != nil {
+		return nil, err
 	}
 
-	if titleExists {
-		for _, feature := range layer.Sketch().FeatureCollection().Features() {
-			if props := feature.Properties(); props != nil {
-				for k, v := range *props {
-					if k == oldTitle {
-						value := v
-						delete(*props, k)
-						(*props)[newTitle] = value
-					}
-				}
-			}
-		}
+	before := sketchFeatureCollection(layer)
+	fc := before.Clone()
+	if err := fc.RenameProperty(oldTitle, newTitle); err != nil {
+		return nil, err
 	}
 
 	layer.Sketch().SetCustomPropertySchema(&inp.Schema)
 
-	err = i.nlslayerRepo.Save(ctx, layer)
-	if err != nil {
-		return nil, err
-	}
-
-	err = updateProjectUpdatedAtByScene(ctx, layer.Scene(), i.projectRepo, i.sceneRepo)
-	if err != nil {
+	if err := i.saveSketchFeatureCollection(ctx, layer, before, fc, operator); err != nil {
 		return nil, err
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
 	return layer, nil
 }
 
@@ -984,49 +998,29 @@ func (i *NLSLayer) RemoveCustomProperty(ctx context.Context, inp interfaces.AddO
 	}
 
 	if err := i.CanWriteScene(layer.Scene(), operator); err != nil {
-		return nil, err
+		return nil, interfaces.ErrOperationDenied
 	}
 
 	if layer.Sketch() == nil || layer.Sketch().FeatureCollection() == nil {
 		return nil, ErrSketchNotFound
 	}
 
-	// Check if removedTitle exists
-	titleExists := false
-	for _, feature := range layer.Sketch().FeatureCollection().Features() {
-		if props := feature.Properties(); props != nil {
-			if _, ok := (*props)[removedTitle]; ok {
-				titleExists = true
-				break
-			}
-		}
+	if _, err := nlslayer.NewCustomPropertySchema(&inp.Schema); err != nil {
+		return nil, err
 	}
 
-	if titleExists {
-		for _, feature := range layer.Sketch().FeatureCollection().Features() {
-			if props := feature.Properties(); props != nil && *props != nil {
-				for k := range *props {
-					if k == removedTitle {
-						delete(*props, k)
-					}
-				}
-			}
-		}
-	}
+	before := sketchFeatureCollection(layer)
+	fc := before.Clone()
+	fc.RemoveProperty(removedTitle)
 
 	layer.Sketch().SetCustomPropertySchema(&inp.Schema)
 
-	err = i.nlslayerRepo.Save(ctx, layer)
-	if err != nil {
-		return nil, err
-	}
-
-	err = updateProjectUpdatedAtByScene(ctx, layer.Scene(), i.projectRepo, i.sceneRepo)
-	if err != nil {
+	if err := i.saveSketchFeatureCollection(ctx, layer, before, fc, operator); err != nil {
 		return nil, err
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
 	return layer, nil
 }
 
@@ -1051,14 +1045,21 @@ func (i *NLSLayer) AddGeoJSONFeature(ctx context.Context, inp interfaces.AddNLSL
 	if err := i.CanWriteScene(layer.Scene(), operator); err != nil {
 		return nlslayer.Feature{}, err
 	}
+	before := sketchFeatureCollection(layer)
 
 	geometry, err := nlslayer.NewGeometryFromMap(inp.Geometry)
 	if err != nil {
 		return nlslayer.Feature{}, err
 	}
 
+	fid := id.NewFeatureID()
+	geometry, err = nlslayer.ValidateGeometry(fid, geometry, inp.RepairGeometry)
+	if err != nil {
+		return nlslayer.Feature{}, err
+	}
+
 	feature, err := nlslayer.NewFeature(
-		id.NewFeatureID(),
+		fid,
 		inp.Type,
 		geometry,
 	)
@@ -1066,8 +1067,16 @@ func (i *NLSLayer) AddGeoJSONFeature(ctx context.Context, inp interfaces.AddNLSL
 		return nlslayer.Feature{}, err
 	}
 
-	if inp.Properties != nil {
-		feature.UpdateProperties(inp.Properties)
+	schema, err := layer.Sketch().TypedCustomPropertySchema()
+	if err != nil {
+		return nlslayer.Feature{}, err
+	}
+	properties, err := schema.Validate(fid, inp.Properties)
+	if err != nil {
+		return nlslayer.Feature{}, err
+	}
+	if properties != nil {
+		feature.UpdateProperties(properties)
 	}
 
 	if layer.Sketch() == nil {
@@ -1092,12 +1101,18 @@ func (i *NLSLayer) AddGeoJSONFeature(ctx context.Context, inp interfaces.AddNLSL
 		return nlslayer.Feature{}, err
 	}
 
+	err = i.saveFeatureRevisions(ctx, layer, before, operator)
+	if err != nil {
+		return nlslayer.Feature{}, err
+	}
+
 	err = updateProjectUpdatedAtByScene(ctx, layer.Scene(), i.projectRepo, i.sceneRepo)
 	if err != nil {
 		return nlslayer.Feature{}, err
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventFeatureAdded, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID()), Feature: lo.ToPtr(feature.ID())})
 	return *feature, nil
 }
 
@@ -1126,6 +1141,7 @@ func (i *NLSLayer) UpdateGeoJSONFeature(ctx context.Context, inp interfaces.Upda
 	if layer.Sketch() == nil || layer.Sketch().FeatureCollection() == nil || layer.Sketch().FeatureCollection().Features() == nil || len(layer.Sketch().FeatureCollection().Features()) == 0 {
 		return nlslayer.Feature{}, interfaces.ErrFeatureNotFound
 	}
+	before := sketchFeatureCollection(layer)
 
 	var updatedFeature nlslayer.Feature
 	var errUp error
@@ -1135,6 +1151,10 @@ func (i *NLSLayer) UpdateGeoJSONFeature(ctx context.Context, inp interfaces.Upda
 		if err != nil {
 			return nlslayer.Feature{}, err
 		}
+		geometry, err = nlslayer.ValidateGeometry(inp.FeatureID, geometry, inp.RepairGeometry)
+		if err != nil {
+			return nlslayer.Feature{}, err
+		}
 		updatedFeature, errUp = layer.Sketch().FeatureCollection().UpdateFeatureGeometry(inp.FeatureID, geometry)
 		if errUp != nil {
 			return nlslayer.Feature{}, errUp
@@ -1142,7 +1162,15 @@ func (i *NLSLayer) UpdateGeoJSONFeature(ctx context.Context, inp interfaces.Upda
 	}
 
 	if inp.Properties != nil {
-		updatedFeature, errUp = layer.Sketch().FeatureCollection().UpdateFeatureProperty(inp.FeatureID, *inp.Properties)
+		schema, err := layer.Sketch().TypedCustomPropertySchema()
+		if err != nil {
+			return nlslayer.Feature{}, err
+		}
+		properties, err := schema.Validate(inp.FeatureID, inp.Properties)
+		if err != nil {
+			return nlslayer.Feature{}, err
+		}
+		updatedFeature, errUp = layer.Sketch().FeatureCollection().UpdateFeatureProperty(inp.FeatureID, *properties)
 		if errUp != nil {
 			return nlslayer.Feature{}, errUp
 		}
@@ -1153,12 +1181,18 @@ func (i *NLSLayer) UpdateGeoJSONFeature(ctx context.Context, inp interfaces.Upda
 		return nlslayer.Feature{}, err
 	}
 
+	err = i.saveFeatureRevisions(ctx, layer, before, operator)
+	if err != nil {
+		return nlslayer.Feature{}, err
+	}
+
 	err = updateProjectUpdatedAtByScene(ctx, layer.Scene(), i.projectRepo, i.sceneRepo)
 	if err != nil {
 		return nlslayer.Feature{}, err
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventFeatureUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID()), Feature: &inp.FeatureID})
 	return updatedFeature, nil
 }
 
@@ -1187,6 +1221,7 @@ func (i *NLSLayer) DeleteGeoJSONFeature(ctx context.Context, inp interfaces.Dele
 	if layer.Sketch() == nil || layer.Sketch().FeatureCollection() == nil || layer.Sketch().FeatureCollection().Features() == nil || len(layer.Sketch().FeatureCollection().Features()) == 0 {
 		return id.FeatureID{}, interfaces.ErrFeatureNotFound
 	}
+	before := sketchFeatureCollection(layer)
 
 	err = layer.Sketch().FeatureCollection().RemoveFeature(inp.FeatureID)
 	if err != nil {
@@ -1198,12 +1233,18 @@ func (i *NLSLayer) DeleteGeoJSONFeature(ctx context.Context, inp interfaces.Dele
 		return id.FeatureID{}, err
 	}
 
+	err = i.saveFeatureRevisions(ctx, layer, before, operator)
+	if err != nil {
+		return id.FeatureID{}, err
+	}
+
 	err = updateProjectUpdatedAtByScene(ctx, layer.Scene(), i.projectRepo, i.sceneRepo)
 	if err != nil {
 		return id.FeatureID{}, err
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventFeatureRemoved, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID()), Feature: &inp.FeatureID})
 	return inp.FeatureID, nil
 }
 
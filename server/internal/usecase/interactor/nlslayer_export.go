@@ -0,0 +1,79 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/czml"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/nlslayer/encoding"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/rerror"
+)
+
+func (i *NLSLayer) ExportCZML(ctx context.Context, lid id.NLSLayerID, operator *usecase.Operator) ([]czml.Feature, error) {
+	l, err := i.nlslayerRepo.FindByID(ctx, lid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadScene(l.Scene(), operator); err != nil {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	ls := nlslayer.ToNLSLayerSimple(l)
+	if ls == nil {
+		return nil, encoding.ErrNotSketchLayer
+	}
+
+	st, err := i.layerStyle(ctx, ls)
+	if err != nil {
+		return nil, err
+	}
+	return encoding.CZMLPackets(ls, st)
+}
+
+func (i *NLSLayer) ExportShapefile(ctx context.Context, lid id.NLSLayerID, w io.Writer, operator *usecase.Operator) error {
+	l, err := i.nlslayerRepo.FindByID(ctx, lid)
+	if err != nil {
+		return err
+	}
+	if err := i.CanReadScene(l.Scene(), operator); err != nil {
+		return interfaces.ErrOperationDenied
+	}
+
+	ls := nlslayer.ToNLSLayerSimple(l)
+	if ls == nil {
+		return encoding.ErrNotSketchLayer
+	}
+	return encoding.NewShapeEncoder(w).Encode(ls)
+}
+
+// layerStyle returns the style linked by "layerStyleId" of the layer config, or nil if the layer has no style.
+func (i *NLSLayer) layerStyle(ctx context.Context, l *nlslayer.NLSLayerSimple) (*scene.Style, error) {
+	if l.Config() == nil {
+		return nil, nil
+	}
+	sid, ok := (*l.Config())["layerStyleId"].(string)
+	if !ok || sid == "" {
+		return nil, nil
+	}
+	styleID, err := id.StyleIDFrom(sid)
+	if err != nil {
+		return nil, nil
+	}
+	st, err := i.styleRepo.FindByID(ctx, styleID)
+	if err != nil {
+		if errors.Is(err, rerror.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if st.Scene() != l.Scene() {
+		return nil, nil
+	}
+	return st, nil
+}
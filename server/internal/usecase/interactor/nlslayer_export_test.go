@@ -0,0 +1,101 @@
+package interactor
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/czml"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNLSLayer_ExportCZML(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	sid := id.NewSceneID()
+	st := scene.NewStyle().NewID().Scene(sid).Name("s").Value(&scene.StyleValue{
+		"polyline": map[string]any{"strokeColor": "#00ff00", "strokeWidth": 3.0},
+	}).MustBuild()
+	_ = db.Style.Save(ctx, *st)
+
+	f, _ := nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewLineString("LineString", [][]float64{{0, 0}, {1, 1}}))
+	l, _ := nlslayer.NewNLSLayerSimple().
+		NewID().
+		Scene(sid).
+		LayerType(nlslayer.Simple).
+		Title("roads").
+		Config(&nlslayer.Config{"layerStyleId": st.ID().String()}).
+		IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*f}))).
+		Build()
+	_ = db.NLSLayer.Save(ctx, l)
+	other, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).LayerType(nlslayer.Simple).Build()
+	_ = db.NLSLayer.Save(ctx, other)
+
+	il := NewNLSLayer(db, &gateway.Container{})
+	op := &usecase.Operator{
+		ReadableScenes: []id.SceneID{sid},
+	}
+
+	_, err := il.ExportCZML(ctx, l.ID(), &usecase.Operator{})
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	_, err = il.ExportCZML(ctx, other.ID(), op)
+	assert.Error(t, err)
+
+	res, err := il.ExportCZML(ctx, l.ID(), op)
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "roads", res[0].Name)
+	assert.Equal(t, &czml.Polyline{
+		Positions: czml.Position{CartographicDegrees: []float64{0, 0, 0, 1, 1, 0}},
+		Material:  &czml.Material{SolidColor: &czml.SolidColor{Color: &czml.Color{RGBA: []int64{0, 255, 0, 255}}}},
+		Width:     3,
+	}, res[1].Polyline)
+}
+
+func TestNLSLayer_ExportShapefile(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	sid := id.NewSceneID()
+	f, _ := nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{1, 2}))
+	l, _ := nlslayer.NewNLSLayerSimple().
+		NewID().
+		Scene(sid).
+		LayerType(nlslayer.Simple).
+		Title("gates").
+		IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*f}))).
+		Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	il := NewNLSLayer(db, &gateway.Container{})
+
+	err := il.ExportShapefile(ctx, l.ID(), io.Discard, &usecase.Operator{})
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	buf := &bytes.Buffer{}
+	err = il.ExportShapefile(ctx, l.ID(), buf, &usecase.Operator{
+		ReadableScenes: []id.SceneID{sid},
+	})
+	assert.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"gates_point.shp", "gates_point.shx", "gates_point.dbf", "gates_point.prj", "gates_point.cpg"}, names)
+}
@@ -0,0 +1,273 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/nlslayer/decoding"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/samber/lo"
+)
+
+var ErrInvalidFeatureOperation = errors.New("invalid feature operation")
+
+// BatchGeoJSONFeatures applies the operations in order to a copy of the feature collection of the layer.
+// The layer is saved once only when every operation succeeds, so a failing operation leaves the layer untouched.
+func (i *NLSLayer) BatchGeoJSONFeatures(ctx context.Context, inp interfaces.BatchNLSLayerGeoJSONFeaturesParams, operator *usecase.Operator) (_ *interfaces.BatchNLSLayerGeoJSONFeaturesResult, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	layer, err := i.nlslayerRepo.FindByID(ctx, inp.LayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.CanWriteScene(layer.Scene(), operator); err != nil {
+		return nil, err
+	}
+
+	schema, err := layer.Sketch().TypedCustomPropertySchema()
+	if err != nil {
+		return nil, err
+	}
+
+	before := sketchFeatureCollection(layer)
+	fc := before.Clone()
+	added := map[id.FeatureID]struct{}{}
+	updated := map[id.FeatureID]struct{}{}
+	var order []id.FeatureID
+	var deleted []id.FeatureID
+
+	for n, op := range inp.Operations {
+		switch op.Type {
+		case interfaces.FeatureOperationAdd:
+			if op.Geometry == nil {
+				return nil, fmt.Errorf("operation %d: %w: geometry is required", n, ErrInvalidFeatureOperation)
+			}
+			featureType := op.FeatureType
+			if featureType == "" {
+				featureType = "Feature"
+			}
+			feature, err := newSketchFeature(id.NewFeatureID(), featureType, *op.Geometry, inp.RepairGeometry)
+			if err != nil {
+				return nil, fmt.Errorf("operation %d: %w", n, err)
+			}
+			properties, err := schema.Validate(feature.ID(), op.Properties)
+			if err != nil {
+				return nil, fmt.Errorf("operation %d: %w", n, err)
+			}
+			if properties != nil {
+				feature.UpdateProperties(properties)
+			}
+			fc.AddFeature(*feature)
+			added[feature.ID()] = struct{}{}
+			order = append(order, feature.ID())
+
+		case interfaces.FeatureOperationUpdate:
+			if op.FeatureID == nil {
+				return nil, fmt.Errorf("operation %d: %w: feature id is required", n, ErrInvalidFeatureOperation)
+			}
+			if _, ok := fc.Feature(*op.FeatureID); !ok {
+				return nil, fmt.Errorf("operation %d: %w", n, interfaces.ErrFeatureNotFound)
+			}
+			if op.Geometry != nil {
+				geometry, err := nlslayer.NewGeometryFromMap(*op.Geometry)
+				if err != nil {
+					return nil, fmt.Errorf("operation %d: %w", n, err)
+				}
+				if geometry, err = nlslayer.ValidateGeometry(*op.FeatureID, geometry, inp.RepairGeometry); err != nil {
+					return nil, fmt.Errorf("operation %d: %w", n, err)
+				}
+				if _, err := fc.UpdateFeatureGeometry(*op.FeatureID, geometry); err != nil {
+					return nil, fmt.Errorf("operation %d: %w", n, err)
+				}
+			}
+			if op.Properties != nil {
+				properties, err := schema.Validate(*op.FeatureID, op.Properties)
+				if err != nil {
+					return nil, fmt.Errorf("operation %d: %w", n, err)
+				}
+				if _, err := fc.UpdateFeatureProperty(*op.FeatureID, *properties); err != nil {
+					return nil, fmt.Errorf("operation %d: %w", n, err)
+				}
+			}
+			if _, ok := updated[*op.FeatureID]; !ok {
+				updated[*op.FeatureID] = struct{}{}
+				order = append(order, *op.FeatureID)
+			}
+
+		case interfaces.FeatureOperationDelete:
+			if op.FeatureID == nil {
+				return nil, fmt.Errorf("operation %d: %w: feature id is required", n, ErrInvalidFeatureOperation)
+			}
+			if err := fc.RemoveFeature(*op.FeatureID); err != nil {
+				return nil, fmt.Errorf("operation %d: %w", n, interfaces.ErrFeatureNotFound)
+			}
+			if _, ok := added[*op.FeatureID]; ok {
+				// the feature was added in this batch, so it is as if it never existed
+				delete(added, *op.FeatureID)
+			} else {
+				deleted = append(deleted, *op.FeatureID)
+			}
+			delete(updated, *op.FeatureID)
+
+		default:
+			return nil, fmt.Errorf("operation %d: %w: unknown type %q", n, ErrInvalidFeatureOperation, op.Type)
+		}
+	}
+
+	setSketchFeatureCollection(layer, fc)
+	if err := i.nlslayerRepo.Save(ctx, layer); err != nil {
+		return nil, err
+	}
+	if err := i.saveFeatureRevisions(ctx, layer, before, operator); err != nil {
+		return nil, err
+	}
+
+	if err := updateProjectUpdatedAtByScene(ctx, layer.Scene(), i.projectRepo, i.sceneRepo); err != nil {
+		return nil, err
+	}
+
+	res := &interfaces.BatchNLSLayerGeoJSONFeaturesResult{
+		Deleted: deleted,
+	}
+	for _, fid := range order {
+		f, ok := fc.Feature(fid)
+		if !ok {
+			continue
+		}
+		if _, ok := added[fid]; ok {
+			res.Added = append(res.Added, f)
+		} else if _, ok := updated[fid]; ok {
+			res.Updated = append(res.Updated, f)
+		}
+	}
+
+	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventFeatureUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
+	return res, nil
+}
+
+// ImportGeoJSONFeatures reads a GeoJSON FeatureCollection and appends its features to the layer or replaces the features of the layer with them.
+// The properties of imported features are validated against the custom property schema in the same way as added features.
+func (i *NLSLayer) ImportGeoJSONFeatures(ctx context.Context, inp interfaces.ImportNLSLayerGeoJSONFeaturesParams, operator *usecase.Operator) (_ *nlslayer.FeatureCollection, err error) {
+	if inp.File == nil || inp.File.Content == nil {
+		return nil, interfaces.ErrFileNotIncluded
+	}
+	defer func() {
+		_ = inp.File.Content.Close()
+	}()
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	layer, err := i.nlslayerRepo.FindByID(ctx, inp.LayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.CanWriteScene(layer.Scene(), operator); err != nil {
+		return nil, err
+	}
+
+	schema, err := layer.Sketch().TypedCustomPropertySchema()
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := decoding.GeoJSONFeatures(inp.File.Content)
+	if err != nil {
+		return nil, err
+	}
+	for n, f := range features {
+		geometry, err := nlslayer.ValidateGeometry(f.ID(), f.Geometry(), inp.RepairGeometry)
+		if err != nil {
+			return nil, fmt.Errorf("features[%d]: %w", n, err)
+		}
+		features[n].UpdateGeometry(geometry)
+		properties, err := schema.Validate(f.ID(), f.Properties())
+		if err != nil {
+			return nil, fmt.Errorf("features[%d]: %w", n, err)
+		}
+		if properties != nil {
+			features[n].UpdateProperties(properties)
+		}
+	}
+
+	before := sketchFeatureCollection(layer)
+	fc := before.Clone()
+	if inp.Replace {
+		fc = nlslayer.NewFeatureCollection("FeatureCollection", features)
+	} else {
+		for _, f := range features {
+			fc.AddFeature(f)
+		}
+	}
+
+	setSketchFeatureCollection(layer, fc)
+	if err := i.nlslayerRepo.Save(ctx, layer); err != nil {
+		return nil, err
+	}
+	if err := i.saveFeatureRevisions(ctx, layer, before, operator); err != nil {
+		return nil, err
+	}
+
+	if err := updateProjectUpdatedAtByScene(ctx, layer.Scene(), i.projectRepo, i.sceneRepo); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventFeatureUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
+	return fc, nil
+}
+
+func newSketchFeature(fid id.FeatureID, featureType string, geometryMap map[string]any, repair bool) (*nlslayer.Feature, error) {
+	geometry, err := nlslayer.NewGeometryFromMap(geometryMap)
+	if err != nil {
+		return nil, err
+	}
+	if geometry, err = nlslayer.ValidateGeometry(fid, geometry, repair); err != nil {
+		return nil, err
+	}
+	return nlslayer.NewFeature(fid, featureType, geometry)
+}
+
+// sketchFeatureCollection returns a copy of the feature collection of the layer, which is safe to modify before saving.
+func sketchFeatureCollection(layer nlslayer.NLSLayer) *nlslayer.FeatureCollection {
+	if layer.Sketch() != nil && layer.Sketch().FeatureCollection() != nil {
+		return layer.Sketch().FeatureCollection().Clone()
+	}
+	return nlslayer.NewFeatureCollection("FeatureCollection", nil)
+}
+
+func setSketchFeatureCollection(layer nlslayer.NLSLayer, fc *nlslayer.FeatureCollection) {
+	if layer.Sketch() == nil {
+		layer.SetIsSketch(true)
+		layer.SetSketch(nlslayer.NewSketchInfo(nil, fc))
+		return
+	}
+	layer.Sketch().SetFeatureCollection(fc)
+}
@@ -0,0 +1,200 @@
+package interactor
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchGeoJSONFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	prj, _ := project.New().NewID().Build()
+	_ = db.Project.Save(ctx, prj)
+	s, _ := scene.New().NewID().Workspace(accountsID.NewWorkspaceID()).Project(prj.ID()).Build()
+	_ = db.Scene.Save(ctx, s)
+	il := NewNLSLayer(db, &gateway.Container{
+		File: lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com")),
+	})
+	operator := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	f1 := lo.Must(nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{1, 2})))
+	f2 := lo.Must(nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{3, 4})))
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", []nlslayer.Feature{*f1, *f2}))).
+		Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	point := func(x, y float64) *map[string]any {
+		return &map[string]any{"type": "Point", "coordinates": []any{x, y}}
+	}
+
+	t.Run("applies all operations", func(t *testing.T) {
+		res, err := il.BatchGeoJSONFeatures(ctx, interfaces.BatchNLSLayerGeoJSONFeaturesParams{
+			LayerID: l.ID(),
+			Operations: []interfaces.FeatureOperation{
+				{Type: interfaces.FeatureOperationAdd, Geometry: point(5, 6), Properties: &map[string]any{"name": "new"}},
+				{Type: interfaces.FeatureOperationUpdate, FeatureID: lo.ToPtr(f1.ID()), Geometry: point(7, 8)},
+				{Type: interfaces.FeatureOperationDelete, FeatureID: lo.ToPtr(f2.ID())},
+			},
+		}, operator)
+		assert.NoError(t, err)
+		assert.Len(t, res.Added, 1)
+		assert.Equal(t, map[string]any{"name": "new"}, *res.Added[0].Properties())
+		assert.Len(t, res.Updated, 1)
+		assert.Equal(t, nlslayer.NewPoint("Point", []float64{7, 8}), res.Updated[0].Geometry())
+		assert.Equal(t, []id.FeatureID{f2.ID()}, res.Deleted)
+
+		saved, err := db.NLSLayer.FindByID(ctx, l.ID())
+		assert.NoError(t, err)
+		features := saved.Sketch().FeatureCollection().Features()
+		assert.Len(t, features, 2)
+		assert.Equal(t, f1.ID(), features[0].ID())
+		assert.Equal(t, res.Added[0].ID(), features[1].ID())
+	})
+
+	t.Run("is atomic", func(t *testing.T) {
+		before, err := db.NLSLayer.FindByID(ctx, l.ID())
+		assert.NoError(t, err)
+		beforeFeatures := before.Sketch().FeatureCollection().Features()
+
+		_, err = il.BatchGeoJSONFeatures(ctx, interfaces.BatchNLSLayerGeoJSONFeaturesParams{
+			LayerID: l.ID(),
+			Operations: []interfaces.FeatureOperation{
+				{Type: interfaces.FeatureOperationDelete, FeatureID: lo.ToPtr(f1.ID())},
+				{Type: interfaces.FeatureOperationDelete, FeatureID: lo.ToPtr(id.NewFeatureID())},
+			},
+		}, operator)
+		assert.ErrorIs(t, err, interfaces.ErrFeatureNotFound)
+
+		after, err := db.NLSLayer.FindByID(ctx, l.ID())
+		assert.NoError(t, err)
+		assert.Equal(t, beforeFeatures, after.Sketch().FeatureCollection().Features())
+	})
+
+	t.Run("rejects invalid operations", func(t *testing.T) {
+		_, err := il.BatchGeoJSONFeatures(ctx, interfaces.BatchNLSLayerGeoJSONFeaturesParams{
+			LayerID:    l.ID(),
+			Operations: []interfaces.FeatureOperation{{Type: interfaces.FeatureOperationUpdate}},
+		}, operator)
+		assert.ErrorIs(t, err, ErrInvalidFeatureOperation)
+	})
+
+	t.Run("denies an operator without write access", func(t *testing.T) {
+		_, err := il.BatchGeoJSONFeatures(ctx, interfaces.BatchNLSLayerGeoJSONFeaturesParams{
+			LayerID:    l.ID(),
+			Operations: []interfaces.FeatureOperation{{Type: interfaces.FeatureOperationAdd, Geometry: point(0, 0)}},
+		}, &usecase.Operator{})
+		assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	})
+}
+
+func TestImportGeoJSONFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	prj, _ := project.New().NewID().Build()
+	_ = db.Project.Save(ctx, prj)
+	s, _ := scene.New().NewID().Workspace(accountsID.NewWorkspaceID()).Project(prj.ID()).Build()
+	_ = db.Scene.Save(ctx, s)
+	il := NewNLSLayer(db, &gateway.Container{
+		File: lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com")),
+	})
+	operator := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	upload := func(content string) *file.File {
+		return &file.File{
+			Content: io.NopCloser(strings.NewReader(content)),
+			Path:    "features.geojson",
+		}
+	}
+	const geojson = `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {"name": "a"}},
+		{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}
+	]}`
+
+	fc, err := il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: l.ID(),
+		File:    upload(geojson),
+	}, operator)
+	assert.NoError(t, err)
+	assert.Len(t, fc.Features(), 2)
+
+	fc, err = il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: l.ID(),
+		File:    upload(geojson),
+	}, operator)
+	assert.NoError(t, err)
+	assert.Len(t, fc.Features(), 4)
+
+	fc, err = il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: l.ID(),
+		File:    upload(geojson),
+		Replace: true,
+	}, operator)
+	assert.NoError(t, err)
+	assert.Len(t, fc.Features(), 2)
+
+	saved, err := db.NLSLayer.FindByID(ctx, l.ID())
+	assert.NoError(t, err)
+	assert.True(t, saved.IsSketch())
+	assert.Equal(t, fc.Features(), saved.Sketch().FeatureCollection().Features())
+
+	_, err = il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: l.ID(),
+		File: upload(`{"type": "FeatureCollection", "features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [200, 0]}}
+		]}`),
+	}, operator)
+	assert.ErrorContains(t, err, "out of range")
+
+	// imported properties are validated against the custom property schema
+	_, err = il.AddOrUpdateCustomProperties(ctx, interfaces.AddOrUpdateCustomPropertiesInput{
+		LayerID: l.ID(),
+		Schema: map[string]any{
+			"name": map[string]any{"type": "string", "required": true},
+			"kind": map[string]any{"type": "enum", "options": []any{"a", "b"}, "default": "a"},
+		},
+	}, operator)
+	assert.NoError(t, err)
+
+	_, err = il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: l.ID(),
+		File:    upload(geojson),
+	}, operator)
+	assert.ErrorContains(t, err, nlslayer.ErrMissingCustomProperty.Error())
+
+	fc, err = il.ImportGeoJSONFeatures(ctx, interfaces.ImportNLSLayerGeoJSONFeaturesParams{
+		LayerID: l.ID(),
+		File: upload(`{"type": "FeatureCollection", "features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {"name": "a"}}
+		]}`),
+		Replace: true,
+	}, operator)
+	assert.NoError(t, err)
+	assert.Equal(t, &map[string]any{"name": "a", "kind": "a"}, fc.Features()[0].Properties())
+}
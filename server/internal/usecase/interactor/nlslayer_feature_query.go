@@ -0,0 +1,85 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+var ErrInvalidFeatureCursor = errors.New("invalid feature cursor")
+
+// QueryFeatures returns the features of a sketch layer matching the query, paginated in collection order.
+// Feature IDs are used as cursors.
+func (i *NLSLayer) QueryFeatures(ctx context.Context, inp interfaces.QueryNLSLayerFeaturesParams, operator *usecase.Operator) ([]nlslayer.Feature, *usecasex.PageInfo, error) {
+	layer, err := i.nlslayerRepo.FindByID(ctx, inp.LayerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.CanReadScene(layer.Scene(), operator); err != nil {
+		return nil, nil, interfaces.ErrOperationDenied
+	}
+
+	var features []nlslayer.Feature
+	if layer.Sketch() != nil {
+		features = layer.Sketch().FeatureCollection().Query(inp.Query)
+	}
+	return paginateFeatures(features, inp.Pagination)
+}
+
+func paginateFeatures(features []nlslayer.Feature, p *usecasex.Pagination) ([]nlslayer.Feature, *usecasex.PageInfo, error) {
+	total := int64(len(features))
+	start, end := 0, len(features)
+
+	if p != nil && p.Offset != nil {
+		start = min(max(int(p.Offset.Offset), 0), end)
+		if p.Offset.Limit > 0 {
+			end = min(start+int(p.Offset.Limit), end)
+		}
+	} else if p != nil && p.Cursor != nil {
+		c := p.Cursor
+		indexOf := func(cur usecasex.Cursor) int {
+			for n, f := range features {
+				if f.ID().String() == string(cur) {
+					return n
+				}
+			}
+			return -1
+		}
+		if c.After != nil {
+			n := indexOf(*c.After)
+			if n < 0 {
+				return nil, nil, ErrInvalidFeatureCursor
+			}
+			start = n + 1
+		}
+		if c.Before != nil {
+			n := indexOf(*c.Before)
+			if n < 0 {
+				return nil, nil, ErrInvalidFeatureCursor
+			}
+			end = n
+		}
+		if end < start {
+			end = start
+		}
+		if c.First != nil && int(*c.First) < end-start {
+			end = start + max(int(*c.First), 0)
+		}
+		if c.Last != nil && int(*c.Last) < end-start {
+			start = end - max(int(*c.Last), 0)
+		}
+	}
+
+	page := features[start:end]
+	var startCursor, endCursor *usecasex.Cursor
+	if len(page) > 0 {
+		sc := usecasex.Cursor(page[0].ID().String())
+		ec := usecasex.Cursor(page[len(page)-1].ID().String())
+		startCursor, endCursor = &sc, &ec
+	}
+	return page, usecasex.NewPageInfo(total, startCursor, endCursor, end < len(features), start > 0), nil
+}
@@ -0,0 +1,112 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	s, _ := scene.New().NewID().Workspace(accountsID.NewWorkspaceID()).Project(id.NewProjectID()).Build()
+	_ = db.Scene.Save(ctx, s)
+	il := NewNLSLayer(db, &gateway.Container{})
+	operator := &usecase.Operator{
+		ReadableScenes: []id.SceneID{s.ID()},
+	}
+
+	var features []nlslayer.Feature
+	for x := 0; x < 5; x++ {
+		f := lo.Must(nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{float64(x), 0})))
+		features = append(features, *f)
+	}
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", features))).
+		Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	bbox := nlslayer.FeatureQuery{BBox: &nlslayer.BBox{West: 0.5, South: -1, East: 10, North: 1}}
+	cursor := func(f nlslayer.Feature) *usecasex.Cursor {
+		return lo.ToPtr(usecasex.Cursor(f.ID().String()))
+	}
+
+	tests := []struct {
+		name       string
+		pagination *usecasex.Pagination
+		want       []nlslayer.Feature
+		wantNext   bool
+		wantPrev   bool
+		wantErr    error
+	}{
+		{
+			name: "no pagination",
+			want: features[1:],
+		},
+		{
+			name:       "first",
+			pagination: usecasex.CursorPagination{First: lo.ToPtr(int64(2))}.Wrap(),
+			want:       features[1:3],
+			wantNext:   true,
+		},
+		{
+			name:       "first after",
+			pagination: usecasex.CursorPagination{First: lo.ToPtr(int64(2)), After: cursor(features[2])}.Wrap(),
+			want:       features[3:5],
+			wantPrev:   true,
+		},
+		{
+			name:       "last before",
+			pagination: usecasex.CursorPagination{Last: lo.ToPtr(int64(1)), Before: cursor(features[3])}.Wrap(),
+			want:       features[2:3],
+			wantNext:   true,
+			wantPrev:   true,
+		},
+		{
+			name:       "offset",
+			pagination: usecasex.OffsetPagination{Offset: 3, Limit: 10}.Wrap(),
+			want:       features[4:],
+			wantPrev:   true,
+		},
+		{
+			name:       "unknown cursor",
+			pagination: usecasex.CursorPagination{After: lo.ToPtr(usecasex.Cursor(id.NewFeatureID().String()))}.Wrap(),
+			wantErr:    ErrInvalidFeatureCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, pi, err := il.QueryFeatures(ctx, interfaces.QueryNLSLayerFeaturesParams{
+				LayerID:    l.ID(),
+				Query:      bbox,
+				Pagination: tt.pagination,
+			}, operator)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, int64(4), pi.TotalCount)
+			assert.Equal(t, tt.wantNext, pi.HasNextPage)
+			assert.Equal(t, tt.wantPrev, pi.HasPreviousPage)
+		})
+	}
+
+	_, _, err := il.QueryFeatures(ctx, interfaces.QueryNLSLayerFeaturesParams{LayerID: l.ID()}, &usecase.Operator{})
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+}
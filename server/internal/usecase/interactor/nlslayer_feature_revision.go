@@ -0,0 +1,148 @@
+package interactor
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+)
+
+func (i *NLSLayer) FetchFeatureRevisions(ctx context.Context, inp interfaces.FetchNLSLayerFeatureRevisionsParams, operator *usecase.Operator) ([]*nlslayer.FeatureRevision, *usecasex.PageInfo, error) {
+	layer, err := i.nlslayerRepo.FindByID(ctx, inp.LayerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.CanReadScene(layer.Scene(), operator); err != nil {
+		return nil, nil, interfaces.ErrOperationDenied
+	}
+
+	return i.featureRevisionRepo.FindByLayer(ctx, layer.ID(), repo.FeatureRevisionFilter{
+		Feature:    inp.FeatureID,
+		Pagination: inp.Pagination,
+	})
+}
+
+// RevertFeature restores a single feature to its state right after the revision and returns the revision.
+// When the revision deleted the feature, the feature is removed.
+func (i *NLSLayer) RevertFeature(ctx context.Context, inp interfaces.RevertNLSLayerFeatureParams, operator *usecase.Operator) (_ *nlslayer.FeatureRevision, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	layer, rev, err := i.findFeatureRevision(ctx, inp, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	before := sketchFeatureCollection(layer)
+	fc := before.Clone()
+	if rev.After() == nil {
+		_ = fc.RemoveFeature(rev.Feature())
+	} else {
+		fc.PutFeature(*rev.After())
+	}
+
+	if err := i.saveSketchFeatureCollection(ctx, layer, before, fc, operator); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventFeatureUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID()), Feature: lo.ToPtr(rev.Feature())})
+	return rev, nil
+}
+
+// RevertFeatureCollection restores all features of the layer to their state right after the revision by undoing every newer revision.
+// The revert itself is recorded as new revisions, so it can be reverted as well.
+func (i *NLSLayer) RevertFeatureCollection(ctx context.Context, inp interfaces.RevertNLSLayerFeatureParams, operator *usecase.Operator) (_ *nlslayer.FeatureCollection, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	layer, rev, err := i.findFeatureRevision(ctx, inp, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	newer, err := i.featureRevisionRepo.FindByLayerAfter(ctx, layer.ID(), rev.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	before := sketchFeatureCollection(layer)
+	fc := nlslayer.RevertFeatureCollection(before, newer)
+
+	if err := i.saveSketchFeatureCollection(ctx, layer, before, fc, operator); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventFeatureUpdated, Scene: layer.Scene(), Layer: lo.ToPtr(layer.ID())})
+	return fc, nil
+}
+
+func (i *NLSLayer) findFeatureRevision(ctx context.Context, inp interfaces.RevertNLSLayerFeatureParams, operator *usecase.Operator) (nlslayer.NLSLayer, *nlslayer.FeatureRevision, error) {
+	layer, err := i.nlslayerRepo.FindByID(ctx, inp.LayerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.CanWriteScene(layer.Scene(), operator); err != nil {
+		return nil, nil, err
+	}
+
+	rev, err := i.featureRevisionRepo.FindByID(ctx, inp.RevisionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rev.Layer() != layer.ID() {
+		return nil, nil, rerror.ErrNotFound
+	}
+	return layer, rev, nil
+}
+
+// saveSketchFeatureCollection saves the layer with the feature collection and records the changes from before as revisions.
+func (i *NLSLayer) saveSketchFeatureCollection(ctx context.Context, layer nlslayer.NLSLayer, before, fc *nlslayer.FeatureCollection, operator *usecase.Operator) error {
+	setSketchFeatureCollection(layer, fc)
+	if err := i.nlslayerRepo.Save(ctx, layer); err != nil {
+		return err
+	}
+	if err := i.saveFeatureRevisions(ctx, layer, before, operator); err != nil {
+		return err
+	}
+	return updateProjectUpdatedAtByScene(ctx, layer.Scene(), i.projectRepo, i.sceneRepo)
+}
+
+// saveFeatureRevisions records the changes between before and the current feature collection of the layer.
+func (i *NLSLayer) saveFeatureRevisions(ctx context.Context, layer nlslayer.NLSLayer, before *nlslayer.FeatureCollection, operator *usecase.Operator) error {
+	var user *accountsID.UserID
+	if operator != nil && operator.AcOperator != nil {
+		user = operator.AcOperator.User
+	}
+	var after *nlslayer.FeatureCollection
+	if layer.Sketch() != nil {
+		after = layer.Sketch().FeatureCollection()
+	}
+	return i.featureRevisionRepo.SaveAll(ctx, nlslayer.DiffFeatureCollections(layer, before, after, user))
+}
@@ -0,0 +1,108 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNLSLayer_FeatureRevisions(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	prj, _ := project.New().NewID().Build()
+	_ = db.Project.Save(ctx, prj)
+	s, _ := scene.New().NewID().Workspace(accountsID.NewWorkspaceID()).Project(prj.ID()).Build()
+	_ = db.Scene.Save(ctx, s)
+	il := NewNLSLayer(db, &gateway.Container{
+		File: lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com")),
+	})
+	operator := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	point := func(x, y float64) map[string]any {
+		return map[string]any{"type": "Point", "coordinates": []any{x, y}}
+	}
+	features := func() []nlslayer.Feature {
+		saved, err := db.NLSLayer.FindByID(ctx, l.ID())
+		require.NoError(t, err)
+		return saved.Sketch().FeatureCollection().Features()
+	}
+
+	f1, err := il.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{LayerID: l.ID(), Type: "Feature", Geometry: point(1, 1)}, operator)
+	require.NoError(t, err)
+	f2, err := il.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{LayerID: l.ID(), Type: "Feature", Geometry: point(2, 2)}, operator)
+	require.NoError(t, err)
+	snapshot := features()
+
+	moved := point(3, 3)
+	_, err = il.UpdateGeoJSONFeature(ctx, interfaces.UpdateNLSLayerGeoJSONFeatureParams{LayerID: l.ID(), FeatureID: f1.ID(), Geometry: &moved}, operator)
+	require.NoError(t, err)
+	_, err = il.DeleteGeoJSONFeature(ctx, interfaces.DeleteNLSLayerGeoJSONFeatureParams{LayerID: l.ID(), FeatureID: f2.ID()}, operator)
+	require.NoError(t, err)
+	assert.Len(t, features(), 1)
+
+	revisions, pi, err := il.FetchFeatureRevisions(ctx, interfaces.FetchNLSLayerFeatureRevisionsParams{LayerID: l.ID()}, operator)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), pi.TotalCount)
+	assert.Equal(t, []nlslayer.FeatureRevisionAction{
+		nlslayer.FeatureRevisionActionDelete,
+		nlslayer.FeatureRevisionActionUpdate,
+		nlslayer.FeatureRevisionActionAdd,
+		nlslayer.FeatureRevisionActionAdd,
+	}, lo.Map(revisions, func(r *nlslayer.FeatureRevision, _ int) nlslayer.FeatureRevisionAction { return r.Action() }))
+
+	f1Revisions, _, err := il.FetchFeatureRevisions(ctx, interfaces.FetchNLSLayerFeatureRevisionsParams{LayerID: l.ID(), FeatureID: lo.ToPtr(f1.ID())}, operator)
+	require.NoError(t, err)
+	assert.Len(t, f1Revisions, 2)
+
+	t.Run("revert a feature", func(t *testing.T) {
+		// restore the deleted feature from the revision that added it
+		restored, err := il.RevertFeature(ctx, interfaces.RevertNLSLayerFeatureParams{LayerID: l.ID(), RevisionID: revisions[2].ID()}, operator)
+		require.NoError(t, err)
+		assert.Equal(t, f2.ID(), restored.Feature())
+		assert.Equal(t, f2.ID(), restored.After().ID())
+		assert.Len(t, features(), 2)
+
+		_, err = il.RevertFeature(ctx, interfaces.RevertNLSLayerFeatureParams{LayerID: l.ID(), RevisionID: revisions[2].ID()}, &usecase.Operator{})
+		assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	})
+
+	t.Run("revert the layer", func(t *testing.T) {
+		fc, err := il.RevertFeatureCollection(ctx, interfaces.RevertNLSLayerFeatureParams{LayerID: l.ID(), RevisionID: revisions[2].ID()}, operator)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, snapshot, fc.Features())
+		assert.ElementsMatch(t, snapshot, features())
+
+		// the revert is recorded as well
+		_, pi, err := il.FetchFeatureRevisions(ctx, interfaces.FetchNLSLayerFeatureRevisionsParams{LayerID: l.ID()}, operator)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), pi.TotalCount)
+	})
+
+	t.Run("revision of another layer", func(t *testing.T) {
+		other, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(s.ID()).Build()
+		_ = db.NLSLayer.Save(ctx, other)
+		_, err := il.RevertFeatureCollection(ctx, interfaces.RevertNLSLayerFeatureParams{LayerID: other.ID(), RevisionID: revisions[0].ID()}, operator)
+		assert.ErrorIs(t, err, rerror.ErrNotFound)
+	})
+}
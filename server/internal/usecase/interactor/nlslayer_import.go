@@ -0,0 +1,205 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/kml"
+	"github.com/reearth/reearth/server/pkg/nlslayer/decoding"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/shp"
+	"github.com/samber/lo"
+)
+
+var ErrUnsupportedImportFile = errors.New("unsupported import file")
+
+func (i *NLSLayer) ImportKML(ctx context.Context, inp interfaces.ImportNLSLayerInput, operator *usecase.Operator) (_ *decoding.Result, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	if err := i.CanWriteScene(inp.SceneID, operator); err != nil {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	// check scene lock
+	if err := i.CheckSceneLock(ctx, inp.SceneID); err != nil {
+		return nil, err
+	}
+
+	a, data, err := i.readImportAsset(ctx, inp.SceneID, inp.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(path.Ext(a.Name())) {
+	case ".kml", ".kmz":
+	default:
+		return nil, ErrUnsupportedImportFile
+	}
+
+	c, err := kml.ParseFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := decoding.NewKMLDecoder(inp.SceneID).Decode(c, importTitle(inp.Title, c.Name, a))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.saveImportResult(ctx, res, inp); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	i.publishImportResult(ctx, res, operator)
+	return res, nil
+}
+
+func (i *NLSLayer) ImportShapefile(ctx context.Context, inp interfaces.ImportNLSLayerInput, operator *usecase.Operator) (_ *decoding.Result, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	if err := i.CanWriteScene(inp.SceneID, operator); err != nil {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	// check scene lock
+	if err := i.CheckSceneLock(ctx, inp.SceneID); err != nil {
+		return nil, err
+	}
+
+	a, data, err := i.readImportAsset(ctx, inp.SceneID, inp.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToLower(path.Ext(a.Name())) != ".zip" {
+		return nil, ErrUnsupportedImportFile
+	}
+
+	zr, err := shp.ReadZipFrom(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	res, err := decoding.NewShapeDecoder(zr, inp.SceneID).Decode(importTitle(inp.Title, "", a))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.saveImportResult(ctx, res, inp); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	i.publishImportResult(ctx, res, operator)
+	return res, nil
+}
+
+// readImportAsset loads the file of an asset that belongs to the workspace of the scene.
+func (i *NLSLayer) readImportAsset(ctx context.Context, sid id.SceneID, aid id.AssetID) (*asset.Asset, []byte, error) {
+	s, err := i.sceneRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a, err := i.assetRepo.FindByID(ctx, aid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a.Workspace() != s.Workspace() {
+		return nil, nil, interfaces.ErrOperationDenied
+	}
+
+	u, err := url.Parse(a.URL())
+	if err != nil {
+		return nil, nil, gateway.ErrInvalidFile
+	}
+
+	r, err := i.file.ReadAsset(ctx, path.Base(u.Path))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(r, gateway.UploadFileSizeLimit+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if n > gateway.UploadFileSizeLimit {
+		return nil, nil, gateway.ErrFileTooLarge
+	}
+
+	return a, buf.Bytes(), nil
+}
+
+func (i *NLSLayer) saveImportResult(ctx context.Context, res *decoding.Result, inp interfaces.ImportNLSLayerInput) error {
+	if inp.Index != nil {
+		res.Root.SetIndex(inp.Index)
+	}
+
+	if len(res.Styles) > 0 {
+		if err := i.styleRepo.SaveAll(ctx, res.Styles); err != nil {
+			return err
+		}
+	}
+
+	if err := i.nlslayerRepo.SaveAll(ctx, res.Layers); err != nil {
+		return err
+	}
+
+	return updateProjectUpdatedAtByScene(ctx, inp.SceneID, i.projectRepo, i.sceneRepo)
+}
+
+// publishImportResult tells the editors of the scene about every layer that an import created.
+func (i *NLSLayer) publishImportResult(ctx context.Context, res *decoding.Result, operator *usecase.Operator) {
+	for _, l := range res.Layers {
+		i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventLayerAdded, Scene: (*l).Scene(), Layer: lo.ToPtr((*l).ID())})
+	}
+}
+
+// importTitle returns the requested title, the title found in the file or the asset file name in this order.
+func importTitle(title *string, fileTitle string, a *asset.Asset) string {
+	if title != nil && *title != "" {
+		return *title
+	}
+	if fileTitle != "" {
+		return fileTitle
+	}
+	return strings.TrimSuffix(a.Name(), path.Ext(a.Name()))
+}
@@ -0,0 +1,160 @@
+package interactor
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/infrastructure/pubsub"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+const importTestKML = `<kml>
+  <Document>
+    <Style id="s"><LineStyle><color>ff00ff00</color></LineStyle></Style>
+    <Placemark>
+      <name>road</name>
+      <styleUrl>#s</styleUrl>
+      <LineString><coordinates>0,0 1,1</coordinates></LineString>
+    </Placemark>
+  </Document>
+</kml>`
+
+func TestNLSLayer_ImportKML(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := memory.New()
+
+	ws := accountsID.NewWorkspaceID()
+	prj, _ := project.New().NewID().Workspace(ws).Build()
+	_ = db.Project.Save(ctx, prj)
+	s, _ := scene.New().NewID().Workspace(ws).Project(prj.ID()).Build()
+	_ = db.Scene.Save(ctx, s)
+
+	mfs := afero.NewMemMapFs()
+	_ = afero.WriteFile(mfs, "assets/roads.kml", []byte(importTestKML), 0666)
+	a := asset.New().NewID().Workspace(ws).Name("roads.kml").Size(int64(len(importTestKML))).URL("https://example.com/assets/roads.kml").MustBuild()
+	_ = db.Asset.Save(ctx, a)
+	other := asset.New().NewID().Workspace(accountsID.NewWorkspaceID()).Name("roads.kml").Size(1).URL("https://example.com/assets/roads.kml").MustBuild()
+	_ = db.Asset.Save(ctx, other)
+
+	ps := pubsub.NewMemory()
+	events, _ := ps.Subscribe(ctx, s.ID())
+	il := NewNLSLayer(db, &gateway.Container{
+		File:   lo.Must(fs.NewFile(mfs, "https://example.com")),
+		PubSub: ps,
+	})
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	t.Run("denies an operator with no write access to the scene", func(t *testing.T) {
+		_, err := il.ImportKML(ctx, interfaces.ImportNLSLayerInput{
+			SceneID: s.ID(),
+			AssetID: a.ID(),
+		}, &usecase.Operator{})
+		assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	})
+
+	t.Run("denies an asset of another workspace", func(t *testing.T) {
+		_, err := il.ImportKML(ctx, interfaces.ImportNLSLayerInput{
+			SceneID: s.ID(),
+			AssetID: other.ID(),
+		}, op)
+		assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	})
+
+	t.Run("imports placemarks and styles", func(t *testing.T) {
+		res, err := il.ImportKML(ctx, interfaces.ImportNLSLayerInput{
+			SceneID: s.ID(),
+			AssetID: a.ID(),
+			Index:   lo.ToPtr(2),
+		}, op)
+		assert.NoError(t, err)
+
+		l, err := db.NLSLayer.FindByID(ctx, res.Root.ID())
+		assert.NoError(t, err)
+		assert.Equal(t, "roads", l.Title())
+		assert.Equal(t, lo.ToPtr(2), l.Index())
+		assert.True(t, l.IsSketch())
+		assert.Len(t, l.Sketch().FeatureCollection().Features(), 1)
+		assert.Equal(t, nlslayer.NewLineString("LineString", [][]float64{{0, 0}, {1, 1}}), l.Sketch().FeatureCollection().Features()[0].Geometry())
+
+		styles, err := db.Style.FindByScene(ctx, s.ID())
+		assert.NoError(t, err)
+		assert.Len(t, *styles, 1)
+		assert.Equal(t, (*styles)[0].ID().String(), (*l.Config())["layerStyleId"])
+
+		for _, l := range res.Layers {
+			select {
+			case e := <-events:
+				assert.Equal(t, scene.EventLayerAdded, e.Type)
+				assert.Equal(t, (*l).ID().Ref(), e.Layer)
+			case <-time.After(time.Second):
+				assert.Fail(t, "no event is received")
+			}
+		}
+	})
+}
+
+func TestNLSLayer_ImportShapefile(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	ws := accountsID.NewWorkspaceID()
+	prj, _ := project.New().NewID().Workspace(ws).Build()
+	_ = db.Project.Save(ctx, prj)
+	s, _ := scene.New().NewID().Workspace(ws).Project(prj.ID()).Build()
+	_ = db.Scene.Save(ctx, s)
+
+	data, err := os.ReadFile("../../../pkg/shp/test_files/ne_110m_admin_0_countries.zip")
+	assert.NoError(t, err)
+	mfs := afero.NewMemMapFs()
+	_ = afero.WriteFile(mfs, "assets/countries.zip", data, 0666)
+	_ = afero.WriteFile(mfs, "assets/roads.kml", []byte(importTestKML), 0666)
+	a := asset.New().NewID().Workspace(ws).Name("countries.zip").Size(int64(len(data))).URL("https://example.com/assets/countries.zip").MustBuild()
+	_ = db.Asset.Save(ctx, a)
+	k := asset.New().NewID().Workspace(ws).Name("roads.kml").Size(int64(len(importTestKML))).URL("https://example.com/assets/roads.kml").MustBuild()
+	_ = db.Asset.Save(ctx, k)
+
+	il := NewNLSLayer(db, &gateway.Container{
+		File: lo.Must(fs.NewFile(mfs, "https://example.com")),
+	})
+	op := &usecase.Operator{
+		WritableScenes: []id.SceneID{s.ID()},
+	}
+
+	_, err = il.ImportShapefile(ctx, interfaces.ImportNLSLayerInput{
+		SceneID: s.ID(),
+		AssetID: k.ID(),
+	}, op)
+	assert.ErrorIs(t, err, ErrUnsupportedImportFile)
+
+	res, err := il.ImportShapefile(ctx, interfaces.ImportNLSLayerInput{
+		SceneID: s.ID(),
+		AssetID: a.ID(),
+	}, op)
+	assert.NoError(t, err)
+
+	l, err := db.NLSLayer.FindByID(ctx, res.Root.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "countries", l.Title())
+	assert.True(t, l.IsSketch())
+	assert.Len(t, l.Sketch().FeatureCollection().Features(), 177)
+	assert.Equal(t, "Text_", (*l.Sketch().CustomPropertySchema())["NAME"].(string)[:5])
+	assert.NotEmpty(t, (*l.Sketch().FeatureCollection().Features()[0].Properties())["NAME"])
+}
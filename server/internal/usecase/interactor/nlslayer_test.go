@@ -128,6 +128,59 @@ func TestAddGeoJSONFeature(t *testing.T) {
 	assert.Equal(t, []float64{1.0, 2.0}, pointGeometry.Coordinates())
 }
 
+func TestAddGeoJSONFeature_GeometryValidation(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	prj, _ := project.New().NewID().Build()
+	_ = db.Project.Save(ctx, prj)
+	scene, _ := scene.New().NewID().Workspace(accountsID.NewWorkspaceID()).Project(prj.ID()).Build()
+	_ = db.Scene.Save(ctx, scene)
+	il := NewNLSLayer(db, &gateway.Container{
+		File: lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com")),
+	})
+
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(scene.ID()).Build()
+	_ = db.NLSLayer.Save(ctx, l)
+	operator := &usecase.Operator{
+		WritableScenes: []id.SceneID{scene.ID()},
+	}
+
+	// an unclosed and clockwise ring
+	geometry := map[string]any{
+		"type": "Polygon",
+		"coordinates": []interface{}{
+			[]interface{}{
+				[]interface{}{0.0, 0.0},
+				[]interface{}{0.0, 1.0},
+				[]interface{}{1.0, 1.0},
+			},
+		},
+	}
+
+	_, err := il.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{
+		LayerID:  l.ID(),
+		Type:     "Feature",
+		Geometry: geometry,
+	}, operator)
+	assert.ErrorContains(t, err, "not closed")
+
+	res, err := db.NLSLayer.FindByID(ctx, l.ID())
+	assert.NoError(t, err)
+	assert.Nil(t, res.Sketch())
+
+	feature, err := il.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{
+		LayerID:        l.ID(),
+		Type:           "Feature",
+		Geometry:       geometry,
+		RepairGeometry: true,
+	}, operator)
+	assert.NoError(t, err)
+	assert.Equal(t, nlslayer.NewPolygon("Polygon", [][][]float64{
+		{{0, 0}, {1, 1}, {0, 1}, {0, 0}},
+	}), feature.Geometry())
+}
+
 func TestUpdateGeoJSONFeature(t *testing.T) {
 	ctx := context.Background()
 
@@ -207,6 +260,99 @@ func TestUpdateGeoJSONFeature(t *testing.T) {
 	assert.Equal(t, [][]float64{{1.0, 2.0}, {3.0, 4.0}}, lineStringGeometry.Coordinates())
 }
 
+func TestNLSLayer_CustomPropertySchema(t *testing.T) {
+	ctx := context.Background()
+
+	db := memory.New()
+	prj, _ := project.New().NewID().Build()
+	_ = db.Project.Save(ctx, prj)
+	scene, _ := scene.New().NewID().Workspace(accountsID.NewWorkspaceID()).Project(prj.ID()).Build()
+	_ = db.Scene.Save(ctx, scene)
+	il := NewNLSLayer(db, &gateway.Container{
+		File: lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com")),
+	})
+	operator := &usecase.Operator{
+		WritableScenes: []id.SceneID{scene.ID()},
+	}
+
+	l, _ := nlslayer.NewNLSLayerSimple().NewID().Scene(scene.ID()).Build()
+	_ = db.NLSLayer.Save(ctx, l)
+
+	_, err := il.AddOrUpdateCustomProperties(ctx, interfaces.AddOrUpdateCustomPropertiesInput{
+		LayerID: l.ID(),
+		Schema:  map[string]any{"kind": map[string]any{"type": "enum"}},
+	}, operator)
+	assert.ErrorContains(t, err, nlslayer.ErrInvalidCustomPropertySchema.Error())
+
+	schema := map[string]any{
+		"name":  map[string]any{"type": "string", "required": true},
+		"kind":  map[string]any{"type": "enum", "options": []any{"a", "b"}, "default": "a"},
+		"count": "Int_1",
+	}
+	_, err = il.AddOrUpdateCustomProperties(ctx, interfaces.AddOrUpdateCustomPropertiesInput{
+		LayerID: l.ID(),
+		Schema:  schema,
+	}, operator)
+	assert.NoError(t, err)
+
+	geometry := map[string]any{"type": "Point", "coordinates": []any{1.0, 2.0}}
+	_, err = il.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{
+		LayerID:    l.ID(),
+		Type:       "Feature",
+		Geometry:   geometry,
+		Properties: &map[string]any{"count": 1},
+	}, operator)
+	assert.ErrorContains(t, err, nlslayer.ErrMissingCustomProperty.Error())
+
+	feature, err := il.AddGeoJSONFeature(ctx, interfaces.AddNLSLayerGeoJSONFeatureParams{
+		LayerID:    l.ID(),
+		Type:       "Feature",
+		Geometry:   geometry,
+		Properties: &map[string]any{"name": "a", "count": 1},
+	}, operator)
+	assert.NoError(t, err)
+	assert.Equal(t, &map[string]any{"name": "a", "kind": "a", "count": 1}, feature.Properties())
+
+	_, err = il.UpdateGeoJSONFeature(ctx, interfaces.UpdateNLSLayerGeoJSONFeatureParams{
+		LayerID:    l.ID(),
+		FeatureID:  feature.ID(),
+		Properties: &map[string]any{"name": "a", "count": "1"},
+	}, operator)
+	assert.ErrorContains(t, err, nlslayer.ErrInvalidCustomPropertyValue.Error())
+
+	// renaming migrates the properties of the features
+	schema["title"] = schema["name"]
+	delete(schema, "name")
+	_, err = il.ChangeCustomPropertyTitle(ctx, interfaces.AddOrUpdateCustomPropertiesInput{
+		LayerID: l.ID(),
+		Schema:  schema,
+	}, "name", "count", operator)
+	assert.ErrorContains(t, err, nlslayer.ErrCustomPropertyAlreadyExists.Error())
+
+	_, err = il.ChangeCustomPropertyTitle(ctx, interfaces.AddOrUpdateCustomPropertiesInput{
+		LayerID: l.ID(),
+		Schema:  schema,
+	}, "name", "title", operator)
+	assert.NoError(t, err)
+
+	delete(schema, "count")
+	_, err = il.RemoveCustomProperty(ctx, interfaces.AddOrUpdateCustomPropertiesInput{
+		LayerID: l.ID(),
+		Schema:  schema,
+	}, "count", operator)
+	assert.NoError(t, err)
+
+	res, err := db.NLSLayer.FindByID(ctx, l.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, &schema, res.Sketch().CustomPropertySchema())
+	assert.Equal(t, &map[string]any{"title": "a", "kind": "a"}, res.Sketch().FeatureCollection().Features()[0].Properties())
+
+	// the migrations are recorded as revisions
+	_, pi, err := il.FetchFeatureRevisions(ctx, interfaces.FetchNLSLayerFeatureRevisionsParams{LayerID: l.ID()}, operator)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pi.TotalCount)
+}
+
 func TestAddLayerSimple(t *testing.T) {
 	ctx := context.Background()
 
@@ -18,6 +18,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/plugin/repourl"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 )
 
@@ -292,9 +293,20 @@ func (i *Plugin) migrateScenePlugin(ctx context.Context, oldm manifest.Manifest,
 	if err != nil {
 		return err
 	}
-	for _, p := range pl {
-		if e := updatedPropertySchemas.FindByFrom(p.Schema()); e != nil && e.Migrate(p) {
-			updatedProperties = append(updatedProperties, p)
+	for _, pp := range pl {
+		e := updatedPropertySchemas.FindByFrom(pp.Schema())
+		if e == nil {
+			continue
+		}
+		if ns := p.Manifest.PropertySchema(e.To); ns != nil {
+			np, dropped := property.NewMigrator(*e, ns).Migrate(pp)
+			for _, d := range dropped {
+				sg, _, f := d.Pointer.GetAll()
+				log.Warnfc(ctx, "plugin %s: migration dropped field %s.%s of property %s: %s", diff.To, sg, f, pp.ID(), d.Reason)
+			}
+			updatedProperties = append(updatedProperties, np)
+		} else if e.Migrate(pp) {
+			updatedProperties = append(updatedProperties, pp)
 		}
 	}
 	if len(updatedProperties) > 0 {
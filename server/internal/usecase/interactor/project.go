@@ -31,11 +31,14 @@ import (
 	"github.com/reearth/reearth/server/pkg/alias"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/builder"
 	"github.com/reearth/reearth/server/pkg/visualizer"
+	"github.com/reearth/reearth/server/pkg/webhook"
 
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 	"github.com/spf13/afero"
 )
 
@@ -46,6 +49,7 @@ type Project struct {
 	userRepo            accountsUser.Repo
 	workspaceRepo       accountsWorkspace.Repo
 	assetRepo           repo.Asset
+	assetReferenceRepo  repo.AssetReference
 	projectRepo         repo.Project
 	projectMetadataRepo repo.ProjectMetadata
 	storytellingRepo    repo.Storytelling
@@ -55,8 +59,13 @@ type Project struct {
 	nlsLayerRepo        repo.NLSLayer
 	layerStyles         repo.Style
 	pluginRepo          repo.Plugin
+	publicationVersion  repo.PublicationVersion
+	featureRevisionRepo repo.FeatureRevision
+	shareTokenRepo      repo.ShareToken
+	publishScheduleRepo repo.PublishSchedule
 	file                gateway.File
 	policyChecker       gateway.PolicyChecker
+	webhooks            webhookEmitter
 }
 
 func NewProject(r *repo.Container, gr *gateway.Container) interfaces.Project {
@@ -65,6 +74,7 @@ func NewProject(r *repo.Container, gr *gateway.Container) interfaces.Project {
 		userRepo:            r.User,
 		workspaceRepo:       r.Workspace,
 		assetRepo:           r.Asset,
+		assetReferenceRepo:  r.AssetReference,
 		projectRepo:         r.Project,
 		projectMetadataRepo: r.ProjectMetadata,
 		storytellingRepo:    r.Storytelling,
@@ -75,8 +85,13 @@ func NewProject(r *repo.Container, gr *gateway.Container) interfaces.Project {
 		layerStyles:         r.Style,
 		pluginRepo:          r.Plugin,
 		propertySchemaRepo:  r.PropertySchema,
+		publicationVersion:  r.PublicationVersion,
+		featureRevisionRepo: r.FeatureRevision,
+		shareTokenRepo:      r.ShareToken,
+		publishScheduleRepo: r.PublishSchedule,
 		file:                gr.File,
 		policyChecker:       gr.PolicyChecker,
+		webhooks:            newWebhookEmitter(r),
 	}
 }
 
@@ -183,6 +198,10 @@ func (i *Project) FindStarredByWorkspace(ctx context.Context, id accountsID.Work
 	return i.projectRepo.FindStarredByWorkspace(ctx, id, p)
 }
 
+func (i *Project) FindTemplatesByWorkspace(ctx context.Context, id accountsID.WorkspaceID, p *usecasex.Pagination, operator *usecase.Operator) ([]*project.Project, *usecasex.PageInfo, error) {
+	return i.projectRepo.FindTemplatesByWorkspace(ctx, id, p)
+}
+
 func (i *Project) FindDeletedByWorkspace(ctx context.Context, id accountsID.WorkspaceID, p *usecasex.Pagination, operator *usecase.Operator) ([]*project.Project, *usecasex.PageInfo, error) {
 	return i.projectRepo.FindDeletedByWorkspace(ctx, id, p)
 }
@@ -437,25 +456,9 @@ func (i *Project) getMetadata(ctx context.Context, pList []*project.Project) ([]
 }
 
 func (i *Project) Create(ctx context.Context, input interfaces.CreateProjectParam, operator *usecase.Operator) (_ *project.Project, err error) {
-	visibility := project.VisibilityPublic
-
-	if i.policyChecker != nil {
-		operationAllowed, err := i.policyChecker.CheckPolicy(ctx, gateway.CreateGeneralOperationAllowedCheckRequest(input.WorkspaceID))
-		if err != nil {
-			return nil, err
-		}
-		if !operationAllowed.Allowed {
-			return nil, visualizer.ErrorWithCallerLogging(ctx, "operation is disabled by overused seat", errors.New("operation is disabled by overused seat"))
-		}
-
-		errPrivate := i.checkGeneralPolicy(ctx, input.WorkspaceID, project.VisibilityPrivate)
-		if errPrivate != nil {
-			visibility = project.VisibilityPublic
-		} else {
-			if input.Visibility != nil {
-				visibility = project.Visibility(*input.Visibility)
-			}
-		}
+	visibility, err := i.creationVisibility(ctx, input.WorkspaceID, input.Visibility)
+	if err != nil {
+		return nil, err
 	}
 
 	return i.createProject(ctx, createProjectInput{
@@ -474,6 +477,181 @@ func (i *Project) Create(ctx context.Context, input interfaces.CreateProjectPara
 	}, operator)
 }
 
+func (i *Project) CreateFromTemplate(ctx context.Context, param interfaces.CreateProjectFromTemplateParam, operator *usecase.Operator) (_ *project.Project, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	tmpl, err := i.projectRepo.FindByID(ctx, param.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(tmpl.Workspace(), operator); err != nil {
+		return nil, err
+	}
+	if !tmpl.IsTemplate() || tmpl.IsDeleted() {
+		return nil, interfaces.ErrProjectIsNotTemplate
+	}
+
+	visibility, err := i.creationVisibility(ctx, tmpl.Workspace(), lo.EmptyableToPtr(tmpl.Visibility()))
+	if err != nil {
+		return nil, err
+	}
+
+	name, description := tmpl.Name(), tmpl.Description()
+	if param.Name != nil {
+		name = *param.Name
+	}
+	if param.Description != nil {
+		description = *param.Description
+	}
+
+	prj, err := i.copyProject(ctx, tmpl, createProjectInput{
+		WorkspaceID: tmpl.Workspace(),
+		Visualizer:  tmpl.Visualizer(),
+		Name:        &name,
+		Description: &description,
+		CoreSupport: lo.ToPtr(tmpl.CoreSupport()),
+		Visibility:  &visibility,
+	}, false, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return prj, nil
+}
+
+func (i *Project) Duplicate(ctx context.Context, param interfaces.DuplicateProjectParam, operator *usecase.Operator) (_ *project.Project, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	src, err := i.projectRepo.FindByID(ctx, param.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadWorkspace(src.Workspace(), operator); err != nil {
+		return nil, err
+	}
+	if src.IsDeleted() {
+		return nil, interfaces.ErrProjectIsDeleted
+	}
+
+	wid := src.Workspace()
+	if param.WorkspaceID != nil {
+		wid = *param.WorkspaceID
+	}
+	if err := i.CanWriteWorkspace(wid, operator); err != nil {
+		return nil, err
+	}
+	// a project in another workspace must not reference assets that members of that workspace cannot manage
+	if param.ShareAssets && wid != src.Workspace() {
+		return nil, interfaces.ErrCannotShareAssets
+	}
+
+	visibility, err := i.creationVisibility(ctx, wid, lo.EmptyableToPtr(src.Visibility()))
+	if err != nil {
+		return nil, err
+	}
+
+	name := src.Name()
+	if param.Name != nil {
+		name = *param.Name
+	}
+
+	prj, err := i.copyProject(ctx, src, createProjectInput{
+		WorkspaceID: wid,
+		Visualizer:  src.Visualizer(),
+		Name:        &name,
+		Description: lo.ToPtr(src.Description()),
+		Archived:    lo.ToPtr(src.IsArchived()),
+		CoreSupport: lo.ToPtr(src.CoreSupport()),
+		Starred:     lo.ToPtr(src.Starred()),
+		Visibility:  &visibility,
+	}, param.ShareAssets, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return prj, nil
+}
+
+// copyProject creates a project from input with the metadata of src and deep copies the scene of src into it.
+// It runs in the transaction of ctx. When shareAssets is true the copy keeps using the assets of src instead of copies of them.
+func (i *Project) copyProject(ctx context.Context, src *project.Project, input createProjectInput, shareAssets bool, operator *usecase.Operator) (*project.Project, error) {
+	if metadata, err := i.projectMetadataRepo.FindByProjectID(ctx, src.ID()); err == nil && metadata != nil {
+		input.Readme = metadata.Readme()
+		input.License = metadata.License()
+		input.Topics = metadata.Topics()
+	}
+
+	prj, err := i.saveNewProject(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	copier := i.newProjectCopier()
+	copier.shareAssets = shareAssets
+	sce, err := copier.Copy(ctx, src, prj)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := updateProjectUpdatedAt(ctx, prj, i.projectRepo); err != nil {
+		return nil, err
+	}
+
+	i.webhooks.emitProject(ctx, webhook.EventProjectCreate, prj)
+
+	operator.AddNewScene(prj.Workspace(), sce.ID())
+	return prj, nil
+}
+
+// creationVisibility checks that the workspace may create a project and returns the visibility the project gets.
+// Private projects are only allowed when the policy of the workspace permits them.
+func (i *Project) creationVisibility(ctx context.Context, wid accountsID.WorkspaceID, requested *string) (project.Visibility, error) {
+	visibility := project.VisibilityPublic
+
+	if i.policyChecker != nil {
+		operationAllowed, err := i.policyChecker.CheckPolicy(ctx, gateway.CreateGeneralOperationAllowedCheckRequest(wid))
+		if err != nil {
+			return "", err
+		}
+		if !operationAllowed.Allowed {
+			return "", visualizer.ErrorWithCallerLogging(ctx, "operation is disabled by overused seat", errors.New("operation is disabled by overused seat"))
+		}
+
+		errPrivate := i.checkGeneralPolicy(ctx, wid, project.VisibilityPrivate)
+		if errPrivate != nil {
+			visibility = project.VisibilityPublic
+		} else {
+			if requested != nil {
+				visibility = project.Visibility(*requested)
+			}
+		}
+	}
+
+	return visibility, nil
+}
+
 func (i *Project) Update(ctx context.Context, p interfaces.UpdateProjectParam, operator *usecase.Operator) (_ *project.Project, err error) {
 	log.Debugfc(ctx, "Update project: %v", p)
 	tx, err := i.transaction.Begin(ctx)
@@ -533,14 +711,23 @@ func (i *Project) Update(ctx context.Context, p interfaces.UpdateProjectParam, o
 		prj.SetBasicAuthUsername(*p.BasicAuthUsername)
 	}
 
-	if p.BasicAuthPassword != nil {
-		prj.SetBasicAuthPassword(*p.BasicAuthPassword)
+	// the stored password is never sent to clients, so an empty password keeps the current one
+	if p.BasicAuthPassword != nil && *p.BasicAuthPassword != "" {
+		password, err := publication.HashBasicAuthPassword(*p.BasicAuthPassword)
+		if err != nil {
+			return nil, err
+		}
+		prj.SetBasicAuthPassword(password)
 	}
 
 	if p.Starred != nil {
 		prj.SetStarred(*p.Starred)
 	}
 
+	if p.Template != nil {
+		prj.SetTemplate(*p.Template)
+	}
+
 	if p.Deleted != nil {
 		prj.SetDeleted(*p.Deleted)
 	}
@@ -629,6 +816,8 @@ func (i *Project) Update(ctx context.Context, p interfaces.UpdateProjectParam, o
 		return nil, err
 	}
 
+	i.webhooks.emitProject(ctx, webhook.EventProjectUpdate, prj)
+
 	tx.Commit()
 	return prj, nil
 }
@@ -670,6 +859,8 @@ func (i *Project) UpdateVisibility(ctx context.Context, pid id.ProjectID, visibi
 	if err := i.projectRepo.Save(ctx, prj); err != nil {
 		return nil, err
 	}
+
+	i.webhooks.emitProject(ctx, webhook.EventProjectUpdate, prj)
 	return prj, nil
 
 }
@@ -691,6 +882,8 @@ func (i *Project) UpdateImportStatus(ctx context.Context, pid id.ProjectID, impo
 	if err := i.projectMetadataRepo.Save(ctx, meta); err != nil {
 		return nil, err
 	}
+
+	i.webhooks.emitImport(ctx, pid, meta)
 	return meta, nil
 
 }
@@ -837,6 +1030,11 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 		return nil, err
 	}
 
+	return i.publish(ctx, prj, dedicatedID1, dedicatedID2, params, op)
+}
+
+// publish publishes the project without checking the operator, so it is also used by publish schedules.
+func (i *Project) publish(ctx context.Context, prj *project.Project, dedicatedID1, dedicatedID2 string, params interfaces.PublishProjectParam, op *usecase.Operator) (*project.Project, error) {
 	operationAllowed, err := i.policyChecker.CheckPolicy(ctx, gateway.CreateGeneralOperationAllowedCheckRequest(prj.Workspace()))
 	if err != nil {
 		return nil, err
@@ -900,21 +1098,37 @@ func (i *Project) Publish(ctx context.Context, params interfaces.PublishProjectP
 	// Phase 2: GCS upload outside the transaction. Previously this ran inside
 	// the transaction and held document locks for the entire upload duration,
 	// causing MongoDB WriteConflict when concurrent publish calls overlapped.
+	var version *publication.Version
 	if prj.PublishmentStatus() != project.PublishmentStatusPrivate {
 		if err := i.uploadPublishScene(ctx, prj, sc, op); err != nil {
 			return nil, err
 		}
 		prj.SetPublishedAt(time.Now())
+
+		if version, err = i.snapshotPublishScene(ctx, prj, sc, params.Message, op); err != nil {
+			return nil, err
+		}
+		prj.SetPublishedVersion(version.Version())
 	}
 
-	// Phase 3: short transaction containing only the two DB saves, with retry
+	// Phase 3: short transaction containing only the DB saves, with retry
 	// on TransientTransactionError. Each attempt gets a fresh session.
 	sc.UpdateAlias(prj.Alias())
 	if err := runWithTxRetry(ctx, i.transaction, 3, func(txCtx context.Context) error {
+		if version != nil {
+			if err := i.publicationVersion.Save(txCtx, version); err != nil {
+				return err
+			}
+		}
 		if err := i.projectRepo.Save(txCtx, prj); err != nil {
 			return err
 		}
-		return i.sceneRepo.Save(txCtx, sc)
+		if err := i.sceneRepo.Save(txCtx, sc); err != nil {
+			return err
+		}
+		// unpublishing is also delivered as project.publish, with the private status
+		i.webhooks.emitProject(txCtx, webhook.EventProjectPublish, prj)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
@@ -984,6 +1198,25 @@ func (i *Project) uploadPublishScene(ctx context.Context, p *project.Project, s
 	return nil
 }
 
+// snapshotPublishScene copies the built scene just uploaded to the snapshot of a new publication version.
+func (i *Project) snapshotPublishScene(ctx context.Context, p *project.Project, s *scene.Scene, message string, op *usecase.Operator) (*publication.Version, error) {
+	v, err := newPublicationVersion(ctx, i.publicationVersion, s.ID(), p.ID().Ref(), nil, p.Alias(), message, op)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := i.file.ReadBuiltSceneFile(ctx, p.Alias())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+
+	if err := i.file.UploadPublicationSnapshot(ctx, r, v.SnapshotName()); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
 func (i *Project) Delete(ctx context.Context, projectID id.ProjectID, operator *usecase.Operator) (err error) {
 	log.Warnf("Deleting a project %s", projectID.String())
 
@@ -1015,7 +1248,18 @@ func (i *Project) Delete(ctx context.Context, projectID id.ProjectID, operator *
 		return visualizer.ErrorWithCallerLogging(ctx, "operation is disabled by over used seat", errors.New("operation is disabled by over used seat"))
 	}
 
-	deleter := ProjectDeleter{
+	if err := i.projectDeleter().Delete(ctx, prj, true, operator); err != nil {
+		return err
+	}
+
+	i.webhooks.emitProject(ctx, webhook.EventProjectDelete, prj)
+
+	tx.Commit()
+	return nil
+}
+
+func (i *Project) projectDeleter() ProjectDeleter {
+	return ProjectDeleter{
 		SceneDeleter: SceneDeleter{
 			Scene:          i.sceneRepo,
 			SceneLock:      i.sceneLockRepo,
@@ -1026,18 +1270,18 @@ func (i *Project) Delete(ctx context.Context, projectID id.ProjectID, operator *
 			Style:          i.layerStyles,
 			PropertySchema: i.propertySchemaRepo,
 			File:           i.file,
+
+			FeatureRevision:    i.featureRevisionRepo,
+			PublicationVersion: i.publicationVersion,
+			ShareToken:         i.shareTokenRepo,
+			PublishSchedule:    i.publishScheduleRepo,
 		},
 		File:            i.file,
 		Project:         i.projectRepo,
 		ProjectMetadata: i.projectMetadataRepo,
 		Asset:           i.assetRepo,
+		AssetReference:  i.assetReferenceRepo,
 	}
-	if err := deleter.Delete(ctx, prj, true, operator); err != nil {
-		return err
-	}
-
-	tx.Commit()
-	return nil
 }
 
 // CheckProjectExportAccess validates that the operator is allowed to export the given project.
@@ -1382,6 +1626,7 @@ type createProjectInput struct {
 	IsDeleted       *bool
 	Archived        *bool
 	CoreSupport     *bool
+	Starred         *bool
 	Visibility      *project.Visibility
 	ProjectAlias    *string
 
@@ -1412,7 +1657,22 @@ func (i *Project) createProject(ctx context.Context, input createProjectInput, o
 		}
 	}()
 
+	proj, err := i.saveNewProject(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	i.webhooks.emitProject(ctx, webhook.EventProjectCreate, proj)
+
+	tx.Commit()
+
+	return proj, nil
+}
+
+// saveNewProject builds and saves a project and its metadata in the transaction of ctx.
+func (i *Project) saveNewProject(ctx context.Context, input createProjectInput) (*project.Project, error) {
 	var prjID idx.ID[id.Project]
+	var err error
 	if input.ProjectID != nil {
 		prjID, err = id.ProjectIDFrom(*input.ProjectID)
 		if err != nil {
@@ -1502,6 +1762,10 @@ func (i *Project) createProject(ctx context.Context, input createProjectInput, o
 		prj = prj.Visibility(*input.Visibility)
 	}
 
+	if input.Starred != nil {
+		prj = prj.Starred(*input.Starred)
+	}
+
 	if input.IsDeleted != nil {
 		prj = prj.Deleted(*input.IsDeleted)
 	} else {
@@ -1518,8 +1782,6 @@ func (i *Project) createProject(ctx context.Context, input createProjectInput, o
 		return nil, err
 	}
 
-	tx.Commit()
-
 	return proj, nil
 }
 
@@ -0,0 +1,768 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/alias"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+)
+
+const assetBatchSize = 100
+
+// projectCopier deep copies the scene of a project, with its widgets, plugins, properties, layers, styles,
+// stories and assets, into another project without going through an export zip.
+// Every copied entity gets a new ID and the references between them are remapped to the new IDs.
+type projectCopier struct {
+	assetRepo          repo.Asset
+	sceneRepo          repo.Scene
+	propertyRepo       repo.Property
+	propertySchemaRepo repo.PropertySchema
+	nlsLayerRepo       repo.NLSLayer
+	styleRepo          repo.Style
+	pluginRepo         repo.Plugin
+	storytellingRepo   repo.Storytelling
+	file               gateway.File
+
+	// shareAssets makes the copy refer to the assets of the source project instead of copies of them
+	shareAssets bool
+
+	sceneID    id.SceneID
+	plugins    map[id.PluginID]id.PluginID
+	properties map[id.PropertyID]id.PropertyID
+	layers     map[id.NLSLayerID]id.NLSLayerID
+	styles     map[id.StyleID]id.StyleID
+	assetURLs  map[string]string
+}
+
+func (i *Project) newProjectCopier() *projectCopier {
+	return &projectCopier{
+		assetRepo:          i.assetRepo,
+		sceneRepo:          i.sceneRepo,
+		propertyRepo:       i.propertyRepo,
+		propertySchemaRepo: i.propertySchemaRepo,
+		nlsLayerRepo:       i.nlsLayerRepo,
+		styleRepo:          i.layerStyles,
+		pluginRepo:         i.pluginRepo,
+		storytellingRepo:   i.storytellingRepo,
+		file:               i.file,
+	}
+}
+
+// Copy copies the scene of src into dst, which must not have a scene yet, and returns the new scene.
+// The alias and the image of dst are updated, so the caller has to save dst afterwards.
+func (c *projectCopier) Copy(ctx context.Context, src, dst *project.Project) (*scene.Scene, error) {
+	srcScene, err := c.sceneRepo.FindByProject(ctx, src.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	c.sceneID = id.NewSceneID()
+	c.plugins = map[id.PluginID]id.PluginID{}
+	c.properties = map[id.PropertyID]id.PropertyID{}
+	c.layers = map[id.NLSLayerID]id.NLSLayerID{}
+	c.styles = map[id.StyleID]id.StyleID{}
+	c.assetURLs = map[string]string{}
+
+	// the source is read with the repos of the operator and the copy is written into the new scene
+	filter := Filter(c.sceneID)
+	propertyRepo := c.propertyRepo.Filtered(filter)
+	nlsLayerRepo := c.nlsLayerRepo.Filtered(filter)
+	styleRepo := c.styleRepo.Filtered(filter)
+	storytellingRepo := c.storytellingRepo.Filtered(filter)
+
+	layers, err := c.nlsLayerRepo.FindByScene(ctx, srcScene.ID())
+	if err != nil {
+		return nil, err
+	}
+	styles, err := c.styleRepo.FindByScene(ctx, srcScene.ID())
+	if err != nil {
+		return nil, err
+	}
+	stories, err := c.storytellingRepo.FindByScene(ctx, srcScene.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.shareAssets {
+		if err := c.copyAssets(ctx, src, dst); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.copyPlugins(ctx, srcScene); err != nil {
+		return nil, err
+	}
+
+	propertyIDs := srcScene.Properties()
+	for _, l := range layers {
+		propertyIDs = append(propertyIDs, layerProperties(*l)...)
+	}
+	if stories != nil {
+		for _, s := range *stories {
+			propertyIDs = append(propertyIDs, s.Properties()...)
+		}
+	}
+	if err := c.copyProperties(ctx, propertyRepo, propertyIDs); err != nil {
+		return nil, err
+	}
+
+	for _, l := range layers {
+		c.layers[(*l).ID()] = id.NewNLSLayerID()
+	}
+	// layers refer to their styles by "layerStyleId" in the config
+	if styles != nil {
+		for _, s := range *styles {
+			c.styles[s.ID()] = id.NewStyleID()
+		}
+	}
+	newLayers := make(nlslayer.NLSLayerList, 0, len(layers))
+	for _, l := range layers {
+		nl, err := c.copyLayer(*l)
+		if err != nil {
+			return nil, err
+		}
+		newLayers = append(newLayers, &nl)
+	}
+	if err := nlsLayerRepo.SaveAll(ctx, newLayers); err != nil {
+		return nil, err
+	}
+
+	if styles != nil {
+		newStyles := make(scene.StyleList, 0, len(*styles))
+		for _, s := range *styles {
+			ns, err := scene.NewStyle().
+				ID(c.styles[s.ID()]).
+				Name(s.Name()).
+				Value(c.copyStyleValue(s.Value())).
+				Scene(c.sceneID).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			newStyles = append(newStyles, ns)
+		}
+		if err := styleRepo.SaveAll(ctx, newStyles); err != nil {
+			return nil, err
+		}
+	}
+
+	if stories != nil {
+		newStories := make(storytelling.StoryList, 0, len(*stories))
+		for _, s := range *stories {
+			ns, err := c.copyStory(s, dst.ID())
+			if err != nil {
+				return nil, err
+			}
+			newStories = append(newStories, ns)
+		}
+		if err := storytellingRepo.SaveAll(ctx, newStories); err != nil {
+			return nil, err
+		}
+	}
+
+	sce, err := c.copyScene(srcScene, dst)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.sceneRepo.Save(ctx, sce); err != nil {
+		return nil, err
+	}
+
+	dst.UpdateAlias(sce.Alias())
+	if u := src.ImageURL(); u != nil {
+		dst.SetImageURL(c.assetURL(u))
+	}
+
+	return sce, nil
+}
+
+// copyAssets uploads a copy of every asset owned by src and registers it as an asset of dst.
+// Assets whose file is no longer available are skipped.
+func (c *projectCopier) copyAssets(ctx context.Context, src, dst *project.Project) error {
+	srcID := src.ID()
+	var assets []*asset.Asset
+	if err := repo.IterateAssetsByWorkspaceProject(c.assetRepo, ctx, src.Workspace(), &srcID, assetBatchSize, func(batch []*asset.Asset) error {
+		assets = append(assets, batch...)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	dstID := dst.ID()
+	for _, a := range assets {
+		newURL, size, err := c.copyFile(ctx, a.URL(), a.Size(), a.ContentType())
+		if err != nil {
+			return err
+		}
+		if newURL == nil {
+			log.Warnfc(ctx, "project copy: skipping asset %s: the file is not available", a.ID())
+			continue
+		}
+
+		var thumbnail string
+		if a.ThumbnailURL() != "" {
+			if u, _, err := c.copyFile(ctx, a.ThumbnailURL(), 0, "image/png"); err != nil {
+				return err
+			} else if u != nil {
+				thumbnail = u.String()
+			}
+		}
+
+		na, err := asset.New().
+			NewID().
+			Workspace(dst.Workspace()).
+			Project(&dstID).
+			Name(a.Name()).
+			Size(size).
+			URL(newURL.String()).
+			ContentType(a.ContentType()).
+			CoreSupport(a.CoreSupport()).
+			Metadata(a.Metadata()).
+			ThumbnailURL(thumbnail).
+			Build()
+		if err != nil {
+			return err
+		}
+		if err := c.assetRepo.Save(ctx, na); err != nil {
+			return err
+		}
+		c.assetURLs[a.URL()] = na.URL()
+	}
+	return nil
+}
+
+// copyFile uploads a copy of an asset file and returns the URL of the copy.
+// It returns a nil URL when the file is no longer available.
+func (c *projectCopier) copyFile(ctx context.Context, rawURL string, size int64, contentType string) (*url.URL, int64, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, 0, nil
+	}
+	name := path.Base(u.Path)
+
+	r, err := c.file.ReadAsset(ctx, name)
+	if err != nil {
+		return nil, 0, nil
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	return c.file.UploadAsset(ctx, &file.File{
+		Content:     r,
+		Path:        name,
+		Size:        size,
+		ContentType: contentType,
+	})
+}
+
+// copyPlugins copies the private plugins of the scene, with their property schemas and files, into the new scene.
+// Public plugins are shared between scenes and are kept as they are.
+func (c *projectCopier) copyPlugins(ctx context.Context, srcScene *scene.Scene) error {
+	var ids []id.PluginID
+	for _, pid := range srcScene.PluginIds() {
+		if s := pid.Scene(); s != nil && *s == srcScene.ID() {
+			ids = append(ids, pid)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	filter := Filter(c.sceneID)
+	pluginRepo := c.pluginRepo.Filtered(filter)
+	propertySchemaRepo := c.propertySchemaRepo.Filtered(filter)
+
+	plugins, err := c.pluginRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range plugins {
+		if p == nil {
+			continue
+		}
+		newID := p.ID().WithScene(&c.sceneID)
+		c.plugins[p.ID()] = newID
+
+		schemas, err := c.propertySchemaRepo.FindByIDs(ctx, p.PropertySchemas())
+		if err != nil {
+			return err
+		}
+		newSchemas := make(property.SchemaList, 0, len(schemas))
+		for _, s := range schemas {
+			ns, err := property.NewSchema().
+				ID(s.ID().WithPlugin(newID)).
+				Version(s.Version()).
+				Groups(s.Groups()).
+				LinkableFields(s.LinkableFields()).
+				Build()
+			if err != nil {
+				return err
+			}
+			newSchemas = append(newSchemas, ns)
+		}
+		if err := propertySchemaRepo.SaveAll(ctx, newSchemas); err != nil {
+			return err
+		}
+
+		extensions := make([]*plugin.Extension, 0, len(p.Extensions()))
+		for _, e := range p.Extensions() {
+			ne, err := plugin.NewExtension().
+				ID(e.ID()).
+				Type(e.Type()).
+				Name(e.Name()).
+				Description(e.Description()).
+				Icon(e.Icon()).
+				Schema(e.Schema().WithPlugin(newID)).
+				Visualizer(e.Visualizer()).
+				SingleOnly(e.SingleOnly()).
+				WidgetLayout(e.WidgetLayout()).
+				Build()
+			if err != nil {
+				return err
+			}
+			extensions = append(extensions, ne)
+
+			if err := c.copyPluginFile(ctx, p.ID(), newID, fmt.Sprintf("%s.js", e.ID().String())); err != nil {
+				return err
+			}
+		}
+
+		var schema *id.PropertySchemaID
+		if s := p.Schema(); s != nil {
+			schema = s.WithPlugin(newID).Ref()
+		}
+
+		np, err := plugin.New().
+			ID(newID).
+			Name(p.Name()).
+			Author(p.Author()).
+			Description(p.Description()).
+			RepositoryURL(p.RepositoryURL()).
+			Extensions(extensions).
+			Schema(schema).
+			Build()
+		if err != nil {
+			return err
+		}
+		if err := pluginRepo.Save(ctx, np); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *projectCopier) copyPluginFile(ctx context.Context, from, to id.PluginID, name string) error {
+	r, err := c.file.ReadPluginFile(ctx, from, name)
+	if err != nil {
+		log.Warnfc(ctx, "project copy: plugin %s: skipping file %s: %v", from, name, err)
+		return nil
+	}
+	defer func() { _ = r.Close() }()
+
+	return c.file.UploadPluginFile(ctx, to, &file.File{
+		Content: r,
+		Path:    name,
+	})
+}
+
+// copyProperties copies the properties with new IDs into the new scene.
+// Asset URLs in their values are replaced with the URLs of the copied assets.
+func (c *projectCopier) copyProperties(ctx context.Context, propertyRepo repo.Property, ids id.PropertyIDList) error {
+	properties, err := c.propertyRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	newProperties := make(property.List, 0, len(properties))
+	for _, p := range properties {
+		if p == nil {
+			continue
+		}
+		if _, ok := c.properties[p.ID()]; ok {
+			continue
+		}
+
+		items := p.Clone().Items()
+		np, err := property.New().
+			NewID().
+			Scene(c.sceneID).
+			Schema(c.schemaID(p.Schema())).
+			Items(items).
+			Build()
+		if err != nil {
+			return err
+		}
+		for _, f := range np.Fields(nil) {
+			c.copyFieldValue(f)
+		}
+
+		c.properties[p.ID()] = np.ID()
+		newProperties = append(newProperties, np)
+	}
+
+	return propertyRepo.SaveAll(ctx, newProperties)
+}
+
+func (c *projectCopier) copyFieldValue(f *property.Field) {
+	if len(c.assetURLs) == 0 {
+		return
+	}
+
+	switch f.Type() {
+	case property.ValueTypeURL:
+		if u := f.Value().ValueURL(); u != nil {
+			f.UpdateUnsafe(property.ValueTypeURL.ValueFrom(c.assetURL(u)))
+		}
+	case property.ValueTypeString:
+		if s := f.Value().ValueString(); s != nil {
+			f.UpdateUnsafe(property.ValueTypeString.ValueFrom(c.replaceAssetURLs(*s)))
+		}
+	}
+}
+
+func (c *projectCopier) copyLayer(l nlslayer.NLSLayer) (nlslayer.NLSLayer, error) {
+	var infobox *nlslayer.Infobox
+	if ib := l.Infobox(); ib != nil {
+		blocks := make([]*nlslayer.InfoboxBlock, 0, len(ib.Blocks()))
+		for _, b := range ib.Blocks() {
+			prop, err := c.propertyID(b.Property())
+			if err != nil {
+				return nil, err
+			}
+			nb, err := nlslayer.NewInfoboxBlock().
+				NewID().
+				Property(prop).
+				Plugin(c.pluginID(b.Plugin())).
+				Extension(b.Extension()).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, nb)
+		}
+		prop, err := c.propertyID(ib.Property())
+		if err != nil {
+			return nil, err
+		}
+		infobox = nlslayer.NewInfobox(blocks, prop)
+	}
+
+	var photoOverlay *nlslayer.PhotoOverlay
+	if po := l.PhotoOverlay(); po != nil {
+		prop, err := c.propertyID(po.Property())
+		if err != nil {
+			return nil, err
+		}
+		photoOverlay = nlslayer.NewPhotoOverlay(prop)
+	}
+
+	var config *nlslayer.Config
+	if cfg := l.Config(); cfg != nil {
+		if nc, ok := c.copyValue(map[string]any(*cfg)).(map[string]any); ok {
+			if sid, ok := nc["layerStyleId"].(string); ok {
+				nc["layerStyleId"] = c.styleID(sid)
+			}
+			config = (*nlslayer.Config)(&nc)
+		}
+	}
+
+	var sketch *nlslayer.SketchInfo
+	if s := l.Sketch(); s != nil {
+		sketch = nlslayer.NewSketchInfo(s.CustomPropertySchema(), s.FeatureCollection().Clone())
+	}
+
+	if g := nlslayer.ToNLSLayerGroup(l); g != nil {
+		var children []id.NLSLayerID
+		if g.Children() != nil {
+			for _, lid := range g.Children().Layers() {
+				if nid, ok := c.layers[lid]; ok {
+					children = append(children, nid)
+				}
+			}
+		}
+		return nlslayer.NewNLSLayerGroup().
+			ID(c.layers[l.ID()]).
+			Index(l.Index()).
+			LayerType(l.LayerType()).
+			Scene(c.sceneID).
+			Title(l.Title()).
+			IsVisible(l.IsVisible()).
+			Infobox(infobox).
+			PhotoOverlay(photoOverlay).
+			Config(config).
+			DataSourceName(l.DataSourceName()).
+			IsSketch(l.IsSketch()).
+			Sketch(sketch).
+			Layers(nlslayer.NewIDList(children)).
+			Root(g.IsRoot()).
+			Build()
+	}
+
+	return nlslayer.NewNLSLayerSimple().
+		ID(c.layers[l.ID()]).
+		Index(l.Index()).
+		LayerType(l.LayerType()).
+		Scene(c.sceneID).
+		Title(l.Title()).
+		IsVisible(l.IsVisible()).
+		Infobox(infobox).
+		PhotoOverlay(photoOverlay).
+		Config(config).
+		DataSourceName(l.DataSourceName()).
+		IsSketch(l.IsSketch()).
+		Sketch(sketch).
+		Build()
+}
+
+func (c *projectCopier) copyStyleValue(v *scene.StyleValue) *scene.StyleValue {
+	if v == nil {
+		return nil
+	}
+	nv, ok := c.copyValue(map[string]any(*v)).(map[string]any)
+	if !ok {
+		return nil
+	}
+	return (*scene.StyleValue)(&nv)
+}
+
+func (c *projectCopier) copyStory(s *storytelling.Story, pid id.ProjectID) (*storytelling.Story, error) {
+	var pages []*storytelling.Page
+	if s.Pages() != nil {
+		for _, p := range s.Pages().Pages() {
+			blocks := make(storytelling.BlockList, 0, len(p.Blocks()))
+			for _, b := range p.Blocks() {
+				prop, err := c.propertyID(b.Property())
+				if err != nil {
+					return nil, err
+				}
+				nb, err := storytelling.NewBlock().
+					NewID().
+					Plugin(c.pluginID(b.Plugin())).
+					Extension(b.Extension()).
+					Property(prop).
+					Build()
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, nb)
+			}
+
+			prop, err := c.propertyID(p.Property())
+			if err != nil {
+				return nil, err
+			}
+			np, err := storytelling.NewPage().
+				NewID().
+				Property(prop).
+				Title(p.Title()).
+				Swipeable(p.Swipeable()).
+				Layers(c.layerIDs(p.Layers())).
+				SwipeableLayers(c.layerIDs(p.SwipeableLayers())).
+				Blocks(blocks).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			pages = append(pages, np)
+		}
+	}
+
+	prop, err := c.propertyID(s.Property())
+	if err != nil {
+		return nil, err
+	}
+	return storytelling.NewStory().
+		NewID().
+		Property(prop).
+		Project(pid).
+		Scene(c.sceneID).
+		Pages(storytelling.NewPageList(pages)).
+		PanelPosition(s.PanelPosition()).
+		BgColor(s.BgColor()).
+		Title(s.Title()).
+		Index(s.Index()).
+		Build()
+}
+
+func (c *projectCopier) copyScene(src *scene.Scene, dst *project.Project) (*scene.Scene, error) {
+	var widgets []*scene.Widget
+	var alignment *scene.WidgetAlignSystems
+	if src.Widgets() != nil {
+		for _, w := range src.Widgets().Widgets() {
+			prop, err := c.propertyID(w.Property())
+			if err != nil {
+				return nil, err
+			}
+			nw, err := scene.NewWidget(w.ID(), c.pluginID(w.Plugin()), w.Extension(), prop, w.Enabled(), w.Extended())
+			if err != nil {
+				return nil, err
+			}
+			widgets = append(widgets, nw)
+		}
+		alignment = src.Widgets().Alignment()
+	}
+
+	var plugins []*scene.Plugin
+	if src.Plugins() != nil {
+		for _, p := range src.Plugins().Plugins() {
+			var prop *id.PropertyID
+			if p.Property() != nil {
+				pid, err := c.propertyID(*p.Property())
+				if err != nil {
+					return nil, err
+				}
+				prop = &pid
+			}
+			plugins = append(plugins, scene.NewPlugin(c.pluginID(p.Plugin()), prop))
+		}
+	}
+
+	prop, err := c.propertyID(src.Property())
+	if err != nil {
+		return nil, err
+	}
+
+	return scene.New().
+		ID(c.sceneID).
+		Project(dst.ID()).
+		Workspace(dst.Workspace()).
+		Property(prop).
+		Widgets(scene.NewWidgets(widgets, alignment)).
+		Plugins(scene.NewPlugins(plugins)).
+		Alias(alias.ReservedReearthPrefixScene + c.sceneID.String()).
+		UpdatedAt(time.Now()).
+		Build()
+}
+
+func (c *projectCopier) propertyID(pid id.PropertyID) (id.PropertyID, error) {
+	if nid, ok := c.properties[pid]; ok {
+		return nid, nil
+	}
+	return id.PropertyID{}, rerror.ErrNotFound
+}
+
+func (c *projectCopier) pluginID(pid id.PluginID) id.PluginID {
+	if nid, ok := c.plugins[pid]; ok {
+		return nid
+	}
+	return pid
+}
+
+func (c *projectCopier) schemaID(sid id.PropertySchemaID) id.PropertySchemaID {
+	if nid, ok := c.plugins[sid.Plugin()]; ok {
+		return sid.WithPlugin(nid)
+	}
+	return sid
+}
+
+// styleID remaps a style ID in a layer config and keeps the ones that are not styles of the source scene.
+func (c *projectCopier) styleID(sid string) string {
+	if old, err := id.StyleIDFrom(sid); err == nil {
+		if nid, ok := c.styles[old]; ok {
+			return nid.String()
+		}
+	}
+	return sid
+}
+
+// layerIDs remaps layer IDs and drops the ones that do not exist in the source scene anymore.
+func (c *projectCopier) layerIDs(ids id.NLSLayerIDList) id.NLSLayerIDList {
+	res := make(id.NLSLayerIDList, 0, len(ids))
+	for _, lid := range ids {
+		if nid, ok := c.layers[lid]; ok {
+			res = append(res, nid)
+		}
+	}
+	return res
+}
+
+func (c *projectCopier) assetURL(u *url.URL) *url.URL {
+	if nu, ok := c.assetURLs[u.String()]; ok {
+		if u2, err := url.Parse(nu); err == nil {
+			return u2
+		}
+	}
+	return u
+}
+
+func (c *projectCopier) replaceAssetURLs(s string) string {
+	for from, to := range c.assetURLs {
+		s = strings.ReplaceAll(s, from, to)
+	}
+	return s
+}
+
+// copyValue deep copies a decoded JSON-like value and replaces asset URLs in its strings.
+func (c *projectCopier) copyValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for k, v2 := range v {
+			res[k] = c.copyValue(v2)
+		}
+		return res
+	case []any:
+		res := make([]any, len(v))
+		for i, v2 := range v {
+			res[i] = c.copyValue(v2)
+		}
+		return res
+	case string:
+		return c.replaceAssetURLs(v)
+	}
+
+	// values loaded from the db have named types such as primitive.A
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			res := make(map[string]any, rv.Len())
+			for _, k := range rv.MapKeys() {
+				res[k.String()] = c.copyValue(rv.MapIndex(k).Interface())
+			}
+			return res
+		}
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Interface {
+			res := make([]any, rv.Len())
+			for i := range res {
+				res[i] = c.copyValue(rv.Index(i).Interface())
+			}
+			return res
+		}
+	}
+	return v
+}
+
+func layerProperties(l nlslayer.NLSLayer) []id.PropertyID {
+	var ids []id.PropertyID
+	if ib := l.Infobox(); ib != nil {
+		ids = append(ids, ib.Property())
+		for _, b := range ib.Blocks() {
+			ids = append(ids, b.Property())
+		}
+	}
+	if po := l.PhotoOverlay(); po != nil {
+		ids = append(ids, po.Property())
+	}
+	return ids
+}
@@ -0,0 +1,321 @@
+package interactor
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/file"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProject_CreateFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	fileGateway := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	uc := NewProject(db, &gateway.Container{File: fileGateway})
+
+	wsID := accountsID.NewWorkspaceID()
+	tmpl := project.New().NewID().Workspace(wsID).Name("template").Description("desc").CoreSupport(true).Template(true).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, tmpl))
+	plain := project.New().NewID().Workspace(wsID).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, plain))
+
+	assetURL, size, err := fileGateway.UploadAsset(ctx, &file.File{
+		Content: io.NopCloser(strings.NewReader("image")),
+		Path:    "image.png",
+	})
+	require.NoError(t, err)
+	tmplID := tmpl.ID()
+	a := asset.New().NewID().Workspace(wsID).Project(&tmplID).Name("image.png").Size(size).URL(assetURL.String()).CoreSupport(true).MustBuild()
+	require.NoError(t, db.Asset.Save(ctx, a))
+
+	sceneID := id.NewSceneID()
+	sceneProp := property.New().NewID().Scene(sceneID).Schema(id.MustPropertySchemaID("reearth/cesium")).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("image").Value(property.ValueTypeURL.ValueFrom(assetURL).Some()).MustBuild(),
+		}).MustBuild(),
+	}).MustBuild()
+	pageProp := property.New().NewID().Scene(sceneID).Schema(id.MustPropertySchemaID("reearth/story")).MustBuild()
+	storyProp := property.New().NewID().Scene(sceneID).Schema(id.MustPropertySchemaID("reearth/story")).MustBuild()
+	require.NoError(t, db.Property.SaveAll(ctx, property.List{sceneProp, pageProp, storyProp}))
+
+	sc := scene.New().ID(sceneID).Workspace(wsID).Project(tmpl.ID()).Property(sceneProp.ID()).MustBuild()
+	require.NoError(t, db.Scene.Save(ctx, sc))
+
+	style := scene.NewStyle().NewID().Scene(sceneID).Name("style").Value(&scene.StyleValue{"color": "red"}).MustBuild()
+	require.NoError(t, db.Style.Save(ctx, *style))
+	layer := nlslayer.NewNLSLayerSimple().NewID().Scene(sceneID).Title("layer").LayerType("simple").
+		Config(&nlslayer.Config{"data": map[string]any{"url": assetURL.String()}, "layerStyleId": style.ID().String()}).MustBuild()
+	require.NoError(t, db.NLSLayer.Save(ctx, layer))
+	page := storytelling.NewPage().NewID().Property(pageProp.ID()).Layers(id.NLSLayerIDList{layer.ID()}).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sceneID).Project(tmpl.ID()).Property(storyProp.ID()).
+		Pages(storytelling.NewPageList([]*storytelling.Page{page})).MustBuild()
+	require.NoError(t, db.Storytelling.Save(ctx, *story))
+
+	op := &usecase.Operator{
+		AcOperator:     &accountsWorkspace.Operator{WritableWorkspaces: accountsID.WorkspaceIDList{wsID}},
+		WritableScenes: id.SceneIDList{sceneID},
+	}
+
+	_, err = uc.CreateFromTemplate(ctx, interfaces.CreateProjectFromTemplateParam{TemplateID: plain.ID()}, op)
+	assert.ErrorIs(t, err, interfaces.ErrProjectIsNotTemplate)
+	_, err = uc.CreateFromTemplate(ctx, interfaces.CreateProjectFromTemplateParam{TemplateID: tmpl.ID()}, &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{},
+	})
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	prj, err := uc.CreateFromTemplate(ctx, interfaces.CreateProjectFromTemplateParam{
+		TemplateID: tmpl.ID(),
+		Name:       lo.ToPtr("copy"),
+	}, op)
+	require.NoError(t, err)
+	assert.NotEqual(t, tmpl.ID(), prj.ID())
+	assert.Equal(t, "copy", prj.Name())
+	assert.Equal(t, "desc", prj.Description())
+	assert.False(t, prj.IsTemplate())
+
+	newScene, err := db.Scene.FindByProject(ctx, prj.ID())
+	require.NoError(t, err)
+	assert.NotEqual(t, sceneID, newScene.ID())
+	assert.Equal(t, "c-"+newScene.ID().String(), newScene.Alias())
+	assert.True(t, op.IsWritableScene(newScene.ID()))
+
+	// the copied asset gets a new file that the copied properties and layers point to
+	newAssets, _, err := db.Asset.FindByWorkspaceProject(ctx, wsID, lo.ToPtr(prj.ID()), repo.AssetFilter{})
+	require.NoError(t, err)
+	require.Len(t, newAssets, 1)
+	assert.NotEqual(t, a.URL(), newAssets[0].URL())
+	newAssetURL := lo.Must(url.Parse(newAssets[0].URL()))
+
+	newSceneProp, err := db.Property.FindByID(ctx, newScene.Property())
+	require.NoError(t, err)
+	assert.NotEqual(t, sceneProp.ID(), newSceneProp.ID())
+	assert.Equal(t, newScene.ID(), newSceneProp.Scene())
+	fields := newSceneProp.Fields(nil)
+	require.Len(t, fields, 1)
+	assert.Equal(t, newAssetURL, fields[0].Value().ValueURL())
+	oldSceneProp, _ := db.Property.FindByID(ctx, sceneProp.ID())
+	assert.Equal(t, assetURL, oldSceneProp.Fields(nil)[0].Value().ValueURL())
+
+	newLayers, err := db.NLSLayer.FindByScene(ctx, newScene.ID())
+	require.NoError(t, err)
+	require.Len(t, newLayers, 1)
+	newLayer := *newLayers[0]
+	assert.NotEqual(t, layer.ID(), newLayer.ID())
+	assert.Equal(t, "layer", newLayer.Title())
+	assert.Equal(t, newAssetURL.String(), (*newLayer.Config())["data"].(map[string]any)["url"])
+
+	newStyles, err := db.Style.FindByScene(ctx, newScene.ID())
+	require.NoError(t, err)
+	require.Len(t, *newStyles, 1)
+	assert.NotEqual(t, style.ID(), (*newStyles)[0].ID())
+	assert.Equal(t, "style", (*newStyles)[0].Name())
+	// the copied layer refers to the copied style
+	assert.Equal(t, (*newStyles)[0].ID().String(), (*newLayer.Config())["layerStyleId"])
+	assert.Equal(t, style.ID().String(), (*layer.Config())["layerStyleId"])
+
+	newStories, err := db.Storytelling.FindByScene(ctx, newScene.ID())
+	require.NoError(t, err)
+	require.Len(t, *newStories, 1)
+	newStory := (*newStories)[0]
+	assert.NotEqual(t, story.Id(), newStory.Id())
+	assert.Equal(t, prj.ID(), newStory.Project())
+	assert.NotEqual(t, storyProp.ID(), newStory.Property())
+	newPage := newStory.Pages().Pages()[0]
+	assert.NotEqual(t, page.Id(), newPage.Id())
+	assert.NotEqual(t, pageProp.ID(), newPage.Property())
+	assert.Equal(t, id.NLSLayerIDList{newLayer.ID()}, newPage.Layers())
+
+	// the template is left untouched
+	oldLayers, _ := db.NLSLayer.FindByScene(ctx, sceneID)
+	assert.Len(t, oldLayers, 1)
+	oldStories, _ := db.Storytelling.FindByScene(ctx, sceneID)
+	assert.Equal(t, id.NLSLayerIDList{layer.ID()}, (*oldStories)[0].Pages().Pages()[0].Layers())
+}
+
+func TestProject_Duplicate(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	fileGateway := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	uc := NewProject(db, &gateway.Container{File: fileGateway})
+
+	wsID := accountsID.NewWorkspaceID()
+	ws2ID := accountsID.NewWorkspaceID()
+	src := project.New().NewID().Workspace(wsID).Name("project").Starred(true).CoreSupport(true).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, src))
+	metadata := project.NewProjectMetadata().NewID().Workspace(wsID).Project(src.ID()).Readme(lo.ToPtr("readme")).MustBuild()
+	require.NoError(t, db.ProjectMetadata.Save(ctx, metadata))
+
+	assetURL := lo.Must(url.Parse("https://example.com/assets/image.png"))
+	srcID := src.ID()
+	a := asset.New().NewID().Workspace(wsID).Project(&srcID).Name("image.png").Size(1).URL(assetURL.String()).CoreSupport(true).MustBuild()
+	require.NoError(t, db.Asset.Save(ctx, a))
+
+	sceneID := id.NewSceneID()
+	sceneProp := property.New().NewID().Scene(sceneID).Schema(id.MustPropertySchemaID("reearth/cesium")).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("image").Value(property.ValueTypeURL.ValueFrom(assetURL).Some()).MustBuild(),
+		}).MustBuild(),
+	}).MustBuild()
+	require.NoError(t, db.Property.Save(ctx, sceneProp))
+	sc := scene.New().ID(sceneID).Workspace(wsID).Project(src.ID()).Property(sceneProp.ID()).MustBuild()
+	require.NoError(t, db.Scene.Save(ctx, sc))
+
+	// members who can only read the target workspace cannot duplicate into it
+	_, err := uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: src.ID(), WorkspaceID: &ws2ID}, &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{
+			ReadableWorkspaces: accountsID.WorkspaceIDList{ws2ID},
+			WritableWorkspaces: accountsID.WorkspaceIDList{wsID},
+		},
+	})
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	op := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{WritableWorkspaces: accountsID.WorkspaceIDList{wsID, ws2ID}},
+	}
+
+	// assets cannot be shared with another workspace
+	_, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{
+		ID:          src.ID(),
+		WorkspaceID: &ws2ID,
+		ShareAssets: true,
+	}, op)
+	assert.ErrorIs(t, err, interfaces.ErrCannotShareAssets)
+
+	prj, err := uc.Duplicate(ctx, interfaces.DuplicateProjectParam{
+		ID:          src.ID(),
+		WorkspaceID: &ws2ID,
+	}, op)
+	require.NoError(t, err)
+	assert.NotEqual(t, src.ID(), prj.ID())
+	assert.Equal(t, ws2ID, prj.Workspace())
+	assert.Equal(t, "project", prj.Name())
+	assert.True(t, prj.Starred())
+	newMetadata, err := db.ProjectMetadata.FindByProjectID(ctx, prj.ID())
+	require.NoError(t, err)
+	assert.Equal(t, lo.ToPtr("readme"), newMetadata.Readme())
+
+	newScene, err := db.Scene.FindByProject(ctx, prj.ID())
+	require.NoError(t, err)
+	assert.Equal(t, ws2ID, newScene.Workspace())
+	assert.True(t, op.IsWritableScene(newScene.ID()))
+
+	// shared assets are neither copied nor rewritten
+	prj, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{
+		ID:          src.ID(),
+		ShareAssets: true,
+	}, op)
+	require.NoError(t, err)
+	assert.Equal(t, wsID, prj.Workspace())
+	newAssets, _, err := db.Asset.FindByWorkspaceProject(ctx, wsID, lo.ToPtr(prj.ID()), repo.AssetFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, newAssets)
+	newScene, err = db.Scene.FindByProject(ctx, prj.ID())
+	require.NoError(t, err)
+	newSceneProp, err := db.Property.FindByID(ctx, newScene.Property())
+	require.NoError(t, err)
+	assert.NotEqual(t, sceneProp.ID(), newSceneProp.ID())
+	assert.Equal(t, assetURL, newSceneProp.Fields(nil)[0].Value().ValueURL())
+
+	// projects in the trash cannot be duplicated
+	src.SetDeleted(true)
+	require.NoError(t, db.Project.Save(ctx, src))
+	_, err = uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: src.ID()}, op)
+	assert.ErrorIs(t, err, interfaces.ErrProjectIsDeleted)
+}
+
+func TestProjectCopier_CopyValue(t *testing.T) {
+	// bsonArray stands for primitive.A that the values loaded from the db have
+	type bsonArray []any
+
+	c := &projectCopier{assetURLs: map[string]string{"https://example.com/a.png": "https://example.com/b.png"}}
+	src := map[string]any{"urls": bsonArray{"https://example.com/a.png", map[string]any{"n": 1}}}
+
+	got := c.copyValue(src)
+	assert.Equal(t, map[string]any{"urls": []any{"https://example.com/b.png", map[string]any{"n": 1}}}, got)
+	assert.Equal(t, "https://example.com/a.png", src["urls"].(bsonArray)[0])
+}
+
+func TestProject_Delete_SharedAssets(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	policyChecker := new(MockPolicyChecker)
+	policyChecker.On("CheckPolicy", mock.Anything, mock.Anything).
+		Return(&gateway.PolicyCheckResponse{Allowed: true}, nil).
+		Maybe()
+	fileGateway := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	uc := NewProject(db, &gateway.Container{File: fileGateway, PolicyChecker: policyChecker})
+
+	wsID := accountsID.NewWorkspaceID()
+	src := project.New().NewID().Workspace(wsID).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, src))
+
+	srcID := src.ID()
+	newAsset := func(name string) *asset.Asset {
+		u, size, err := fileGateway.UploadAsset(ctx, &file.File{
+			Content: io.NopCloser(strings.NewReader(name)),
+			Path:    name,
+		})
+		require.NoError(t, err)
+		a := asset.New().NewID().Workspace(wsID).Project(&srcID).Name(name).Size(size).URL(u.String()).CoreSupport(true).MustBuild()
+		require.NoError(t, db.Asset.Save(ctx, a))
+		return a
+	}
+	shared := newAsset("shared.png")
+	unused := newAsset("unused.png")
+
+	sceneID := id.NewSceneID()
+	sceneProp := property.New().NewID().Scene(sceneID).Schema(id.MustPropertySchemaID("reearth/cesium")).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("image").Value(property.ValueTypeURL.ValueFrom(shared.URL()).Some()).MustBuild(),
+		}).MustBuild(),
+	}).MustBuild()
+	require.NoError(t, db.Property.Save(ctx, sceneProp))
+	require.NoError(t, db.Scene.Save(ctx, scene.New().ID(sceneID).Workspace(wsID).Project(src.ID()).Property(sceneProp.ID()).MustBuild()))
+
+	op := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{WritableWorkspaces: accountsID.WorkspaceIDList{wsID}},
+	}
+	dup, err := uc.Duplicate(ctx, interfaces.DuplicateProjectParam{ID: src.ID(), ShareAssets: true}, op)
+	require.NoError(t, err)
+
+	require.NoError(t, uc.Delete(ctx, src.ID(), op))
+
+	// the asset that the duplicate refers to is moved to the workspace instead of being removed
+	got, err := db.Asset.FindByID(ctx, shared.ID())
+	require.NoError(t, err)
+	assert.Nil(t, got.Project())
+
+	_, err = db.Asset.FindByID(ctx, unused.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	// the duplicate still finds the asset
+	dupScene, err := db.Scene.FindByProject(ctx, dup.ID())
+	require.NoError(t, err)
+	dupProp, err := db.Property.FindByID(ctx, dupScene.Property())
+	require.NoError(t, err)
+	assert.Equal(t, shared.URL(), dupProp.Fields(nil)[0].Value().ValueURL().String())
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 )
 
 type Property struct {
@@ -23,6 +25,7 @@ type Property struct {
 	assetRepo          repo.Asset
 	file               gateway.File
 	transaction        usecasex.Transaction
+	sceneEvents        sceneEvents
 }
 
 func NewProperty(r *repo.Container, gr *gateway.Container) interfaces.Property {
@@ -34,6 +37,7 @@ func NewProperty(r *repo.Container, gr *gateway.Container) interfaces.Property {
 		assetRepo:          r.Asset,
 		transaction:        r.Transaction,
 		file:               gr.File,
+		sceneEvents:        newSceneEvents(gr),
 	}
 }
 
@@ -98,6 +102,7 @@ func (i *Property) UpdateValue(ctx context.Context, inp interfaces.UpdatePropert
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventPropertyUpdated, Scene: p.Scene(), Property: lo.ToPtr(p.ID())})
 	return p, pgl, pg, field, nil
 }
 
@@ -135,6 +140,7 @@ func (i *Property) RemoveField(ctx context.Context, inp interfaces.RemovePropert
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventPropertyUpdated, Scene: p.Scene(), Property: lo.ToPtr(p.ID())})
 	return p, nil
 }
 
@@ -176,6 +182,7 @@ func (i *Property) LinkValue(ctx context.Context, inp interfaces.LinkPropertyVal
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventPropertyUpdated, Scene: p.Scene(), Property: lo.ToPtr(p.ID())})
 	return p, pgl, pg, field, nil
 }
 
@@ -222,6 +229,7 @@ func (i *Property) UnlinkValue(ctx context.Context, inp interfaces.UnlinkPropert
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventPropertyUpdated, Scene: p.Scene(), Property: lo.ToPtr(p.ID())})
 	return p, pgl, pg, field, nil
 }
 
@@ -314,6 +322,7 @@ func (i *Property) AddItem(ctx context.Context, inp interfaces.AddPropertyItemPa
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventPropertyUpdated, Scene: p.Scene(), Property: lo.ToPtr(p.ID())})
 	return p, gl, item, nil
 }
 
@@ -353,6 +362,7 @@ func (i *Property) MoveItem(ctx context.Context, inp interfaces.MovePropertyItem
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventPropertyUpdated, Scene: p.Scene(), Property: lo.ToPtr(p.ID())})
 	return p, gl, item, nil
 }
 
@@ -391,6 +401,7 @@ func (i *Property) RemoveItem(ctx context.Context, inp interfaces.RemoveProperty
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventPropertyUpdated, Scene: p.Scene(), Property: lo.ToPtr(p.ID())})
 	return p, nil
 }
 
@@ -435,5 +446,6 @@ func (i *Property) UpdateItems(ctx context.Context, inp interfaces.UpdatePropert
 		return nil, err
 	}
 
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventPropertyUpdated, Scene: p.Scene(), Property: lo.ToPtr(p.ID())})
 	return p, nil
 }
@@ -0,0 +1,216 @@
+package interactor
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type PublicationVersion struct {
+	common
+	commonSceneLock
+	publicationVersionRepo repo.PublicationVersion
+	projectRepo            repo.Project
+	storytellingRepo       repo.Storytelling
+	sceneRepo              repo.Scene
+	transaction            usecasex.Transaction
+	file                   gateway.File
+}
+
+func NewPublicationVersion(r *repo.Container, gr *gateway.Container) interfaces.PublicationVersion {
+	return &PublicationVersion{
+		commonSceneLock:        commonSceneLock{sceneLockRepo: r.SceneLock},
+		publicationVersionRepo: r.PublicationVersion,
+		projectRepo:            r.Project,
+		storytellingRepo:       r.Storytelling,
+		sceneRepo:              r.Scene,
+		transaction:            r.Transaction,
+		file:                   gr.File,
+	}
+}
+
+func (i *PublicationVersion) FetchByProject(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) ([]*publication.Version, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+	return i.publicationVersionRepo.FindByProject(ctx, pid)
+}
+
+func (i *PublicationVersion) FetchByStory(ctx context.Context, sid id.StoryID, operator *usecase.Operator) ([]*publication.Version, error) {
+	story, err := i.storytellingRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(story.Scene(), operator); err != nil {
+		return nil, err
+	}
+	return i.publicationVersionRepo.FindByStory(ctx, sid)
+}
+
+func (i *PublicationVersion) Rollback(ctx context.Context, param interfaces.RollbackPublicationParam, operator *usecase.Operator) (_ *publication.Version, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	var v *publication.Version
+	switch {
+	case param.ProjectID != nil && param.StoryID == nil:
+		v, err = i.rollbackProject(ctx, *param.ProjectID, param.Version, operator)
+	case param.StoryID != nil && param.ProjectID == nil:
+		v, err = i.rollbackStory(ctx, *param.StoryID, param.Version, operator)
+	default:
+		err = publication.ErrInvalidVersionTarget
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return v, nil
+}
+
+func (i *PublicationVersion) rollbackProject(ctx context.Context, pid id.ProjectID, version int, operator *usecase.Operator) (*publication.Version, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+	if prj.PublishmentStatus() == project.PublishmentStatusPrivate {
+		return nil, interfaces.ErrPublicationNotPublished
+	}
+
+	v, err := i.publicationVersionRepo.FindProjectVersion(ctx, pid, version)
+	if err != nil {
+		return nil, err
+	}
+
+	sc, err := i.sceneRepo.FindByProject(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.withPublishingLock(ctx, sc.ID(), func() error {
+		r, err := i.file.ReadPublicationSnapshot(ctx, v.SnapshotName())
+		if err != nil {
+			return err
+		}
+		defer func() { _ = r.Close() }()
+		return i.file.UploadBuiltScene(ctx, r, prj.Alias())
+	}); err != nil {
+		return nil, err
+	}
+
+	prj.SetPublishedVersion(v.Version())
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (i *PublicationVersion) rollbackStory(ctx context.Context, sid id.StoryID, version int, operator *usecase.Operator) (*publication.Version, error) {
+	story, err := i.storytellingRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanWriteScene(story.Scene(), operator); err != nil {
+		return nil, err
+	}
+	if story.PublishmentStatus() == storytelling.PublishmentStatusPrivate {
+		return nil, interfaces.ErrPublicationNotPublished
+	}
+
+	v, err := i.publicationVersionRepo.FindStoryVersion(ctx, sid, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.withPublishingLock(ctx, story.Scene(), func() error {
+		r, err := i.file.ReadPublicationSnapshot(ctx, v.SnapshotName())
+		if err != nil {
+			return err
+		}
+		defer func() { _ = r.Close() }()
+		return i.file.UploadStory(ctx, r, story.Alias())
+	}); err != nil {
+		return nil, err
+	}
+
+	story.SetPublishedVersion(v.Version())
+	if err := i.storytellingRepo.Save(ctx, *story); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// withPublishingLock runs f while holding the publishing lock of the scene so that it does not race with a publish.
+func (i *PublicationVersion) withPublishingLock(ctx context.Context, sceneID id.SceneID, f func() error) error {
+	if err := i.CheckSceneLock(ctx, sceneID); err != nil {
+		return err
+	}
+	if err := i.UpdateSceneLock(ctx, sceneID, scene.LockModeFree, scene.LockModePublishing); err != nil {
+		return err
+	}
+	defer i.ReleaseSceneLock(ctx, sceneID)
+
+	return f()
+}
+
+// newPublicationVersion builds the next version of the project or the story being published.
+// The caller uploads its snapshot and saves it together with the project or the story.
+func newPublicationVersion(ctx context.Context, r repo.PublicationVersion, sceneID id.SceneID, pid *id.ProjectID, sid *id.StoryID, alias, message string, op *usecase.Operator) (*publication.Version, error) {
+	var versions []*publication.Version
+	var err error
+	if pid != nil {
+		versions, err = r.FindByProject(ctx, *pid)
+	} else if sid != nil {
+		versions, err = r.FindByStory(ctx, *sid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	next := 1
+	if len(versions) > 0 {
+		next = versions[0].Version() + 1
+	}
+
+	var publishedBy *accountsID.UserID
+	if op != nil && op.AcOperator != nil {
+		publishedBy = op.AcOperator.User
+	}
+
+	return publication.NewVersion().
+		NewID().
+		Scene(sceneID).
+		Project(pid).
+		Story(sid).
+		Version(next).
+		Alias(alias).
+		PublishedBy(publishedBy).
+		Message(message).
+		Build()
+}
@@ -0,0 +1,118 @@
+package interactor
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicationVersion(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	policyChecker := new(MockPolicyChecker)
+	policyChecker.On("CheckPolicy", mock.Anything, mock.Anything).
+		Return(&gateway.PolicyCheckResponse{Allowed: true}, nil).
+		Maybe()
+	file := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	g := &gateway.Container{File: file, PolicyChecker: policyChecker}
+	storyUC := NewStorytelling(db, g)
+	uc := NewPublicationVersion(db, g)
+
+	wsID := accountsID.NewWorkspaceID()
+	prj := project.New().NewID().Workspace(wsID).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, prj))
+	sc := lo.Must(scene.New().NewID().Workspace(wsID).Project(prj.ID()).Build())
+	require.NoError(t, db.Scene.Save(ctx, sc))
+	// the alias is set beforehand since the memory repository finds the story itself when checking a new alias
+	story := storytelling.NewStory().NewID().Scene(sc.ID()).Alias("versioned-story").MustBuild()
+	require.NoError(t, db.Storytelling.Save(ctx, *story))
+
+	uid := accountsID.NewUserID()
+	owner := &usecase.Operator{
+		AcOperator:     &accountsWorkspace.Operator{User: &uid, WritableWorkspaces: accountsID.WorkspaceIDList{wsID}},
+		WritableScenes: id.SceneIDList{sc.ID()},
+	}
+	attacker := &usecase.Operator{
+		AcOperator:     &accountsWorkspace.Operator{},
+		WritableScenes: id.SceneIDList{id.NewSceneID()},
+	}
+
+	for _, message := range []string{"first", "second"} {
+		_, err := storyUC.Publish(ctx, interfaces.PublishStoryInput{
+			ID:      story.Id(),
+			Alias:   lo.ToPtr("versioned-story"),
+			Status:  storytelling.PublishmentStatusPublic,
+			Message: message,
+		}, owner)
+		require.NoError(t, err)
+	}
+
+	versions, err := uc.FetchByStory(ctx, story.Id(), owner)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 2, versions[0].Version())
+	assert.Equal(t, "second", versions[0].Message())
+	assert.Equal(t, 1, versions[1].Version())
+	assert.Equal(t, "first", versions[1].Message())
+	assert.Equal(t, &uid, versions[1].PublishedBy())
+	assert.Equal(t, "versioned-story", versions[1].Alias())
+	got, _ := db.Storytelling.FindByID(ctx, story.Id())
+	assert.Equal(t, 2, got.PublishedVersion())
+
+	_, err = uc.FetchByStory(ctx, story.Id(), attacker)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	_, err = uc.Rollback(ctx, interfaces.RollbackPublicationParam{StoryID: story.Id().Ref(), Version: 1}, attacker)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	_, err = uc.Rollback(ctx, interfaces.RollbackPublicationParam{Version: 1}, owner)
+	assert.ErrorIs(t, err, publication.ErrInvalidVersionTarget)
+
+	v, err := uc.Rollback(ctx, interfaces.RollbackPublicationParam{StoryID: story.Id().Ref(), Version: 1}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, versions[1].ID(), v.ID())
+	got, _ = db.Storytelling.FindByID(ctx, story.Id())
+	assert.Equal(t, 1, got.PublishedVersion())
+
+	snapshot := readAll(t, lo.Must(file.ReadPublicationSnapshot(ctx, v.SnapshotName())))
+	assert.Equal(t, snapshot, readAll(t, lo.Must(file.ReadStoryFile(ctx, "versioned-story"))))
+
+	// old versions can be previewed through the published data
+	published := NewPublished(db.Project, db.Storytelling, db.ShareToken, db.PublicationVersion, file, "")
+	r, err := published.DataVersion(ctx, "versioned-story", 2)
+	require.NoError(t, err)
+	assert.Equal(t, readAll(t, lo.Must(file.ReadPublicationSnapshot(ctx, versions[0].SnapshotName()))), readAll(t, r))
+	_, err = published.DataVersion(ctx, "versioned-story", 3)
+	assert.Error(t, err)
+
+	_, err = storyUC.Publish(ctx, interfaces.PublishStoryInput{
+		ID:     story.Id(),
+		Status: storytelling.PublishmentStatusPrivate,
+	}, owner)
+	require.NoError(t, err)
+	_, err = uc.Rollback(ctx, interfaces.RollbackPublicationParam{StoryID: story.Id().Ref(), Version: 1}, owner)
+	assert.ErrorIs(t, err, interfaces.ErrPublicationNotPublished)
+}
+
+func readAll(t *testing.T, r io.Reader) string {
+	t.Helper()
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
@@ -0,0 +1,268 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+const publishScheduleLockName = "publishSchedule"
+
+type PublishSchedule struct {
+	common
+	publishScheduleRepo repo.PublishSchedule
+	projectRepo         repo.Project
+	storytellingRepo    repo.Storytelling
+	sceneRepo           repo.Scene
+	lock                repo.Lock
+	transaction         usecasex.Transaction
+	project             *Project
+	storytelling        *Storytelling
+}
+
+func NewPublishSchedule(r *repo.Container, gr *gateway.Container) interfaces.PublishSchedule {
+	return &PublishSchedule{
+		publishScheduleRepo: r.PublishSchedule,
+		projectRepo:         r.Project,
+		storytellingRepo:    r.Storytelling,
+		sceneRepo:           r.Scene,
+		lock:                r.Lock,
+		transaction:         r.Transaction,
+		project:             NewProject(r, gr).(*Project),
+		storytelling:        NewStorytelling(r, gr).(*Storytelling),
+	}
+}
+
+func (i *PublishSchedule) FetchByProject(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) ([]*publication.Schedule, error) {
+	if _, err := i.projectScene(ctx, pid, operator); err != nil {
+		return nil, err
+	}
+	return i.publishScheduleRepo.FindByProject(ctx, pid)
+}
+
+func (i *PublishSchedule) FetchByStory(ctx context.Context, sid id.StoryID, operator *usecase.Operator) ([]*publication.Schedule, error) {
+	if _, err := i.storyScene(ctx, sid, operator); err != nil {
+		return nil, err
+	}
+	return i.publishScheduleRepo.FindByStory(ctx, sid)
+}
+
+func (i *PublishSchedule) Create(ctx context.Context, param interfaces.CreatePublishScheduleParam, operator *usecase.Operator) (_ *publication.Schedule, err error) {
+	now := time.Now()
+	if param.PublishAt != nil && !param.PublishAt.After(now) || param.UnpublishAt != nil && !param.UnpublishAt.After(now) {
+		return nil, interfaces.ErrInvalidPublishScheduleTime
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	var sceneID id.SceneID
+	switch {
+	case param.ProjectID != nil && param.StoryID == nil:
+		sceneID, err = i.projectScene(ctx, *param.ProjectID, operator)
+	case param.StoryID != nil && param.ProjectID == nil:
+		sceneID, err = i.storyScene(ctx, *param.StoryID, operator)
+	default:
+		err = publication.ErrInvalidScheduleTarget
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBy *accountsID.UserID
+	if operator != nil && operator.AcOperator != nil {
+		createdBy = operator.AcOperator.User
+	}
+
+	b := publication.NewSchedule().
+		NewID().
+		Scene(sceneID).
+		Project(param.ProjectID).
+		Story(param.StoryID).
+		Status(param.Status).
+		PublishAt(param.PublishAt).
+		UnpublishAt(param.UnpublishAt).
+		CreatedBy(createdBy)
+	if param.Alias != nil {
+		b = b.Alias(*param.Alias)
+	}
+	s, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.publishScheduleRepo.Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return s, nil
+}
+
+func (i *PublishSchedule) Cancel(ctx context.Context, sid id.PublishScheduleID, operator *usecase.Operator) (_ *publication.Schedule, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	s, err := i.publishScheduleRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+
+	if pid := s.Project(); pid != nil {
+		_, err = i.projectScene(ctx, *pid, operator)
+	} else {
+		_, err = i.storyScene(ctx, *s.Story(), operator)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Cancel(); err != nil {
+		return nil, err
+	}
+
+	if err := i.publishScheduleRepo.Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return s, nil
+}
+
+func (i *PublishSchedule) RunDue(ctx context.Context, now time.Time) error {
+	// only one server instance runs the schedules at a time
+	if err := i.lock.Lock(ctx, publishScheduleLockName); err != nil {
+		if errors.Is(err, repo.ErrAlreadyLocked) || errors.Is(err, repo.ErrFailedToLock) {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		if err := i.lock.Unlock(ctx, publishScheduleLockName); err != nil {
+			log.Errorfc(ctx, "publish schedule: failed to unlock: %v", err)
+		}
+	}()
+
+	schedules, err := i.publishScheduleRepo.FindDue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range schedules {
+		if err := i.run(ctx, s); err != nil {
+			if errors.Is(err, interfaces.ErrSceneIsLocked) {
+				// the scene is being published by someone else, so retry at the next run
+				log.Infofc(ctx, "publish schedule: scene of %s is locked, retrying later", s.ID())
+				continue
+			}
+			log.Warnfc(ctx, "publish schedule: failed to run %s: %v", s.ID(), err)
+			s.Fail(err)
+		} else if err := s.Done(); err != nil {
+			return err
+		}
+
+		if err := i.publishScheduleRepo.Save(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// run executes the next action of the schedule through the same path as the publish mutations.
+func (i *PublishSchedule) run(ctx context.Context, s *publication.Schedule) error {
+	publish := s.NextAction() == publication.ScheduleActionPublish
+	var a *string
+	if publish && s.Alias() != "" {
+		alias := s.Alias()
+		a = &alias
+	}
+
+	if pid := s.Project(); pid != nil {
+		prj, dedicatedID1, dedicatedID2, err := i.project.dedicatedID(ctx, pid)
+		if err != nil {
+			return err
+		}
+		status := project.PublishmentStatusPrivate
+		if publish {
+			status = project.PublishmentStatus(s.Status())
+		}
+		_, err = i.project.publish(ctx, prj, dedicatedID1, dedicatedID2, interfaces.PublishProjectParam{
+			ID:     *pid,
+			Alias:  a,
+			Status: status,
+		}, nil)
+		return err
+	}
+
+	story, err := i.storytellingRepo.FindByID(ctx, *s.Story())
+	if err != nil {
+		return err
+	}
+	status := storytelling.PublishmentStatusPrivate
+	if publish {
+		status = storytelling.PublishmentStatus(s.Status())
+	}
+	_, err = i.storytelling.publish(ctx, story, interfaces.PublishStoryInput{
+		ID:     story.Id(),
+		Alias:  a,
+		Status: status,
+	}, nil)
+	return err
+}
+
+func (i *PublishSchedule) projectScene(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (id.SceneID, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return id.SceneID{}, err
+	}
+	if err := i.CanWriteWorkspace(prj.Workspace(), operator); err != nil {
+		return id.SceneID{}, err
+	}
+	sc, err := i.sceneRepo.FindByProject(ctx, pid)
+	if err != nil {
+		return id.SceneID{}, err
+	}
+	return sc.ID(), nil
+}
+
+func (i *PublishSchedule) storyScene(ctx context.Context, sid id.StoryID, operator *usecase.Operator) (id.SceneID, error) {
+	story, err := i.storytellingRepo.FindByID(ctx, sid)
+	if err != nil {
+		return id.SceneID{}, err
+	}
+	if err := i.CanWriteScene(story.Scene(), operator); err != nil {
+		return id.SceneID{}, err
+	}
+	return story.Scene(), nil
+}
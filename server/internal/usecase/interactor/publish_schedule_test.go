@@ -0,0 +1,123 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishSchedule(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	policyChecker := new(MockPolicyChecker)
+	policyChecker.On("CheckPolicy", mock.Anything, mock.Anything).
+		Return(&gateway.PolicyCheckResponse{Allowed: true}, nil).
+		Maybe()
+	uc := NewPublishSchedule(db, &gateway.Container{
+		File:          lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com")),
+		PolicyChecker: policyChecker,
+	})
+
+	wsID := accountsID.NewWorkspaceID()
+	prj := project.New().NewID().Workspace(wsID).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, prj))
+	sc := lo.Must(scene.New().NewID().Workspace(wsID).Project(prj.ID()).Build())
+	require.NoError(t, db.Scene.Save(ctx, sc))
+	story := storytelling.NewStory().NewID().Scene(sc.ID()).MustBuild()
+	require.NoError(t, db.Storytelling.Save(ctx, *story))
+
+	owner := &usecase.Operator{
+		AcOperator:     &accountsWorkspace.Operator{WritableWorkspaces: accountsID.WorkspaceIDList{wsID}},
+		WritableScenes: id.SceneIDList{sc.ID()},
+	}
+	attacker := &usecase.Operator{
+		AcOperator:     &accountsWorkspace.Operator{},
+		WritableScenes: id.SceneIDList{id.NewSceneID()},
+	}
+
+	now := time.Now()
+	publishAt := now.Add(time.Hour)
+	unpublishAt := now.Add(2 * time.Hour)
+
+	_, err := uc.Create(ctx, interfaces.CreatePublishScheduleParam{
+		StoryID: story.Id().Ref(), Status: publication.ScheduleStatusPublic, PublishAt: &publishAt,
+	}, attacker)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	_, err = uc.Create(ctx, interfaces.CreatePublishScheduleParam{
+		StoryID: story.Id().Ref(), Status: publication.ScheduleStatusPublic, PublishAt: lo.ToPtr(now.Add(-time.Hour)),
+	}, owner)
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPublishScheduleTime)
+
+	s, err := uc.Create(ctx, interfaces.CreatePublishScheduleParam{
+		StoryID:     story.Id().Ref(),
+		Status:      publication.ScheduleStatusPublic,
+		PublishAt:   &publishAt,
+		UnpublishAt: &unpublishAt,
+	}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, publication.ScheduleStatePending, s.State())
+
+	list, err := uc.FetchByStory(ctx, story.Id(), owner)
+	assert.NoError(t, err)
+	assert.Equal(t, []*publication.Schedule{s}, list)
+	_, err = uc.FetchByStory(ctx, story.Id(), attacker)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	// nothing is due yet
+	require.NoError(t, uc.RunDue(ctx, now))
+	got, _ := db.Storytelling.FindByID(ctx, story.Id())
+	assert.NotEqual(t, storytelling.PublishmentStatusPublic, got.PublishmentStatus())
+
+	// a locked scene is retried at the next run
+	require.NoError(t, db.SceneLock.SaveLock(ctx, sc.ID(), scene.LockModePublishing))
+	require.NoError(t, uc.RunDue(ctx, publishAt))
+	assert.Equal(t, publication.ScheduleStatePending, s.State())
+	require.NoError(t, db.SceneLock.SaveLock(ctx, sc.ID(), scene.LockModeFree))
+
+	require.NoError(t, uc.RunDue(ctx, publishAt))
+	assert.Equal(t, publication.ScheduleStatePublished, s.State())
+	got, _ = db.Storytelling.FindByID(ctx, story.Id())
+	assert.Equal(t, storytelling.PublishmentStatusPublic, got.PublishmentStatus())
+
+	require.NoError(t, uc.RunDue(ctx, unpublishAt))
+	assert.Equal(t, publication.ScheduleStateDone, s.State())
+	got, _ = db.Storytelling.FindByID(ctx, story.Id())
+	assert.Equal(t, storytelling.PublishmentStatusPrivate, got.PublishmentStatus())
+
+	_, err = uc.Cancel(ctx, s.ID(), owner)
+	assert.ErrorIs(t, err, publication.ErrScheduleNotCancelable)
+
+	// a project schedule is canceled before it runs
+	s, err = uc.Create(ctx, interfaces.CreatePublishScheduleParam{
+		ProjectID: prj.ID().Ref(), Status: publication.ScheduleStatusLimited, PublishAt: &publishAt,
+	}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, sc.ID(), s.Scene())
+	_, err = uc.Cancel(ctx, s.ID(), attacker)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	s, err = uc.Cancel(ctx, s.ID(), owner)
+	require.NoError(t, err)
+	assert.Equal(t, publication.ScheduleStateCanceled, s.State())
+	require.NoError(t, uc.RunDue(ctx, unpublishAt))
+	gotPrj, _ := db.Project.FindByID(ctx, prj.ID())
+	assert.Equal(t, project.PublishmentStatusPrivate, gotPrj.PublishmentStatus())
+}
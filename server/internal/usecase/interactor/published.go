@@ -16,6 +16,10 @@ import (
 	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/reearth/reearth/server/pkg/visualizer"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
@@ -23,27 +27,33 @@ import (
 )
 
 type Published struct {
-	project      repo.Project
-	Storytelling repo.Storytelling
-	file         gateway.File
-	indexHTML    *util.Cache[string]
-	indexHTMLStr string
+	project            repo.Project
+	Storytelling       repo.Storytelling
+	shareToken         repo.ShareToken
+	publicationVersion repo.PublicationVersion
+	file               gateway.File
+	indexHTML          *util.Cache[string]
+	indexHTMLStr       string
 }
 
-func NewPublished(project repo.Project, storytelling repo.Storytelling, file gateway.File, indexHTML string) interfaces.Published {
+func NewPublished(project repo.Project, storytelling repo.Storytelling, shareToken repo.ShareToken, publicationVersion repo.PublicationVersion, file gateway.File, indexHTML string) interfaces.Published {
 	return &Published{
-		project:      project,
-		Storytelling: storytelling,
-		file:         file,
-		indexHTMLStr: indexHTML,
+		project:            project,
+		Storytelling:       storytelling,
+		shareToken:         shareToken,
+		publicationVersion: publicationVersion,
+		file:               file,
+		indexHTMLStr:       indexHTML,
 	}
 }
 
-func NewPublishedWithURL(project repo.Project, storytelling repo.Storytelling, file gateway.File, indexHTMLURL *url.URL) interfaces.Published {
+func NewPublishedWithURL(project repo.Project, storytelling repo.Storytelling, shareToken repo.ShareToken, publicationVersion repo.PublicationVersion, file gateway.File, indexHTMLURL *url.URL) interfaces.Published {
 	return &Published{
-		project:      project,
-		file:         file,
-		Storytelling: storytelling,
+		project:            project,
+		file:               file,
+		Storytelling:       storytelling,
+		shareToken:         shareToken,
+		publicationVersion: publicationVersion,
 		indexHTML: util.NewCache(func(c context.Context, i string) (string, error) {
 			req, err := http.NewRequestWithContext(c, http.MethodGet, indexHTMLURL.String(), nil)
 			if err != nil {
@@ -112,6 +122,80 @@ func (i *Published) Data(ctx context.Context, name string) (io.Reader, error) {
 	return nil, visualizer.ErrorWithCallerLogging(ctx, "published: no data file found", rerror.ErrNotFound)
 }
 
+func (i *Published) DataVersion(ctx context.Context, name string, version int) (io.Reader, error) {
+	if i.publicationVersion == nil {
+		return nil, rerror.ErrNotFound
+	}
+
+	var v *publication.Version
+	prj, err := i.project.FindByPublicName(ctx, name)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+	if prj != nil {
+		v, err = i.publicationVersion.FindProjectVersion(ctx, prj.ID(), version)
+	} else {
+		story, err2 := i.Storytelling.FindByPublicName(ctx, name)
+		if err2 != nil {
+			return nil, err2
+		}
+		v, err = i.publicationVersion.FindStoryVersion(ctx, story.Id(), version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := i.file.ReadPublicationSnapshot(ctx, v.SnapshotName())
+	if err != nil {
+		return nil, visualizer.ErrorWithCallerLogging(ctx, "published: read publication snapshot", err)
+	}
+	return r, nil
+}
+
+func (i *Published) ValidateShareToken(ctx context.Context, name, token string) error {
+	var pid *id.ProjectID
+	var sid *id.StoryID
+
+	prj, err := i.project.FindByPublicName(ctx, name)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+	if prj != nil {
+		if prj.PublishmentStatus() != project.PublishmentStatusLimited {
+			return nil
+		}
+		pid = prj.ID().Ref()
+	} else {
+		story, err := i.Storytelling.FindByPublicName(ctx, name)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+		if story == nil || story.PublishmentStatus() != storytelling.PublishmentStatusLimited {
+			return nil
+		}
+		sid = story.Id().Ref()
+	}
+
+	if token == "" {
+		return interfaces.ErrShareTokenRequired
+	}
+	if i.shareToken == nil {
+		return interfaces.ErrInvalidShareToken
+	}
+
+	st, err := i.shareToken.FindByHash(ctx, publication.HashShareToken(token))
+	if errors.Is(err, rerror.ErrNotFound) {
+		return interfaces.ErrInvalidShareToken
+	}
+	if err != nil {
+		return err
+	}
+	if !st.Targets(pid, sid) || st.Validate(time.Now()) != nil {
+		return interfaces.ErrInvalidShareToken
+	}
+	return nil
+}
+
 func (i *Published) Index(ctx context.Context, name string, u *url.URL) (string, error) {
 	htmlStr := i.indexHTMLStr
 	if i.indexHTML != nil {
@@ -1,10 +1,19 @@
 package interactor
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRenderIndex(t *testing.T) {
@@ -66,3 +75,72 @@ func TestRenderIndexWithoutIconImage(t *testing.T) {
 	// Should keep the default favicon when no custom IconImage is provided
 	assert.Contains(t, result, `<link rel="icon" href="./src/favicon.ico" />`)
 }
+
+func TestPublished_ValidateShareToken(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	sid := id.NewSceneID()
+	newProject := func(alias string, status project.PublishmentStatus) *project.Project {
+		p := project.New().NewID().Workspace(accountsID.NewWorkspaceID()).Scene(sid).
+			Alias(alias).PublishmentStatus(status).MustBuild()
+		require.NoError(t, db.Project.Save(ctx, p))
+		return p
+	}
+	limited := newProject("limited", project.PublishmentStatusLimited)
+	other := newProject("other", project.PublishmentStatusLimited)
+	_ = newProject("public", project.PublishmentStatusPublic)
+
+	newToken := func(p *project.Project) string {
+		token, hash, err := publication.GenerateShareToken()
+		require.NoError(t, err)
+		st := publication.NewShareToken().NewID().Scene(sid).Project(p.ID().Ref()).Hash(hash).MustBuild()
+		require.NoError(t, db.ShareToken.Save(ctx, st))
+		return token
+	}
+	valid := newToken(limited)
+	otherToken := newToken(other)
+
+	revoked, revokedHash, err := publication.GenerateShareToken()
+	require.NoError(t, err)
+	st := publication.NewShareToken().NewID().Scene(sid).Project(limited.ID().Ref()).Hash(revokedHash).MustBuild()
+	st.Revoke(time.Now())
+	require.NoError(t, db.ShareToken.Save(ctx, st))
+
+	expired, expiredHash, err := publication.GenerateShareToken()
+	require.NoError(t, err)
+	st = publication.NewShareToken().NewID().Scene(sid).Project(limited.ID().Ref()).Hash(expiredHash).
+		ExpiresAt(lo.ToPtr(time.Now().Add(-time.Minute))).MustBuild()
+	require.NoError(t, db.ShareToken.Save(ctx, st))
+
+	uc := NewPublished(db.Project, db.Storytelling, db.ShareToken, db.PublicationVersion, nil, "")
+
+	tests := []struct {
+		name    string
+		alias   string
+		token   string
+		wantErr error
+	}{
+		{name: "public", alias: "public"},
+		{name: "not found", alias: "unknown"},
+		{name: "valid token", alias: "limited", token: valid},
+		{name: "no token", alias: "limited", wantErr: interfaces.ErrShareTokenRequired},
+		{name: "unknown token", alias: "limited", token: "xxx", wantErr: interfaces.ErrInvalidShareToken},
+		{name: "token of another project", alias: "limited", token: otherToken, wantErr: interfaces.ErrInvalidShareToken},
+		{name: "revoked token", alias: "limited", token: revoked, wantErr: interfaces.ErrInvalidShareToken},
+		{name: "expired token", alias: "limited", token: expired, wantErr: interfaces.ErrInvalidShareToken},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := uc.ValidateShareToken(ctx, tt.alias, tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
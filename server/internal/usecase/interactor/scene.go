@@ -42,6 +42,7 @@ type Scene struct {
 	nlsLayerRepo       repo.NLSLayer
 	layerStyles        repo.Style
 	storytellingRepo   repo.Storytelling
+	sceneEvents        sceneEvents
 }
 
 func NewScene(r *repo.Container, g *gateway.Container) interfaces.Scene {
@@ -59,6 +60,7 @@ func NewScene(r *repo.Container, g *gateway.Container) interfaces.Scene {
 		nlsLayerRepo:       r.NLSLayer,
 		layerStyles:        r.Style,
 		storytellingRepo:   r.Storytelling,
+		sceneEvents:        newSceneEvents(g),
 	}
 }
 
@@ -386,6 +388,7 @@ func (i *Scene) AddWidget(ctx context.Context, _type scene.WidgetAlignSystemType
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventWidgetAdded, Scene: s.ID(), Widget: lo.ToPtr(widget.ID())})
 	return s, widget, nil
 }
 
@@ -402,19 +405,19 @@ func (i *Scene) UpdateWidget(ctx context.Context, param interfaces.UpdateWidgetP
 		}
 	}()
 
-	scene, err2 := i.sceneRepo.FindByID(ctx, param.SceneID)
+	s, err2 := i.sceneRepo.FindByID(ctx, param.SceneID)
 	if err2 != nil {
 		return nil, nil, err2
 	}
-	if err := i.CanWriteWorkspace(scene.Workspace(), operator); err != nil {
+	if err := i.CanWriteWorkspace(s.Workspace(), operator); err != nil {
 		return nil, nil, err
 	}
 
-	widget := scene.Widgets().Widget(param.WidgetID)
+	widget := s.Widgets().Widget(param.WidgetID)
 	if widget == nil {
 		return nil, nil, rerror.ErrNotFound
 	}
-	_, location := scene.Widgets().Alignment().System(param.Type).Find(param.WidgetID)
+	_, location := s.Widgets().Alignment().System(param.Type).Find(param.WidgetID)
 
 	extension, err := i.getWidgePlugin(ctx, widget.Plugin(), widget.Extension(), nil)
 	if err != nil {
@@ -433,7 +436,7 @@ func (i *Scene) UpdateWidget(ctx context.Context, param interfaces.UpdateWidgetP
 		if param.Index != nil {
 			index = *param.Index
 		}
-		scene.Widgets().Alignment().System(param.Type).Move(widget.ID(), location, index)
+		s.Widgets().Alignment().System(param.Type).Move(widget.ID(), location, index)
 	}
 
 	if param.Extended != nil {
@@ -452,18 +455,19 @@ func (i *Scene) UpdateWidget(ctx context.Context, param interfaces.UpdateWidgetP
 		}
 	}
 
-	err2 = i.sceneRepo.Save(ctx, scene)
+	err2 = i.sceneRepo.Save(ctx, s)
 	if err2 != nil {
 		return nil, nil, err2
 	}
 
-	err = updateProjectUpdatedAtByID(ctx, scene.Project(), i.projectRepo)
+	err = updateProjectUpdatedAtByID(ctx, s.Project(), i.projectRepo)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	tx.Commit()
-	return scene, widget, nil
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventWidgetUpdated, Scene: s.ID(), Widget: lo.ToPtr(widget.ID())})
+	return s, widget, nil
 }
 
 func (i *Scene) UpdateWidgetAlignSystem(ctx context.Context, param interfaces.UpdateWidgetAlignSystemParam, operator *usecase.Operator) (_ *scene.Scene, err error) {
@@ -520,6 +524,7 @@ func (i *Scene) UpdateWidgetAlignSystem(ctx context.Context, param interfaces.Up
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventWidgetUpdated, Scene: s.ID()})
 	return s, nil
 }
 
@@ -536,15 +541,15 @@ func (i *Scene) RemoveWidget(ctx context.Context, _type scene.WidgetAlignSystemT
 		}
 	}()
 
-	scene, err2 := i.sceneRepo.FindByID(ctx, id)
+	s, err2 := i.sceneRepo.FindByID(ctx, id)
 	if err2 != nil {
 		return nil, err2
 	}
-	if err := i.CanWriteWorkspace(scene.Workspace(), operator); err != nil {
+	if err := i.CanWriteWorkspace(s.Workspace(), operator); err != nil {
 		return nil, err
 	}
 
-	ws := scene.Widgets()
+	ws := s.Widgets()
 
 	widget := ws.Widget(wid)
 	if widget == nil {
@@ -552,25 +557,42 @@ func (i *Scene) RemoveWidget(ctx context.Context, _type scene.WidgetAlignSystemT
 	}
 
 	ws.Remove(wid)
-	scene.Widgets().Alignment().System(_type).Remove(wid)
+	s.Widgets().Alignment().System(_type).Remove(wid)
 
 	err2 = i.propertyRepo.Remove(ctx, widget.Property())
 	if err2 != nil {
 		return nil, err2
 	}
 
-	err2 = i.sceneRepo.Save(ctx, scene)
+	err2 = i.sceneRepo.Save(ctx, s)
 	if err2 != nil {
 		return nil, err2
 	}
 
-	err = updateProjectUpdatedAtByID(ctx, scene.Project(), i.projectRepo)
+	err = updateProjectUpdatedAtByID(ctx, s.Project(), i.projectRepo)
 	if err != nil {
 		return nil, err
 	}
 
 	tx.Commit()
-	return scene, nil
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventWidgetRemoved, Scene: s.ID(), Widget: &wid})
+	return s, nil
+}
+
+func (i *Scene) SubscribeEvents(ctx context.Context, sid id.SceneID, operator *usecase.Operator) (<-chan scene.Event, error) {
+	if operator == nil || operator.AcOperator == nil {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	s, err := i.sceneRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if !operator.IsReadableWorkspace(s.Workspace()) {
+		return nil, interfaces.ErrOperationDenied
+	}
+
+	return i.sceneEvents.subscribe(ctx, sid)
 }
 
 func (i *Scene) ExportSceneData(ctx context.Context, prj *project.Project) (*scene.Scene, map[string]interface{}, error) {
@@ -0,0 +1,50 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearthx/log"
+)
+
+// sceneEvents tells the editors of scenes about the changes made to them.
+type sceneEvents struct {
+	pubsub gateway.PubSub
+}
+
+func newSceneEvents(gr *gateway.Container) sceneEvents {
+	if gr == nil {
+		return sceneEvents{}
+	}
+	return sceneEvents{pubsub: gr.PubSub}
+}
+
+// publish is called once the change is committed. Failing to publish is logged instead of failing the change,
+// since the editors still see the change when they fetch the scene again.
+func (e sceneEvents) publish(ctx context.Context, operator *usecase.Operator, ev scene.Event) {
+	if e.pubsub == nil {
+		return
+	}
+
+	if operator != nil && operator.AcOperator != nil {
+		ev.Actor = operator.AcOperator.User
+	}
+	if ev.OccurredAt.IsZero() {
+		ev.OccurredAt = time.Now()
+	}
+	if err := e.pubsub.Publish(ctx, ev); err != nil {
+		log.Warnfc(ctx, "pubsub: failed to publish %s of scene %s: %v", ev.Type, ev.Scene, err)
+	}
+}
+
+func (e sceneEvents) subscribe(ctx context.Context, sid id.SceneID) (<-chan scene.Event, error) {
+	if e.pubsub == nil {
+		return nil, interfaces.ErrSceneEventsUnavailable
+	}
+	return e.pubsub.Subscribe(ctx, sid)
+}
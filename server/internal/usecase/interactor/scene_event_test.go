@@ -0,0 +1,90 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/infrastructure/pubsub"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScene_SubscribeEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := memory.New()
+	g := &gateway.Container{PubSub: pubsub.NewMemory()}
+
+	wid := accountsID.NewWorkspaceID()
+	prj, _ := project.New().NewID().Workspace(wid).Build()
+	_ = db.Project.Save(ctx, prj)
+	s, _ := scene.New().NewID().Workspace(wid).Project(prj.ID()).Build()
+	_ = db.Scene.Save(ctx, s)
+
+	uid := accountsID.NewUserID()
+	editor := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{
+			User:               &uid,
+			WritableWorkspaces: accountsID.WorkspaceIDList{wid},
+		},
+		WritableScenes: id.SceneIDList{s.ID()},
+	}
+	outsider := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{
+			ReadableWorkspaces: accountsID.WorkspaceIDList{accountsID.NewWorkspaceID()},
+		},
+	}
+
+	uc := NewScene(db, g)
+
+	_, err := uc.SubscribeEvents(ctx, s.ID(), outsider)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	events, err := uc.SubscribeEvents(ctx, s.ID(), editor)
+	require.NoError(t, err)
+
+	st, err := NewStyle(db, g).AddStyle(ctx, interfaces.AddStyleInput{
+		SceneID: s.ID(),
+		Name:    "style",
+	}, editor)
+	require.NoError(t, err)
+
+	select {
+	case e := <-events:
+		assert.Equal(t, scene.EventStyleAdded, e.Type)
+		assert.Equal(t, s.ID(), e.Scene)
+		assert.Equal(t, st.ID().Ref(), e.Style)
+		assert.Equal(t, &uid, e.Actor)
+		assert.False(t, e.OccurredAt.IsZero())
+	case <-time.After(time.Second):
+		assert.Fail(t, "no event is received")
+	}
+}
+
+func TestScene_SubscribeEvents_Unavailable(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	wid := accountsID.NewWorkspaceID()
+	s, _ := scene.New().NewID().Workspace(wid).Project(id.NewProjectID()).Build()
+	_ = db.Scene.Save(ctx, s)
+
+	op := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{
+			ReadableWorkspaces: accountsID.WorkspaceIDList{wid},
+		},
+	}
+
+	_, err := NewScene(db, &gateway.Container{}).SubscribeEvents(ctx, s.ID(), op)
+	assert.ErrorIs(t, err, interfaces.ErrSceneEventsUnavailable)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/sceneops"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 )
 
@@ -196,6 +197,8 @@ func (i *Scene) UpgradePlugin(ctx context.Context, sid id.SceneID, oldPluginID,
 		Property:       repo.PropertyLoaderFrom(i.propertyRepo),
 		PropertySchema: repo.PropertySchemaLoaderFrom(i.propertySchemaRepo),
 		Plugin:         repo.PluginLoaderFrom(i.pluginRepo),
+		NLSLayer:       repo.NLSLayerLoaderBySceneFrom(i.nlsLayerRepo),
+		Story:          repo.StorytellingLoaderBySceneFrom(i.storytellingRepo),
 	}
 
 	result, err := pluginMigrator.MigratePlugins(ctx, s, oldPluginID, newPluginID)
@@ -203,6 +206,13 @@ func (i *Scene) UpgradePlugin(ctx context.Context, sid id.SceneID, oldPluginID,
 		return nil, err
 	}
 
+	for pid, dropped := range result.DroppedFields {
+		for _, d := range dropped {
+			sg, _, f := d.Pointer.GetAll()
+			log.Warnfc(ctx, "scene %s: upgrading plugin %s to %s dropped field %s.%s of property %s: %s", sid, oldPluginID, newPluginID, sg, f, pid, d.Reason)
+		}
+	}
+
 	if err := i.sceneRepo.Save(ctx, result.Scene); err != nil {
 		return nil, err
 	}
@@ -212,7 +222,56 @@ func (i *Scene) UpgradePlugin(ctx context.Context, sid id.SceneID, oldPluginID,
 	if err := i.propertyRepo.RemoveAll(ctx, result.RemovedProperties); err != nil {
 		return nil, err
 	}
+	if len(result.NLSLayers) > 0 {
+		if err := i.nlsLayerRepo.SaveAll(ctx, result.NLSLayers); err != nil {
+			return nil, err
+		}
+	}
+	if len(result.Stories) > 0 {
+		if err := i.storytellingRepo.SaveAll(ctx, result.Stories); err != nil {
+			return nil, err
+		}
+	}
 
 	tx.Commit()
 	return result.Scene, err
 }
+
+// PluginUpgradeReport tells what UpgradePlugin would do without writing anything.
+// Unlike UpgradePlugin, it does not download the new plugin, so the new plugin must already be known.
+func (i *Scene) PluginUpgradeReport(ctx context.Context, sid id.SceneID, oldPluginID, newPluginID id.PluginID, operator *usecase.Operator) (*sceneops.PluginUpgradeReport, error) {
+	s, err := i.sceneRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.CanReadWorkspace(s.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	if oldPluginID.IsNil() || newPluginID.IsNil() || oldPluginID.Equal(newPluginID) || !oldPluginID.NameEqual(newPluginID) {
+		return nil, interfaces.ErrCannotUpgradeToPlugin
+	}
+
+	if !s.Plugins().Has(oldPluginID) {
+		return nil, interfaces.ErrPluginNotInstalled
+	}
+
+	if plugin, err := i.pluginRepo.FindByID(ctx, newPluginID); err != nil {
+		if errors.Is(err, rerror.ErrNotFound) {
+			return nil, ErrPluginNotFound
+		}
+		return nil, err
+	} else if plugin == nil {
+		return nil, ErrPluginNotFound
+	}
+
+	pluginMigrator := sceneops.PluginMigrator{
+		Property:       repo.PropertyLoaderFrom(i.propertyRepo),
+		PropertySchema: repo.PropertySchemaLoaderFrom(i.propertySchemaRepo),
+		Plugin:         repo.PluginLoaderFrom(i.pluginRepo),
+		NLSLayer:       repo.NLSLayerLoaderBySceneFrom(i.nlsLayerRepo),
+		Story:          repo.StorytellingLoaderBySceneFrom(i.storytellingRepo),
+	}
+
+	return pluginMigrator.Report(ctx, s, oldPluginID, newPluginID)
+}
@@ -370,6 +370,8 @@ func TestScene_UpgradePlugin(t *testing.T) {
 				pluginRepo:         pr,
 				propertyRepo:       prr,
 				propertySchemaRepo: psr,
+				nlsLayerRepo:       memory.NewNLSLayer(),
+				storytellingRepo:   memory.NewStorytelling(),
 				pluginRegistry:     &mockPluginRegistry{},
 				transaction:        &usecasex.NopTransaction{},
 			}
@@ -401,6 +403,76 @@ func TestScene_UpgradePlugin(t *testing.T) {
 	}
 }
 
+func TestScene_PluginUpgradeReport(t *testing.T) {
+	ctx := context.Background()
+
+	sid := id.NewSceneID()
+	pid1 := id.MustPluginID("plugin~1.0.0")
+	pid2 := id.MustPluginID("plugin~1.0.1")
+
+	pl1ps := property.NewSchema().ID(id.NewPropertySchemaID(pid1, "a")).Groups(property.NewSchemaGroupList([]*property.SchemaGroup{
+		property.NewSchemaGroup().ID("default").Fields([]*property.SchemaField{
+			property.NewSchemaField().ID("x").Type(property.ValueTypeString).MustBuild(),
+		}).MustBuild(),
+	})).MustBuild()
+	pl2ps := property.NewSchema().ID(id.NewPropertySchemaID(pid2, "a")).MustBuild()
+	pl1 := plugin.New().ID(pid1).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("a").Type(plugin.ExtensionTypeWidget).Schema(pl1ps.ID()).MustBuild(),
+	}).MustBuild()
+	pl2 := plugin.New().ID(pid2).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("a").Type(plugin.ExtensionTypeWidget).Schema(pl2ps.ID()).MustBuild(),
+	}).MustBuild()
+
+	wp := property.New().NewID().Scene(sid).Schema(pl1ps.ID()).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("x").Value(property.OptionalValueFrom(property.ValueTypeString.ValueFrom("a"))).Build(),
+		}).MustBuild(),
+	}).MustBuild()
+	prr := memory.NewPropertyWith(wp)
+
+	tid := accountsID.NewWorkspaceID()
+	wid := id.NewWidgetID()
+	sc := scene.New().ID(sid).Workspace(tid).Widgets(scene.NewWidgets([]*scene.Widget{
+		scene.MustWidget(wid, pid1, "a", wp.ID(), true, false),
+	}, nil)).MustBuild()
+	sc.Plugins().Add(scene.NewPlugin(pid1, nil))
+
+	uc := &Scene{
+		sceneRepo:          memory.NewSceneWith(sc),
+		pluginRepo:         memory.NewPluginWith(pl1, pl2),
+		propertyRepo:       prr,
+		propertySchemaRepo: memory.NewPropertySchemaWith(pl1ps, pl2ps),
+		nlsLayerRepo:       memory.NewNLSLayer(),
+		storytellingRepo:   memory.NewStorytelling(),
+		transaction:        &usecasex.NopTransaction{},
+	}
+	op := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{
+			ReadableWorkspaces: accountsID.WorkspaceIDList{tid},
+		},
+	}
+
+	report, err := uc.PluginUpgradeReport(ctx, sid, pid1, pid2, op)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(report.Targets))
+	assert.Equal(t, wid.String(), report.Targets[0].ID)
+	assert.Equal(t, 1, len(report.Targets[0].Dropped))
+	assert.Equal(t, property.DropReasonDeleted, report.Targets[0].Dropped[0].Reason)
+
+	// nothing is written
+	assert.True(t, sc.Plugins().Has(pid1))
+	p, _ := prr.FindByID(ctx, wp.ID())
+	assert.Equal(t, pl1ps.ID(), p.Schema())
+	f, _, _ := p.Field(property.PointFieldBySchemaGroup("default", "x"))
+	assert.Equal(t, property.ValueTypeString.ValueFrom("a"), f.Value())
+
+	_, err = uc.PluginUpgradeReport(ctx, sid, pid1, id.MustPluginID("plugin~1.0.2"), op)
+	assert.Equal(t, ErrPluginNotFound, err)
+
+	_, err = uc.PluginUpgradeReport(ctx, sid, pid1, pid2, &usecase.Operator{AcOperator: &accountsWorkspace.Operator{}})
+	assert.Equal(t, interfaces.ErrOperationDenied, err)
+}
+
 type mockPluginRegistry struct {
 	gateway.PluginRegistry
 }
@@ -0,0 +1,180 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type ShareToken struct {
+	common
+	shareTokenRepo   repo.ShareToken
+	projectRepo      repo.Project
+	storytellingRepo repo.Storytelling
+	transaction      usecasex.Transaction
+}
+
+func NewShareToken(r *repo.Container) interfaces.ShareToken {
+	return &ShareToken{
+		shareTokenRepo:   r.ShareToken,
+		projectRepo:      r.Project,
+		storytellingRepo: r.Storytelling,
+		transaction:      r.Transaction,
+	}
+}
+
+func (i *ShareToken) FetchByProject(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) ([]*publication.ShareToken, error) {
+	if _, err := i.projectScene(ctx, pid, operator); err != nil {
+		return nil, err
+	}
+	return i.shareTokenRepo.FindByProject(ctx, pid)
+}
+
+func (i *ShareToken) FetchByStory(ctx context.Context, sid id.StoryID, operator *usecase.Operator) ([]*publication.ShareToken, error) {
+	if _, err := i.storyScene(ctx, sid, operator); err != nil {
+		return nil, err
+	}
+	return i.shareTokenRepo.FindByStory(ctx, sid)
+}
+
+func (i *ShareToken) Create(ctx context.Context, param interfaces.CreateShareTokenParam, operator *usecase.Operator) (_ *publication.ShareToken, _ string, err error) {
+	if param.ExpiresAt != nil && !param.ExpiresAt.After(time.Now()) {
+		return nil, "", interfaces.ErrInvalidShareTokenExpiry
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	var sceneID id.SceneID
+	switch {
+	case param.ProjectID != nil && param.StoryID == nil:
+		sceneID, err = i.projectScene(ctx, *param.ProjectID, operator)
+	case param.StoryID != nil && param.ProjectID == nil:
+		sceneID, err = i.storyScene(ctx, *param.StoryID, operator)
+	default:
+		err = publication.ErrInvalidShareTokenTarget
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, hash, err := publication.GenerateShareToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var createdBy *accountsID.UserID
+	if operator != nil && operator.AcOperator != nil {
+		createdBy = operator.AcOperator.User
+	}
+
+	st, err := publication.NewShareToken().
+		NewID().
+		Scene(sceneID).
+		Project(param.ProjectID).
+		Story(param.StoryID).
+		Name(param.Name).
+		Hash(hash).
+		ExpiresAt(param.ExpiresAt).
+		CreatedBy(createdBy).
+		Build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := i.shareTokenRepo.Save(ctx, st); err != nil {
+		return nil, "", err
+	}
+
+	tx.Commit()
+	return st, token, nil
+}
+
+func (i *ShareToken) Revoke(ctx context.Context, tid id.ShareTokenID, operator *usecase.Operator) (*publication.ShareToken, error) {
+	return i.update(ctx, tid, operator, func(st *publication.ShareToken) error {
+		st.Revoke(time.Now())
+		return nil
+	})
+}
+
+func (i *ShareToken) UpdateExpiry(ctx context.Context, tid id.ShareTokenID, expiresAt *time.Time, operator *usecase.Operator) (*publication.ShareToken, error) {
+	return i.update(ctx, tid, operator, func(st *publication.ShareToken) error {
+		if expiresAt != nil && !expiresAt.After(time.Now()) {
+			return interfaces.ErrInvalidShareTokenExpiry
+		}
+		st.SetExpiresAt(expiresAt)
+		return nil
+	})
+}
+
+func (i *ShareToken) update(ctx context.Context, tid id.ShareTokenID, operator *usecase.Operator, f func(*publication.ShareToken) error) (_ *publication.ShareToken, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	st, err := i.shareTokenRepo.FindByID(ctx, tid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.CanWriteScene(st.Scene(), operator); err != nil {
+		return nil, err
+	}
+
+	if err := f(st); err != nil {
+		return nil, err
+	}
+
+	if err := i.shareTokenRepo.Save(ctx, st); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return st, nil
+}
+
+func (i *ShareToken) projectScene(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (id.SceneID, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return id.SceneID{}, err
+	}
+	if err := i.CanWriteScene(prj.Scene(), operator); err != nil {
+		return id.SceneID{}, err
+	}
+	return prj.Scene(), nil
+}
+
+func (i *ShareToken) storyScene(ctx context.Context, sid id.StoryID, operator *usecase.Operator) (id.SceneID, error) {
+	story, err := i.storytellingRepo.FindByID(ctx, sid)
+	if err != nil {
+		return id.SceneID{}, err
+	}
+	if err := i.CanWriteScene(story.Scene(), operator); err != nil {
+		return id.SceneID{}, err
+	}
+	return story.Scene(), nil
+}
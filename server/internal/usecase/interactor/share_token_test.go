@@ -0,0 +1,74 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareToken(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+
+	sid := id.NewSceneID()
+	prj := project.New().NewID().Workspace(accountsID.NewWorkspaceID()).Scene(sid).
+		Alias("limited").PublishmentStatus(project.PublishmentStatusLimited).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, prj))
+
+	uc := NewShareToken(db)
+	published := NewPublished(db.Project, db.Storytelling, db.ShareToken, db.PublicationVersion, nil, "")
+	owner := &usecase.Operator{WritableScenes: id.SceneIDList{sid}}
+	attacker := &usecase.Operator{WritableScenes: id.SceneIDList{id.NewSceneID()}}
+
+	_, _, err := uc.Create(ctx, interfaces.CreateShareTokenParam{ProjectID: prj.ID().Ref()}, attacker)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	_, _, err = uc.Create(ctx, interfaces.CreateShareTokenParam{
+		ProjectID: prj.ID().Ref(),
+		ExpiresAt: lo.ToPtr(time.Now().Add(-time.Hour)),
+	}, owner)
+	assert.ErrorIs(t, err, interfaces.ErrInvalidShareTokenExpiry)
+
+	_, _, err = uc.Create(ctx, interfaces.CreateShareTokenParam{}, owner)
+	assert.ErrorIs(t, err, publication.ErrInvalidShareTokenTarget)
+
+	st, token, err := uc.Create(ctx, interfaces.CreateShareTokenParam{
+		ProjectID: prj.ID().Ref(),
+		Name:      "stakeholder",
+	}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, "stakeholder", st.Name())
+	assert.Equal(t, publication.HashShareToken(token), st.Hash())
+	assert.NoError(t, published.ValidateShareToken(ctx, "limited", token))
+
+	list, err := uc.FetchByProject(ctx, prj.ID(), owner)
+	assert.NoError(t, err)
+	assert.Equal(t, []*publication.ShareToken{st}, list)
+	_, err = uc.FetchByProject(ctx, prj.ID(), attacker)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	expiresAt := time.Now().Add(time.Hour)
+	st, err = uc.UpdateExpiry(ctx, st.ID(), &expiresAt, owner)
+	assert.NoError(t, err)
+	assert.True(t, expiresAt.Equal(*st.ExpiresAt()))
+	_, err = uc.UpdateExpiry(ctx, st.ID(), lo.ToPtr(time.Now().Add(-time.Hour)), owner)
+	assert.ErrorIs(t, err, interfaces.ErrInvalidShareTokenExpiry)
+
+	_, err = uc.Revoke(ctx, st.ID(), attacker)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	st, err = uc.Revoke(ctx, st.ID(), owner)
+	assert.NoError(t, err)
+	assert.True(t, st.IsRevoked())
+	assert.ErrorIs(t, published.ValidateShareToken(ctx, "limited", token), interfaces.ErrInvalidShareToken)
+}
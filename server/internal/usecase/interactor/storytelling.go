@@ -17,6 +17,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/publication"
 	"github.com/reearth/reearth/server/pkg/scene"
 	"github.com/reearth/reearth/server/pkg/scene/builder"
 	"github.com/reearth/reearth/server/pkg/storytelling"
@@ -45,6 +46,9 @@ type Storytelling struct {
 	policyChecker    gateway.PolicyChecker
 
 	propertySchemaRepo repo.PropertySchema
+	publicationVersion repo.PublicationVersion
+	webhooks           webhookEmitter
+	sceneEvents        sceneEvents
 }
 
 func NewStorytelling(r *repo.Container, gr *gateway.Container) interfaces.Storytelling {
@@ -62,6 +66,9 @@ func NewStorytelling(r *repo.Container, gr *gateway.Container) interfaces.Storyt
 		layerStyles:        r.Style,
 		policyChecker:      gr.PolicyChecker,
 		propertySchemaRepo: r.PropertySchema,
+		publicationVersion: r.PublicationVersion,
+		webhooks:           newWebhookEmitter(r),
+		sceneEvents:        newSceneEvents(gr),
 	}
 }
 
@@ -121,8 +128,14 @@ func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInp
 		return nil, err
 	}
 
-	// TODO: Handel ordering
-	if err := i.storytellingRepo.Filtered(filter).Save(ctx, *story); err != nil {
+	stories, err := i.findStoriesByScene(ctx, inp.SceneID)
+	if err != nil {
+		return nil, err
+	}
+	stories = stories.AddAt(story, inp.Index)
+	stories.Reindex()
+
+	if err := i.storytellingRepo.Filtered(filter).SaveAll(ctx, stories); err != nil {
 		return nil, err
 	}
 
@@ -132,6 +145,7 @@ func (i *Storytelling) Create(ctx context.Context, inp interfaces.CreateStoryInp
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventStoryAdded, Scene: story.Scene(), Story: lo.ToPtr(story.Id())})
 	return story, nil
 }
 
@@ -193,7 +207,18 @@ func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInp
 	}
 
 	if inp.IsBasicAuthActive != nil {
-		if err := story.SetBasicAuth(*inp.IsBasicAuthActive, inp.BasicAuthUsername, inp.BasicAuthPassword); err != nil {
+		password := inp.BasicAuthPassword
+		if password != nil && *password == "" {
+			// the stored password is never sent to clients, so an empty password keeps the current one
+			password = lo.ToPtr(story.BasicAuthPassword())
+		} else if password != nil {
+			hashed, err := publication.HashBasicAuthPassword(*password)
+			if err != nil {
+				return nil, err
+			}
+			password = &hashed
+		}
+		if err := story.SetBasicAuth(*inp.IsBasicAuthActive, inp.BasicAuthUsername, password); err != nil {
 			return nil, err
 		}
 	}
@@ -218,7 +243,11 @@ func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInp
 		story.SetTrackingID(*inp.TrackingID)
 	}
 
-	// TODO: Handel ordering
+	if inp.Index != nil {
+		if _, err := i.moveStory(ctx, story, *inp.Index); err != nil {
+			return nil, err
+		}
+	}
 
 	err = i.storytellingRepo.Save(ctx, *story)
 	if err != nil {
@@ -231,6 +260,7 @@ func (i *Storytelling) Update(ctx context.Context, inp interfaces.UpdateStoryInp
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventStoryUpdated, Scene: story.Scene(), Story: lo.ToPtr(story.Id())})
 	return story, nil
 }
 
@@ -271,13 +301,24 @@ func (i *Storytelling) Remove(ctx context.Context, inp interfaces.RemoveStoryInp
 		return nil, err
 	}
 
-	// TODO: Handle ordering
+	stories, err := i.findStoriesByScene(ctx, story.Scene())
+	if err != nil {
+		return nil, err
+	}
+	if stories = stories.Remove(inp.StoryID); len(stories) > 0 {
+		stories.Reindex()
+		if err := i.storytellingRepo.SaveAll(ctx, stories); err != nil {
+			return nil, err
+		}
+	}
 
 	err = updateProjectUpdatedAtByScene(ctx, story.Scene(), i.projectRepo, i.sceneRepo)
 	if err != nil {
 		return nil, err
 	}
 
+	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventStoryRemoved, Scene: story.Scene(), Story: lo.ToPtr(story.Id())})
 	return &inp.StoryID, nil
 }
 
@@ -353,6 +394,11 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 		return nil, err
 	}
 
+	return i.publish(ctx, story, inp, op)
+}
+
+// publish publishes the story without checking the operator, so it is also used by publish schedules.
+func (i *Storytelling) publish(ctx context.Context, story *storytelling.Story, inp interfaces.PublishStoryInput, op *usecase.Operator) (*storytelling.Story, error) {
 	sc, err := i.sceneRepo.FindByID(ctx, story.Scene())
 	if err != nil {
 		return nil, err
@@ -417,20 +463,35 @@ func (i *Storytelling) Publish(ctx context.Context, inp interfaces.PublishStoryI
 	// Phase 2: GCS upload outside the transaction. Previously this ran inside
 	// the transaction and held document locks for the entire upload duration,
 	// causing MongoDB WriteConflict when concurrent publish calls overlapped.
+	var version *publication.Version
 	if story.PublishmentStatus() != storytelling.PublishmentStatusPrivate {
 		if err := i.uploadPublishStory(ctx, story, op); err != nil {
 			return nil, err
 		}
 		story.SetPublishedAt(time.Now())
+
+		if version, err = i.snapshotPublishStory(ctx, story, inp.Message, op); err != nil {
+			return nil, err
+		}
+		story.SetPublishedVersion(version.Version())
 	}
 
 	// Phase 3: short transaction containing only the DB saves, with retry on
 	// TransientTransactionError. Each attempt gets a fresh session.
 	if err := runWithTxRetry(ctx, i.transaction, 3, func(txCtx context.Context) error {
+		if version != nil {
+			if err := i.publicationVersion.Save(txCtx, version); err != nil {
+				return err
+			}
+		}
 		if err := i.storytellingRepo.Save(txCtx, *story); err != nil {
 			return err
 		}
-		return updateProjectUpdatedAtByScene(txCtx, story.Scene(), i.projectRepo, i.sceneRepo)
+		if err := updateProjectUpdatedAtByScene(txCtx, story.Scene(), i.projectRepo, i.sceneRepo); err != nil {
+			return err
+		}
+		i.webhooks.emitStoryPublish(txCtx, sc.Workspace(), sc.Project(), story)
+		return nil
 	}); err != nil {
 		return nil, err
 	}
@@ -474,6 +535,11 @@ func (i *Storytelling) uploadPublishStory(ctx context.Context, story *storytelli
 		return err
 	}
 
+	stories, err := i.findStoriesByScene(ctx, story.Scene())
+	if err != nil {
+		return err
+	}
+
 	// publish
 	r, w := io.Pipe()
 	// See the matching comment in Project.uploadPublishScene.
@@ -495,6 +561,7 @@ func (i *Storytelling) uploadPublishStory(ctx context.Context, story *storytelli
 			WithNLSLayers(&nlsLayers).
 			WithLayerStyle(layerStyles).
 			WithStory(story).
+			WithStories(stories).
 			Build(ctx, w, time.Now(), true, story.EnableGa(), story.TrackingID())
 	}()
 
@@ -506,8 +573,108 @@ func (i *Storytelling) uploadPublishStory(ctx context.Context, story *storytelli
 	return nil
 }
 
-func (i *Storytelling) Move(_ context.Context, _ interfaces.MoveStoryInput, _ *usecase.Operator) (*id.StoryID, int, error) {
-	return nil, 0, rerror.ErrNotImplemented
+// snapshotPublishStory copies the built story just uploaded to the snapshot of a new publication version.
+func (i *Storytelling) snapshotPublishStory(ctx context.Context, story *storytelling.Story, message string, op *usecase.Operator) (*publication.Version, error) {
+	v, err := newPublicationVersion(ctx, i.publicationVersion, story.Scene(), nil, story.Id().Ref(), story.Alias(), message, op)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := i.file.ReadStoryFile(ctx, story.Alias())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+
+	if err := i.file.UploadPublicationSnapshot(ctx, r, v.SnapshotName()); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (i *Storytelling) Move(ctx context.Context, inp interfaces.MoveStoryInput, op *usecase.Operator) (_ *id.StoryID, _ int, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	story, err := i.storytellingRepo.FindByID(ctx, inp.StoryID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := i.CanWriteScene(story.Scene(), op); err != nil {
+		return nil, 0, interfaces.ErrOperationDenied
+	}
+
+	sc, err := i.sceneRepo.FindByID(ctx, story.Scene())
+	if err != nil {
+		return nil, 0, err
+	}
+	operationAllowed, err := i.policyChecker.CheckPolicy(ctx, gateway.CreateGeneralOperationAllowedCheckRequest(sc.Workspace()))
+	if err != nil {
+		return nil, 0, err
+	}
+	if !operationAllowed.Allowed {
+		return nil, 0, visualizer.ErrorWithCallerLogging(ctx, "operation is disabled by over used seat", errors.New("operation is disabled by over used seat"))
+	}
+
+	index, err := i.moveStory(ctx, story, inp.Index)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = updateProjectUpdatedAtByScene(ctx, story.Scene(), i.projectRepo, i.sceneRepo)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventStoryUpdated, Scene: story.Scene(), Story: lo.ToPtr(story.Id())})
+	return &inp.StoryID, index, nil
+}
+
+// moveStory moves the story to the index among the stories of its scene, saves the new order and returns the index of the story.
+func (i *Storytelling) moveStory(ctx context.Context, story *storytelling.Story, index int) (int, error) {
+	stories, err := i.findStoriesByScene(ctx, story.Scene())
+	if err != nil {
+		return 0, err
+	}
+	// use the given story since it may have unsaved changes
+	stories = lo.Map(stories, func(s *storytelling.Story, _ int) *storytelling.Story {
+		if s.Id() == story.Id() {
+			return story
+		}
+		return s
+	})
+
+	stories, index = stories.Move(story.Id(), index)
+	if index < 0 {
+		return 0, rerror.ErrNotFound
+	}
+	stories.Reindex()
+
+	if err := i.storytellingRepo.SaveAll(ctx, stories); err != nil {
+		return 0, err
+	}
+	return index, nil
+}
+
+func (i *Storytelling) findStoriesByScene(ctx context.Context, sid id.SceneID) (storytelling.StoryList, error) {
+	stories, err := i.storytellingRepo.FindByScene(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	if stories == nil {
+		return nil, nil
+	}
+	return stories.Sorted(), nil
 }
 
 func (i *Storytelling) CreatePage(ctx context.Context, inp interfaces.CreatePageParam, op *usecase.Operator) (*storytelling.Story, *storytelling.Page, error) {
@@ -588,6 +755,7 @@ func (i *Storytelling) CreatePage(ctx context.Context, inp interfaces.CreatePage
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventPageAdded, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id())})
 	return story, page, nil
 }
 
@@ -658,6 +826,7 @@ func (i *Storytelling) UpdatePage(ctx context.Context, inp interfaces.UpdatePage
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventPageUpdated, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id())})
 	return story, page, nil
 }
 
@@ -712,6 +881,7 @@ func (i *Storytelling) RemovePage(ctx context.Context, inp interfaces.RemovePage
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventPageRemoved, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id())})
 	return story, page.Id().Ref(), nil
 }
 
@@ -766,6 +936,7 @@ func (i *Storytelling) MovePage(ctx context.Context, inp interfaces.MovePagePara
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventPageUpdated, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id())})
 	return story, page, inp.Index, nil
 }
 
@@ -821,6 +992,7 @@ func (i *Storytelling) DuplicatePage(ctx context.Context, inp interfaces.Duplica
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventPageAdded, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(dupPage.Id())})
 	return story, dupPage, nil
 }
 
@@ -883,6 +1055,7 @@ func (i *Storytelling) AddPageLayer(ctx context.Context, inp interfaces.PageLaye
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventPageUpdated, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id())})
 	return story, page, nil
 }
 
@@ -945,6 +1118,7 @@ func (i *Storytelling) RemovePageLayer(ctx context.Context, inp interfaces.PageL
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventPageUpdated, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id())})
 	return story, page, nil
 }
 
@@ -1024,6 +1198,7 @@ func (i *Storytelling) CreateBlock(ctx context.Context, inp interfaces.CreateBlo
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventBlockAdded, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id()), Block: lo.ToPtr(block.ID())})
 	return story, page, block, 1, err
 }
 
@@ -1086,6 +1261,7 @@ func (i *Storytelling) RemoveBlock(ctx context.Context, inp interfaces.RemoveBlo
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventBlockRemoved, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id()), Block: &inp.BlockID})
 	return story, page, &inp.BlockID, nil
 }
 
@@ -1143,6 +1319,7 @@ func (i *Storytelling) MoveBlock(ctx context.Context, inp interfaces.MoveBlockPa
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, op, scene.Event{Type: scene.EventBlockUpdated, Scene: story.Scene(), Story: lo.ToPtr(story.Id()), Page: lo.ToPtr(page.Id()), Block: &inp.BlockID})
 	return story, page, &inp.BlockID, inp.Index, nil
 }
 
@@ -0,0 +1,74 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorytelling_Order(t *testing.T) {
+	ctx := context.Background()
+	env := setupStorytellingTestEnv(ctx, t)
+	env.mockPolicyChecker.On("CheckPolicy", mock.Anything, mock.Anything).
+		Return(&gateway.PolicyCheckResponse{Allowed: true}, nil).
+		Maybe()
+
+	prj := project.New().NewID().Workspace(env.wsID).Name("Test Project").MustBuild()
+	_ = env.db.Project.Save(ctx, prj)
+	sc := lo.Must(scene.New().NewID().Workspace(env.wsID).Project(prj.ID()).Build())
+	_ = env.db.Scene.Save(ctx, sc)
+	env.operator.WritableScenes = []id.SceneID{sc.ID()}
+
+	create := func(title string, index *int) *storytelling.Story {
+		s, err := env.storytellingUC.Create(ctx, interfaces.CreateStoryInput{SceneID: sc.ID(), Title: title, Index: index}, env.operator)
+		require.NoError(t, err)
+		return s
+	}
+	titles := func() []string {
+		stories, err := env.storytellingUC.FetchByScene(ctx, sc.ID(), env.operator)
+		require.NoError(t, err)
+		return lo.Map(*stories, func(s *storytelling.Story, i int) string {
+			assert.Equal(t, i, s.Index())
+			return s.Title()
+		})
+	}
+
+	a := create("a", nil)
+	b := create("b", nil)
+	create("c", lo.ToPtr(1))
+	assert.Equal(t, []string{"a", "c", "b"}, titles())
+
+	sid, index, err := env.storytellingUC.Move(ctx, interfaces.MoveStoryInput{SceneID: sc.ID(), StoryID: a.Id(), Index: 2}, env.operator)
+	assert.NoError(t, err)
+	assert.Equal(t, a.Id(), *sid)
+	assert.Equal(t, 2, index)
+	assert.Equal(t, []string{"c", "b", "a"}, titles())
+
+	// out of range moves the story to the end
+	_, index, err = env.storytellingUC.Move(ctx, interfaces.MoveStoryInput{SceneID: sc.ID(), StoryID: b.Id(), Index: 10}, env.operator)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, index)
+	assert.Equal(t, []string{"c", "a", "b"}, titles())
+
+	_, err = env.storytellingUC.Update(ctx, interfaces.UpdateStoryInput{SceneID: sc.ID(), StoryID: b.Id(), Title: lo.ToPtr("b2"), Index: lo.ToPtr(0)}, env.operator)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b2", "c", "a"}, titles())
+
+	_, err = env.storytellingUC.Remove(ctx, interfaces.RemoveStoryInput{SceneID: sc.ID(), StoryID: b.Id()}, env.operator)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "a"}, titles())
+
+	env.operator.WritableScenes = nil
+	_, _, err = env.storytellingUC.Move(ctx, interfaces.MoveStoryInput{SceneID: sc.ID(), StoryID: a.Id(), Index: 0}, env.operator)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+}
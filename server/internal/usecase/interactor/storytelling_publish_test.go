@@ -46,20 +46,21 @@ func setupStorytellingTestEnv(ctx context.Context, t *testing.T) *storytellingTe
 
 	// Create repositories
 	repos := &repo.Container{
-		User:            db.User,
-		Workspace:       db.Workspace,
-		Project:         db.Project,
-		ProjectMetadata: db.ProjectMetadata,
-		Scene:           db.Scene,
-		Property:        db.Property,
-		PropertySchema:  db.PropertySchema,
-		Asset:           db.Asset,
-		Plugin:          db.Plugin,
-		NLSLayer:        db.NLSLayer,
-		Style:           db.Style,
-		Storytelling:    db.Storytelling,
-		SceneLock:       db.SceneLock,
-		Transaction:     &usecasex.NopTransaction{},
+		User:               db.User,
+		Workspace:          db.Workspace,
+		Project:            db.Project,
+		ProjectMetadata:    db.ProjectMetadata,
+		Scene:              db.Scene,
+		Property:           db.Property,
+		PropertySchema:     db.PropertySchema,
+		Asset:              db.Asset,
+		Plugin:             db.Plugin,
+		NLSLayer:           db.NLSLayer,
+		Style:              db.Style,
+		Storytelling:       db.Storytelling,
+		SceneLock:          db.SceneLock,
+		PublicationVersion: db.PublicationVersion,
+		Transaction:        &usecasex.NopTransaction{},
 	}
 
 	// Create gateways
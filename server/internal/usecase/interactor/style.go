@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/internal/usecase/repo"
 	"github.com/reearth/reearth/server/pkg/id"
@@ -13,6 +14,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/scene/builder"
 	"github.com/reearth/reearthx/idx"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 )
 
 type Style struct {
@@ -23,9 +25,10 @@ type Style struct {
 	sceneRepo     repo.Scene
 	sceneLockRepo repo.SceneLock
 	transaction   usecasex.Transaction
+	sceneEvents   sceneEvents
 }
 
-func NewStyle(r *repo.Container) interfaces.Style {
+func NewStyle(r *repo.Container, gr *gateway.Container) interfaces.Style {
 	return &Style{
 		commonSceneLock: commonSceneLock{sceneLockRepo: r.SceneLock},
 		styleRepo:       r.Style,
@@ -33,6 +36,7 @@ func NewStyle(r *repo.Container) interfaces.Style {
 		sceneRepo:       r.Scene,
 		sceneLockRepo:   r.SceneLock,
 		transaction:     r.Transaction,
+		sceneEvents:     newSceneEvents(gr),
 	}
 }
 
@@ -80,6 +84,7 @@ func (i *Style) AddStyle(ctx context.Context, param interfaces.AddStyleInput, op
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventStyleAdded, Scene: style.Scene(), Style: lo.ToPtr(style.ID())})
 	return style, nil
 }
 
@@ -126,6 +131,7 @@ func (i *Style) UpdateStyle(ctx context.Context, param interfaces.UpdateStyleInp
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventStyleUpdated, Scene: style.Scene(), Style: lo.ToPtr(style.ID())})
 	return style, nil
 }
 
@@ -166,6 +172,7 @@ func (i *Style) RemoveStyle(ctx context.Context, styleID id.StyleID, operator *u
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventStyleRemoved, Scene: s.Scene(), Style: &styleID})
 	return styleID, nil
 }
 
@@ -203,6 +210,7 @@ func (i *Style) DuplicateStyle(ctx context.Context, styleID id.StyleID, operator
 	}
 
 	tx.Commit()
+	i.sceneEvents.publish(ctx, operator, scene.Event{Type: scene.EventStyleAdded, Scene: duplicatedStyle.Scene(), Style: lo.ToPtr(duplicatedStyle.ID())})
 	return duplicatedStyle, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
 	"github.com/reearth/reearth/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
@@ -30,7 +31,7 @@ func TestStyle_CrossTenantIDOR(t *testing.T) {
 	victimScene, _ := scene.New().NewID().Workspace(accountsID.NewWorkspaceID()).Project(prj.ID()).Build()
 	_ = db.Scene.Save(ctx, victimScene)
 
-	uc := NewStyle(db)
+	uc := NewStyle(db, &gateway.Container{})
 
 	attacker := &usecase.Operator{
 		WritableScenes: id.SceneIDList{id.NewSceneID()}, // some other scene, not victimScene
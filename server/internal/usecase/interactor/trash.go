@@ -0,0 +1,269 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/trash"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
+)
+
+const trashPurgeLockName = "trashPurge"
+
+type Trash struct {
+	common
+	projectRepo      repo.Project
+	trashPolicyRepo  repo.TrashPolicy
+	trashPurgeRepo   repo.TrashPurge
+	lock             repo.Lock
+	transaction      usecasex.Transaction
+	project          *Project
+	defaultRetention time.Duration
+}
+
+// NewTrash returns the trash usecase. defaultRetention applies to the workspaces that have not configured their policy.
+func NewTrash(r *repo.Container, gr *gateway.Container, defaultRetention time.Duration) interfaces.Trash {
+	return &Trash{
+		projectRepo:      r.Project,
+		trashPolicyRepo:  r.TrashPolicy,
+		trashPurgeRepo:   r.TrashPurge,
+		lock:             r.Lock,
+		transaction:      r.Transaction,
+		project:          NewProject(r, gr).(*Project),
+		defaultRetention: defaultRetention,
+	}
+}
+
+func (i *Trash) FindPolicy(ctx context.Context, wid accountsID.WorkspaceID, operator *usecase.Operator) (*trash.Policy, error) {
+	if err := i.CanReadWorkspace(wid, operator); err != nil {
+		return nil, err
+	}
+	return i.policy(ctx, wid)
+}
+
+func (i *Trash) UpdatePolicy(ctx context.Context, wid accountsID.WorkspaceID, retention time.Duration, operator *usecase.Operator) (_ *trash.Policy, err error) {
+	if err := i.canManage(wid, operator); err != nil {
+		return nil, err
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	p, err := i.policy(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.SetRetention(retention, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := i.trashPolicyRepo.Save(ctx, p); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return p, nil
+}
+
+func (i *Trash) FindPurges(ctx context.Context, wid accountsID.WorkspaceID, operator *usecase.Operator) ([]*trash.Purge, error) {
+	if err := i.CanReadWorkspace(wid, operator); err != nil {
+		return nil, err
+	}
+	return i.trashPurgeRepo.FindByWorkspace(ctx, wid)
+}
+
+func (i *Trash) Restore(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (_ *project.Project, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	prj, err := i.trashedProject(ctx, pid, i.CanWriteWorkspace, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	prj.SetDeleted(false)
+	if err := i.projectRepo.Save(ctx, prj); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return prj, nil
+}
+
+func (i *Trash) Purge(ctx context.Context, pid id.ProjectID, operator *usecase.Operator) (_ *trash.Purge, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	// purging cannot be undone, so it is limited to the maintainers like the retention policy
+	prj, err := i.trashedProject(ctx, pid, i.canManage, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	var purgedBy *accountsID.UserID
+	if operator != nil && operator.AcOperator != nil {
+		purgedBy = operator.AcOperator.User
+	}
+
+	p, err := i.purge(ctx, prj, trash.PurgeReasonManual, purgedBy, operator)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return p, nil
+}
+
+func (i *Trash) PurgeExpired(ctx context.Context, now time.Time) error {
+	// only one server instance purges the trash at a time
+	if err := i.lock.Lock(ctx, trashPurgeLockName); err != nil {
+		if errors.Is(err, repo.ErrAlreadyLocked) || errors.Is(err, repo.ErrFailedToLock) {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		if err := i.lock.Unlock(ctx, trashPurgeLockName); err != nil {
+			log.Errorfc(ctx, "trash: failed to unlock: %v", err)
+		}
+	}()
+
+	// the retention differs by workspace, so every project in the trash is a candidate
+	projects, err := i.projectRepo.FindDeletedBefore(ctx, now)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return nil
+	}
+
+	wids := lo.Uniq(lo.Map(projects, func(p *project.Project, _ int) accountsID.WorkspaceID { return p.Workspace() }))
+	policies, err := i.trashPolicyRepo.FindByWorkspaces(ctx, wids)
+	if err != nil {
+		return err
+	}
+	policyOf := lo.SliceToMap(policies, func(p *trash.Policy) (accountsID.WorkspaceID, *trash.Policy) {
+		return p.Workspace(), p
+	})
+
+	for _, prj := range projects {
+		policy, ok := policyOf[prj.Workspace()]
+		if !ok {
+			policy = i.defaultPolicy(prj.Workspace())
+		}
+		if !policy.IsExpired(*prj.DeletedAt(), now) {
+			continue
+		}
+
+		// each project is purged in its own transaction so that a failure does not keep the others in the trash
+		if err := runWithTxRetry(ctx, i.transaction, 0, func(ctx context.Context) error {
+			_, err := i.purge(ctx, prj, trash.PurgeReasonExpired, nil, nil)
+			return err
+		}); err != nil {
+			log.Warnfc(ctx, "trash: failed to purge project %s: %v", prj.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+// purge hard-deletes the project with its scene, assets, files and metadata, and records the purge.
+func (i *Trash) purge(ctx context.Context, prj *project.Project, reason trash.PurgeReason, purgedBy *accountsID.UserID, operator *usecase.Operator) (*trash.Purge, error) {
+	p, err := trash.NewPurge().
+		NewID().
+		Workspace(prj.Workspace()).
+		Project(prj.ID()).
+		ProjectName(prj.Name()).
+		Reason(reason).
+		DeletedAt(prj.DeletedAt()).
+		PurgedBy(purgedBy).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "trash: purging project %s (%s)", prj.ID(), reason)
+	if err := i.project.projectDeleter().Delete(ctx, prj, true, operator); err != nil {
+		return nil, err
+	}
+
+	if err := i.trashPurgeRepo.Save(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (i *Trash) trashedProject(ctx context.Context, pid id.ProjectID, can func(accountsID.WorkspaceID, *usecase.Operator) error, operator *usecase.Operator) (*project.Project, error) {
+	prj, err := i.projectRepo.FindByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if err := can(prj.Workspace(), operator); err != nil {
+		return nil, err
+	}
+	if !prj.IsDeleted() {
+		return nil, interfaces.ErrProjectNotInTrash
+	}
+	return prj, nil
+}
+
+func (i *Trash) canManage(wid accountsID.WorkspaceID, operator *usecase.Operator) error {
+	if err := i.OnlyOperator(operator); err != nil {
+		return err
+	}
+	if operator.AcOperator == nil || !operator.IsMaintainingWorkspace(wid) {
+		return interfaces.ErrOperationDenied
+	}
+	return nil
+}
+
+func (i *Trash) policy(ctx context.Context, wid accountsID.WorkspaceID) (*trash.Policy, error) {
+	p, err := i.trashPolicyRepo.FindByWorkspace(ctx, wid)
+	if errors.Is(err, rerror.ErrNotFound) {
+		return i.defaultPolicy(wid), nil
+	}
+	return p, err
+}
+
+func (i *Trash) defaultPolicy(wid accountsID.WorkspaceID) *trash.Policy {
+	// the default retention comes from the config and is never negative
+	p, _ := trash.NewPolicy(wid, max(i.defaultRetention, 0), time.Time{})
+	return p
+}
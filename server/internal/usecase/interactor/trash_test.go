@@ -0,0 +1,182 @@
+package interactor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/infrastructure/fs"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/publication"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/trash"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	file := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	day := 24 * time.Hour
+	uc := NewTrash(db, &gateway.Container{File: file}, 30*day)
+
+	now := time.Now()
+	wsID := accountsID.NewWorkspaceID()
+	ws2ID := accountsID.NewWorkspaceID()
+	trashed := func(ws accountsID.WorkspaceID, name string, deletedAt time.Time) *project.Project {
+		prj := project.New().NewID().Workspace(ws).Name(name).Deleted(true).DeletedAt(&deletedAt).MustBuild()
+		require.NoError(t, db.Project.Save(ctx, prj))
+		sc := scene.New().NewID().Workspace(ws).Project(prj.ID()).MustBuild()
+		require.NoError(t, db.Scene.Save(ctx, sc))
+		return prj
+	}
+	expired := trashed(wsID, "expired", now.Add(-31*day))
+	recent := trashed(wsID, "recent", now.Add(-1*day))
+	kept := trashed(ws2ID, "kept", now.Add(-365*day))
+	active := project.New().NewID().Workspace(wsID).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, active))
+
+	uid := accountsID.NewUserID()
+	op := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{User: &uid, MaintainableWorkspaces: accountsID.WorkspaceIDList{wsID, ws2ID}},
+	}
+	writer := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{User: &uid, WritableWorkspaces: accountsID.WorkspaceIDList{wsID, ws2ID}},
+	}
+	reader := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{ReadableWorkspaces: accountsID.WorkspaceIDList{wsID}},
+	}
+
+	// workspaces without a policy use the default retention
+	p, err := uc.FindPolicy(ctx, wsID, reader)
+	require.NoError(t, err)
+	assert.Equal(t, 30*day, p.Retention())
+	_, err = uc.UpdatePolicy(ctx, ws2ID, 0, reader)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	_, err = uc.UpdatePolicy(ctx, ws2ID, 0, writer)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	_, err = uc.UpdatePolicy(ctx, ws2ID, -day, op)
+	assert.ErrorIs(t, err, trash.ErrInvalidRetention)
+	p, err = uc.UpdatePolicy(ctx, ws2ID, 0, op)
+	require.NoError(t, err)
+	assert.True(t, p.KeepsForever())
+
+	_, err = uc.Restore(ctx, active.ID(), op)
+	assert.ErrorIs(t, err, interfaces.ErrProjectNotInTrash)
+	_, err = uc.Purge(ctx, recent.ID(), reader)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	_, err = uc.Purge(ctx, recent.ID(), writer)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	// writers can restore the projects they can no longer purge
+	restored, err := uc.Restore(ctx, recent.ID(), writer)
+	require.NoError(t, err)
+	assert.False(t, restored.IsDeleted())
+	assert.Nil(t, restored.DeletedAt())
+
+	// only the projects past the retention of their workspaces are purged
+	require.NoError(t, uc.PurgeExpired(ctx, now))
+	_, err = db.Project.FindByID(ctx, expired.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err = db.Scene.FindByProject(ctx, expired.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err = db.Project.FindByID(ctx, kept.ID())
+	assert.NoError(t, err)
+
+	purge, err := uc.Purge(ctx, kept.ID(), op)
+	require.NoError(t, err)
+	assert.Equal(t, &uid, purge.PurgedBy())
+	_, err = db.Project.FindByID(ctx, kept.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	purges, err := uc.FindPurges(ctx, wsID, reader)
+	require.NoError(t, err)
+	require.Len(t, purges, 1)
+	assert.Equal(t, expired.ID(), purges[0].Project())
+	assert.Equal(t, "expired", purges[0].ProjectName())
+	assert.Equal(t, trash.PurgeReasonExpired, purges[0].Reason())
+	assert.Nil(t, purges[0].PurgedBy())
+	purges, err = uc.FindPurges(ctx, ws2ID, op)
+	require.NoError(t, err)
+	require.Len(t, purges, 1)
+	assert.Equal(t, trash.PurgeReasonManual, purges[0].Reason())
+}
+
+func TestTrash_Purge_PublicationData(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	file := lo.Must(fs.NewFile(afero.NewMemMapFs(), "https://example.com"))
+	uc := NewTrash(db, &gateway.Container{File: file}, 0)
+
+	wsID := accountsID.NewWorkspaceID()
+	uid := accountsID.NewUserID()
+	op := &usecase.Operator{
+		AcOperator: &accountsWorkspace.Operator{User: &uid, MaintainableWorkspaces: accountsID.WorkspaceIDList{wsID}},
+	}
+
+	prj := project.New().NewID().Workspace(wsID).Deleted(true).MustBuild()
+	require.NoError(t, db.Project.Save(ctx, prj))
+	sc := scene.New().NewID().Workspace(wsID).Project(prj.ID()).MustBuild()
+	require.NoError(t, db.Scene.Save(ctx, sc))
+	st := storytelling.NewStory().NewID().Project(prj.ID()).Scene(sc.ID()).
+		Alias("purged-story").Status(storytelling.PublishmentStatusPublic).MustBuild()
+	require.NoError(t, db.Storytelling.Save(ctx, *st))
+	require.NoError(t, file.UploadStory(ctx, strings.NewReader("{}"), st.Alias()))
+
+	pv := publication.NewVersion().NewID().Scene(sc.ID()).Project(lo.ToPtr(prj.ID())).Version(1).MustBuild()
+	sv := publication.NewVersion().NewID().Scene(sc.ID()).Story(lo.ToPtr(st.Id())).Version(1).MustBuild()
+	for _, v := range []*publication.Version{pv, sv} {
+		require.NoError(t, db.PublicationVersion.Save(ctx, v))
+		require.NoError(t, file.UploadPublicationSnapshot(ctx, strings.NewReader("{}"), v.SnapshotName()))
+	}
+	require.NoError(t, db.ShareToken.Save(ctx, publication.NewShareToken().NewID().Scene(sc.ID()).
+		Story(lo.ToPtr(st.Id())).Hash("hash").MustBuild()))
+	require.NoError(t, db.PublishSchedule.Save(ctx, publication.NewSchedule().NewID().Scene(sc.ID()).
+		Project(lo.ToPtr(prj.ID())).UnpublishAt(lo.ToPtr(time.Now().Add(time.Hour))).MustBuild()))
+	lid := id.NewNLSLayerID()
+	f := lo.Must(nlslayer.NewFeature(id.NewFeatureID(), "Feature", nlslayer.NewPoint("Point", []float64{1, 2})))
+	require.NoError(t, db.FeatureRevision.SaveAll(ctx, []*nlslayer.FeatureRevision{
+		nlslayer.NewFeatureRevision().NewID().Layer(lid).Scene(sc.ID()).
+			Feature(f.ID()).Action(nlslayer.FeatureRevisionActionAdd).After(f).MustBuild(),
+	}))
+
+	_, err := uc.Purge(ctx, prj.ID(), op)
+	require.NoError(t, err)
+
+	versions, err := db.PublicationVersion.FindByProject(ctx, prj.ID())
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+	versions, err = db.PublicationVersion.FindByStory(ctx, st.Id())
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+	for _, v := range []*publication.Version{pv, sv} {
+		_, err = file.ReadPublicationSnapshot(ctx, v.SnapshotName())
+		assert.ErrorIs(t, err, rerror.ErrNotFound)
+	}
+	_, err = file.ReadStoryFile(ctx, st.Alias())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	tokens, err := db.ShareToken.FindByStory(ctx, st.Id())
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+	schedules, err := db.PublishSchedule.FindByProject(ctx, prj.ID())
+	require.NoError(t, err)
+	assert.Empty(t, schedules)
+	revisions, _, err := db.FeatureRevision.FindByLayer(ctx, lid, repo.FeatureRevisionFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
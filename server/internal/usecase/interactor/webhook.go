@@ -0,0 +1,299 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	webhookDeliveryLockName = "webhookDelivery"
+	// webhookDeliveryBatchSize is how many deliveries are sent under the lock at a time. The lock is released between
+	// the batches, so that a run does not hold it for long and the other server instances can take their turn.
+	webhookDeliveryBatchSize = 100
+	// webhookDeliveryConcurrency is how many deliveries of a batch are sent at the same time, so that slow webhooks
+	// do not keep the batch waiting for their timeouts one after another.
+	webhookDeliveryConcurrency = 10
+)
+
+type Webhook struct {
+	common
+	webhookRepo  repo.Webhook
+	deliveryRepo repo.WebhookDelivery
+	lock         repo.Lock
+	transaction  usecasex.Transaction
+	sender       gateway.WebhookSender
+}
+
+func NewWebhook(r *repo.Container, gr *gateway.Container) interfaces.Webhook {
+	return &Webhook{
+		webhookRepo:  r.Webhook,
+		deliveryRepo: r.WebhookDelivery,
+		lock:         r.Lock,
+		transaction:  r.Transaction,
+		sender:       gr.WebhookSender,
+	}
+}
+
+func (i *Webhook) FetchByWorkspace(ctx context.Context, wid accountsID.WorkspaceID, operator *usecase.Operator) ([]*webhook.Webhook, error) {
+	if err := i.canManage(wid, operator); err != nil {
+		return nil, err
+	}
+	return i.webhookRepo.FindByWorkspace(ctx, wid)
+}
+
+func (i *Webhook) FetchDeliveries(ctx context.Context, param interfaces.FetchWebhookDeliveriesParam, operator *usecase.Operator) ([]*webhook.Delivery, *usecasex.PageInfo, error) {
+	w, err := i.webhookRepo.FindByID(ctx, param.WebhookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := i.canManage(w.Workspace(), operator); err != nil {
+		return nil, nil, err
+	}
+	return i.deliveryRepo.FindByWebhook(ctx, w.ID(), repo.WebhookDeliveryFilter{
+		Status:     param.Status,
+		Pagination: param.Pagination,
+	})
+}
+
+func (i *Webhook) Create(ctx context.Context, param interfaces.CreateWebhookParam, operator *usecase.Operator) (_ *webhook.Webhook, err error) {
+	if err := i.canManage(param.WorkspaceID, operator); err != nil {
+		return nil, err
+	}
+
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := webhook.New().
+		NewID().
+		Workspace(param.WorkspaceID).
+		Name(param.Name).
+		URL(param.URL).
+		Secret(secret).
+		Events(param.Events).
+		Enabled(param.Enabled).
+		CreatedBy(operator.AcOperator.User).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.webhookRepo.Save(ctx, w); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return w, nil
+}
+
+func (i *Webhook) Update(ctx context.Context, param interfaces.UpdateWebhookParam, operator *usecase.Operator) (_ *webhook.Webhook, err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	w, err := i.webhookRepo.FindByID(ctx, param.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.canManage(w.Workspace(), operator); err != nil {
+		return nil, err
+	}
+
+	if param.Name != nil {
+		w.SetName(*param.Name)
+	}
+	if param.URL != nil {
+		if err := w.SetURL(param.URL); err != nil {
+			return nil, err
+		}
+	}
+	if param.Events != nil {
+		w.SetEvents(*param.Events)
+	}
+	if param.Enabled != nil {
+		w.SetEnabled(*param.Enabled)
+	}
+	if param.RegenerateSecret {
+		secret, err := webhook.GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+		w.SetSecret(secret)
+	}
+	w.SetUpdatedAt(time.Now())
+
+	if err := i.webhookRepo.Save(ctx, w); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return w, nil
+}
+
+func (i *Webhook) Delete(ctx context.Context, wid id.WebhookID, operator *usecase.Operator) (err error) {
+	tx, err := i.transaction.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx = tx.Context()
+	defer func() {
+		if err2 := tx.End(ctx); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	w, err := i.webhookRepo.FindByID(ctx, wid)
+	if err != nil {
+		return err
+	}
+	if err := i.canManage(w.Workspace(), operator); err != nil {
+		return err
+	}
+
+	if err := i.deliveryRepo.RemoveByWebhook(ctx, wid); err != nil {
+		return err
+	}
+	if err := i.webhookRepo.Remove(ctx, wid); err != nil {
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+func (i *Webhook) DeliverDue(ctx context.Context, now time.Time) error {
+	if i.sender == nil {
+		return nil
+	}
+
+	for ctx.Err() == nil {
+		n, err := i.deliverBatch(ctx, now)
+		if err != nil || n < webhookDeliveryBatchSize {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// deliverBatch sends a batch of the due deliveries and returns how many deliveries the batch had.
+func (i *Webhook) deliverBatch(ctx context.Context, now time.Time) (int, error) {
+	// only one server instance sends the deliveries at a time, so that a delivery is not sent twice
+	if err := i.lock.Lock(ctx, webhookDeliveryLockName); err != nil {
+		if errors.Is(err, repo.ErrAlreadyLocked) || errors.Is(err, repo.ErrFailedToLock) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer func() {
+		if err := i.lock.Unlock(ctx, webhookDeliveryLockName); err != nil {
+			log.Errorfc(ctx, "webhook: failed to unlock: %v", err)
+		}
+	}()
+
+	deliveries, err := i.deliveryRepo.FindDue(ctx, now, webhookDeliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := map[id.WebhookID]*webhook.Webhook{}
+	for _, d := range deliveries {
+		if _, ok := webhooks[d.Webhook()]; ok {
+			continue
+		}
+		w, err := i.webhookRepo.FindByID(ctx, d.Webhook())
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return 0, err
+		}
+		webhooks[d.Webhook()] = w
+	}
+
+	var g errgroup.Group
+	g.SetLimit(webhookDeliveryConcurrency)
+	for _, d := range deliveries {
+		w := webhooks[d.Webhook()]
+		g.Go(func() error {
+			switch {
+			case w == nil:
+				d.Abandon(time.Now(), "webhook is deleted")
+			case !w.Enabled():
+				d.Abandon(time.Now(), "webhook is disabled")
+			default:
+				d.RecordAttempt(i.send(ctx, w, d))
+			}
+			return i.deliveryRepo.Save(ctx, d)
+		})
+	}
+	return len(deliveries), g.Wait()
+}
+
+// send sends the delivery to the webhook and returns the attempt. The payload is signed at the time it is sent.
+func (i *Webhook) send(ctx context.Context, w *webhook.Webhook, d *webhook.Delivery) webhook.Attempt {
+	start := time.Now()
+	body := d.Payload()
+	header := http.Header{}
+	header.Set(webhook.HeaderEvent, string(d.Event()))
+	header.Set(webhook.HeaderDelivery, d.ID().String())
+	header.Set(webhook.HeaderSignature, webhook.Signature(w.Secret(), start, body))
+
+	res, err := i.sender.Send(ctx, gateway.WebhookRequest{
+		URL:    w.URL(),
+		Header: header,
+		Body:   body,
+	})
+
+	a := webhook.Attempt{At: start, Duration: time.Since(start)}
+	if err != nil {
+		log.Warnfc(ctx, "webhook: failed to send %s to %s: %v", d.ID(), w.ID(), err)
+		a.Error = err.Error()
+		return a
+	}
+	a.StatusCode = res.StatusCode
+	return a
+}
+
+// canManage allows the maintainers of the workspace to manage its webhooks, since they receive the events of the whole workspace.
+func (i *Webhook) canManage(wid accountsID.WorkspaceID, operator *usecase.Operator) error {
+	if err := i.OnlyOperator(operator); err != nil {
+		return err
+	}
+	if operator.AcOperator == nil || !operator.IsMaintainingWorkspace(wid) {
+		return interfaces.ErrOperationDenied
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/log"
+)
+
+// webhookEmitter queues the deliveries of an event to the webhooks of the workspace that subscribe to it.
+// The deliveries are sent later by Webhook.DeliverDue, so emitting an event never waits for the webhooks.
+type webhookEmitter struct {
+	webhookRepo  repo.Webhook
+	deliveryRepo repo.WebhookDelivery
+}
+
+func newWebhookEmitter(r *repo.Container) webhookEmitter {
+	return webhookEmitter{
+		webhookRepo:  r.Webhook,
+		deliveryRepo: r.WebhookDelivery,
+	}
+}
+
+// emit is called in the transaction of the change that caused the event when there is one, so that the deliveries are
+// queued only when the change is committed. Failing to queue them is logged instead of failing the change.
+func (e webhookEmitter) emit(ctx context.Context, t webhook.EventType, wid accountsID.WorkspaceID, data any) {
+	// the interactors built without the webhook repos do not emit events
+	if e.webhookRepo == nil || e.deliveryRepo == nil {
+		return
+	}
+
+	hooks, err := e.webhookRepo.FindByWorkspace(ctx, wid)
+	if err != nil {
+		log.Warnfc(ctx, "webhook: failed to find the webhooks of %s for %s: %v", wid, t, err)
+		return
+	}
+
+	ev := webhook.Event{
+		Type:       t,
+		Workspace:  wid,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+	for _, w := range hooks {
+		if !w.Subscribes(t) {
+			continue
+		}
+		d, err := webhook.NewEventDelivery(w, ev)
+		if err == nil {
+			err = e.deliveryRepo.Save(ctx, d)
+		}
+		if err != nil {
+			log.Warnfc(ctx, "webhook: failed to queue %s for %s: %v", t, w.ID(), err)
+		}
+	}
+}
+
+func (e webhookEmitter) emitProject(ctx context.Context, t webhook.EventType, p *project.Project) {
+	e.emit(ctx, t, p.Workspace(), map[string]any{"project": projectEventData(p)})
+}
+
+func (e webhookEmitter) emitImport(ctx context.Context, pid id.ProjectID, m *project.ProjectMetadata) {
+	if m == nil || m.ImportStatus() == nil {
+		return
+	}
+
+	var t webhook.EventType
+	switch *m.ImportStatus() {
+	case project.ProjectImportStatusSuccess:
+		t = webhook.EventImportSuccess
+	case project.ProjectImportStatusFailed:
+		t = webhook.EventImportFailure
+	default:
+		return
+	}
+
+	data := map[string]any{
+		"project": map[string]any{"id": pid.String()},
+		"status":  string(*m.ImportStatus()),
+	}
+	if l := m.ImportResultLog(); l != nil {
+		data["result"] = *l
+	}
+	e.emit(ctx, t, m.Workspace(), data)
+}
+
+func (e webhookEmitter) emitStoryPublish(ctx context.Context, wid accountsID.WorkspaceID, pid id.ProjectID, s *storytelling.Story) {
+	e.emit(ctx, webhook.EventStoryPublish, wid, map[string]any{
+		"story": map[string]any{
+			"id":                s.Id().String(),
+			"projectId":         pid.String(),
+			"sceneId":           s.Scene().String(),
+			"title":             s.Title(),
+			"alias":             s.Alias(),
+			"publishmentStatus": string(s.PublishmentStatus()),
+			"publishedAt":       s.PublishedAt(),
+			"publishedVersion":  s.PublishedVersion(),
+		},
+	})
+}
+
+func (e webhookEmitter) emitAssetUpload(ctx context.Context, a *asset.Asset) {
+	data := map[string]any{
+		"id":          a.ID().String(),
+		"name":        a.Name(),
+		"size":        a.Size(),
+		"url":         a.URL(),
+		"contentType": a.ContentType(),
+	}
+	if pid := a.Project(); pid != nil {
+		data["projectId"] = pid.String()
+	}
+	e.emit(ctx, webhook.EventAssetUpload, a.Workspace(), map[string]any{"asset": data})
+}
+
+func projectEventData(p *project.Project) map[string]any {
+	data := map[string]any{
+		"id":                p.ID().String(),
+		"sceneId":           p.Scene().String(),
+		"name":              p.Name(),
+		"alias":             p.Alias(),
+		"projectAlias":      p.ProjectAlias(),
+		"visibility":        p.Visibility(),
+		"publishmentStatus": string(p.PublishmentStatus()),
+		"updatedAt":         p.UpdatedAt(),
+	}
+	if t := p.PublishedAt(); !t.IsZero() {
+		data["publishedAt"] = t
+		data["publishedVersion"] = p.PublishedVersion()
+	}
+	return data
+}
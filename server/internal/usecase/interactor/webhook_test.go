@@ -0,0 +1,229 @@
+package interactor
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	accountsWorkspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearth/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/internal/usecase/gateway"
+	"github.com/reearth/reearth/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth/server/internal/usecase/repo"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookSenderMock struct {
+	statusCode int
+	delay      time.Duration
+	requests   []gateway.WebhookRequest
+	inFlight   int
+	maxFlight  int
+	mu         sync.Mutex
+}
+
+func (s *webhookSenderMock) Send(_ context.Context, req gateway.WebhookRequest) (*gateway.WebhookResponse, error) {
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.inFlight++
+	s.maxFlight = max(s.maxFlight, s.inFlight)
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+	return &gateway.WebhookResponse{StatusCode: s.statusCode}, nil
+}
+
+type countingLock struct {
+	lock  repo.Lock
+	locks int
+}
+
+func (l *countingLock) Lock(ctx context.Context, name string) error {
+	l.locks++
+	return l.lock.Lock(ctx, name)
+}
+
+func (l *countingLock) Unlock(ctx context.Context, name string) error {
+	return l.lock.Unlock(ctx, name)
+}
+
+func TestWebhook(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	uc := NewWebhook(db, &gateway.Container{})
+
+	wid := accountsID.NewWorkspaceID()
+	uid := accountsID.NewUserID()
+	maintainer := &usecase.Operator{AcOperator: &accountsWorkspace.Operator{
+		User:                   &uid,
+		MaintainableWorkspaces: accountsID.WorkspaceIDList{wid},
+	}}
+	writer := &usecase.Operator{AcOperator: &accountsWorkspace.Operator{
+		WritableWorkspaces: accountsID.WorkspaceIDList{wid},
+	}}
+	u := lo.Must(url.Parse("https://example.com/hook"))
+
+	_, err := uc.Create(ctx, interfaces.CreateWebhookParam{WorkspaceID: wid, URL: u}, writer)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	_, err = uc.Create(ctx, interfaces.CreateWebhookParam{WorkspaceID: wid, URL: lo.Must(url.Parse("ftp://example.com"))}, maintainer)
+	assert.ErrorIs(t, err, webhook.ErrInvalidURL)
+
+	w, err := uc.Create(ctx, interfaces.CreateWebhookParam{
+		WorkspaceID: wid,
+		Name:        "ci",
+		URL:         u,
+		Events:      []webhook.EventType{webhook.EventProjectPublish},
+		Enabled:     true,
+	}, maintainer)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", w.Name())
+	assert.Equal(t, &uid, w.CreatedBy())
+	assert.True(t, strings.HasPrefix(w.Secret(), "whsec_"))
+
+	list, err := uc.FetchByWorkspace(ctx, wid, maintainer)
+	assert.NoError(t, err)
+	assert.Equal(t, []*webhook.Webhook{w}, list)
+	_, err = uc.FetchByWorkspace(ctx, wid, writer)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+
+	secret := w.Secret()
+	_, err = uc.Update(ctx, interfaces.UpdateWebhookParam{ID: w.ID(), Enabled: lo.ToPtr(false)}, writer)
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	w, err = uc.Update(ctx, interfaces.UpdateWebhookParam{
+		ID:               w.ID(),
+		Name:             lo.ToPtr("deploy"),
+		RegenerateSecret: true,
+	}, maintainer)
+	assert.NoError(t, err)
+	assert.Equal(t, "deploy", w.Name())
+	assert.NotEqual(t, secret, w.Secret())
+
+	assert.ErrorIs(t, uc.Delete(ctx, w.ID(), writer), interfaces.ErrOperationDenied)
+	assert.NoError(t, uc.Delete(ctx, w.ID(), maintainer))
+	list, err = uc.FetchByWorkspace(ctx, wid, maintainer)
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestWebhook_DeliverDue(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	sender := &webhookSenderMock{statusCode: http.StatusOK}
+	uc := NewWebhook(db, &gateway.Container{WebhookSender: sender})
+	emitter := newWebhookEmitter(db)
+
+	wid := accountsID.NewWorkspaceID()
+	newWebhook := func(events ...webhook.EventType) *webhook.Webhook {
+		w := webhook.New().NewID().Workspace(wid).
+			URL(lo.Must(url.Parse("https://example.com/hook"))).
+			Secret("secret").Events(events).Enabled(true).MustBuild()
+		require.NoError(t, db.Webhook.Save(ctx, w))
+		return w
+	}
+	publish := newWebhook(webhook.EventProjectPublish)
+	all := newWebhook()
+	prj := project.New().NewID().Workspace(wid).Scene(id.NewSceneID()).Name("p").MustBuild()
+
+	emitter.emitProject(ctx, webhook.EventProjectPublish, prj)
+	emitter.emitProject(ctx, webhook.EventProjectUpdate, prj)
+
+	deliveries, _, err := db.WebhookDelivery.FindByWebhook(ctx, publish.ID(), repo.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	deliveries, _, err = db.WebhookDelivery.FindByWebhook(ctx, all.ID(), repo.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+
+	// succeeded
+	require.NoError(t, uc.DeliverDue(ctx, time.Now()))
+	assert.Len(t, sender.requests, 3)
+	req := sender.requests[0]
+	assert.Equal(t, "https://example.com/hook", req.URL.String())
+	assert.NotEmpty(t, req.Header.Get(webhook.HeaderSignature))
+	assert.NotEmpty(t, req.Header.Get(webhook.HeaderDelivery))
+	deliveries, _, err = db.WebhookDelivery.FindByWebhook(ctx, all.ID(), repo.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	for _, d := range deliveries {
+		assert.Equal(t, webhook.DeliveryStatusSucceeded, d.Status())
+		assert.Nil(t, d.NextAttemptAt())
+	}
+
+	// retried with backoff
+	sender.statusCode = http.StatusInternalServerError
+	sender.requests = nil
+	emitter.emitProject(ctx, webhook.EventProjectPublish, prj)
+	require.NoError(t, uc.DeliverDue(ctx, time.Now()))
+	assert.Len(t, sender.requests, 2)
+	require.NoError(t, uc.DeliverDue(ctx, time.Now()))
+	assert.Len(t, sender.requests, 2)
+	deliveries, _, err = db.WebhookDelivery.FindByWebhook(ctx, publish.ID(), repo.WebhookDeliveryFilter{
+		Status: lo.ToPtr(webhook.DeliveryStatusPending),
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	d := deliveries[0]
+	assert.Equal(t, http.StatusInternalServerError, d.LastAttempt().StatusCode)
+	assert.Equal(t, d.LastAttempt().At.Add(webhook.Backoff(1)), *d.NextAttemptAt())
+
+	// abandoned when the webhook is disabled
+	publish.SetEnabled(false)
+	require.NoError(t, db.Webhook.Save(ctx, publish))
+	sender.requests = nil
+	require.NoError(t, uc.DeliverDue(ctx, d.NextAttemptAt().Add(time.Second)))
+	assert.Len(t, sender.requests, 1)
+	deliveries, _, err = db.WebhookDelivery.FindByWebhook(ctx, publish.ID(), repo.WebhookDeliveryFilter{
+		Status: lo.ToPtr(webhook.DeliveryStatusFailed),
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "webhook is disabled", deliveries[0].LastAttempt().Error)
+}
+
+func TestWebhook_DeliverDue_Batches(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	lock := &countingLock{lock: db.Lock}
+	db.Lock = lock
+	sender := &webhookSenderMock{statusCode: http.StatusOK, delay: time.Millisecond}
+	uc := NewWebhook(db, &gateway.Container{WebhookSender: sender})
+	emitter := newWebhookEmitter(db)
+
+	wid := accountsID.NewWorkspaceID()
+	w := webhook.New().NewID().Workspace(wid).
+		URL(lo.Must(url.Parse("https://example.com/hook"))).
+		Secret("secret").Enabled(true).MustBuild()
+	require.NoError(t, db.Webhook.Save(ctx, w))
+	prj := project.New().NewID().Workspace(wid).Scene(id.NewSceneID()).Name("p").MustBuild()
+	for range 2*webhookDeliveryBatchSize + 1 {
+		emitter.emitProject(ctx, webhook.EventProjectUpdate, prj)
+	}
+
+	// all the due deliveries are sent in one run, a batch at a time with the lock released in between
+	require.NoError(t, uc.DeliverDue(ctx, time.Now()))
+	assert.Len(t, sender.requests, 2*webhookDeliveryBatchSize+1)
+	assert.Equal(t, 3, lock.locks)
+	assert.Greater(t, sender.maxFlight, 1)
+	assert.LessOrEqual(t, sender.maxFlight, webhookDeliveryConcurrency)
+
+	deliveries, _, err := db.WebhookDelivery.FindByWebhook(ctx, w.ID(), repo.WebhookDeliveryFilter{
+		Status: lo.ToPtr(webhook.DeliveryStatusPending),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+var (
+	ErrInvalidAPITokenExpiry = errors.New("api token expiry must be in the future")
+	ErrInvalidAPIToken       = errors.New("invalid api token")
+	// ErrAPITokenCreatorNotAllowed is returned when the creator of an API token is no longer a maintainer of its workspace.
+	ErrAPITokenCreatorNotAllowed = errors.New("the creator of the api token is not allowed to use it")
+)
+
+type CreateAPITokenParam struct {
+	WorkspaceID accountsID.WorkspaceID
+	Name        string
+	Scopes      apitoken.Scopes
+	ExpiresAt   *time.Time
+}
+
+type APIToken interface {
+	// FetchByWorkspace returns the API tokens of the workspace. Only the maintainers of the workspace can manage its tokens.
+	FetchByWorkspace(context.Context, accountsID.WorkspaceID, *usecase.Operator) ([]*apitoken.APIToken, error)
+	// Create returns the created API token and the token itself, which cannot be retrieved later.
+	Create(context.Context, CreateAPITokenParam, *usecase.Operator) (*apitoken.APIToken, string, error)
+	Revoke(context.Context, id.APITokenID, *usecase.Operator) (*apitoken.APIToken, error)
+	// Authenticate returns the API token for the token sent by a client, or ErrInvalidAPIToken when it is unknown,
+	// revoked or expired. It is called before the operator is known.
+	Authenticate(context.Context, string) (*apitoken.APIToken, error)
+}
@@ -40,6 +40,7 @@ var (
 	ErrInvalidIconImage     error = errors.New("invalid icon image")
 	ErrIconImageTooLarge    error = errors.New("icon image file too large")
 	ErrIconImageDimTooLarge error = errors.New("icon image dimensions too large")
+	ErrAssetInUse           error = errors.New("asset is in use")
 )
 
 type Asset interface {
@@ -48,6 +49,11 @@ type Asset interface {
 	Create(context.Context, CreateAssetParam, *usecase.Operator) (*asset.Asset, error)
 	CreateIconAsset(context.Context, CreateIconAssetParam, *usecase.Operator) (*asset.Asset, error)
 	Update(context.Context, id.AssetID, *id.ProjectID, *usecase.Operator) (id.AssetID, *id.ProjectID, error)
-	Remove(context.Context, id.AssetID, *usecase.Operator) (id.AssetID, error)
+	// Remove removes the asset. Unless force is set, it fails with ErrAssetInUse while a project still references the asset.
+	Remove(ctx context.Context, aid id.AssetID, force bool, operator *usecase.Operator) (id.AssetID, error)
+	// FindUsages returns where the asset is used. The usages in the workspaces that the operator cannot read are hidden.
+	FindUsages(context.Context, id.AssetID, *usecase.Operator) ([]asset.Usage, error)
+	// FindOrphans returns the assets of the workspace that no project references.
+	FindOrphans(context.Context, accountsID.WorkspaceID, *usecase.Operator) ([]*asset.Asset, error)
 	ImportAssetFiles(context.Context, map[string]*zip.File, *[]byte, *project.Project, *usecase.Operator) (*[]byte, map[string]any, error)
 }
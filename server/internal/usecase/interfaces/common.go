@@ -27,19 +27,25 @@ var (
 )
 
 type Container struct {
-	Asset           Asset
-	NLSLayer        NLSLayer
-	Plugin          Plugin
-	Policy          Policy
-	Project         Project
-	ProjectMetadata ProjectMetadata
-	Property        Property
-	Published       Published
-	Scene           Scene
-	StoryTelling    Storytelling
-	Style           Style
-	User            User
-	Workspace       Workspace
+	APIToken           APIToken
+	Asset              Asset
+	NLSLayer           NLSLayer
+	Plugin             Plugin
+	Policy             Policy
+	Project            Project
+	ProjectMetadata    ProjectMetadata
+	Property           Property
+	PublicationVersion PublicationVersion
+	Published          Published
+	PublishSchedule    PublishSchedule
+	Scene              Scene
+	ShareToken         ShareToken
+	StoryTelling       Storytelling
+	Style              Style
+	Trash              Trash
+	User               User
+	Webhook            Webhook
+	Workspace          Workspace
 }
 
 // User defines the interface for user-related use cases.
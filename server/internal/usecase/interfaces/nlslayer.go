@@ -2,11 +2,16 @@ package interfaces
 
 import (
 	"context"
+	"io"
 
 	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/czml"
+	"github.com/reearth/reearth/server/pkg/file"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/nlslayer/decoding"
 	"github.com/reearth/reearthx/idx"
+	"github.com/reearth/reearthx/usecasex"
 )
 
 type AddNLSLayerSimpleInput struct {
@@ -53,17 +58,19 @@ type AddOrUpdateCustomPropertiesInput struct {
 }
 
 type AddNLSLayerGeoJSONFeatureParams struct {
-	LayerID    id.NLSLayerID
-	Type       string
-	Geometry   map[string]any
-	Properties *map[string]any
+	LayerID        id.NLSLayerID
+	Type           string
+	Geometry       map[string]any
+	Properties     *map[string]any
+	RepairGeometry bool
 }
 
 type UpdateNLSLayerGeoJSONFeatureParams struct {
-	LayerID    id.NLSLayerID
-	FeatureID  id.FeatureID
-	Geometry   *map[string]any
-	Properties *map[string]any
+	LayerID        id.NLSLayerID
+	FeatureID      id.FeatureID
+	Geometry       *map[string]any
+	Properties     *map[string]any
+	RepairGeometry bool
 }
 
 type DeleteNLSLayerGeoJSONFeatureParams struct {
@@ -71,6 +78,69 @@ type DeleteNLSLayerGeoJSONFeatureParams struct {
 	FeatureID id.FeatureID
 }
 
+type FeatureOperationType string
+
+const (
+	FeatureOperationAdd    FeatureOperationType = "add"
+	FeatureOperationUpdate FeatureOperationType = "update"
+	FeatureOperationDelete FeatureOperationType = "delete"
+)
+
+type FeatureOperation struct {
+	Type FeatureOperationType
+	// FeatureID is required for update and delete operations.
+	FeatureID *id.FeatureID
+	// FeatureType is used by add operations and defaults to "Feature".
+	FeatureType string
+	Geometry    *map[string]any
+	Properties  *map[string]any
+}
+
+type BatchNLSLayerGeoJSONFeaturesParams struct {
+	LayerID        id.NLSLayerID
+	Operations     []FeatureOperation
+	RepairGeometry bool
+}
+
+type BatchNLSLayerGeoJSONFeaturesResult struct {
+	Added   []nlslayer.Feature
+	Updated []nlslayer.Feature
+	Deleted []id.FeatureID
+}
+
+type ImportNLSLayerGeoJSONFeaturesParams struct {
+	LayerID id.NLSLayerID
+	File    *file.File
+	// Replace removes the existing features of the layer instead of appending to them.
+	Replace        bool
+	RepairGeometry bool
+}
+
+type QueryNLSLayerFeaturesParams struct {
+	LayerID    id.NLSLayerID
+	Query      nlslayer.FeatureQuery
+	Pagination *usecasex.Pagination
+}
+
+type FetchNLSLayerFeatureRevisionsParams struct {
+	LayerID id.NLSLayerID
+	// FeatureID narrows the revisions down to a single feature when set.
+	FeatureID  *id.FeatureID
+	Pagination *usecasex.Pagination
+}
+
+type RevertNLSLayerFeatureParams struct {
+	LayerID    id.NLSLayerID
+	RevisionID id.FeatureRevisionID
+}
+
+type ImportNLSLayerInput struct {
+	SceneID id.SceneID
+	AssetID id.AssetID
+	Title   *string
+	Index   *int
+}
+
 type NLSLayer interface {
 	Fetch(context.Context, id.NLSLayerIDList, *usecase.Operator) (nlslayer.NLSLayerList, error)
 	FetchByScene(context.Context, id.SceneID, *usecase.Operator) (nlslayer.NLSLayerList, error)
@@ -93,5 +163,15 @@ type NLSLayer interface {
 	AddGeoJSONFeature(context.Context, AddNLSLayerGeoJSONFeatureParams, *usecase.Operator) (nlslayer.Feature, error)
 	UpdateGeoJSONFeature(context.Context, UpdateNLSLayerGeoJSONFeatureParams, *usecase.Operator) (nlslayer.Feature, error)
 	DeleteGeoJSONFeature(context.Context, DeleteNLSLayerGeoJSONFeatureParams, *usecase.Operator) (id.FeatureID, error)
+	BatchGeoJSONFeatures(context.Context, BatchNLSLayerGeoJSONFeaturesParams, *usecase.Operator) (*BatchNLSLayerGeoJSONFeaturesResult, error)
+	ImportGeoJSONFeatures(context.Context, ImportNLSLayerGeoJSONFeaturesParams, *usecase.Operator) (*nlslayer.FeatureCollection, error)
+	QueryFeatures(context.Context, QueryNLSLayerFeaturesParams, *usecase.Operator) ([]nlslayer.Feature, *usecasex.PageInfo, error)
+	FetchFeatureRevisions(context.Context, FetchNLSLayerFeatureRevisionsParams, *usecase.Operator) ([]*nlslayer.FeatureRevision, *usecasex.PageInfo, error)
+	RevertFeature(context.Context, RevertNLSLayerFeatureParams, *usecase.Operator) (*nlslayer.FeatureRevision, error)
+	RevertFeatureCollection(context.Context, RevertNLSLayerFeatureParams, *usecase.Operator) (*nlslayer.FeatureCollection, error)
 	ImportNLSLayers(context.Context, idx.ID[id.Scene], *[]byte) (map[string]any, error)
+	ImportKML(context.Context, ImportNLSLayerInput, *usecase.Operator) (*decoding.Result, error)
+	ImportShapefile(context.Context, ImportNLSLayerInput, *usecase.Operator) (*decoding.Result, error)
+	ExportCZML(context.Context, id.NLSLayerID, *usecase.Operator) ([]czml.Feature, error)
+	ExportShapefile(context.Context, id.NLSLayerID, io.Writer, *usecase.Operator) error
 }
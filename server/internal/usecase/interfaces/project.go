@@ -48,6 +48,7 @@ type UpdateProjectParam struct {
 	ImportStatus   project.ProjectImportStatus
 	SceneID        *id.SceneID
 	Starred        *bool
+	Template       *bool
 	Deleted        *bool
 	Visibility     *string
 	ProjectAlias   *string
@@ -67,14 +68,36 @@ type UpdateProjectParam struct {
 	TrackingID            *string
 }
 
+type CreateProjectFromTemplateParam struct {
+	TemplateID  id.ProjectID
+	Name        *string
+	Description *string
+}
+
+type DuplicateProjectParam struct {
+	ID id.ProjectID
+	// WorkspaceID is the workspace the duplicate is created in. It defaults to the workspace of the project.
+	WorkspaceID *accountsID.WorkspaceID
+	Name        *string
+	// ShareAssets makes the duplicate use the assets of the project instead of copies of them.
+	// It is only allowed when the duplicate is created in the workspace of the project.
+	// Deleting either project keeps the assets that the other one still uses.
+	ShareAssets bool
+}
+
 type PublishProjectParam struct {
 	ID     id.ProjectID
 	Alias  *string
 	Status project.PublishmentStatus
+	// Message is stored with the publication version
+	Message string
 }
 
 var (
 	ErrProjectAliasIsNotSet    error = errors.New("project alias is not set")
+	ErrProjectIsNotTemplate    error = errors.New("project is not a template")
+	ErrProjectIsDeleted        error = errors.New("project is deleted")
+	ErrCannotShareAssets       error = errors.New("assets can be shared only within the workspace of the project")
 	ErrProjectAliasAlreadyUsed       = verror.NewVError(
 		errmsg.ErrKeyUsecaseInterfaceProjectAliasAlreadyUsed,
 		errmsg.ErrorMessages[errmsg.ErrKeyUsecaseInterfaceProjectAliasAlreadyUsed],
@@ -92,6 +115,7 @@ type Project interface {
 	Fetch(context.Context, []id.ProjectID, *usecase.Operator) ([]*project.Project, error)
 	FindByWorkspace(context.Context, accountsID.WorkspaceID, *string, *project.SortType, *usecasex.Pagination, *usecase.Operator) ([]*project.Project, *usecasex.PageInfo, error)
 	FindStarredByWorkspace(context.Context, accountsID.WorkspaceID, *usecasex.Pagination, *usecase.Operator) ([]*project.Project, *usecasex.PageInfo, error)
+	FindTemplatesByWorkspace(context.Context, accountsID.WorkspaceID, *usecasex.Pagination, *usecase.Operator) ([]*project.Project, *usecasex.PageInfo, error)
 	FindDeletedByWorkspace(context.Context, accountsID.WorkspaceID, *usecasex.Pagination, *usecase.Operator) ([]*project.Project, *usecasex.PageInfo, error)
 
 	FindActiveById(context.Context, id.ProjectID, *usecase.Operator) (*project.Project, error)
@@ -105,6 +129,8 @@ type Project interface {
 	UpdateVisibility(context.Context, id.ProjectID, string, *usecase.Operator) (*project.Project, error)
 
 	Create(context.Context, CreateProjectParam, *usecase.Operator) (*project.Project, error)
+	CreateFromTemplate(context.Context, CreateProjectFromTemplateParam, *usecase.Operator) (*project.Project, error)
+	Duplicate(context.Context, DuplicateProjectParam, *usecase.Operator) (*project.Project, error)
 	Update(context.Context, UpdateProjectParam, *usecase.Operator) (*project.Project, error)
 	Delete(context.Context, id.ProjectID, *usecase.Operator) error
 
@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+)
+
+var ErrPublicationNotPublished = errors.New("publication is not published")
+
+type RollbackPublicationParam struct {
+	ProjectID *id.ProjectID
+	StoryID   *id.StoryID
+	Version   int
+}
+
+type PublicationVersion interface {
+	FetchByProject(context.Context, id.ProjectID, *usecase.Operator) ([]*publication.Version, error)
+	FetchByStory(context.Context, id.StoryID, *usecase.Operator) ([]*publication.Version, error)
+	// Rollback re-points the current alias of the published project or story to the snapshot of the version.
+	Rollback(context.Context, RollbackPublicationParam, *usecase.Operator) (*publication.Version, error)
+}
@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+)
+
+var ErrInvalidPublishScheduleTime = errors.New("publish schedule time must be in the future")
+
+type CreatePublishScheduleParam struct {
+	ProjectID *id.ProjectID
+	StoryID   *id.StoryID
+	// Alias is applied when the project or the story is published. Nil keeps the current alias.
+	Alias       *string
+	Status      publication.ScheduleStatus
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+}
+
+type PublishSchedule interface {
+	FetchByProject(context.Context, id.ProjectID, *usecase.Operator) ([]*publication.Schedule, error)
+	FetchByStory(context.Context, id.StoryID, *usecase.Operator) ([]*publication.Schedule, error)
+	Create(context.Context, CreatePublishScheduleParam, *usecase.Operator) (*publication.Schedule, error)
+	Cancel(context.Context, id.PublishScheduleID, *usecase.Operator) (*publication.Schedule, error)
+	// RunDue publishes and unpublishes the projects and the stories whose schedules are due at the time.
+	// It is called by the publish scheduler without an operator.
+	RunDue(context.Context, time.Time) error
+}
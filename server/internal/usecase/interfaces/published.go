@@ -2,10 +2,16 @@ package interfaces
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/url"
 )
 
+var (
+	ErrShareTokenRequired = errors.New("share token required")
+	ErrInvalidShareToken  = errors.New("invalid share token")
+)
+
 type HasPublicMeta interface {
 	PublicTitle() string
 	PublicDescription() string
@@ -26,7 +32,8 @@ type PublishedMetadata struct {
 	Noindex           bool   `json:"noindex,omitempty"`
 	IsBasicAuthActive bool   `json:"isBasicAuthActive,omitempty"`
 	BasicAuthUsername string `json:"basicAuthUsername,omitempty"`
-	BasicAuthPassword string `json:"basicAuthPassword,omitempty"`
+	// BasicAuthPassword is the stored hash of the password, which must never be sent to clients.
+	BasicAuthPassword string `json:"-"`
 	CoreSupport       bool   `json:"coreSupport,omitempty"`
 }
 
@@ -47,5 +54,10 @@ func PublishedMetadataFrom(i HasPublicMeta) PublishedMetadata {
 type Published interface {
 	Metadata(context.Context, string) (PublishedMetadata, error)
 	Data(context.Context, string) (io.Reader, error)
+	// DataVersion returns the snapshot of the version of the publication, so that old versions can be previewed.
+	DataVersion(context.Context, string, int) (io.Reader, error)
 	Index(context.Context, string, *url.URL) (string, error)
+	// ValidateShareToken returns ErrShareTokenRequired or ErrInvalidShareToken when the publication is limited
+	// and the token does not grant access to it.
+	ValidateShareToken(context.Context, string, string) error
 }
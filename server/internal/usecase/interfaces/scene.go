@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/project"
 	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/scene/sceneops"
 	"github.com/reearth/reearth/server/pkg/storytelling"
 )
 
@@ -16,6 +17,7 @@ var (
 	ErrPluginNotInstalled        error = errors.New("plugin not installed")
 	ErrCannotUpgradeToPlugin     error = errors.New("cannot upgrade to such plugin")
 	ErrExtensionTypeMustBeWidget error = errors.New("extension type must be widget")
+	ErrSceneEventsUnavailable    error = errors.New("scene events are unavailable")
 )
 
 type Scene interface {
@@ -30,8 +32,11 @@ type Scene interface {
 	InstallPlugin(context.Context, id.SceneID, id.PluginID, *usecase.Operator) (*scene.Scene, *id.PropertyID, error)
 	UninstallPlugin(context.Context, id.SceneID, id.PluginID, *usecase.Operator) (*scene.Scene, error)
 	UpgradePlugin(context.Context, id.SceneID, id.PluginID, id.PluginID, *usecase.Operator) (*scene.Scene, error)
+	PluginUpgradeReport(context.Context, id.SceneID, id.PluginID, id.PluginID, *usecase.Operator) (*sceneops.PluginUpgradeReport, error)
 	ExportSceneData(context.Context, *project.Project) (*scene.Scene, map[string]any, error)
 	ImportSceneData(context.Context, *scene.Scene, *[]byte) (*scene.Scene, error)
+	// SubscribeEvents returns a channel that receives the changes made to the scene until the context is done.
+	SubscribeEvents(context.Context, id.SceneID, *usecase.Operator) (<-chan scene.Event, error)
 }
 
 type UpdateWidgetParam struct {
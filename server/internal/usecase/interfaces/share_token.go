@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+)
+
+var ErrInvalidShareTokenExpiry = errors.New("share token expiry must be in the future")
+
+type CreateShareTokenParam struct {
+	ProjectID *id.ProjectID
+	StoryID   *id.StoryID
+	Name      string
+	ExpiresAt *time.Time
+}
+
+type ShareToken interface {
+	FetchByProject(context.Context, id.ProjectID, *usecase.Operator) ([]*publication.ShareToken, error)
+	FetchByStory(context.Context, id.StoryID, *usecase.Operator) ([]*publication.ShareToken, error)
+	// Create returns the created share token and the token itself, which cannot be retrieved later.
+	Create(context.Context, CreateShareTokenParam, *usecase.Operator) (*publication.ShareToken, string, error)
+	Revoke(context.Context, id.ShareTokenID, *usecase.Operator) (*publication.ShareToken, error)
+	UpdateExpiry(context.Context, id.ShareTokenID, *time.Time, *usecase.Operator) (*publication.ShareToken, error)
+}
@@ -55,6 +55,8 @@ type PublishStoryInput struct {
 	ID     id.StoryID
 	Alias  *string
 	Status storytelling.PublishmentStatus
+	// Message is stored with the publication version
+	Message string
 }
 
 type CreatePageParam struct {
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/trash"
+)
+
+var ErrProjectNotInTrash = errors.New("project is not in the trash")
+
+type Trash interface {
+	// FindPolicy returns the trash retention policy of the workspace, or the default policy when the workspace has not configured one.
+	FindPolicy(context.Context, accountsID.WorkspaceID, *usecase.Operator) (*trash.Policy, error)
+	// UpdatePolicy sets the retention of the workspace. Zero keeps the projects in the trash forever. Only maintainers can update it.
+	UpdatePolicy(context.Context, accountsID.WorkspaceID, time.Duration, *usecase.Operator) (*trash.Policy, error)
+	FindPurges(context.Context, accountsID.WorkspaceID, *usecase.Operator) ([]*trash.Purge, error)
+	Restore(context.Context, id.ProjectID, *usecase.Operator) (*project.Project, error)
+	// Purge deletes the project in the trash with everything it owns and records the purge. Only maintainers can purge.
+	Purge(context.Context, id.ProjectID, *usecase.Operator) (*trash.Purge, error)
+	// PurgeExpired purges the projects that have been in the trash longer than the retention of their workspaces.
+	// It is called by the trash purge job without an operator.
+	PurgeExpired(context.Context, time.Time) error
+}
@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/internal/usecase"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type CreateWebhookParam struct {
+	WorkspaceID accountsID.WorkspaceID
+	Name        string
+	URL         *url.URL
+	// Events are the event types the webhook receives. Empty receives all of them.
+	Events  []webhook.EventType
+	Enabled bool
+}
+
+type UpdateWebhookParam struct {
+	ID      id.WebhookID
+	Name    *string
+	URL     *url.URL
+	Events  *[]webhook.EventType
+	Enabled *bool
+	// RegenerateSecret replaces the secret, which is then returned only this time.
+	RegenerateSecret bool
+}
+
+type FetchWebhookDeliveriesParam struct {
+	WebhookID  id.WebhookID
+	Status     *webhook.DeliveryStatus
+	Pagination *usecasex.Pagination
+}
+
+type Webhook interface {
+	// FetchByWorkspace returns the webhooks of the workspace. Only the maintainers of the workspace can manage its webhooks.
+	FetchByWorkspace(context.Context, accountsID.WorkspaceID, *usecase.Operator) ([]*webhook.Webhook, error)
+	// FetchDeliveries returns the delivery log of the webhook from the newest to the oldest.
+	FetchDeliveries(context.Context, FetchWebhookDeliveriesParam, *usecase.Operator) ([]*webhook.Delivery, *usecasex.PageInfo, error)
+	Create(context.Context, CreateWebhookParam, *usecase.Operator) (*webhook.Webhook, error)
+	Update(context.Context, UpdateWebhookParam, *usecase.Operator) (*webhook.Webhook, error)
+	// Delete deletes the webhook and its delivery log.
+	Delete(context.Context, id.WebhookID, *usecase.Operator) error
+	// DeliverDue sends the deliveries whose next attempt is due at the time. It is run periodically by the server.
+	DeliverDue(context.Context, time.Time) error
+}
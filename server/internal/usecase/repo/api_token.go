@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/apitoken"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+type APIToken interface {
+	Filtered(WorkspaceFilter) APIToken
+	FindByID(context.Context, id.APITokenID) (*apitoken.APIToken, error)
+	FindByHash(context.Context, string) (*apitoken.APIToken, error)
+	// FindByWorkspace returns the API tokens of the workspace from the newest to the oldest.
+	FindByWorkspace(context.Context, accountsID.WorkspaceID) ([]*apitoken.APIToken, error)
+	Save(context.Context, *apitoken.APIToken) error
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/reearth/reearth/server/pkg/asset"
 	"github.com/reearth/reearth/server/pkg/id"
 	"github.com/reearth/reearthx/usecasex"
+	"github.com/samber/lo"
 )
 
 type AssetFilter struct {
@@ -27,3 +28,37 @@ type Asset interface {
 	Remove(context.Context, id.AssetID) error
 	RemoveByProjectWithFile(context.Context, id.ProjectID, gateway.File) error
 }
+
+// IterateAssetsByWorkspaceProject calls callback with every asset of the workspace, or of the project when pid is given, in batches.
+func IterateAssetsByWorkspaceProject(repo Asset, ctx context.Context, tid accountsID.WorkspaceID, pid *id.ProjectID, batch int64, callback func([]*asset.Asset) error) error {
+	pagination := usecasex.CursorPagination{
+		First: lo.ToPtr(batch),
+	}.Wrap()
+
+	filter := AssetFilter{
+		Pagination: pagination,
+	}
+
+	for {
+		assets, info, err := repo.FindByWorkspaceProject(ctx, tid, pid, filter)
+		if err != nil {
+			return err
+		}
+		if len(assets) == 0 {
+			break
+		}
+
+		if err := callback(assets); err != nil {
+			return err
+		}
+
+		if info == nil || !info.HasNextPage || int64(len(assets)) < batch {
+			break
+		}
+
+		c := usecasex.Cursor(assets[len(assets)-1].ID().String())
+		pagination.Cursor.After = &c
+	}
+
+	return nil
+}
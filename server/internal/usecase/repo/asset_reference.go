@@ -0,0 +1,28 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/project"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+// AssetReference looks up the projects, layers, styles and properties that reference asset URLs.
+// The URLs they contain are recorded when they are saved.
+// It is never filtered: an asset must not be removed while a project refers to it,
+// even when the operator cannot read that project.
+type AssetReference interface {
+	FindByURLs(context.Context, []string) (*AssetReferences, error)
+}
+
+// AssetReferences are the entities, in every workspace, that contain any of the URLs.
+// Scenes are the scenes of the layers, the styles and the properties.
+type AssetReferences struct {
+	Projects   []*project.Project
+	Scenes     []*scene.Scene
+	NLSLayers  nlslayer.NLSLayerList
+	Styles     scene.StyleList
+	Properties property.List
+}
@@ -23,25 +23,35 @@ var (
 )
 
 type Container struct {
-	Asset           Asset
-	Config          Config
-	NLSLayer        NLSLayer
-	Style           Style
-	Lock            Lock
-	Plugin          Plugin
-	Project         Project
-	ProjectMetadata ProjectMetadata
-	PropertySchema  PropertySchema
-	Property        Property
-	Scene           Scene
-	SceneLock       SceneLock
-	Workspace       accountsWorkspace.Repo
-	User            accountsUser.Repo
-	Storytelling    Storytelling
-	Transaction     usecasex.Transaction
-	Extensions      []id.PluginID
-	Role            accountsRole.Repo        // TODO: Delete this once the permission check migration is complete.
-	Permittable     accountsPermittable.Repo // TODO: Delete this once the permission check migration is complete.
+	Asset              Asset
+	AssetReference     AssetReference
+	Config             Config
+	NLSLayer           NLSLayer
+	FeatureRevision    FeatureRevision
+	Style              Style
+	Lock               Lock
+	Plugin             Plugin
+	Project            Project
+	ProjectMetadata    ProjectMetadata
+	PropertySchema     PropertySchema
+	Property           Property
+	Scene              Scene
+	SceneLock          SceneLock
+	Workspace          accountsWorkspace.Repo
+	User               accountsUser.Repo
+	Storytelling       Storytelling
+	ShareToken         ShareToken
+	PublishSchedule    PublishSchedule
+	PublicationVersion PublicationVersion
+	TrashPolicy        TrashPolicy
+	TrashPurge         TrashPurge
+	APIToken           APIToken
+	Webhook            Webhook
+	WebhookDelivery    WebhookDelivery
+	Transaction        usecasex.Transaction
+	Extensions         []id.PluginID
+	Role               accountsRole.Repo        // TODO: Delete this once the permission check migration is complete.
+	Permittable        accountsPermittable.Repo // TODO: Delete this once the permission check migration is complete.
 }
 
 func (c *Container) AccountRepos() *accountsInfra.Container {
@@ -60,23 +70,33 @@ func (c *Container) Filtered(workspace WorkspaceFilter, scene SceneFilter) *Cont
 		return c
 	}
 	return &Container{
-		Asset:           c.Asset.Filtered(workspace),
-		Config:          c.Config,
-		NLSLayer:        c.NLSLayer.Filtered(scene),
-		Style:           c.Style.Filtered(scene),
-		Lock:            c.Lock,
-		Plugin:          c.Plugin.Filtered(scene),
-		Storytelling:    c.Storytelling.Filtered(scene),
-		Project:         c.Project.Filtered(workspace),
-		ProjectMetadata: c.ProjectMetadata.Filtered(workspace),
-		PropertySchema:  c.PropertySchema.Filtered(scene),
-		Property:        c.Property.Filtered(scene),
-		Scene:           c.Scene.Filtered(workspace),
-		SceneLock:       c.SceneLock,
-		Transaction:     c.Transaction,
-		User:            c.User,
-		Workspace:       c.Workspace,
-		Extensions:      c.Extensions,
+		Asset:              c.Asset.Filtered(workspace),
+		AssetReference:     c.AssetReference,
+		Config:             c.Config,
+		NLSLayer:           c.NLSLayer.Filtered(scene),
+		FeatureRevision:    c.FeatureRevision.Filtered(scene),
+		Style:              c.Style.Filtered(scene),
+		Lock:               c.Lock,
+		Plugin:             c.Plugin.Filtered(scene),
+		Storytelling:       c.Storytelling.Filtered(scene),
+		ShareToken:         c.ShareToken.Filtered(scene),
+		PublishSchedule:    c.PublishSchedule.Filtered(scene),
+		PublicationVersion: c.PublicationVersion.Filtered(scene),
+		TrashPolicy:        c.TrashPolicy.Filtered(workspace),
+		TrashPurge:         c.TrashPurge.Filtered(workspace),
+		APIToken:           c.APIToken.Filtered(workspace),
+		Webhook:            c.Webhook.Filtered(workspace),
+		WebhookDelivery:    c.WebhookDelivery.Filtered(workspace),
+		Project:            c.Project.Filtered(workspace),
+		ProjectMetadata:    c.ProjectMetadata.Filtered(workspace),
+		PropertySchema:     c.PropertySchema.Filtered(scene),
+		Property:           c.Property.Filtered(scene),
+		Scene:              c.Scene.Filtered(workspace),
+		SceneLock:          c.SceneLock,
+		Transaction:        c.Transaction,
+		User:               c.User,
+		Workspace:          c.Workspace,
+		Extensions:         c.Extensions,
 	}
 }
 
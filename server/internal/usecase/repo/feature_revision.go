@@ -0,0 +1,26 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type FeatureRevisionFilter struct {
+	Feature    *id.FeatureID
+	Pagination *usecasex.Pagination
+}
+
+type FeatureRevision interface {
+	Filtered(SceneFilter) FeatureRevision
+	FindByID(context.Context, id.FeatureRevisionID) (*nlslayer.FeatureRevision, error)
+	// FindByLayer returns the revisions of the layer from the newest to the oldest.
+	FindByLayer(context.Context, id.NLSLayerID, FeatureRevisionFilter) ([]*nlslayer.FeatureRevision, *usecasex.PageInfo, error)
+	// FindByLayerAfter returns the revisions of the layer newer than the given revision from the oldest to the newest.
+	FindByLayerAfter(context.Context, id.NLSLayerID, id.FeatureRevisionID) ([]*nlslayer.FeatureRevision, error)
+	SaveAll(context.Context, []*nlslayer.FeatureRevision) error
+	RemoveByLayer(context.Context, id.NLSLayerID) error
+	RemoveByScene(context.Context, id.SceneID) error
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/id"
@@ -29,7 +30,10 @@ type Project interface {
 	FindByWorkspace(context.Context, accountsID.WorkspaceID, ProjectFilter) ([]*project.Project, *usecasex.PageInfo, error)
 	FindByWorkspaces(context.Context, bool, ProjectFilter, []string, []string, []string) ([]*project.Project, *usecasex.PageInfo, error)
 	FindStarredByWorkspace(context.Context, accountsID.WorkspaceID, *usecasex.Pagination) ([]*project.Project, *usecasex.PageInfo, error)
+	FindTemplatesByWorkspace(context.Context, accountsID.WorkspaceID, *usecasex.Pagination) ([]*project.Project, *usecasex.PageInfo, error)
 	FindDeletedByWorkspace(context.Context, accountsID.WorkspaceID, *usecasex.Pagination) ([]*project.Project, *usecasex.PageInfo, error)
+	// FindDeletedBefore returns the projects of all workspaces moved to the trash at or before the time, from the oldest.
+	FindDeletedBefore(context.Context, time.Time) ([]*project.Project, error)
 	FindActiveById(context.Context, id.ProjectID) (*project.Project, error)
 
 	// TODO should be removed because project id is not unique
@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+)
+
+// ErrDuplicatedPublicationVersion is returned when the version number is already taken by a concurrent publish.
+var ErrDuplicatedPublicationVersion = errors.New("publication version already exists")
+
+type PublicationVersion interface {
+	Filtered(SceneFilter) PublicationVersion
+	// FindByProject returns the publication versions of the project from the newest to the oldest.
+	FindByProject(context.Context, id.ProjectID) ([]*publication.Version, error)
+	// FindByStory returns the publication versions of the story from the newest to the oldest.
+	FindByStory(context.Context, id.StoryID) ([]*publication.Version, error)
+	FindProjectVersion(context.Context, id.ProjectID, int) (*publication.Version, error)
+	FindStoryVersion(context.Context, id.StoryID, int) (*publication.Version, error)
+	Save(context.Context, *publication.Version) error
+	RemoveByScene(context.Context, id.SceneID) error
+}
@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+)
+
+type PublishSchedule interface {
+	Filtered(SceneFilter) PublishSchedule
+	FindByID(context.Context, id.PublishScheduleID) (*publication.Schedule, error)
+	// FindByProject returns the publish schedules of the project from the newest to the oldest.
+	FindByProject(context.Context, id.ProjectID) ([]*publication.Schedule, error)
+	// FindByStory returns the publish schedules of the story from the newest to the oldest.
+	FindByStory(context.Context, id.StoryID) ([]*publication.Schedule, error)
+	// FindDue returns the unfinished publish schedules whose next action is due at the time, from the earliest.
+	FindDue(context.Context, time.Time) ([]*publication.Schedule, error)
+	Save(context.Context, *publication.Schedule) error
+	RemoveByScene(context.Context, id.SceneID) error
+}
@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/publication"
+)
+
+type ShareToken interface {
+	Filtered(SceneFilter) ShareToken
+	FindByID(context.Context, id.ShareTokenID) (*publication.ShareToken, error)
+	FindByHash(context.Context, string) (*publication.ShareToken, error)
+	// FindByProject returns the share tokens of the project from the newest to the oldest.
+	FindByProject(context.Context, id.ProjectID) ([]*publication.ShareToken, error)
+	// FindByStory returns the share tokens of the story from the newest to the oldest.
+	FindByStory(context.Context, id.StoryID) ([]*publication.ShareToken, error)
+	Save(context.Context, *publication.ShareToken) error
+	RemoveByScene(context.Context, id.SceneID) error
+}
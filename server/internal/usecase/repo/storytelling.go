@@ -21,3 +21,9 @@ type Storytelling interface {
 	RemoveAll(context.Context, id.StoryIDList) error
 	RemoveByScene(context.Context, id.SceneID) error
 }
+
+func StorytellingLoaderBySceneFrom(r Storytelling) storytelling.LoaderByScene {
+	return func(ctx context.Context, s id.SceneID) (*storytelling.StoryList, error) {
+		return r.FindByScene(ctx, s)
+	}
+}
@@ -0,0 +1,23 @@
+package repo
+
+import (
+	"context"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/trash"
+)
+
+type TrashPolicy interface {
+	Filtered(WorkspaceFilter) TrashPolicy
+	// FindByWorkspace returns rerror.ErrNotFound when the workspace has not configured its policy.
+	FindByWorkspace(context.Context, accountsID.WorkspaceID) (*trash.Policy, error)
+	FindByWorkspaces(context.Context, accountsID.WorkspaceIDList) ([]*trash.Policy, error)
+	Save(context.Context, *trash.Policy) error
+}
+
+type TrashPurge interface {
+	Filtered(WorkspaceFilter) TrashPurge
+	// FindByWorkspace returns the purges of the workspace from the newest to the oldest.
+	FindByWorkspace(context.Context, accountsID.WorkspaceID) ([]*trash.Purge, error)
+	Save(context.Context, *trash.Purge) error
+}
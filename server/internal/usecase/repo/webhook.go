@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/webhook"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+type Webhook interface {
+	Filtered(WorkspaceFilter) Webhook
+	FindByID(context.Context, id.WebhookID) (*webhook.Webhook, error)
+	// FindByWorkspace returns the webhooks of the workspace from the newest to the oldest.
+	FindByWorkspace(context.Context, accountsID.WorkspaceID) ([]*webhook.Webhook, error)
+	Save(context.Context, *webhook.Webhook) error
+	Remove(context.Context, id.WebhookID) error
+}
+
+type WebhookDeliveryFilter struct {
+	Status     *webhook.DeliveryStatus
+	Pagination *usecasex.Pagination
+}
+
+type WebhookDelivery interface {
+	Filtered(WorkspaceFilter) WebhookDelivery
+	// FindByWebhook returns the deliveries of the webhook from the newest to the oldest.
+	FindByWebhook(context.Context, id.WebhookID, WebhookDeliveryFilter) ([]*webhook.Delivery, *usecasex.PageInfo, error)
+	// FindDue returns at most limit pending deliveries whose next attempt is due at the time, from the earliest.
+	FindDue(context.Context, time.Time, int) ([]*webhook.Delivery, error)
+	Save(context.Context, *webhook.Delivery) error
+	RemoveByWebhook(context.Context, id.WebhookID) error
+}
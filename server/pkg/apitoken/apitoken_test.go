@@ -0,0 +1,101 @@
+package apitoken
+
+import (
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	base := func() *Builder {
+		return New().NewID().Workspace(accountsID.NewWorkspaceID()).Hash(HashToken("token"))
+	}
+
+	tests := []struct {
+		name    string
+		builder *Builder
+		wantErr error
+	}{
+		{name: "read", builder: base().Scopes(Scopes{ScopeRead})},
+		{name: "all scopes", builder: base().Scopes(Scopes{ScopeRead, ScopeWrite, ScopePublish})},
+		{name: "no scope", builder: base(), wantErr: ErrNoScope},
+		{name: "invalid scope", builder: base().Scopes(Scopes{"admin"}), wantErr: ErrInvalidScope},
+		{name: "no hash", builder: New().NewID().Workspace(accountsID.NewWorkspaceID()).Scopes(Scopes{ScopeRead}), wantErr: idx.ErrInvalidID},
+		{name: "no workspace", builder: New().NewID().Hash("h").Scopes(Scopes{ScopeRead}), wantErr: idx.ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			at, err := tt.builder.Build()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, at)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, at.ID().Timestamp(), at.CreatedAt())
+		})
+	}
+}
+
+func TestAPIToken_Validate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	at := New().NewID().Workspace(accountsID.NewWorkspaceID()).Hash("h").Scopes(Scopes{ScopeRead}).ExpiresAt(&future).MustBuild()
+	assert.NoError(t, at.Validate(now))
+
+	at2 := New().NewID().Workspace(accountsID.NewWorkspaceID()).Hash("h").Scopes(Scopes{ScopeRead}).ExpiresAt(&past).MustBuild()
+	assert.ErrorIs(t, at2.Validate(now), ErrAPITokenExpired)
+
+	at.Revoke(now)
+	assert.ErrorIs(t, at.Validate(now), ErrAPITokenRevoked)
+	at.Revoke(future)
+	assert.Equal(t, &now, at.RevokedAt())
+}
+
+func TestAPIToken_Use(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	at := New().NewID().Workspace(accountsID.NewWorkspaceID()).Hash("h").Scopes(Scopes{ScopeRead}).MustBuild()
+
+	assert.True(t, at.Use(now, time.Minute))
+	assert.False(t, at.Use(now.Add(30*time.Second), time.Minute))
+	assert.Equal(t, &now, at.LastUsedAt())
+	assert.True(t, at.Use(now.Add(time.Minute), time.Minute))
+	assert.Equal(t, now.Add(time.Minute), *at.LastUsedAt())
+}
+
+func TestGenerateToken(t *testing.T) {
+	token, hash, err := GenerateToken()
+	assert.NoError(t, err)
+	assert.True(t, IsToken(token))
+	assert.Equal(t, HashToken(token), hash)
+	assert.NotEqual(t, token, hash)
+
+	token2, _, err := GenerateToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, token2)
+	assert.False(t, IsToken("eyJhbGciOiJSUzI1NiJ9.e30.sig"))
+}
+
+func TestScopes(t *testing.T) {
+	s, err := ScopesFrom([]string{"write", "read", "write"})
+	assert.NoError(t, err)
+	assert.Equal(t, Scopes{ScopeWrite, ScopeRead}, s)
+	assert.Equal(t, []string{"write", "read"}, s.Strings())
+
+	_, err = ScopesFrom([]string{"read", "admin"})
+	assert.ErrorIs(t, err, ErrInvalidScope)
+
+	assert.True(t, Scopes{ScopePublish}.Allows(ScopeRead))
+	assert.False(t, Scopes{ScopePublish}.Allows(ScopeWrite))
+	assert.True(t, Scopes{ScopePublish}.CanWrite())
+	assert.False(t, Scopes{ScopeRead}.CanWrite())
+	assert.False(t, Scopes{}.Allows(ScopeRead))
+}
@@ -0,0 +1,90 @@
+package apitoken
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+)
+
+type Builder struct {
+	t *APIToken
+}
+
+func New() *Builder {
+	return &Builder{t: &APIToken{}}
+}
+
+func (b *Builder) Build() (*APIToken, error) {
+	if b.t.id.IsNil() || b.t.workspace.IsNil() || b.t.hash == "" {
+		return nil, idx.ErrInvalidID
+	}
+	if len(b.t.scopes) == 0 {
+		return nil, ErrNoScope
+	}
+	for _, s := range b.t.scopes {
+		if _, ok := ScopeFrom(string(s)); !ok {
+			return nil, ErrInvalidScope
+		}
+	}
+	return b.t, nil
+}
+
+func (b *Builder) MustBuild() *APIToken {
+	t, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (b *Builder) ID(id id.APITokenID) *Builder {
+	b.t.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.t.id = id.NewAPITokenID()
+	return b
+}
+
+func (b *Builder) Workspace(workspace accountsID.WorkspaceID) *Builder {
+	b.t.workspace = workspace
+	return b
+}
+
+func (b *Builder) Name(name string) *Builder {
+	b.t.name = name
+	return b
+}
+
+func (b *Builder) Scopes(scopes Scopes) *Builder {
+	b.t.scopes = append(Scopes{}, scopes...)
+	return b
+}
+
+func (b *Builder) Hash(hash string) *Builder {
+	b.t.hash = hash
+	return b
+}
+
+func (b *Builder) ExpiresAt(expiresAt *time.Time) *Builder {
+	b.t.expiresAt = cloneTime(expiresAt)
+	return b
+}
+
+func (b *Builder) RevokedAt(revokedAt *time.Time) *Builder {
+	b.t.revokedAt = cloneTime(revokedAt)
+	return b
+}
+
+func (b *Builder) LastUsedAt(lastUsedAt *time.Time) *Builder {
+	b.t.lastUsedAt = cloneTime(lastUsedAt)
+	return b
+}
+
+func (b *Builder) CreatedBy(createdBy *accountsID.UserID) *Builder {
+	b.t.createdBy = createdBy
+	return b
+}
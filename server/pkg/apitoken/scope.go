@@ -0,0 +1,65 @@
+package apitoken
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrInvalidScope = errors.New("invalid api token scope")
+
+type Scope string
+
+const (
+	// ScopeRead allows reading the projects, layers, features and assets of the workspace.
+	ScopeRead Scope = "read"
+	// ScopeWrite allows changing layers and features and uploading assets.
+	ScopeWrite Scope = "write"
+	// ScopePublish allows publishing the projects of the workspace.
+	ScopePublish Scope = "publish"
+)
+
+func ScopeFrom(s string) (Scope, bool) {
+	switch Scope(s) {
+	case ScopeRead, ScopeWrite, ScopePublish:
+		return Scope(s), true
+	}
+	return "", false
+}
+
+type Scopes []Scope
+
+func ScopesFrom(scopes []string) (Scopes, error) {
+	res := make(Scopes, 0, len(scopes))
+	for _, s := range scopes {
+		sc, ok := ScopeFrom(s)
+		if !ok {
+			return nil, ErrInvalidScope
+		}
+		if !slices.Contains(res, sc) {
+			res = append(res, sc)
+		}
+	}
+	return res, nil
+}
+
+// Allows reports whether the scopes grant s. Every scope grants read since changing or publishing data requires reading it.
+func (s Scopes) Allows(sc Scope) bool {
+	if sc == ScopeRead {
+		return len(s) > 0
+	}
+	return slices.Contains(s, sc)
+}
+
+// CanWrite reports whether the operator of the token needs write access to the workspace.
+// Publishing a project requires write access in the usecases, so the publish scope needs it too.
+func (s Scopes) CanWrite() bool {
+	return s.Allows(ScopeWrite) || s.Allows(ScopePublish)
+}
+
+func (s Scopes) Strings() []string {
+	res := make([]string, 0, len(s))
+	for _, sc := range s {
+		res = append(res, string(sc))
+	}
+	return res
+}
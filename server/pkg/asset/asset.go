@@ -24,6 +24,8 @@ type Asset struct {
 	url         string
 	contentType string
 	coreSupport bool
+	metadata    *Metadata
+	thumbnail   string // url of the generated thumbnail
 }
 
 func (a *Asset) ID() id.AssetID {
@@ -62,6 +64,23 @@ func (a *Asset) CoreSupport() bool {
 	return a.coreSupport
 }
 
+func (a *Asset) Metadata() *Metadata {
+	return a.metadata.Clone()
+}
+
+func (a *Asset) SetMetadata(metadata *Metadata) {
+	a.metadata = metadata.Clone()
+}
+
+// ThumbnailURL is the URL of a small preview of an image asset. It is empty for other assets and small images.
+func (a *Asset) ThumbnailURL() string {
+	return a.thumbnail
+}
+
+func (a *Asset) SetThumbnailURL(thumbnail string) {
+	a.thumbnail = thumbnail
+}
+
 func (a *Asset) CreatedAt() time.Time {
 	if a == nil {
 		return time.Time{}
@@ -0,0 +1,72 @@
+package extraction
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/asset"
+)
+
+var (
+	csvLatColumns = []string{"lat", "latitude"}
+	csvLngColumns = []string{"lng", "lon", "long", "longitude"}
+)
+
+// extractCSV reads the columns and the number of rows of a CSV with a header row.
+// The bounds are computed when the CSV has latitude and longitude columns.
+func extractCSV(data []byte) (*asset.Metadata, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	attrs := make([]string, len(header))
+	copy(attrs, header)
+	latCol, lngCol := csvColumn(attrs, csvLatColumns), csvColumn(attrs, csvLngColumns)
+
+	var b bounds
+	count := 0
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		count++
+		if latCol < 0 || lngCol < 0 || latCol >= len(rec) || lngCol >= len(rec) {
+			continue
+		}
+		lat, err1 := strconv.ParseFloat(strings.TrimSpace(rec[latCol]), 64)
+		lng, err2 := strconv.ParseFloat(strings.TrimSpace(rec[lngCol]), 64)
+		if err1 == nil && err2 == nil {
+			b.extendPoint(lng, lat)
+		}
+	}
+
+	return &asset.Metadata{
+		Bounds:       b.result(),
+		FeatureCount: &count,
+		Attributes:   attrs,
+	}, nil
+}
+
+func csvColumn(header []string, names []string) int {
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		for _, n := range names {
+			if h == n {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,136 @@
+// Package extraction reads format-specific metadata, such as image dimensions and data extents, from asset files.
+package extraction
+
+import (
+	"errors"
+	"math"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/asset"
+)
+
+// MaxSize is the largest file metadata is extracted from. Larger files are stored without metadata.
+const MaxSize = 100 * 1024 * 1024
+
+var ErrUnsupportedFormat = errors.New("unsupported asset format")
+
+type format int
+
+const (
+	formatUnknown format = iota
+	formatImage
+	formatJSON
+	formatGeoJSON
+	formatCSV
+	formatShapefile
+	formatShapefileZip
+	formatGLTF
+	formatGLB
+)
+
+func formatOf(name string) format {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return formatImage
+	case ".json":
+		// either a GeoJSON or a 3D Tiles tileset
+		return formatJSON
+	case ".geojson":
+		return formatGeoJSON
+	case ".csv":
+		return formatCSV
+	case ".shp":
+		return formatShapefile
+	case ".zip":
+		return formatShapefileZip
+	case ".gltf":
+		return formatGLTF
+	case ".glb":
+		return formatGLB
+	}
+	return formatUnknown
+}
+
+// IsSupported reports whether metadata can be extracted from a file with the name.
+func IsSupported(name string) bool {
+	return formatOf(name) != formatUnknown
+}
+
+// IsImage reports whether the file with the name is an image that a thumbnail can be made of.
+func IsImage(name string) bool {
+	return formatOf(name) == formatImage
+}
+
+// Extract returns the metadata of the file with the name and the content data.
+// It returns nil metadata when nothing could be read from a file of a supported format.
+func Extract(name string, data []byte) (*asset.Metadata, error) {
+	var m *asset.Metadata
+	var err error
+	switch formatOf(name) {
+	case formatImage:
+		m, err = extractImage(data)
+	case formatJSON:
+		if isTileset(data) {
+			m, err = extractTileset(data)
+		} else {
+			m, err = extractGeoJSON(data)
+		}
+	case formatGeoJSON:
+		m, err = extractGeoJSON(data)
+	case formatCSV:
+		m, err = extractCSV(data)
+	case formatShapefile:
+		m, err = extractShapefile(data)
+	case formatShapefileZip:
+		m, err = extractShapefileZip(data)
+	case formatGLTF:
+		m, err = extractGLTF(data)
+	case formatGLB:
+		m, err = extractGLB(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil || m.IsEmpty() {
+		return nil, err
+	}
+	return m, nil
+}
+
+// bounds accumulates an axis-aligned box of two or three dimensions.
+type bounds struct {
+	min, max []float64
+}
+
+func (b *bounds) extend(lo, hi []float64) {
+	if len(lo) < 2 || len(lo) != len(hi) {
+		return
+	}
+	if b.min == nil {
+		b.min = slices.Clone(lo)
+		b.max = slices.Clone(hi)
+		return
+	}
+	n := len(b.min)
+	if len(lo) < n {
+		// keep the dimensions that every extent has
+		n = len(lo)
+		b.min, b.max = b.min[:n], b.max[:n]
+	}
+	for i := 0; i < n; i++ {
+		b.min[i] = math.Min(b.min[i], lo[i])
+		b.max[i] = math.Max(b.max[i], hi[i])
+	}
+}
+
+func (b *bounds) extendPoint(p ...float64) {
+	b.extend(p, p)
+}
+
+func (b *bounds) result() *asset.Bounds {
+	if b.min == nil {
+		return nil
+	}
+	return &asset.Bounds{Min: b.min, Max: b.max}
+}
@@ -0,0 +1,238 @@
+package extraction
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	var pngBuf bytes.Buffer
+	require.NoError(t, png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 30, 20))))
+
+	glbJSON := []byte(`{"accessors":[{"min":[-1,-2,-3],"max":[1,2,3]}],"meshes":[{"primitives":[{"attributes":{"POSITION":0}}]}]}`)
+	glb := binary.LittleEndian.AppendUint32([]byte("glTF"), 2)
+	glb = binary.LittleEndian.AppendUint32(glb, uint32(glbHeaderSize+8+len(glbJSON)))
+	glb = binary.LittleEndian.AppendUint32(glb, uint32(len(glbJSON)))
+	glb = binary.LittleEndian.AppendUint32(glb, glbChunkTypeJSON)
+	glb = append(glb, glbJSON...)
+
+	tests := []struct {
+		name     string
+		file     string
+		data     []byte
+		want     *asset.Metadata
+		wantErr  error
+		wantNone bool
+	}{
+		{
+			name: "png",
+			file: "image.PNG",
+			data: pngBuf.Bytes(),
+			want: &asset.Metadata{Width: lo.ToPtr(30), Height: lo.ToPtr(20)},
+		},
+		{
+			name: "geojson feature collection",
+			file: "data.geojson",
+			data: []byte(`{"type":"FeatureCollection","features":[
+				{"type":"Feature","geometry":{"type":"Point","coordinates":[139,35]},"properties":{"name":"a"}},
+				{"type":"Feature","geometry":{"type":"LineString","coordinates":[[140,36],[141,34]]},"properties":{"id":1,"name":"b"}}
+			]}`),
+			want: &asset.Metadata{
+				Bounds:       &asset.Bounds{Min: []float64{139, 34}, Max: []float64{141, 36}},
+				FeatureCount: lo.ToPtr(2),
+				Attributes:   []string{"id", "name"},
+			},
+		},
+		{
+			name: "geojson feature",
+			file: "data.json",
+			data: []byte(`{"type":"Feature","geometry":{"type":"Point","coordinates":[139,35]},"properties":null}`),
+			want: &asset.Metadata{
+				Bounds:       &asset.Bounds{Min: []float64{139, 35}, Max: []float64{139, 35}},
+				FeatureCount: lo.ToPtr(1),
+			},
+		},
+		{
+			name: "csv",
+			file: "data.csv",
+			data: []byte("\xef\xbb\xbfname,Latitude,lng\na,35,139\nb,36.5,140\nc,,\n"),
+			want: &asset.Metadata{
+				Bounds:       &asset.Bounds{Min: []float64{139, 35}, Max: []float64{140, 36.5}},
+				FeatureCount: lo.ToPtr(3),
+				Attributes:   []string{"name", "Latitude", "lng"},
+			},
+		},
+		{
+			name: "csv without coordinates",
+			file: "data.csv",
+			data: []byte("a,b\n1,2\n"),
+			want: &asset.Metadata{
+				FeatureCount: lo.ToPtr(1),
+				Attributes:   []string{"a", "b"},
+			},
+		},
+		{
+			name: "shapefile",
+			file: "point.shp",
+			data: lo.Must(os.ReadFile("../../shp/test_files/point.shp")),
+			want: &asset.Metadata{
+				Bounds:       &asset.Bounds{Min: []float64{0, 5}, Max: []float64{10, 10}},
+				FeatureCount: lo.ToPtr(3),
+			},
+		},
+		{
+			name: "gltf",
+			file: "model.gltf",
+			data: glbJSON,
+			want: &asset.Metadata{Bounds: &asset.Bounds{Min: []float64{-1, -2, -3}, Max: []float64{1, 2, 3}}},
+		},
+		{
+			name: "glb",
+			file: "model.glb",
+			data: glb,
+			want: &asset.Metadata{Bounds: &asset.Bounds{Min: []float64{-1, -2, -3}, Max: []float64{1, 2, 3}}},
+		},
+		{
+			name:    "invalid glb",
+			file:    "model.glb",
+			data:    []byte("glTF"),
+			wantErr: ErrInvalidGLB,
+		},
+		{
+			name: "tileset region",
+			file: "tileset.json",
+			data: []byte(`{"asset":{"version":"1.0"},"root":{"boundingVolume":{"region":[0,0,` +
+				`1.5707963267948966,0.7853981633974483,-10,100]}}}`),
+			want: &asset.Metadata{Bounds: &asset.Bounds{Min: []float64{0, 0, -10}, Max: []float64{90, 45, 100}}},
+		},
+		{
+			name: "tileset box",
+			file: "tileset.json",
+			data: []byte(`{"asset":{"version":"1.0"},"root":{"boundingVolume":{"box":[10,20,30,1,0,0,0,2,0,0,0,3]}}}`),
+			want: &asset.Metadata{Bounds: &asset.Bounds{Min: []float64{9, 18, 27}, Max: []float64{11, 22, 33}}},
+		},
+		{
+			name:     "nothing to read",
+			file:     "empty.gltf",
+			data:     []byte(`{}`),
+			wantNone: true,
+		},
+		{
+			name:    "unsupported",
+			file:    "doc.pdf",
+			data:    []byte("%PDF"),
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Extract(tt.file, tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNone {
+				assert.Nil(t, got)
+				return
+			}
+			if tt.want.Bounds != nil {
+				require.NotNil(t, got.Bounds)
+				assert.InDeltaSlice(t, tt.want.Bounds.Min, got.Bounds.Min, 1e-9)
+				assert.InDeltaSlice(t, tt.want.Bounds.Max, got.Bounds.Max, 1e-9)
+				got.Bounds, tt.want.Bounds = nil, nil
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtract_ShapefileZip(t *testing.T) {
+	got, err := Extract("countries.zip", lo.Must(os.ReadFile("../../shp/test_files/ne_110m_admin_0_countries.zip")))
+	require.NoError(t, err)
+	assert.Equal(t, 177, *got.FeatureCount)
+	assert.Contains(t, got.Attributes, "NAME")
+	require.NotNil(t, got.Bounds)
+	assert.InDelta(t, -180, got.Bounds.Min[0], 1e-6)
+	assert.InDelta(t, 180, got.Bounds.Max[0], 1e-6)
+}
+
+func TestExtract_JPEGLocation(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 4)), nil))
+	data := buf.Bytes()
+
+	// insert an EXIF segment with GPS 35°30'0"S 139°45'0"E right after the SOI marker
+	exif := append([]byte("Exif\x00\x00"), testGPSTIFF()...)
+	app1 := binary.BigEndian.AppendUint16([]byte{0xFF, jpegMarkerAPP1}, uint16(len(exif)+2))
+	data = append(append(append([]byte{}, data[:2]...), append(app1, exif...)...), data[2:]...)
+
+	got, err := Extract("photo.jpg", data)
+	require.NoError(t, err)
+	assert.Equal(t, 8, *got.Width)
+	assert.Equal(t, 4, *got.Height)
+	require.NotNil(t, got.Location)
+	assert.InDelta(t, -35.5, got.Location.Lat, 1e-9)
+	assert.InDelta(t, 139.75, got.Location.Lng, 1e-9)
+
+	got, err = Extract("photo.jpg", buf.Bytes())
+	require.NoError(t, err)
+	assert.Nil(t, got.Location)
+}
+
+// testGPSTIFF builds a little-endian TIFF with IFD0 pointing to a GPS IFD.
+func testGPSTIFF() []byte {
+	le := binary.LittleEndian
+	b := []byte("II")
+	b = le.AppendUint16(b, 42)
+	b = le.AppendUint32(b, 8)
+
+	// IFD0 at 8: one entry, the GPS IFD pointer
+	gpsOffset := uint32(8 + 2 + tiffIFDEntrySize + 4)
+	b = le.AppendUint16(b, 1)
+	b = appendTIFFEntry(b, exifTagGPSIFD, exifTypeLong, 1, le.AppendUint32(nil, gpsOffset))
+	b = le.AppendUint32(b, 0)
+
+	// GPS IFD: four entries followed by the rationals
+	rationals := gpsOffset + 2 + 4*tiffIFDEntrySize + 4
+	b = le.AppendUint16(b, 4)
+	b = appendTIFFEntry(b, exifTagGPSLatRef, exifTypeASCII, 2, []byte{'S', 0, 0, 0})
+	b = appendTIFFEntry(b, exifTagGPSLat, exifTypeRational, 3, le.AppendUint32(nil, rationals))
+	b = appendTIFFEntry(b, exifTagGPSLngRef, exifTypeASCII, 2, []byte{'E', 0, 0, 0})
+	b = appendTIFFEntry(b, exifTagGPSLng, exifTypeRational, 3, le.AppendUint32(nil, rationals+24))
+	b = le.AppendUint32(b, 0)
+
+	for _, v := range [][2]uint32{{35, 1}, {30, 1}, {0, 1}, {139, 1}, {45, 1}, {0, 1}} {
+		b = le.AppendUint32(b, v[0])
+		b = le.AppendUint32(b, v[1])
+	}
+	return b
+}
+
+func appendTIFFEntry(b []byte, tag, typ uint16, count uint32, value []byte) []byte {
+	b = binary.LittleEndian.AppendUint16(b, tag)
+	b = binary.LittleEndian.AppendUint16(b, typ)
+	b = binary.LittleEndian.AppendUint32(b, count)
+	return append(b, value...)
+}
+
+func TestBounds(t *testing.T) {
+	var b bounds
+	assert.Nil(t, b.result())
+	b.extend([]float64{1}, []float64{2})
+	assert.Nil(t, b.result())
+	b.extendPoint(1, 2, 3)
+	b.extend([]float64{0, 5}, []float64{4, 6})
+	assert.Equal(t, &asset.Bounds{Min: []float64{0, 2}, Max: []float64{4, 6}}, b.result())
+}
@@ -0,0 +1,55 @@
+package extraction
+
+import (
+	"sort"
+
+	"github.com/reearth/orb/geojson"
+	"github.com/reearth/reearth/server/pkg/asset"
+)
+
+func extractGeoJSON(data []byte) (*asset.Metadata, error) {
+	var features []*geojson.Feature
+	if fc, err := geojson.UnmarshalFeatureCollection(data); err == nil {
+		features = fc.Features
+	} else {
+		f, err2 := geojson.UnmarshalFeature(data)
+		if err2 != nil {
+			return nil, err
+		}
+		features = []*geojson.Feature{f}
+	}
+
+	var b bounds
+	attrs := map[string]struct{}{}
+	for _, f := range features {
+		if f == nil {
+			continue
+		}
+		if f.Geometry != nil {
+			bound := f.Geometry.Bound()
+			b.extend([]float64{bound.Min[0], bound.Min[1]}, []float64{bound.Max[0], bound.Max[1]})
+		}
+		for k := range f.Properties {
+			attrs[k] = struct{}{}
+		}
+	}
+
+	count := len(features)
+	return &asset.Metadata{
+		Bounds:       b.result(),
+		FeatureCount: &count,
+		Attributes:   sortedKeys(attrs),
+	}, nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,64 @@
+package extraction
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	"github.com/reearth/reearth/server/pkg/asset"
+)
+
+var ErrInvalidGLB = errors.New("invalid glb")
+
+const (
+	glbHeaderSize    = 12
+	glbChunkTypeJSON = 0x4E4F534A
+)
+
+type gltfDocument struct {
+	Accessors []struct {
+		Min []float64 `json:"min"`
+		Max []float64 `json:"max"`
+	} `json:"accessors"`
+	Meshes []struct {
+		Primitives []struct {
+			Attributes map[string]int `json:"attributes"`
+		} `json:"primitives"`
+	} `json:"meshes"`
+}
+
+// extractGLTF reads the bounds of the vertex positions of a glTF model in its local coordinates.
+// Node transforms are not applied.
+func extractGLTF(data []byte) (*asset.Metadata, error) {
+	var doc gltfDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var b bounds
+	for _, m := range doc.Meshes {
+		for _, p := range m.Primitives {
+			i, ok := p.Attributes["POSITION"]
+			if !ok || i < 0 || i >= len(doc.Accessors) {
+				continue
+			}
+			a := doc.Accessors[i]
+			if len(a.Min) == 3 && len(a.Max) == 3 {
+				b.extend(a.Min, a.Max)
+			}
+		}
+	}
+	return &asset.Metadata{Bounds: b.result()}, nil
+}
+
+// extractGLB reads the JSON chunk of a binary glTF model.
+func extractGLB(data []byte) (*asset.Metadata, error) {
+	if len(data) < glbHeaderSize+8 || string(data[:4]) != "glTF" {
+		return nil, ErrInvalidGLB
+	}
+	l := binary.LittleEndian.Uint32(data[glbHeaderSize:])
+	if binary.LittleEndian.Uint32(data[glbHeaderSize+4:]) != glbChunkTypeJSON || uint64(l) > uint64(len(data)-glbHeaderSize-8) {
+		return nil, ErrInvalidGLB
+	}
+	return extractGLTF(data[glbHeaderSize+8 : glbHeaderSize+8+int(l)])
+}
@@ -0,0 +1,174 @@
+package extraction
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+
+	// Import image decoders
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/reearth/reearth/server/pkg/asset"
+)
+
+func extractImage(data []byte) (*asset.Metadata, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &asset.Metadata{
+		Width:    &cfg.Width,
+		Height:   &cfg.Height,
+		Location: exifLocation(data),
+	}, nil
+}
+
+const (
+	exifTagGPSIFD       = 0x8825
+	exifTagGPSLatRef    = 0x0001
+	exifTagGPSLat       = 0x0002
+	exifTagGPSLngRef    = 0x0003
+	exifTagGPSLng       = 0x0004
+	exifTypeASCII       = 2
+	exifTypeLong        = 4
+	exifTypeRational    = 5
+	tiffIFDEntrySize    = 12
+	jpegMarkerAPP1      = 0xE1
+	jpegMarkerStartScan = 0xDA
+)
+
+// exifLocation reads the GPS position of a JPEG photo from its EXIF tags.
+func exifLocation(data []byte) *asset.Location {
+	t := newTIFF(jpegExif(data))
+	if t == nil {
+		return nil
+	}
+	ifd0 := t.ifd(int(t.order.Uint32(t.data[4:])))
+	gpsPtr, ok := ifd0[exifTagGPSIFD]
+	if !ok || gpsPtr.typ != exifTypeLong {
+		return nil
+	}
+	gps := t.ifd(int(t.order.Uint32(gpsPtr.value)))
+
+	lat, ok1 := t.degrees(gps[exifTagGPSLat])
+	lng, ok2 := t.degrees(gps[exifTagGPSLng])
+	if !ok1 || !ok2 {
+		return nil
+	}
+	if t.ref(gps[exifTagGPSLatRef]) == 'S' {
+		lat = -lat
+	}
+	if t.ref(gps[exifTagGPSLngRef]) == 'W' {
+		lng = -lng
+	}
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil
+	}
+	return &asset.Location{Lat: lat, Lng: lng}
+}
+
+// jpegExif returns the TIFF structure in the EXIF APP1 segment of a JPEG file.
+func jpegExif(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		if marker == jpegMarkerStartScan {
+			return nil
+		}
+		l := int(binary.BigEndian.Uint16(data[i+2:]))
+		if l < 2 || i+2+l > len(data) {
+			return nil
+		}
+		if seg := data[i+4 : i+2+l]; marker == jpegMarkerAPP1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return seg[6:]
+		}
+		i += 2 + l
+	}
+	return nil
+}
+
+type tiff struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+type tiffEntry struct {
+	typ   uint16
+	count uint32
+	// value is the inline value, or the offset of the value when it does not fit in four bytes
+	value []byte
+}
+
+func newTIFF(data []byte) *tiff {
+	if len(data) < 8 {
+		return nil
+	}
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil
+	}
+	if order.Uint16(data[2:]) != 42 {
+		return nil
+	}
+	return &tiff{data: data, order: order}
+}
+
+func (t *tiff) ifd(offset int) map[uint16]tiffEntry {
+	if offset < 0 || offset+2 > len(t.data) {
+		return nil
+	}
+	n := int(t.order.Uint16(t.data[offset:]))
+	entries := make(map[uint16]tiffEntry, n)
+	for i := 0; i < n; i++ {
+		o := offset + 2 + i*tiffIFDEntrySize
+		if o+tiffIFDEntrySize > len(t.data) {
+			break
+		}
+		entries[t.order.Uint16(t.data[o:])] = tiffEntry{
+			typ:   t.order.Uint16(t.data[o+2:]),
+			count: t.order.Uint32(t.data[o+4:]),
+			value: t.data[o+8 : o+12],
+		}
+	}
+	return entries
+}
+
+// degrees converts a GPS coordinate of three rationals, degrees, minutes and seconds, into degrees.
+func (t *tiff) degrees(e tiffEntry) (float64, bool) {
+	if e.typ != exifTypeRational || e.count != 3 {
+		return 0, false
+	}
+	offset := int(t.order.Uint32(e.value))
+	if offset < 0 || offset+24 > len(t.data) {
+		return 0, false
+	}
+	var v [3]float64
+	for i := range v {
+		num := t.order.Uint32(t.data[offset+i*8:])
+		den := t.order.Uint32(t.data[offset+i*8+4:])
+		if den == 0 {
+			return 0, false
+		}
+		v[i] = float64(num) / float64(den)
+	}
+	return v[0] + v[1]/60 + v[2]/3600, true
+}
+
+func (t *tiff) ref(e tiffEntry) byte {
+	if e.typ != exifTypeASCII || e.count == 0 || e.count > 4 {
+		return 0
+	}
+	return e.value[0]
+}
@@ -0,0 +1,86 @@
+package extraction
+
+import (
+	"bytes"
+
+	"github.com/reearth/reearth/server/pkg/asset"
+	"github.com/reearth/reearth/server/pkg/shp"
+)
+
+// extractShapefileZip reads a zipped shapefile. The bounds are converted into WGS84 according to the .prj file.
+func extractShapefileZip(data []byte) (*asset.Metadata, error) {
+	zr, err := shp.ReadZipFrom(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	proj, err := shp.ParseProjection(zr.Projection())
+	if err != nil {
+		// the extent is unknown but the features and the attributes can still be read
+		proj = nil
+	}
+
+	var b bounds
+	count := 0
+	for zr.Next() {
+		_, s := zr.Shape()
+		count++
+		if proj != nil {
+			extendShape(&b, s, proj)
+		}
+	}
+	if err := zr.Err(); err != nil {
+		return nil, err
+	}
+
+	var attrs []string
+	for _, f := range zr.Fields() {
+		attrs = append(attrs, f.String())
+	}
+
+	return &asset.Metadata{
+		Bounds:       b.result(),
+		FeatureCount: &count,
+		Attributes:   attrs,
+	}, nil
+}
+
+// extractShapefile reads a .shp file uploaded without its sidecar files, so it has neither attributes nor a projection.
+func extractShapefile(data []byte) (*asset.Metadata, error) {
+	r, err := shp.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var b bounds
+	count := 0
+	for r.Next() {
+		_, s := r.Shape()
+		count++
+		extendShape(&b, s, nil)
+	}
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	return &asset.Metadata{
+		Bounds:       b.result(),
+		FeatureCount: &count,
+	}, nil
+}
+
+func extendShape(b *bounds, s shp.Shape, proj *shp.Projection) {
+	if s == nil {
+		return
+	}
+	if _, ok := s.(*shp.Null); ok {
+		return
+	}
+	box := s.BBox()
+	minX, minY := proj.ToWGS84(box.MinX, box.MinY)
+	maxX, maxY := proj.ToWGS84(box.MaxX, box.MaxY)
+	b.extend([]float64{minX, minY}, []float64{maxX, maxY})
+}
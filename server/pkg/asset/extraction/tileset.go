@@ -0,0 +1,65 @@
+package extraction
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/reearth/reearth/server/pkg/asset"
+)
+
+type tilesetDocument struct {
+	Asset *struct {
+		Version string `json:"version"`
+	} `json:"asset"`
+	Root *struct {
+		BoundingVolume struct {
+			Region []float64 `json:"region"`
+			Box    []float64 `json:"box"`
+			Sphere []float64 `json:"sphere"`
+		} `json:"boundingVolume"`
+	} `json:"root"`
+}
+
+func isTileset(data []byte) bool {
+	var doc tilesetDocument
+	return json.Unmarshal(data, &doc) == nil && doc.Asset != nil && doc.Root != nil
+}
+
+// extractTileset reads the bounding volume of the root tile of a 3D Tiles tileset.
+// A region is converted into degrees and meters. A box or a sphere is in the coordinates of the tileset,
+// which are usually earth-centered, and the transform of the root tile is not applied.
+func extractTileset(data []byte) (*asset.Metadata, error) {
+	var doc tilesetDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Root == nil {
+		return nil, nil
+	}
+
+	var b bounds
+	v := doc.Root.BoundingVolume
+	switch {
+	case len(v.Region) == 6:
+		deg := 180 / math.Pi
+		b.extend(
+			[]float64{v.Region[0] * deg, v.Region[1] * deg, v.Region[4]},
+			[]float64{v.Region[2] * deg, v.Region[3] * deg, v.Region[5]},
+		)
+	case len(v.Box) == 12:
+		// the center followed by the x, y and z half-axes
+		lo, hi := make([]float64, 3), make([]float64, 3)
+		for i := 0; i < 3; i++ {
+			half := math.Abs(v.Box[3+i]) + math.Abs(v.Box[6+i]) + math.Abs(v.Box[9+i])
+			lo[i], hi[i] = v.Box[i]-half, v.Box[i]+half
+		}
+		b.extend(lo, hi)
+	case len(v.Sphere) == 4:
+		r := v.Sphere[3]
+		b.extend(
+			[]float64{v.Sphere[0] - r, v.Sphere[1] - r, v.Sphere[2] - r},
+			[]float64{v.Sphere[0] + r, v.Sphere[1] + r, v.Sphere[2] + r},
+		)
+	}
+	return &asset.Metadata{Bounds: b.result()}, nil
+}
@@ -0,0 +1,60 @@
+package asset
+
+import "slices"
+
+// Metadata is the format-specific information extracted from the file of an asset on upload.
+// Only the fields that apply to the format of the file are set.
+type Metadata struct {
+	// Width and Height are the dimensions of an image in pixels.
+	Width  *int
+	Height *int
+	// Location is where a photo was taken, read from its EXIF GPS tags.
+	Location *Location
+	// Bounds is the extent of the data. It is in WGS84 degrees for GeoJSON, CSV, shapefiles and 3D Tiles regions,
+	// and in the coordinates of the model or the tileset for glTF and 3D Tiles boxes and spheres.
+	Bounds *Bounds
+	// FeatureCount is the number of features of GeoJSON and shapefiles, or the number of rows of a CSV.
+	FeatureCount *int
+	// Attributes are the property names of GeoJSON features, the columns of a CSV or the DBF fields of a shapefile.
+	Attributes []string
+}
+
+type Location struct {
+	Lat float64
+	Lng float64
+}
+
+// Bounds is an axis-aligned box. Min and Max have two or three elements in x, y, z order.
+type Bounds struct {
+	Min []float64
+	Max []float64
+}
+
+func (m *Metadata) IsEmpty() bool {
+	return m == nil || m.Width == nil && m.Height == nil && m.Location == nil && m.Bounds == nil && m.FeatureCount == nil && len(m.Attributes) == 0
+}
+
+func (m *Metadata) Clone() *Metadata {
+	if m == nil {
+		return nil
+	}
+	res := &Metadata{
+		Width:        clonePtr(m.Width),
+		Height:       clonePtr(m.Height),
+		Location:     clonePtr(m.Location),
+		FeatureCount: clonePtr(m.FeatureCount),
+		Attributes:   slices.Clone(m.Attributes),
+	}
+	if m.Bounds != nil {
+		res.Bounds = &Bounds{Min: slices.Clone(m.Bounds.Min), Max: slices.Clone(m.Bounds.Max)}
+	}
+	return res
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	v2 := *v
+	return &v2
+}
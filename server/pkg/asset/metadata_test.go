@@ -0,0 +1,51 @@
+package asset
+
+import (
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata_IsEmpty(t *testing.T) {
+	assert.True(t, (*Metadata)(nil).IsEmpty())
+	assert.True(t, (&Metadata{}).IsEmpty())
+	assert.False(t, (&Metadata{Width: lo.ToPtr(1)}).IsEmpty())
+	assert.False(t, (&Metadata{Attributes: []string{"a"}}).IsEmpty())
+}
+
+func TestMetadata_Clone(t *testing.T) {
+	m := &Metadata{
+		Width:        lo.ToPtr(10),
+		Height:       lo.ToPtr(20),
+		Location:     &Location{Lat: 35, Lng: 139},
+		Bounds:       &Bounds{Min: []float64{0, 1}, Max: []float64{2, 3}},
+		FeatureCount: lo.ToPtr(3),
+		Attributes:   []string{"name"},
+	}
+	c := m.Clone()
+	assert.Equal(t, m, c)
+	assert.NotSame(t, m.Width, c.Width)
+	c.Bounds.Min[0] = 100
+	c.Attributes[0] = "x"
+	assert.Equal(t, 0.0, m.Bounds.Min[0])
+	assert.Equal(t, "name", m.Attributes[0])
+	assert.Nil(t, (*Metadata)(nil).Clone())
+}
+
+func TestAsset_Metadata(t *testing.T) {
+	m := &Metadata{Width: lo.ToPtr(10)}
+	a := New().NewID().Workspace(accountsID.NewWorkspaceID()).URL("https://example.com/a.png").Size(1).
+		Metadata(m).ThumbnailURL("https://example.com/a_thumbnail.png").MustBuild()
+	assert.Equal(t, m, a.Metadata())
+	assert.Equal(t, "https://example.com/a_thumbnail.png", a.ThumbnailURL())
+
+	*m.Width = 20
+	assert.Equal(t, 10, *a.Metadata().Width)
+
+	a.SetMetadata(nil)
+	assert.Nil(t, a.Metadata())
+	a.SetThumbnailURL("")
+	assert.Empty(t, a.ThumbnailURL())
+}
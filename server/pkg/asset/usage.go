@@ -0,0 +1,178 @@
+package asset
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+type UsageType string
+
+const (
+	// UsageTypeProperty is a property field of a scene, a widget, a layer infobox or photo overlay, or a story.
+	UsageTypeProperty UsageType = "property"
+	// UsageTypeLayer is the config of an NLSLayer.
+	UsageTypeLayer UsageType = "layer"
+	UsageTypeStyle UsageType = "style"
+	// UsageTypeProject is the image, the public image or the public icon of a project.
+	UsageTypeProject UsageType = "project"
+	// UsageTypeFeature is the properties of a sketch feature of an NLSLayer.
+	UsageTypeFeature UsageType = "feature"
+)
+
+// Usage is a place in a project that references the URL of an asset.
+type Usage struct {
+	Type      UsageType
+	Workspace accountsID.WorkspaceID
+	Project   id.ProjectID
+	// Target is the ID of the property, the layer, the style or the project that references the asset.
+	// For sketch features, it is the ID of the layer that holds the feature.
+	Target string
+	// Field is the property field ID, the dot-separated path in the layer config or the style value, or the project field name.
+	// For sketch features, it is the feature ID followed by the dot-separated path in the feature properties.
+	Field string
+}
+
+// UsageIndex records which places reference each of a set of asset URLs.
+// Strings reference an asset when they contain its URL, so URLs embedded in larger values such as HTML are found too.
+type UsageIndex struct {
+	urls   []string
+	usages map[string][]Usage
+}
+
+func NewUsageIndex(urls ...string) *UsageIndex {
+	i := &UsageIndex{usages: map[string][]Usage{}}
+	for _, u := range urls {
+		if u != "" {
+			i.urls = append(i.urls, u)
+		}
+	}
+	return i
+}
+
+// AddString records u for every indexed URL that s contains.
+func (i *UsageIndex) AddString(s string, u Usage) {
+	if s == "" {
+		return
+	}
+	for _, url := range i.urls {
+		if strings.Contains(s, url) {
+			i.usages[url] = append(i.usages[url], u)
+		}
+	}
+}
+
+// AddValue records u for every indexed URL contained in the strings of a decoded JSON or BSON value.
+// The path to each string is appended to the field of u.
+func (i *UsageIndex) AddValue(v any, u Usage) {
+	walkStrings(v, "", func(path, s string) {
+		u2 := u
+		if path != "" {
+			u2 = u.withField(path)
+		}
+		i.AddString(s, u2)
+	})
+}
+
+func (i *UsageIndex) Usages(url string) []Usage {
+	return i.usages[url]
+}
+
+func (i *UsageIndex) IsUsed(url string) bool {
+	return len(i.usages[url]) > 0
+}
+
+func (u Usage) withField(f string) Usage {
+	if u.Field != "" {
+		f = u.Field + "." + f
+	}
+	u.Field = f
+	return u
+}
+
+var (
+	urlStart = regexp.MustCompile(`https?://`)
+	// urlBody stops at the characters that do not appear in asset URLs, such as quotes, spaces and brackets
+	urlBody = regexp.MustCompile(`^https?://[A-Za-z0-9\-._~:/?#@!$&*+=%]+`)
+)
+
+// URLs returns the http(s) URLs in the strings of decoded JSON values, sorted and without duplicates.
+// It is used to record which assets a document may reference so that the references can be looked up by asset URL.
+// URLs nested in others, such as in query parameters, are returned too, and every URL is also returned
+// without its query and fragment so that asset URLs with cache busters are found.
+func URLs(values ...any) []string {
+	found := map[string]struct{}{}
+	for _, v := range values {
+		collectURLs(v, found)
+	}
+	res := make([]string, 0, len(found))
+	for u := range found {
+		res = append(res, u)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func collectURLs(v any, found map[string]struct{}) {
+	walkStrings(v, "", func(_, s string) {
+		for _, loc := range urlStart.FindAllStringIndex(s, -1) {
+			u := strings.TrimRight(urlBody.FindString(s[loc[0]:]), ".,:;!?")
+			if u == "" {
+				continue
+			}
+			found[u] = struct{}{}
+			if i := strings.IndexAny(u, "?#"); i > 0 {
+				found[u[:i]] = struct{}{}
+			}
+		}
+	})
+}
+
+// walkStrings calls f with the dot-separated path and the content of every string in a decoded JSON or BSON value.
+// Values decoded from BSON use named types such as primitive.A, so maps and slices are also walked by reflection.
+func walkStrings(v any, path string, f func(path, s string)) {
+	switch v := v.(type) {
+	case nil:
+		return
+	case string:
+		f(path, v)
+		return
+	case *string:
+		if v != nil {
+			f(path, *v)
+		}
+		return
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return
+		}
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			walkStrings(rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())).Interface(), p, f)
+		}
+	case reflect.Slice, reflect.Array:
+		for j := 0; j < rv.Len(); j++ {
+			walkStrings(rv.Index(j).Interface(), path, f)
+		}
+	case reflect.Pointer:
+		if !rv.IsNil() {
+			walkStrings(rv.Elem().Interface(), path, f)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package asset
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageIndex(t *testing.T) {
+	pid := id.NewProjectID()
+	a := "https://example.com/assets/a.png"
+	b := "https://example.com/assets/b.png"
+	i := NewUsageIndex(a, b, "")
+
+	i.AddString(`<img src="`+a+`">`, Usage{Type: UsageTypeProperty, Project: pid, Target: "p", Field: "html"})
+	i.AddString("https://example.com/assets/c.png", Usage{Type: UsageTypeProperty, Project: pid, Target: "p", Field: "url"})
+	i.AddString("", Usage{Type: UsageTypeProperty, Project: pid, Target: "p", Field: "empty"})
+	i.AddValue(map[string]any{
+		"data": map[string]any{"url": a},
+		"list": []any{1, b},
+	}, Usage{Type: UsageTypeLayer, Project: pid, Target: "l"})
+
+	assert.Equal(t, []Usage{
+		{Type: UsageTypeProperty, Project: pid, Target: "p", Field: "html"},
+		{Type: UsageTypeLayer, Project: pid, Target: "l", Field: "data.url"},
+	}, i.Usages(a))
+	assert.Equal(t, []Usage{
+		{Type: UsageTypeLayer, Project: pid, Target: "l", Field: "list"},
+	}, i.Usages(b))
+	assert.True(t, i.IsUsed(b))
+	assert.False(t, i.IsUsed("https://example.com/assets/c.png"))
+	assert.Empty(t, i.Usages(""))
+}
+
+// bsonArray and bsonDocument stand for the named types that values decoded from BSON have
+type (
+	bsonArray    []any
+	bsonDocument map[string]any
+)
+
+func TestUsageIndex_AddValue_NamedTypes(t *testing.T) {
+	pid := id.NewProjectID()
+	a := "https://example.com/assets/a.png"
+	i := NewUsageIndex(a)
+
+	i.AddValue(map[string]any{
+		"list": bsonArray{1, bsonDocument{"url": a}},
+	}, Usage{Type: UsageTypeLayer, Project: pid, Target: "l"})
+
+	assert.Equal(t, []Usage{
+		{Type: UsageTypeLayer, Project: pid, Target: "l", Field: "list.url"},
+	}, i.Usages(a))
+	assert.Equal(t, []string{a}, URLs(bsonArray{bsonDocument{"url": a}}))
+}
+
+func TestURLs(t *testing.T) {
+	s := "https://example.com/assets/s.png"
+	assert.Equal(t, []string{
+		"http://example.com/assets/d.png",
+		"https://example.com/assets/a.png",
+		"https://example.com/assets/b.png",
+		"https://example.com/assets/c.png",
+		"https://example.com/assets/c.png?v=1",
+		"https://example.com/assets/s.png",
+		"https://proxy.example.com/",
+		"https://proxy.example.com/?url=https://example.com/assets/a.png",
+	}, URLs(
+		`<p>see https://example.com/assets/a.png.</p><img src="https://example.com/assets/b.png">`,
+		map[string]any{
+			"data": map[string]any{"url": "https://example.com/assets/c.png?v=1"},
+			"list": []any{1, "(http://example.com/assets/d.png)", "https://example.com/assets/b.png"},
+		},
+		[]string{"https://proxy.example.com/?url=https://example.com/assets/a.png"},
+		(*string)(nil),
+		"not a url",
+		&s,
+	))
+	assert.Empty(t, URLs(nil, "", map[string]any{"n": 1}))
+}
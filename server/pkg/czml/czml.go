@@ -1,20 +1,26 @@
 package czml
 
 type Feature struct {
-	Id       string    `json:"id"`
-	Name     string    `json:"name"`
-	Polygon  *Polygon  `json:"polygon,omitempty"`
-	Polyline *Polyline `json:"polyline,omitempty"`
-	Position *Position `json:"position,omitempty"`
-	Point    *Point    `json:"point,omitempty"`
+	Id          string         `json:"id"`
+	Name        string         `json:"name"`
+	Version     string         `json:"version,omitempty"`
+	Parent      string         `json:"parent,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Properties  map[string]any `json:"properties,omitempty"`
+	Polygon     *Polygon       `json:"polygon,omitempty"`
+	Polyline    *Polyline      `json:"polyline,omitempty"`
+	Position    *Position      `json:"position,omitempty"`
+	Point       *Point         `json:"point,omitempty"`
 }
 type Polyline struct {
-	Positions Position  `json:"positions"`
-	Material  *Material `json:"material,omitempty"`
-	Width     float64   `json:"width,omitempty"`
+	Positions     Position  `json:"positions"`
+	Material      *Material `json:"material,omitempty"`
+	Width         float64   `json:"width,omitempty"`
+	ClampToGround bool      `json:"clampToGround,omitempty"`
 }
 type Polygon struct {
 	Positions   Position  `json:"positions"`
+	Holes       *Holes    `json:"holes,omitempty"`
 	Fill        bool      `json:"fill,omitempty"`
 	Material    *Material `json:"material,omitempty"`
 	Stroke      bool      `json:"outline,omitempty"`
@@ -22,12 +28,17 @@ type Polygon struct {
 	StrokeWidth float64   `json:"outlineWidth,omitempty"`
 }
 type Point struct {
-	Color     string  `json:"color,omitempty"`
-	PixelSize float64 `json:"pixelSize,omitempty"`
+	Color        *Color  `json:"color,omitempty"`
+	PixelSize    float64 `json:"pixelSize,omitempty"`
+	OutlineColor *Color  `json:"outlineColor,omitempty"`
+	OutlineWidth float64 `json:"outlineWidth,omitempty"`
 }
 type Position struct {
 	CartographicDegrees []float64 `json:"cartographicDegrees"`
 }
+type Holes struct {
+	CartographicDegrees [][]float64 `json:"cartographicDegrees"`
+}
 type Material struct {
 	SolidColor      *SolidColor      `json:"solidColor,omitempty"`
 	PolylineOutline *PolylineOutline `json:"polylineOutline,omitempty"`
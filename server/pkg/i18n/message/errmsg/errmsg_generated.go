@@ -7,6 +7,16 @@ import (
 )
 
 const (
+	ErrKeyPkgNlslayerCoordinateOutOfRange message.ErrKey = "pkg.nlslayer.coordinate_out_of_range"
+	ErrKeyPkgNlslayerCustomPropertyAlreadyExists message.ErrKey = "pkg.nlslayer.custom_property_already_exists"
+	ErrKeyPkgNlslayerInvalidCustomPropertySchema message.ErrKey = "pkg.nlslayer.invalid_custom_property_schema"
+	ErrKeyPkgNlslayerInvalidCustomPropertyValue message.ErrKey = "pkg.nlslayer.invalid_custom_property_value"
+	ErrKeyPkgNlslayerInvalidPosition message.ErrKey = "pkg.nlslayer.invalid_position"
+	ErrKeyPkgNlslayerLineTooShort message.ErrKey = "pkg.nlslayer.line_too_short"
+	ErrKeyPkgNlslayerMissingCustomProperty message.ErrKey = "pkg.nlslayer.missing_custom_property"
+	ErrKeyPkgNlslayerRingNotClosed message.ErrKey = "pkg.nlslayer.ring_not_closed"
+	ErrKeyPkgNlslayerRingSelfIntersection message.ErrKey = "pkg.nlslayer.ring_self_intersection"
+	ErrKeyPkgNlslayerRingTooShort message.ErrKey = "pkg.nlslayer.ring_too_short"
 	ErrKeyPkgProjectAliasAlreadyExists message.ErrKey = "pkg.project.alias_already_exists"
 	ErrKeyPkgProjectInvalidAlias message.ErrKey = "pkg.project.invalid_alias"
 	ErrKeyPkgProjectInvalidPrefixAlias message.ErrKey = "pkg.project.invalid_prefix_alias"
@@ -20,6 +30,106 @@ const (
 )
 
 var ErrorMessages = map[message.ErrKey]map[language.Tag]message.ErrorMessage{
+	ErrKeyPkgNlslayerCoordinateOutOfRange: {
+		language.English: {
+			Message:     "Position {{.position}} of feature {{.featureId}} is out of range.",
+			Description: "Longitude must be between -180 and 180 and latitude must be between -90 and 90.",
+		},
+		language.Japanese: {
+			Message:     "地物 {{.featureId}} の座標 {{.position}} が範囲外です。",
+			Description: "経度は-180から180、緯度は-90から90の範囲で指定してください。",
+		},
+	},
+	ErrKeyPkgNlslayerCustomPropertyAlreadyExists: {
+		language.English: {
+			Message:     "Custom property {{.property}} already exists.",
+			Description: "Choose a title that is not used by another custom property or feature property.",
+		},
+		language.Japanese: {
+			Message:     "カスタムプロパティ {{.property}} は既に存在します。",
+			Description: "他のカスタムプロパティや地物のプロパティで使用されていないタイトルを指定してください。",
+		},
+	},
+	ErrKeyPkgNlslayerInvalidCustomPropertySchema: {
+		language.English: {
+			Message:     "Custom property {{.property}} has an invalid definition.",
+			Description: "A custom property must have a known type, enum properties need options and the default value must match the type.",
+		},
+		language.Japanese: {
+			Message:     "カスタムプロパティ {{.property}} の定義が不正です。",
+			Description: "カスタムプロパティには有効な型が必要です。列挙型には選択肢が必要で、デフォルト値は型に一致している必要があります。",
+		},
+	},
+	ErrKeyPkgNlslayerInvalidCustomPropertyValue: {
+		language.English: {
+			Message:     "Property {{.property}} of feature {{.featureId}} must be a valid {{.type}}.",
+			Description: "The value of a feature property must match the type of the custom property.",
+		},
+		language.Japanese: {
+			Message:     "地物 {{.featureId}} のプロパティ {{.property}} は有効な {{.type}} である必要があります。",
+			Description: "地物のプロパティの値はカスタムプロパティの型に一致している必要があります。",
+		},
+	},
+	ErrKeyPkgNlslayerInvalidPosition: {
+		language.English: {
+			Message:     "Position {{.position}} of feature {{.featureId}} is invalid.",
+			Description: "A position must consist of a longitude, a latitude and an optional height.",
+		},
+		language.Japanese: {
+			Message:     "地物 {{.featureId}} の座標 {{.position}} が不正です。",
+			Description: "座標は経度、緯度、および任意の高さで構成される必要があります。",
+		},
+	},
+	ErrKeyPkgNlslayerLineTooShort: {
+		language.English: {
+			Message:     "Line {{.line}} of feature {{.featureId}} has fewer than two positions.",
+			Description: "A line needs at least two positions.",
+		},
+		language.Japanese: {
+			Message:     "地物 {{.featureId}} のライン {{.line}} の座標が2点未満です。",
+			Description: "ラインには2点以上の座標が必要です。",
+		},
+	},
+	ErrKeyPkgNlslayerMissingCustomProperty: {
+		language.English: {
+			Message:     "Required property {{.property}} of feature {{.featureId}} is missing.",
+			Description: "Required custom properties must have a value unless they have a default value.",
+		},
+		language.Japanese: {
+			Message:     "地物 {{.featureId}} の必須プロパティ {{.property}} がありません。",
+			Description: "デフォルト値のない必須のカスタムプロパティには値が必要です。",
+		},
+	},
+	ErrKeyPkgNlslayerRingNotClosed: {
+		language.English: {
+			Message:     "Ring {{.ring}} of feature {{.featureId}} is not closed.",
+			Description: "The first and last positions of a polygon ring must be identical.",
+		},
+		language.Japanese: {
+			Message:     "地物 {{.featureId}} のリング {{.ring}} が閉じていません。",
+			Description: "ポリゴンのリングは始点と終点の座標が一致している必要があります。",
+		},
+	},
+	ErrKeyPkgNlslayerRingSelfIntersection: {
+		language.English: {
+			Message:     "Ring {{.ring}} of feature {{.featureId}} intersects itself.",
+			Description: "The edges of a polygon ring must not cross or touch each other.",
+		},
+		language.Japanese: {
+			Message:     "地物 {{.featureId}} のリング {{.ring}} が自己交差しています。",
+			Description: "ポリゴンのリングの辺同士が交差または接触してはいけません。",
+		},
+	},
+	ErrKeyPkgNlslayerRingTooShort: {
+		language.English: {
+			Message:     "Ring {{.ring}} of feature {{.featureId}} has fewer than four positions.",
+			Description: "A polygon ring needs at least four positions including the closing position.",
+		},
+		language.Japanese: {
+			Message:     "地物 {{.featureId}} のリング {{.ring}} の座標が4点未満です。",
+			Description: "ポリゴンのリングには終点を含めて4点以上の座標が必要です。",
+		},
+	},
 	ErrKeyPkgProjectAliasAlreadyExists: {
 		language.English: {
 			Message:     "This alias is already in use. Please try another one.",
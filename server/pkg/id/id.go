@@ -22,6 +22,14 @@ type Infobox struct{}
 type PhotoOverlay struct{}
 type InfoboxBlock struct{}
 type Feature struct{}
+type FeatureRevision struct{}
+type ShareToken struct{}
+type PublishSchedule struct{}
+type PublicationVersion struct{}
+type TrashPurge struct{}
+type APIToken struct{}
+type Webhook struct{}
+type WebhookDelivery struct{}
 
 func (Asset) Type() string               { return "asset" }
 func (ProjectMetadata) Type() string     { return "projectmetadata" }
@@ -43,6 +51,14 @@ func (Infobox) Type() string             { return "infobox" }
 func (PhotoOverlay) Type() string        { return "photoOverlay" }
 func (InfoboxBlock) Type() string        { return "infoboxBlock" }
 func (Feature) Type() string             { return "feature" }
+func (FeatureRevision) Type() string     { return "featureRevision" }
+func (ShareToken) Type() string          { return "shareToken" }
+func (PublishSchedule) Type() string     { return "publishSchedule" }
+func (PublicationVersion) Type() string  { return "publicationVersion" }
+func (TrashPurge) Type() string          { return "trashPurge" }
+func (APIToken) Type() string            { return "apiToken" }
+func (Webhook) Type() string             { return "webhook" }
+func (WebhookDelivery) Type() string     { return "webhookDelivery" }
 
 type AssetID = idx.ID[Asset]
 type ProjectMetadataID = idx.ID[ProjectMetadata]
@@ -60,6 +76,14 @@ type InfoboxID = idx.ID[Infobox]
 type PhotoOverlayID = idx.ID[PhotoOverlay]
 type InfoboxBlockID = idx.ID[InfoboxBlock]
 type FeatureID = idx.ID[Feature]
+type FeatureRevisionID = idx.ID[FeatureRevision]
+type ShareTokenID = idx.ID[ShareToken]
+type PublishScheduleID = idx.ID[PublishSchedule]
+type PublicationVersionID = idx.ID[PublicationVersion]
+type TrashPurgeID = idx.ID[TrashPurge]
+type APITokenID = idx.ID[APIToken]
+type WebhookID = idx.ID[Webhook]
+type WebhookDeliveryID = idx.ID[WebhookDelivery]
 
 type PluginExtensionID = idx.StringID[PluginExtension]
 type PropertySchemaGroupID = idx.StringID[PropertySchemaGroup]
@@ -80,6 +104,14 @@ var NewInfoboxID = idx.New[Infobox]
 var NewPhotoOverlayID = idx.New[PhotoOverlay]
 var NewInfoboxBlockID = idx.New[InfoboxBlock]
 var NewFeatureID = idx.New[Feature]
+var NewFeatureRevisionID = idx.New[FeatureRevision]
+var NewShareTokenID = idx.New[ShareToken]
+var NewPublishScheduleID = idx.New[PublishSchedule]
+var NewPublicationVersionID = idx.New[PublicationVersion]
+var NewTrashPurgeID = idx.New[TrashPurge]
+var NewAPITokenID = idx.New[APIToken]
+var NewWebhookID = idx.New[Webhook]
+var NewWebhookDeliveryID = idx.New[WebhookDelivery]
 
 var MustAssetID = idx.Must[Asset]
 var MustProjectMetadataID = idx.Must[ProjectMetadata]
@@ -97,6 +129,14 @@ var MustInfoboxID = idx.Must[Infobox]
 var MustPhotoOverlayID = idx.Must[PhotoOverlay]
 var MustInfoboxBlockID = idx.Must[InfoboxBlock]
 var MustFeatureID = idx.Must[Feature]
+var MustFeatureRevisionID = idx.Must[FeatureRevision]
+var MustShareTokenID = idx.Must[ShareToken]
+var MustPublishScheduleID = idx.Must[PublishSchedule]
+var MustPublicationVersionID = idx.Must[PublicationVersion]
+var MustTrashPurgeID = idx.Must[TrashPurge]
+var MustAPITokenID = idx.Must[APIToken]
+var MustWebhookID = idx.Must[Webhook]
+var MustWebhookDeliveryID = idx.Must[WebhookDelivery]
 
 var AssetIDFrom = idx.From[Asset]
 var ProjectMetadataIDFrom = idx.From[ProjectMetadata]
@@ -114,6 +154,14 @@ var InfoboxIDFrom = idx.From[Infobox]
 var PhotoOverlayIDFrom = idx.From[PhotoOverlay]
 var InfoboxBlockIDFrom = idx.From[InfoboxBlock]
 var FeatureIDFrom = idx.From[Feature]
+var FeatureRevisionIDFrom = idx.From[FeatureRevision]
+var ShareTokenIDFrom = idx.From[ShareToken]
+var PublishScheduleIDFrom = idx.From[PublishSchedule]
+var PublicationVersionIDFrom = idx.From[PublicationVersion]
+var TrashPurgeIDFrom = idx.From[TrashPurge]
+var APITokenIDFrom = idx.From[APIToken]
+var WebhookIDFrom = idx.From[Webhook]
+var WebhookDeliveryIDFrom = idx.From[WebhookDelivery]
 
 var AssetIDFromRef = idx.FromRef[Asset]
 var ProjectMetadataIDFromRef = idx.FromRef[ProjectMetadata]
@@ -131,6 +179,14 @@ var InfoboxIDFromRef = idx.FromRef[Infobox]
 var PhotoOverlayIDFromRef = idx.FromRef[PhotoOverlay]
 var InfoboxBlockIDFromRef = idx.FromRef[InfoboxBlock]
 var FeatureIDFromRef = idx.FromRef[Feature]
+var FeatureRevisionIDFromRef = idx.FromRef[FeatureRevision]
+var ShareTokenIDFromRef = idx.FromRef[ShareToken]
+var PublishScheduleIDFromRef = idx.FromRef[PublishSchedule]
+var PublicationVersionIDFromRef = idx.FromRef[PublicationVersion]
+var TrashPurgeIDFromRef = idx.FromRef[TrashPurge]
+var APITokenIDFromRef = idx.FromRef[APIToken]
+var WebhookIDFromRef = idx.FromRef[Webhook]
+var WebhookDeliveryIDFromRef = idx.FromRef[WebhookDelivery]
 
 var PluginExtensionIDFromRef = idx.StringIDFromRef[PluginExtension]
 var PropertyFieldIDFromRef = idx.StringIDFromRef[PropertyField]
@@ -152,6 +208,14 @@ type InfoboxIDList = idx.List[Infobox]
 type PhotoOverlayIDList = idx.List[PhotoOverlay]
 type InfoboxBlockIDList = idx.List[InfoboxBlock]
 type FeatureIDList = idx.List[Feature]
+type FeatureRevisionIDList = idx.List[FeatureRevision]
+type ShareTokenIDList = idx.List[ShareToken]
+type PublishScheduleIDList = idx.List[PublishSchedule]
+type PublicationVersionIDList = idx.List[PublicationVersion]
+type TrashPurgeIDList = idx.List[TrashPurge]
+type APITokenIDList = idx.List[APIToken]
+type WebhookIDList = idx.List[Webhook]
+type WebhookDeliveryIDList = idx.List[WebhookDelivery]
 
 var AssetIDListFrom = idx.ListFrom[Asset]
 var ProjectMetadataIDListFrom = idx.ListFrom[ProjectMetadata]
@@ -169,6 +233,14 @@ var InfoboxIDListFrom = idx.ListFrom[Infobox]
 var PhotoOverlayIDListFrom = idx.ListFrom[PhotoOverlay]
 var InfoboxBlockIDListFrom = idx.ListFrom[InfoboxBlock]
 var FeatureIDListFrom = idx.ListFrom[Feature]
+var FeatureRevisionIDListFrom = idx.ListFrom[FeatureRevision]
+var ShareTokenIDListFrom = idx.ListFrom[ShareToken]
+var PublishScheduleIDListFrom = idx.ListFrom[PublishSchedule]
+var PublicationVersionIDListFrom = idx.ListFrom[PublicationVersion]
+var TrashPurgeIDListFrom = idx.ListFrom[TrashPurge]
+var APITokenIDListFrom = idx.ListFrom[APIToken]
+var WebhookIDListFrom = idx.ListFrom[Webhook]
+var WebhookDeliveryIDListFrom = idx.ListFrom[WebhookDelivery]
 
 type AssetIDSet = idx.Set[Asset]
 type ProjectMetadataIDSet = idx.Set[ProjectMetadata]
@@ -186,6 +258,14 @@ type InfoboxIDSet = idx.Set[Infobox]
 type PhotoOverlayIDSet = idx.Set[PhotoOverlay]
 type InfoboxBlockIDSet = idx.Set[InfoboxBlock]
 type FeatureIDSet = idx.Set[Feature]
+type FeatureRevisionIDSet = idx.Set[FeatureRevision]
+type ShareTokenIDSet = idx.Set[ShareToken]
+type PublishScheduleIDSet = idx.Set[PublishSchedule]
+type PublicationVersionIDSet = idx.Set[PublicationVersion]
+type TrashPurgeIDSet = idx.Set[TrashPurge]
+type APITokenIDSet = idx.Set[APIToken]
+type WebhookIDSet = idx.Set[Webhook]
+type WebhookDeliveryIDSet = idx.Set[WebhookDelivery]
 
 var NewAssetIDSet = idx.NewSet[Asset]
 var NewProjectMetadataIDSet = idx.NewSet[ProjectMetadata]
@@ -203,6 +283,14 @@ var NewPhotoOverlayIDSet = idx.NewSet[PhotoOverlay]
 var NewInfoboxIDSet = idx.NewSet[InfoboxBlock]
 var NewInfoboxBlockIDSet = idx.NewSet[InfoboxBlock]
 var NewFeatureIDSet = idx.NewSet[Feature]
+var NewFeatureRevisionIDSet = idx.NewSet[FeatureRevision]
+var NewShareTokenIDSet = idx.NewSet[ShareToken]
+var NewPublishScheduleIDSet = idx.NewSet[PublishSchedule]
+var NewPublicationVersionIDSet = idx.NewSet[PublicationVersion]
+var NewTrashPurgeIDSet = idx.NewSet[TrashPurge]
+var NewAPITokenIDSet = idx.NewSet[APIToken]
+var NewWebhookIDSet = idx.NewSet[Webhook]
+var NewWebhookDeliveryIDSet = idx.NewSet[WebhookDelivery]
 
 // Storytelling ids
 
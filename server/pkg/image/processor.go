@@ -20,6 +20,9 @@ const (
 	MaxUploadSize   = 10 * 1024 * 1024 // 10MB limit for icon uploads
 	MaxImagePixels  = 10000 * 10000    // 100 megapixels limit to prevent decompression bombs
 	MaxImageDimSize = 10000            // Max width or height
+
+	ThumbnailSize          = 256
+	MaxThumbnailSourceSize = 50 * 1024 * 1024 // 50MB limit for images to make thumbnails of
 )
 
 var (
@@ -27,24 +30,78 @@ var (
 	ErrImageTooLarge    = errors.New("image file too large")
 	ErrImageTooManyPx   = errors.New("image dimensions too large")
 	ErrProcessingFailed = errors.New("image processing failed")
+
+	ErrNoThumbnailNeeded = errors.New("image is smaller than a thumbnail")
 )
 
 // ProcessIconImage takes an image reader and returns a 64x64 PNG image.
 // It center-crops non-square images and resizes to IconSize.
 func ProcessIconImage(r io.Reader, size int64) ([]byte, error) {
-	if size > MaxUploadSize {
+	src, err := decode(r, size, MaxUploadSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get dimensions and center-crop to square
+	bounds := src.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+
+	var cropped image.Image
+	if width != height {
+		// Center-crop to square
+		cropped = centerCrop(src, width, height)
+	} else {
+		cropped = src
+	}
+
+	// Resize to 64x64 using CatmullRom (high quality) interpolation
+	resized := resize(cropped, IconSize, IconSize)
+
+	return encodePNG(resized)
+}
+
+// ProcessThumbnail takes an image reader and returns a PNG image that fits in ThumbnailSize x ThumbnailSize
+// with the aspect ratio kept. It returns ErrNoThumbnailNeeded when the image is already small enough.
+func ProcessThumbnail(r io.Reader, size int64) ([]byte, error) {
+	src, err := decode(r, size, MaxThumbnailSourceSize)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+	if width <= ThumbnailSize && height <= ThumbnailSize {
+		return nil, ErrNoThumbnailNeeded
+	}
+
+	// Scale the longer side to ThumbnailSize
+	tw, th := ThumbnailSize, ThumbnailSize
+	if width > height {
+		th = max(1, height*ThumbnailSize/width)
+	} else {
+		tw = max(1, width*ThumbnailSize/height)
+	}
+
+	return encodePNG(resize(src, tw, th))
+}
+
+// decode reads and decodes an image of at most maxSize bytes.
+func decode(r io.Reader, size, maxSize int64) (image.Image, error) {
+	if size > maxSize {
 		return nil, ErrImageTooLarge
 	}
 
 	// Wrap reader with a limit to prevent reading more than declared size
-	limitedReader := io.LimitReader(r, MaxUploadSize+1)
+	limitedReader := io.LimitReader(r, maxSize+1)
 
 	// Buffer the data so we can read it twice (config check + full decode)
 	data, err := io.ReadAll(limitedReader)
 	if err != nil {
 		return nil, ErrInvalidImage
 	}
-	if int64(len(data)) > MaxUploadSize {
+	if int64(len(data)) > maxSize {
 		return nil, ErrImageTooLarge
 	}
 
@@ -63,29 +120,14 @@ func ProcessIconImage(r io.Reader, size int64) ([]byte, error) {
 	if err != nil {
 		return nil, ErrInvalidImage
 	}
+	return src, nil
+}
 
-	// Get dimensions and center-crop to square
-	bounds := src.Bounds()
-	width := bounds.Dx()
-	height := bounds.Dy()
-
-	var cropped image.Image
-	if width != height {
-		// Center-crop to square
-		cropped = centerCrop(src, width, height)
-	} else {
-		cropped = src
-	}
-
-	// Resize to 64x64 using CatmullRom (high quality) interpolation
-	resized := resize(cropped, IconSize, IconSize)
-
-	// Encode to PNG
+func encodePNG(img image.Image) ([]byte, error) {
 	var buf bytes.Buffer
-	if err := png.Encode(&buf, resized); err != nil {
+	if err := png.Encode(&buf, img); err != nil {
 		return nil, ErrProcessingFailed
 	}
-
 	return buf.Bytes(), nil
 }
 
@@ -74,6 +74,48 @@ func TestProcessIconImage_DimensionsTooLarge(t *testing.T) {
 	assert.ErrorIs(t, err, ErrImageTooManyPx)
 }
 
+func TestProcessThumbnail(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		wantWidth  int
+		wantHeight int
+		wantErr    error
+	}{
+		{"landscape image", 1024, 512, ThumbnailSize, ThumbnailSize / 2, nil},
+		{"portrait image", 300, 600, ThumbnailSize / 2, ThumbnailSize, nil},
+		{"square image", 400, 400, ThumbnailSize, ThumbnailSize, nil},
+		{"small image", ThumbnailSize, 100, 0, 0, ErrNoThumbnailNeeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := createTestImage(tt.width, tt.height)
+			var buf bytes.Buffer
+			require.NoError(t, png.Encode(&buf, img))
+
+			result, err := ProcessThumbnail(&buf, int64(buf.Len()))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			resultImg, err := png.Decode(bytes.NewReader(result))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWidth, resultImg.Bounds().Dx())
+			assert.Equal(t, tt.wantHeight, resultImg.Bounds().Dy())
+		})
+	}
+}
+
+func TestProcessThumbnail_TooLarge(t *testing.T) {
+	_, err := ProcessThumbnail(bytes.NewReader([]byte{}), MaxThumbnailSourceSize+1)
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+}
+
 func createTestImage(width, height int) image.Image {
 	img := image.NewRGBA(image.Rect(0, 0, width, height))
 	for y := 0; y < height; y++ {
@@ -4,6 +4,13 @@ import (
 	"github.com/reearth/reearth/server/pkg/property"
 )
 
+type KML struct {
+	Document Collection `xml:"Document"`
+	// some producers put Folders and Placemarks directly under <kml>
+	Folders    []Collection `xml:"Folder"`
+	Placemarks []Placemark  `xml:"Placemark"`
+}
+
 type Collection struct {
 	Folders    []Collection `xml:"Folder"`
 	Placemarks []Placemark  `xml:"Placemark"`
@@ -11,11 +18,26 @@ type Collection struct {
 	Name       string       `xml:"name"`
 }
 type Placemark struct {
-	Point    Point      `xml:"Point"`
-	Polygon  Polygon    `xml:"Polygon"`
-	Polyline LineString `xml:"LineString"`
-	Name     string     `xml:"name"`
-	StyleUrl string     `xml:"styleUrl"`
+	Point         Point          `xml:"Point"`
+	Polygon       Polygon        `xml:"Polygon"`
+	Polyline      LineString     `xml:"LineString"`
+	MultiGeometry *MultiGeometry `xml:"MultiGeometry"`
+	Name          string         `xml:"name"`
+	Description   string         `xml:"description"`
+	StyleUrl      string         `xml:"styleUrl"`
+	ExtendedData  ExtendedData   `xml:"ExtendedData"`
+}
+type MultiGeometry struct {
+	Points      []Point      `xml:"Point"`
+	Polygons    []Polygon    `xml:"Polygon"`
+	LineStrings []LineString `xml:"LineString"`
+}
+type ExtendedData struct {
+	Data []Data `xml:"Data"`
+}
+type Data struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
 }
 type BoundaryIs struct {
 	LinearRing LinearRing `xml:"LinearRing"`
@@ -63,6 +85,16 @@ type LineStyle struct {
 }
 type PolyStyle struct {
 	Color  string `xml:"color"`
-	Fill   bool   `xml:"fill"`
-	Stroke bool   `xml:"outline"`
+	Fill   *bool  `xml:"fill"`
+	Stroke *bool  `xml:"outline"`
+}
+
+// IsFill reports whether the polygon is filled. KML fills polygons unless <fill> is 0.
+func (s PolyStyle) IsFill() bool {
+	return s.Fill == nil || *s.Fill
+}
+
+// IsStroke reports whether the polygon is outlined. KML outlines polygons unless <outline> is 0.
+func (s PolyStyle) IsStroke() bool {
+	return s.Stroke == nil || *s.Stroke
 }
@@ -0,0 +1,124 @@
+package kml
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidKML     = errors.New("invalid kml")
+	ErrKMLNotFound    = errors.New("kml file not found in kmz")
+	ErrInvalidColor   = errors.New("invalid kml color")
+	ErrInvalidCoords  = errors.New("invalid kml coordinates")
+	ErrTooManyEntries = errors.New("kmz has too many entries")
+)
+
+const maxKMZEntries = 10000
+
+// Parse reads a KML document. Placemarks and folders placed directly under <kml>
+// are merged into the returned document.
+func Parse(r io.Reader) (*Collection, error) {
+	var k KML
+	if err := xml.NewDecoder(r).Decode(&k); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKML, err)
+	}
+
+	c := k.Document
+	c.Folders = append(c.Folders, k.Folders...)
+	c.Placemarks = append(c.Placemarks, k.Placemarks...)
+	return &c, nil
+}
+
+// ParseKMZ reads the main KML document of a KMZ archive. "doc.kml" is preferred,
+// otherwise the first .kml file at the shallowest level is used.
+func ParseKMZ(r io.ReaderAt, size int64) (*Collection, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKML, err)
+	}
+	if len(zr.File) > maxKMZEntries {
+		return nil, ErrTooManyEntries
+	}
+
+	var doc *zip.File
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || strings.ToLower(path.Ext(f.Name)) != ".kml" {
+			continue
+		}
+		if path.Base(f.Name) == "doc.kml" {
+			doc = f
+			break
+		}
+		if doc == nil || strings.Count(f.Name, "/") < strings.Count(doc.Name, "/") {
+			doc = f
+		}
+	}
+	if doc == nil {
+		return nil, ErrKMLNotFound
+	}
+
+	rc, err := doc.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	return Parse(rc)
+}
+
+// ParseFile parses KML or KMZ data. KMZ is detected by the zip signature.
+func ParseFile(data []byte) (*Collection, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return ParseKMZ(bytes.NewReader(data), int64(len(data)))
+	}
+	return Parse(bytes.NewReader(data))
+}
+
+// ParseCoordinates parses a KML coordinates string ("lon,lat[,alt] lon,lat[,alt] ...").
+func ParseCoordinates(s string) ([][]float64, error) {
+	tuples := strings.Fields(s)
+	res := make([][]float64, 0, len(tuples))
+	for _, t := range tuples {
+		parts := strings.Split(strings.Trim(t, ","), ",")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCoords, t)
+		}
+		coord := make([]float64, 0, len(parts))
+		for _, p := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidCoords, t)
+			}
+			coord = append(coord, v)
+		}
+		res = append(res, coord)
+	}
+	return res, nil
+}
+
+// ParseColor converts a KML color (aabbggrr) to a CSS hex color.
+// "#rrggbb" is returned for opaque colors and "#rrggbbaa" otherwise.
+func ParseColor(s string) (string, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 8 {
+		return "", ErrInvalidColor
+	}
+	if _, err := strconv.ParseUint(s, 16, 32); err != nil {
+		return "", ErrInvalidColor
+	}
+	s = strings.ToLower(s)
+	a, b, g, r := s[0:2], s[2:4], s[4:6], s[6:8]
+	if a == "ff" {
+		return "#" + r + g + b, nil
+	}
+	return "#" + r + g + b + a, nil
+}
@@ -0,0 +1,142 @@
+package kml
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testKML = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <name>parks</name>
+    <Style id="red">
+      <LineStyle><color>ff0000ff</color><width>2</width></LineStyle>
+      <PolyStyle><color>7f0000ff</color><outline>0</outline></PolyStyle>
+    </Style>
+    <Placemark>
+      <name>gate</name>
+      <styleUrl>#red</styleUrl>
+      <Point><coordinates>139.7,35.6,10</coordinates></Point>
+    </Placemark>
+    <Folder>
+      <name>areas</name>
+      <Placemark>
+        <name>lawn</name>
+        <ExtendedData><Data name="area"><value>120</value></Data></ExtendedData>
+        <Polygon>
+          <outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1 0,0</coordinates></LinearRing></outerBoundaryIs>
+        </Polygon>
+      </Placemark>
+    </Folder>
+  </Document>
+</kml>`
+
+func TestParse(t *testing.T) {
+	c, err := Parse(strings.NewReader(testKML))
+	assert.NoError(t, err)
+	assert.Equal(t, "parks", c.Name)
+	assert.Len(t, c.Styles, 1)
+	assert.Equal(t, "red", c.Styles[0].Id)
+	assert.Equal(t, 2.0, c.Styles[0].LineStyle.Width)
+	assert.True(t, c.Styles[0].PolyStyle.IsFill())
+	assert.False(t, c.Styles[0].PolyStyle.IsStroke())
+	assert.Len(t, c.Placemarks, 1)
+	assert.Equal(t, "#red", c.Placemarks[0].StyleUrl)
+	assert.Len(t, c.Folders, 1)
+	assert.Equal(t, "areas", c.Folders[0].Name)
+	assert.Equal(t, []Data{{Name: "area", Value: "120"}}, c.Folders[0].Placemarks[0].ExtendedData.Data)
+
+	_, err = Parse(strings.NewReader("<kml><Document>"))
+	assert.ErrorIs(t, err, ErrInvalidKML)
+}
+
+func TestParse_WithoutDocument(t *testing.T) {
+	c, err := Parse(strings.NewReader(`<kml><Placemark><name>a</name></Placemark><Folder><name>f</name></Folder></kml>`))
+	assert.NoError(t, err)
+	assert.Len(t, c.Placemarks, 1)
+	assert.Len(t, c.Folders, 1)
+}
+
+func TestParseKMZ(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, _ := zw.Create("files/other.kml")
+	_, _ = w.Write([]byte(`<kml><Document><name>other</name></Document></kml>`))
+	w, _ = zw.Create("doc.kml")
+	_, _ = w.Write([]byte(testKML))
+	assert.NoError(t, zw.Close())
+
+	c, err := ParseFile(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "parks", c.Name)
+
+	buf = &bytes.Buffer{}
+	zw = zip.NewWriter(buf)
+	w, _ = zw.Create("image.png")
+	_, _ = w.Write([]byte("png"))
+	assert.NoError(t, zw.Close())
+
+	_, err = ParseFile(buf.Bytes())
+	assert.ErrorIs(t, err, ErrKMLNotFound)
+}
+
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    [][]float64
+		wantErr bool
+	}{
+		{
+			name:  "2d and 3d",
+			input: " 1,2\n\t3,4,5 ",
+			want:  [][]float64{{1, 2}, {3, 4, 5}},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  [][]float64{},
+		},
+		{
+			name:    "missing latitude",
+			input:   "1",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			input:   "1,a",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseCoordinates(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCoords)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	c, err := ParseColor("ff0000ff")
+	assert.NoError(t, err)
+	assert.Equal(t, "#ff0000", c)
+
+	c, err = ParseColor("7FFF0000")
+	assert.NoError(t, err)
+	assert.Equal(t, "#0000ff7f", c)
+
+	_, err = ParseColor("red")
+	assert.ErrorIs(t, err, ErrInvalidColor)
+}
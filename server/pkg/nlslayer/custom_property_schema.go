@@ -0,0 +1,333 @@
+package nlslayer
+
+import (
+	"encoding/json"
+	"math"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/i18n/message/errmsg"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/verror"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrInvalidCustomPropertySchema = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerInvalidCustomPropertySchema,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerInvalidCustomPropertySchema],
+		nil,
+		nil,
+	)
+
+	ErrInvalidCustomPropertyValue = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerInvalidCustomPropertyValue,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerInvalidCustomPropertyValue],
+		nil,
+		nil,
+	)
+
+	ErrMissingCustomProperty = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerMissingCustomProperty,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerMissingCustomProperty],
+		nil,
+		nil,
+	)
+
+	ErrCustomPropertyAlreadyExists = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerCustomPropertyAlreadyExists,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerCustomPropertyAlreadyExists],
+		nil,
+		nil,
+	)
+)
+
+type CustomPropertyType string
+
+const (
+	CustomPropertyTypeString  CustomPropertyType = "string"
+	CustomPropertyTypeNumber  CustomPropertyType = "number"
+	CustomPropertyTypeBoolean CustomPropertyType = "boolean"
+	CustomPropertyTypeDate    CustomPropertyType = "date"
+	CustomPropertyTypeEnum    CustomPropertyType = "enum"
+	CustomPropertyTypeURL     CustomPropertyType = "url"
+)
+
+// legacyCustomPropertyTypes maps the types the editor stores as "<Type>_<index>" to typed fields.
+// URL is treated as a string since the editor saves the value while it is being typed.
+var legacyCustomPropertyTypes = map[string]CustomPropertyType{
+	"Text":     CustomPropertyTypeString,
+	"TextArea": CustomPropertyTypeString,
+	"Asset":    CustomPropertyTypeString,
+	"URL":      CustomPropertyTypeString,
+	"Int":      CustomPropertyTypeNumber,
+	"Float":    CustomPropertyTypeNumber,
+	"Boolean":  CustomPropertyTypeBoolean,
+}
+
+func (t CustomPropertyType) Valid() bool {
+	switch t {
+	case CustomPropertyTypeString, CustomPropertyTypeNumber, CustomPropertyTypeBoolean,
+		CustomPropertyTypeDate, CustomPropertyTypeEnum, CustomPropertyTypeURL:
+		return true
+	}
+	return false
+}
+
+type CustomPropertyField struct {
+	key          string
+	fieldType    CustomPropertyType
+	required     bool
+	defaultValue any
+	options      []string
+	index        int
+}
+
+func (f *CustomPropertyField) Key() string {
+	return f.key
+}
+
+func (f *CustomPropertyField) Type() CustomPropertyType {
+	return f.fieldType
+}
+
+func (f *CustomPropertyField) Required() bool {
+	return f.required
+}
+
+func (f *CustomPropertyField) Default() any {
+	return f.defaultValue
+}
+
+func (f *CustomPropertyField) Options() []string {
+	return append([]string{}, f.options...)
+}
+
+func (f *CustomPropertyField) Index() int {
+	return f.index
+}
+
+// ValidValue reports whether the value can be stored in the property.
+func (f *CustomPropertyField) ValidValue(v any) bool {
+	switch f.fieldType {
+	case CustomPropertyTypeString:
+		_, ok := v.(string)
+		return ok
+	case CustomPropertyTypeNumber:
+		_, ok := customPropertyNumber(v)
+		return ok
+	case CustomPropertyTypeBoolean:
+		_, ok := v.(bool)
+		return ok
+	case CustomPropertyTypeDate:
+		s, ok := v.(string)
+		return ok && validDate(s)
+	case CustomPropertyTypeEnum:
+		s, ok := v.(string)
+		return ok && lo.Contains(f.options, s)
+	case CustomPropertyTypeURL:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	}
+	return false
+}
+
+// CustomPropertySchema is the typed form of the custom property schema of a sketch layer.
+//
+// The schema is stored as a map from property keys to definitions. A definition is either a string
+// "<Type>_<index>" written by the editor (e.g. "Text_1") or an object such as
+// {"type": "enum", "required": true, "default": "a", "options": ["a", "b"], "index": 2}.
+type CustomPropertySchema struct {
+	fields []*CustomPropertyField
+}
+
+// NewCustomPropertySchema parses the stored schema. It returns nil when the schema is nil.
+func NewCustomPropertySchema(schema *map[string]any) (*CustomPropertySchema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	fields := make([]*CustomPropertyField, 0, len(*schema))
+	for k, v := range *schema {
+		f, ok := parseCustomPropertyField(k, v)
+		if !ok {
+			return nil, ErrInvalidCustomPropertySchema.AddTemplateData("property", k)
+		}
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].index != fields[j].index {
+			return fields[i].index < fields[j].index
+		}
+		return fields[i].key < fields[j].key
+	})
+
+	return &CustomPropertySchema{fields: fields}, nil
+}
+
+func (s *CustomPropertySchema) Fields() []*CustomPropertyField {
+	if s == nil {
+		return nil
+	}
+	return append([]*CustomPropertyField{}, s.fields...)
+}
+
+func (s *CustomPropertySchema) Field(key string) *CustomPropertyField {
+	if s == nil {
+		return nil
+	}
+	f, _ := lo.Find(s.fields, func(f *CustomPropertyField) bool { return f.key == key })
+	return f
+}
+
+// Validate checks the properties of the feature against the schema and returns a copy of them with default values filled in.
+// Properties that are not defined in the schema are kept as they are. Null and empty string values are treated as missing.
+func (s *CustomPropertySchema) Validate(fid id.FeatureID, properties *map[string]any) (*map[string]any, error) {
+	if s == nil {
+		return properties, nil
+	}
+
+	res := map[string]any{}
+	if properties != nil {
+		for k, v := range *properties {
+			res[k] = v
+		}
+	}
+
+	for _, f := range s.fields {
+		v, ok := res[f.key]
+		if !ok || v == nil || v == "" {
+			if f.defaultValue != nil {
+				res[f.key] = f.defaultValue
+			} else if f.required {
+				return nil, ErrMissingCustomProperty.
+					AddTemplateData("featureId", fid.String()).
+					AddTemplateData("property", f.key)
+			}
+			continue
+		}
+		if !f.ValidValue(v) {
+			return nil, ErrInvalidCustomPropertyValue.
+				AddTemplateData("featureId", fid.String()).
+				AddTemplateData("property", f.key).
+				AddTemplateData("type", string(f.fieldType))
+		}
+	}
+
+	return &res, nil
+}
+
+func parseCustomPropertyField(key string, v any) (*CustomPropertyField, bool) {
+	switch v := v.(type) {
+	case string:
+		// legacy definitions are "<Type>_<index>" and unknown types are kept as strings
+		typ, index := v, math.MaxInt
+		if i := strings.LastIndex(v, "_"); i >= 0 {
+			if n, err := strconv.Atoi(v[i+1:]); err == nil {
+				typ, index = v[:i], n
+			}
+		}
+		t, ok := legacyCustomPropertyTypes[typ]
+		if !ok {
+			t = CustomPropertyTypeString
+		}
+		return &CustomPropertyField{key: key, fieldType: t, index: index}, true
+
+	case map[string]any:
+		typ, _ := v["type"].(string)
+		f := &CustomPropertyField{
+			key:       key,
+			fieldType: CustomPropertyType(strings.ToLower(typ)),
+			index:     math.MaxInt,
+		}
+		if !f.fieldType.Valid() {
+			return nil, false
+		}
+		if r, ok := v["required"]; ok {
+			if f.required, ok = r.(bool); !ok {
+				return nil, false
+			}
+		}
+		if i, ok := v["index"]; ok {
+			n, ok := customPropertyNumber(i)
+			if !ok || n != math.Trunc(n) {
+				return nil, false
+			}
+			f.index = int(n)
+		}
+		if o, ok := v["options"]; ok {
+			options, ok := customPropertyOptions(o)
+			if !ok {
+				return nil, false
+			}
+			f.options = options
+		}
+		if f.fieldType == CustomPropertyTypeEnum && len(f.options) == 0 {
+			return nil, false
+		}
+		if d := v["default"]; d != nil {
+			if !f.ValidValue(d) {
+				return nil, false
+			}
+			f.defaultValue = d
+		}
+		return f, true
+	}
+	return nil, false
+}
+
+// customPropertyOptions accepts any slice of strings, as the options are decoded as primitive.A when the schema is loaded from the database.
+func customPropertyOptions(v any) ([]string, bool) {
+	if v, ok := v.([]string); ok {
+		return v, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	res := make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		s, ok := rv.Index(i).Interface().(string)
+		if !ok {
+			return nil, false
+		}
+		res = append(res, s)
+	}
+	return res, true
+}
+
+func customPropertyNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func validDate(s string) bool {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
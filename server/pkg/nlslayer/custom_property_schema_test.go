@@ -0,0 +1,178 @@
+package nlslayer
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/verror"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestNewCustomPropertySchema(t *testing.T) {
+	schema, err := NewCustomPropertySchema(&map[string]any{
+		"name":   "Text_1",
+		"height": "Float_2",
+		"url":    "URL_3",
+		"note":   "Unknown",
+		"kind": map[string]any{
+			"type":     "enum",
+			"required": true,
+			"default":  "a",
+			"options":  []any{"a", "b"},
+			"index":    4.0,
+		},
+		"since": map[string]any{"type": "Date"},
+	})
+	assert.NoError(t, err)
+
+	fields := schema.Fields()
+	assert.Equal(t, []string{"name", "height", "url", "kind", "note", "since"}, keysOf(fields))
+	assert.Equal(t, CustomPropertyTypeString, schema.Field("name").Type())
+	assert.Equal(t, CustomPropertyTypeNumber, schema.Field("height").Type())
+	assert.Equal(t, CustomPropertyTypeString, schema.Field("url").Type())
+	assert.Equal(t, CustomPropertyTypeString, schema.Field("note").Type())
+	assert.Equal(t, math.MaxInt, schema.Field("note").Index())
+	assert.Equal(t, CustomPropertyTypeDate, schema.Field("since").Type())
+
+	kind := schema.Field("kind")
+	assert.Equal(t, CustomPropertyTypeEnum, kind.Type())
+	assert.True(t, kind.Required())
+	assert.Equal(t, "a", kind.Default())
+	assert.Equal(t, []string{"a", "b"}, kind.Options())
+	assert.Equal(t, 4, kind.Index())
+
+	assert.Nil(t, schema.Field("unknown"))
+
+	schema, err = NewCustomPropertySchema(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, schema)
+}
+
+func TestNewCustomPropertySchema_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		field any
+	}{
+		{name: "unknown type", field: map[string]any{"type": "color"}},
+		{name: "missing type", field: map[string]any{"required": true}},
+		{name: "enum without options", field: map[string]any{"type": "enum"}},
+		{name: "non-string options", field: map[string]any{"type": "enum", "options": []any{1}}},
+		{name: "invalid required", field: map[string]any{"type": "string", "required": "yes"}},
+		{name: "fractional index", field: map[string]any{"type": "string", "index": 1.5}},
+		{name: "default of another type", field: map[string]any{"type": "number", "default": "1"}},
+		{name: "default not in options", field: map[string]any{"type": "enum", "options": []any{"a"}, "default": "b"}},
+		{name: "not a definition", field: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewCustomPropertySchema(&map[string]any{"field": tt.field})
+			var verr *verror.VError
+			assert.True(t, errors.As(err, &verr))
+			assert.Equal(t, ErrInvalidCustomPropertySchema.Key, verr.Key)
+			assert.Equal(t, "field", verr.TemplateData[language.English]["property"])
+		})
+	}
+}
+
+func TestCustomPropertySchema_Validate(t *testing.T) {
+	fid := id.NewFeatureID()
+	schema, err := NewCustomPropertySchema(&map[string]any{
+		"name":    map[string]any{"type": "string", "required": true},
+		"count":   map[string]any{"type": "number"},
+		"visible": map[string]any{"type": "boolean", "default": true},
+		"since":   map[string]any{"type": "date"},
+		"kind":    map[string]any{"type": "enum", "options": []any{"a", "b"}},
+		"link":    map[string]any{"type": "url"},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		properties  map[string]any
+		want        map[string]any
+		wantErr     *verror.VError
+		wantInvalid string
+	}{
+		{
+			name:       "valid",
+			properties: map[string]any{"name": "a", "count": 1, "visible": false, "since": "2024-01-02", "kind": "b", "link": "https://example.com", "extra": 1},
+			want:       map[string]any{"name": "a", "count": 1, "visible": false, "since": "2024-01-02", "kind": "b", "link": "https://example.com", "extra": 1},
+		},
+		{
+			name:       "defaults and empty values",
+			properties: map[string]any{"name": "a", "count": nil, "since": ""},
+			want:       map[string]any{"name": "a", "count": nil, "since": "", "visible": true},
+		},
+		{
+			name:        "missing required",
+			properties:  map[string]any{"name": ""},
+			wantErr:     ErrMissingCustomProperty,
+			wantInvalid: "name",
+		},
+		{
+			name:        "invalid number",
+			properties:  map[string]any{"name": "a", "count": "1"},
+			wantErr:     ErrInvalidCustomPropertyValue,
+			wantInvalid: "count",
+		},
+		{
+			name:        "invalid date",
+			properties:  map[string]any{"name": "a", "since": "yesterday"},
+			wantErr:     ErrInvalidCustomPropertyValue,
+			wantInvalid: "since",
+		},
+		{
+			name:        "invalid enum",
+			properties:  map[string]any{"name": "a", "kind": "c"},
+			wantErr:     ErrInvalidCustomPropertyValue,
+			wantInvalid: "kind",
+		},
+		{
+			name:        "invalid url",
+			properties:  map[string]any{"name": "a", "link": "example"},
+			wantErr:     ErrInvalidCustomPropertyValue,
+			wantInvalid: "link",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := schema.Validate(fid, &tt.properties)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, &tt.want, got)
+				return
+			}
+
+			assert.Nil(t, got)
+			var verr *verror.VError
+			assert.True(t, errors.As(err, &verr))
+			assert.Equal(t, tt.wantErr.Key, verr.Key)
+			assert.Equal(t, fid.String(), verr.TemplateData[language.English]["featureId"])
+			assert.Equal(t, tt.wantInvalid, verr.TemplateData[language.English]["property"])
+		})
+	}
+}
+
+func TestCustomPropertySchema_Validate_Nil(t *testing.T) {
+	properties := &map[string]any{"key": 1}
+	got, err := (*CustomPropertySchema)(nil).Validate(id.NewFeatureID(), properties)
+	assert.NoError(t, err)
+	assert.Same(t, properties, got)
+}
+
+func keysOf(fields []*CustomPropertyField) []string {
+	res := make([]string, 0, len(fields))
+	for _, f := range fields {
+		res = append(res, f.Key())
+	}
+	return res
+}
@@ -0,0 +1,37 @@
+package decoding
+
+import (
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+// Result is the set of layers and styles produced by a decoder.
+// Root is the top-level layer and Layers contains every decoded layer including Root.
+type Result struct {
+	Root   nlslayer.NLSLayer
+	Layers nlslayer.NLSLayerList
+	Styles scene.StyleList
+}
+
+func (r *Result) addLayer(l nlslayer.NLSLayer) {
+	r.Layers = append(r.Layers, &l)
+}
+
+func newSketchInfo(schema map[string]any, features []nlslayer.Feature) *nlslayer.SketchInfo {
+	return nlslayer.NewSketchInfo(&schema, nlslayer.NewFeatureCollection("FeatureCollection", features))
+}
+
+func sketchConfig(name string, styleID *string) *nlslayer.Config {
+	c := nlslayer.Config{
+		"data": map[string]any{
+			"type": "geojson",
+		},
+		"properties": map[string]any{
+			"name": name,
+		},
+	}
+	if styleID != nil {
+		c["layerStyleId"] = *styleID
+	}
+	return &c
+}
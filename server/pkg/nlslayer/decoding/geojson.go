@@ -0,0 +1,60 @@
+package decoding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+)
+
+var ErrInvalidFeatureCollection = errors.New("invalid GeoJSON feature collection")
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string         `json:"type"`
+	Geometry   map[string]any `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// GeoJSONFeatures reads a GeoJSON FeatureCollection and returns its features with new feature IDs.
+// Features without geometry are rejected since sketch features always have one.
+func GeoJSONFeatures(r io.Reader) ([]nlslayer.Feature, error) {
+	var fc geoJSONFeatureCollection
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFeatureCollection, err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("%w: unexpected type %q", ErrInvalidFeatureCollection, fc.Type)
+	}
+
+	features := make([]nlslayer.Feature, 0, len(fc.Features))
+	for i, f := range fc.Features {
+		if f.Type != "Feature" {
+			return nil, fmt.Errorf("%w: features[%d] has unexpected type %q", ErrInvalidFeatureCollection, i, f.Type)
+		}
+		if f.Geometry == nil {
+			return nil, fmt.Errorf("%w: features[%d] has no geometry", ErrInvalidFeatureCollection, i)
+		}
+		g, err := nlslayer.NewGeometryFromMap(f.Geometry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: features[%d]: %v", ErrInvalidFeatureCollection, i, err)
+		}
+
+		feature, err := nlslayer.NewFeature(id.NewFeatureID(), f.Type, g)
+		if err != nil {
+			return nil, err
+		}
+		if f.Properties != nil {
+			feature.UpdateProperties(&f.Properties)
+		}
+		features = append(features, *feature)
+	}
+	return features, nil
+}
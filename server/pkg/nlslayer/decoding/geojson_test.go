@@ -0,0 +1,49 @@
+package decoding
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoJSONFeatures(t *testing.T) {
+	features, err := GeoJSONFeatures(strings.NewReader(`{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "id": "a", "geometry": {"type": "Point", "coordinates": [139.7, 35.6]}, "properties": {"name": "gate"}},
+			{"type": "Feature", "geometry": {"type": "MultiPoint", "coordinates": [[0, 0], [1, 1]]}, "properties": null}
+		]
+	}`))
+	assert.NoError(t, err)
+	assert.Len(t, features, 2)
+	assert.Equal(t, "Feature", features[0].FeatureType())
+	assert.Equal(t, nlslayer.NewPoint("Point", []float64{139.7, 35.6}), features[0].Geometry())
+	assert.Equal(t, map[string]any{"name": "gate"}, *features[0].Properties())
+	assert.Equal(t, nlslayer.NewMultiPoint("MultiPoint", [][]float64{{0, 0}, {1, 1}}), features[1].Geometry())
+	assert.Empty(t, *features[1].Properties())
+	assert.NotEqual(t, features[0].ID(), features[1].ID())
+}
+
+func TestGeoJSONFeatures_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "malformed", input: `{"type":`},
+		{name: "not a collection", input: `{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}}`},
+		{name: "no geometry", input: `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": null}]}`},
+		{name: "invalid geometry", input: `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Circle"}}]}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := GeoJSONFeatures(strings.NewReader(tt.input))
+			assert.True(t, errors.Is(err, ErrInvalidFeatureCollection))
+		})
+	}
+}
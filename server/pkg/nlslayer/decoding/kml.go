@@ -0,0 +1,350 @@
+package decoding
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/kml"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+var ErrKMLNoPlacemark = errors.New("kml has no placemarks")
+
+// KMLDecoder converts a parsed KML document into sketch layers.
+// A collection without folders becomes a NLSLayerSimple, otherwise it becomes a NLSLayerGroup
+// whose children are a NLSLayerSimple for its own placemarks followed by its folders.
+type KMLDecoder struct {
+	scene  id.SceneID
+	styles map[string]*scene.Style
+	result *Result
+}
+
+func NewKMLDecoder(sceneID id.SceneID) *KMLDecoder {
+	return &KMLDecoder{
+		scene:  sceneID,
+		styles: map[string]*scene.Style{},
+	}
+}
+
+func (d *KMLDecoder) Decode(c *kml.Collection, title string) (*Result, error) {
+	if c == nil || !hasPlacemark(c) {
+		return nil, ErrKMLNoPlacemark
+	}
+
+	d.result = &Result{}
+	d.decodeStyles(c, title)
+
+	root, err := d.decodeCollection(c, title)
+	if err != nil {
+		return nil, err
+	}
+	d.result.Root = root
+	return d.result, nil
+}
+
+func (d *KMLDecoder) decodeStyles(c *kml.Collection, prefix string) {
+	for _, s := range c.Styles {
+		if s.Id == "" {
+			continue
+		}
+		if _, ok := d.styles[s.Id]; ok {
+			continue
+		}
+		st := scene.NewStyle().
+			NewID().
+			Scene(d.scene).
+			Name(fmt.Sprintf("%s:%s", prefix, s.Id)).
+			Value(kmlStyleValue(s)).
+			MustBuild()
+		d.styles[s.Id] = st
+		d.result.Styles = append(d.result.Styles, st)
+	}
+	for i := range c.Folders {
+		d.decodeStyles(&c.Folders[i], prefix)
+	}
+}
+
+func (d *KMLDecoder) decodeCollection(c *kml.Collection, title string) (nlslayer.NLSLayer, error) {
+	if len(c.Folders) == 0 {
+		return d.decodeSimple(c.Placemarks, title)
+	}
+
+	children := make([]id.NLSLayerID, 0, len(c.Folders)+1)
+	if len(c.Placemarks) > 0 {
+		l, err := d.decodeSimple(c.Placemarks, title)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, l.ID())
+	}
+	for i := range c.Folders {
+		f := &c.Folders[i]
+		if !hasPlacemark(f) {
+			continue
+		}
+		name := f.Name
+		if name == "" {
+			name = fmt.Sprintf("%s %d", title, i+1)
+		}
+		l, err := d.decodeCollection(f, name)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, l.ID())
+	}
+
+	g, err := nlslayer.NewNLSLayerGroup().
+		NewID().
+		Scene(d.scene).
+		LayerType(nlslayer.Group).
+		Title(title).
+		IsVisible(true).
+		Config(&nlslayer.Config{}).
+		Layers(nlslayer.NewIDList(children)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	d.result.addLayer(g)
+	return g, nil
+}
+
+func (d *KMLDecoder) decodeSimple(placemarks []kml.Placemark, title string) (nlslayer.NLSLayer, error) {
+	schema := map[string]any{
+		"name":        "Text_1",
+		"description": "TextArea_2",
+	}
+	features := make([]nlslayer.Feature, 0, len(placemarks))
+	styleCount := map[string]int{}
+
+	for _, p := range placemarks {
+		g, err := placemarkGeometry(p)
+		if err != nil {
+			return nil, fmt.Errorf("placemark %q: %w", p.Name, err)
+		}
+		if g == nil {
+			continue
+		}
+
+		f, err := nlslayer.NewFeature(id.NewFeatureID(), "Feature", g)
+		if err != nil {
+			return nil, err
+		}
+
+		props := map[string]any{
+			"name":        p.Name,
+			"description": p.Description,
+		}
+		if p.StyleUrl != "" {
+			props["styleUrl"] = p.StyleUrl
+			styleCount[strings.TrimPrefix(p.StyleUrl, "#")]++
+		}
+		for _, ed := range p.ExtendedData.Data {
+			if ed.Name == "" {
+				continue
+			}
+			props[ed.Name] = ed.Value
+			if _, ok := schema[ed.Name]; !ok {
+				schema[ed.Name] = fmt.Sprintf("Text_%d", len(schema)+1)
+			}
+		}
+		f.UpdateProperties(&props)
+		features = append(features, *f)
+	}
+
+	var styleID *string
+	if st := d.mostUsedStyle(styleCount); st != nil {
+		sid := st.ID().String()
+		styleID = &sid
+	}
+
+	l, err := nlslayer.NewNLSLayerSimple().
+		NewID().
+		Scene(d.scene).
+		LayerType(nlslayer.Simple).
+		Title(title).
+		IsVisible(true).
+		Config(sketchConfig(title, styleID)).
+		IsSketch(true).
+		Sketch(newSketchInfo(schema, features)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	d.result.addLayer(l)
+	return l, nil
+}
+
+// mostUsedStyle returns the style referenced by the most placemarks of a layer, as a layer can link only one style.
+func (d *KMLDecoder) mostUsedStyle(count map[string]int) *scene.Style {
+	var res *scene.Style
+	best := 0
+	for sid, c := range count {
+		st, ok := d.styles[sid]
+		if !ok {
+			continue
+		}
+		if c > best || (c == best && st.Name() < res.Name()) {
+			res, best = st, c
+		}
+	}
+	return res
+}
+
+func placemarkGeometry(p kml.Placemark) (nlslayer.Geometry, error) {
+	var geometries []nlslayer.Geometry
+
+	add := func(g nlslayer.Geometry, err error) error {
+		if err != nil {
+			return err
+		}
+		if g != nil {
+			geometries = append(geometries, g)
+		}
+		return nil
+	}
+
+	if err := add(pointGeometry(p.Point)); err != nil {
+		return nil, err
+	}
+	if err := add(lineStringGeometry(p.Polyline)); err != nil {
+		return nil, err
+	}
+	if err := add(polygonGeometry(p.Polygon)); err != nil {
+		return nil, err
+	}
+	if mg := p.MultiGeometry; mg != nil {
+		for _, pt := range mg.Points {
+			if err := add(pointGeometry(pt)); err != nil {
+				return nil, err
+			}
+		}
+		for _, ls := range mg.LineStrings {
+			if err := add(lineStringGeometry(ls)); err != nil {
+				return nil, err
+			}
+		}
+		for _, pg := range mg.Polygons {
+			if err := add(polygonGeometry(pg)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch len(geometries) {
+	case 0:
+		return nil, nil
+	case 1:
+		return geometries[0], nil
+	}
+	return nlslayer.NewGeometryCollection("GeometryCollection", geometries), nil
+}
+
+func pointGeometry(p kml.Point) (nlslayer.Geometry, error) {
+	if strings.TrimSpace(p.Coordinates) == "" {
+		return nil, nil
+	}
+	coords, err := kml.ParseCoordinates(p.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+	if len(coords) != 1 {
+		return nil, kml.ErrInvalidCoords
+	}
+	return nlslayer.NewPoint("Point", coords[0]), nil
+}
+
+func lineStringGeometry(l kml.LineString) (nlslayer.Geometry, error) {
+	if strings.TrimSpace(l.Coordinates) == "" {
+		return nil, nil
+	}
+	coords, err := kml.ParseCoordinates(l.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+	if len(coords) < 2 {
+		return nil, kml.ErrInvalidCoords
+	}
+	return nlslayer.NewLineString("LineString", coords), nil
+}
+
+func polygonGeometry(p kml.Polygon) (nlslayer.Geometry, error) {
+	if strings.TrimSpace(p.OuterBoundaryIs.LinearRing.Coordinates) == "" {
+		return nil, nil
+	}
+	rings := make([][][]float64, 0, len(p.InnerBoundaryIs)+1)
+	for _, b := range append([]kml.BoundaryIs{p.OuterBoundaryIs}, p.InnerBoundaryIs...) {
+		ring, err := kml.ParseCoordinates(b.LinearRing.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		if len(ring) < 3 {
+			return nil, kml.ErrInvalidCoords
+		}
+		rings = append(rings, ring)
+	}
+	return nlslayer.NewPolygon("Polygon", rings), nil
+}
+
+func kmlStyleValue(s kml.Style) *scene.StyleValue {
+	v := scene.StyleValue{}
+
+	marker := map[string]any{}
+	if s.IconStyle.Icon != nil && s.IconStyle.Icon.Href != "" {
+		marker["style"] = "image"
+		marker["image"] = s.IconStyle.Icon.Href
+		if s.IconStyle.Scale > 0 {
+			marker["imageSize"] = s.IconStyle.Scale
+		}
+		if c, err := kml.ParseColor(s.IconStyle.Color); err == nil {
+			marker["imageColor"] = c
+		}
+	} else if c, err := kml.ParseColor(s.IconStyle.Color); err == nil {
+		marker["style"] = "point"
+		marker["pointColor"] = c
+	}
+	if len(marker) > 0 {
+		v["marker"] = marker
+	}
+
+	polyline := map[string]any{}
+	polygon := map[string]any{}
+	if c, err := kml.ParseColor(s.LineStyle.Color); err == nil {
+		polyline["strokeColor"] = c
+		polygon["strokeColor"] = c
+	}
+	if s.LineStyle.Width > 0 {
+		polyline["strokeWidth"] = s.LineStyle.Width
+		polygon["strokeWidth"] = s.LineStyle.Width
+	}
+	if c, err := kml.ParseColor(s.PolyStyle.Color); err == nil {
+		polygon["fillColor"] = c
+	}
+	if len(polygon) > 0 || s.PolyStyle.Fill != nil || s.PolyStyle.Stroke != nil {
+		polygon["fill"] = s.PolyStyle.IsFill()
+		polygon["stroke"] = s.PolyStyle.IsStroke()
+	}
+	if len(polyline) > 0 {
+		v["polyline"] = polyline
+	}
+	if len(polygon) > 0 {
+		v["polygon"] = polygon
+	}
+
+	return &v
+}
+
+func hasPlacemark(c *kml.Collection) bool {
+	if len(c.Placemarks) > 0 {
+		return true
+	}
+	for i := range c.Folders {
+		if hasPlacemark(&c.Folders[i]) {
+			return true
+		}
+	}
+	return false
+}
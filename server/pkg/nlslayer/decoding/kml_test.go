@@ -0,0 +1,142 @@
+package decoding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/kml"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKML = `<kml>
+  <Document>
+    <name>parks</name>
+    <Style id="red">
+      <IconStyle><color>ff0000ff</color></IconStyle>
+      <LineStyle><color>ff0000ff</color><width>2</width></LineStyle>
+      <PolyStyle><fill>0</fill></PolyStyle>
+    </Style>
+    <Placemark>
+      <name>gate</name>
+      <description>main gate</description>
+      <styleUrl>#red</styleUrl>
+      <Point><coordinates>139.7,35.6</coordinates></Point>
+    </Placemark>
+    <Folder>
+      <name>areas</name>
+      <Placemark>
+        <name>lawn</name>
+        <ExtendedData><Data name="area"><value>120</value></Data></ExtendedData>
+        <Polygon>
+          <outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1 0,0</coordinates></LinearRing></outerBoundaryIs>
+          <innerBoundaryIs><LinearRing><coordinates>0.1,0.1 0.2,0.1 0.2,0.2 0.1,0.1</coordinates></LinearRing></innerBoundaryIs>
+        </Polygon>
+      </Placemark>
+      <Placemark>
+        <name>paths</name>
+        <MultiGeometry>
+          <LineString><coordinates>0,0 1,1</coordinates></LineString>
+          <LineString><coordinates>1,1 2,2</coordinates></LineString>
+        </MultiGeometry>
+      </Placemark>
+    </Folder>
+    <Folder><name>empty</name></Folder>
+  </Document>
+</kml>`
+
+func TestKMLDecoder_Decode(t *testing.T) {
+	sid := id.NewSceneID()
+	c, err := kml.Parse(strings.NewReader(testKML))
+	assert.NoError(t, err)
+
+	res, err := NewKMLDecoder(sid).Decode(c, "parks")
+	assert.NoError(t, err)
+
+	// root group, layer for document placemarks and layer for the "areas" folder
+	assert.Len(t, res.Layers, 3)
+	root := nlslayer.ToNLSLayerGroup(res.Root)
+	assert.NotNil(t, root)
+	assert.Equal(t, "parks", root.Title())
+	assert.Equal(t, sid, root.Scene())
+	assert.Len(t, root.Children().Layers(), 2)
+
+	assert.Len(t, res.Styles, 1)
+	st := res.Styles[0]
+	assert.Equal(t, "parks:red", st.Name())
+	assert.Equal(t, &scene.StyleValue{
+		"marker": map[string]any{
+			"style":      "point",
+			"pointColor": "#ff0000",
+		},
+		"polyline": map[string]any{
+			"strokeColor": "#ff0000",
+			"strokeWidth": 2.0,
+		},
+		"polygon": map[string]any{
+			"strokeColor": "#ff0000",
+			"strokeWidth": 2.0,
+			"fill":        false,
+			"stroke":      true,
+		},
+	}, st.Value())
+
+	gate := nlslayer.ToNLSLayerSimple(*res.Layers.Find(root.Children().LayerAt(0)))
+	assert.True(t, gate.IsSketch())
+	assert.Equal(t, st.ID().String(), (*gate.Config())["layerStyleId"])
+	features := gate.Sketch().FeatureCollection().Features()
+	assert.Len(t, features, 1)
+	assert.Equal(t, "Feature", features[0].FeatureType())
+	assert.Equal(t, nlslayer.NewPoint("Point", []float64{139.7, 35.6}), features[0].Geometry())
+	assert.Equal(t, map[string]any{
+		"name":        "gate",
+		"description": "main gate",
+		"styleUrl":    "#red",
+	}, *features[0].Properties())
+
+	areas := nlslayer.ToNLSLayerSimple(*res.Layers.Find(root.Children().LayerAt(1)))
+	assert.Equal(t, "areas", areas.Title())
+	assert.Nil(t, (*areas.Config())["layerStyleId"])
+	assert.Equal(t, map[string]any{
+		"name":        "Text_1",
+		"description": "TextArea_2",
+		"area":        "Text_3",
+	}, *areas.Sketch().CustomPropertySchema())
+	features = areas.Sketch().FeatureCollection().Features()
+	assert.Len(t, features, 2)
+	assert.Equal(t, nlslayer.NewPolygon("Polygon", [][][]float64{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 0}},
+		{{0.1, 0.1}, {0.2, 0.1}, {0.2, 0.2}, {0.1, 0.1}},
+	}), features[0].Geometry())
+	assert.Equal(t, "120", (*features[0].Properties())["area"])
+	assert.Equal(t, nlslayer.NewGeometryCollection("GeometryCollection", []nlslayer.Geometry{
+		nlslayer.NewLineString("LineString", [][]float64{{0, 0}, {1, 1}}),
+		nlslayer.NewLineString("LineString", [][]float64{{1, 1}, {2, 2}}),
+	}), features[1].Geometry())
+}
+
+func TestKMLDecoder_DecodeSimple(t *testing.T) {
+	c, err := kml.Parse(strings.NewReader(`<kml><Placemark><Point><coordinates>1,2,3</coordinates></Point></Placemark></kml>`))
+	assert.NoError(t, err)
+
+	res, err := NewKMLDecoder(id.NewSceneID()).Decode(c, "points")
+	assert.NoError(t, err)
+	assert.Len(t, res.Layers, 1)
+	assert.Empty(t, res.Styles)
+
+	l := nlslayer.ToNLSLayerSimple(res.Root)
+	assert.NotNil(t, l)
+	assert.Equal(t, "points", l.Title())
+	assert.Equal(t, nlslayer.NewPoint("Point", []float64{1, 2, 3}), l.Sketch().FeatureCollection().Features()[0].Geometry())
+}
+
+func TestKMLDecoder_DecodeErrors(t *testing.T) {
+	_, err := NewKMLDecoder(id.NewSceneID()).Decode(&kml.Collection{}, "empty")
+	assert.ErrorIs(t, err, ErrKMLNoPlacemark)
+
+	c := &kml.Collection{Placemarks: []kml.Placemark{{Name: "broken", Polyline: kml.LineString{Coordinates: "1,2"}}}}
+	_, err = NewKMLDecoder(id.NewSceneID()).Decode(c, "broken")
+	assert.ErrorIs(t, err, kml.ErrInvalidCoords)
+}
@@ -0,0 +1,304 @@
+package decoding
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/shp"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+var (
+	ErrShapeNoFeature     = errors.New("shapefile has no features")
+	ErrUnsupportedShape   = errors.New("unsupported shape type")
+	ErrUnsupportedCharset = errors.New("unsupported shapefile charset")
+)
+
+// ShapeReader is implemented by shp.ZipReader.
+type ShapeReader interface {
+	Next() bool
+	Shape() (int, shp.Shape)
+	Attribute(int) string
+	Fields() []shp.Field
+	Projection() string
+	CodePage() string
+	Err() error
+}
+
+// ShapeDecoder converts a shapefile into a sketch layer.
+// DBF attributes become feature properties and the custom property schema,
+// and coordinates are reprojected to WGS84 according to the .prj file.
+type ShapeDecoder struct {
+	reader ShapeReader
+	scene  id.SceneID
+}
+
+func NewShapeDecoder(r ShapeReader, sceneID id.SceneID) *ShapeDecoder {
+	return &ShapeDecoder{
+		reader: r,
+		scene:  sceneID,
+	}
+}
+
+func (d *ShapeDecoder) Decode(title string) (*Result, error) {
+	proj, err := shp.ParseProjection(d.reader.Projection())
+	if err != nil {
+		return nil, err
+	}
+	charset, err := shapeCharset(d.reader.CodePage())
+	if err != nil {
+		return nil, err
+	}
+
+	fields := d.reader.Fields()
+	schema := make(map[string]any, len(fields))
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.String()
+		schema[names[i]] = fmt.Sprintf("%s_%d", shapeFieldType(f), i+1)
+	}
+
+	var features []nlslayer.Feature
+	for d.reader.Next() {
+		_, s := d.reader.Shape()
+		g, err := shapeGeometry(s, proj)
+		if err != nil {
+			return nil, err
+		}
+		if g == nil {
+			continue
+		}
+
+		f, err := nlslayer.NewFeature(id.NewFeatureID(), "Feature", g)
+		if err != nil {
+			return nil, err
+		}
+		props := map[string]any{}
+		for i, field := range fields {
+			if v, ok := shapeAttribute(field, d.reader.Attribute(i), charset); ok {
+				props[names[i]] = v
+			}
+		}
+		f.UpdateProperties(&props)
+		features = append(features, *f)
+	}
+	if err := d.reader.Err(); err != nil {
+		return nil, err
+	}
+	if len(features) == 0 {
+		return nil, ErrShapeNoFeature
+	}
+
+	l, err := nlslayer.NewNLSLayerSimple().
+		NewID().
+		Scene(d.scene).
+		LayerType(nlslayer.Simple).
+		Title(title).
+		IsVisible(true).
+		Config(sketchConfig(title, nil)).
+		IsSketch(true).
+		Sketch(newSketchInfo(schema, features)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Root: l}
+	res.addLayer(l)
+	return res, nil
+}
+
+func shapeCharset(cpg string) (encoding.Encoding, error) {
+	label := strings.ToLower(strings.TrimSpace(cpg))
+	switch label {
+	case "", "utf-8", "utf8", "65001":
+		return nil, nil
+	case "932", "cp932", "sjis":
+		label = "shift_jis"
+	case "1252", "cp1252", "ansi 1252":
+		label = "windows-1252"
+	}
+	e, err := htmlindex.Get(label)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCharset, cpg)
+	}
+	return e, nil
+}
+
+// shapeFieldType returns the custom property type of a DBF field.
+func shapeFieldType(f shp.Field) string {
+	switch f.Fieldtype {
+	case 'N':
+		if f.Precision == 0 {
+			return "Int"
+		}
+		return "Float"
+	case 'F':
+		return "Float"
+	case 'L':
+		return "Boolean"
+	}
+	return "Text"
+}
+
+func shapeAttribute(f shp.Field, v string, charset encoding.Encoding) (any, bool) {
+	v = strings.TrimRight(strings.TrimSpace(v), "\x00")
+	if v == "" {
+		return nil, false
+	}
+	switch f.Fieldtype {
+	case 'N', 'F':
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			// DBF writes "*" or other placeholders for missing numbers
+			return nil, false
+		}
+		return n, true
+	case 'L':
+		switch strings.ToUpper(v) {
+		case "T", "Y":
+			return true, true
+		case "F", "N":
+			return false, true
+		}
+		return nil, false
+	}
+	if charset != nil {
+		if s, err := charset.NewDecoder().String(v); err == nil {
+			v = s
+		}
+	}
+	return v, true
+}
+
+func shapeGeometry(s shp.Shape, proj *shp.Projection) (nlslayer.Geometry, error) {
+	switch s := s.(type) {
+	case *shp.Null, nil:
+		return nil, nil
+	case *shp.Point:
+		return nlslayer.NewPoint("Point", shapeCoords(proj, s.X, s.Y)), nil
+	case *shp.PointZ:
+		return nlslayer.NewPoint("Point", shapeCoords(proj, s.X, s.Y, s.Z)), nil
+	case *shp.PointM:
+		return nlslayer.NewPoint("Point", shapeCoords(proj, s.X, s.Y)), nil
+	case *shp.MultiPoint:
+		return shapeMultiPoint(proj, s.Points, nil), nil
+	case *shp.MultiPointZ:
+		return shapeMultiPoint(proj, s.Points, s.ZArray), nil
+	case *shp.MultiPointM:
+		return shapeMultiPoint(proj, s.Points, nil), nil
+	case *shp.PolyLine:
+		return shapeLines(shapeParts(proj, s.Parts, s.Points, nil)), nil
+	case *shp.PolyLineZ:
+		return shapeLines(shapeParts(proj, s.Parts, s.Points, s.ZArray)), nil
+	case *shp.PolyLineM:
+		return shapeLines(shapeParts(proj, s.Parts, s.Points, nil)), nil
+	case *shp.Polygon:
+		return shapePolygons(shapeParts(proj, s.Parts, s.Points, nil)), nil
+	case *shp.PolygonZ:
+		return shapePolygons(shapeParts(proj, s.Parts, s.Points, s.ZArray)), nil
+	case *shp.PolygonM:
+		return shapePolygons(shapeParts(proj, s.Parts, s.Points, nil)), nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedShape, s)
+}
+
+func shapeCoords(proj *shp.Projection, x, y float64, z ...float64) []float64 {
+	lon, lat := proj.ToWGS84(x, y)
+	return append([]float64{lon, lat}, z...)
+}
+
+func shapeMultiPoint(proj *shp.Projection, points []shp.Point, z []float64) nlslayer.Geometry {
+	coords := make([][]float64, 0, len(points))
+	for i, p := range points {
+		if i < len(z) {
+			coords = append(coords, shapeCoords(proj, p.X, p.Y, z[i]))
+		} else {
+			coords = append(coords, shapeCoords(proj, p.X, p.Y))
+		}
+	}
+	return nlslayer.NewMultiPoint("MultiPoint", coords)
+}
+
+// shapeParts splits the points of a shape into its parts.
+func shapeParts(proj *shp.Projection, parts []int32, points []shp.Point, z []float64) [][][]float64 {
+	res := make([][][]float64, 0, len(parts))
+	for i, start := range parts {
+		end := int32(len(points))
+		if i+1 < len(parts) {
+			end = parts[i+1]
+		}
+		if start < 0 || start > end || end > int32(len(points)) {
+			continue
+		}
+		part := make([][]float64, 0, end-start)
+		for j := start; j < end; j++ {
+			p := points[j]
+			if int(j) < len(z) {
+				part = append(part, shapeCoords(proj, p.X, p.Y, z[j]))
+			} else {
+				part = append(part, shapeCoords(proj, p.X, p.Y))
+			}
+		}
+		res = append(res, part)
+	}
+	return res
+}
+
+func shapeLines(parts [][][]float64) nlslayer.Geometry {
+	switch len(parts) {
+	case 0:
+		return nil
+	case 1:
+		return nlslayer.NewLineString("LineString", parts[0])
+	}
+	return nlslayer.NewMultiLineString("MultiLineString", parts)
+}
+
+// shapePolygons groups rings into polygons. Shapefile outer rings are clockwise and holes are counterclockwise,
+// and each hole belongs to the preceding outer ring. Rings are reversed to follow the GeoJSON right-hand rule.
+func shapePolygons(rings [][][]float64) nlslayer.Geometry {
+	var polygons [][][][]float64
+	for _, r := range rings {
+		if len(r) < 4 {
+			continue
+		}
+		hole := ringArea(r) > 0
+		r = reverseRing(r)
+		if hole && len(polygons) > 0 {
+			polygons[len(polygons)-1] = append(polygons[len(polygons)-1], r)
+			continue
+		}
+		polygons = append(polygons, [][][]float64{r})
+	}
+
+	switch len(polygons) {
+	case 0:
+		return nil
+	case 1:
+		return nlslayer.NewPolygon("Polygon", polygons[0])
+	}
+	return nlslayer.NewMultiPolygon("MultiPolygon", polygons)
+}
+
+// ringArea returns the signed area of a ring, which is positive if the ring is counterclockwise.
+func ringArea(r [][]float64) float64 {
+	var a float64
+	for i := 0; i+1 < len(r); i++ {
+		a += r[i][0]*r[i+1][1] - r[i+1][0]*r[i][1]
+	}
+	return a / 2
+}
+
+func reverseRing(r [][]float64) [][]float64 {
+	res := make([][]float64, len(r))
+	for i, c := range r {
+		res[len(r)-1-i] = c
+	}
+	return res
+}
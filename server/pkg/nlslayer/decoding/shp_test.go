@@ -0,0 +1,149 @@
+package decoding
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/shp"
+	"github.com/stretchr/testify/assert"
+)
+
+type shapeReaderMock struct {
+	shapes     []shp.Shape
+	attributes [][]string
+	fields     []shp.Field
+	prj        string
+	cpg        string
+	cur        int
+}
+
+func (r *shapeReaderMock) Next() bool {
+	r.cur++
+	return r.cur <= len(r.shapes)
+}
+
+func (r *shapeReaderMock) Shape() (int, shp.Shape) {
+	return r.cur - 1, r.shapes[r.cur-1]
+}
+
+func (r *shapeReaderMock) Attribute(n int) string {
+	return r.attributes[r.cur-1][n]
+}
+
+func (r *shapeReaderMock) Fields() []shp.Field {
+	return r.fields
+}
+
+func (r *shapeReaderMock) Projection() string {
+	return r.prj
+}
+
+func (r *shapeReaderMock) CodePage() string {
+	return r.cpg
+}
+
+func (r *shapeReaderMock) Err() error {
+	return nil
+}
+
+func testField(name string, t byte, precision uint8) shp.Field {
+	f := shp.Field{Fieldtype: t, Size: 10, Precision: precision}
+	copy(f.Name[:], name)
+	return f
+}
+
+func TestShapeDecoder_Decode(t *testing.T) {
+	sid := id.NewSceneID()
+	r := &shapeReaderMock{
+		shapes: []shp.Shape{
+			&shp.Point{X: 139.7, Y: 35.6},
+			&shp.Null{},
+			shp.NewPolyLine([][]shp.Point{{{X: 0, Y: 0}, {X: 1, Y: 1}}, {{X: 2, Y: 2}, {X: 3, Y: 3}}}),
+			&shp.Polygon{
+				NumParts: 2,
+				Parts:    []int32{0, 5},
+				Points: []shp.Point{
+					// clockwise outer ring
+					{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 0},
+					// counterclockwise hole
+					{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 2}, {X: 1, Y: 1},
+				},
+			},
+		},
+		attributes: [][]string{
+			{"gate", "12", "1.5", "T"},
+			{"", "", "", ""},
+			{"road", "*", "", "F"},
+			{"park", "3", "0.25", "?"},
+		},
+		fields: []shp.Field{
+			testField("NAME", 'C', 0),
+			testField("COUNT", 'N', 0),
+			testField("RATIO", 'N', 2),
+			testField("OPEN", 'L', 0),
+		},
+	}
+
+	res, err := NewShapeDecoder(r, sid).Decode("sites")
+	assert.NoError(t, err)
+	assert.Len(t, res.Layers, 1)
+	assert.Empty(t, res.Styles)
+
+	l := nlslayer.ToNLSLayerSimple(res.Root)
+	assert.NotNil(t, l)
+	assert.Equal(t, "sites", l.Title())
+	assert.Equal(t, sid, l.Scene())
+	assert.True(t, l.IsSketch())
+	assert.Equal(t, map[string]any{
+		"NAME":  "Text_1",
+		"COUNT": "Int_2",
+		"RATIO": "Float_3",
+		"OPEN":  "Boolean_4",
+	}, *l.Sketch().CustomPropertySchema())
+
+	features := l.Sketch().FeatureCollection().Features()
+	assert.Len(t, features, 3)
+	assert.Equal(t, nlslayer.NewPoint("Point", []float64{139.7, 35.6}), features[0].Geometry())
+	assert.Equal(t, map[string]any{"NAME": "gate", "COUNT": 12.0, "RATIO": 1.5, "OPEN": true}, *features[0].Properties())
+	assert.Equal(t, nlslayer.NewMultiLineString("MultiLineString", [][][]float64{
+		{{0, 0}, {1, 1}},
+		{{2, 2}, {3, 3}},
+	}), features[1].Geometry())
+	assert.Equal(t, map[string]any{"NAME": "road", "OPEN": false}, *features[1].Properties())
+	assert.Equal(t, nlslayer.NewPolygon("Polygon", [][][]float64{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+	}), features[2].Geometry())
+}
+
+func TestShapeDecoder_Decode_Reprojection(t *testing.T) {
+	r := &shapeReaderMock{
+		shapes: []shp.Shape{&shp.Point{X: 500000, Y: 0}},
+		prj:    `PROJCS["WGS_1984_UTM_Zone_54N",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Transverse_Mercator"],PARAMETER["False_Easting",500000.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",141.0],PARAMETER["Scale_Factor",0.9996],PARAMETER["Latitude_Of_Origin",0.0],UNIT["Meter",1.0]]`,
+	}
+
+	res, err := NewShapeDecoder(r, id.NewSceneID()).Decode("points")
+	assert.NoError(t, err)
+	p, ok := nlslayer.ToNLSLayerSimple(res.Root).Sketch().FeatureCollection().Features()[0].Geometry().(*nlslayer.Point)
+	assert.True(t, ok)
+	assert.InDelta(t, 141, p.Coordinates()[0], 1e-9)
+	assert.InDelta(t, 0, p.Coordinates()[1], 1e-9)
+}
+
+func TestShapeDecoder_Decode_Errors(t *testing.T) {
+	_, err := NewShapeDecoder(&shapeReaderMock{}, id.NewSceneID()).Decode("empty")
+	assert.ErrorIs(t, err, ErrShapeNoFeature)
+
+	_, err = NewShapeDecoder(&shapeReaderMock{
+		shapes: []shp.Shape{&shp.Point{}},
+		prj:    `PROJCS["x",GEOGCS["y",DATUM["z",SPHEROID["GRS 1980",6378137,298.257222101]]],PROJECTION["Albers"],UNIT["metre",1]]`,
+	}, id.NewSceneID()).Decode("albers")
+	assert.ErrorIs(t, err, shp.ErrUnsupportedProjection)
+
+	_, err = NewShapeDecoder(&shapeReaderMock{
+		shapes: []shp.Shape{&shp.Point{}},
+		cpg:    "unknown",
+	}, id.NewSceneID()).Decode("charset")
+	assert.ErrorIs(t, err, ErrUnsupportedCharset)
+}
@@ -0,0 +1,93 @@
+package encoding
+
+import (
+	"strconv"
+	"strings"
+)
+
+var namedColors = map[string][]int64{
+	"black":       {0, 0, 0, 255},
+	"white":       {255, 255, 255, 255},
+	"red":         {255, 0, 0, 255},
+	"green":       {0, 128, 0, 255},
+	"lime":        {0, 255, 0, 255},
+	"blue":        {0, 0, 255, 255},
+	"yellow":      {255, 255, 0, 255},
+	"cyan":        {0, 255, 255, 255},
+	"magenta":     {255, 0, 255, 255},
+	"orange":      {255, 165, 0, 255},
+	"purple":      {128, 0, 128, 255},
+	"gray":        {128, 128, 128, 255},
+	"grey":        {128, 128, 128, 255},
+	"transparent": {0, 0, 0, 0},
+}
+
+// ParseCSSColor parses "#rgb", "#rgba", "#rrggbb", "#rrggbbaa", "rgb()", "rgba()" and basic named colors into [r, g, b, a].
+func ParseCSSColor(s string) ([]int64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if c, ok := namedColors[s]; ok {
+		return append([]int64{}, c...), true
+	}
+	if strings.HasPrefix(s, "#") {
+		return parseHexColor(s[1:])
+	}
+	if strings.HasPrefix(s, "rgb") {
+		return parseRGBColor(s)
+	}
+	return nil, false
+}
+
+func parseHexColor(h string) ([]int64, bool) {
+	switch len(h) {
+	case 3, 4:
+		var b strings.Builder
+		for _, r := range h {
+			b.WriteRune(r)
+			b.WriteRune(r)
+		}
+		h = b.String()
+	case 6, 8:
+	default:
+		return nil, false
+	}
+	if len(h) == 6 {
+		h += "ff"
+	}
+	res := make([]int64, 0, 4)
+	for i := 0; i < 8; i += 2 {
+		v, err := strconv.ParseInt(h[i:i+2], 16, 64)
+		if err != nil {
+			return nil, false
+		}
+		res = append(res, v)
+	}
+	return res, true
+}
+
+func parseRGBColor(s string) ([]int64, bool) {
+	start, end := strings.Index(s, "("), strings.LastIndex(s, ")")
+	if start < 0 || end < start {
+		return nil, false
+	}
+	parts := strings.Split(s[start+1:end], ",")
+	if len(parts) != 3 && len(parts) != 4 {
+		return nil, false
+	}
+	res := make([]int64, 0, 4)
+	for _, p := range parts[:3] {
+		v, err := strconv.ParseInt(strings.TrimSpace(p), 10, 64)
+		if err != nil || v < 0 || v > 255 {
+			return nil, false
+		}
+		res = append(res, v)
+	}
+	alpha := int64(255)
+	if len(parts) == 4 {
+		a, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+		if err != nil || a < 0 || a > 1 {
+			return nil, false
+		}
+		alpha = int64(a*255 + 0.5)
+	}
+	return append(res, alpha), true
+}
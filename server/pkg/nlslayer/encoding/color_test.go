@@ -0,0 +1,36 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSSColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []int64
+		ok    bool
+	}{
+		{name: "hex", input: "#ff8000", want: []int64{255, 128, 0, 255}, ok: true},
+		{name: "hex with alpha", input: "#ff800080", want: []int64{255, 128, 0, 128}, ok: true},
+		{name: "short hex", input: "#f00", want: []int64{255, 0, 0, 255}, ok: true},
+		{name: "rgb", input: "rgb(1, 2, 3)", want: []int64{1, 2, 3, 255}, ok: true},
+		{name: "rgba", input: "rgba(1,2,3,0)", want: []int64{1, 2, 3, 0}, ok: true},
+		{name: "named", input: "Red", want: []int64{255, 0, 0, 255}, ok: true},
+		{name: "expression", input: "${color}", ok: false},
+		{name: "invalid hex", input: "#zzzzzz", ok: false},
+		{name: "out of range", input: "rgb(256, 0, 0)", ok: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseCSSColor(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package encoding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/reearth/reearth/server/pkg/czml"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+var ErrNotSketchLayer = errors.New("layer is not a sketch layer")
+
+const czmlVersion = "1.0"
+
+// CZMLEncoder writes a sketch layer as a CZML document.
+// Appearance is taken from the linked style and overridden by the "marker", "polyline" and "polygon" keys of the layer config.
+type CZMLEncoder struct {
+	writer io.Writer
+}
+
+func NewCZMLEncoder(w io.Writer) *CZMLEncoder {
+	return &CZMLEncoder{
+		writer: w,
+	}
+}
+
+func (e *CZMLEncoder) Encode(l *nlslayer.NLSLayerSimple, style *scene.Style) error {
+	packets, err := CZMLPackets(l, style)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(e.writer).Encode(packets)
+}
+
+// CZMLPackets builds the packets of a CZML document. The first packet is always the document packet.
+func CZMLPackets(l *nlslayer.NLSLayerSimple, style *scene.Style) ([]czml.Feature, error) {
+	if l == nil || !l.IsSketch() || l.Sketch() == nil {
+		return nil, ErrNotSketchLayer
+	}
+
+	a := newCZMLAppearance(l.Config(), style)
+	res := []czml.Feature{
+		{
+			Id:      "document",
+			Name:    l.Title(),
+			Version: czmlVersion,
+		},
+	}
+
+	fc := l.Sketch().FeatureCollection()
+	if fc == nil {
+		return res, nil
+	}
+	for _, f := range fc.Features() {
+		packets, err := a.packets(f.ID().String(), f.Geometry())
+		if err != nil {
+			return nil, err
+		}
+		props := *f.Properties()
+		for i := range packets {
+			if name, ok := props["name"].(string); ok {
+				packets[i].Name = name
+			}
+			if desc, ok := props["description"].(string); ok {
+				packets[i].Description = desc
+			}
+			if len(props) > 0 {
+				packets[i].Properties = props
+			}
+		}
+		res = append(res, packets...)
+	}
+	return res, nil
+}
+
+type czmlAppearance struct {
+	marker   map[string]any
+	polyline map[string]any
+	polygon  map[string]any
+}
+
+func newCZMLAppearance(c *nlslayer.Config, style *scene.Style) *czmlAppearance {
+	a := &czmlAppearance{
+		marker:   map[string]any{},
+		polyline: map[string]any{},
+		polygon:  map[string]any{},
+	}
+	if style != nil && style.Value() != nil {
+		a.merge(map[string]any(*style.Value()))
+	}
+	if c != nil {
+		a.merge(map[string]any(*c))
+	}
+	return a
+}
+
+func (a *czmlAppearance) merge(v map[string]any) {
+	for k, dest := range map[string]map[string]any{
+		"marker":   a.marker,
+		"polyline": a.polyline,
+		"polygon":  a.polygon,
+	} {
+		src, ok := v[k].(map[string]any)
+		if !ok {
+			continue
+		}
+		for kk, vv := range src {
+			dest[kk] = vv
+		}
+	}
+}
+
+func (a *czmlAppearance) packets(id string, g nlslayer.Geometry) ([]czml.Feature, error) {
+	switch g := g.(type) {
+	case *nlslayer.Point:
+		return []czml.Feature{{
+			Id:       id,
+			Position: &czml.Position{CartographicDegrees: g.Coordinates()},
+			Point:    a.point(),
+		}}, nil
+	case *nlslayer.MultiPoint:
+		res := make([]czml.Feature, 0, len(g.Coordinates()))
+		for i, c := range g.Coordinates() {
+			res = append(res, czml.Feature{
+				Id:       fmt.Sprintf("%s_%d", id, i),
+				Position: &czml.Position{CartographicDegrees: c},
+				Point:    a.point(),
+			})
+		}
+		return res, nil
+	case *nlslayer.LineString:
+		return []czml.Feature{{
+			Id:       id,
+			Polyline: a.line(g.Coordinates()),
+		}}, nil
+	case *nlslayer.MultiLineString:
+		res := make([]czml.Feature, 0, len(g.Coordinates()))
+		for i, l := range g.Coordinates() {
+			res = append(res, czml.Feature{
+				Id:       fmt.Sprintf("%s_%d", id, i),
+				Polyline: a.line(l),
+			})
+		}
+		return res, nil
+	case *nlslayer.Polygon:
+		return []czml.Feature{{
+			Id:      id,
+			Polygon: a.poly(g.Coordinates()),
+		}}, nil
+	case *nlslayer.MultiPolygon:
+		res := make([]czml.Feature, 0, len(g.Coordinates()))
+		for i, p := range g.Coordinates() {
+			res = append(res, czml.Feature{
+				Id:      fmt.Sprintf("%s_%d", id, i),
+				Polygon: a.poly(p),
+			})
+		}
+		return res, nil
+	case *nlslayer.GeometryCollection:
+		var res []czml.Feature
+		for i, gg := range g.Geometries() {
+			p, err := a.packets(fmt.Sprintf("%s_%d", id, i), gg)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, p...)
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("unsupported geometry type: %T", g)
+}
+
+func (a *czmlAppearance) point() *czml.Point {
+	return &czml.Point{
+		Color:        czmlColor(a.marker["pointColor"]),
+		PixelSize:    number(a.marker["pointSize"]),
+		OutlineColor: czmlColor(a.marker["pointOutlineColor"]),
+		OutlineWidth: number(a.marker["pointOutlineWidth"]),
+	}
+}
+
+func (a *czmlAppearance) line(coords [][]float64) *czml.Polyline {
+	p := &czml.Polyline{
+		Positions:     czml.Position{CartographicDegrees: flatten(coords)},
+		Width:         number(a.polyline["strokeWidth"]),
+		ClampToGround: boolean(a.polyline["clampToGround"], false),
+	}
+	if c := czmlColor(a.polyline["strokeColor"]); c != nil {
+		p.Material = &czml.Material{SolidColor: &czml.SolidColor{Color: c}}
+	}
+	return p
+}
+
+func (a *czmlAppearance) poly(rings [][][]float64) *czml.Polygon {
+	p := &czml.Polygon{
+		Fill:        boolean(a.polygon["fill"], true),
+		Stroke:      boolean(a.polygon["stroke"], false),
+		StrokeColor: czmlColor(a.polygon["strokeColor"]),
+		StrokeWidth: number(a.polygon["strokeWidth"]),
+	}
+	if len(rings) > 0 {
+		p.Positions = czml.Position{CartographicDegrees: flatten(rings[0])}
+	}
+	if len(rings) > 1 {
+		holes := make([][]float64, 0, len(rings)-1)
+		for _, r := range rings[1:] {
+			holes = append(holes, flatten(r))
+		}
+		p.Holes = &czml.Holes{CartographicDegrees: holes}
+	}
+	if c := czmlColor(a.polygon["fillColor"]); c != nil {
+		p.Material = &czml.Material{SolidColor: &czml.SolidColor{Color: c}}
+	}
+	return p
+}
+
+// flatten converts GeoJSON positions into the flat [lon, lat, height, ...] list used by CZML.
+func flatten(coords [][]float64) []float64 {
+	res := make([]float64, 0, len(coords)*3)
+	for _, c := range coords {
+		if len(c) < 2 {
+			continue
+		}
+		h := 0.0
+		if len(c) > 2 {
+			h = c[2]
+		}
+		res = append(res, c[0], c[1], h)
+	}
+	return res
+}
+
+// czmlColor converts a literal CSS color of a style value. Expressions and conditions are not evaluated.
+func czmlColor(v any) *czml.Color {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	rgba, ok := ParseCSSColor(s)
+	if !ok {
+		return nil
+	}
+	return &czml.Color{RGBA: rgba}
+}
+
+func number(v any) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case float32:
+		return float64(n)
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case int32:
+		return float64(n)
+	}
+	return 0
+}
+
+func boolean(v any, def bool) bool {
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	return def
+}
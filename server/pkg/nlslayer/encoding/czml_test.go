@@ -0,0 +1,145 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/czml"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSketchLayer(t *testing.T, config *nlslayer.Config, features ...nlslayer.Feature) *nlslayer.NLSLayerSimple {
+	t.Helper()
+	l, err := nlslayer.NewNLSLayerSimple().
+		NewID().
+		Scene(id.NewSceneID()).
+		LayerType(nlslayer.Simple).
+		Title("parks").
+		Config(config).
+		IsSketch(true).
+		Sketch(nlslayer.NewSketchInfo(nil, nlslayer.NewFeatureCollection("FeatureCollection", features))).
+		Build()
+	assert.NoError(t, err)
+	return l
+}
+
+func testFeature(t *testing.T, g nlslayer.Geometry, props map[string]any) nlslayer.Feature {
+	t.Helper()
+	f, err := nlslayer.NewFeature(id.NewFeatureID(), "Feature", g)
+	assert.NoError(t, err)
+	f.UpdateProperties(&props)
+	return *f
+}
+
+func TestCZMLPackets(t *testing.T) {
+	point := testFeature(t, nlslayer.NewPoint("Point", []float64{139.7, 35.6}), map[string]any{"name": "gate"})
+	line := testFeature(t, nlslayer.NewLineString("LineString", [][]float64{{0, 0}, {1, 1, 10}}), nil)
+	poly := testFeature(t, nlslayer.NewPolygon("Polygon", [][][]float64{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 0}},
+		{{0.1, 0.1}, {0.2, 0.1}, {0.2, 0.2}, {0.1, 0.1}},
+	}), nil)
+	style := scene.NewStyle().NewID().Scene(id.NewSceneID()).Name("s").Value(&scene.StyleValue{
+		"marker":   map[string]any{"pointColor": "#ff0000", "pointSize": 10.0},
+		"polyline": map[string]any{"strokeColor": "rgba(0, 0, 255, 0.5)", "strokeWidth": 2.0},
+		"polygon":  map[string]any{"fillColor": "#00ff00", "stroke": true, "strokeColor": "${color}"},
+	}).MustBuild()
+	config := &nlslayer.Config{
+		"polyline": map[string]any{"strokeWidth": 4},
+	}
+
+	res, err := CZMLPackets(testSketchLayer(t, config, point, line, poly), style)
+	assert.NoError(t, err)
+	assert.Equal(t, []czml.Feature{
+		{Id: "document", Name: "parks", Version: "1.0"},
+		{
+			Id:         point.ID().String(),
+			Name:       "gate",
+			Properties: map[string]any{"name": "gate"},
+			Position:   &czml.Position{CartographicDegrees: []float64{139.7, 35.6}},
+			Point: &czml.Point{
+				Color:     &czml.Color{RGBA: []int64{255, 0, 0, 255}},
+				PixelSize: 10,
+			},
+		},
+		{
+			Id: line.ID().String(),
+			Polyline: &czml.Polyline{
+				Positions: czml.Position{CartographicDegrees: []float64{0, 0, 0, 1, 1, 10}},
+				Material:  &czml.Material{SolidColor: &czml.SolidColor{Color: &czml.Color{RGBA: []int64{0, 0, 255, 128}}}},
+				Width:     4,
+			},
+		},
+		{
+			Id: poly.ID().String(),
+			Polygon: &czml.Polygon{
+				Positions: czml.Position{CartographicDegrees: []float64{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 0}},
+				Holes:     &czml.Holes{CartographicDegrees: [][]float64{{0.1, 0.1, 0, 0.2, 0.1, 0, 0.2, 0.2, 0, 0.1, 0.1, 0}}},
+				Fill:      true,
+				Material:  &czml.Material{SolidColor: &czml.SolidColor{Color: &czml.Color{RGBA: []int64{0, 255, 0, 255}}}},
+				Stroke:    true,
+			},
+		},
+	}, res)
+}
+
+func TestCZMLPackets_Collection(t *testing.T) {
+	f := testFeature(t, nlslayer.NewGeometryCollection("GeometryCollection", []nlslayer.Geometry{
+		nlslayer.NewPoint("Point", []float64{1, 2}),
+		nlslayer.NewMultiPolygon("MultiPolygon", [][][][]float64{
+			{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+			{{{2, 2}, {3, 2}, {3, 3}, {2, 2}}},
+		}),
+	}), nil)
+
+	res, err := CZMLPackets(testSketchLayer(t, nil, f), nil)
+	assert.NoError(t, err)
+	assert.Len(t, res, 4)
+	assert.Equal(t, f.ID().String()+"_0", res[1].Id)
+	assert.NotNil(t, res[1].Point)
+	assert.Equal(t, f.ID().String()+"_1_0", res[2].Id)
+	assert.Equal(t, f.ID().String()+"_1_1", res[3].Id)
+	assert.NotNil(t, res[3].Polygon)
+}
+
+func TestCZMLPackets_Multi(t *testing.T) {
+	f := testFeature(t, nlslayer.NewGeometryCollection("GeometryCollection", []nlslayer.Geometry{
+		nlslayer.NewMultiPoint("MultiPoint", [][]float64{{1, 2}, {3, 4}}),
+		nlslayer.NewMultiLineString("MultiLineString", [][][]float64{{{0, 0}, {1, 1}}}),
+	}), nil)
+
+	res, err := CZMLPackets(testSketchLayer(t, nil, f), nil)
+	assert.NoError(t, err)
+	assert.Len(t, res, 4)
+	assert.Equal(t, f.ID().String()+"_0_0", res[1].Id)
+	assert.Equal(t, &czml.Position{CartographicDegrees: []float64{1, 2}}, res[1].Position)
+	assert.Equal(t, f.ID().String()+"_0_1", res[2].Id)
+	assert.Equal(t, &czml.Position{CartographicDegrees: []float64{3, 4}}, res[2].Position)
+	assert.Equal(t, f.ID().String()+"_1_0", res[3].Id)
+	assert.Equal(t, czml.Position{CartographicDegrees: []float64{0, 0, 0, 1, 1, 0}}, res[3].Polyline.Positions)
+}
+
+func TestCZMLPackets_NotSketch(t *testing.T) {
+	l, err := nlslayer.NewNLSLayerSimple().NewID().Scene(id.NewSceneID()).LayerType(nlslayer.Simple).Build()
+	assert.NoError(t, err)
+
+	_, err = CZMLPackets(l, nil)
+	assert.Equal(t, ErrNotSketchLayer, err)
+}
+
+func TestCZMLEncoder_Encode(t *testing.T) {
+	f := testFeature(t, nlslayer.NewPoint("Point", []float64{1, 2}), nil)
+	buf := &bytes.Buffer{}
+
+	assert.NoError(t, NewCZMLEncoder(buf).Encode(testSketchLayer(t, nil, f), nil))
+
+	var res []map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.Len(t, res, 2)
+	assert.Equal(t, "document", res[0]["id"])
+	assert.Equal(t, "1.0", res[0]["version"])
+	assert.Equal(t, map[string]any{"cartographicDegrees": []any{1.0, 2.0}}, res[1]["position"])
+}
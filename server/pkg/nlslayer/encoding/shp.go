@@ -0,0 +1,387 @@
+package encoding
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/shp"
+	"github.com/reearth/reearth/server/pkg/writer"
+)
+
+var ErrNoShapeFeature = errors.New("layer has no features to export as shapefile")
+
+// WGS84PRJ is the .prj content of the exported shapefiles.
+const WGS84PRJ = `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`
+
+const (
+	shapeTextSize   = 254
+	shapeIntSize    = 18
+	shapeFloatSize  = 24
+	shapeFloatPrec  = 8
+	shapeFieldLimit = 10
+)
+
+var shapeNameRe = regexp.MustCompile(`[^0-9A-Za-z_\-]+`)
+
+// ShapeEncoder writes a sketch layer as a zip of shapefile sets (.shp, .shx, .dbf, .prj and .cpg).
+// A shapefile has only one shape type, so features are split into "point", "multipoint", "line" and "polygon" shapefiles.
+// DBF fields are derived from the custom property schema of the layer. Heights are dropped.
+type ShapeEncoder struct {
+	writer io.Writer
+}
+
+func NewShapeEncoder(w io.Writer) *ShapeEncoder {
+	return &ShapeEncoder{
+		writer: w,
+	}
+}
+
+type shapeRecord struct {
+	shape      shp.Shape
+	properties map[string]any
+}
+
+type shapeGroup struct {
+	suffix    string
+	shapeType shp.ShapeType
+	records   []shapeRecord
+}
+
+func (e *ShapeEncoder) Encode(l *nlslayer.NLSLayerSimple) error {
+	if l == nil || !l.IsSketch() || l.Sketch() == nil {
+		return ErrNotSketchLayer
+	}
+
+	groups := []*shapeGroup{
+		{suffix: "point", shapeType: shp.POINT},
+		{suffix: "multipoint", shapeType: shp.MULTIPOINT},
+		{suffix: "line", shapeType: shp.POLYLINE},
+		{suffix: "polygon", shapeType: shp.POLYGON},
+	}
+	if fc := l.Sketch().FeatureCollection(); fc != nil {
+		for _, f := range fc.Features() {
+			addShapeRecords(groups, f.Geometry(), *f.Properties())
+		}
+	}
+
+	fields, keys := shapeFields(l.Sketch().CustomPropertySchema())
+	base := shapeFileName(l.Title())
+	zw := zip.NewWriter(e.writer)
+	written := false
+	for _, g := range groups {
+		if len(g.records) == 0 {
+			continue
+		}
+		if err := writeShapeGroup(zw, base+"_"+g.suffix, g, fields, keys); err != nil {
+			return err
+		}
+		written = true
+	}
+	if !written {
+		return ErrNoShapeFeature
+	}
+	return zw.Close()
+}
+
+func addShapeRecords(groups []*shapeGroup, g nlslayer.Geometry, props map[string]any) {
+	points, lines, polygons := collectShapeParts(g)
+	switch len(points) {
+	case 0:
+	case 1:
+		groups[0].records = append(groups[0].records, shapeRecord{shape: &points[0], properties: props})
+	default:
+		groups[1].records = append(groups[1].records, shapeRecord{shape: &shp.MultiPoint{
+			Box:       shp.BBoxFromPoints(points),
+			NumPoints: int32(len(points)),
+			Points:    points,
+		}, properties: props})
+	}
+	if len(lines) > 0 {
+		groups[2].records = append(groups[2].records, shapeRecord{shape: shp.NewPolyLine(lines), properties: props})
+	}
+	if len(polygons) > 0 {
+		groups[3].records = append(groups[3].records, shapeRecord{shape: (*shp.Polygon)(shp.NewPolyLine(polygons)), properties: props})
+	}
+}
+
+// collectShapeParts flattens a geometry into points, line parts and polygon rings.
+// Outer rings are made clockwise and holes counterclockwise as required by the shapefile specification.
+func collectShapeParts(g nlslayer.Geometry) (points []shp.Point, lines [][]shp.Point, rings [][]shp.Point) {
+	switch g := g.(type) {
+	case *nlslayer.Point:
+		if c := g.Coordinates(); len(c) >= 2 {
+			points = append(points, shp.Point{X: c[0], Y: c[1]})
+		}
+	case *nlslayer.MultiPoint:
+		points = append(points, shapePoints(g.Coordinates())...)
+	case *nlslayer.LineString:
+		if p := shapePoints(g.Coordinates()); len(p) > 0 {
+			lines = append(lines, p)
+		}
+	case *nlslayer.MultiLineString:
+		for _, l := range g.Coordinates() {
+			if p := shapePoints(l); len(p) > 0 {
+				lines = append(lines, p)
+			}
+		}
+	case *nlslayer.Polygon:
+		rings = append(rings, shapeRings(g.Coordinates())...)
+	case *nlslayer.MultiPolygon:
+		for _, p := range g.Coordinates() {
+			rings = append(rings, shapeRings(p)...)
+		}
+	case *nlslayer.GeometryCollection:
+		for _, gg := range g.Geometries() {
+			p, l, r := collectShapeParts(gg)
+			points = append(points, p...)
+			lines = append(lines, l...)
+			rings = append(rings, r...)
+		}
+	}
+	return
+}
+
+func shapePoints(coords [][]float64) []shp.Point {
+	res := make([]shp.Point, 0, len(coords))
+	for _, c := range coords {
+		if len(c) >= 2 {
+			res = append(res, shp.Point{X: c[0], Y: c[1]})
+		}
+	}
+	return res
+}
+
+func shapeRings(polygon [][][]float64) [][]shp.Point {
+	res := make([][]shp.Point, 0, len(polygon))
+	for i, r := range polygon {
+		p := shapePoints(r)
+		if len(p) == 0 {
+			continue
+		}
+		// outer rings are clockwise (negative area) and holes are counterclockwise
+		if clockwise := signedArea(p) < 0; clockwise != (i == 0) {
+			for a, b := 0, len(p)-1; a < b; a, b = a+1, b-1 {
+				p[a], p[b] = p[b], p[a]
+			}
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+func signedArea(p []shp.Point) float64 {
+	var a float64
+	for i := 0; i+1 < len(p); i++ {
+		a += p[i].X*p[i+1].Y - p[i+1].X*p[i].Y
+	}
+	return a / 2
+}
+
+// shapeFields converts the custom property schema into DBF fields ordered by the index of the schema.
+// It also returns the property key of each field.
+func shapeFields(schema *map[string]any) ([]shp.Field, []string) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	type entry struct {
+		key   string
+		typ   string
+		index int
+	}
+	entries := make([]entry, 0, len(*schema))
+	for k, v := range *schema {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		typ, index := s, math.MaxInt
+		if i := strings.LastIndex(s, "_"); i >= 0 {
+			if n, err := strconv.Atoi(s[i+1:]); err == nil {
+				typ, index = s[:i], n
+			}
+		}
+		entries = append(entries, entry{key: k, typ: typ, index: index})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].index != entries[j].index {
+			return entries[i].index < entries[j].index
+		}
+		return entries[i].key < entries[j].key
+	})
+
+	fields := make([]shp.Field, 0, len(entries))
+	keys := make([]string, 0, len(entries))
+	used := map[string]struct{}{}
+	for _, e := range entries {
+		name := shapeFieldName(e.key, used)
+		var f shp.Field
+		switch e.typ {
+		case "Int":
+			f = shp.NumberField(name, shapeIntSize)
+		case "Float":
+			f = shp.FloatField(name, shapeFloatSize, shapeFloatPrec)
+		case "Boolean":
+			f = shp.LogicalField(name)
+		default:
+			f = shp.StringField(name, shapeTextSize)
+		}
+		fields = append(fields, f)
+		keys = append(keys, e.key)
+	}
+	return fields, keys
+}
+
+// shapeFieldName returns an unique DBF field name, which is limited to 10 ASCII characters.
+func shapeFieldName(key string, used map[string]struct{}) string {
+	name := shapeNameRe.ReplaceAllString(key, "_")
+	name = strings.ReplaceAll(name, "-", "_")
+	if name == "" || strings.Trim(name, "_") == "" {
+		name = "FIELD"
+	}
+	if len(name) > shapeFieldLimit {
+		name = name[:shapeFieldLimit]
+	}
+	candidate := name
+	for i := 1; ; i++ {
+		if _, ok := used[strings.ToUpper(candidate)]; !ok {
+			break
+		}
+		suffix := "_" + strconv.Itoa(i)
+		candidate = name
+		if len(candidate)+len(suffix) > shapeFieldLimit {
+			candidate = candidate[:shapeFieldLimit-len(suffix)]
+		}
+		candidate += suffix
+	}
+	used[strings.ToUpper(candidate)] = struct{}{}
+	return candidate
+}
+
+func shapeFileName(title string) string {
+	name := strings.Trim(shapeNameRe.ReplaceAllString(title, "_"), "_")
+	if name == "" {
+		return "layer"
+	}
+	return name
+}
+
+func writeShapeGroup(zw *zip.Writer, name string, g *shapeGroup, fields []shp.Field, keys []string) error {
+	shpw, shxw, dbfw := &writer.WriterSeeker{}, &writer.WriterSeeker{}, &writer.WriterSeeker{}
+
+	w, err := shp.CreateWithIndexFrom(shpw, shxw, g.shapeType)
+	if err != nil {
+		return err
+	}
+	if err := w.SetFields(dbfw, fields); err != nil {
+		return err
+	}
+	for _, r := range g.records {
+		row, err := w.Write(r.shape)
+		if err != nil {
+			return err
+		}
+		for i, f := range fields {
+			v, ok := shapeValue(f, r.properties[keys[i]])
+			if !ok {
+				continue
+			}
+			if err := w.WriteAttribute(int(row), i, v); err != nil {
+				return err
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	for _, f := range []struct {
+		ext  string
+		data []byte
+	}{
+		{".shp", shpw.Buffer()},
+		{".shx", shxw.Buffer()},
+		{".dbf", dbfw.Buffer()},
+		{".prj", []byte(WGS84PRJ)},
+		{".cpg", []byte("UTF-8")},
+	} {
+		fw, err := zw.Create(name + f.ext)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shapeValue converts a property value into a value for the DBF field. Values that cannot be converted are left empty.
+func shapeValue(f shp.Field, v any) (any, bool) {
+	if v == nil {
+		return nil, false
+	}
+	switch f.Fieldtype {
+	case 'N':
+		n, ok := toFloat(v)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, false
+		}
+		s := strconv.Itoa(int(math.Round(n)))
+		return int(math.Round(n)), len(s) <= int(f.Size)
+	case 'F':
+		n, ok := toFloat(v)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, false
+		}
+		return n, len(strconv.FormatFloat(n, 'f', int(f.Precision), 64)) <= int(f.Size)
+	case 'L':
+		b, ok := v.(bool)
+		return b, ok
+	}
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		s = fmt.Sprint(v)
+	}
+	return truncateUTF8(s, int(f.Size)), true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func truncateUTF8(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	s = s[:size]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
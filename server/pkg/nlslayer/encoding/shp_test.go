@@ -0,0 +1,118 @@
+package encoding
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/shp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShapeEncoder_Encode(t *testing.T) {
+	gate := testFeature(t, nlslayer.NewPoint("Point", []float64{139.7, 35.6, 10}), map[string]any{
+		"name": "gate", "visitors": 12.0, "ratio": 0.5, "open": true,
+	})
+	road := testFeature(t, nlslayer.NewLineString("LineString", [][]float64{{0, 0}, {1, 1}}), map[string]any{
+		"name": "road", "visitors": "many",
+	})
+	park := testFeature(t, nlslayer.NewPolygon("Polygon", [][][]float64{
+		// counterclockwise outer ring as in GeoJSON
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+	}), map[string]any{"name": "park"})
+	l := testSketchLayer(t, nil, gate, road, park)
+	l.Sketch().SetCustomPropertySchema(&map[string]any{
+		"name":     "Text_1",
+		"visitors": "Int_2",
+		"ratio":    "Float_3",
+		"open":     "Boolean_4",
+	})
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, NewShapeEncoder(buf).Encode(l))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		files[f.Name] = b
+	}
+	assert.Len(t, files, 15)
+	assert.Equal(t, WGS84PRJ, string(files["parks_point.prj"]))
+
+	read := func(name string) ([]shp.Shape, [][]string) {
+		sr := shp.SequentialReaderFromExt(io.NopCloser(bytes.NewReader(files[name+".shp"])), io.NopCloser(bytes.NewReader(files[name+".dbf"])))
+		var shapes []shp.Shape
+		var attrs [][]string
+		for sr.Next() {
+			_, s := sr.Shape()
+			shapes = append(shapes, s)
+			attrs = append(attrs, shp.Attributes(sr))
+		}
+		assert.NoError(t, sr.Err())
+		return shapes, attrs
+	}
+
+	shapes, attrs := read("parks_point")
+	assert.Equal(t, []shp.Shape{&shp.Point{X: 139.7, Y: 35.6}}, shapes)
+	assert.Equal(t, [][]string{{"gate", "12", "0.50000000", "T"}}, attrs)
+
+	shapes, attrs = read("parks_line")
+	assert.Len(t, shapes, 1)
+	assert.Equal(t, []shp.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}, shapes[0].(*shp.PolyLine).Points)
+	assert.Equal(t, [][]string{{"road", "", "", ""}}, attrs)
+
+	shapes, _ = read("parks_polygon")
+	assert.Len(t, shapes, 1)
+	// the outer ring is written clockwise
+	assert.Equal(t, []shp.Point{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 0}}, shapes[0].(*shp.Polygon).Points)
+}
+
+func TestShapeEncoder_Encode_Multi(t *testing.T) {
+	stops := testFeature(t, nlslayer.NewMultiPoint("MultiPoint", [][]float64{{1, 2}, {3, 4}}), nil)
+	routes := testFeature(t, nlslayer.NewMultiLineString("MultiLineString", [][][]float64{{{0, 0}, {1, 1}}, {{2, 2}, {3, 3}}}), nil)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, NewShapeEncoder(buf).Encode(testSketchLayer(t, nil, stops, routes)))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	shapes := map[string]shp.Shape{}
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".shp") {
+			continue
+		}
+		r, err := f.Open()
+		assert.NoError(t, err)
+		sr := shp.SequentialReaderFromExt(r, nil)
+		assert.True(t, sr.Next())
+		_, shapes[f.Name] = sr.Shape()
+		assert.NoError(t, sr.Err())
+	}
+	assert.Len(t, shapes, 2)
+	assert.Equal(t, []shp.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}, shapes["parks_multipoint.shp"].(*shp.MultiPoint).Points)
+	assert.Equal(t, []int32{0, 2}, shapes["parks_line.shp"].(*shp.PolyLine).Parts)
+}
+
+func TestShapeEncoder_Encode_Errors(t *testing.T) {
+	assert.Equal(t, ErrNoShapeFeature, NewShapeEncoder(io.Discard).Encode(testSketchLayer(t, nil)))
+
+	l, err := nlslayer.NewNLSLayerSimple().NewID().LayerType(nlslayer.Simple).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, ErrNotSketchLayer, NewShapeEncoder(io.Discard).Encode(l))
+}
+
+func TestShapeFieldName(t *testing.T) {
+	used := map[string]struct{}{}
+	assert.Equal(t, "population", shapeFieldName("population", used))
+	assert.Equal(t, "populati_1", shapeFieldName("population density", used))
+	assert.Equal(t, "FIELD", shapeFieldName("名前", used))
+	assert.Equal(t, "FIELD_1", shapeFieldName("住所", used))
+}
@@ -1,6 +1,10 @@
 package nlslayer
 
-import "github.com/reearth/reearth/server/pkg/id"
+import (
+	"reflect"
+
+	"github.com/reearth/reearth/server/pkg/id"
+)
 
 type Feature struct {
 	id          id.FeatureID
@@ -62,3 +66,15 @@ func (f *Feature) UpdateProperties(newProperty *map[string]any) {
 
 	f.properties = &clonedProperties
 }
+
+// Equal reports whether both features have the same ID, type, geometry and properties.
+// Missing properties are equal to empty properties.
+func (f *Feature) Equal(g Feature) bool {
+	if f == nil {
+		return false
+	}
+	return f.id == g.id &&
+		f.featureType == g.featureType &&
+		reflect.DeepEqual(f.geometry, g.geometry) &&
+		reflect.DeepEqual(*f.Properties(), *g.Properties())
+}
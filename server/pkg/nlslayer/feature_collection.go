@@ -29,6 +29,18 @@ func (fc *FeatureCollection) Features() []Feature {
 	return append([]Feature{}, fc.features...)
 }
 
+func (fc *FeatureCollection) Feature(id id.FeatureID) (Feature, bool) {
+	if fc == nil {
+		return Feature{}, false
+	}
+	for _, f := range fc.features {
+		if f.ID() == id {
+			return f, true
+		}
+	}
+	return Feature{}, false
+}
+
 func (fc *FeatureCollection) AddFeature(feature Feature) {
 	if fc == nil {
 		return
@@ -87,3 +99,79 @@ func (fc *FeatureCollection) RemoveFeature(id id.FeatureID) error {
 
 	return errors.New("feature not found")
 }
+
+func (fc *FeatureCollection) Clone() *FeatureCollection {
+	if fc == nil {
+		return nil
+	}
+	return &FeatureCollection{
+		featureCollectionType: fc.featureCollectionType,
+		features:              append([]Feature{}, fc.features...),
+	}
+}
+
+// PutFeature replaces the feature that has the same ID, or appends the feature when there is none.
+func (fc *FeatureCollection) PutFeature(feature Feature) {
+	if fc == nil {
+		return
+	}
+	for i, f := range fc.features {
+		if f.ID() == feature.ID() {
+			fc.features[i] = feature
+			return
+		}
+	}
+	fc.features = append(fc.features, feature)
+}
+
+// RenameProperty renames the property of every feature that has it.
+// It fails without changing any feature when a feature already has a property named newKey.
+func (fc *FeatureCollection) RenameProperty(oldKey, newKey string) error {
+	if fc == nil || oldKey == newKey {
+		return nil
+	}
+	for _, f := range fc.features {
+		if _, ok := (*f.Properties())[newKey]; ok {
+			return ErrCustomPropertyAlreadyExists.AddTemplateData("property", newKey)
+		}
+	}
+
+	for i, f := range fc.features {
+		props := *f.Properties()
+		v, ok := props[oldKey]
+		if !ok {
+			continue
+		}
+		// properties are copied since they may be shared with other copies of the collection
+		renamed := make(map[string]any, len(props))
+		for k, v := range props {
+			renamed[k] = v
+		}
+		delete(renamed, oldKey)
+		renamed[newKey] = v
+		f.properties = &renamed
+		fc.features[i] = f
+	}
+	return nil
+}
+
+// RemoveProperty removes the property from every feature that has it.
+func (fc *FeatureCollection) RemoveProperty(key string) {
+	if fc == nil {
+		return
+	}
+	for i, f := range fc.features {
+		props := *f.Properties()
+		if _, ok := props[key]; !ok {
+			continue
+		}
+		removed := make(map[string]any, len(props))
+		for k, v := range props {
+			if k != key {
+				removed[k] = v
+			}
+		}
+		f.properties = &removed
+		fc.features[i] = f
+	}
+}
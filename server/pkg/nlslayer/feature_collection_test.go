@@ -118,3 +118,74 @@ func TestFeatureCollectionRemoveFeature(t *testing.T) {
 	assert.Equal(t, featureCollectionType, fc.FeatureCollectionType())
 	assert.Equal(t, []Feature{}, fc.Features())
 }
+
+func TestFeatureCollectionFeature(t *testing.T) {
+	f, err := NewFeature(id.NewFeatureID(), "Feature", NewPoint("Point", []float64{1, 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := NewFeatureCollection("FeatureCollection", []Feature{*f})
+
+	got, ok := fc.Feature(f.ID())
+	assert.True(t, ok)
+	assert.Equal(t, *f, got)
+
+	_, ok = fc.Feature(id.NewFeatureID())
+	assert.False(t, ok)
+
+	_, ok = (*FeatureCollection)(nil).Feature(f.ID())
+	assert.False(t, ok)
+}
+
+func TestFeatureCollectionClone(t *testing.T) {
+	f, err := NewFeature(id.NewFeatureID(), "Feature", NewPoint("Point", []float64{1, 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := NewFeatureCollection("FeatureCollection", []Feature{*f})
+
+	cloned := fc.Clone()
+	assert.Equal(t, fc, cloned)
+	assert.NotSame(t, fc, cloned)
+
+	_, err = cloned.UpdateFeatureProperty(f.ID(), map[string]any{"key": "value"})
+	assert.NoError(t, err)
+	assert.NoError(t, cloned.RemoveFeature(f.ID()))
+	assert.Equal(t, []Feature{*f}, fc.Features())
+
+	assert.Nil(t, (*FeatureCollection)(nil).Clone())
+}
+
+func TestFeatureCollectionRenameProperty(t *testing.T) {
+	f1, _ := NewFeature(id.NewFeatureID(), "Feature", NewPoint("Point", []float64{1, 2}))
+	f1.UpdateProperties(&map[string]any{"old": 1, "other": 2})
+	f2, _ := NewFeature(id.NewFeatureID(), "Feature", NewPoint("Point", []float64{3, 4}))
+	fc := NewFeatureCollection("FeatureCollection", []Feature{*f1, *f2})
+	cloned := fc.Clone()
+
+	assert.NoError(t, cloned.RenameProperty("old", "new"))
+	assert.Equal(t, &map[string]any{"new": 1, "other": 2}, cloned.Features()[0].Properties())
+	assert.Equal(t, &map[string]any{}, cloned.Features()[1].Properties())
+	// the original collection shares the features but not the properties
+	assert.Equal(t, &map[string]any{"old": 1, "other": 2}, fc.Features()[0].Properties())
+
+	err := cloned.RenameProperty("new", "other")
+	assert.ErrorContains(t, err, ErrCustomPropertyAlreadyExists.Error())
+	assert.Equal(t, &map[string]any{"new": 1, "other": 2}, cloned.Features()[0].Properties())
+
+	assert.NoError(t, (*FeatureCollection)(nil).RenameProperty("old", "new"))
+}
+
+func TestFeatureCollectionRemoveProperty(t *testing.T) {
+	f, _ := NewFeature(id.NewFeatureID(), "Feature", NewPoint("Point", []float64{1, 2}))
+	f.UpdateProperties(&map[string]any{"key": 1, "other": 2})
+	fc := NewFeatureCollection("FeatureCollection", []Feature{*f})
+	cloned := fc.Clone()
+
+	cloned.RemoveProperty("key")
+	assert.Equal(t, &map[string]any{"other": 2}, cloned.Features()[0].Properties())
+	assert.Equal(t, &map[string]any{"key": 1, "other": 2}, fc.Features()[0].Properties())
+
+	cloned.RemoveProperty("unknown")
+	assert.Equal(t, &map[string]any{"other": 2}, cloned.Features()[0].Properties())
+}
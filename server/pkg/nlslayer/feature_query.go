@@ -0,0 +1,408 @@
+package nlslayer
+
+import (
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+const earthRadius = 6371008.8 // mean radius in meters
+
+// BBox is a bounding box in WGS84 degrees. West may be greater than East when the box crosses the antimeridian.
+type BBox struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
+}
+
+// Near matches features that lie within Distance meters of Center.
+type Near struct {
+	Center   []float64
+	Distance float64
+}
+
+type PropertyOperator string
+
+const (
+	PropertyOperatorEq       PropertyOperator = "eq"
+	PropertyOperatorNe       PropertyOperator = "ne"
+	PropertyOperatorGt       PropertyOperator = "gt"
+	PropertyOperatorGte      PropertyOperator = "gte"
+	PropertyOperatorLt       PropertyOperator = "lt"
+	PropertyOperatorLte      PropertyOperator = "lte"
+	PropertyOperatorContains PropertyOperator = "contains"
+	PropertyOperatorExists   PropertyOperator = "exists"
+)
+
+type PropertyPredicate struct {
+	Key      string
+	Operator PropertyOperator
+	Value    any
+}
+
+// FeatureQuery filters features of a feature collection. All non-empty conditions must match.
+type FeatureQuery struct {
+	BBox       *BBox
+	Intersects Geometry
+	Near       *Near
+	Properties []PropertyPredicate
+}
+
+// Query returns the features of the collection that match q, in collection order.
+func (fc *FeatureCollection) Query(q FeatureQuery) []Feature {
+	if fc == nil {
+		return nil
+	}
+	var res []Feature
+	for _, f := range fc.features {
+		if q.Match(f) {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+func (q FeatureQuery) Match(f Feature) bool {
+	g := f.Geometry()
+	if q.BBox != nil && !q.BBox.intersects(g) {
+		return false
+	}
+	if q.Intersects != nil && !Intersects(g, q.Intersects) {
+		return false
+	}
+	if q.Near != nil && !(Distance(q.Near.Center, g) <= q.Near.Distance) {
+		return false
+	}
+	for _, p := range q.Properties {
+		if !p.Match(f.Properties()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b BBox) intersects(g Geometry) bool {
+	if b.West > b.East {
+		return Intersects(g, BBox{West: b.West, South: b.South, East: 180, North: b.North}.polygon()) ||
+			Intersects(g, BBox{West: -180, South: b.South, East: b.East, North: b.North}.polygon())
+	}
+	return Intersects(g, b.polygon())
+}
+
+func (b BBox) polygon() *Polygon {
+	return NewPolygon("Polygon", [][][]float64{{
+		{b.West, b.South}, {b.East, b.South}, {b.East, b.North}, {b.West, b.North}, {b.West, b.South},
+	}})
+}
+
+// Match reports whether the properties satisfy the predicate. Predicates other than exists and ne never match a missing key.
+func (p PropertyPredicate) Match(properties *map[string]any) bool {
+	var v any
+	ok := false
+	if properties != nil {
+		v, ok = (*properties)[p.Key]
+	}
+
+	switch p.Operator {
+	case PropertyOperatorExists:
+		want, isBool := p.Value.(bool)
+		if !isBool {
+			want = true
+		}
+		return ok == want
+	case PropertyOperatorNe:
+		return !ok || !propertyEqual(v, p.Value)
+	}
+	if !ok {
+		return false
+	}
+
+	switch p.Operator {
+	case PropertyOperatorEq:
+		return propertyEqual(v, p.Value)
+	case PropertyOperatorGt:
+		c, ok := propertyCompare(v, p.Value)
+		return ok && c > 0
+	case PropertyOperatorGte:
+		c, ok := propertyCompare(v, p.Value)
+		return ok && c >= 0
+	case PropertyOperatorLt:
+		c, ok := propertyCompare(v, p.Value)
+		return ok && c < 0
+	case PropertyOperatorLte:
+		c, ok := propertyCompare(v, p.Value)
+		return ok && c <= 0
+	case PropertyOperatorContains:
+		return propertyContains(v, p.Value)
+	}
+	return false
+}
+
+func propertyEqual(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func propertyCompare(a, b any) (int, bool) {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		}
+		return 0, true
+	}
+	sa, ok1 := a.(string)
+	sb, ok2 := b.(string)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return strings.Compare(sa, sb), true
+}
+
+func propertyContains(v, target any) bool {
+	if s, ok := v.(string); ok {
+		t, ok := target.(string)
+		return ok && strings.Contains(s, t)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if propertyEqual(rv.Index(i).Interface(), target) {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// geometryParts is a geometry flattened into its points, lines and polygons.
+type geometryParts struct {
+	points   [][]float64
+	lines    [][][]float64
+	polygons [][][][]float64
+}
+
+// partsOf drops positions with less than two dimensions, so that the parts can be indexed safely.
+func partsOf(g Geometry) (p geometryParts) {
+	switch g := g.(type) {
+	case *Point:
+		p.points = planarPositions([][]float64{g.Coordinates()})
+	case *MultiPoint:
+		p.points = planarPositions(g.Coordinates())
+	case *LineString:
+		p.lines = [][][]float64{planarPositions(g.Coordinates())}
+	case *MultiLineString:
+		p.lines = lo.Map(g.Coordinates(), func(l [][]float64, _ int) [][]float64 { return planarPositions(l) })
+	case *Polygon:
+		p.polygons = [][][][]float64{planarRings(g.Coordinates())}
+	case *MultiPolygon:
+		p.polygons = lo.Map(g.Coordinates(), func(poly [][][]float64, _ int) [][][]float64 { return planarRings(poly) })
+	case *GeometryCollection:
+		for _, c := range g.Geometries() {
+			cp := partsOf(c)
+			p.points = append(p.points, cp.points...)
+			p.lines = append(p.lines, cp.lines...)
+			p.polygons = append(p.polygons, cp.polygons...)
+		}
+	}
+	return
+}
+
+func planarPositions(positions [][]float64) [][]float64 {
+	return lo.Filter(positions, func(c []float64, _ int) bool { return len(c) >= 2 })
+}
+
+func planarRings(rings [][][]float64) [][][]float64 {
+	return lo.Map(rings, func(r [][]float64, _ int) [][]float64 { return planarPositions(r) })
+}
+
+func (p geometryParts) vertices() [][]float64 {
+	res := append([][]float64{}, p.points...)
+	for _, l := range p.lines {
+		res = append(res, l...)
+	}
+	for _, poly := range p.polygons {
+		for _, r := range poly {
+			res = append(res, r...)
+		}
+	}
+	return res
+}
+
+// paths returns the lines and the polygon rings, whose consecutive positions form segments.
+func (p geometryParts) paths() [][][]float64 {
+	res := append([][][]float64{}, p.lines...)
+	for _, poly := range p.polygons {
+		res = append(res, poly...)
+	}
+	return res
+}
+
+// covers reports whether the position lies on a point, on a line, or inside or on the boundary of a polygon.
+func (p geometryParts) covers(c []float64) bool {
+	if len(c) < 2 {
+		return false
+	}
+	for _, q := range p.points {
+		if q[0] == c[0] && q[1] == c[1] {
+			return true
+		}
+	}
+	for _, path := range p.paths() {
+		for i := 0; i+1 < len(path); i++ {
+			if orientation(path[i], path[i+1], c) == 0 && onSegment(path[i], path[i+1], c) {
+				return true
+			}
+		}
+	}
+	for _, poly := range p.polygons {
+		if polygonContains(poly, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersects reports whether two geometries share at least one position, treating coordinates as planar.
+func Intersects(a, b Geometry) bool {
+	pa, pb := partsOf(a), partsOf(b)
+	for _, v := range pa.vertices() {
+		if pb.covers(v) {
+			return true
+		}
+	}
+	for _, v := range pb.vertices() {
+		if pa.covers(v) {
+			return true
+		}
+	}
+	for _, la := range pa.paths() {
+		for i := 0; i+1 < len(la); i++ {
+			for _, lb := range pb.paths() {
+				for j := 0; j+1 < len(lb); j++ {
+					if segmentsIntersect(la[i], la[i+1], lb[j], lb[j+1]) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Distance returns the distance in meters from the position to the nearest part of the geometry.
+// It is zero when the position lies inside a polygon. Distances to line segments are measured on a local
+// equirectangular projection around the position, which is accurate for distances up to a few hundred kilometers.
+func Distance(c []float64, g Geometry) float64 {
+	if len(c) < 2 {
+		return math.Inf(1)
+	}
+	p := partsOf(g)
+	for _, poly := range p.polygons {
+		if polygonContains(poly, c) {
+			return 0
+		}
+	}
+
+	d := math.Inf(1)
+	for _, v := range p.vertices() {
+		d = math.Min(d, haversine(c, v))
+	}
+	for _, path := range p.paths() {
+		for i := 0; i+1 < len(path); i++ {
+			d = math.Min(d, segmentDistance(c, path[i], path[i+1]))
+		}
+	}
+	return d
+}
+
+// polygonContains reports whether the position lies inside the outer ring and outside every hole.
+// A position on a boundary is inside.
+func polygonContains(rings [][][]float64, c []float64) bool {
+	if len(rings) == 0 || !ringContains(rings[0], c) {
+		return false
+	}
+	for _, hole := range rings[1:] {
+		if ringContains(hole, c) && !onRing(hole, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func ringContains(ring [][]float64, c []float64) bool {
+	if onRing(ring, c) {
+		return true
+	}
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > c[1]) != (b[1] > c[1]) && c[0] < (b[0]-a[0])*(c[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func onRing(ring [][]float64, c []float64) bool {
+	for i := 0; i+1 < len(ring); i++ {
+		if orientation(ring[i], ring[i+1], c) == 0 && onSegment(ring[i], ring[i+1], c) {
+			return true
+		}
+	}
+	return false
+}
+
+func haversine(a, b []float64) float64 {
+	lat1, lat2 := a[1]*math.Pi/180, b[1]*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b[0] - a[0]) * math.Pi / 180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func segmentDistance(c, a, b []float64) float64 {
+	project := func(p []float64) (float64, float64) {
+		dLng := math.Mod(p[0]-c[0]+540, 360) - 180
+		x := dLng * math.Pi / 180 * math.Cos(c[1]*math.Pi/180) * earthRadius
+		y := (p[1] - c[1]) * math.Pi / 180 * earthRadius
+		return x, y
+	}
+	ax, ay := project(a)
+	bx, by := project(b)
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
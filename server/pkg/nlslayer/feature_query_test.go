@@ -0,0 +1,134 @@
+package nlslayer
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureCollectionQuery(t *testing.T) {
+	feature := func(g Geometry, props map[string]any) Feature {
+		f := lo.Must(NewFeature(id.NewFeatureID(), "Feature", g))
+		f.UpdateProperties(&props)
+		return *f
+	}
+	tokyo := feature(NewPoint("Point", []float64{139.767, 35.681}), map[string]any{"name": "Tokyo", "population": float64(14000000), "tags": []any{"capital", "port"}})
+	yokohama := feature(NewPoint("Point", []float64{139.638, 35.444}), map[string]any{"name": "Yokohama", "population": int32(3700000)})
+	osaka := feature(NewPoint("Point", []float64{135.502, 34.694}), map[string]any{"name": "Osaka"})
+	line := feature(NewLineString("LineString", [][]float64{{139, 36}, {140, 36}}), nil)
+	fc := NewFeatureCollection("FeatureCollection", []Feature{tokyo, yokohama, osaka, line})
+
+	tests := []struct {
+		name  string
+		query FeatureQuery
+		want  []Feature
+	}{
+		{
+			name:  "empty query",
+			query: FeatureQuery{},
+			want:  []Feature{tokyo, yokohama, osaka, line},
+		},
+		{
+			name:  "bbox",
+			query: FeatureQuery{BBox: &BBox{West: 139, South: 35, East: 140, North: 35.6}},
+			want:  []Feature{yokohama},
+		},
+		{
+			name:  "bbox touching a line",
+			query: FeatureQuery{BBox: &BBox{West: 139.5, South: 35.6, East: 139.6, North: 36}},
+			want:  []Feature{line},
+		},
+		{
+			name:  "bbox crossing the antimeridian",
+			query: FeatureQuery{BBox: &BBox{West: 170, South: -90, East: 136, North: 90}},
+			want:  []Feature{osaka},
+		},
+		{
+			name: "intersects",
+			query: FeatureQuery{Intersects: NewPolygon("Polygon", [][][]float64{
+				{{139.5, 35}, {140, 35}, {140, 37}, {139.5, 37}, {139.5, 35}},
+				{{139.6, 35.3}, {139.7, 35.3}, {139.7, 35.5}, {139.6, 35.5}, {139.6, 35.3}},
+			})},
+			want: []Feature{tokyo, line},
+		},
+		{
+			name:  "near",
+			query: FeatureQuery{Near: &Near{Center: []float64{139.767, 35.681}, Distance: 30000}},
+			want:  []Feature{tokyo, yokohama},
+		},
+		{
+			name:  "near a segment",
+			query: FeatureQuery{Near: &Near{Center: []float64{139.5, 36.01}, Distance: 2000}},
+			want:  []Feature{line},
+		},
+		{
+			name: "properties",
+			query: FeatureQuery{Properties: []PropertyPredicate{
+				{Key: "population", Operator: PropertyOperatorGte, Value: 3700000},
+				{Key: "name", Operator: PropertyOperatorNe, Value: "Tokyo"},
+			}},
+			want: []Feature{yokohama},
+		},
+		{
+			name: "combined",
+			query: FeatureQuery{
+				Near:       &Near{Center: []float64{139.767, 35.681}, Distance: 30000},
+				Properties: []PropertyPredicate{{Key: "tags", Operator: PropertyOperatorContains, Value: "capital"}},
+			},
+			want: []Feature{tokyo},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, fc.Query(tt.query))
+		})
+	}
+
+	assert.Nil(t, (*FeatureCollection)(nil).Query(FeatureQuery{}))
+}
+
+func TestPropertyPredicate_Match(t *testing.T) {
+	props := &map[string]any{"name": "gate", "height": float64(12.5), "floors": int64(3), "open": true}
+
+	tests := []struct {
+		name      string
+		predicate PropertyPredicate
+		want      bool
+	}{
+		{name: "eq string", predicate: PropertyPredicate{Key: "name", Operator: PropertyOperatorEq, Value: "gate"}, want: true},
+		{name: "eq number of another type", predicate: PropertyPredicate{Key: "floors", Operator: PropertyOperatorEq, Value: float64(3)}, want: true},
+		{name: "eq bool", predicate: PropertyPredicate{Key: "open", Operator: PropertyOperatorEq, Value: false}, want: false},
+		{name: "ne missing", predicate: PropertyPredicate{Key: "color", Operator: PropertyOperatorNe, Value: "red"}, want: true},
+		{name: "gt", predicate: PropertyPredicate{Key: "height", Operator: PropertyOperatorGt, Value: 12}, want: true},
+		{name: "lt", predicate: PropertyPredicate{Key: "height", Operator: PropertyOperatorLt, Value: 12}, want: false},
+		{name: "lte string", predicate: PropertyPredicate{Key: "name", Operator: PropertyOperatorLte, Value: "gate"}, want: true},
+		{name: "gt mismatched types", predicate: PropertyPredicate{Key: "name", Operator: PropertyOperatorGt, Value: 1}, want: false},
+		{name: "contains", predicate: PropertyPredicate{Key: "name", Operator: PropertyOperatorContains, Value: "at"}, want: true},
+		{name: "exists", predicate: PropertyPredicate{Key: "open", Operator: PropertyOperatorExists}, want: true},
+		{name: "not exists", predicate: PropertyPredicate{Key: "open", Operator: PropertyOperatorExists, Value: false}, want: false},
+		{name: "missing key", predicate: PropertyPredicate{Key: "color", Operator: PropertyOperatorEq, Value: "red"}, want: false},
+		{name: "unknown operator", predicate: PropertyPredicate{Key: "name", Operator: "like", Value: "gate"}, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.predicate.Match(props))
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	square := NewPolygon("Polygon", [][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}})
+
+	assert.Equal(t, 0.0, Distance([]float64{0.5, 0.5}, square))
+	assert.InDelta(t, 111195, Distance([]float64{0.5, 2}, square), 100)
+	assert.InDelta(t, 111195, Distance([]float64{0, 0}, NewPoint("Point", []float64{1, 0})), 1)
+	assert.InDelta(t, 111195, Distance([]float64{179.5, 0}, NewLineString("LineString", [][]float64{{-179.5, 1}, {-179.5, -1}})), 100)
+}
@@ -0,0 +1,217 @@
+package nlslayer
+
+import (
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+)
+
+var ErrInvalidFeatureRevision = errors.New("invalid feature revision")
+
+type FeatureRevisionAction string
+
+const (
+	FeatureRevisionActionAdd    FeatureRevisionAction = "add"
+	FeatureRevisionActionUpdate FeatureRevisionAction = "update"
+	FeatureRevisionActionDelete FeatureRevisionAction = "delete"
+)
+
+// FeatureRevision records a single mutation of a feature in a sketch layer.
+// Before is nil for an added feature and After is nil for a deleted feature.
+type FeatureRevision struct {
+	id       id.FeatureRevisionID
+	layer    id.NLSLayerID
+	scene    id.SceneID
+	feature  id.FeatureID
+	action   FeatureRevisionAction
+	before   *Feature
+	after    *Feature
+	operator *accountsID.UserID
+}
+
+func (r *FeatureRevision) ID() id.FeatureRevisionID {
+	return r.id
+}
+
+func (r *FeatureRevision) Layer() id.NLSLayerID {
+	return r.layer
+}
+
+func (r *FeatureRevision) Scene() id.SceneID {
+	return r.scene
+}
+
+func (r *FeatureRevision) Feature() id.FeatureID {
+	return r.feature
+}
+
+func (r *FeatureRevision) Action() FeatureRevisionAction {
+	return r.action
+}
+
+func (r *FeatureRevision) Before() *Feature {
+	return r.before
+}
+
+func (r *FeatureRevision) After() *Feature {
+	return r.after
+}
+
+func (r *FeatureRevision) Operator() *accountsID.UserID {
+	return r.operator
+}
+
+func (r *FeatureRevision) CreatedAt() time.Time {
+	if r == nil {
+		return time.Time{}
+	}
+	return r.id.Timestamp()
+}
+
+type FeatureRevisionBuilder struct {
+	r *FeatureRevision
+}
+
+func NewFeatureRevision() *FeatureRevisionBuilder {
+	return &FeatureRevisionBuilder{r: &FeatureRevision{}}
+}
+
+func (b *FeatureRevisionBuilder) Build() (*FeatureRevision, error) {
+	if b.r.id.IsNil() || b.r.layer.IsNil() || b.r.scene.IsNil() || b.r.feature.IsNil() {
+		return nil, idx.ErrInvalidID
+	}
+	switch b.r.action {
+	case FeatureRevisionActionAdd:
+		if b.r.before != nil || b.r.after == nil {
+			return nil, ErrInvalidFeatureRevision
+		}
+	case FeatureRevisionActionUpdate:
+		if b.r.before == nil || b.r.after == nil {
+			return nil, ErrInvalidFeatureRevision
+		}
+	case FeatureRevisionActionDelete:
+		if b.r.before == nil || b.r.after != nil {
+			return nil, ErrInvalidFeatureRevision
+		}
+	default:
+		return nil, ErrInvalidFeatureRevision
+	}
+	return b.r, nil
+}
+
+func (b *FeatureRevisionBuilder) MustBuild() *FeatureRevision {
+	r, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (b *FeatureRevisionBuilder) ID(id id.FeatureRevisionID) *FeatureRevisionBuilder {
+	b.r.id = id
+	return b
+}
+
+func (b *FeatureRevisionBuilder) NewID() *FeatureRevisionBuilder {
+	b.r.id = id.NewFeatureRevisionID()
+	return b
+}
+
+func (b *FeatureRevisionBuilder) Layer(layer id.NLSLayerID) *FeatureRevisionBuilder {
+	b.r.layer = layer
+	return b
+}
+
+func (b *FeatureRevisionBuilder) Scene(scene id.SceneID) *FeatureRevisionBuilder {
+	b.r.scene = scene
+	return b
+}
+
+func (b *FeatureRevisionBuilder) Feature(feature id.FeatureID) *FeatureRevisionBuilder {
+	b.r.feature = feature
+	return b
+}
+
+func (b *FeatureRevisionBuilder) Action(action FeatureRevisionAction) *FeatureRevisionBuilder {
+	b.r.action = action
+	return b
+}
+
+func (b *FeatureRevisionBuilder) Before(before *Feature) *FeatureRevisionBuilder {
+	b.r.before = before
+	return b
+}
+
+func (b *FeatureRevisionBuilder) After(after *Feature) *FeatureRevisionBuilder {
+	b.r.after = after
+	return b
+}
+
+func (b *FeatureRevisionBuilder) Operator(operator *accountsID.UserID) *FeatureRevisionBuilder {
+	b.r.operator = operator
+	return b
+}
+
+// DiffFeatureCollections returns the revisions that turn before into after, one per added, updated or deleted feature.
+// Features are compared by ID, and a feature whose type, geometry and properties are unchanged produces no revision.
+func DiffFeatureCollections(layer NLSLayer, before, after *FeatureCollection, operator *accountsID.UserID) []*FeatureRevision {
+	var res []*FeatureRevision
+	newRevision := func(fid id.FeatureID, action FeatureRevisionAction, b, a *Feature) {
+		res = append(res, NewFeatureRevision().
+			NewID().
+			Layer(layer.ID()).
+			Scene(layer.Scene()).
+			Feature(fid).
+			Action(action).
+			Before(b).
+			After(a).
+			Operator(operator).
+			MustBuild())
+	}
+
+	beforeFeatures, afterFeatures := before.Features(), after.Features()
+	afterIndex := make(map[id.FeatureID]int, len(afterFeatures))
+	for i, f := range afterFeatures {
+		afterIndex[f.ID()] = i
+	}
+	beforeIDs := make(map[id.FeatureID]struct{}, len(beforeFeatures))
+
+	for _, f := range beforeFeatures {
+		f := f
+		beforeIDs[f.ID()] = struct{}{}
+		i, ok := afterIndex[f.ID()]
+		if !ok {
+			newRevision(f.ID(), FeatureRevisionActionDelete, &f, nil)
+		} else if a := afterFeatures[i]; !f.Equal(a) {
+			newRevision(f.ID(), FeatureRevisionActionUpdate, &f, &a)
+		}
+	}
+	for _, f := range afterFeatures {
+		f := f
+		if _, ok := beforeIDs[f.ID()]; !ok {
+			newRevision(f.ID(), FeatureRevisionActionAdd, nil, &f)
+		}
+	}
+	return res
+}
+
+// RevertFeatureCollection returns a copy of fc with the revisions undone, restoring each feature to its state before the revision.
+// Revisions must be sorted from the oldest to the newest.
+func RevertFeatureCollection(fc *FeatureCollection, revisions []*FeatureRevision) *FeatureCollection {
+	res := fc.Clone()
+	if res == nil {
+		res = NewFeatureCollection("FeatureCollection", nil)
+	}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		r := revisions[i]
+		if r.Before() == nil {
+			_ = res.RemoveFeature(r.Feature())
+		} else {
+			res.PutFeature(*r.Before())
+		}
+	}
+	return res
+}
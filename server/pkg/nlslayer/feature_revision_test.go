@@ -0,0 +1,80 @@
+package nlslayer
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureRevisionBuilder_Build(t *testing.T) {
+	f := Feature{id: id.NewFeatureID(), featureType: "Feature", geometry: NewPoint("Point", []float64{1, 2})}
+	base := func() *FeatureRevisionBuilder {
+		return NewFeatureRevision().NewID().Layer(id.NewNLSLayerID()).Scene(id.NewSceneID()).Feature(f.ID())
+	}
+
+	tests := []struct {
+		name    string
+		builder *FeatureRevisionBuilder
+		wantErr error
+	}{
+		{name: "add", builder: base().Action(FeatureRevisionActionAdd).After(&f)},
+		{name: "update", builder: base().Action(FeatureRevisionActionUpdate).Before(&f).After(&f)},
+		{name: "delete", builder: base().Action(FeatureRevisionActionDelete).Before(&f)},
+		{name: "add with before", builder: base().Action(FeatureRevisionActionAdd).Before(&f).After(&f), wantErr: ErrInvalidFeatureRevision},
+		{name: "delete with after", builder: base().Action(FeatureRevisionActionDelete).After(&f), wantErr: ErrInvalidFeatureRevision},
+		{name: "unknown action", builder: base().Action("move").Before(&f), wantErr: ErrInvalidFeatureRevision},
+		{name: "no layer", builder: NewFeatureRevision().NewID().Scene(id.NewSceneID()).Feature(f.ID()).Action(FeatureRevisionActionAdd).After(&f), wantErr: idx.ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := tt.builder.Build()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, r)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, r.ID().Timestamp(), r.CreatedAt())
+		})
+	}
+}
+
+func TestDiffAndRevertFeatureCollections(t *testing.T) {
+	layer := NewNLSLayerSimple().NewID().Scene(id.NewSceneID()).MustBuild()
+	a := Feature{id: id.NewFeatureID(), featureType: "Feature", geometry: NewPoint("Point", []float64{1, 2})}
+	b := Feature{id: id.NewFeatureID(), featureType: "Feature", geometry: NewPoint("Point", []float64{3, 4})}
+	c := Feature{id: id.NewFeatureID(), featureType: "Feature", geometry: NewPoint("Point", []float64{5, 6})}
+	movedB := b
+	movedB.geometry = NewPoint("Point", []float64{7, 8})
+
+	before := NewFeatureCollection("FeatureCollection", []Feature{a, b})
+	after := NewFeatureCollection("FeatureCollection", []Feature{movedB, c})
+
+	revisions := DiffFeatureCollections(layer, before, after, nil)
+	assert.Len(t, revisions, 3)
+	assert.Equal(t, FeatureRevisionActionDelete, revisions[0].Action())
+	assert.Equal(t, a.ID(), revisions[0].Feature())
+	assert.Equal(t, FeatureRevisionActionUpdate, revisions[1].Action())
+	assert.Equal(t, &b, revisions[1].Before())
+	assert.Equal(t, &movedB, revisions[1].After())
+	assert.Equal(t, FeatureRevisionActionAdd, revisions[2].Action())
+	assert.Equal(t, c.ID(), revisions[2].Feature())
+	for _, r := range revisions {
+		assert.Equal(t, layer.ID(), r.Layer())
+		assert.Equal(t, layer.Scene(), r.Scene())
+	}
+
+	assert.Empty(t, DiffFeatureCollections(layer, after, after.Clone(), nil))
+
+	reverted := RevertFeatureCollection(after, revisions)
+	assert.ElementsMatch(t, before.Features(), reverted.Features())
+	assert.Equal(t, []Feature{movedB, c}, after.Features())
+
+	// undoing only the latest revision removes the added feature
+	assert.Equal(t, []Feature{movedB}, RevertFeatureCollection(after, revisions[2:]).Features())
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,3 +43,20 @@ func TestNewFeature(t *testing.T) {
 	assert.Equal(t, point, f.Geometry())
 	assert.Equal(t, properties, *f.Properties())
 }
+
+func TestFeature_Equal(t *testing.T) {
+	f := lo.Must(NewFeature(id.NewFeatureID(), "Feature", NewPoint("Point", []float64{1, 2})))
+	g := *f
+	assert.True(t, f.Equal(g))
+
+	g.UpdateProperties(&map[string]any{})
+	assert.True(t, f.Equal(g))
+
+	g.UpdateGeometry(NewPoint("Point", []float64{1, 3}))
+	assert.False(t, f.Equal(g))
+
+	h := *f
+	h.UpdateProperties(&map[string]any{"name": "a"})
+	assert.False(t, f.Equal(h))
+	assert.False(t, (*Feature)(nil).Equal(*f))
+}
@@ -12,11 +12,21 @@ type Point struct {
 	coordinates []float64
 }
 
+type MultiPoint struct {
+	multiPointType string
+	coordinates    [][]float64
+}
+
 type LineString struct {
 	lineStringType string
 	coordinates    [][]float64
 }
 
+type MultiLineString struct {
+	multiLineStringType string
+	coordinates         [][][]float64
+}
+
 type Polygon struct {
 	polygonType string
 	coordinates [][][]float64
@@ -47,6 +57,21 @@ func (p *Point) Coordinates() []float64 {
 	return append([]float64{}, p.coordinates...)
 }
 
+func NewMultiPoint(multiPointType string, coordinates [][]float64) *MultiPoint {
+	return &MultiPoint{
+		multiPointType: multiPointType,
+		coordinates:    coordinates,
+	}
+}
+
+func (m *MultiPoint) MultiPointType() string {
+	return m.multiPointType
+}
+
+func (m *MultiPoint) Coordinates() [][]float64 {
+	return append([][]float64{}, m.coordinates...)
+}
+
 func NewLineString(lineStringType string, coordinates [][]float64) *LineString {
 	return &LineString{
 		lineStringType: lineStringType,
@@ -62,6 +87,21 @@ func (l *LineString) Coordinates() [][]float64 {
 	return append([][]float64{}, l.coordinates...)
 }
 
+func NewMultiLineString(multiLineStringType string, coordinates [][][]float64) *MultiLineString {
+	return &MultiLineString{
+		multiLineStringType: multiLineStringType,
+		coordinates:         coordinates,
+	}
+}
+
+func (m *MultiLineString) MultiLineStringType() string {
+	return m.multiLineStringType
+}
+
+func (m *MultiLineString) Coordinates() [][][]float64 {
+	return append([][][]float64{}, m.coordinates...)
+}
+
 func NewPolygon(polygonType string, coordinates [][][]float64) *Polygon {
 	return &Polygon{
 		polygonType: polygonType,
@@ -116,111 +156,59 @@ func NewGeometryFromMap(data map[string]any) (Geometry, error) {
 		return nil, errors.New("geometry type not found")
 	}
 
-	if geometryType == "Point" || geometryType == "LineString" || geometryType == "Polygon" || geometryType == "MultiPolygon" {
+	switch geometryType {
+	case "Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon":
 		coordinates, ok := data["coordinates"].([]interface{})
 		if !ok {
 			return nil, errors.New("coordinates not found")
 		}
+		invalid := fmt.Errorf("invalid coordinate format in %s", geometryType)
 
 		switch geometryType {
 		case "Point":
-			var coords []float64
-			for _, rawCoord := range coordinates {
-				coord, ok := rawCoord.(float64)
-				if !ok {
-					return nil, errors.New("invalid coordinate format in Point")
-				}
-				coords = append(coords, coord)
+			coords, ok := positionFrom(coordinates)
+			if !ok {
+				return nil, invalid
 			}
 			return NewPoint(geometryType, coords), nil
 
-		case "LineString":
-			var coords [][]float64
-			for _, rawCoord := range coordinates {
-				coord, ok := rawCoord.([]interface{})
-				if !ok {
-					return nil, errors.New("invalid coordinate format in LineString")
-				}
-				var lineCoords []float64
-				for _, rawLineCoord := range coord {
-					lineCoord, ok := rawLineCoord.(float64)
-					if !ok {
-						return nil, errors.New("invalid coordinate format in LineString")
-					}
-					lineCoords = append(lineCoords, lineCoord)
-				}
-				coords = append(coords, lineCoords)
+		case "MultiPoint", "LineString":
+			coords, ok := positionsFrom(coordinates)
+			if !ok {
+				return nil, invalid
+			}
+			if geometryType == "MultiPoint" {
+				return NewMultiPoint(geometryType, coords), nil
 			}
 			return NewLineString(geometryType, coords), nil
 
-		case "Polygon":
-			var coords [][][]float64
-			for _, rawCoord := range coordinates {
-				coord, ok := rawCoord.([]interface{})
-				if !ok {
-					return nil, errors.New("invalid coordinate format in Polygon")
-				}
-				var polyCoords [][]float64
-				for _, rawPolyCoord := range coord {
-					polyCoord, ok := rawPolyCoord.([]interface{})
-					if !ok {
-						return nil, errors.New("invalid coordinate format in Polygon")
-					}
-					var ringCoords []float64
-					for _, rawRingCoord := range polyCoord {
-						ringCoord, ok := rawRingCoord.(float64)
-						if !ok {
-							return nil, errors.New("invalid coordinate format in Polygon")
-						}
-						ringCoords = append(ringCoords, ringCoord)
-					}
-					polyCoords = append(polyCoords, ringCoords)
-				}
-				coords = append(coords, polyCoords)
+		case "MultiLineString", "Polygon":
+			coords, ok := linesFrom(coordinates)
+			if !ok {
+				return nil, invalid
+			}
+			if geometryType == "MultiLineString" {
+				return NewMultiLineString(geometryType, coords), nil
 			}
 			return NewPolygon(geometryType, coords), nil
 
-		case "MultiPolygon":
+		default:
 			var coords [][][][]float64
-			for _, rawCoord := range coordinates {
-				coord, ok := rawCoord.([]interface{})
+			for _, rawPolygon := range coordinates {
+				polygon, ok := rawPolygon.([]interface{})
 				if !ok {
-					return nil, errors.New("invalid coordinate format in MultiPolygon")
+					return nil, invalid
 				}
-				var multiPolyCoords [][][]float64
-				for _, rawMultiPolyCoord := range coord {
-					multiPolyCoord, ok := rawMultiPolyCoord.([]interface{})
-					if !ok {
-						return nil, errors.New("invalid coordinate format in MultiPolygon")
-					}
-					var polyCoords [][]float64
-					for _, rawPolyCoord := range multiPolyCoord {
-						polyCoord, ok := rawPolyCoord.([]interface{})
-						if !ok {
-							return nil, errors.New("invalid coordinate format in MultiPolygon")
-						}
-						var ringCoords []float64
-						for _, rawRingCoord := range polyCoord {
-							ringCoord, ok := rawRingCoord.(float64)
-							if !ok {
-								return nil, errors.New("invalid coordinate format in MultiPolygon")
-							}
-							ringCoords = append(ringCoords, ringCoord)
-						}
-						polyCoords = append(polyCoords, ringCoords)
-					}
-					multiPolyCoords = append(multiPolyCoords, polyCoords)
+				polyCoords, ok := linesFrom(polygon)
+				if !ok {
+					return nil, invalid
 				}
-				coords = append(coords, multiPolyCoords)
+				coords = append(coords, polyCoords)
 			}
 			return NewMultiPolygon(geometryType, coords), nil
-
-		default:
-			return nil, errors.New("unsupported geometry type")
 		}
-	}
 
-	if geometryType == "GeometryCollection" {
+	case "GeometryCollection":
 		geometries, ok := data["geometries"].([]interface{})
 		if !ok {
 			return nil, errors.New("geometries not found")
@@ -243,3 +231,72 @@ func NewGeometryFromMap(data map[string]any) (Geometry, error) {
 
 	return nil, fmt.Errorf("unsupported geometry type: %s", geometryType)
 }
+
+// GeometryToMap returns the GeoJSON object of the geometry. It is the inverse of NewGeometryFromMap.
+func GeometryToMap(g Geometry) map[string]any {
+	switch g := g.(type) {
+	case *Point:
+		return map[string]any{"type": g.PointType(), "coordinates": g.Coordinates()}
+	case *MultiPoint:
+		return map[string]any{"type": g.MultiPointType(), "coordinates": g.Coordinates()}
+	case *LineString:
+		return map[string]any{"type": g.LineStringType(), "coordinates": g.Coordinates()}
+	case *MultiLineString:
+		return map[string]any{"type": g.MultiLineStringType(), "coordinates": g.Coordinates()}
+	case *Polygon:
+		return map[string]any{"type": g.PolygonType(), "coordinates": g.Coordinates()}
+	case *MultiPolygon:
+		return map[string]any{"type": g.MultiPolygonType(), "coordinates": g.Coordinates()}
+	case *GeometryCollection:
+		geometries := make([]map[string]any, 0, len(g.Geometries()))
+		for _, g := range g.Geometries() {
+			geometries = append(geometries, GeometryToMap(g))
+		}
+		return map[string]any{"type": g.GeometryCollectionType(), "geometries": geometries}
+	}
+	return nil
+}
+
+func positionFrom(raw []interface{}) ([]float64, bool) {
+	var res []float64
+	for _, rawCoord := range raw {
+		coord, ok := rawCoord.(float64)
+		if !ok {
+			return nil, false
+		}
+		res = append(res, coord)
+	}
+	return res, true
+}
+
+func positionsFrom(raw []interface{}) ([][]float64, bool) {
+	var res [][]float64
+	for _, rawPosition := range raw {
+		position, ok := rawPosition.([]interface{})
+		if !ok {
+			return nil, false
+		}
+		coords, ok := positionFrom(position)
+		if !ok {
+			return nil, false
+		}
+		res = append(res, coords)
+	}
+	return res, true
+}
+
+func linesFrom(raw []interface{}) ([][][]float64, bool) {
+	var res [][][]float64
+	for _, rawLine := range raw {
+		line, ok := rawLine.([]interface{})
+		if !ok {
+			return nil, false
+		}
+		coords, ok := positionsFrom(line)
+		if !ok {
+			return nil, false
+		}
+		res = append(res, coords)
+	}
+	return res, true
+}
@@ -20,6 +20,20 @@ func TestNewLineString(t *testing.T) {
 	assert.Equal(t, [][]float64{{1, 2}, {3, 4}}, l.Coordinates())
 }
 
+func TestNewMultiPoint(t *testing.T) {
+	m := NewMultiPoint("MultiPoint", [][]float64{{1, 2}, {3, 4}})
+
+	assert.Equal(t, "MultiPoint", m.MultiPointType())
+	assert.Equal(t, [][]float64{{1, 2}, {3, 4}}, m.Coordinates())
+}
+
+func TestNewMultiLineString(t *testing.T) {
+	m := NewMultiLineString("MultiLineString", [][][]float64{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}})
+
+	assert.Equal(t, "MultiLineString", m.MultiLineStringType())
+	assert.Equal(t, [][][]float64{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}}, m.Coordinates())
+}
+
 func TestNewPolygon(t *testing.T) {
 	p := NewPolygon("Polygon", [][][]float64{{{1, 2}, {3, 4}, {5, 6}, {1, 2}}})
 
@@ -82,6 +96,48 @@ func TestNewGeometryFromMap(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "MultiPoint",
+			input: map[string]any{
+				"type": "MultiPoint",
+				"coordinates": []interface{}{
+					[]interface{}{1.0, 2.0},
+					[]interface{}{3.0, 4.0, 5.0},
+				},
+			},
+			want: &MultiPoint{
+				multiPointType: "MultiPoint",
+				coordinates: [][]float64{
+					{1.0, 2.0},
+					{3.0, 4.0, 5.0},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "MultiLineString",
+			input: map[string]any{
+				"type": "MultiLineString",
+				"coordinates": []interface{}{
+					[]interface{}{
+						[]interface{}{1.0, 2.0},
+						[]interface{}{3.0, 4.0},
+					},
+					[]interface{}{
+						[]interface{}{5.0, 6.0},
+						[]interface{}{7.0, 8.0},
+					},
+				},
+			},
+			want: &MultiLineString{
+				multiLineStringType: "MultiLineString",
+				coordinates: [][][]float64{
+					{{1.0, 2.0}, {3.0, 4.0}},
+					{{5.0, 6.0}, {7.0, 8.0}},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Polygon",
 			input: map[string]any{
@@ -210,6 +266,62 @@ func TestNewGeometryFromMap(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Nested GeometryCollection",
+			input: map[string]any{
+				"type": "GeometryCollection",
+				"geometries": []interface{}{
+					map[string]any{
+						"type": "MultiPoint",
+						"coordinates": []interface{}{
+							[]interface{}{1.0, 2.0},
+						},
+					},
+					map[string]any{
+						"type": "GeometryCollection",
+						"geometries": []interface{}{
+							map[string]any{
+								"type": "MultiLineString",
+								"coordinates": []interface{}{
+									[]interface{}{
+										[]interface{}{1.0, 2.0},
+										[]interface{}{3.0, 4.0},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &GeometryCollection{
+				geometryCollectionType: "GeometryCollection",
+				geometries: []Geometry{
+					&MultiPoint{
+						multiPointType: "MultiPoint",
+						coordinates:    [][]float64{{1.0, 2.0}},
+					},
+					&GeometryCollection{
+						geometryCollectionType: "GeometryCollection",
+						geometries: []Geometry{
+							&MultiLineString{
+								multiLineStringType: "MultiLineString",
+								coordinates:         [][][]float64{{{1.0, 2.0}, {3.0, 4.0}}},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid MultiPoint coordinates",
+			input: map[string]any{
+				"type":        "MultiPoint",
+				"coordinates": []interface{}{1.0, 2.0},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Invalid geometry type",
 			input: map[string]any{
@@ -233,3 +345,18 @@ func TestNewGeometryFromMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGeometryToMap(t *testing.T) {
+	g := NewGeometryCollection("GeometryCollection", []Geometry{
+		NewPoint("Point", []float64{1, 2}),
+		NewPolygon("Polygon", [][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+	})
+	assert.Equal(t, map[string]any{
+		"type": "GeometryCollection",
+		"geometries": []map[string]any{
+			{"type": "Point", "coordinates": []float64{1, 2}},
+			{"type": "Polygon", "coordinates": [][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}},
+		},
+	}, GeometryToMap(g))
+	assert.Nil(t, GeometryToMap(nil))
+}
@@ -0,0 +1,314 @@
+package nlslayer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/reearth/reearth/server/pkg/i18n/message/errmsg"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/verror"
+)
+
+var (
+	ErrInvalidPosition = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerInvalidPosition,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerInvalidPosition],
+		nil,
+		nil,
+	)
+
+	ErrCoordinateOutOfRange = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerCoordinateOutOfRange,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerCoordinateOutOfRange],
+		nil,
+		nil,
+	)
+
+	ErrLineTooShort = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerLineTooShort,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerLineTooShort],
+		nil,
+		nil,
+	)
+
+	ErrRingNotClosed = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerRingNotClosed,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerRingNotClosed],
+		nil,
+		nil,
+	)
+
+	ErrRingTooShort = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerRingTooShort,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerRingTooShort],
+		nil,
+		nil,
+	)
+
+	ErrRingSelfIntersection = verror.NewVError(
+		errmsg.ErrKeyPkgNlslayerRingSelfIntersection,
+		errmsg.ErrorMessages[errmsg.ErrKeyPkgNlslayerRingSelfIntersection],
+		nil,
+		nil,
+	)
+)
+
+const positionEpsilon = 1e-9
+
+// ValidateGeometry checks that the geometry of the feature can be rendered and returns a VError
+// naming the feature and the offending position, line or ring (e.g. "coordinates[0]" or "geometries[1].coordinates[2][0]").
+//
+// If repair is true, unclosed rings are closed, consecutive duplicate positions in rings are removed,
+// rings are rewound to follow the right-hand rule of RFC 7946, longitudes are wrapped and latitudes are clamped instead of being rejected.
+// Rings that are too short or intersect themselves cannot be repaired.
+// Winding order is not validated since RFC 7946 asks parsers not to reject polygons that do not follow the right-hand rule.
+//
+// The returned geometry is a validated copy and the given geometry is not modified.
+func ValidateGeometry(fid id.FeatureID, g Geometry, repair bool) (Geometry, error) {
+	v := &geometryValidator{
+		feature: fid,
+		repair:  repair,
+	}
+	return v.geometry("", g)
+}
+
+type geometryValidator struct {
+	feature id.FeatureID
+	repair  bool
+}
+
+func (v *geometryValidator) err(base *verror.VError, key, path string) error {
+	return base.AddTemplateData("featureId", v.feature.String()).AddTemplateData(key, path)
+}
+
+func (v *geometryValidator) geometry(prefix string, g Geometry) (Geometry, error) {
+	path := prefix + "coordinates"
+
+	switch g := g.(type) {
+	case *Point:
+		c, err := v.position(path, g.Coordinates())
+		if err != nil {
+			return nil, err
+		}
+		return NewPoint(g.PointType(), c), nil
+
+	case *MultiPoint:
+		coords := make([][]float64, 0, len(g.Coordinates()))
+		for i, c := range g.Coordinates() {
+			p, err := v.position(fmt.Sprintf("%s[%d]", path, i), c)
+			if err != nil {
+				return nil, err
+			}
+			coords = append(coords, p)
+		}
+		return NewMultiPoint(g.MultiPointType(), coords), nil
+
+	case *LineString:
+		coords, err := v.line(path, g.Coordinates())
+		if err != nil {
+			return nil, err
+		}
+		return NewLineString(g.LineStringType(), coords), nil
+
+	case *MultiLineString:
+		coords := make([][][]float64, 0, len(g.Coordinates()))
+		for i, l := range g.Coordinates() {
+			line, err := v.line(fmt.Sprintf("%s[%d]", path, i), l)
+			if err != nil {
+				return nil, err
+			}
+			coords = append(coords, line)
+		}
+		return NewMultiLineString(g.MultiLineStringType(), coords), nil
+
+	case *Polygon:
+		coords, err := v.polygon(path, g.Coordinates())
+		if err != nil {
+			return nil, err
+		}
+		return NewPolygon(g.PolygonType(), coords), nil
+
+	case *MultiPolygon:
+		coords := make([][][][]float64, 0, len(g.Coordinates()))
+		for i, p := range g.Coordinates() {
+			polygon, err := v.polygon(fmt.Sprintf("%s[%d]", path, i), p)
+			if err != nil {
+				return nil, err
+			}
+			coords = append(coords, polygon)
+		}
+		return NewMultiPolygon(g.MultiPolygonType(), coords), nil
+
+	case *GeometryCollection:
+		geometries := make([]Geometry, 0, len(g.Geometries()))
+		for i, gg := range g.Geometries() {
+			geometry, err := v.geometry(fmt.Sprintf("%sgeometries[%d].", prefix, i), gg)
+			if err != nil {
+				return nil, err
+			}
+			geometries = append(geometries, geometry)
+		}
+		return NewGeometryCollection(g.GeometryCollectionType(), geometries), nil
+	}
+
+	return nil, fmt.Errorf("unsupported geometry type: %T", g)
+}
+
+func (v *geometryValidator) position(path string, c []float64) ([]float64, error) {
+	if len(c) != 2 && len(c) != 3 {
+		return nil, v.err(ErrInvalidPosition, "position", path)
+	}
+	for _, n := range c {
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, v.err(ErrInvalidPosition, "position", path)
+		}
+	}
+
+	res := append([]float64{}, c...)
+	if res[0] >= -180 && res[0] <= 180 && res[1] >= -90 && res[1] <= 90 {
+		return res, nil
+	}
+	if !v.repair {
+		return nil, v.err(ErrCoordinateOutOfRange, "position", path)
+	}
+	if res[0] < -180 || res[0] > 180 {
+		res[0] = math.Mod(res[0]+180, 360)
+		if res[0] < 0 {
+			res[0] += 360
+		}
+		res[0] -= 180
+	}
+	res[1] = math.Max(-90, math.Min(90, res[1]))
+	return res, nil
+}
+
+func (v *geometryValidator) line(path string, coords [][]float64) ([][]float64, error) {
+	res := make([][]float64, 0, len(coords))
+	for i, c := range coords {
+		p, err := v.position(fmt.Sprintf("%s[%d]", path, i), c)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+	if len(res) < 2 {
+		return nil, v.err(ErrLineTooShort, "line", path)
+	}
+	return res, nil
+}
+
+func (v *geometryValidator) polygon(path string, rings [][][]float64) ([][][]float64, error) {
+	res := make([][][]float64, 0, len(rings))
+	for i, r := range rings {
+		ringPath := fmt.Sprintf("%s[%d]", path, i)
+		ring := make([][]float64, 0, len(r)+1)
+		for j, c := range r {
+			p, err := v.position(fmt.Sprintf("%s[%d]", ringPath, j), c)
+			if err != nil {
+				return nil, err
+			}
+			ring = append(ring, p)
+		}
+
+		if len(ring) > 0 && !positionEqual(ring[0], ring[len(ring)-1]) {
+			if !v.repair {
+				return nil, v.err(ErrRingNotClosed, "ring", ringPath)
+			}
+			ring = append(ring, append([]float64{}, ring[0]...))
+		}
+
+		// duplicate positions would be reported as self-intersections
+		deduped := dedupePositions(ring)
+		if len(deduped) < 4 {
+			return nil, v.err(ErrRingTooShort, "ring", ringPath)
+		}
+		if ringSelfIntersects(deduped) {
+			return nil, v.err(ErrRingSelfIntersection, "ring", ringPath)
+		}
+
+		if v.repair {
+			ring = deduped
+			// exterior rings are counterclockwise and holes are clockwise
+			if ccw := signedRingArea(ring) > 0; ccw != (i == 0) {
+				for a, b := 0, len(ring)-1; a < b; a, b = a+1, b-1 {
+					ring[a], ring[b] = ring[b], ring[a]
+				}
+			}
+		}
+		res = append(res, ring)
+	}
+	return res, nil
+}
+
+func positionEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > positionEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+func dedupePositions(ring [][]float64) [][]float64 {
+	res := make([][]float64, 0, len(ring))
+	for _, p := range ring {
+		if len(res) > 0 && positionEqual(res[len(res)-1], p) {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+// signedRingArea returns the signed area of a ring, which is positive if the ring is counterclockwise.
+func signedRingArea(ring [][]float64) float64 {
+	var a float64
+	for i := 0; i+1 < len(ring); i++ {
+		a += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return a / 2
+}
+
+// ringSelfIntersects reports whether any two non-adjacent edges of a closed ring cross or touch.
+func ringSelfIntersects(ring [][]float64) bool {
+	n := len(ring) - 1 // number of edges
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			// adjacent edges share an endpoint, including the first and the last edge
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func segmentsIntersect(p1, p2, q1, q2 []float64) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(q1, q2, p1)) ||
+		(d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) ||
+		(d4 == 0 && onSegment(p1, p2, q2))
+}
+
+func orientation(a, b, c []float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+func onSegment(a, b, c []float64) bool {
+	return math.Min(a[0], b[0]) <= c[0] && c[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= c[1] && c[1] <= math.Max(a[1], b[1])
+}
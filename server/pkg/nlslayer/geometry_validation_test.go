@@ -0,0 +1,148 @@
+package nlslayer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/verror"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestValidateGeometry(t *testing.T) {
+	fid := id.NewFeatureID()
+
+	tests := []struct {
+		name     string
+		geometry Geometry
+		repair   bool
+		want     Geometry
+		wantErr  *verror.VError
+		wantData map[string]any
+	}{
+		{
+			name:     "valid point",
+			geometry: NewPoint("Point", []float64{139.7, 35.6, 10}),
+			want:     NewPoint("Point", []float64{139.7, 35.6, 10}),
+		},
+		{
+			name:     "invalid position",
+			geometry: NewPoint("Point", []float64{139.7}),
+			wantErr:  ErrInvalidPosition,
+			wantData: map[string]any{"position": "coordinates"},
+		},
+		{
+			name:     "out of range",
+			geometry: NewMultiPoint("MultiPoint", [][]float64{{0, 0}, {181, 0}}),
+			wantErr:  ErrCoordinateOutOfRange,
+			wantData: map[string]any{"position": "coordinates[1]"},
+		},
+		{
+			name:     "out of range repaired",
+			geometry: NewMultiPoint("MultiPoint", [][]float64{{190, 95}, {-540, -100, 5}}),
+			repair:   true,
+			want:     NewMultiPoint("MultiPoint", [][]float64{{-170, 90}, {-180, -90, 5}}),
+		},
+		{
+			name:     "short line",
+			geometry: NewMultiLineString("MultiLineString", [][][]float64{{{0, 0}, {1, 1}}, {{0, 0}}}),
+			repair:   true,
+			wantErr:  ErrLineTooShort,
+			wantData: map[string]any{"line": "coordinates[1]"},
+		},
+		{
+			name:     "unclosed ring",
+			geometry: NewPolygon("Polygon", [][][]float64{{{0, 0}, {1, 0}, {1, 1}}}),
+			wantErr:  ErrRingNotClosed,
+			wantData: map[string]any{"ring": "coordinates[0]"},
+		},
+		{
+			name: "unclosed ring repaired",
+			geometry: NewPolygon("Polygon", [][][]float64{
+				{{0, 0}, {1, 0}, {1, 1}},
+			}),
+			repair: true,
+			want: NewPolygon("Polygon", [][][]float64{
+				{{0, 0}, {1, 0}, {1, 1}, {0, 0}},
+			}),
+		},
+		{
+			name:     "short ring",
+			geometry: NewPolygon("Polygon", [][][]float64{{{0, 0}, {1, 0}, {0, 0}}}),
+			repair:   true,
+			wantErr:  ErrRingTooShort,
+			wantData: map[string]any{"ring": "coordinates[0]"},
+		},
+		{
+			name: "self-intersecting ring",
+			geometry: NewMultiPolygon("MultiPolygon", [][][][]float64{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				{{{0, 0}, {2, 2}, {2, 0}, {0, 2}, {0, 0}}},
+			}),
+			repair:   true,
+			wantErr:  ErrRingSelfIntersection,
+			wantData: map[string]any{"ring": "coordinates[1][0]"},
+		},
+		{
+			name: "winding order is not validated",
+			geometry: NewPolygon("Polygon", [][][]float64{
+				{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+			}),
+			want: NewPolygon("Polygon", [][][]float64{
+				{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+			}),
+		},
+		{
+			name: "winding order repaired",
+			geometry: NewPolygon("Polygon", [][][]float64{
+				{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+				{{1, 1}, {2, 1}, {2, 1}, {2, 2}, {1, 1}},
+			}),
+			repair: true,
+			want: NewPolygon("Polygon", [][][]float64{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{1, 1}, {2, 2}, {2, 1}, {1, 1}},
+			}),
+		},
+		{
+			name: "geometry collection",
+			geometry: NewGeometryCollection("GeometryCollection", []Geometry{
+				NewPoint("Point", []float64{0, 0}),
+				NewLineString("LineString", [][]float64{{0, 0}, {0, 91}}),
+			}),
+			wantErr:  ErrCoordinateOutOfRange,
+			wantData: map[string]any{"position": "geometries[1].coordinates[1]"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ValidateGeometry(fid, tt.geometry, tt.repair)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				return
+			}
+
+			assert.Nil(t, got)
+			var verr *verror.VError
+			assert.True(t, errors.As(err, &verr))
+			assert.Equal(t, tt.wantErr.Key, verr.Key)
+			assert.Equal(t, fid.String(), verr.TemplateData[language.English]["featureId"])
+			for k, v := range tt.wantData {
+				assert.Equal(t, v, verr.TemplateData[language.English][k])
+			}
+		})
+	}
+}
+
+func TestValidateGeometry_DoesNotModifyInput(t *testing.T) {
+	p := NewPolygon("Polygon", [][][]float64{{{0, 0}, {0, 1}, {1, 1}}})
+
+	_, err := ValidateGeometry(id.NewFeatureID(), p, true)
+	assert.NoError(t, err)
+	assert.Equal(t, [][][]float64{{{0, 0}, {0, 1}, {1, 1}}}, p.Coordinates())
+}
@@ -60,3 +60,10 @@ func (i *InfoboxBlock) Plugin() id.PluginID {
 func (i *InfoboxBlock) Extension() id.PluginExtensionID {
 	return i.extension
 }
+
+func (i *InfoboxBlock) UpgradePlugin(id id.PluginID) {
+	if i == nil || !i.plugin.NameEqual(id) {
+		return
+	}
+	i.plugin = id
+}
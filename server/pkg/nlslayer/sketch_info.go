@@ -16,6 +16,14 @@ func (s *SketchInfo) CustomPropertySchema() *map[string]any {
 	return s.customPropertySchema
 }
 
+// TypedCustomPropertySchema parses the custom property schema. It returns nil when there is no schema.
+func (s *SketchInfo) TypedCustomPropertySchema() (*CustomPropertySchema, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return NewCustomPropertySchema(s.customPropertySchema)
+}
+
 func (s *SketchInfo) FeatureCollection() *FeatureCollection {
 	return s.featureCollection
 }
@@ -24,6 +32,10 @@ func (s *SketchInfo) SetCustomPropertySchema(schema *map[string]any) {
 	s.customPropertySchema = schema
 }
 
+func (s *SketchInfo) SetFeatureCollection(fc *FeatureCollection) {
+	s.featureCollection = fc
+}
+
 func (s *SketchInfo) Clone() *SketchInfo {
 	if s == nil {
 		return nil
@@ -36,7 +36,7 @@ func DiffFrom(old, new Manifest) (d Diff) {
 		d.PropertySchemaDiff.From = *oldsid
 		d.PropertySchemaDeleted = true
 	} else if oldsid != nil && newsid != nil {
-		d.PropertySchemaDiff = property.SchemaDiffFrom(old.PropertySchema(*oldsid), old.PropertySchema(*newsid))
+		d.PropertySchemaDiff = property.SchemaDiffFrom(old.PropertySchema(*oldsid), new.PropertySchema(*newsid))
 	}
 
 	for _, e := range old.Plugin.Extensions() {
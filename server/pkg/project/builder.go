@@ -109,11 +109,26 @@ func (b *Builder) Starred(starred bool) *Builder {
 	return b
 }
 
+func (b *Builder) Template(isTemplate bool) *Builder {
+	b.p.isTemplate = isTemplate
+	return b
+}
+
 func (b *Builder) Deleted(deleted bool) *Builder {
 	b.p.isDeleted = deleted
 	return b
 }
 
+func (b *Builder) DeletedAt(deletedAt *time.Time) *Builder {
+	if deletedAt != nil {
+		t := *deletedAt
+		b.p.deletedAt = &t
+	} else {
+		b.p.deletedAt = nil
+	}
+	return b
+}
+
 func (b *Builder) Visibility(visibility Visibility) *Builder {
 	b.p.visibility = string(visibility)
 	return b
@@ -146,6 +161,11 @@ func (b *Builder) PublishedAt(publishedAt time.Time) *Builder {
 	return b
 }
 
+func (b *Builder) PublishedVersion(version int) *Builder {
+	b.p.publishedVersion = version
+	return b
+}
+
 func (b *Builder) PublicTitle(publicTitle string) *Builder {
 	b.p.publicTitle = publicTitle
 	return b
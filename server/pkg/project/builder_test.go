@@ -310,6 +310,7 @@ func TestBuilder_MustBuild(t *testing.T) {
 		trackingId        string
 		enableGa          bool
 		starred           bool
+		isTemplate        bool
 	}
 
 	tests := []struct {
@@ -340,6 +341,7 @@ func TestBuilder_MustBuild(t *testing.T) {
 				trackingId:        "dfdfdfd",
 				enableGa:          true,
 				starred:           true,
+				isTemplate:        true,
 			},
 			expected: &Project{
 				id:          pid,
@@ -361,6 +363,7 @@ func TestBuilder_MustBuild(t *testing.T) {
 				trackingId:        "dfdfdfd",
 				enableGa:          true,
 				starred:           true,
+				isTemplate:        true,
 			},
 		},
 		{
@@ -407,6 +410,7 @@ func TestBuilder_MustBuild(t *testing.T) {
 					EnableGA(tt.args.enableGa).
 					TrackingID(tt.args.trackingId).
 					Starred(tt.args.starred).
+					Template(tt.args.isTemplate).
 					MustBuild()
 			}
 
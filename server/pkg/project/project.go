@@ -22,7 +22,9 @@ type Project struct {
 	isArchived   bool
 	coreSupport  bool
 	starred      bool
+	isTemplate   bool
 	isDeleted    bool
+	deletedAt    *time.Time
 	visibility   string
 	metadata     *ProjectMetadata
 	projectAlias string
@@ -31,6 +33,7 @@ type Project struct {
 	alias             string
 	publishmentStatus PublishmentStatus
 	publishedAt       time.Time
+	publishedVersion  int
 	publicTitle       string
 	publicDescription string
 	publicImage       string
@@ -98,10 +101,23 @@ func (p *Project) Starred() bool {
 	return p.starred
 }
 
+func (p *Project) IsTemplate() bool {
+	return p.isTemplate
+}
+
 func (p *Project) IsDeleted() bool {
 	return p.isDeleted
 }
 
+// DeletedAt returns when the project was moved to the trash, or nil when it is not in the trash.
+func (p *Project) DeletedAt() *time.Time {
+	if p.deletedAt == nil {
+		return nil
+	}
+	t := *p.deletedAt
+	return &t
+}
+
 func (p *Project) Visibility() string {
 	return p.visibility
 }
@@ -158,7 +174,18 @@ func (p *Project) SetStarred(starred bool) {
 	p.starred = starred
 }
 
+func (p *Project) SetTemplate(isTemplate bool) {
+	p.isTemplate = isTemplate
+}
+
+// SetDeleted moves the project to the trash or restores it, and records when it was moved to the trash.
 func (p *Project) SetDeleted(isDeleted bool) {
+	if isDeleted && (!p.isDeleted || p.deletedAt == nil) {
+		now := time.Now()
+		p.deletedAt = &now
+	} else if !isDeleted {
+		p.deletedAt = nil
+	}
 	p.isDeleted = isDeleted
 }
 
@@ -209,6 +236,11 @@ func (p *Project) PublishedAt() time.Time {
 	return p.publishedAt
 }
 
+// PublishedVersion is the number of the publication version the alias serves. Zero means it has no versions.
+func (p *Project) PublishedVersion() int {
+	return p.publishedVersion
+}
+
 func (p *Project) PublicTitle() string {
 	return p.publicTitle
 }
@@ -261,6 +293,10 @@ func (p *Project) SetPublishedAt(publishedAt time.Time) {
 	p.publishedAt = publishedAt
 }
 
+func (p *Project) SetPublishedVersion(version int) {
+	p.publishedVersion = version
+}
+
 func (p *Project) UpdatePublicTitle(publicTitle string) {
 	p.publicTitle = publicTitle
 }
@@ -100,6 +100,22 @@ func TestProject_SetArchived(t *testing.T) {
 	assert.Equal(t, true, p.IsArchived())
 }
 
+func TestProject_SetDeleted(t *testing.T) {
+	p := &Project{}
+	p.SetDeleted(true)
+	assert.True(t, p.IsDeleted())
+	deletedAt := p.DeletedAt()
+	assert.NotNil(t, deletedAt)
+
+	// deleting again keeps the time the project was moved to the trash
+	p.SetDeleted(true)
+	assert.Equal(t, deletedAt, p.DeletedAt())
+
+	p.SetDeleted(false)
+	assert.False(t, p.IsDeleted())
+	assert.Nil(t, p.DeletedAt())
+}
+
 func TestProject_SetPublishedAt(t *testing.T) {
 	p := &Project{}
 	p.SetPublishedAt(time.Date(1900, 1, 1, 00, 00, 1, 1, time.UTC))
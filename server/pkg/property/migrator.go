@@ -1,5 +1,9 @@
 package property
 
+import "github.com/reearth/reearth/server/pkg/id"
+
+// Migrator rebuilds properties for a new schema. Each field is moved to the group that has the field in the new schema
+// unless a plan maps it explicitly, and its value is cast to the type of the new field.
 type Migrator struct {
 	NewSchema *Schema
 	Plans     []MigrationPlan
@@ -10,6 +14,204 @@ type MigrationPlan struct {
 	To   *Pointer
 }
 
-// func (m Migrator) Migrate(from *Property) *Property {
+type DropReason string
+
+const (
+	// DropReasonDeleted means the new schema does not have the field.
+	DropReasonDeleted DropReason = "deleted"
+	// DropReasonCastFailed means the value could not be cast to the type of the new field.
+	DropReasonCastFailed DropReason = "cast_failed"
+	// DropReasonConflict means another value was already moved into the same field,
+	// e.g. the second and later items of a list whose fields moved into a group.
+	DropReasonConflict DropReason = "conflict"
+)
+
+// DroppedField is a value that could not be carried over by a migration. Pointer points to the field in the old property.
+type DroppedField struct {
+	Pointer *Pointer
+	Value   *OptionalValue
+	Reason  DropReason
+}
+
+// NewMigrator returns a Migrator that applies the moves of the diff to properties of its old schema.
+func NewMigrator(d SchemaDiff, newSchema *Schema) Migrator {
+	plans := make([]MigrationPlan, 0, len(d.Moved))
+	for _, m := range d.Moved {
+		plans = append(plans, MigrationPlan{
+			From: m.From.Pointer(),
+			To:   m.To.Pointer(),
+		})
+	}
+	return Migrator{
+		NewSchema: newSchema,
+		Plans:     plans,
+	}
+}
+
+// Migrate returns a copy of the property that follows the new schema, and the values that had to be dropped.
+// Fields moved from a group into a group list make up a new list item. Fields moved between group lists keep one item
+// per source item, and fields moved from a group list into a group are taken from the first item only.
+func (m Migrator) Migrate(from *Property) (*Property, []DroppedField) {
+	if from == nil || m.NewSchema == nil {
+		return nil, nil
+	}
+
+	mg := migration{
+		schema:     m.NewSchema,
+		groups:     map[id.PropertySchemaGroupID]*Group{},
+		listItems:  map[id.PropertySchemaGroupID][]*Group{},
+		itemsByKey: map[migrationItemKey]*Group{},
+		lists:      map[id.PropertySchemaGroupID]id.PropertyItemID{},
+	}
+
+	for _, item := range from.items {
+		if g := ToGroup(item); g != nil {
+			mg.migrateGroup(m, g, nil)
+		} else if gl := ToGroupList(item); gl != nil {
+			mg.lists[gl.SchemaGroup()] = gl.ID()
+			for _, g := range gl.Groups() {
+				mg.migrateGroup(m, g, gl)
+			}
+		}
+	}
+
+	p := &Property{
+		id:     from.id,
+		scene:  from.scene,
+		schema: m.NewSchema.ID(),
+		items:  mg.items(),
+	}
+	p.Prune()
+	return p, mg.dropped
+}
+
+// target returns the group and the field in the new schema where the field should be moved.
+func (m Migrator) target(sg id.PropertySchemaGroupID, fid id.PropertyFieldID) (*SchemaGroup, *SchemaField) {
+	for _, p := range m.Plans {
+		if p.From.FieldRef() == nil || !p.From.TestSchemaGroup(sg) || !p.From.TestField(fid) {
+			continue
+		}
+		tsg, _, tfid := p.To.GetAll()
+		if tfid == nil {
+			tfid = fid.Ref()
+		}
+		return m.targetGroup(tsg, *tfid)
+	}
+	return m.targetGroup(nil, fid)
+}
+
+func (m Migrator) targetGroup(sg *id.PropertySchemaGroupID, fid id.PropertyFieldID) (*SchemaGroup, *SchemaField) {
+	var g *SchemaGroup
+	if sg != nil {
+		g = m.NewSchema.Groups().Group(*sg)
+	} else {
+		g = m.NewSchema.Groups().GroupByField(fid)
+	}
+	f := g.Field(fid)
+	if f == nil {
+		return nil, nil
+	}
+	return g, f
+}
+
+type migrationItemKey struct {
+	from id.PropertyItemID
+	to   id.PropertySchemaGroupID
+}
+
+type migration struct {
+	schema     *Schema
+	groups     map[id.PropertySchemaGroupID]*Group
+	listItems  map[id.PropertySchemaGroupID][]*Group
+	itemsByKey map[migrationItemKey]*Group
+	lists      map[id.PropertySchemaGroupID]id.PropertyItemID
+	dropped    []DroppedField
+}
+
+func (mg *migration) migrateGroup(m Migrator, g *Group, parent *GroupList) {
+	for _, f := range g.fields {
+		if f == nil || f.IsEmpty() {
+			continue
+		}
+
+		sg, sf := m.target(g.SchemaGroup(), f.Field())
+		if sf == nil {
+			mg.drop(g, parent, f, DropReasonDeleted)
+			continue
+		}
+
+		v := f.TypeAndValue().Cast(sf.Type())
+		if v.Value() == nil {
+			mg.drop(g, parent, f, DropReasonCastFailed)
+			continue
+		}
+
+		tg := mg.group(sg, g)
+		if tg.Field(sf.ID()) != nil {
+			mg.drop(g, parent, f, DropReasonConflict)
+			continue
+		}
+		tg.AddFields(&Field{field: sf.ID(), v: v})
+	}
+}
 
-// }
+// group returns the group in the new property which the fields of the source group should be moved into.
+func (mg *migration) group(sg *SchemaGroup, from *Group) *Group {
+	if !sg.IsList() {
+		if g := mg.groups[sg.ID()]; g != nil {
+			return g
+		}
+		g := &Group{itemBase: itemBase{ID: mg.itemID(sg.ID(), from), SchemaGroup: sg.ID()}}
+		mg.groups[sg.ID()] = g
+		return g
+	}
+
+	key := migrationItemKey{from: from.ID(), to: sg.ID()}
+	if g := mg.itemsByKey[key]; g != nil {
+		return g
+	}
+	g := &Group{itemBase: itemBase{ID: mg.itemID(sg.ID(), from), SchemaGroup: sg.ID()}}
+	mg.itemsByKey[key] = g
+	mg.listItems[sg.ID()] = append(mg.listItems[sg.ID()], g)
+	return g
+}
+
+// itemID keeps the ID of the source item when it stays in the same schema group.
+func (mg *migration) itemID(sg id.PropertySchemaGroupID, from *Group) id.PropertyItemID {
+	if from.SchemaGroup() == sg {
+		return from.ID()
+	}
+	return id.NewPropertyItemID()
+}
+
+func (mg *migration) drop(g *Group, parent *GroupList, f *Field, reason DropReason) {
+	var iid *id.PropertyItemID
+	if parent != nil {
+		iid = g.IDRef()
+	}
+	mg.dropped = append(mg.dropped, DroppedField{
+		Pointer: PointField(g.SchemaGroupRef(), iid, f.Field()),
+		Value:   f.TypeAndValue().Clone(),
+		Reason:  reason,
+	})
+}
+
+// items returns the new items in the order of the groups of the new schema.
+func (mg *migration) items() []Item {
+	var res []Item
+	for _, sg := range mg.schema.Groups().Groups() {
+		if g := mg.groups[sg.ID()]; g != nil && !sg.IsList() {
+			res = append(res, g)
+		} else if items := mg.listItems[sg.ID()]; len(items) > 0 {
+			lid, ok := mg.lists[sg.ID()]
+			if !ok {
+				lid = id.NewPropertyItemID()
+			}
+			res = append(res, &GroupList{
+				itemBase: itemBase{ID: lid, SchemaGroup: sg.ID()},
+				groups:   items,
+			})
+		}
+	}
+	return res
+}
@@ -0,0 +1,118 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMigrator(t *testing.T) {
+	s := &Schema{id: id.MustPropertySchemaID("x~1.0.0/a")}
+	m := NewMigrator(SchemaDiff{
+		Moved: []SchemaDiffMoved{
+			{From: SchemaFieldPointer{SchemaGroup: "a", Field: "b"}, To: SchemaFieldPointer{SchemaGroup: "c", Field: "b"}, ToList: true},
+		},
+	}, s)
+	assert.Equal(t, Migrator{
+		NewSchema: s,
+		Plans: []MigrationPlan{
+			{From: PointFieldBySchemaGroup("a", "b"), To: PointFieldBySchemaGroup("c", "b")},
+		},
+	}, m)
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	ps1 := id.MustPropertySchemaID("x~1.0.0/a")
+	ps2 := id.MustPropertySchemaID("x~1.0.1/a")
+	pid := id.NewPropertyID()
+	sid := id.NewSceneID()
+	gid := id.NewPropertyItemID()
+	lid := id.NewPropertyItemID()
+	liid1 := id.NewPropertyItemID()
+	liid2 := id.NewPropertyItemID()
+
+	newSchema := &Schema{
+		id: ps2,
+		groups: &SchemaGroupList{groups: []*SchemaGroup{
+			{id: "a", fields: []*SchemaField{
+				{id: "a1", propertyType: ValueTypeNumber},
+				{id: "b1", propertyType: ValueTypeString},
+			}},
+			{id: "b", list: true, fields: []*SchemaField{
+				{id: "a2", propertyType: ValueTypeString},
+				{id: "b2", propertyType: ValueTypeBool},
+			}},
+		}},
+	}
+
+	old := &Property{
+		id:     pid,
+		scene:  sid,
+		schema: ps1,
+		items: []Item{
+			&Group{itemBase: itemBase{ID: gid, SchemaGroup: "a"}, fields: []*Field{
+				{field: "a1", v: OptionalValueFrom(ValueTypeString.ValueFrom("10"))},      // cast to number
+				{field: "a2", v: OptionalValueFrom(ValueTypeString.ValueFrom("x"))},       // moved into a new list item
+				{field: "a3", v: OptionalValueFrom(ValueTypeString.ValueFrom("deleted"))}, // deleted
+				{field: "a4", v: OptionalValueFrom(ValueTypeString.ValueFrom("renamed"))}, // renamed by the plan
+				{field: "a5", v: NewOptionalValue(ValueTypeString, nil)},                  // empty
+			}},
+			&GroupList{itemBase: itemBase{ID: lid, SchemaGroup: "b"}, groups: []*Group{
+				{itemBase: itemBase{ID: liid1, SchemaGroup: "b"}, fields: []*Field{
+					{field: "b1", v: OptionalValueFrom(ValueTypeString.ValueFrom("first"))}, // moved into the group
+					{field: "b2", v: OptionalValueFrom(ValueTypeString.ValueFrom("true"))},  // cast to bool
+				}},
+				{itemBase: itemBase{ID: liid2, SchemaGroup: "b"}, fields: []*Field{
+					{field: "b1", v: OptionalValueFrom(ValueTypeString.ValueFrom("second"))}, // conflicts with the first item
+					{field: "b2", v: OptionalValueFrom(ValueTypeString.ValueFrom("nope"))},   // cast fails
+				}},
+			}},
+		},
+	}
+
+	m := Migrator{
+		NewSchema: newSchema,
+		Plans: []MigrationPlan{
+			{From: PointFieldBySchemaGroup("a", "a4"), To: PointFieldBySchemaGroup("b", "a2")},
+		},
+	}
+	got, dropped := m.Migrate(old)
+
+	assert.Equal(t, pid, got.ID())
+	assert.Equal(t, sid, got.Scene())
+	assert.Equal(t, ps2, got.Schema())
+	assert.Equal(t, ps1, old.Schema(), "the source property should not be changed")
+
+	g := got.GroupBySchema("a")
+	assert.Equal(t, gid, g.ID())
+	assert.Equal(t, ValueTypeNumber.ValueFrom(10.0), g.Field("a1").Value())
+	assert.Equal(t, ValueTypeString.ValueFrom("first"), g.Field("b1").Value())
+	assert.Equal(t, []id.PropertyFieldID{"a1", "b1"}, g.FieldIDs())
+
+	gl := got.GroupListBySchema("b")
+	assert.Equal(t, lid, gl.ID())
+	assert.Equal(t, 2, gl.Count())
+	assert.Equal(t, ValueTypeString.ValueFrom("x"), gl.GroupAt(0).Field("a2").Value())
+	assert.Nil(t, gl.GroupAt(0).Field("b2"))
+	assert.Equal(t, liid1, gl.GroupAt(1).ID())
+	assert.Equal(t, ValueTypeBool.ValueFrom(true), gl.GroupAt(1).Field("b2").Value())
+	assert.Nil(t, gl.Group(liid2), "the second item has no field left")
+
+	assert.Equal(t, []DroppedField{
+		{Pointer: PointField(id.PropertySchemaGroupID("a").Ref(), nil, "a3"), Value: OptionalValueFrom(ValueTypeString.ValueFrom("deleted")), Reason: DropReasonDeleted},
+		{Pointer: PointField(id.PropertySchemaGroupID("a").Ref(), nil, "a4"), Value: OptionalValueFrom(ValueTypeString.ValueFrom("renamed")), Reason: DropReasonConflict},
+		{Pointer: PointField(id.PropertySchemaGroupID("b").Ref(), liid2.Ref(), "b1"), Value: OptionalValueFrom(ValueTypeString.ValueFrom("second")), Reason: DropReasonConflict},
+		{Pointer: PointField(id.PropertySchemaGroupID("b").Ref(), liid2.Ref(), "b2"), Value: OptionalValueFrom(ValueTypeString.ValueFrom("nope")), Reason: DropReasonCastFailed},
+	}, dropped)
+}
+
+func TestMigrator_Migrate_Nil(t *testing.T) {
+	got, dropped := Migrator{}.Migrate(&Property{})
+	assert.Nil(t, got)
+	assert.Nil(t, dropped)
+
+	got, dropped = Migrator{NewSchema: &Schema{}}.Migrate(nil)
+	assert.Nil(t, got)
+	assert.Nil(t, dropped)
+}
@@ -0,0 +1,47 @@
+package publication
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashBasicAuthPassword returns the hash of the basic auth password of a publication to be stored.
+// The password is always hashed even if it looks like a hash, so a client cannot store a hash of its choice.
+// Stored plaintext passwords are migrated by the database migration, which skips values that are already hashed.
+func HashBasicAuthPassword(password string) (string, error) {
+	if password == "" {
+		return password, nil
+	}
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(h), nil
+}
+
+func IsHashedBasicAuthPassword(s string) bool {
+	if len(s) != 60 {
+		return false
+	}
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(s, prefix) {
+			_, err := bcrypt.Cost([]byte(s))
+			return err == nil
+		}
+	}
+	return false
+}
+
+// MatchBasicAuthPassword reports whether the password matches the stored one.
+// Plaintext passwords stored before they were hashed are still compared until they are migrated.
+func MatchBasicAuthPassword(stored, password string) bool {
+	if stored == "" {
+		return false
+	}
+	if IsHashedBasicAuthPassword(stored) {
+		return bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)) == nil
+	}
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+}
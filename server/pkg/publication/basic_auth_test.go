@@ -0,0 +1,49 @@
+package publication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashBasicAuthPassword(t *testing.T) {
+	h, err := HashBasicAuthPassword("pass")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "pass", h)
+	assert.True(t, IsHashedBasicAuthPassword(h))
+	assert.True(t, MatchBasicAuthPassword(h, "pass"))
+	assert.False(t, MatchBasicAuthPassword(h, "wrong"))
+
+	// a hash given as a password is hashed like any other password
+	h2, err := HashBasicAuthPassword(h)
+	assert.NoError(t, err)
+	assert.NotEqual(t, h, h2)
+	assert.True(t, MatchBasicAuthPassword(h2, h))
+	assert.False(t, MatchBasicAuthPassword(h2, "pass"))
+
+	empty, err := HashBasicAuthPassword("")
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestMatchBasicAuthPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		stored   string
+		password string
+		want     bool
+	}{
+		{name: "legacy plaintext", stored: "pass", password: "pass", want: true},
+		{name: "legacy plaintext mismatch", stored: "pass", password: "pas", want: false},
+		{name: "empty stored", stored: "", password: "", want: false},
+		{name: "hash-like plaintext", stored: "$2a$10$short", password: "$2a$10$short", want: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, MatchBasicAuthPassword(tt.stored, tt.password))
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package publication
+
+import (
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+)
+
+var (
+	ErrInvalidScheduleTarget    = errors.New("publish schedule must target either a project or a story")
+	ErrInvalidScheduleTime      = errors.New("publish schedule must have publishAt or unpublishAt, and unpublishAt must be after publishAt")
+	ErrInvalidScheduleStatus    = errors.New("publish schedule status must be public or limited")
+	ErrScheduleNotCancelable    = errors.New("publish schedule is already finished")
+	ErrScheduleNothingToExecute = errors.New("publish schedule has nothing to execute")
+)
+
+// ScheduleStatus is the publishment status a schedule publishes the project or the story with.
+type ScheduleStatus string
+
+const (
+	ScheduleStatusPublic  ScheduleStatus = "public"
+	ScheduleStatusLimited ScheduleStatus = "limited"
+)
+
+type ScheduleState string
+
+const (
+	// ScheduleStatePending waits for publishAt, or for unpublishAt when the schedule only unpublishes.
+	ScheduleStatePending ScheduleState = "pending"
+	// ScheduleStatePublished has been published and waits for unpublishAt.
+	ScheduleStatePublished ScheduleState = "published"
+	ScheduleStateDone      ScheduleState = "done"
+	ScheduleStateCanceled  ScheduleState = "canceled"
+	ScheduleStateFailed    ScheduleState = "failed"
+)
+
+type ScheduleAction string
+
+const (
+	ScheduleActionNone      ScheduleAction = ""
+	ScheduleActionPublish   ScheduleAction = "publish"
+	ScheduleActionUnpublish ScheduleAction = "unpublish"
+)
+
+// Schedule publishes a project or a story at publishAt and unpublishes it at unpublishAt.
+// Either time can be omitted to only publish or only unpublish.
+type Schedule struct {
+	id          id.PublishScheduleID
+	scene       id.SceneID
+	project     *id.ProjectID
+	story       *id.StoryID
+	alias       string
+	status      ScheduleStatus
+	publishAt   *time.Time
+	unpublishAt *time.Time
+	state       ScheduleState
+	lastError   string
+	createdBy   *accountsID.UserID
+}
+
+func (s *Schedule) ID() id.PublishScheduleID {
+	return s.id
+}
+
+func (s *Schedule) Scene() id.SceneID {
+	return s.scene
+}
+
+func (s *Schedule) Project() *id.ProjectID {
+	return s.project.CloneRef()
+}
+
+func (s *Schedule) Story() *id.StoryID {
+	return s.story.CloneRef()
+}
+
+// Alias is the alias the project or the story is published with. Empty keeps the current alias.
+func (s *Schedule) Alias() string {
+	return s.alias
+}
+
+func (s *Schedule) Status() ScheduleStatus {
+	return s.status
+}
+
+func (s *Schedule) PublishAt() *time.Time {
+	return cloneTime(s.publishAt)
+}
+
+func (s *Schedule) UnpublishAt() *time.Time {
+	return cloneTime(s.unpublishAt)
+}
+
+func (s *Schedule) State() ScheduleState {
+	return s.state
+}
+
+func (s *Schedule) LastError() string {
+	return s.lastError
+}
+
+func (s *Schedule) CreatedBy() *accountsID.UserID {
+	return s.createdBy
+}
+
+func (s *Schedule) CreatedAt() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.id.Timestamp()
+}
+
+// NextAction returns the action the scheduler executes next.
+func (s *Schedule) NextAction() ScheduleAction {
+	switch s.state {
+	case ScheduleStatePending:
+		if s.publishAt != nil {
+			return ScheduleActionPublish
+		}
+		return ScheduleActionUnpublish
+	case ScheduleStatePublished:
+		if s.unpublishAt != nil {
+			return ScheduleActionUnpublish
+		}
+	}
+	return ScheduleActionNone
+}
+
+// NextRunAt returns the time the next action is due at, or nil when the schedule is finished.
+func (s *Schedule) NextRunAt() *time.Time {
+	switch s.NextAction() {
+	case ScheduleActionPublish:
+		return s.PublishAt()
+	case ScheduleActionUnpublish:
+		return s.UnpublishAt()
+	}
+	return nil
+}
+
+func (s *Schedule) IsDue(now time.Time) bool {
+	next := s.NextRunAt()
+	return next != nil && !now.Before(*next)
+}
+
+func (s *Schedule) IsFinished() bool {
+	return s.NextAction() == ScheduleActionNone
+}
+
+// Done records that the next action has been executed.
+func (s *Schedule) Done() error {
+	switch s.NextAction() {
+	case ScheduleActionPublish:
+		if s.unpublishAt != nil {
+			s.state = ScheduleStatePublished
+		} else {
+			s.state = ScheduleStateDone
+		}
+	case ScheduleActionUnpublish:
+		s.state = ScheduleStateDone
+	default:
+		return ErrScheduleNothingToExecute
+	}
+	s.lastError = ""
+	return nil
+}
+
+func (s *Schedule) Fail(err error) {
+	s.state = ScheduleStateFailed
+	if err != nil {
+		s.lastError = err.Error()
+	}
+}
+
+func (s *Schedule) Cancel() error {
+	if s.IsFinished() {
+		return ErrScheduleNotCancelable
+	}
+	s.state = ScheduleStateCanceled
+	return nil
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	t2 := *t
+	return &t2
+}
+
+type ScheduleBuilder struct {
+	s *Schedule
+}
+
+func NewSchedule() *ScheduleBuilder {
+	return &ScheduleBuilder{s: &Schedule{state: ScheduleStatePending}}
+}
+
+func (b *ScheduleBuilder) Build() (*Schedule, error) {
+	if b.s.id.IsNil() || b.s.scene.IsNil() {
+		return nil, idx.ErrInvalidID
+	}
+	if (b.s.project == nil) == (b.s.story == nil) {
+		return nil, ErrInvalidScheduleTarget
+	}
+	if b.s.publishAt == nil && b.s.unpublishAt == nil ||
+		b.s.publishAt != nil && b.s.unpublishAt != nil && !b.s.unpublishAt.After(*b.s.publishAt) {
+		return nil, ErrInvalidScheduleTime
+	}
+	if b.s.publishAt != nil && b.s.status != ScheduleStatusPublic && b.s.status != ScheduleStatusLimited {
+		return nil, ErrInvalidScheduleStatus
+	}
+	return b.s, nil
+}
+
+func (b *ScheduleBuilder) MustBuild() *Schedule {
+	s, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (b *ScheduleBuilder) ID(id id.PublishScheduleID) *ScheduleBuilder {
+	b.s.id = id
+	return b
+}
+
+func (b *ScheduleBuilder) NewID() *ScheduleBuilder {
+	b.s.id = id.NewPublishScheduleID()
+	return b
+}
+
+func (b *ScheduleBuilder) Scene(scene id.SceneID) *ScheduleBuilder {
+	b.s.scene = scene
+	return b
+}
+
+func (b *ScheduleBuilder) Project(project *id.ProjectID) *ScheduleBuilder {
+	b.s.project = project.CloneRef()
+	return b
+}
+
+func (b *ScheduleBuilder) Story(story *id.StoryID) *ScheduleBuilder {
+	b.s.story = story.CloneRef()
+	return b
+}
+
+func (b *ScheduleBuilder) Alias(alias string) *ScheduleBuilder {
+	b.s.alias = alias
+	return b
+}
+
+func (b *ScheduleBuilder) Status(status ScheduleStatus) *ScheduleBuilder {
+	b.s.status = status
+	return b
+}
+
+func (b *ScheduleBuilder) PublishAt(publishAt *time.Time) *ScheduleBuilder {
+	b.s.publishAt = cloneTime(publishAt)
+	return b
+}
+
+func (b *ScheduleBuilder) UnpublishAt(unpublishAt *time.Time) *ScheduleBuilder {
+	b.s.unpublishAt = cloneTime(unpublishAt)
+	return b
+}
+
+func (b *ScheduleBuilder) State(state ScheduleState) *ScheduleBuilder {
+	if state != "" {
+		b.s.state = state
+	}
+	return b
+}
+
+func (b *ScheduleBuilder) LastError(lastError string) *ScheduleBuilder {
+	b.s.lastError = lastError
+	return b
+}
+
+func (b *ScheduleBuilder) CreatedBy(createdBy *accountsID.UserID) *ScheduleBuilder {
+	b.s.createdBy = createdBy
+	return b
+}
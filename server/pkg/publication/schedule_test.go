@@ -0,0 +1,94 @@
+package publication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleBuilder_Build(t *testing.T) {
+	pid := id.NewProjectID()
+	sid := id.NewStoryID()
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	base := func() *ScheduleBuilder {
+		return NewSchedule().NewID().Scene(id.NewSceneID()).Status(ScheduleStatusPublic)
+	}
+
+	tests := []struct {
+		name    string
+		builder *ScheduleBuilder
+		wantErr error
+	}{
+		{name: "publish", builder: base().Project(&pid).PublishAt(&now)},
+		{name: "unpublish", builder: NewSchedule().NewID().Scene(id.NewSceneID()).Story(&sid).UnpublishAt(&now)},
+		{name: "publish and unpublish", builder: base().Story(&sid).PublishAt(&now).UnpublishAt(&later)},
+		{name: "no target", builder: base().PublishAt(&now), wantErr: ErrInvalidScheduleTarget},
+		{name: "both targets", builder: base().Project(&pid).Story(&sid).PublishAt(&now), wantErr: ErrInvalidScheduleTarget},
+		{name: "no time", builder: base().Project(&pid), wantErr: ErrInvalidScheduleTime},
+		{name: "unpublish before publish", builder: base().Project(&pid).PublishAt(&later).UnpublishAt(&now), wantErr: ErrInvalidScheduleTime},
+		{name: "private", builder: base().Project(&pid).Status("private").PublishAt(&now), wantErr: ErrInvalidScheduleStatus},
+		{name: "no scene", builder: NewSchedule().NewID().Project(&pid).PublishAt(&now), wantErr: idx.ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := tt.builder.Build()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, s)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, ScheduleStatePending, s.State())
+		})
+	}
+}
+
+func TestSchedule_Run(t *testing.T) {
+	pid := id.NewProjectID()
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	s := NewSchedule().NewID().Scene(id.NewSceneID()).Project(&pid).Status(ScheduleStatusPublic).
+		PublishAt(&now).UnpublishAt(&later).MustBuild()
+	assert.Equal(t, ScheduleActionPublish, s.NextAction())
+	assert.Equal(t, &now, s.NextRunAt())
+	assert.False(t, s.IsDue(now.Add(-time.Second)))
+	assert.True(t, s.IsDue(now))
+
+	assert.NoError(t, s.Done())
+	assert.Equal(t, ScheduleStatePublished, s.State())
+	assert.Equal(t, ScheduleActionUnpublish, s.NextAction())
+	assert.Equal(t, &later, s.NextRunAt())
+	assert.False(t, s.IsDue(now))
+
+	assert.NoError(t, s.Done())
+	assert.Equal(t, ScheduleStateDone, s.State())
+	assert.True(t, s.IsFinished())
+	assert.Nil(t, s.NextRunAt())
+	assert.False(t, s.IsDue(later))
+	assert.ErrorIs(t, s.Done(), ErrScheduleNothingToExecute)
+	assert.ErrorIs(t, s.Cancel(), ErrScheduleNotCancelable)
+
+	s = NewSchedule().NewID().Scene(id.NewSceneID()).Project(&pid).Status(ScheduleStatusPublic).PublishAt(&now).MustBuild()
+	assert.NoError(t, s.Done())
+	assert.Equal(t, ScheduleStateDone, s.State())
+
+	s = NewSchedule().NewID().Scene(id.NewSceneID()).Project(&pid).UnpublishAt(&later).MustBuild()
+	assert.Equal(t, ScheduleActionUnpublish, s.NextAction())
+	assert.NoError(t, s.Cancel())
+	assert.Equal(t, ScheduleStateCanceled, s.State())
+	assert.Nil(t, s.NextRunAt())
+
+	s = NewSchedule().NewID().Scene(id.NewSceneID()).Project(&pid).UnpublishAt(&later).MustBuild()
+	s.Fail(ErrScheduleNothingToExecute)
+	assert.Equal(t, ScheduleStateFailed, s.State())
+	assert.Equal(t, ErrScheduleNothingToExecute.Error(), s.LastError())
+	assert.True(t, s.IsFinished())
+}
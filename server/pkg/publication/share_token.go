@@ -0,0 +1,221 @@
+package publication
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+)
+
+var (
+	ErrInvalidShareTokenTarget = errors.New("share token must target either a project or a story")
+	ErrShareTokenRevoked       = errors.New("share token is revoked")
+	ErrShareTokenExpired       = errors.New("share token is expired")
+)
+
+// ShareToken grants access to a project or a story published with the limited status.
+// Only the hash of the token is stored, so the token itself is shown once when it is created.
+type ShareToken struct {
+	id        id.ShareTokenID
+	scene     id.SceneID
+	project   *id.ProjectID
+	story     *id.StoryID
+	name      string
+	hash      string
+	expiresAt *time.Time
+	revokedAt *time.Time
+	createdBy *accountsID.UserID
+}
+
+func (t *ShareToken) ID() id.ShareTokenID {
+	return t.id
+}
+
+func (t *ShareToken) Scene() id.SceneID {
+	return t.scene
+}
+
+func (t *ShareToken) Project() *id.ProjectID {
+	return t.project.CloneRef()
+}
+
+func (t *ShareToken) Story() *id.StoryID {
+	return t.story.CloneRef()
+}
+
+func (t *ShareToken) Name() string {
+	return t.name
+}
+
+func (t *ShareToken) Hash() string {
+	return t.hash
+}
+
+func (t *ShareToken) ExpiresAt() *time.Time {
+	if t.expiresAt == nil {
+		return nil
+	}
+	e := *t.expiresAt
+	return &e
+}
+
+func (t *ShareToken) RevokedAt() *time.Time {
+	if t.revokedAt == nil {
+		return nil
+	}
+	r := *t.revokedAt
+	return &r
+}
+
+func (t *ShareToken) CreatedBy() *accountsID.UserID {
+	return t.createdBy
+}
+
+func (t *ShareToken) CreatedAt() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.id.Timestamp()
+}
+
+func (t *ShareToken) IsRevoked() bool {
+	return t.revokedAt != nil
+}
+
+func (t *ShareToken) IsExpired(now time.Time) bool {
+	return t.expiresAt != nil && !now.Before(*t.expiresAt)
+}
+
+// Validate returns an error when the token can no longer be used.
+func (t *ShareToken) Validate(now time.Time) error {
+	if t.IsRevoked() {
+		return ErrShareTokenRevoked
+	}
+	if t.IsExpired(now) {
+		return ErrShareTokenExpired
+	}
+	return nil
+}
+
+// Targets reports whether the token grants access to the project or the story.
+func (t *ShareToken) Targets(project *id.ProjectID, story *id.StoryID) bool {
+	if t.project != nil {
+		return project != nil && *t.project == *project
+	}
+	return t.story != nil && story != nil && *t.story == *story
+}
+
+func (t *ShareToken) Revoke(now time.Time) {
+	if t.revokedAt == nil {
+		t.revokedAt = &now
+	}
+}
+
+func (t *ShareToken) SetExpiresAt(expiresAt *time.Time) {
+	if expiresAt == nil {
+		t.expiresAt = nil
+		return
+	}
+	e := *expiresAt
+	t.expiresAt = &e
+}
+
+// GenerateShareToken returns a new random token and its hash.
+func GenerateShareToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, HashShareToken(token), nil
+}
+
+func HashShareToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+type ShareTokenBuilder struct {
+	t *ShareToken
+}
+
+func NewShareToken() *ShareTokenBuilder {
+	return &ShareTokenBuilder{t: &ShareToken{}}
+}
+
+func (b *ShareTokenBuilder) Build() (*ShareToken, error) {
+	if b.t.id.IsNil() || b.t.scene.IsNil() || b.t.hash == "" {
+		return nil, idx.ErrInvalidID
+	}
+	if (b.t.project == nil) == (b.t.story == nil) {
+		return nil, ErrInvalidShareTokenTarget
+	}
+	return b.t, nil
+}
+
+func (b *ShareTokenBuilder) MustBuild() *ShareToken {
+	t, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (b *ShareTokenBuilder) ID(id id.ShareTokenID) *ShareTokenBuilder {
+	b.t.id = id
+	return b
+}
+
+func (b *ShareTokenBuilder) NewID() *ShareTokenBuilder {
+	b.t.id = id.NewShareTokenID()
+	return b
+}
+
+func (b *ShareTokenBuilder) Scene(scene id.SceneID) *ShareTokenBuilder {
+	b.t.scene = scene
+	return b
+}
+
+func (b *ShareTokenBuilder) Project(project *id.ProjectID) *ShareTokenBuilder {
+	b.t.project = project.CloneRef()
+	return b
+}
+
+func (b *ShareTokenBuilder) Story(story *id.StoryID) *ShareTokenBuilder {
+	b.t.story = story.CloneRef()
+	return b
+}
+
+func (b *ShareTokenBuilder) Name(name string) *ShareTokenBuilder {
+	b.t.name = name
+	return b
+}
+
+func (b *ShareTokenBuilder) Hash(hash string) *ShareTokenBuilder {
+	b.t.hash = hash
+	return b
+}
+
+func (b *ShareTokenBuilder) ExpiresAt(expiresAt *time.Time) *ShareTokenBuilder {
+	b.t.SetExpiresAt(expiresAt)
+	return b
+}
+
+func (b *ShareTokenBuilder) RevokedAt(revokedAt *time.Time) *ShareTokenBuilder {
+	if revokedAt != nil {
+		r := *revokedAt
+		b.t.revokedAt = &r
+	}
+	return b
+}
+
+func (b *ShareTokenBuilder) CreatedBy(createdBy *accountsID.UserID) *ShareTokenBuilder {
+	b.t.createdBy = createdBy
+	return b
+}
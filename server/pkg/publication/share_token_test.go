@@ -0,0 +1,93 @@
+package publication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareTokenBuilder_Build(t *testing.T) {
+	pid := id.NewProjectID()
+	sid := id.NewStoryID()
+	base := func() *ShareTokenBuilder {
+		return NewShareToken().NewID().Scene(id.NewSceneID()).Hash(HashShareToken("token"))
+	}
+
+	tests := []struct {
+		name    string
+		builder *ShareTokenBuilder
+		wantErr error
+	}{
+		{name: "project", builder: base().Project(&pid)},
+		{name: "story", builder: base().Story(&sid)},
+		{name: "no target", builder: base(), wantErr: ErrInvalidShareTokenTarget},
+		{name: "both targets", builder: base().Project(&pid).Story(&sid), wantErr: ErrInvalidShareTokenTarget},
+		{name: "no hash", builder: NewShareToken().NewID().Scene(id.NewSceneID()).Project(&pid), wantErr: idx.ErrInvalidID},
+		{name: "no scene", builder: NewShareToken().NewID().Hash("h").Project(&pid), wantErr: idx.ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			st, err := tt.builder.Build()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, st)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, st.ID().Timestamp(), st.CreatedAt())
+		})
+	}
+}
+
+func TestShareToken_Validate(t *testing.T) {
+	pid := id.NewProjectID()
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	st := NewShareToken().NewID().Scene(id.NewSceneID()).Hash("h").Project(&pid).MustBuild()
+	assert.NoError(t, st.Validate(now))
+
+	st.SetExpiresAt(&future)
+	assert.NoError(t, st.Validate(now))
+	st.SetExpiresAt(&past)
+	assert.ErrorIs(t, st.Validate(now), ErrShareTokenExpired)
+	st.SetExpiresAt(nil)
+	assert.Nil(t, st.ExpiresAt())
+
+	st.Revoke(now)
+	assert.ErrorIs(t, st.Validate(now), ErrShareTokenRevoked)
+	st.Revoke(future)
+	assert.Equal(t, &now, st.RevokedAt())
+}
+
+func TestShareToken_Targets(t *testing.T) {
+	pid := id.NewProjectID()
+	sid := id.NewStoryID()
+
+	st := NewShareToken().NewID().Scene(id.NewSceneID()).Hash("h").Project(&pid).MustBuild()
+	assert.True(t, st.Targets(&pid, nil))
+	assert.False(t, st.Targets(id.NewProjectID().Ref(), nil))
+	assert.False(t, st.Targets(nil, &sid))
+
+	st2 := NewShareToken().NewID().Scene(id.NewSceneID()).Hash("h").Story(&sid).MustBuild()
+	assert.True(t, st2.Targets(nil, &sid))
+	assert.False(t, st2.Targets(&pid, nil))
+}
+
+func TestGenerateShareToken(t *testing.T) {
+	token, hash, err := GenerateShareToken()
+	assert.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Equal(t, HashShareToken(token), hash)
+
+	token2, _, err := GenerateShareToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, token2)
+}
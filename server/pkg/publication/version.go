@@ -0,0 +1,167 @@
+package publication
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+)
+
+var (
+	ErrInvalidVersionTarget = errors.New("publication version must target either a project or a story")
+	ErrInvalidVersionNumber = errors.New("publication version number must be positive")
+)
+
+// Version is an immutable record of a publish of a project or a story.
+// The built data of the publish is kept as a snapshot named by SnapshotName, so the publication can be rolled back to it.
+type Version struct {
+	id          id.PublicationVersionID
+	scene       id.SceneID
+	project     *id.ProjectID
+	story       *id.StoryID
+	version     int
+	alias       string
+	publishedAt time.Time
+	publishedBy *accountsID.UserID
+	message     string
+}
+
+func (v *Version) ID() id.PublicationVersionID {
+	return v.id
+}
+
+func (v *Version) Scene() id.SceneID {
+	return v.scene
+}
+
+func (v *Version) Project() *id.ProjectID {
+	return v.project.CloneRef()
+}
+
+func (v *Version) Story() *id.StoryID {
+	return v.story.CloneRef()
+}
+
+// Target returns the ID of the project or the story as a string.
+func (v *Version) Target() string {
+	if v.project != nil {
+		return v.project.String()
+	}
+	return v.story.String()
+}
+
+// Version is the sequential number of the publish starting from 1 for each project or story.
+func (v *Version) Version() int {
+	return v.version
+}
+
+// Alias is the alias the project or the story was published with.
+func (v *Version) Alias() string {
+	return v.alias
+}
+
+func (v *Version) PublishedAt() time.Time {
+	return v.publishedAt
+}
+
+// PublishedBy is the user who published it. It is nil when it was published by a publish schedule.
+func (v *Version) PublishedBy() *accountsID.UserID {
+	return v.publishedBy
+}
+
+func (v *Version) Message() string {
+	return v.message
+}
+
+func (v *Version) SnapshotName() string {
+	return SnapshotName(v.Target(), v.version)
+}
+
+func SnapshotName(target string, version int) string {
+	return fmt.Sprintf("%s_v%d", target, version)
+}
+
+type VersionBuilder struct {
+	v *Version
+}
+
+func NewVersion() *VersionBuilder {
+	return &VersionBuilder{v: &Version{}}
+}
+
+func (b *VersionBuilder) Build() (*Version, error) {
+	if b.v.id.IsNil() || b.v.scene.IsNil() {
+		return nil, idx.ErrInvalidID
+	}
+	if (b.v.project == nil) == (b.v.story == nil) {
+		return nil, ErrInvalidVersionTarget
+	}
+	if b.v.version <= 0 {
+		return nil, ErrInvalidVersionNumber
+	}
+	if b.v.publishedAt.IsZero() {
+		b.v.publishedAt = b.v.id.Timestamp()
+	}
+	return b.v, nil
+}
+
+func (b *VersionBuilder) MustBuild() *Version {
+	v, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (b *VersionBuilder) ID(id id.PublicationVersionID) *VersionBuilder {
+	b.v.id = id
+	return b
+}
+
+func (b *VersionBuilder) NewID() *VersionBuilder {
+	b.v.id = id.NewPublicationVersionID()
+	return b
+}
+
+func (b *VersionBuilder) Scene(scene id.SceneID) *VersionBuilder {
+	b.v.scene = scene
+	return b
+}
+
+func (b *VersionBuilder) Project(project *id.ProjectID) *VersionBuilder {
+	b.v.project = project.CloneRef()
+	return b
+}
+
+func (b *VersionBuilder) Story(story *id.StoryID) *VersionBuilder {
+	b.v.story = story.CloneRef()
+	return b
+}
+
+func (b *VersionBuilder) Version(version int) *VersionBuilder {
+	b.v.version = version
+	return b
+}
+
+func (b *VersionBuilder) Alias(alias string) *VersionBuilder {
+	b.v.alias = alias
+	return b
+}
+
+func (b *VersionBuilder) PublishedAt(publishedAt time.Time) *VersionBuilder {
+	b.v.publishedAt = publishedAt
+	return b
+}
+
+func (b *VersionBuilder) PublishedBy(publishedBy *accountsID.UserID) *VersionBuilder {
+	b.v.publishedBy = publishedBy
+	return b
+}
+
+func (b *VersionBuilder) Message(message string) *VersionBuilder {
+	b.v.message = message
+	return b
+}
@@ -0,0 +1,61 @@
+package publication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionBuilder_Build(t *testing.T) {
+	pid := id.NewProjectID()
+	sid := id.NewStoryID()
+	base := func() *VersionBuilder {
+		return NewVersion().NewID().Scene(id.NewSceneID()).Version(1)
+	}
+
+	tests := []struct {
+		name    string
+		builder *VersionBuilder
+		wantErr error
+	}{
+		{name: "project", builder: base().Project(&pid)},
+		{name: "story", builder: base().Story(&sid)},
+		{name: "no target", builder: base(), wantErr: ErrInvalidVersionTarget},
+		{name: "both targets", builder: base().Project(&pid).Story(&sid), wantErr: ErrInvalidVersionTarget},
+		{name: "zero version", builder: base().Project(&pid).Version(0), wantErr: ErrInvalidVersionNumber},
+		{name: "no scene", builder: NewVersion().NewID().Project(&pid).Version(1), wantErr: idx.ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v, err := tt.builder.Build()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, v)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, v.ID().Timestamp(), v.PublishedAt())
+		})
+	}
+}
+
+func TestVersion_SnapshotName(t *testing.T) {
+	pid := id.NewProjectID()
+	sid := id.NewStoryID()
+	publishedAt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	v := NewVersion().NewID().Scene(id.NewSceneID()).Project(&pid).Version(3).PublishedAt(publishedAt).MustBuild()
+	assert.Equal(t, pid.String(), v.Target())
+	assert.Equal(t, pid.String()+"_v3", v.SnapshotName())
+	assert.Equal(t, publishedAt, v.PublishedAt())
+
+	v = NewVersion().NewID().Scene(id.NewSceneID()).Story(&sid).Version(1).MustBuild()
+	assert.Equal(t, sid.String(), v.Target())
+	assert.Equal(t, SnapshotName(sid.String(), 1), v.SnapshotName())
+}
@@ -25,6 +25,7 @@ type Builder struct {
 	nlsLayer    *nlslayer.NLSLayerList
 	layerStyles *scene.StyleList
 	story       *storytelling.Story
+	stories     storytelling.StoryList
 
 	exportType bool
 }
@@ -69,6 +70,15 @@ func (b *Builder) WithStory(s *storytelling.Story) *Builder {
 	return b
 }
 
+// WithStories sets the stories of the scene, which are listed in index order next to the published story.
+func (b *Builder) WithStories(stories storytelling.StoryList) *Builder {
+	if b == nil {
+		return nil
+	}
+	b.stories = stories
+	return b
+}
+
 // Build this is used to publish projects and stories
 func (b *Builder) Build(ctx context.Context, w io.Writer, publishedAt time.Time, coreSupport bool, enableGa bool, trackingId string) error {
 	if b == nil || b.scene == nil {
@@ -86,6 +96,7 @@ func (b *Builder) Build(ctx context.Context, w io.Writer, publishedAt time.Time,
 			return err
 		}
 		res.Story = story
+		res.Stories = b.storySummariesJSON()
 	}
 
 	if b.nlsLayer != nil {
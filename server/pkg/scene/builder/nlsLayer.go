@@ -70,6 +70,16 @@ type lineStringJSON struct {
 	Coordinates [][]float64 `json:"coordinates"`
 }
 
+type multiPointJSON struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+type multiLineStringJSON struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
 type polygonJSON struct {
 	Type        string        `json:"type"`
 	Coordinates [][][]float64 `json:"coordinates"`
@@ -222,11 +232,21 @@ func (b *Builder) geometryJSON(ctx context.Context, geometry nlslayer.Geometry)
 			Type:        g.PointType(),
 			Coordinates: g.Coordinates(),
 		}}
+	case *nlslayer.MultiPoint:
+		return []any{&multiPointJSON{
+			Type:        g.MultiPointType(),
+			Coordinates: g.Coordinates(),
+		}}
 	case *nlslayer.LineString:
 		return []any{&lineStringJSON{
 			Type:        g.LineStringType(),
 			Coordinates: g.Coordinates(),
 		}}
+	case *nlslayer.MultiLineString:
+		return []any{&multiLineStringJSON{
+			Type:        g.MultiLineStringType(),
+			Coordinates: g.Coordinates(),
+		}}
 	case *nlslayer.Polygon:
 		return []any{&polygonJSON{
 			Type:        g.PolygonType(),
@@ -18,6 +18,7 @@ type sceneJSON struct {
 	Widgets            []*widgetJSON           `json:"widgets"`
 	WidgetAlignSystems *widgetAlignSystemsJSON `json:"widgetAlignSystems"`
 	Story              *storyJSON              `json:"story,omitempty"`
+	Stories            []storySummaryJSON      `json:"stories,omitempty"`
 	NLSLayers          []*nlsLayerJSON         `json:"nlsLayers"`
 	LayerStyles        []*layerStylesJSON      `json:"layerStyles"`
 	CoreSupport        bool                    `json:"coreSupport"`
@@ -11,12 +11,21 @@ import (
 type storyJSON struct {
 	ID            string       `json:"id"`
 	Title         string       `json:"title"`
+	Index         int          `json:"index"`
 	Property      propertyJSON `json:"property"`
 	Pages         []pageJSON   `json:"pages"`
 	PanelPosition string       `json:"position"`
 	BgColor       string       `json:"bgColor"`
 }
 
+// storySummaryJSON links to another published story of the scene.
+type storySummaryJSON struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Alias string `json:"alias"`
+	Index int    `json:"index"`
+}
+
 type pageJSON struct {
 	ID              string       `json:"id"`
 	Property        propertyJSON `json:"property"`
@@ -43,6 +52,7 @@ func (b *Builder) storyJSON(ctx context.Context, p []*property.Property) (*story
 	return &storyJSON{
 		ID:       b.story.Id().String(),
 		Title:    b.story.Title(),
+		Index:    b.story.Index(),
 		Property: b.property(ctx, findProperty(p, b.story.Property())),
 		Pages: lo.FilterMap(b.story.Pages().Pages(), func(page *storytelling.Page, _ int) (pageJSON, bool) {
 			if page == nil {
@@ -55,6 +65,31 @@ func (b *Builder) storyJSON(ctx context.Context, p []*property.Property) (*story
 	}, nil
 }
 
+// storySummariesJSON lists the published stories of the scene in index order, including the story being built.
+func (b *Builder) storySummariesJSON() []storySummaryJSON {
+	if b.stories == nil {
+		return nil
+	}
+
+	var res []storySummaryJSON
+	for _, s := range b.stories.Sorted() {
+		if b.story != nil && s.Id() == b.story.Id() {
+			// the story being built may not be saved yet
+			s = b.story
+		}
+		if st := s.PublishmentStatus(); st != storytelling.PublishmentStatusPublic && st != storytelling.PublishmentStatusLimited {
+			continue
+		}
+		res = append(res, storySummaryJSON{
+			ID:    s.Id().String(),
+			Title: s.Title(),
+			Alias: s.Alias(),
+			Index: s.Index(),
+		})
+	}
+	return res
+}
+
 func (b *Builder) pageJSON(ctx context.Context, page storytelling.Page, p []*property.Property) pageJSON {
 	return pageJSON{
 		ID:       page.Id().String(),
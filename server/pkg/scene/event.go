@@ -0,0 +1,56 @@
+package scene
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+// EventType is what has changed in a scene.
+type EventType string
+
+const (
+	EventLayerAdded      EventType = "layer.added"
+	EventLayerUpdated    EventType = "layer.updated"
+	EventLayerRemoved    EventType = "layer.removed"
+	EventFeatureAdded    EventType = "feature.added"
+	EventFeatureUpdated  EventType = "feature.updated"
+	EventFeatureRemoved  EventType = "feature.removed"
+	EventPropertyUpdated EventType = "property.updated"
+	EventStyleAdded      EventType = "style.added"
+	EventStyleUpdated    EventType = "style.updated"
+	EventStyleRemoved    EventType = "style.removed"
+	EventWidgetAdded     EventType = "widget.added"
+	EventWidgetUpdated   EventType = "widget.updated"
+	EventWidgetRemoved   EventType = "widget.removed"
+	EventStoryAdded      EventType = "story.added"
+	EventStoryUpdated    EventType = "story.updated"
+	EventStoryRemoved    EventType = "story.removed"
+	EventPageAdded       EventType = "page.added"
+	EventPageUpdated     EventType = "page.updated"
+	EventPageRemoved     EventType = "page.removed"
+	EventBlockAdded      EventType = "block.added"
+	EventBlockUpdated    EventType = "block.updated"
+	EventBlockRemoved    EventType = "block.removed"
+)
+
+// Event tells the editors of a scene that something in it has changed, so that they do not overwrite each other's edits.
+// It carries only the IDs of what has changed, and the editors fetch the latest state themselves.
+type Event struct {
+	Type  EventType
+	Scene id.SceneID
+	// Actor is the user who made the change, so that editors can skip their own changes.
+	Actor *accountsID.UserID
+	Layer *id.NLSLayerID
+	// Feature is nil when several features of the layer have changed at once.
+	Feature  *id.FeatureID
+	Property *id.PropertyID
+	Style    *id.StyleID
+	Widget   *id.WidgetID
+	Story    *id.StoryID
+	Page     *id.PageID
+	Block    *id.BlockID
+
+	OccurredAt time.Time
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/reearth/reearthx/rerror"
 )
 
@@ -15,12 +17,20 @@ type PluginMigrator struct {
 	Property       property.Loader
 	PropertySchema property.SchemaLoader
 	Plugin         plugin.Loader
+	// NLSLayer and Story are optional. When set, infobox blocks and story blocks of the plugin are migrated as well.
+	NLSLayer nlslayer.LoaderByScene
+	Story    storytelling.LoaderByScene
 }
 
 type MigratePluginsResult struct {
 	Scene             *scene.Scene
 	Properties        []*property.Property
 	RemovedProperties id.PropertyIDList
+	// NLSLayers and Stories are the layers and the stories whose blocks were upgraded or removed.
+	NLSLayers nlslayer.NLSLayerList
+	Stories   storytelling.StoryList
+	// DroppedFields are the values that could not be migrated to the new property schemas.
+	DroppedFields map[id.PropertyID][]property.DroppedField
 }
 
 var (
@@ -29,37 +39,20 @@ var (
 )
 
 func (s *PluginMigrator) MigratePlugins(ctx context.Context, sc *scene.Scene, oldPluginID, newPluginID id.PluginID) (MigratePluginsResult, error) {
-	if s == nil {
-		return MigratePluginsResult{}, rerror.ErrInternalByWithContext(ctx, errors.New("scene is nil"))
-	}
-
-	// should be same plugin but different version
-	if oldPluginID.Equal(newPluginID) || !oldPluginID.NameEqual(newPluginID) {
-		return MigratePluginsResult{}, ErrInvalidPlugins
-	}
-
-	// should be installed
-	if !sc.Plugins().Has(oldPluginID) {
-		return MigratePluginsResult{}, ErrPluginNotInstalled
-	}
-
-	// Get plugins
-	plugins, err := s.Plugin(ctx, []id.PluginID{oldPluginID, newPluginID})
-	if err != nil || len(plugins) < 2 {
-		return MigratePluginsResult{}, ErrInvalidPlugins
+	oldPlugin, newPlugin, err := s.loadPlugins(ctx, sc, oldPluginID, newPluginID)
+	if err != nil {
+		return MigratePluginsResult{}, err
 	}
 
-	oldPlugin := plugins[0]
-	newPlugin := plugins[1]
-
 	propertyIDs := id.PropertyIDList{}
 	removedPropertyIDs := id.PropertyIDList{}
 
 	// Obtain property schema and map old schema to new schema
-	schemaMap, err := s.loadSchemas(ctx, oldPlugin, newPlugin)
+	schemas, err := s.loadSchemas(ctx, oldPlugin, newPlugin)
 	if err != nil {
 		return MigratePluginsResult{}, err
 	}
+	migrators := migratorsFrom(oldPlugin, newPlugin, schemas)
 
 	// Scene Plug-ins
 	sc.Plugins().Upgrade(oldPluginID, newPluginID, nil, false)
@@ -81,6 +74,67 @@ func (s *PluginMigrator) MigratePlugins(ctx context.Context, sc *scene.Scene, ol
 		}
 	}
 
+	// Infobox blocks
+	var layers nlslayer.NLSLayerList
+	if s.NLSLayer != nil {
+		ll, err := s.NLSLayer(ctx, sc.ID())
+		if err != nil {
+			return MigratePluginsResult{}, err
+		}
+		for _, l := range ll {
+			if l == nil || !(*l).HasInfobox() {
+				continue
+			}
+			ib := (*l).Infobox()
+			changed := false
+			for _, b := range ib.Blocks() {
+				if !b.Plugin().Equal(oldPluginID) {
+					continue
+				}
+				changed = true
+				if newPlugin.Extension(b.Extension()) == nil {
+					ib.Remove(b.ID())
+					removedPropertyIDs = append(removedPropertyIDs, b.Property())
+				} else {
+					b.UpgradePlugin(newPluginID)
+					propertyIDs = append(propertyIDs, b.Property())
+				}
+			}
+			if changed {
+				layers = append(layers, l)
+			}
+		}
+	}
+
+	// Story blocks
+	var stories storytelling.StoryList
+	if s.Story != nil {
+		sl, err := s.Story(ctx, sc.ID())
+		if err != nil {
+			return MigratePluginsResult{}, err
+		}
+		if sl != nil {
+			for _, st := range *sl {
+				changed := false
+				for _, page := range st.Pages().Pages() {
+					for _, b := range page.BlocksByPlugin(oldPluginID, nil) {
+						changed = true
+						if newPlugin.Extension(b.Extension()) == nil {
+							page.RemoveBlock(b.ID())
+							removedPropertyIDs = append(removedPropertyIDs, b.Property())
+						} else {
+							b.UpgradePlugin(newPluginID)
+							propertyIDs = append(propertyIDs, b.Property())
+						}
+					}
+				}
+				if changed {
+					stories = append(stories, st)
+				}
+			}
+		}
+	}
+
 	// Get all Properties
 	properties, err := s.Property(ctx, propertyIDs...)
 	if err != nil {
@@ -88,9 +142,22 @@ func (s *PluginMigrator) MigratePlugins(ctx context.Context, sc *scene.Scene, ol
 	}
 
 	// Migrate Properties
-	for _, p := range properties {
-		if schema := schemaMap[p.Schema()]; schema != nil {
-			p.MigrateSchema(ctx, schema)
+	var dropped map[id.PropertyID][]property.DroppedField
+	for i, p := range properties {
+		if p == nil {
+			continue
+		}
+		m, ok := migrators[p.Schema()]
+		if !ok {
+			continue
+		}
+		np, d := m.Migrate(p)
+		properties[i] = np
+		if len(d) > 0 {
+			if dropped == nil {
+				dropped = map[id.PropertyID][]property.DroppedField{}
+			}
+			dropped[p.ID()] = d
 		}
 	}
 
@@ -98,33 +165,60 @@ func (s *PluginMigrator) MigratePlugins(ctx context.Context, sc *scene.Scene, ol
 		Scene:             sc,
 		Properties:        properties,
 		RemovedProperties: removedPropertyIDs,
+		NLSLayers:         layers,
+		Stories:           stories,
+		DroppedFields:     dropped,
 	}, nil
 }
 
-func (s *PluginMigrator) loadSchemas(ctx context.Context, oldPlugin *plugin.Plugin, newPlugin *plugin.Plugin) (map[id.PropertySchemaID]*property.Schema, error) {
+func (s *PluginMigrator) loadPlugins(ctx context.Context, sc *scene.Scene, oldPluginID, newPluginID id.PluginID) (*plugin.Plugin, *plugin.Plugin, error) {
+	if s == nil {
+		return nil, nil, rerror.ErrInternalByWithContext(ctx, errors.New("scene is nil"))
+	}
+
+	// should be same plugin but different version
+	if oldPluginID.Equal(newPluginID) || !oldPluginID.NameEqual(newPluginID) {
+		return nil, nil, ErrInvalidPlugins
+	}
+
+	// should be installed
+	if !sc.Plugins().Has(oldPluginID) {
+		return nil, nil, ErrPluginNotInstalled
+	}
+
+	// Get plugins
+	plugins, err := s.Plugin(ctx, []id.PluginID{oldPluginID, newPluginID})
+	if err != nil || len(plugins) < 2 || plugins[0] == nil || plugins[1] == nil {
+		return nil, nil, ErrInvalidPlugins
+	}
+
+	return plugins[0], plugins[1], nil
+}
+
+func (s *PluginMigrator) loadSchemas(ctx context.Context, oldPlugin *plugin.Plugin, newPlugin *plugin.Plugin) (property.SchemaList, error) {
 	schemasIDs := newPlugin.PropertySchemas().MergeUnique(oldPlugin.PropertySchemas())
-	schemas, err := s.PropertySchema(ctx, schemasIDs...)
-	if err != nil {
-		return nil, err
+	return s.PropertySchema(ctx, schemasIDs...)
+}
+
+// migratorsFrom returns property migrators keyed by the old property schema IDs.
+func migratorsFrom(oldPlugin *plugin.Plugin, newPlugin *plugin.Plugin, schemas property.SchemaList) map[id.PropertySchemaID]property.Migrator {
+	migrators := map[id.PropertySchemaID]property.Migrator{}
+	add := func(old, new id.PropertySchemaID) {
+		ns := schemas.Find(new)
+		if ns == nil {
+			return
+		}
+		migrators[old] = property.NewMigrator(property.SchemaDiffFrom(schemas.Find(old), ns), ns)
 	}
-	schemaMap := map[id.PropertySchemaID]*property.Schema{}
 	if opsId := oldPlugin.Schema(); opsId != nil {
 		if npsId := newPlugin.Schema(); npsId != nil {
-			for _, s := range schemas {
-				if s.ID() == *npsId {
-					schemaMap[*opsId] = s
-				}
-			}
+			add(*opsId, *npsId)
 		}
 	}
 	for _, e := range oldPlugin.Extensions() {
 		if npe := newPlugin.Extension(e.ID()); npe != nil {
-			for _, s := range schemas {
-				if s.ID() == npe.Schema() {
-					schemaMap[e.Schema()] = s
-				}
-			}
+			add(e.Schema(), npe.Schema())
 		}
 	}
-	return schemaMap, nil
+	return migrators
 }
@@ -6,10 +6,13 @@ import (
 
 	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
 	"github.com/reearth/reearth/server/pkg/plugin"
 	"github.com/reearth/reearth/server/pkg/property"
 	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPluginMigrator_MigratePlugins(t *testing.T) {
@@ -47,8 +50,109 @@ func TestPluginMigrator_MigratePlugins(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(MigratePluginsResult{
 		Scene:             sc,
-		Properties:        property.List{pl1p},
+		Properties:        property.List{property.New().ID(pl1p.ID()).Scene(sid).Schema(pl2ps.ID()).MustBuild()},
 		RemovedProperties: []id.PropertyID{},
 	}, result)
 
 }
+
+func TestPluginMigrator_MigratePlugins_Blocks(t *testing.T) {
+	ctx := context.Background()
+
+	sid := id.NewSceneID()
+	pid1 := id.MustPluginID("plugin~1.0.0")
+	pid2 := id.MustPluginID("plugin~1.0.1")
+
+	ps1 := property.NewSchema().ID(id.NewPropertySchemaID(pid1, "a")).Groups(property.NewSchemaGroupList([]*property.SchemaGroup{
+		property.NewSchemaGroup().ID("default").Fields([]*property.SchemaField{
+			property.NewSchemaField().ID("x").Type(property.ValueTypeString).MustBuild(),
+			property.NewSchemaField().ID("y").Type(property.ValueTypeString).MustBuild(),
+		}).MustBuild(),
+	})).MustBuild()
+	ps2 := property.NewSchema().ID(id.NewPropertySchemaID(pid2, "a")).Groups(property.NewSchemaGroupList([]*property.SchemaGroup{
+		property.NewSchemaGroup().ID("default").Fields([]*property.SchemaField{
+			property.NewSchemaField().ID("x").Type(property.ValueTypeNumber).MustBuild(),
+		}).MustBuild(),
+	})).MustBuild()
+	psb := property.NewSchema().ID(id.NewPropertySchemaID(pid1, "b")).MustBuild()
+
+	pl1 := plugin.New().ID(pid1).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("a").Type(plugin.ExtensionTypeInfoboxBlock).Schema(ps1.ID()).MustBuild(),
+		plugin.NewExtension().ID("b").Type(plugin.ExtensionTypeStoryBlock).Schema(psb.ID()).MustBuild(),
+	}).MustBuild()
+	pl2 := plugin.New().ID(pid2).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("a").Type(plugin.ExtensionTypeInfoboxBlock).Schema(ps2.ID()).MustBuild(),
+	}).MustBuild()
+
+	newProperty := func(ps id.PropertySchemaID, x, y string) *property.Property {
+		return property.New().NewID().Scene(sid).Schema(ps).Items([]property.Item{
+			property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+				property.NewField("x").Value(property.OptionalValueFrom(property.ValueTypeString.ValueFrom(x))).Build(),
+				property.NewField("y").Value(property.OptionalValueFrom(property.ValueTypeString.ValueFrom(y))).Build(),
+			}).MustBuild(),
+		}).MustBuild()
+	}
+	ibp := newProperty(ps1.ID(), "1", "a")
+	sbp1 := newProperty(ps1.ID(), "x", "b")
+	sbp2 := property.New().NewID().Scene(sid).Schema(psb.ID()).MustBuild()
+
+	ib := nlslayer.NewInfoboxBlock().NewID().Plugin(pid1).Extension("a").Property(ibp.ID()).MustBuild()
+	layer := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).LayerType(nlslayer.Simple).
+		Infobox(nlslayer.NewInfobox([]*nlslayer.InfoboxBlock{ib}, id.NewPropertyID())).MustBuild()
+
+	sb1 := storytelling.NewBlock().NewID().Plugin(pid1).Extension("a").Property(sbp1.ID()).MustBuild()
+	sb2 := storytelling.NewBlock().NewID().Plugin(pid1).Extension("b").Property(sbp2.ID()).MustBuild()
+	page := storytelling.NewPage().NewID().Blocks(storytelling.BlockList{sb1, sb2}).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sid).Pages(storytelling.NewPageList([]*storytelling.Page{page})).MustBuild()
+
+	sc := scene.New().ID(sid).Workspace(accountsID.NewWorkspaceID()).MustBuild()
+	sc.Plugins().Add(scene.NewPlugin(pid1, nil))
+
+	pm := PluginMigrator{
+		Plugin:         plugin.LoaderFrom(pl1, pl2),
+		Property:       property.LoaderFrom([]*property.Property{ibp, sbp1, sbp2}),
+		PropertySchema: property.SchemaLoaderFrom(ps1, ps2, psb),
+		NLSLayer:       nlslayer.LoaderBySceneFrom(layer),
+		Story:          storytelling.LoaderBySceneFrom(story),
+	}
+
+	result, err := pm.MigratePlugins(ctx, sc, pid1, pid2)
+	assert.NoError(t, err)
+
+	assert.Equal(t, pid2, ib.Plugin())
+	// the page builder clones the blocks
+	require.Equal(t, 1, len(page.Blocks()))
+	assert.Equal(t, sb1.ID(), page.Blocks()[0].ID())
+	assert.Equal(t, pid2, page.Blocks()[0].Plugin())
+	assert.Equal(t, id.PropertyIDList{sbp2.ID()}, result.RemovedProperties)
+	assert.Equal(t, 1, len(result.NLSLayers))
+	assert.Equal(t, storytelling.StoryList{story}, result.Stories)
+
+	assert.Equal(t, 2, len(result.Properties))
+	assert.Equal(t, ibp.ID(), result.Properties[0].ID())
+	assert.Equal(t, ps2.ID(), result.Properties[0].Schema())
+	f, _, _ := result.Properties[0].Field(property.PointFieldBySchemaGroup("default", "x"))
+	assert.Equal(t, property.ValueTypeNumber.ValueFrom(1.0), f.Value())
+
+	assert.Equal(t, map[id.PropertyID][]property.DroppedField{
+		ibp.ID(): {
+			{
+				Pointer: property.PointFieldBySchemaGroup("default", "y"),
+				Value:   property.OptionalValueFrom(property.ValueTypeString.ValueFrom("a")),
+				Reason:  property.DropReasonDeleted,
+			},
+		},
+		sbp1.ID(): {
+			{
+				Pointer: property.PointFieldBySchemaGroup("default", "x"),
+				Value:   property.OptionalValueFrom(property.ValueTypeString.ValueFrom("x")),
+				Reason:  property.DropReasonCastFailed,
+			},
+			{
+				Pointer: property.PointFieldBySchemaGroup("default", "y"),
+				Value:   property.OptionalValueFrom(property.ValueTypeString.ValueFrom("b")),
+				Reason:  property.DropReasonDeleted,
+			},
+		},
+	}, result.DroppedFields)
+}
@@ -0,0 +1,187 @@
+package sceneops
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/plugin/manifest"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+)
+
+// PluginUpgradeReport describes what MigratePlugins would do to a scene.
+type PluginUpgradeReport struct {
+	Diff    manifest.Diff
+	Targets []PluginUpgradeTarget
+}
+
+type PluginUpgradeTargetType string
+
+const (
+	PluginUpgradeTargetScenePlugin  PluginUpgradeTargetType = "scenePlugin"
+	PluginUpgradeTargetWidget       PluginUpgradeTargetType = "widget"
+	PluginUpgradeTargetInfoboxBlock PluginUpgradeTargetType = "infoboxBlock"
+	PluginUpgradeTargetStoryBlock   PluginUpgradeTargetType = "storyBlock"
+)
+
+// PluginUpgradeTarget is the scene plugin, a widget or a block that uses the plugin being upgraded.
+type PluginUpgradeTarget struct {
+	Type PluginUpgradeTargetType
+	// ID is the ID of the widget or the block, or the plugin ID for the scene plugin.
+	ID        string
+	Extension *id.PluginExtensionID
+	Property  *id.PropertyID
+	Layer     *id.NLSLayerID
+	Story     *id.StoryID
+	Page      *id.PageID
+	// Removed is true when the new plugin no longer has the extension, so the target and its property will be removed.
+	Removed bool
+	// Dropped are the values of the property that the migration will not be able to keep.
+	Dropped []property.DroppedField
+}
+
+// Report computes the upgrade from oldPluginID to newPluginID without changing the scene, its blocks or their properties.
+func (s *PluginMigrator) Report(ctx context.Context, sc *scene.Scene, oldPluginID, newPluginID id.PluginID) (*PluginUpgradeReport, error) {
+	oldPlugin, newPlugin, err := s.loadPlugins(ctx, sc, oldPluginID, newPluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := s.loadSchemas(ctx, oldPlugin, newPlugin)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PluginUpgradeReport{
+		Diff: manifest.DiffFrom(manifestFrom(oldPlugin, schemas), manifestFrom(newPlugin, schemas)),
+	}
+	removed := func(e id.PluginExtensionID) bool {
+		return newPlugin.Extension(e) == nil
+	}
+
+	if sp := sc.Plugins().Plugin(oldPluginID); sp != nil && sp.Property() != nil {
+		report.Targets = append(report.Targets, PluginUpgradeTarget{
+			Type:     PluginUpgradeTargetScenePlugin,
+			ID:       oldPluginID.String(),
+			Property: sp.Property(),
+			Removed:  report.Diff.PropertySchemaDeleted,
+		})
+	}
+
+	for _, w := range sc.Widgets().Widgets() {
+		if !w.Plugin().Equal(oldPluginID) {
+			continue
+		}
+		report.Targets = append(report.Targets, PluginUpgradeTarget{
+			Type:      PluginUpgradeTargetWidget,
+			ID:        w.ID().String(),
+			Extension: w.Extension().Ref(),
+			Property:  w.Property().Ref(),
+			Removed:   removed(w.Extension()),
+		})
+	}
+
+	if s.NLSLayer != nil {
+		layers, err := s.NLSLayer(ctx, sc.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range layers {
+			if l == nil || !(*l).HasInfobox() {
+				continue
+			}
+			for _, b := range (*l).Infobox().Blocks() {
+				if !b.Plugin().Equal(oldPluginID) {
+					continue
+				}
+				report.Targets = append(report.Targets, PluginUpgradeTarget{
+					Type:      PluginUpgradeTargetInfoboxBlock,
+					ID:        b.ID().String(),
+					Extension: b.Extension().Ref(),
+					Property:  b.PropertyRef(),
+					Layer:     (*l).ID().Ref(),
+					Removed:   removed(b.Extension()),
+				})
+			}
+		}
+	}
+
+	if s.Story != nil {
+		stories, err := s.Story(ctx, sc.ID())
+		if err != nil {
+			return nil, err
+		}
+		if stories != nil {
+			for _, st := range *stories {
+				for _, page := range st.Pages().Pages() {
+					for _, b := range page.BlocksByPlugin(oldPluginID, nil) {
+						report.Targets = append(report.Targets, PluginUpgradeTarget{
+							Type:      PluginUpgradeTargetStoryBlock,
+							ID:        b.ID().String(),
+							Extension: b.Extension().Ref(),
+							Property:  b.PropertyRef(),
+							Story:     st.Id().Ref(),
+							Page:      page.Id().Ref(),
+							Removed:   removed(b.Extension()),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	if err := s.reportDroppedFields(ctx, report, migratorsFrom(oldPlugin, newPlugin, schemas)); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// reportDroppedFields runs the property migrations of the targets that will be kept. Migrator.Migrate returns
+// new properties, so the loaded properties are left untouched.
+func (s *PluginMigrator) reportDroppedFields(ctx context.Context, report *PluginUpgradeReport, migrators map[id.PropertySchemaID]property.Migrator) error {
+	propertyIDs := id.PropertyIDList{}
+	for _, t := range report.Targets {
+		if !t.Removed && t.Property != nil {
+			propertyIDs = append(propertyIDs, *t.Property)
+		}
+	}
+	if len(propertyIDs) == 0 {
+		return nil
+	}
+
+	properties, err := s.Property(ctx, propertyIDs...)
+	if err != nil {
+		return err
+	}
+
+	dropped := map[id.PropertyID][]property.DroppedField{}
+	for _, p := range properties {
+		if p == nil {
+			continue
+		}
+		if m, ok := migrators[p.Schema()]; ok {
+			_, dropped[p.ID()] = m.Migrate(p)
+		}
+	}
+
+	for i, t := range report.Targets {
+		if !t.Removed && t.Property != nil {
+			report.Targets[i].Dropped = dropped[*t.Property]
+		}
+	}
+	return nil
+}
+
+func manifestFrom(p *plugin.Plugin, schemas property.SchemaList) manifest.Manifest {
+	m := manifest.Manifest{Plugin: p}
+	if ps := p.Schema(); ps != nil {
+		m.Schema = schemas.Find(*ps)
+	}
+	for _, e := range p.Extensions() {
+		if es := schemas.Find(e.Schema()); es != nil {
+			m.ExtensionSchema = append(m.ExtensionSchema, es)
+		}
+	}
+	return m
+}
@@ -0,0 +1,119 @@
+package sceneops
+
+import (
+	"context"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/nlslayer"
+	"github.com/reearth/reearth/server/pkg/plugin"
+	"github.com/reearth/reearth/server/pkg/property"
+	"github.com/reearth/reearth/server/pkg/scene"
+	"github.com/reearth/reearth/server/pkg/storytelling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginMigrator_Report(t *testing.T) {
+	ctx := context.Background()
+
+	sid := id.NewSceneID()
+	pid1 := id.MustPluginID("plugin~1.0.0")
+	pid2 := id.MustPluginID("plugin~1.0.1")
+
+	ps1 := property.NewSchema().ID(id.NewPropertySchemaID(pid1, "a")).Groups(property.NewSchemaGroupList([]*property.SchemaGroup{
+		property.NewSchemaGroup().ID("default").Fields([]*property.SchemaField{
+			property.NewSchemaField().ID("x").Type(property.ValueTypeString).MustBuild(),
+		}).MustBuild(),
+	})).MustBuild()
+	ps2 := property.NewSchema().ID(id.NewPropertySchemaID(pid2, "a")).Groups(property.NewSchemaGroupList([]*property.SchemaGroup{
+		property.NewSchemaGroup().ID("default").Fields([]*property.SchemaField{
+			property.NewSchemaField().ID("x").Type(property.ValueTypeNumber).MustBuild(),
+		}).MustBuild(),
+	})).MustBuild()
+	psb := property.NewSchema().ID(id.NewPropertySchemaID(pid1, "b")).MustBuild()
+
+	pl1 := plugin.New().ID(pid1).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("a").Type(plugin.ExtensionTypeWidget).Schema(ps1.ID()).MustBuild(),
+		plugin.NewExtension().ID("b").Type(plugin.ExtensionTypeStoryBlock).Schema(psb.ID()).MustBuild(),
+	}).MustBuild()
+	pl2 := plugin.New().ID(pid2).Extensions([]*plugin.Extension{
+		plugin.NewExtension().ID("a").Type(plugin.ExtensionTypeWidget).Schema(ps2.ID()).MustBuild(),
+	}).MustBuild()
+
+	wp := property.New().NewID().Scene(sid).Schema(ps1.ID()).Items([]property.Item{
+		property.NewGroup().NewID().SchemaGroup("default").Fields([]*property.Field{
+			property.NewField("x").Value(property.OptionalValueFrom(property.ValueTypeString.ValueFrom("abc"))).Build(),
+		}).MustBuild(),
+	}).MustBuild()
+	ibp := property.New().NewID().Scene(sid).Schema(ps1.ID()).MustBuild()
+	sbp := property.New().NewID().Scene(sid).Schema(psb.ID()).MustBuild()
+
+	wid := id.NewWidgetID()
+	sc := scene.New().ID(sid).Workspace(accountsID.NewWorkspaceID()).Widgets(scene.NewWidgets([]*scene.Widget{
+		scene.MustWidget(wid, pid1, "a", wp.ID(), true, false),
+	}, nil)).MustBuild()
+	sc.Plugins().Add(scene.NewPlugin(pid1, nil))
+
+	ib := nlslayer.NewInfoboxBlock().NewID().Plugin(pid1).Extension("a").Property(ibp.ID()).MustBuild()
+	layer := nlslayer.NewNLSLayerSimple().NewID().Scene(sid).LayerType(nlslayer.Simple).
+		Infobox(nlslayer.NewInfobox([]*nlslayer.InfoboxBlock{ib}, id.NewPropertyID())).MustBuild()
+	sb := storytelling.NewBlock().NewID().Plugin(pid1).Extension("b").Property(sbp.ID()).MustBuild()
+	page := storytelling.NewPage().NewID().Blocks(storytelling.BlockList{sb}).MustBuild()
+	story := storytelling.NewStory().NewID().Scene(sid).Pages(storytelling.NewPageList([]*storytelling.Page{page})).MustBuild()
+
+	pm := PluginMigrator{
+		Plugin:         plugin.LoaderFrom(pl1, pl2),
+		Property:       property.LoaderFrom([]*property.Property{wp, ibp, sbp}),
+		PropertySchema: property.SchemaLoaderFrom(ps1, ps2, psb),
+		NLSLayer:       nlslayer.LoaderBySceneFrom(layer),
+		Story:          storytelling.LoaderBySceneFrom(story),
+	}
+
+	report, err := pm.Report(ctx, sc, pid1, pid2)
+	require.NoError(t, err)
+
+	assert.Equal(t, pid1, report.Diff.From)
+	assert.Equal(t, pid2, report.Diff.To)
+	assert.Equal(t, 1, len(report.Diff.DeletedExtensions))
+	assert.Equal(t, 1, len(report.Diff.UpdatedExtensions))
+
+	assert.Equal(t, []PluginUpgradeTarget{
+		{
+			Type:      PluginUpgradeTargetWidget,
+			ID:        wid.String(),
+			Extension: id.PluginExtensionID("a").Ref(),
+			Property:  wp.ID().Ref(),
+			Dropped: []property.DroppedField{
+				{
+					Pointer: property.PointFieldBySchemaGroup("default", "x"),
+					Value:   property.OptionalValueFrom(property.ValueTypeString.ValueFrom("abc")),
+					Reason:  property.DropReasonCastFailed,
+				},
+			},
+		},
+		{
+			Type:      PluginUpgradeTargetInfoboxBlock,
+			ID:        ib.ID().String(),
+			Extension: id.PluginExtensionID("a").Ref(),
+			Property:  ibp.ID().Ref(),
+			Layer:     layer.ID().Ref(),
+		},
+		{
+			Type:      PluginUpgradeTargetStoryBlock,
+			ID:        sb.ID().String(),
+			Extension: id.PluginExtensionID("b").Ref(),
+			Property:  sbp.ID().Ref(),
+			Story:     story.Id().Ref(),
+			Page:      page.Id().Ref(),
+			Removed:   true,
+		},
+	}, report.Targets)
+
+	// nothing is changed
+	assert.Equal(t, pid1, sc.Widgets().Widget(wid).Plugin())
+	assert.Equal(t, pid1, ib.Plugin())
+	assert.Equal(t, storytelling.BlockList{sb}, page.Blocks())
+	assert.Equal(t, ps1.ID(), wp.Schema())
+}
@@ -0,0 +1,375 @@
+package shp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPRJ            = errors.New("invalid prj")
+	ErrUnsupportedProjection = errors.New("unsupported projection")
+)
+
+// Projection converts coordinates of the CRS described by a .prj file into WGS84 longitude and latitude.
+// Geographic CRSs are treated as WGS84 and datum shifts are ignored, which is accurate enough
+// for WGS84-compatible datums such as JGD2000, JGD2011, GDA94, ETRS89 and NAD83.
+type Projection struct {
+	kind          string
+	a             float64 // semi-major axis
+	e2            float64 // squared eccentricity
+	k0            float64 // scale factor
+	lat0          float64 // latitude of origin in radians
+	lon0          float64 // central meridian in radians
+	falseEasting  float64
+	falseNorthing float64
+	unit          float64 // linear unit in meters
+}
+
+const (
+	projectionGeographic         = "geographic"
+	projectionTransverseMercator = "transverse_mercator"
+	projectionMercator           = "mercator"
+	projectionWebMercator        = "web_mercator"
+)
+
+// ParseProjection parses the WKT of a .prj file. An empty WKT is treated as WGS84.
+func ParseProjection(wkt string) (*Projection, error) {
+	if strings.TrimSpace(wkt) == "" {
+		return &Projection{kind: projectionGeographic}, nil
+	}
+
+	root, err := parseWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToUpper(root.name) {
+	case "GEOGCS", "GEOGCRS":
+		return &Projection{kind: projectionGeographic}, nil
+	case "PROJCS":
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProjection, root.name)
+	}
+
+	p := &Projection{
+		a:    6378137,
+		e2:   0.00669437999014,
+		k0:   1,
+		unit: 1,
+	}
+	if sph := root.find("SPHEROID"); sph != nil {
+		a, ok1 := sph.number(1)
+		invf, ok2 := sph.number(2)
+		if !ok1 || !ok2 {
+			return nil, ErrInvalidPRJ
+		}
+		p.a = a
+		if invf != 0 {
+			f := 1 / invf
+			p.e2 = 2*f - f*f
+		} else {
+			p.e2 = 0
+		}
+	}
+	if u := root.child("UNIT"); u != nil {
+		if v, ok := u.number(1); ok && v > 0 {
+			p.unit = v
+		}
+	}
+	for _, c := range root.children {
+		if !strings.EqualFold(c.name, "PARAMETER") {
+			continue
+		}
+		name, _ := c.str(0)
+		v, ok := c.number(1)
+		if !ok {
+			return nil, ErrInvalidPRJ
+		}
+		switch normalizeWKTName(name) {
+		case "false_easting":
+			p.falseEasting = v
+		case "false_northing":
+			p.falseNorthing = v
+		case "central_meridian", "longitude_of_center", "longitude_of_origin":
+			p.lon0 = v * math.Pi / 180
+		case "latitude_of_origin", "latitude_of_center":
+			p.lat0 = v * math.Pi / 180
+		case "scale_factor":
+			p.k0 = v
+		}
+	}
+
+	proj := root.child("PROJECTION")
+	if proj == nil {
+		return nil, ErrInvalidPRJ
+	}
+	name, _ := proj.str(0)
+	switch normalizeWKTName(name) {
+	case "transverse_mercator", "gauss_kruger":
+		p.kind = projectionTransverseMercator
+	case "mercator_auxiliary_sphere", "popular_visualisation_pseudo_mercator":
+		p.kind = projectionWebMercator
+	case "mercator", "mercator_1sp":
+		p.kind = projectionMercator
+		if p.e2 == 0 || isWebMercator(root) {
+			p.kind = projectionWebMercator
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProjection, name)
+	}
+	return p, nil
+}
+
+// IsGeographic returns true if the coordinates are already longitude and latitude.
+func (p *Projection) IsGeographic() bool {
+	return p == nil || p.kind == projectionGeographic
+}
+
+// ToWGS84 converts a coordinate of the projection into longitude and latitude in degrees.
+func (p *Projection) ToWGS84(x, y float64) (float64, float64) {
+	if p.IsGeographic() {
+		return x, y
+	}
+
+	// false easting and northing are in the linear unit of the projection
+	x = (x - p.falseEasting) * p.unit
+	y = (y - p.falseNorthing) * p.unit
+
+	var lon, lat float64
+	switch p.kind {
+	case projectionTransverseMercator:
+		lon, lat = p.inverseTransverseMercator(x, y)
+	case projectionWebMercator:
+		lon = p.lon0 + x/p.a
+		lat = math.Atan(math.Sinh(y / p.a))
+	case projectionMercator:
+		lon, lat = p.inverseMercator(x, y)
+	}
+	return lon * 180 / math.Pi, lat * 180 / math.Pi
+}
+
+// inverseTransverseMercator follows Snyder, Map Projections: A Working Manual, p.63.
+func (p *Projection) inverseTransverseMercator(x, y float64) (float64, float64) {
+	e2 := p.e2
+	e4, e6 := e2*e2, e2*e2*e2
+	ep2 := e2 / (1 - e2)
+
+	m := p.meridianArc(p.lat0) + y/p.k0
+	mu := m / (p.a * (1 - e2/4 - 3*e4/64 - 5*e6/256))
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+	phi1 := mu +
+		(3*e1/2-27*math.Pow(e1, 3)/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*math.Pow(e1, 4)/32)*math.Sin(4*mu) +
+		(151*math.Pow(e1, 3)/96)*math.Sin(6*mu) +
+		(1097*math.Pow(e1, 4)/512)*math.Sin(8*mu)
+
+	sin, cos, tan := math.Sin(phi1), math.Cos(phi1), math.Tan(phi1)
+	c1 := ep2 * cos * cos
+	t1 := tan * tan
+	n1 := p.a / math.Sqrt(1-e2*sin*sin)
+	r1 := p.a * (1 - e2) / math.Pow(1-e2*sin*sin, 1.5)
+	d := x / (n1 * p.k0)
+
+	lat := phi1 - (n1*tan/r1)*(d*d/2-
+		(5+3*t1+10*c1-4*c1*c1-9*ep2)*math.Pow(d, 4)/24+
+		(61+90*t1+298*c1+45*t1*t1-252*ep2-3*c1*c1)*math.Pow(d, 6)/720)
+	lon := p.lon0 + (d-
+		(1+2*t1+c1)*math.Pow(d, 3)/6+
+		(5-2*c1+28*t1-3*c1*c1+8*ep2+24*t1*t1)*math.Pow(d, 5)/120)/cos
+	return lon, lat
+}
+
+func (p *Projection) meridianArc(phi float64) float64 {
+	e2 := p.e2
+	e4, e6 := e2*e2, e2*e2*e2
+	return p.a * ((1-e2/4-3*e4/64-5*e6/256)*phi -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*phi) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*phi) -
+		(35*e6/3072)*math.Sin(6*phi))
+}
+
+func (p *Projection) inverseMercator(x, y float64) (float64, float64) {
+	e := math.Sqrt(p.e2)
+	t := math.Exp(-y / (p.a * p.k0))
+	lat := math.Pi/2 - 2*math.Atan(t)
+	for i := 0; i < 15; i++ {
+		es := e * math.Sin(lat)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-es)/(1+es), e/2))
+		if math.Abs(next-lat) < 1e-12 {
+			lat = next
+			break
+		}
+		lat = next
+	}
+	return p.lon0 + x/(p.a*p.k0), lat
+}
+
+// isWebMercator detects EPSG:3857, which is described as Mercator_1SP on the WGS84 ellipsoid although it uses spherical formulas.
+func isWebMercator(root *wktNode) bool {
+	if auth := root.child("AUTHORITY"); auth != nil {
+		if code, _ := auth.str(1); code == "3857" || code == "900913" {
+			return true
+		}
+	}
+	name, _ := root.str(0)
+	name = normalizeWKTName(name)
+	return strings.Contains(name, "pseudo-mercator") || strings.Contains(name, "pseudo_mercator") || strings.Contains(name, "web_mercator")
+}
+
+func normalizeWKTName(n string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(n), " ", "_"))
+}
+
+// wktNode is a node of a WKT such as PARAMETER["scale_factor",0.9996].
+type wktNode struct {
+	name     string
+	args     []string
+	children []*wktNode
+}
+
+func (n *wktNode) child(name string) *wktNode {
+	for _, c := range n.children {
+		if strings.EqualFold(c.name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *wktNode) find(name string) *wktNode {
+	if c := n.child(name); c != nil {
+		return c
+	}
+	for _, c := range n.children {
+		if f := c.find(name); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// str returns the i-th argument of the node. Quoted strings are unquoted.
+func (n *wktNode) str(i int) (string, bool) {
+	if i >= len(n.args) {
+		return "", false
+	}
+	return n.args[i], true
+}
+
+func (n *wktNode) number(i int) (float64, bool) {
+	s, ok := n.str(i)
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil
+}
+
+func parseWKT(s string) (*wktNode, error) {
+	w := &wktParser{s: s}
+	n, err := w.node()
+	if err != nil {
+		return nil, err
+	}
+	w.skipSpaces()
+	if w.pos != len(w.s) {
+		return nil, ErrInvalidPRJ
+	}
+	return n, nil
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (w *wktParser) skipSpaces() {
+	for w.pos < len(w.s) && strings.ContainsRune(" \t\r\n", rune(w.s[w.pos])) {
+		w.pos++
+	}
+}
+
+func (w *wktParser) node() (*wktNode, error) {
+	w.skipSpaces()
+	start := w.pos
+	for w.pos < len(w.s) && (isWKTLetter(w.s[w.pos])) {
+		w.pos++
+	}
+	n := &wktNode{name: w.s[start:w.pos]}
+	if n.name == "" {
+		return nil, ErrInvalidPRJ
+	}
+
+	w.skipSpaces()
+	if w.pos >= len(w.s) || (w.s[w.pos] != '[' && w.s[w.pos] != '(') {
+		return nil, ErrInvalidPRJ
+	}
+	closing := byte(']')
+	if w.s[w.pos] == '(' {
+		closing = ')'
+	}
+	w.pos++
+
+	for {
+		w.skipSpaces()
+		if w.pos >= len(w.s) {
+			return nil, ErrInvalidPRJ
+		}
+		switch c := w.s[w.pos]; {
+		case c == '"':
+			end := strings.IndexByte(w.s[w.pos+1:], '"')
+			if end < 0 {
+				return nil, ErrInvalidPRJ
+			}
+			n.args = append(n.args, w.s[w.pos+1:w.pos+1+end])
+			w.pos += end + 2
+		case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+			start := w.pos
+			for w.pos < len(w.s) && strings.IndexByte("+-.0123456789eE", w.s[w.pos]) >= 0 {
+				w.pos++
+			}
+			n.args = append(n.args, w.s[start:w.pos])
+		case isWKTLetter(c):
+			start := w.pos
+			for w.pos < len(w.s) && isWKTLetter(w.s[w.pos]) {
+				w.pos++
+			}
+			end := w.pos
+			w.skipSpaces()
+			if w.pos < len(w.s) && (w.s[w.pos] == '[' || w.s[w.pos] == '(') {
+				w.pos = start
+				child, err := w.node()
+				if err != nil {
+					return nil, err
+				}
+				n.children = append(n.children, child)
+			} else {
+				// enumeration such as AXIS["Easting",EAST]
+				n.args = append(n.args, w.s[start:end])
+			}
+		default:
+			return nil, ErrInvalidPRJ
+		}
+
+		w.skipSpaces()
+		if w.pos >= len(w.s) {
+			return nil, ErrInvalidPRJ
+		}
+		if w.s[w.pos] == ',' {
+			w.pos++
+			continue
+		}
+		if w.s[w.pos] == closing {
+			w.pos++
+			return n, nil
+		}
+		return nil, ErrInvalidPRJ
+	}
+}
+
+func isWKTLetter(c byte) bool {
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
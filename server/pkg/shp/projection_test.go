@@ -0,0 +1,78 @@
+package shp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProjection(t *testing.T) {
+	tests := []struct {
+		name    string
+		wkt     string
+		x, y    float64
+		lon     float64
+		lat     float64
+		wantErr error
+	}{
+		{
+			name: "empty",
+			wkt:  "",
+			x:    139.7, y: 35.6,
+			lon: 139.7, lat: 35.6,
+		},
+		{
+			name: "geographic",
+			wkt:  `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`,
+			x:    139.7, y: 35.6,
+			lon: 139.7, lat: 35.6,
+		},
+		{
+			// EPSG Guidance Note 7-2 example for British National Grid
+			name: "transverse mercator",
+			wkt: `PROJCS["OSGB 1936 / British National Grid",GEOGCS["OSGB 1936",DATUM["OSGB_1936",SPHEROID["Airy 1830",6377563.396,299.3249646]],PRIMEM["Greenwich",0],UNIT["degree",0.0174532925199433]],
+				PROJECTION["Transverse_Mercator"],PARAMETER["latitude_of_origin",49],PARAMETER["central_meridian",-2],PARAMETER["scale_factor",0.9996012717],
+				PARAMETER["false_easting",400000],PARAMETER["false_northing",-100000],UNIT["metre",1],AXIS["Easting",EAST],AXIS["Northing",NORTH]]`,
+			x: 577274.99, y: 69740.49,
+			lon: 0.5, lat: 50.5,
+		},
+		{
+			name: "utm",
+			wkt:  `PROJCS["WGS_1984_UTM_Zone_54N",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Transverse_Mercator"],PARAMETER["False_Easting",500000.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",141.0],PARAMETER["Scale_Factor",0.9996],PARAMETER["Latitude_Of_Origin",0.0],UNIT["Meter",1.0]]`,
+			x:    500000, y: 0,
+			lon: 141, lat: 0,
+		},
+		{
+			name: "web mercator",
+			wkt:  `PROJCS["WGS_1984_Web_Mercator_Auxiliary_Sphere",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Mercator_Auxiliary_Sphere"],PARAMETER["False_Easting",0.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",0.0],PARAMETER["Standard_Parallel_1",0.0],PARAMETER["Auxiliary_Sphere_Type",0.0],UNIT["Meter",1.0]]`,
+			x:    15551332.863820316, y: 4245720.660441586,
+			lon: 139.7, lat: 35.6,
+		},
+		{
+			name:    "unsupported",
+			wkt:     `PROJCS["x",GEOGCS["y",DATUM["z",SPHEROID["GRS 1980",6378137,298.257222101]]],PROJECTION["Lambert_Conformal_Conic_2SP"],UNIT["metre",1]]`,
+			wantErr: ErrUnsupportedProjection,
+		},
+		{
+			name:    "invalid",
+			wkt:     `PROJCS["x",`,
+			wantErr: ErrInvalidPRJ,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := ParseProjection(tt.wkt)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			lon, lat := p.ToWGS84(tt.x, tt.y)
+			assert.InDelta(t, tt.lon, lon, 1e-6)
+			assert.InDelta(t, tt.lat, lat, 1e-6)
+		})
+	}
+}
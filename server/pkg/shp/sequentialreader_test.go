@@ -17,9 +17,7 @@ func openFile(name string, t *testing.T) *os.File {
 
 func getShapesSequentially(prefix string, t *testing.T) (shapes []Shape) {
 	shp := openFile(prefix+".shp", t)
-	// dbf := openFile(prefix+".dbf", t)
-
-	sr := SequentialReaderFromExt(shp /*, dbf*/)
+	sr := SequentialReaderFromExt(shp, nil)
 	err := sr.Err()
 	assert.Nil(t, err, "Error when iterating over the shapefile header")
 
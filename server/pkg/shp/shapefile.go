@@ -3,6 +3,7 @@ package shp
 import (
 	"encoding/binary"
 	"io"
+	"strings"
 )
 
 //go:generate stringer -type=ShapeType
@@ -1024,7 +1025,6 @@ type Field struct {
 	Padding   [14]byte
 }
 
-/* Note: not used
 // Returns a string representation of the Field. Currently
 // this only returns field name.
 func (f Field) String() string {
@@ -1063,4 +1063,12 @@ func DateField(name string) Field {
 	field := Field{Fieldtype: 'D', Size: 8}
 	copy(field.Name[:], []byte(name))
 	return field
-}*/
+}
+
+// LogicalField returns a Field that can be used in SetFields to initialize the
+// DBF file. Used to store booleans as "T" or "F".
+func LogicalField(name string) Field {
+	field := Field{Fieldtype: 'L', Size: 1}
+	copy(field.Name[:], []byte(name))
+	return field
+}
@@ -1,17 +1,28 @@
 package shp
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
 	"math"
+	"strconv"
+	"time"
 )
 
 // Writer is the type that is used to write a new shapefile.
 type Writer struct {
 	shp          io.WriteSeeker
+	shx          io.WriteSeeker
 	GeometryType ShapeType
 	num          int32
 	bbox         Box
+
+	dbf             io.WriteSeeker
+	dbfFields       []Field
+	dbfHeaderLength int16
+	dbfRecordLength int16
 }
 
 func CreateFrom(ws io.WriteSeeker, t ShapeType) (*Writer, error) {
@@ -26,6 +37,111 @@ func CreateFrom(ws io.WriteSeeker, t ShapeType) (*Writer, error) {
 	return w, nil
 }
 
+// CreateWithIndexFrom creates a Writer that also writes the .shx index of the shapes to shx.
+func CreateWithIndexFrom(ws, shx io.WriteSeeker, t ShapeType) (*Writer, error) {
+	w, err := CreateFrom(ws, t)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := shx.Seek(100, io.SeekStart); err != nil {
+		return nil, err
+	}
+	w.shx = shx
+	return w, nil
+}
+
+// SetFields sets the fields of the DBF table written to dbf. It must be called only once.
+func (w *Writer) SetFields(dbf io.WriteSeeker, fields []Field) error {
+	if w.dbf != nil {
+		return errors.New("Cannot set fields in existing dbf")
+	}
+
+	w.dbf = dbf
+	w.dbfFields = fields
+
+	// calculate record length
+	w.dbfRecordLength = int16(1)
+	for _, field := range w.dbfFields {
+		w.dbfRecordLength += int16(field.Size)
+	}
+
+	// header length
+	w.dbfHeaderLength = int16(len(w.dbfFields)*32 + 33)
+
+	// fill header space with empty bytes for now
+	if _, err := w.dbf.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := binary.Write(w.dbf, binary.LittleEndian, make([]byte, w.dbfHeaderLength)); err != nil {
+		return err
+	}
+
+	// write empty records
+	for n := int32(0); n < w.num; n++ {
+		if err := w.writeEmptyRecord(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEmptyRecord writes a record of spaces to the end of the DBF.
+func (w *Writer) writeEmptyRecord() error {
+	if _, err := w.dbf.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	return binary.Write(w.dbf, binary.LittleEndian, bytes.Repeat([]byte{' '}, int(w.dbfRecordLength)))
+}
+
+// WriteAttribute writes value for field into the given row in the DBF.
+// Supported values are int, float64, string and bool. Numbers are right-aligned and the others are left-aligned.
+func (w *Writer) WriteAttribute(row int, field int, value interface{}) error {
+	if w.dbf == nil {
+		return errors.New("Initialize DBF by using SetFields first")
+	}
+	if field < 0 || field >= len(w.dbfFields) {
+		return fmt.Errorf("Unable to write field %v: field does not exist", field)
+	}
+
+	f := w.dbfFields[field]
+	var buf []byte
+	switch v := value.(type) {
+	case int:
+		buf = []byte(strconv.Itoa(v))
+	case string:
+		buf = []byte(v)
+	case float64:
+		buf = []byte(strconv.FormatFloat(v, 'f', int(f.Precision), 64))
+	case bool:
+		buf = []byte("F")
+		if v {
+			buf = []byte("T")
+		}
+	default:
+		return fmt.Errorf("Unsupported value type: %T", v)
+	}
+
+	sz := int(f.Size)
+	if len(buf) > sz {
+		return fmt.Errorf("Unable to write field %v: %q exceeds field length %v", field, buf, sz)
+	}
+	padding := bytes.Repeat([]byte{' '}, sz-len(buf))
+	if f.Fieldtype == 'N' || f.Fieldtype == 'F' {
+		buf = append(padding, buf...)
+	} else {
+		buf = append(buf, padding...)
+	}
+
+	seekTo := 1 + int64(w.dbfHeaderLength) + (int64(row) * int64(w.dbfRecordLength))
+	for n := 0; n < field; n++ {
+		seekTo += int64(w.dbfFields[n].Size)
+	}
+	if _, err := w.dbf.Seek(seekTo, io.SeekStart); err != nil {
+		return err
+	}
+	return binary.Write(w.dbf, binary.LittleEndian, buf)
+}
+
 // Write shape to the writer.
 // Returns the index of the written object
 // which can be used in WriteAttribute.
@@ -75,12 +191,76 @@ func (w *Writer) Write(shape Shape) (int32, error) {
 	if err != nil {
 		return 0, err
 	}
+
+	if w.shx != nil {
+		// offset of the record header and length of the content in 16-bit words
+		err = binary.Write(w.shx, binary.BigEndian, []int32{int32((start - 8) / 2), length})
+		if err != nil {
+			return 0, err
+		}
+	}
+	if w.dbf != nil {
+		if err := w.writeEmptyRecord(); err != nil {
+			return 0, err
+		}
+	}
 	return w.num - 1, nil
 }
 
 // Close closes the writer.
 func (w *Writer) Close() error {
-	return w.writeHeader(w.shp)
+	if err := w.writeHeader(w.shp); err != nil {
+		return err
+	}
+	if w.shx != nil {
+		if err := w.writeHeader(w.shx); err != nil {
+			return err
+		}
+	}
+	if w.dbf != nil {
+		return w.writeDbfHeader()
+	}
+	return nil
+}
+
+// writeDbfHeader writes the DBF header and the end-of-file marker.
+func (w *Writer) writeDbfHeader() error {
+	if _, err := w.dbf.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	if _, err := w.dbf.Write([]byte{0x1a}); err != nil {
+		return err
+	}
+
+	if _, err := w.dbf.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	// version, year (YEAR-1900), month, day
+	now := time.Now()
+	err := binary.Write(w.dbf, binary.LittleEndian, []byte{3, byte(now.Year() - 1900), byte(now.Month()), byte(now.Day())})
+	if err != nil {
+		return err
+	}
+	// number of records
+	if err := binary.Write(w.dbf, binary.LittleEndian, w.num); err != nil {
+		return err
+	}
+	// header length, record length
+	if err := binary.Write(w.dbf, binary.LittleEndian, []int16{w.dbfHeaderLength, w.dbfRecordLength}); err != nil {
+		return err
+	}
+	// padding
+	if err := binary.Write(w.dbf, binary.LittleEndian, make([]byte, 20)); err != nil {
+		return err
+	}
+	for _, field := range w.dbfFields {
+		if err := binary.Write(w.dbf, binary.LittleEndian, field); err != nil {
+			return err
+		}
+	}
+	// end with return
+	_, err = w.dbf.Write([]byte("\r"))
+	return err
 }
 
 // writeHeader writes SHP to ws.
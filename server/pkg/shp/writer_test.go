@@ -1,10 +1,13 @@
 package shp
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"testing"
 
+	"github.com/reearth/reearth/server/pkg/writer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -151,3 +154,48 @@ func TestWriter(t *testing.T) {
 	}
 
 }
+
+func TestWriter_IndexAndAttributes(t *testing.T) {
+	shpw, shxw, dbfw := &writer.WriterSeeker{}, &writer.WriterSeeker{}, &writer.WriterSeeker{}
+
+	w, err := CreateWithIndexFrom(shpw, shxw, POINT)
+	assert.NoError(t, err)
+	assert.NoError(t, w.SetFields(dbfw, []Field{
+		StringField("NAME", 10),
+		NumberField("COUNT", 5),
+		FloatField("RATIO", 8, 2),
+		LogicalField("OPEN"),
+	}))
+
+	for i, p := range []Point{{X: 1, Y: 2}, {X: 3, Y: 4}} {
+		row, err := w.Write(&Point{X: p.X, Y: p.Y})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(i), row)
+	}
+	assert.NoError(t, w.WriteAttribute(0, 0, "gate"))
+	assert.NoError(t, w.WriteAttribute(0, 1, 12))
+	assert.NoError(t, w.WriteAttribute(0, 2, 1.5))
+	assert.NoError(t, w.WriteAttribute(0, 3, true))
+	assert.NoError(t, w.WriteAttribute(1, 0, "road"))
+	assert.Error(t, w.WriteAttribute(1, 0, "too long for the field"))
+	assert.Error(t, w.WriteAttribute(1, 4, "no field"))
+	assert.NoError(t, w.Close())
+
+	// 100 bytes header and 8 bytes for each record
+	assert.Len(t, shxw.Buffer(), 116)
+
+	sr := SequentialReaderFromExt(io.NopCloser(bytes.NewReader(shpw.Buffer())), io.NopCloser(bytes.NewReader(dbfw.Buffer())))
+	assert.NoError(t, sr.Err())
+	assert.Equal(t, []string{"NAME", "COUNT", "RATIO", "OPEN"}, []string{sr.Fields()[0].String(), sr.Fields()[1].String(), sr.Fields()[2].String(), sr.Fields()[3].String()})
+
+	assert.True(t, sr.Next())
+	_, s := sr.Shape()
+	assert.Equal(t, &Point{X: 1, Y: 2}, s)
+	assert.Equal(t, []string{"gate", "12", "1.50", "T"}, Attributes(sr))
+
+	assert.True(t, sr.Next())
+	assert.Equal(t, []string{"road", "", "", ""}, Attributes(sr))
+
+	assert.False(t, sr.Next())
+	assert.NoError(t, sr.Err())
+}
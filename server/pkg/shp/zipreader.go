@@ -5,13 +5,16 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"path"
 	"strings"
 )
 
 // ZipReader provides an interface for reading Shapefiles that are compressed in a ZIP archive.
 type ZipReader struct {
-	sr SequentialReader
-	z  *zip.Reader
+	sr  SequentialReader
+	z   *zip.Reader
+	prj string
+	cpg string
 }
 
 // openFromZIP is convenience function for opening the file called name that is
@@ -50,18 +53,49 @@ func ReadZipFrom(r io.Reader) (*ZipReader, error) {
 	if err != nil {
 		return nil, err
 	}
-	/* Note: not used
-	withoutExt := strings.TrimSuffix(shapeFiles[0].Name, ".shp")
-	// dbf is optional, so no error checking here
-	dbf, _ := openFromZIP(zr.z, withoutExt+".dbf")*/
-	zr.sr = SequentialReaderFromExt(shp /*, dbf*/)
+	withoutExt := strings.TrimSuffix(shapeFiles[0].Name, path.Ext(shapeFiles[0].Name))
+	// dbf, prj and cpg are optional, so no error checking here
+	dbf, _ := openFromZIP(zr.z, sidecarName(reader, withoutExt, ".dbf"))
+	if zr.prj, err = readSidecar(reader, withoutExt, ".prj"); err != nil {
+		return nil, err
+	}
+	if zr.cpg, err = readSidecar(reader, withoutExt, ".cpg"); err != nil {
+		return nil, err
+	}
+	zr.sr = SequentialReaderFromExt(shp, dbf)
 	return zr, nil
 }
 
+// readSidecar returns the trimmed content of an optional text file next to the .shp file.
+func readSidecar(z *zip.Reader, withoutExt, ext string) (string, error) {
+	f, err := openFromZIP(z, sidecarName(z, withoutExt, ext))
+	if err != nil {
+		return "", nil
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// sidecarName returns the name of the file in z that has the basename withoutExt and the extension ext, ignoring the case of the extension.
+func sidecarName(z *zip.Reader, withoutExt, ext string) string {
+	for _, f := range z.File {
+		if strings.TrimSuffix(f.Name, path.Ext(f.Name)) == withoutExt && strings.EqualFold(path.Ext(f.Name), ext) {
+			return f.Name
+		}
+	}
+	return withoutExt + ext
+}
+
 func shapesInZip(z *zip.Reader) []*zip.File {
 	var shapeFiles []*zip.File
 	for _, f := range z.File {
-		if strings.HasSuffix(f.Name, ".shp") {
+		if strings.EqualFold(path.Ext(f.Name), ".shp") {
 			shapeFiles = append(shapeFiles, f)
 		}
 	}
@@ -88,7 +122,6 @@ func (zr *ZipReader) Shape() (int, Shape) {
 	return zr.sr.Shape()
 }
 
-/* Note: not used
 // Attribute returns the n-th field of the last row that was read. If there
 // were any errors before, the empty string is returned.
 func (zr *ZipReader) Attribute(n int) string {
@@ -99,7 +132,17 @@ func (zr *ZipReader) Attribute(n int) string {
 // DBF table.
 func (zr *ZipReader) Fields() []Field {
 	return zr.sr.Fields()
-}*/
+}
+
+// Projection returns the WKT in the .prj file of the archive, or the empty string if there is no .prj file.
+func (zr *ZipReader) Projection() string {
+	return zr.prj
+}
+
+// CodePage returns the character encoding of the DBF in the .cpg file of the archive, or the empty string if there is no .cpg file.
+func (zr *ZipReader) CodePage() string {
+	return zr.cpg
+}
 
 // Err returns the last non-EOF error that was encountered by this ZipReader.
 func (zr *ZipReader) Err() error {
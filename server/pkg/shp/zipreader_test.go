@@ -82,3 +82,36 @@ func TestReadZipFromClosedReader(t *testing.T) {
 	_, err = ReadZipFrom(ior)
 	assert.NotNil(t, err)
 }
+
+func TestReadZipFrom_Attributes(t *testing.T) {
+	ior, err := os.Open("test_files/ne_110m_admin_0_countries.zip")
+	assert.Nil(t, err)
+	defer func() {
+		err := ior.Close()
+		assert.Nil(t, err)
+	}()
+
+	zr, err := ReadZipFrom(ior)
+	assert.Nil(t, err)
+	defer func() {
+		err := zr.Close()
+		assert.Nil(t, err)
+	}()
+
+	assert.Contains(t, zr.Projection(), `GEOGCS["GCS_WGS_1984"`)
+	assert.Equal(t, "UTF-8", zr.CodePage())
+
+	fields := zr.Fields()
+	assert.NotEmpty(t, fields)
+	name := -1
+	for i, f := range fields {
+		if f.String() == "NAME" {
+			name = i
+		}
+	}
+	assert.NotEqual(t, -1, name)
+
+	assert.True(t, zr.Next())
+	assert.NotEmpty(t, zr.Attribute(name))
+	assert.Equal(t, "", zr.Attribute(len(fields)))
+}
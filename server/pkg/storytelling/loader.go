@@ -0,0 +1,19 @@
+package storytelling
+
+import (
+	"context"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/samber/lo"
+)
+
+type LoaderByScene func(context.Context, id.SceneID) (*StoryList, error)
+
+func LoaderBySceneFrom(data ...*Story) LoaderByScene {
+	return func(ctx context.Context, id id.SceneID) (*StoryList, error) {
+		res := StoryList(lo.Filter(data, func(s *Story, _ int) bool {
+			return s.Scene() == id
+		}))
+		return &res, nil
+	}
+}
@@ -22,6 +22,7 @@ type Story struct {
 	project       id.ProjectID
 	scene         id.SceneID
 	title         string
+	index         int
 	pages         *PageList
 	panelPosition Position
 	bgColor       string
@@ -32,6 +33,7 @@ type Story struct {
 	alias             string
 	status            PublishmentStatus
 	publishedAt       *time.Time
+	publishedVersion  int
 	publicTitle       string
 	publicDescription string
 	publicImage       string
@@ -71,6 +73,11 @@ func (s *Story) Title() string {
 	return s.title
 }
 
+// Index is the position of the story among the stories of the scene.
+func (s *Story) Index() int {
+	return s.index
+}
+
 func (s *Story) CreatedAt() time.Time {
 	return s.id.Timestamp()
 }
@@ -87,6 +94,10 @@ func (s *Story) SetPanelPosition(panelPosition Position) {
 	s.panelPosition = panelPosition
 }
 
+func (s *Story) SetIndex(index int) {
+	s.index = index
+}
+
 func (s *Story) SetBgColor(bgColor string) {
 	s.bgColor = bgColor
 }
@@ -161,6 +172,11 @@ func (s *Story) PublishedAt() *time.Time {
 	return s.publishedAt
 }
 
+// PublishedVersion is the number of the publication version the alias serves. Zero means it has no versions.
+func (s *Story) PublishedVersion() int {
+	return s.publishedVersion
+}
+
 func (s *Story) IsBasicAuthActive() bool {
 	return s.isBasicAuthActive
 }
@@ -219,6 +235,10 @@ func (s *Story) SetPublishedAt(now time.Time) {
 	s.publishedAt = &now
 }
 
+func (s *Story) SetPublishedVersion(version int) {
+	s.publishedVersion = version
+}
+
 func (s *Story) SetPublicTitle(publicTitle string) {
 	s.publicTitle = publicTitle
 }
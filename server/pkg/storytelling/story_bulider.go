@@ -86,6 +86,11 @@ func (b *StoryBuilder) Title(title string) *StoryBuilder {
 	return b
 }
 
+func (b *StoryBuilder) Index(index int) *StoryBuilder {
+	b.s.index = index
+	return b
+}
+
 func (b *StoryBuilder) UpdatedAt(at time.Time) *StoryBuilder {
 	b.s.updatedAt = at
 	return b
@@ -108,6 +113,11 @@ func (b *StoryBuilder) PublishedAt(t *time.Time) *StoryBuilder {
 	return b
 }
 
+func (b *StoryBuilder) PublishedVersion(version int) *StoryBuilder {
+	b.s.publishedVersion = version
+	return b
+}
+
 func (b *StoryBuilder) PublicTitle(title string) *StoryBuilder {
 	b.s.publicTitle = title
 	return b
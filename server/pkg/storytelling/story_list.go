@@ -1,3 +1,76 @@
 package storytelling
 
+import (
+	"sort"
+
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
 type StoryList []*Story
+
+// Sorted returns the stories ordered by index. Stories that have the same index are ordered by creation.
+func (l StoryList) Sorted() StoryList {
+	res := make(StoryList, 0, len(l))
+	for _, s := range l {
+		if s != nil {
+			res = append(res, s)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].index != res[j].index {
+			return res[i].index < res[j].index
+		}
+		return res[i].id.Compare(res[j].id) < 0
+	})
+	return res
+}
+
+// AddAt returns the sorted stories with the story inserted at the index.
+// The story is appended when the index is nil or out of range.
+func (l StoryList) AddAt(s *Story, index *int) StoryList {
+	res := l.Sorted()
+	if s == nil {
+		return res
+	}
+	if index == nil || *index < 0 || len(res) <= *index {
+		return append(res, s)
+	}
+	return append(res[:*index], append(StoryList{s}, res[*index:]...)...)
+}
+
+// Move returns the sorted stories with the story moved to the index and the index where the story is placed.
+// The story is moved to the end when the index is out of range, and -1 is returned when the story is not found.
+func (l StoryList) Move(sid id.StoryID, index int) (StoryList, int) {
+	res := l.Sorted()
+	for i, s := range res {
+		if s.Id() != sid {
+			continue
+		}
+		res = append(res[:i], res[i+1:]...)
+		if index < 0 || len(res) < index {
+			index = len(res)
+		}
+		return res.AddAt(s, &index), index
+	}
+	return res, -1
+}
+
+// Remove returns the sorted stories without the story.
+func (l StoryList) Remove(sid id.StoryID) StoryList {
+	res := make(StoryList, 0, len(l))
+	for _, s := range l.Sorted() {
+		if s.Id() != sid {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// Reindex sets the index of each story to its position in the list.
+func (l StoryList) Reindex() {
+	for i, s := range l {
+		if s != nil {
+			s.index = i
+		}
+	}
+}
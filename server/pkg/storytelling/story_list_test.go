@@ -0,0 +1,91 @@
+package storytelling
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoryList_Sorted(t *testing.T) {
+	s1 := NewStory().NewID().Index(1).MustBuild()
+	s2 := NewStory().NewID().Index(0).MustBuild()
+	// same index as s1 but created later
+	s3 := NewStory().NewID().Index(1).MustBuild()
+
+	l := StoryList{s3, nil, s1, s2}
+	assert.Equal(t, StoryList{s2, s1, s3}, l.Sorted())
+	assert.Equal(t, StoryList{s3, nil, s1, s2}, l)
+	assert.Equal(t, StoryList{}, StoryList(nil).Sorted())
+}
+
+func TestStoryList_AddAt(t *testing.T) {
+	s1 := NewStory().NewID().Index(0).MustBuild()
+	s2 := NewStory().NewID().Index(1).MustBuild()
+	s := NewStory().NewID().MustBuild()
+
+	tests := []struct {
+		name  string
+		index *int
+		want  StoryList
+	}{
+		{name: "nil index", index: nil, want: StoryList{s1, s2, s}},
+		{name: "first", index: lo.ToPtr(0), want: StoryList{s, s1, s2}},
+		{name: "middle", index: lo.ToPtr(1), want: StoryList{s1, s, s2}},
+		{name: "out of range", index: lo.ToPtr(5), want: StoryList{s1, s2, s}},
+		{name: "negative", index: lo.ToPtr(-1), want: StoryList{s1, s2, s}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, StoryList{s2, s1}.AddAt(s, tt.index))
+		})
+	}
+}
+
+func TestStoryList_Move(t *testing.T) {
+	s1 := NewStory().NewID().Index(0).MustBuild()
+	s2 := NewStory().NewID().Index(1).MustBuild()
+	s3 := NewStory().NewID().Index(2).MustBuild()
+	l := StoryList{s1, s2, s3}
+
+	tests := []struct {
+		name      string
+		story     id.StoryID
+		index     int
+		want      StoryList
+		wantIndex int
+	}{
+		{name: "forward", story: s1.Id(), index: 2, want: StoryList{s2, s3, s1}, wantIndex: 2},
+		{name: "backward", story: s3.Id(), index: 0, want: StoryList{s3, s1, s2}, wantIndex: 0},
+		{name: "same", story: s2.Id(), index: 1, want: StoryList{s1, s2, s3}, wantIndex: 1},
+		{name: "out of range", story: s1.Id(), index: 10, want: StoryList{s2, s3, s1}, wantIndex: 2},
+		{name: "not found", story: id.NewStoryID(), index: 0, want: StoryList{s1, s2, s3}, wantIndex: -1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, index := l.Move(tt.story, tt.index)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantIndex, index)
+		})
+	}
+}
+
+func TestStoryList_RemoveAndReindex(t *testing.T) {
+	s1 := NewStory().NewID().Index(0).MustBuild()
+	s2 := NewStory().NewID().Index(1).MustBuild()
+	s3 := NewStory().NewID().Index(2).MustBuild()
+
+	l := StoryList{s1, s2, s3}.Remove(s1.Id())
+	assert.Equal(t, StoryList{s2, s3}, l)
+
+	l.Reindex()
+	assert.Equal(t, 0, s2.Index())
+	assert.Equal(t, 1, s3.Index())
+}
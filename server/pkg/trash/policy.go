@@ -0,0 +1,70 @@
+package trash
+
+import (
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+var ErrInvalidRetention = errors.New("trash retention must not be negative")
+
+// Policy is the trash retention policy of a workspace.
+// Projects that have been in the trash longer than the retention are purged with everything they own.
+type Policy struct {
+	workspace accountsID.WorkspaceID
+	retention time.Duration
+	updatedAt time.Time
+}
+
+// NewPolicy returns the policy of the workspace. Zero retention keeps the projects in the trash forever.
+func NewPolicy(workspace accountsID.WorkspaceID, retention time.Duration, updatedAt time.Time) (*Policy, error) {
+	if retention < 0 {
+		return nil, ErrInvalidRetention
+	}
+	return &Policy{
+		workspace: workspace,
+		retention: retention,
+		updatedAt: updatedAt,
+	}, nil
+}
+
+func (p *Policy) Workspace() accountsID.WorkspaceID {
+	return p.workspace
+}
+
+func (p *Policy) Retention() time.Duration {
+	return p.retention
+}
+
+func (p *Policy) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// KeepsForever reports whether the projects in the trash are never purged automatically.
+func (p *Policy) KeepsForever() bool {
+	return p.retention == 0
+}
+
+func (p *Policy) SetRetention(retention time.Duration, now time.Time) error {
+	if retention < 0 {
+		return ErrInvalidRetention
+	}
+	p.retention = retention
+	p.updatedAt = now
+	return nil
+}
+
+// ExpiresAt returns the time a project deleted at deletedAt is purged at, or nil when it is kept forever.
+func (p *Policy) ExpiresAt(deletedAt time.Time) *time.Time {
+	if p.KeepsForever() {
+		return nil
+	}
+	t := deletedAt.Add(p.retention)
+	return &t
+}
+
+func (p *Policy) IsExpired(deletedAt, now time.Time) bool {
+	expiresAt := p.ExpiresAt(deletedAt)
+	return expiresAt != nil && !now.Before(*expiresAt)
+}
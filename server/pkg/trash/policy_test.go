@@ -0,0 +1,35 @@
+package trash
+
+import (
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	_, err := NewPolicy(accountsID.NewWorkspaceID(), -day, now)
+	assert.ErrorIs(t, err, ErrInvalidRetention)
+
+	p, err := NewPolicy(accountsID.NewWorkspaceID(), 30*day, now)
+	require.NoError(t, err)
+	assert.False(t, p.KeepsForever())
+	assert.Equal(t, now.Add(30*day), *p.ExpiresAt(now))
+	assert.False(t, p.IsExpired(now.Add(-29*day), now))
+	assert.True(t, p.IsExpired(now.Add(-30*day), now))
+
+	later := now.Add(time.Hour)
+	assert.ErrorIs(t, p.SetRetention(-day, later), ErrInvalidRetention)
+	assert.Equal(t, 30*day, p.Retention())
+
+	require.NoError(t, p.SetRetention(0, later))
+	assert.True(t, p.KeepsForever())
+	assert.Nil(t, p.ExpiresAt(now))
+	assert.False(t, p.IsExpired(now.Add(-365*day), now))
+	assert.Equal(t, later, p.UpdatedAt())
+}
@@ -0,0 +1,140 @@
+package trash
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+)
+
+type PurgeReason string
+
+const (
+	// PurgeReasonManual is a purge requested by a workspace member.
+	PurgeReasonManual PurgeReason = "manual"
+	// PurgeReasonExpired is a purge done by the purge job after the retention period.
+	PurgeReasonExpired PurgeReason = "expired"
+)
+
+// Purge is the audit record of a project that has been purged from the trash.
+// The project and everything it owned no longer exist, so the record keeps what identifies them.
+type Purge struct {
+	id          id.TrashPurgeID
+	workspace   accountsID.WorkspaceID
+	project     id.ProjectID
+	projectName string
+	reason      PurgeReason
+	deletedAt   *time.Time
+	purgedBy    *accountsID.UserID
+}
+
+func (p *Purge) ID() id.TrashPurgeID {
+	return p.id
+}
+
+func (p *Purge) Workspace() accountsID.WorkspaceID {
+	return p.workspace
+}
+
+func (p *Purge) Project() id.ProjectID {
+	return p.project
+}
+
+func (p *Purge) ProjectName() string {
+	return p.projectName
+}
+
+func (p *Purge) Reason() PurgeReason {
+	return p.reason
+}
+
+// DeletedAt is the time the project was moved to the trash.
+func (p *Purge) DeletedAt() *time.Time {
+	if p.deletedAt == nil {
+		return nil
+	}
+	t := *p.deletedAt
+	return &t
+}
+
+// PurgedBy is the user who purged the project. It is nil for the purges of the purge job.
+func (p *Purge) PurgedBy() *accountsID.UserID {
+	return p.purgedBy
+}
+
+func (p *Purge) PurgedAt() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return p.id.Timestamp()
+}
+
+type PurgeBuilder struct {
+	p *Purge
+}
+
+func NewPurge() *PurgeBuilder {
+	return &PurgeBuilder{p: &Purge{}}
+}
+
+func (b *PurgeBuilder) Build() (*Purge, error) {
+	if b.p.id.IsNil() || b.p.workspace.IsNil() || b.p.project.IsNil() {
+		return nil, idx.ErrInvalidID
+	}
+	if b.p.reason == "" {
+		b.p.reason = PurgeReasonManual
+	}
+	return b.p, nil
+}
+
+func (b *PurgeBuilder) MustBuild() *Purge {
+	p, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (b *PurgeBuilder) ID(id id.TrashPurgeID) *PurgeBuilder {
+	b.p.id = id
+	return b
+}
+
+func (b *PurgeBuilder) NewID() *PurgeBuilder {
+	b.p.id = id.NewTrashPurgeID()
+	return b
+}
+
+func (b *PurgeBuilder) Workspace(workspace accountsID.WorkspaceID) *PurgeBuilder {
+	b.p.workspace = workspace
+	return b
+}
+
+func (b *PurgeBuilder) Project(project id.ProjectID) *PurgeBuilder {
+	b.p.project = project
+	return b
+}
+
+func (b *PurgeBuilder) ProjectName(projectName string) *PurgeBuilder {
+	b.p.projectName = projectName
+	return b
+}
+
+func (b *PurgeBuilder) Reason(reason PurgeReason) *PurgeBuilder {
+	b.p.reason = reason
+	return b
+}
+
+func (b *PurgeBuilder) DeletedAt(deletedAt *time.Time) *PurgeBuilder {
+	if deletedAt != nil {
+		t := *deletedAt
+		b.p.deletedAt = &t
+	}
+	return b
+}
+
+func (b *PurgeBuilder) PurgedBy(purgedBy *accountsID.UserID) *PurgeBuilder {
+	b.p.purgedBy = purgedBy
+	return b
+}
@@ -0,0 +1,33 @@
+package trash
+
+import (
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeBuilder_Build(t *testing.T) {
+	wid := accountsID.NewWorkspaceID()
+	pid := id.NewProjectID()
+	deletedAt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	p, err := NewPurge().NewID().Workspace(wid).Project(pid).ProjectName("project").DeletedAt(&deletedAt).Build()
+	require.NoError(t, err)
+	assert.Equal(t, wid, p.Workspace())
+	assert.Equal(t, pid, p.Project())
+	assert.Equal(t, "project", p.ProjectName())
+	assert.Equal(t, PurgeReasonManual, p.Reason())
+	assert.Equal(t, &deletedAt, p.DeletedAt())
+	assert.Nil(t, p.PurgedBy())
+	assert.Equal(t, p.ID().Timestamp(), p.PurgedAt())
+
+	_, err = NewPurge().NewID().Workspace(wid).Build()
+	assert.ErrorIs(t, err, idx.ErrInvalidID)
+	_, err = NewPurge().Workspace(wid).Project(pid).Build()
+	assert.ErrorIs(t, err, idx.ErrInvalidID)
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"net/url"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+)
+
+type Builder struct {
+	w *Webhook
+}
+
+func New() *Builder {
+	return &Builder{w: &Webhook{}}
+}
+
+func (b *Builder) Build() (*Webhook, error) {
+	if b.w.id.IsNil() || b.w.workspace.IsNil() {
+		return nil, idx.ErrInvalidID
+	}
+	if err := validateURL(b.w.url); err != nil {
+		return nil, err
+	}
+	if b.w.secret == "" {
+		return nil, ErrInvalidSecret
+	}
+	for _, e := range b.w.events {
+		if _, ok := EventTypeFrom(string(e)); !ok {
+			return nil, ErrInvalidEventType
+		}
+	}
+	return b.w, nil
+}
+
+func (b *Builder) MustBuild() *Webhook {
+	w, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func (b *Builder) ID(id id.WebhookID) *Builder {
+	b.w.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.w.id = id.NewWebhookID()
+	return b
+}
+
+func (b *Builder) Workspace(workspace accountsID.WorkspaceID) *Builder {
+	b.w.workspace = workspace
+	return b
+}
+
+func (b *Builder) Name(name string) *Builder {
+	b.w.name = name
+	return b
+}
+
+func (b *Builder) URL(u *url.URL) *Builder {
+	if u == nil {
+		b.w.url = nil
+	} else {
+		u2 := *u
+		b.w.url = &u2
+	}
+	return b
+}
+
+func (b *Builder) Secret(secret string) *Builder {
+	b.w.secret = secret
+	return b
+}
+
+func (b *Builder) Events(events []EventType) *Builder {
+	b.w.events = append([]EventType{}, events...)
+	return b
+}
+
+func (b *Builder) Enabled(enabled bool) *Builder {
+	b.w.enabled = enabled
+	return b
+}
+
+func (b *Builder) UpdatedAt(updatedAt time.Time) *Builder {
+	b.w.updatedAt = updatedAt
+	return b
+}
+
+func (b *Builder) CreatedBy(createdBy *accountsID.UserID) *Builder {
+	b.w.createdBy = createdBy
+	return b
+}
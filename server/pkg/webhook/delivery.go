@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+var ErrEmptyPayload = errors.New("webhook delivery must have a payload")
+
+// MaxAttempts is how many times a delivery is attempted before it fails.
+const MaxAttempts = 8
+
+const (
+	backoffBase = 30 * time.Second
+	backoffMax  = 6 * time.Hour
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// Attempt is a request sent to a webhook. StatusCode is zero when no response was received.
+type Attempt struct {
+	At         time.Time
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+}
+
+func (a Attempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// Delivery is an event queued for a webhook. The payload is encoded when the event happens, so that retries send
+// the same body, and the delivery is retried with an exponential backoff until it succeeds or runs out of attempts.
+type Delivery struct {
+	id            id.WebhookDeliveryID
+	webhook       id.WebhookID
+	workspace     accountsID.WorkspaceID
+	event         EventType
+	payload       []byte
+	status        DeliveryStatus
+	attempts      []Attempt
+	nextAttemptAt *time.Time
+}
+
+// NewEventDelivery queues the event for the webhook.
+func NewEventDelivery(w *Webhook, e Event) (*Delivery, error) {
+	did := id.NewWebhookDeliveryID()
+	payload, err := NewPayload(did, e)
+	if err != nil {
+		return nil, err
+	}
+	return NewDelivery().
+		ID(did).
+		Webhook(w.ID()).
+		Workspace(w.Workspace()).
+		Event(e.Type).
+		Payload(payload).
+		NextAttemptAt(&e.OccurredAt).
+		Build()
+}
+
+func (d *Delivery) ID() id.WebhookDeliveryID {
+	return d.id
+}
+
+func (d *Delivery) Webhook() id.WebhookID {
+	return d.webhook
+}
+
+func (d *Delivery) Workspace() accountsID.WorkspaceID {
+	return d.workspace
+}
+
+func (d *Delivery) Event() EventType {
+	return d.event
+}
+
+func (d *Delivery) Payload() []byte {
+	return append([]byte{}, d.payload...)
+}
+
+func (d *Delivery) Status() DeliveryStatus {
+	return d.status
+}
+
+func (d *Delivery) Attempts() []Attempt {
+	return append([]Attempt{}, d.attempts...)
+}
+
+// LastAttempt returns nil when the delivery has not been attempted yet.
+func (d *Delivery) LastAttempt() *Attempt {
+	if len(d.attempts) == 0 {
+		return nil
+	}
+	a := d.attempts[len(d.attempts)-1]
+	return &a
+}
+
+// NextAttemptAt returns nil when the delivery is finished.
+func (d *Delivery) NextAttemptAt() *time.Time {
+	return cloneTime(d.nextAttemptAt)
+}
+
+func (d *Delivery) CreatedAt() time.Time {
+	if d == nil {
+		return time.Time{}
+	}
+	return d.id.Timestamp()
+}
+
+func (d *Delivery) IsDue(now time.Time) bool {
+	return d.status == DeliveryStatusPending && d.nextAttemptAt != nil && !now.Before(*d.nextAttemptAt)
+}
+
+// RecordAttempt finishes the delivery when the attempt succeeded or was the last one, and schedules the next attempt otherwise.
+func (d *Delivery) RecordAttempt(a Attempt) {
+	d.attempts = append(d.attempts, a)
+	switch {
+	case a.Succeeded():
+		d.status = DeliveryStatusSucceeded
+		d.nextAttemptAt = nil
+	case len(d.attempts) >= MaxAttempts:
+		d.status = DeliveryStatusFailed
+		d.nextAttemptAt = nil
+	default:
+		next := a.At.Add(Backoff(len(d.attempts)))
+		d.nextAttemptAt = &next
+	}
+}
+
+// Abandon fails the delivery without sending it, for example when its webhook has been disabled or deleted.
+func (d *Delivery) Abandon(now time.Time, reason string) {
+	d.attempts = append(d.attempts, Attempt{At: now, Error: reason})
+	d.status = DeliveryStatusFailed
+	d.nextAttemptAt = nil
+}
+
+// Backoff returns how long to wait after the nth failed attempt: 30 seconds doubled for each attempt, up to 6 hours.
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	b := backoffBase
+	for i := 1; i < attempts; i++ {
+		b *= 2
+		if b >= backoffMax {
+			return backoffMax
+		}
+	}
+	return b
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	t2 := *t
+	return &t2
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+)
+
+type DeliveryBuilder struct {
+	d *Delivery
+}
+
+func NewDelivery() *DeliveryBuilder {
+	return &DeliveryBuilder{d: &Delivery{}}
+}
+
+func (b *DeliveryBuilder) Build() (*Delivery, error) {
+	if b.d.id.IsNil() || b.d.webhook.IsNil() || b.d.workspace.IsNil() {
+		return nil, idx.ErrInvalidID
+	}
+	if _, ok := EventTypeFrom(string(b.d.event)); !ok {
+		return nil, ErrInvalidEventType
+	}
+	if len(b.d.payload) == 0 {
+		return nil, ErrEmptyPayload
+	}
+	if b.d.status == "" {
+		b.d.status = DeliveryStatusPending
+	}
+	return b.d, nil
+}
+
+func (b *DeliveryBuilder) MustBuild() *Delivery {
+	d, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (b *DeliveryBuilder) ID(id id.WebhookDeliveryID) *DeliveryBuilder {
+	b.d.id = id
+	return b
+}
+
+func (b *DeliveryBuilder) NewID() *DeliveryBuilder {
+	b.d.id = id.NewWebhookDeliveryID()
+	return b
+}
+
+func (b *DeliveryBuilder) Webhook(webhook id.WebhookID) *DeliveryBuilder {
+	b.d.webhook = webhook
+	return b
+}
+
+func (b *DeliveryBuilder) Workspace(workspace accountsID.WorkspaceID) *DeliveryBuilder {
+	b.d.workspace = workspace
+	return b
+}
+
+func (b *DeliveryBuilder) Event(event EventType) *DeliveryBuilder {
+	b.d.event = event
+	return b
+}
+
+func (b *DeliveryBuilder) Payload(payload []byte) *DeliveryBuilder {
+	b.d.payload = append([]byte{}, payload...)
+	return b
+}
+
+func (b *DeliveryBuilder) Status(status DeliveryStatus) *DeliveryBuilder {
+	b.d.status = status
+	return b
+}
+
+func (b *DeliveryBuilder) Attempts(attempts []Attempt) *DeliveryBuilder {
+	b.d.attempts = append([]Attempt{}, attempts...)
+	return b
+}
+
+func (b *DeliveryBuilder) NextAttemptAt(nextAttemptAt *time.Time) *DeliveryBuilder {
+	b.d.nextAttemptAt = cloneTime(nextAttemptAt)
+	return b
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventDelivery(t *testing.T) {
+	u, _ := url.Parse("https://example.com/hook")
+	w := New().NewID().Workspace(accountsID.NewWorkspaceID()).URL(u).Secret("secret").Enabled(true).MustBuild()
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	d, err := NewEventDelivery(w, Event{
+		Type:       EventProjectPublish,
+		Workspace:  w.Workspace(),
+		OccurredAt: now,
+		Data:       map[string]any{"project": map[string]any{"id": "p"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, w.ID(), d.Webhook())
+	assert.Equal(t, DeliveryStatusPending, d.Status())
+	assert.True(t, d.IsDue(now))
+	assert.False(t, d.IsDue(now.Add(-time.Second)))
+
+	var p map[string]any
+	require.NoError(t, json.Unmarshal(d.Payload(), &p))
+	assert.Equal(t, map[string]any{
+		"id":          d.ID().String(),
+		"type":        "project.publish",
+		"workspaceId": w.Workspace().String(),
+		"occurredAt":  "2026-10-18T09:00:00Z",
+		"data":        map[string]any{"project": map[string]any{"id": "p"}},
+	}, p)
+}
+
+func TestDelivery_RecordAttempt(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	newDelivery := func() *Delivery {
+		return NewDelivery().NewID().Webhook(id.NewWebhookID()).Workspace(accountsID.NewWorkspaceID()).
+			Event(EventAssetUpload).Payload([]byte("{}")).NextAttemptAt(&now).MustBuild()
+	}
+
+	d := newDelivery()
+	d.RecordAttempt(Attempt{At: now, StatusCode: 500})
+	assert.Equal(t, DeliveryStatusPending, d.Status())
+	assert.Equal(t, now.Add(30*time.Second), *d.NextAttemptAt())
+	d.RecordAttempt(Attempt{At: now.Add(30 * time.Second), Error: "connection refused"})
+	assert.Equal(t, now.Add(90*time.Second), *d.NextAttemptAt())
+	d.RecordAttempt(Attempt{At: now.Add(90 * time.Second), StatusCode: 204})
+	assert.Equal(t, DeliveryStatusSucceeded, d.Status())
+	assert.Nil(t, d.NextAttemptAt())
+	assert.Len(t, d.Attempts(), 3)
+	assert.Equal(t, 204, d.LastAttempt().StatusCode)
+
+	d = newDelivery()
+	for i := 0; i < MaxAttempts; i++ {
+		d.RecordAttempt(Attempt{At: now, StatusCode: 410})
+	}
+	assert.Equal(t, DeliveryStatusFailed, d.Status())
+	assert.Nil(t, d.NextAttemptAt())
+	assert.False(t, d.IsDue(now.Add(24*time.Hour)))
+
+	d = newDelivery()
+	d.Abandon(now, "webhook is disabled")
+	assert.Equal(t, DeliveryStatusFailed, d.Status())
+	assert.Equal(t, "webhook is disabled", d.LastAttempt().Error)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), Backoff(0))
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 32*time.Minute, Backoff(7))
+	assert.Equal(t, 6*time.Hour, Backoff(20))
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+var ErrInvalidEventType = errors.New("invalid webhook event type")
+
+type EventType string
+
+const (
+	EventProjectCreate  EventType = "project.create"
+	EventProjectUpdate  EventType = "project.update"
+	EventProjectDelete  EventType = "project.delete"
+	EventProjectPublish EventType = "project.publish"
+	EventStoryPublish   EventType = "story.publish"
+	EventImportSuccess  EventType = "import.success"
+	EventImportFailure  EventType = "import.failure"
+	EventAssetUpload    EventType = "asset.upload"
+)
+
+var eventTypes = []EventType{
+	EventProjectCreate,
+	EventProjectUpdate,
+	EventProjectDelete,
+	EventProjectPublish,
+	EventStoryPublish,
+	EventImportSuccess,
+	EventImportFailure,
+	EventAssetUpload,
+}
+
+func EventTypes() []EventType {
+	return append([]EventType{}, eventTypes...)
+}
+
+func EventTypeFrom(s string) (EventType, bool) {
+	for _, t := range eventTypes {
+		if string(t) == s {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// EventTypesFrom returns the event types without duplicates, or ErrInvalidEventType when one of them is unknown.
+func EventTypesFrom(s []string) ([]EventType, error) {
+	res := make([]EventType, 0, len(s))
+	for _, e := range s {
+		t, ok := EventTypeFrom(e)
+		if !ok {
+			return nil, ErrInvalidEventType
+		}
+		if !containsEventType(res, t) {
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
+
+// Event is something that happened in a workspace and is delivered to the webhooks of the workspace that subscribe to it.
+type Event struct {
+	Type       EventType
+	Workspace  accountsID.WorkspaceID
+	OccurredAt time.Time
+	// Data is the resource the event is about. It is encoded to JSON as the data of the payload.
+	Data any
+}
+
+// Payload is the JSON body of the requests sent to webhooks.
+type Payload struct {
+	// ID is the ID of the delivery. It stays the same across retries, so that receivers can ignore duplicates.
+	ID          string    `json:"id"`
+	Type        EventType `json:"type"`
+	WorkspaceID string    `json:"workspaceId"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	Data        any       `json:"data"`
+}
+
+func NewPayload(did id.WebhookDeliveryID, e Event) ([]byte, error) {
+	return json.Marshal(Payload{
+		ID:          did.String(),
+		Type:        e.Type,
+		WorkspaceID: e.Workspace.String(),
+		OccurredAt:  e.OccurredAt.UTC(),
+		Data:        e.Data,
+	})
+}
+
+func containsEventType(l []EventType, t EventType) bool {
+	for _, e := range l {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Reearth-Event"
+	HeaderDelivery  = "X-Reearth-Delivery"
+	HeaderSignature = "X-Reearth-Signature"
+)
+
+// Signature returns the value of the signature header of a request sent at the time.
+// It is "t=<unix time>,v1=<hex of HMAC-SHA256 of "<unix time>.<body>" with the secret>", so that receivers can
+// verify the body and reject replayed requests by the time.
+func Signature(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, sign(secret, ts, body))
+}
+
+func sign(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
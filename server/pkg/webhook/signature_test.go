@@ -0,0 +1,14 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature(t *testing.T) {
+	at := time.Unix(1760778000, 0)
+	// echo -n '1760778000.{"a":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "t=1760778000,v1=0492381391666fb97892480325355558cab6698c86d17239a83d480bcaa2e9b8", Signature("secret", at, []byte(`{"a":1}`)))
+}
@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth/server/pkg/id"
+)
+
+var (
+	ErrInvalidURL    = errors.New("webhook url must be an absolute http or https url")
+	ErrPrivateURL    = errors.New("webhook url must not point to a loopback, private or link-local address")
+	ErrInvalidSecret = errors.New("webhook secret must not be empty")
+)
+
+// Webhook receives the events of a workspace. When events is empty, it receives all of them.
+// The secret signs the payloads, so it is stored as is and shown to the maintainers of the workspace only once.
+type Webhook struct {
+	id        id.WebhookID
+	workspace accountsID.WorkspaceID
+	name      string
+	url       *url.URL
+	secret    string
+	events    []EventType
+	enabled   bool
+	updatedAt time.Time
+	createdBy *accountsID.UserID
+}
+
+func (w *Webhook) ID() id.WebhookID {
+	return w.id
+}
+
+func (w *Webhook) Workspace() accountsID.WorkspaceID {
+	return w.workspace
+}
+
+func (w *Webhook) Name() string {
+	return w.name
+}
+
+func (w *Webhook) URL() *url.URL {
+	if w.url == nil {
+		return nil
+	}
+	u := *w.url
+	return &u
+}
+
+func (w *Webhook) Secret() string {
+	return w.secret
+}
+
+func (w *Webhook) Events() []EventType {
+	return append([]EventType{}, w.events...)
+}
+
+func (w *Webhook) Enabled() bool {
+	return w.enabled
+}
+
+func (w *Webhook) CreatedBy() *accountsID.UserID {
+	return w.createdBy
+}
+
+func (w *Webhook) CreatedAt() time.Time {
+	if w == nil {
+		return time.Time{}
+	}
+	return w.id.Timestamp()
+}
+
+func (w *Webhook) UpdatedAt() time.Time {
+	if w.updatedAt.IsZero() {
+		return w.CreatedAt()
+	}
+	return w.updatedAt
+}
+
+// Subscribes reports whether the webhook receives the event type.
+func (w *Webhook) Subscribes(t EventType) bool {
+	if !w.enabled {
+		return false
+	}
+	return len(w.events) == 0 || containsEventType(w.events, t)
+}
+
+func (w *Webhook) SetName(name string) {
+	w.name = name
+}
+
+func (w *Webhook) SetURL(u *url.URL) error {
+	if err := validateURL(u); err != nil {
+		return err
+	}
+	u2 := *u
+	w.url = &u2
+	return nil
+}
+
+func (w *Webhook) SetSecret(secret string) {
+	w.secret = secret
+}
+
+func (w *Webhook) SetEvents(events []EventType) {
+	w.events = append([]EventType{}, events...)
+}
+
+func (w *Webhook) SetEnabled(enabled bool) {
+	w.enabled = enabled
+}
+
+func (w *Webhook) SetUpdatedAt(t time.Time) {
+	w.updatedAt = t
+}
+
+// GenerateSecret returns a new random secret to sign payloads with.
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which cloud providers use for internal networks.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicIP reports whether webhooks may be sent to the address. Loopback, private, shared, link-local and unspecified
+// addresses are rejected so that webhooks cannot reach the server itself or its internal network.
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	// 0.0.0.0/8 reaches the host itself as well
+	if ip4 := ip.To4(); ip4 != nil && (ip4[0] == 0 || sharedAddressSpace.Contains(ip4)) {
+		return false
+	}
+	return true
+}
+
+// validateURL rejects the hosts that are private addresses by themselves. Host names are resolved only when the
+// webhook is sent, so the sender has to check the resolved addresses as well.
+func validateURL(u *url.URL) error {
+	if u == nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+		return ErrInvalidURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrPrivateURL
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return ErrPrivateURL
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	accountsID "github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearthx/idx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	u, _ := url.Parse("https://example.com/hook")
+	base := func() *Builder {
+		return New().NewID().Workspace(accountsID.NewWorkspaceID()).URL(u).Secret("secret")
+	}
+
+	tests := []struct {
+		name    string
+		builder *Builder
+		wantErr error
+	}{
+		{name: "all events", builder: base()},
+		{name: "events", builder: base().Events([]EventType{EventProjectPublish, EventAssetUpload})},
+		{name: "invalid event", builder: base().Events([]EventType{"project.archive"}), wantErr: ErrInvalidEventType},
+		{name: "no url", builder: base().URL(nil), wantErr: ErrInvalidURL},
+		{name: "relative url", builder: base().URL(&url.URL{Path: "/hook"}), wantErr: ErrInvalidURL},
+		{name: "ftp url", builder: base().URL(&url.URL{Scheme: "ftp", Host: "example.com"}), wantErr: ErrInvalidURL},
+		{name: "localhost", builder: base().URL(&url.URL{Scheme: "http", Host: "localhost:8080"}), wantErr: ErrPrivateURL},
+		{name: "loopback", builder: base().URL(&url.URL{Scheme: "http", Host: "127.0.0.1"}), wantErr: ErrPrivateURL},
+		{name: "ipv6 loopback", builder: base().URL(&url.URL{Scheme: "http", Host: "[::1]:8080"}), wantErr: ErrPrivateURL},
+		{name: "private", builder: base().URL(&url.URL{Scheme: "https", Host: "10.0.0.1"}), wantErr: ErrPrivateURL},
+		{name: "link-local", builder: base().URL(&url.URL{Scheme: "http", Host: "169.254.169.254"}), wantErr: ErrPrivateURL},
+		{name: "unspecified", builder: base().URL(&url.URL{Scheme: "http", Host: "0.0.0.0"}), wantErr: ErrPrivateURL},
+		{name: "no secret", builder: base().Secret(""), wantErr: ErrInvalidSecret},
+		{name: "no workspace", builder: New().NewID().URL(u).Secret("secret"), wantErr: idx.ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w, err := tt.builder.Build()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, w)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, w.CreatedAt(), w.UpdatedAt())
+		})
+	}
+}
+
+func TestWebhook_Subscribes(t *testing.T) {
+	u, _ := url.Parse("https://example.com/hook")
+	all := New().NewID().Workspace(accountsID.NewWorkspaceID()).URL(u).Secret("secret").Enabled(true).MustBuild()
+	assert.True(t, all.Subscribes(EventProjectCreate))
+	assert.True(t, all.Subscribes(EventImportFailure))
+
+	some := New().NewID().Workspace(accountsID.NewWorkspaceID()).URL(u).Secret("secret").Enabled(true).
+		Events([]EventType{EventProjectPublish, EventStoryPublish}).MustBuild()
+	assert.True(t, some.Subscribes(EventStoryPublish))
+	assert.False(t, some.Subscribes(EventProjectCreate))
+
+	some.SetEnabled(false)
+	assert.False(t, some.Subscribes(EventStoryPublish))
+}
+
+func TestWebhook_SetURL(t *testing.T) {
+	u, _ := url.Parse("https://example.com/hook")
+	w := New().NewID().Workspace(accountsID.NewWorkspaceID()).URL(u).Secret("secret").MustBuild()
+
+	assert.ErrorIs(t, w.SetURL(&url.URL{Scheme: "file", Path: "/etc/passwd"}), ErrInvalidURL)
+	assert.ErrorIs(t, w.SetURL(&url.URL{Scheme: "http", Host: "192.168.0.1"}), ErrPrivateURL)
+	assert.Equal(t, u, w.URL())
+
+	u2, _ := url.Parse("http://example.com/hook2")
+	assert.NoError(t, w.SetURL(u2))
+	assert.Equal(t, u2, w.URL())
+}
+
+func TestEventTypesFrom(t *testing.T) {
+	got, err := EventTypesFrom([]string{"project.publish", "asset.upload", "project.publish"})
+	assert.NoError(t, err)
+	assert.Equal(t, []EventType{EventProjectPublish, EventAssetUpload}, got)
+
+	_, err = EventTypesFrom([]string{"project.archive"})
+	assert.ErrorIs(t, err, ErrInvalidEventType)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	s1, err := GenerateSecret()
+	require.NoError(t, err)
+	s2, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(s1, "whsec_"))
+	assert.NotEqual(t, s1, s2)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for ip, want := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:2800:220::1": true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"::ffff:127.0.0.1": false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"fd00::1":          false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"0.0.0.0":          false,
+		"0.0.0.1":          false,
+		"100.64.0.1":       false,
+		"100.127.255.255":  false,
+		"100.63.255.255":   true,
+		"100.128.0.1":      true,
+		"::":               false,
+	} {
+		assert.Equal(t, want, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}